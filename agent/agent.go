@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -163,6 +164,13 @@ const (
 
 	MgoStatsEnabled = "MGO_STATS_ENABLED"
 
+	// ExternalMongoAddresses and ExternalMongoCACert are the comma
+	// separated addresses of, and the CA certificate used to validate,
+	// an externally managed mongo replica set used by the controller
+	// instead of its own mongo server.
+	ExternalMongoAddresses = "EXTERNAL_MONGO_ADDRESSES"
+	ExternalMongoCACert    = "EXTERNAL_MONGO_CA_CERT"
+
 	// LoggingOverride will set the logging for this agent to the value
 	// specified. Model configuration will be ignored and this value takes
 	// precidence for the agent.
@@ -873,6 +881,17 @@ func (c *configInternal) MongoInfo() (info *mongo.MongoInfo, ok bool) {
 	if !ok {
 		return nil, false
 	}
+	if externalAddrs := c.values[ExternalMongoAddresses]; externalAddrs != "" {
+		return &mongo.MongoInfo{
+			Info: mongo.Info{
+				Addrs:           strings.Split(externalAddrs, ","),
+				CACert:          c.values[ExternalMongoCACert],
+				VerifyHostnames: true,
+			},
+			Password: c.statePassword,
+			Tag:      c.tag,
+		}, true
+	}
 	addrs := c.apiDetails.addresses
 	var netAddrs network.SpaceAddresses
 	for _, addr := range addrs {
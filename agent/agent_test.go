@@ -513,6 +513,26 @@ func (*suite) TestMongoInfoNoCloudLocalAvailable(c *gc.C) {
 	c.Check(mongoInfo.Info.DisableTLS, jc.IsFalse)
 }
 
+func (*suite) TestMongoInfoExternalMongo(c *gc.C) {
+	attrParams := attributeParams
+	attrParams.APIAddresses = []string{"foo.example:1235", "3.4.2.1:1070"}
+	attrParams.Values = map[string]string{
+		agent.ExternalMongoAddresses: "db-0.example:27017,db-1.example:27017",
+		agent.ExternalMongoCACert:    "external-ca-cert",
+	}
+	servingInfo := stateServingInfo()
+	conf, err := agent.NewStateMachineConfig(attrParams, servingInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	mongoInfo, ok := conf.MongoInfo()
+	c.Assert(ok, jc.IsTrue)
+	c.Check(mongoInfo.Info, jc.DeepEquals, mongo.Info{
+		Addrs:           []string{"db-0.example:27017", "db-1.example:27017"},
+		CACert:          "external-ca-cert",
+		VerifyHostnames: true,
+	})
+	c.Check(mongoInfo.Tag, gc.Equals, attrParams.Tag)
+}
+
 func (*suite) TestPromotedMongoInfo(c *gc.C) {
 	attrParams := attributeParams
 	attrParams.APIAddresses = []string{"foo.example:1235", "bar.example:1236", "localhost:88", "3.4.2.1:1070"}
@@ -110,7 +110,11 @@ func InitializeState(
 		return nil, errors.Trace(err)
 	}
 
-	session, err := initMongo(info.Info, dialOpts, info.Password)
+	initMongoSession := initMongo
+	if c.Value(agent.ExternalMongoAddresses) != "" {
+		initMongoSession = initExternalMongo
+	}
+	session, err := initMongoSession(info.Info, dialOpts, info.Password)
 	if err != nil {
 		return nil, errors.Annotate(err, "failed to initialize mongo")
 	}
@@ -423,6 +427,21 @@ func initMongo(info mongo.Info, dialOpts mongo.DialOpts, password string) (*mgo.
 	return session, nil
 }
 
+// initExternalMongo dials the initial connection to an externally managed
+// MongoDB, logging in as the admin user, which must already exist with the
+// admin password, and returning the session.
+func initExternalMongo(info mongo.Info, dialOpts mongo.DialOpts, password string) (*mgo.Session, error) {
+	session, err := mongo.DialWithInfo(mongo.MongoInfo{Info: info}, dialOpts)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := mongo.Login(session, mongo.AdminUser, password); err != nil {
+		session.Close()
+		return nil, errors.Annotate(err, "logging in to external mongo")
+	}
+	return session, nil
+}
+
 // initBootstrapMachine initializes the initial bootstrap machine in state.
 func initBootstrapMachine(st *state.State, args InitializeStateParams) (bootstrapController, error) {
 	model, err := st.Model()
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apicharm "github.com/juju/juju/api/common/charm"
 	"github.com/juju/juju/apiserver/params"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/devices"
@@ -624,6 +625,75 @@ func (c *Client) ScaleApplication(in ScaleApplicationParams) (params.ScaleApplic
 	return results.Results[0], nil
 }
 
+// SetAutoscalePolicy sets the policy used to scale the application
+// automatically. A nil policy stops the application being autoscaled.
+func (c *Client) SetAutoscalePolicy(application string, policy *coreapplication.AutoscalePolicy) error {
+	if c.BestAPIVersion() < 14 {
+		return errors.NotSupportedf("autoscaling applications")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application %q", application)
+	}
+	arg := params.SetAutoscalePolicyArg{
+		ApplicationTag: names.NewApplicationTag(application).String(),
+	}
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		arg.Policy = &params.AutoscalePolicy{
+			MinUnits:     policy.MinUnits,
+			MaxUnits:     policy.MaxUnits,
+			TargetCPU:    policy.TargetCPU,
+			TargetMemory: policy.TargetMemory,
+			Metric:       policy.Metric,
+			TargetMetric: policy.TargetMetric,
+		}
+	}
+	args := params.SetAutoscalePoliciesArgs{Args: []params.SetAutoscalePolicyArg{arg}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetAutoscalePolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AutoscalePolicy returns the policy used to scale the application
+// automatically, or nil if the application isn't autoscaled.
+func (c *Client) AutoscalePolicy(application string) (*coreapplication.AutoscalePolicy, error) {
+	if c.BestAPIVersion() < 14 {
+		return nil, errors.NotSupportedf("autoscaling applications")
+	}
+	if !names.IsValidApplication(application) {
+		return nil, errors.NotValidf("application %q", application)
+	}
+	args := params.Entities{Entities: []params.Entity{{
+		Tag: names.NewApplicationTag(application).String(),
+	}}}
+	var results params.AutoscalePolicyResults
+	if err := c.facade.FacadeCall("AutoscalePolicies", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.Policy == nil {
+		return nil, nil
+	}
+	return &coreapplication.AutoscalePolicy{
+		MinUnits:     result.Policy.MinUnits,
+		MaxUnits:     result.Policy.MaxUnits,
+		TargetCPU:    result.Policy.TargetCPU,
+		TargetMemory: result.Policy.TargetMemory,
+		Metric:       result.Policy.Metric,
+		TargetMetric: result.Policy.TargetMetric,
+	}, nil
+}
+
 // GetConstraints returns the constraints for the given applications.
 func (c *Client) GetConstraints(applications ...string) ([]constraints.Value, error) {
 	var allConstraints []constraints.Value
@@ -20,6 +20,7 @@ import (
 	apitesting "github.com/juju/juju/api/testing"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/params"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
@@ -979,6 +980,85 @@ func (s *applicationSuite) TestScaleApplication(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestSetAutoscalePolicy(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Assert(request, gc.Equals, "SetAutoscalePolicies")
+				c.Assert(a, jc.DeepEquals, params.SetAutoscalePoliciesArgs{
+					Args: []params.SetAutoscalePolicyArg{{
+						ApplicationTag: "application-foo",
+						Policy:         &params.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetCPU: 70},
+					}},
+				})
+				result := response.(*params.ErrorResults)
+				result.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+	err := client.SetAutoscalePolicy("foo", &coreapplication.AutoscalePolicy{
+		MinUnits: 1, MaxUnits: 5, TargetCPU: 70,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetAutoscalePolicyInvalid(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+	err := client.SetAutoscalePolicy("foo", &coreapplication.AutoscalePolicy{MinUnits: 1, MaxUnits: 5})
+	c.Assert(err, gc.ErrorMatches, "autoscale policy without a target not valid")
+}
+
+func (s *applicationSuite) TestSetAutoscalePolicyNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 13,
+	})
+	err := client.SetAutoscalePolicy("foo", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestAutoscalePolicy(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "AutoscalePolicies")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-foo"}},
+				})
+				result := response.(*params.AutoscalePolicyResults)
+				result.Results = []params.AutoscalePolicyResult{{
+					Policy: &params.AutoscalePolicy{MinUnits: 2, MaxUnits: 8, Metric: "queue", TargetMetric: 10},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 14,
+	})
+	policy, err := client.AutoscalePolicy("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, &coreapplication.AutoscalePolicy{
+		MinUnits: 2, MaxUnits: 8, Metric: "queue", TargetMetric: 10,
+	})
+}
+
 func (s *applicationSuite) TestChangeScaleApplication(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string, version int, id, request string, a, response interface{}) error {
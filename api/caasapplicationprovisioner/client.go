@@ -14,6 +14,7 @@ import (
 	charmscommon "github.com/juju/juju/api/common/charms"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/life"
@@ -117,8 +118,13 @@ type ProvisioningInfo struct {
 	Devices              []devices.KubernetesDeviceParams
 	Series               string
 	ImageRepo            string
+	IsPrivateImageRepo   bool
 	CharmModifiedVersion int
 	CharmURL             *charm.URL
+	NetworkAttachments   []string
+	UpdateStrategy       caas.UpdateStrategy
+	DisruptionBudget     *caas.DisruptionBudget
+	ContainerResources   map[string]caas.ContainerResources
 }
 
 // ProvisioningInfo returns the info needed to provision an operator for an application.
@@ -147,7 +153,28 @@ func (c *Client) ProvisioningInfo(applicationName string) (ProvisioningInfo, err
 		Constraints:          r.Constraints,
 		Series:               r.Series,
 		ImageRepo:            r.ImageRepo,
+		IsPrivateImageRepo:   r.IsPrivateImageRepo,
 		CharmModifiedVersion: r.CharmModifiedVersion,
+		NetworkAttachments:   r.NetworkAttachments,
+	}
+	if r.UpdateStrategy != nil {
+		info.UpdateStrategy = caas.UpdateStrategy{
+			Partition:      r.UpdateStrategy.Partition,
+			MaxUnavailable: r.UpdateStrategy.MaxUnavailable,
+			MaxSurge:       r.UpdateStrategy.MaxSurge,
+		}
+	}
+	if r.DisruptionBudget != nil {
+		info.DisruptionBudget = &caas.DisruptionBudget{
+			MinAvailable:   r.DisruptionBudget.MinAvailable,
+			MaxUnavailable: r.DisruptionBudget.MaxUnavailable,
+		}
+	}
+	if len(r.ContainerResources) > 0 {
+		info.ContainerResources = make(map[string]caas.ContainerResources, len(r.ContainerResources))
+		for name, res := range r.ContainerResources {
+			info.ContainerResources[name] = caas.ContainerResources(res)
+		}
 	}
 
 	for _, fs := range r.Filesystems {
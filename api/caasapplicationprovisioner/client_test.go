@@ -15,6 +15,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/caasapplicationprovisioner"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/status"
@@ -138,6 +139,7 @@ func (s *provisionerSuite) TestLifeCount(c *gc.C) {
 
 func (s *provisionerSuite) TestProvisioningInfo(c *gc.C) {
 	vers := version.MustParse("2.99.0")
+	memLimit := uint64(1024)
 	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
 		c.Check(objType, gc.Equals, "CAASApplicationProvisioner")
 		c.Check(id, gc.Equals, "")
@@ -154,6 +156,12 @@ func (s *provisionerSuite) TestProvisioningInfo(c *gc.C) {
 				ImageRepo:            "jujuqa",
 				CharmModifiedVersion: 1,
 				CharmURL:             "cs:~test/charm-1",
+				NetworkAttachments:   []string{"storage"},
+				UpdateStrategy:       &params.CAASUpdateStrategy{MaxSurge: "1"},
+				DisruptionBudget:     &params.CAASDisruptionBudget{MinAvailable: "2"},
+				ContainerResources: map[string]params.CAASContainerResources{
+					"gitlab": {MemLimit: &memLimit, GPUs: 1},
+				},
 			}}}
 		return nil
 	})
@@ -168,6 +176,12 @@ func (s *provisionerSuite) TestProvisioningInfo(c *gc.C) {
 		ImageRepo:            "jujuqa",
 		CharmModifiedVersion: 1,
 		CharmURL:             &charm.URL{Schema: "cs", User: "test", Name: "charm", Revision: 1},
+		NetworkAttachments:   []string{"storage"},
+		UpdateStrategy:       caas.UpdateStrategy{MaxSurge: "1"},
+		DisruptionBudget:     &caas.DisruptionBudget{MinAvailable: "2"},
+		ContainerResources: map[string]caas.ContainerResources{
+			"gitlab": {MemLimit: &memLimit, GPUs: 1},
+		},
 	})
 }
 
@@ -0,0 +1,91 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+)
+
+// Client makes calls to the CAASAutoscaler facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a client used to access the CAASAutoscaler facade.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, "CAASAutoscaler"),
+	}
+}
+
+// AutoscaledApplication holds an application's autoscaling policy and
+// its current scale.
+type AutoscaledApplication struct {
+	Name   string
+	Policy application.AutoscalePolicy
+	Scale  int
+
+	// MetricAverage is the average value of the policy's charm
+	// metric across the units, or nil if it hasn't been reported.
+	MetricAverage *float64
+
+	// Err is set if the metric value couldn't be determined.
+	Err error
+}
+
+// AutoscaledApplications returns the applications in the model which
+// have an autoscaling policy.
+func (c *Client) AutoscaledApplications() ([]AutoscaledApplication, error) {
+	var results params.AutoscaledApplicationResults
+	if err := c.facade.FacadeCall("AutoscaledApplications", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	apps := make([]AutoscaledApplication, len(results.Results))
+	for i, result := range results.Results {
+		tag, err := names.ParseApplicationTag(result.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		apps[i] = AutoscaledApplication{
+			Name: tag.Id(),
+			Policy: application.AutoscalePolicy{
+				MinUnits:     result.Policy.MinUnits,
+				MaxUnits:     result.Policy.MaxUnits,
+				TargetCPU:    result.Policy.TargetCPU,
+				TargetMemory: result.Policy.TargetMemory,
+				Metric:       result.Policy.Metric,
+				TargetMetric: result.Policy.TargetMetric,
+			},
+			Scale:         result.Scale,
+			MetricAverage: result.MetricAverage,
+		}
+		if result.Error != nil {
+			apps[i].Err = result.Error
+		}
+	}
+	return apps, nil
+}
+
+// SetScale sets the scale of the named application.
+func (c *Client) SetScale(appName string, scale int) error {
+	if !names.IsValidApplication(appName) {
+		return errors.NotValidf("application name %q", appName)
+	}
+	args := params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: names.NewApplicationTag(appName).String(),
+			Scale:          scale,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetScale", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/caasautoscaler"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestAutoscaledApplications(c *gc.C) {
+	average := 150.0
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASAutoscaler")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "AutoscaledApplications")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.AutoscaledApplicationResults)) = params.AutoscaledApplicationResults{
+			Results: []params.AutoscaledApplication{{
+				ApplicationTag: "application-gitlab",
+				Policy:         params.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetCPU: 70},
+				Scale:          3,
+			}, {
+				ApplicationTag: "application-rabbitmq",
+				Policy:         params.AutoscalePolicy{MaxUnits: 5, Metric: "queue-length", TargetMetric: 100},
+				Scale:          2,
+				MetricAverage:  &average,
+				Error:          &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	client := caasautoscaler.NewClient(apiCaller)
+	apps, err := client.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apps, gc.HasLen, 2)
+	c.Assert(apps[0], jc.DeepEquals, caasautoscaler.AutoscaledApplication{
+		Name:   "gitlab",
+		Policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetCPU: 70},
+		Scale:  3,
+	})
+	c.Assert(apps[1].Name, gc.Equals, "rabbitmq")
+	c.Assert(apps[1].Policy, jc.DeepEquals, application.AutoscalePolicy{MaxUnits: 5, Metric: "queue-length", TargetMetric: 100})
+	c.Assert(*apps[1].MetricAverage, gc.Equals, 150.0)
+	c.Assert(apps[1].Err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestSetScale(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASAutoscaler")
+		c.Check(request, gc.Equals, "SetScale")
+		c.Check(arg, jc.DeepEquals, params.ScaleApplicationsParams{
+			Applications: []params.ScaleApplicationParams{{
+				ApplicationTag: "application-gitlab",
+				Scale:          4,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := caasautoscaler.NewClient(apiCaller)
+	err := client.SetScale("gitlab", 4)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestSetScaleInvalidName(c *gc.C) {
+	client := caasautoscaler.NewClient(basetesting.APICallerFunc(nil))
+	err := client.SetScale("gitlab/0", 4)
+	c.Assert(err, gc.ErrorMatches, `application name "gitlab/0" not valid`)
+}
@@ -49,6 +49,48 @@ var _ = gc.Suite(&firewallerLegacySuite{
 	},
 })
 
+func (s *firewallerLegacySuite) TestRelatedApplications(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CAASFirewaller")
+			c.Check(version, gc.Equals, 2)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RelatedApplications")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{
+					Tag: "application-gitlab",
+				}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.StringsResults{})
+			*(result.(*params.StringsResults)) = params.StringsResults{
+				Results: []params.StringsResult{{
+					Result: []string{"mariadb", "redis"},
+				}},
+			}
+			return nil
+		},
+	}
+
+	client := caasfirewaller.NewClientLegacy(apiCaller)
+	related, err := client.RelatedApplications("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(related, jc.DeepEquals, []string{"mariadb", "redis"})
+}
+
+func (s *firewallerLegacySuite) TestRelatedApplicationsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 1,
+		APICallerFunc: func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			return errors.New("should not be called")
+		},
+	}
+
+	client := caasfirewaller.NewClientLegacy(apiCaller)
+	_, err := client.RelatedApplications("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 type firewallerEmbeddedSuite struct {
 	firewallerBaseSuite
 }
@@ -62,6 +104,29 @@ var _ = gc.Suite(&firewallerEmbeddedSuite{
 	},
 })
 
+func (s *firewallerEmbeddedSuite) TestRelatedApplications(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "CAASFirewallerEmbedded")
+			c.Check(version, gc.Equals, 2)
+			c.Check(request, gc.Equals, "RelatedApplications")
+			c.Assert(result, gc.FitsTypeOf, &params.StringsResults{})
+			*(result.(*params.StringsResults)) = params.StringsResults{
+				Results: []params.StringsResult{{
+					Result: []string{"mariadb"},
+				}},
+			}
+			return nil
+		},
+	}
+
+	client := caasfirewaller.NewClientEmbedded(apiCaller)
+	related, err := client.RelatedApplications("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(related, jc.DeepEquals, []string{"mariadb"})
+}
+
 func (s *firewallerEmbeddedSuite) TestWatchOpenedPorts(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		c.Check(objType, gc.Equals, s.objType)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/docker"
 )
 
 // Client is a caas model operator facade client
@@ -30,6 +31,10 @@ type ModelOperatorProvisioningInfo struct {
 	APIAddresses []string
 	ImagePath    string
 	Version      version.Number
+
+	// ImageRepo holds the details of the controller's image repo, if
+	// it is private.
+	ImageRepo *docker.ImageRepoDetails
 }
 
 // ModelOperatorProvisioningInfo returns the information needed for a given model
@@ -40,11 +45,23 @@ func (c *Client) ModelOperatorProvisioningInfo() (ModelOperatorProvisioningInfo,
 		return ModelOperatorProvisioningInfo{}, err
 	}
 
-	return ModelOperatorProvisioningInfo{
+	info := ModelOperatorProvisioningInfo{
 		APIAddresses: result.APIAddresses,
 		ImagePath:    result.ImagePath,
 		Version:      result.Version,
-	}, nil
+	}
+	if r := result.ImageRepo; r != nil {
+		info.ImageRepo = &docker.ImageRepoDetails{
+			Repository:    r.Repository,
+			ServerAddress: r.ServerAddress,
+			Username:      r.Username,
+			Password:      r.Password,
+			Auth:          r.Auth,
+			IdentityToken: r.IdentityToken,
+			RegistryToken: r.RegistryToken,
+		}
+	}
+	return info, nil
 }
 
 // SetPasswords sets the supplied passwords on their corresponding models
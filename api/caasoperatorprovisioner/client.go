@@ -103,11 +103,12 @@ func (c *Client) Life(appName string) (life.Value, error) {
 
 // OperatorProvisioningInfo holds the info needed to provision an operator.
 type OperatorProvisioningInfo struct {
-	ImagePath    string
-	Version      version.Number
-	APIAddresses []string
-	Tags         map[string]string
-	CharmStorage *storage.KubernetesFilesystemParams
+	ImagePath          string
+	Version            version.Number
+	APIAddresses       []string
+	Tags               map[string]string
+	CharmStorage       *storage.KubernetesFilesystemParams
+	IsPrivateImageRepo bool
 }
 
 // OperatorProvisioningInfo returns the info needed to provision an operator for an application.
@@ -127,11 +128,12 @@ func (c *Client) OperatorProvisioningInfo(applicationName string) (OperatorProvi
 		return OperatorProvisioningInfo{}, errors.Trace(err)
 	}
 	return OperatorProvisioningInfo{
-		ImagePath:    info.ImagePath,
-		Version:      info.Version,
-		APIAddresses: info.APIAddresses,
-		Tags:         info.Tags,
-		CharmStorage: filesystemFromParams(info.CharmStorage),
+		ImagePath:          info.ImagePath,
+		Version:            info.Version,
+		APIAddresses:       info.APIAddresses,
+		Tags:               info.Tags,
+		CharmStorage:       filesystemFromParams(info.CharmStorage),
+		IsPrivateImageRepo: info.IsPrivateImageRepo,
 	}, nil
 }
 
@@ -285,6 +285,36 @@ func (c *Client) SetModelAgentVersion(version version.Number, ignoreAgentVersion
 	return c.facade.FacadeCall("SetModelAgentVersion", args, nil)
 }
 
+// SetCanaryAgentVersion upgrades the agents of the given machines, and
+// of the units on them, to the given version, leaving the rest of the
+// model at its current agent version.
+func (c *Client) SetCanaryAgentVersion(version version.Number, machineIds []string) error {
+	if c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("canary upgrades by this version of Juju")
+	}
+	args := params.SetCanaryAgentVersion{
+		Version:  version,
+		Machines: make([]params.Entity, len(machineIds)),
+	}
+	for i, id := range machineIds {
+		if !names.IsValidMachine(id) {
+			return errors.NotValidf("machine ID %q", id)
+		}
+		args.Machines[i].Tag = names.NewMachineTag(id).String()
+	}
+	return c.facade.FacadeCall("SetCanaryAgentVersion", args, nil)
+}
+
+// ClearCanaryAgentVersion ends any canary upgrade of the model's
+// agents, rolling the canary machines back to the model's agent version
+// unless the model has been upgraded.
+func (c *Client) ClearCanaryAgentVersion() error {
+	if c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("canary upgrades by this version of Juju")
+	}
+	return c.facade.FacadeCall("ClearCanaryAgentVersion", nil, nil)
+}
+
 // AbortCurrentUpgrade aborts and archives the current upgrade
 // synchronisation record, if any.
 func (c *Client) AbortCurrentUpgrade() error {
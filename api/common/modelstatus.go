@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 )
 
@@ -114,6 +115,17 @@ func constructModelStatus(m names.ModelTag, owner names.UserTag, r params.ModelS
 			Message:     mm.Message,
 			HAPrimary:   mm.HAPrimary,
 		}
+		if mm.Hardware != nil {
+			result.Machines[j].Hardware = &instance.HardwareCharacteristics{
+				Arch:             mm.Hardware.Arch,
+				Mem:              mm.Hardware.Mem,
+				RootDisk:         mm.Hardware.RootDisk,
+				CpuCores:         mm.Hardware.Cores,
+				CpuPower:         mm.Hardware.CpuPower,
+				Tags:             mm.Hardware.Tags,
+				AvailabilityZone: mm.Hardware.AvailabilityZone,
+			}
+		}
 	}
 	return result
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
+	"github.com/juju/version/v2"
 	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/api"
@@ -319,16 +320,105 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationPrechecks runs all of the migration prechecks for the
+// specified model on the source and target controllers, without
+// starting the migration. Every blocking issue found is returned.
+func (c *Client) MigrationPrechecks(spec MigrationSpec) ([]string, error) {
+	if c.BestAPIVersion() < 10 {
+		return nil, errors.NotSupportedf("migration prechecks")
+	}
+	args, err := makeInitiateMigrationArgs(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	response := params.MigrationPrecheckResults{}
+	if err := c.facade.FacadeCall("MigrationPrechecks", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Issues, nil
+}
+
+// RetryMigration requests that the failed model data transfer of the
+// active migration of the specified model is retried.
+func (c *Client) RetryMigration(modelUUID string) error {
+	if c.BestAPIVersion() < 11 {
+		return errors.NotSupportedf("retrying migrations")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewModelTag(modelUUID).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RetryMigrations", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CheckUpgrade runs the upgrade steps needed to upgrade the controller
+// to the target version against a copy of the controller's database,
+// and reports the result of each pending step. The running version of
+// the controller is not changed.
+func (c *Client) CheckUpgrade(target version.Number) (params.CheckUpgradeResult, error) {
+	var result params.CheckUpgradeResult
+	if c.BestAPIVersion() < 12 {
+		return result, errors.NotSupportedf("checking upgrades")
+	}
+	args := params.CheckUpgradeArgs{TargetVersion: target}
+	if err := c.facade.FacadeCall("CheckUpgrade", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// ControllerHealth reports on the raft, lease store, Mongo and pubsub
+// forwarding health of each of the controllers.
+func (c *Client) ControllerHealth() (params.ControllerHealthResult, error) {
+	var result params.ControllerHealthResult
+	if c.BestAPIVersion() < 13 {
+		return result, errors.NotSupportedf("controller health")
+	}
+	if err := c.facade.FacadeCall("ControllerHealth", nil, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func makeInitiateMigrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -341,19 +431,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:       macsJSON,
 			},
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -11,6 +11,7 @@ import (
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2"
+	"github.com/juju/version/v2"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/macaroon.v2"
 
@@ -172,6 +173,200 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestMigrationPrechecks(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationPrecheckResults)
+			*out = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Issues: []string{"source: cleanup needed"},
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	issues, err := client.MigrationPrechecks(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(issues, jc.DeepEquals, []string{"source: cleanup needed"})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationPrechecks", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationPrechecksError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.MigrationPrecheckResults)
+			*out = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Error: apiservererrors.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestMigrationPrechecksNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationPrechecks(makeSpec())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestRetryMigration(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.RetryMigration(coretesting.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.RetryMigrations", []interface{}{params.Entities{
+			Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+		}}},
+	})
+}
+
+func (s *Suite) TestRetryMigrationError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: []params.ErrorResult{{
+				Error: apiservererrors.ServerError(errors.New("boom")),
+			}}}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.RetryMigration(coretesting.ModelTag.Id())
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestCheckUpgrade(c *gc.C) {
+	var stub jujutesting.Stub
+	expected := params.CheckUpgradeResult{
+		FromVersion: version.MustParse("2.9.0"),
+		Steps: []params.UpgradeStepCheck{{
+			Version:     "2.9.1",
+			Description: "add a thing",
+			Targets:     []string{"controller"},
+			Checked:     true,
+		}},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.CheckUpgradeResult)) = expected
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	result, err := client.CheckUpgrade(version.MustParse("2.9.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, expected)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.CheckUpgrade", []interface{}{params.CheckUpgradeArgs{
+			TargetVersion: version.MustParse("2.9.1"),
+		}}},
+	})
+}
+
+func (s *Suite) TestCheckUpgradeNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.CheckUpgrade(version.MustParse("2.9.1"))
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestControllerHealth(c *gc.C) {
+	var stub jujutesting.Stub
+	expected := params.ControllerHealthResult{
+		Agents: []params.ControllerAgentHealth{{
+			ControllerId: "0",
+			Responded:    true,
+			RaftState:    "Leader",
+			RaftLeader:   "0",
+		}},
+		MongoMembers: []params.MongoMemberHealth{{
+			Id:      1,
+			State:   "PRIMARY",
+			Healthy: true,
+		}},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.ControllerHealthResult)) = expected
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	result, err := client.ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, expected)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.ControllerHealth", []interface{}{nil}},
+	})
+}
+
+func (s *Suite) TestControllerHealthNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.ControllerHealth()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestRetryMigrationNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	err := client.RetryMigration(coretesting.ModelTag.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
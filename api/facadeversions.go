@@ -18,7 +18,7 @@ var facadeVersions = map[string]int{
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  13,
+	"Application":                  14,
 	"ApplicationOffers":            3,
 	"ApplicationScaler":            1,
 	"Backups":                      3,
@@ -28,8 +28,9 @@ var facadeVersions = map[string]int{
 	"CAASAdmission":                1,
 	"CAASApplication":              1,
 	"CAASApplicationProvisioner":   1,
-	"CAASFirewaller":               1,
-	"CAASFirewallerEmbedded":       1,
+	"CAASAutoscaler":               1,
+	"CAASFirewaller":               2,
+	"CAASFirewallerEmbedded":       2,
 	"CAASModelOperator":            1,
 	"CAASOperator":                 1,
 	"CAASOperatorProvisioner":      1,
@@ -39,9 +40,9 @@ var facadeVersions = map[string]int{
 	"CharmRevisionUpdater":         2,
 	"Charms":                       4,
 	"Cleaner":                      2,
-	"Client":                       3,
+	"Client":                       4,
 	"Cloud":                        7,
-	"Controller":                   9,
+	"Controller":                   13,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
@@ -54,7 +55,7 @@ var facadeVersions = map[string]int{
 	"FilesystemAttachmentsWatcher": 2,
 	"Firewaller":                   6,
 	"FirewallRules":                1,
-	"HighAvailability":             2,
+	"HighAvailability":             3,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                3,
@@ -67,7 +68,7 @@ var facadeVersions = map[string]int{
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
 	"Logger":                       1,
-	"MachineActions":               1,
+	"MachineActions":               2,
 	"MachineManager":               6,
 	"MachineUndertaker":            1,
 	"Machiner":                     5,
@@ -76,13 +77,13 @@ var facadeVersions = map[string]int{
 	"MetricsDebug":                 2,
 	"MetricsManager":               1,
 	"MigrationFlag":                1,
-	"MigrationMaster":              3,
+	"MigrationMaster":              4,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              2,
 	"ModelConfig":                  2,
 	"ModelGeneration":              4,
-	"ModelManager":                 9,
+	"ModelManager":                 11,
 	"ModelSummaryWatcher":          1,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
@@ -108,10 +109,10 @@ var facadeVersions = map[string]int{
 	"Storage":                      6,
 	"StorageProvisioner":           4,
 	"StringsWatcher":               1,
-	"Subnets":                      4,
+	"Subnets":                      5,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       17,
+	"Uniter":                       18,
 	"Upgrader":                     1,
 	"UpgradeSeries":                3,
 	"UpgradeSteps":                 2,
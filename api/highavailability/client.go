@@ -4,7 +4,10 @@
 package highavailability
 
 import (
+	"time"
+
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -49,3 +52,53 @@ func (c *Client) EnableHA(
 	}
 	return result.Result, nil
 }
+
+// RemoveControllerNode starts the removal of the controller machine with
+// the given id. The machine gives up its vote and is removed once the
+// other controllers have taken over.
+func (c *Client) RemoveControllerNode(machineId string, force bool, maxWait *time.Duration) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("removing controller machines")
+	}
+	var results params.ErrorResults
+	arg := params.RemoveControllerNodesArgs{
+		Nodes: []params.RemoveControllerNodeArg{{
+			MachineTag: names.NewMachineTag(machineId).String(),
+			Force:      force,
+			MaxWait:    maxWait,
+		}}}
+	if err := c.facade.FacadeCall("RemoveControllerNodes", arg, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ReplaceControllerNode removes the controller machine with the given id
+// and starts a new controller machine in its place.
+func (c *Client) ReplaceControllerNode(
+	machineId string, force bool, maxWait *time.Duration, cons constraints.Value, placement []string,
+) (params.ControllersChanges, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.ControllersChanges{}, errors.NotSupportedf("replacing controller machines")
+	}
+	var results params.ControllersChangeResults
+	arg := params.ReplaceControllerNodesArgs{
+		Nodes: []params.ReplaceControllerNodeArg{{
+			MachineTag:  names.NewMachineTag(machineId).String(),
+			Force:       force,
+			MaxWait:     maxWait,
+			Constraints: cons,
+			Placement:   placement,
+		}}}
+	if err := c.facade.FacadeCall("ReplaceControllerNodes", arg, &results); err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ControllersChanges{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ControllersChanges{}, result.Error
+	}
+	return result.Result, nil
+}
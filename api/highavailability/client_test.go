@@ -65,5 +65,25 @@ func (s *clientSuite) TestClientEnableHA(c *gc.C) {
 
 func (s *clientSuite) TestClientEnableHAVersion(c *gc.C) {
 	client := highavailability.NewClient(s.APIState)
-	c.Assert(client.BestAPIVersion(), gc.Equals, 2)
+	c.Assert(client.BestAPIVersion(), gc.Equals, 3)
+}
+
+func (s *clientSuite) TestClientRemoveControllerNode(c *gc.C) {
+	assertEnableHA(c, &s.JujuConnSuite)
+	client := highavailability.NewClient(s.APIState)
+	err := client.RemoveControllerNode("1", false, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	node, err := s.State.ControllerNode("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(node.WantsVote(), jc.IsFalse)
+}
+
+func (s *clientSuite) TestClientReplaceControllerNode(c *gc.C) {
+	assertEnableHA(c, &s.JujuConnSuite)
+	client := highavailability.NewClient(s.APIState)
+	result, err := client.ReplaceControllerNode("1", false, nil, constraints.Value{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Added, gc.DeepEquals, []string{"machine-3"})
+	c.Assert(result.Removed, gc.DeepEquals, []string{"machine-1"})
 }
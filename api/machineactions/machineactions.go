@@ -156,3 +156,32 @@ func (c *Client) RunningActions(agent names.MachineTag) ([]params.ActionResult,
 
 	return result.Actions, nil
 }
+
+// NetworkCheckTargets returns the addresses of units related to those on
+// the input machine, that should be probed when performing a network check.
+func (c *Client) NetworkCheckTargets(agent names.MachineTag) ([]params.NetworkCheckTarget, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("network check targets on this version of Juju")
+	}
+
+	var results params.NetworkCheckTargetsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: agent.String()}},
+	}
+
+	err := c.facade.FacadeCall("NetworkCheckTargets", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return result.Targets, nil
+}
@@ -565,3 +565,70 @@ func (s *ClientSuite) TestRunningActionsTooManyResults(c *gc.C) {
 	c.Assert(actions, gc.IsNil)
 	stub.CheckCalls(c, expectedCalls)
 }
+
+func (s *ClientSuite) TestNetworkCheckTargets(c *gc.C) {
+	tag := names.NewMachineTag("2")
+	expectedCalls := []jujutesting.StubCall{{
+		"MachineActions.NetworkCheckTargets",
+		[]interface{}{"", params.Entities{
+			Entities: []params.Entity{{Tag: tag.String()}},
+		}},
+	}}
+	targets := []params.NetworkCheckTarget{{
+		Unit:        "mysql/0",
+		Endpoint:    "db",
+		RelatedUnit: "wordpress/0",
+		Space:       "alpha",
+		Address:     "10.0.0.2",
+	}}
+	var stub jujutesting.Stub
+
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			c.Check(result, gc.FitsTypeOf, &params.NetworkCheckTargetsResults{})
+			*(result.(*params.NetworkCheckTargetsResults)) = params.NetworkCheckTargetsResults{
+				Results: []params.NetworkCheckTargetsResult{{Targets: targets}},
+			}
+			return nil
+		}),
+		BestVersion: 2,
+	}
+
+	client := machineactions.NewClient(apiCaller)
+	result, err := client.NetworkCheckTargets(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, targets)
+	stub.CheckCalls(c, expectedCalls)
+}
+
+func (s *ClientSuite) TestNetworkCheckTargetsResultError(c *gc.C) {
+	expectErr := &params.Error{
+		Message: "rigged",
+		Code:    params.CodeNotFound,
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.NetworkCheckTargetsResults)) = params.NetworkCheckTargetsResults{
+				Results: []params.NetworkCheckTargetsResult{{Error: expectErr}},
+			}
+			return nil
+		}),
+		BestVersion: 2,
+	}
+
+	client := machineactions.NewClient(apiCaller)
+	_, err := client.NetworkCheckTargets(names.NewMachineTag("2"))
+	c.Assert(errors.Cause(err), gc.Equals, expectErr)
+}
+
+func (s *ClientSuite) TestNetworkCheckTargetsNotSupported(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+
+	client := machineactions.NewClient(apiCaller)
+	_, err := client.NetworkCheckTargets(names.NewMachineTag("2"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -10,10 +10,8 @@ import (
 	"net/http"
 	"time"
 
-	charmresource "github.com/juju/charm/v9/resource"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
-	"github.com/juju/version/v2"
 	"gopkg.in/httprequest.v1"
 	"gopkg.in/macaroon.v2"
 
@@ -22,7 +20,6 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/watcher"
-	"github.com/juju/juju/resource"
 )
 
 // NewWatcherFunc exists to let us unit test Facade without patching.
@@ -110,6 +107,17 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 			Password:      target.Password,
 			Macaroons:     macs,
 		},
+		Progress: migration.TransferProgress{
+			Charms:         status.Progress.Charms,
+			CharmsTotal:    status.Progress.CharmsTotal,
+			Tools:          status.Progress.Tools,
+			ToolsTotal:     status.Progress.ToolsTotal,
+			Resources:      status.Progress.Resources,
+			ResourcesTotal: status.Progress.ResourcesTotal,
+			LogMessages:    status.Progress.LogMessages,
+			LogBytes:       status.Progress.LogBytes,
+		},
+		Retries: status.Retries,
 	}, nil
 }
 
@@ -130,6 +138,24 @@ func (c *Client) SetStatusMessage(message string) error {
 	return c.caller.FacadeCall("SetStatusMessage", args, nil)
 }
 
+// SetProgress records how much of the model's data has been
+// transferred to the target controller.
+func (c *Client) SetProgress(progress migration.TransferProgress) error {
+	args := params.SetMigrationProgressArgs{
+		Progress: params.MigrationProgress{
+			Charms:         progress.Charms,
+			CharmsTotal:    progress.CharmsTotal,
+			Tools:          progress.Tools,
+			ToolsTotal:     progress.ToolsTotal,
+			Resources:      progress.Resources,
+			ResourcesTotal: progress.ResourcesTotal,
+			LogMessages:    progress.LogMessages,
+			LogBytes:       progress.LogBytes,
+		},
+	}
+	return c.caller.FacadeCall("SetProgress", args, nil)
+}
+
 // ModelInfo return basic information about the model to migrated.
 func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 	var info params.MigrationModelInfo
@@ -160,34 +186,12 @@ func (c *Client) Prechecks() error {
 // with the API connection. The charms used by the model are also
 // returned.
 func (c *Client) Export() (migration.SerializedModel, error) {
-	var empty migration.SerializedModel
 	var serialized params.SerializedModel
 	err := c.caller.FacadeCall("Export", nil, &serialized)
 	if err != nil {
-		return empty, errors.Trace(err)
-	}
-
-	// Convert tools info to output map.
-	tools := make(map[version.Binary]string)
-	for _, toolsInfo := range serialized.Tools {
-		v, err := version.ParseBinary(toolsInfo.Version)
-		if err != nil {
-			return migration.SerializedModel{}, errors.Annotate(err, "error parsing agent binary version")
-		}
-		tools[v] = toolsInfo.URI
-	}
-
-	resources, err := convertResources(serialized.Resources)
-	if err != nil {
-		return empty, errors.Trace(err)
+		return migration.SerializedModel{}, errors.Trace(err)
 	}
-
-	return migration.SerializedModel{
-		Bytes:     serialized.Bytes,
-		Charms:    serialized.Charms,
-		Tools:     tools,
-		Resources: resources,
-	}, nil
+	return common.SerializedModelFromParams(serialized)
 }
 
 // ProcessRelations runs a series of processes to ensure that the relations
@@ -298,6 +302,22 @@ func (c *Client) MinionReportTimeout() (time.Duration, error) {
 	return timeout, errors.Trace(err)
 }
 
+// TransferRetryTimeout returns how long the migration master worker
+// should wait for a retry to be requested after a model data transfer
+// fails.
+func (c *Client) TransferRetryTimeout() (time.Duration, error) {
+	var res params.StringResult
+	err := c.caller.FacadeCall("TransferRetryTimeout", nil, &res)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	timeout, err := time.ParseDuration(res.Result)
+	return timeout, errors.Trace(err)
+}
+
 // StreamModelLog takes a starting time and returns a channel that
 // will yield the logs on or after that time - these are the logs that
 // need to be transferred to the target after the migration is
@@ -333,78 +353,3 @@ func groupTagIds(tagStrs []string) ([]string, []string, []string, error) {
 	}
 	return machines, units, applications, nil
 }
-
-func convertResources(in []params.SerializedModelResource) ([]migration.SerializedModelResource, error) {
-	if len(in) == 0 {
-		return nil, nil
-	}
-	out := make([]migration.SerializedModelResource, 0, len(in))
-	for _, resource := range in {
-		outResource, err := convertAppResource(resource)
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		out = append(out, outResource)
-	}
-	return out, nil
-}
-
-func convertAppResource(in params.SerializedModelResource) (migration.SerializedModelResource, error) {
-	var empty migration.SerializedModelResource
-	appRev, err := convertResourceRevision(in.Application, in.Name, in.ApplicationRevision)
-	if err != nil {
-		return empty, errors.Annotate(err, "application revision")
-	}
-	csRev, err := convertResourceRevision(in.Application, in.Name, in.CharmStoreRevision)
-	if err != nil {
-		return empty, errors.Annotate(err, "charmstore revision")
-	}
-	unitRevs := make(map[string]resource.Resource)
-	for unitName, inUnitRev := range in.UnitRevisions {
-		unitRev, err := convertResourceRevision(in.Application, in.Name, inUnitRev)
-		if err != nil {
-			return empty, errors.Annotate(err, "unit revision")
-		}
-		unitRevs[unitName] = unitRev
-	}
-	return migration.SerializedModelResource{
-		ApplicationRevision: appRev,
-		CharmStoreRevision:  csRev,
-		UnitRevisions:       unitRevs,
-	}, nil
-}
-
-func convertResourceRevision(app, name string, rev params.SerializedModelResourceRevision) (resource.Resource, error) {
-	var empty resource.Resource
-	type_, err := charmresource.ParseType(rev.Type)
-	if err != nil {
-		return empty, errors.Trace(err)
-	}
-	origin, err := charmresource.ParseOrigin(rev.Origin)
-	if err != nil {
-		return empty, errors.Trace(err)
-	}
-	var fp charmresource.Fingerprint
-	if rev.FingerprintHex != "" {
-		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex); err != nil {
-			return empty, errors.Annotate(err, "invalid fingerprint")
-		}
-	}
-	return resource.Resource{
-		Resource: charmresource.Resource{
-			Meta: charmresource.Meta{
-				Name:        name,
-				Type:        type_,
-				Path:        rev.Path,
-				Description: rev.Description,
-			},
-			Origin:      origin,
-			Revision:    rev.Revision,
-			Size:        rev.Size,
-			Fingerprint: fp,
-		},
-		ApplicationID: app,
-		Username:      rev.Username,
-		Timestamp:     rev.Timestamp,
-	}, nil
-}
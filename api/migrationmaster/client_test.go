@@ -99,6 +99,8 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 			MigrationId:      "id",
 			Phase:            "IMPORT",
 			PhaseChangedTime: timestamp,
+			Progress:         params.MigrationProgress{Charms: 1, CharmsTotal: 3},
+			Retries:          2,
 		}
 		return nil
 	})
@@ -122,6 +124,8 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 			AuthTag:       names.NewUserTag("admin"),
 			Password:      "secret",
 		},
+		Progress: migration.TransferProgress{Charms: 1, CharmsTotal: 3},
+		Retries:  2,
 	})
 }
 
@@ -173,6 +177,31 @@ func (s *ClientSuite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestSetProgress(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.SetProgress(migration.TransferProgress{
+		Charms:      1,
+		CharmsTotal: 2,
+		LogBytes:    42,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.SetMigrationProgressArgs{
+		Progress: params.MigrationProgress{
+			Charms:      1,
+			CharmsTotal: 2,
+			LogBytes:    42,
+		},
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SetProgress", []interface{}{"", expectedArg}},
+	})
+}
+
 func (s *ClientSuite) TestModelInfo(c *gc.C) {
 	var stub jujutesting.Stub
 	owner := names.NewUserTag("owner")
@@ -569,6 +598,23 @@ func (s *ClientSuite) TestMinionReportTimeout(c *gc.C) {
 	c.Check(timeout, gc.Equals, 30*time.Second)
 }
 
+func (s *ClientSuite) TestTransferRetryTimeout(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(facade string, _ int, _, method string, _ interface{}, result interface{}) error {
+		c.Assert(facade, gc.Equals, "MigrationMaster")
+		c.Assert(method, gc.Equals, "TransferRetryTimeout")
+
+		out := result.(*params.StringResult)
+		*out = params.StringResult{
+			Result: "5m0s",
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	timeout, err := client.TransferRetryTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(timeout, gc.Equals, 5*time.Minute)
+}
+
 func (s *ClientSuite) TestStreamModelLogs(c *gc.C) {
 	caller := fakeConnector{path: new(string), attrs: &url.Values{}}
 	client := migrationmaster.NewClient(caller, nil)
@@ -42,16 +42,43 @@ type Client struct {
 }
 
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
-	args := params.MigrationModelInfo{
-		UUID:                   model.UUID,
-		Name:                   model.Name,
-		OwnerTag:               model.Owner.String(),
-		AgentVersion:           model.AgentVersion,
-		ControllerAgentVersion: model.ControllerAgentVersion,
-	}
+	args := migrationModelInfo(model)
 	return errors.Trace(c.caller.FacadeCall("Prechecks", args, nil))
 }
 
+// PrecheckIssues runs all of the migration prechecks on the target
+// controller, returning every blocking issue found rather than
+// failing at the first.
+func (c *Client) PrecheckIssues(model coremigration.ModelInfo) ([]string, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("PrecheckIssues")
+	}
+	args := migrationModelInfo(model)
+	var result params.MigrationPrecheckResult
+	if err := c.caller.FacadeCall("PrecheckIssues", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Issues, nil
+}
+
+func migrationModelInfo(model coremigration.ModelInfo) params.MigrationModelInfo {
+	info := params.MigrationModelInfo{
+		UUID:                    model.UUID,
+		Name:                    model.Name,
+		OwnerTag:                model.Owner.String(),
+		AgentVersion:            model.AgentVersion,
+		ControllerAgentVersion:  model.ControllerAgentVersion,
+		CloudName:               model.CloudName,
+		CloudType:               model.CloudType,
+		CloudRegion:             model.CloudRegion,
+		CloudCredentialAuthType: model.CloudCredentialAuthType,
+	}
+	if !model.CloudCredential.IsZero() {
+		info.CloudCredentialTag = model.CloudCredential.String()
+	}
+	return info
+}
+
 // Import takes a serialized model and imports it into the target
 // controller.
 func (c *Client) Import(bytes []byte) error {
@@ -58,26 +58,81 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	controllerVers := version.MustParse("1.2.5")
 
 	err := client.Prechecks(coremigration.ModelInfo{
+		UUID:                    "uuid",
+		Owner:                   ownerTag,
+		Name:                    "name",
+		AgentVersion:            vers,
+		ControllerAgentVersion:  controllerVers,
+		CloudName:               "aws",
+		CloudType:               "ec2",
+		CloudRegion:             "us-east-1",
+		CloudCredential:         names.NewCloudCredentialTag("aws/owner/default"),
+		CloudCredentialAuthType: "access-key",
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	expectedArg := params.MigrationModelInfo{
+		UUID:                    "uuid",
+		Name:                    "name",
+		OwnerTag:                ownerTag.String(),
+		AgentVersion:            vers,
+		ControllerAgentVersion:  controllerVers,
+		CloudName:               "aws",
+		CloudType:               "ec2",
+		CloudRegion:             "us-east-1",
+		CloudCredentialTag:      "cloudcred-aws_owner_default",
+		CloudCredentialAuthType: "access-key",
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{FuncName: "MigrationTarget.Prechecks", Args: []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestPrecheckIssues(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			*(result.(*params.MigrationPrecheckResult)) = params.MigrationPrecheckResult{
+				Issues: []string{"upgrade in progress"},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	issues, err := client.PrecheckIssues(coremigration.ModelInfo{
 		UUID:                   "uuid",
 		Owner:                  ownerTag,
 		Name:                   "name",
 		AgentVersion:           vers,
-		ControllerAgentVersion: controllerVers,
+		ControllerAgentVersion: vers,
 	})
-	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []string{"upgrade in progress"})
 
 	expectedArg := params.MigrationModelInfo{
 		UUID:                   "uuid",
 		Name:                   "name",
 		OwnerTag:               ownerTag.String(),
 		AgentVersion:           vers,
-		ControllerAgentVersion: controllerVers,
+		ControllerAgentVersion: vers,
 	}
 	stub.CheckCalls(c, []jujutesting.StubCall{
-		{FuncName: "MigrationTarget.Prechecks", Args: []interface{}{"", expectedArg}},
+		{FuncName: "MigrationTarget.PrecheckIssues", Args: []interface{}{"", expectedArg}},
 	})
 }
 
+func (s *ClientSuite) TestPrecheckIssuesNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.PrecheckIssues(coremigration.ModelInfo{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
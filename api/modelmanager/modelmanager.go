@@ -390,6 +390,80 @@ func (c *Client) DumpModelDB(model names.ModelTag) (map[string]interface{}, erro
 	return result.Result, nil
 }
 
+// ExportModel returns the serialized form of the specified model, along
+// with the charms, agent binaries and resources it uses, so that it can
+// be archived for import into another controller.
+func (c *Client) ExportModel(model names.ModelTag) (params.SerializedModel, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 10 {
+		return params.SerializedModel{}, errors.NotImplementedf("ExportModel in version %v", bestVer)
+	}
+
+	var results params.SerializedModelResults
+	entities := params.Entities{
+		Entities: []params.Entity{{Tag: model.String()}},
+	}
+	if err := c.facade.FacadeCall("ExportModels", entities, &results); err != nil {
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+	if count := len(results.Results); count != 1 {
+		return params.SerializedModel{}, errors.Errorf("unexpected result count: %d", count)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.SerializedModel{}, result.Error
+	}
+	if result.Result == nil {
+		return params.SerializedModel{}, errors.New("missing serialized model")
+	}
+	return *result.Result, nil
+}
+
+// CloneModel creates a new model with the given name, running the same
+// applications, with the same relations and offers, as the source model.
+// Config values given override those copied from the source model, and
+// maxUnits, if greater than zero, caps the number of units of each
+// application. If owner is empty, the new model is owned by the
+// current user.
+func (c *Client) CloneModel(
+	source names.ModelTag, name, owner string,
+	config map[string]interface{}, maxUnits int,
+) (base.ModelInfo, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 11 {
+		return base.ModelInfo{}, errors.NotImplementedf("CloneModel in version %v", bestVer)
+	}
+	var ownerTag string
+	if owner != "" {
+		if !names.IsValidUser(owner) {
+			return base.ModelInfo{}, errors.Errorf("invalid owner name %q", owner)
+		}
+		ownerTag = names.NewUserTag(owner).String()
+	}
+	args := params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: source.String(),
+			Name:           name,
+			OwnerTag:       ownerTag,
+			Config:         config,
+			MaxUnits:       maxUnits,
+		}},
+	}
+	var results params.ModelInfoResults
+	if err := c.facade.FacadeCall("CloneModels", args, &results); err != nil {
+		return base.ModelInfo{}, errors.Trace(err)
+	}
+	if count := len(results.Results); count != 1 {
+		return base.ModelInfo{}, errors.Errorf("unexpected result count: %d", count)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return base.ModelInfo{}, result.Error
+	}
+	if result.Result == nil {
+		return base.ModelInfo{}, errors.New("missing model info")
+	}
+	return convertParamsModelInfo(*result.Result)
+}
+
 // DestroyModel puts the specified model into a "dying" state, which will
 // cause the model's resources to be cleaned up, after which the model will
 // be removed.
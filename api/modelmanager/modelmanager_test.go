@@ -933,3 +933,152 @@ func (s *validateUpdateModelSuite) TestValidateModelUpgrade(c *gc.C) {
 	err := client.ValidateModelUpgrade(coretesting.ModelTag, true)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+type exportModelSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&exportModelSuite{})
+
+func (s *exportModelSuite) TestExportModelWithWrongAPIVersion(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, `ExportModel in version 9 not implemented`)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *exportModelSuite) TestExportModel(c *gc.C) {
+	expected := params.SerializedModel{
+		Bytes:  []byte("model-data"),
+		Charms: []string{"cs:ubuntu-1"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.9.0-ubuntu-amd64",
+			URI:     "/tools/2.9.0-ubuntu-amd64",
+		}},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "ExportModels")
+			c.Check(args, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+			})
+			res, ok := result.(*params.SerializedModelResults)
+			c.Assert(ok, jc.IsTrue)
+			res.Results = []params.SerializedModelResult{{Result: &expected}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	serialized, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(serialized, jc.DeepEquals, expected)
+}
+
+func (s *exportModelSuite) TestExportModelError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			res, ok := result.(*params.SerializedModelResults)
+			c.Assert(ok, jc.IsTrue)
+			res.Results = []params.SerializedModelResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type cloneModelSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&cloneModelSuite{})
+
+func (s *cloneModelSuite) TestCloneModelWithWrongAPIVersion(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.CloneModel(coretesting.ModelTag, "staging", "", nil, 0)
+	c.Assert(err, gc.ErrorMatches, `CloneModel in version 10 not implemented`)
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *cloneModelSuite) TestCloneModel(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelManager")
+			c.Check(request, gc.Equals, "CloneModels")
+			c.Check(args, jc.DeepEquals, params.CloneModelArgs{
+				Models: []params.CloneModelArg{{
+					SourceModelTag: coretesting.ModelTag.String(),
+					Name:           "staging",
+					OwnerTag:       "user-bob",
+					Config:         map[string]interface{}{"foo": "bar"},
+					MaxUnits:       1,
+				}},
+			})
+			res, ok := result.(*params.ModelInfoResults)
+			c.Assert(ok, jc.IsTrue)
+			res.Results = []params.ModelInfoResult{{
+				Result: &params.ModelInfo{
+					Name:     "staging",
+					UUID:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+					Type:     "iaas",
+					CloudTag: "cloud-aws",
+					OwnerTag: "user-bob",
+				},
+			}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	info, err := client.CloneModel(coretesting.ModelTag, "staging", "bob", map[string]interface{}{"foo": "bar"}, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Name, gc.Equals, "staging")
+	c.Check(info.UUID, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	c.Check(info.Cloud, gc.Equals, "aws")
+	c.Check(info.Owner, gc.Equals, "bob")
+}
+
+func (s *cloneModelSuite) TestCloneModelError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			res, ok := result.(*params.ModelInfoResults)
+			c.Assert(ok, jc.IsTrue)
+			res.Results = []params.ModelInfoResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}}
+			return nil
+		},
+	}
+
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.CloneModel(coretesting.ModelTag, "staging", "", nil, 0)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
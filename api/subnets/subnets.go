@@ -93,6 +93,26 @@ func (api *API) SubnetsByCIDR(cidrs []string) ([]params.SubnetsResult, error) {
 	return result.Results, nil
 }
 
+// ImportSubnets adds the input subnets to the model without consulting
+// the provider, returning a result for each. Subnets already known to the
+// model are not changed, and have an error result satisfying
+// params.IsCodeAlreadyExists.
+func (api *API) ImportSubnets(subnets []params.ImportSubnetParams) ([]params.ErrorResult, error) {
+	if api.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("importing subnets with this version of Juju")
+	}
+
+	args := params.ImportSubnetsParams{Subnets: subnets}
+	var result params.ErrorResults
+	if err := api.facade.FacadeCall("ImportSubnets", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(result.Results) != len(subnets) {
+		return nil, errors.Errorf("expected %d results, got %d", len(subnets), len(result.Results))
+	}
+	return result.Results, nil
+}
+
 func makeAddSubnetsParamsV2(cidr string, providerId network.Id, space names.SpaceTag, zones []string) params.AddSubnetsParamsV2 {
 	var subnetTag string
 	if cidr != "" {
@@ -246,3 +246,36 @@ func (s *SubnetsSuite) TestSubnetsByCIDRWithResults(c *gc.C) {
 		}},
 	}}, nil, "")
 }
+
+func (s *SubnetsSuite) TestImportSubnets(c *gc.C) {
+	subnetArgs := []params.ImportSubnetParams{
+		{CIDR: "10.0.0.0/24"},
+		{CIDR: "10.0.1.0/24", SpaceTag: "space-internal"},
+	}
+	expectResults := params.ErrorResults{
+		Results: []params.ErrorResult{{}, {Error: &params.Error{Code: params.CodeAlreadyExists}}},
+	}
+	apiCaller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:  "Subnets",
+		Method:  "ImportSubnets",
+		Args:    params.ImportSubnetsParams{Subnets: subnetArgs},
+		Results: expectResults,
+	})
+	api := subnets.NewAPI(&apitesting.BestVersionCaller{
+		BestVersion:   5,
+		APICallerFunc: apiCaller.APICallerFunc,
+	})
+
+	results, err := api.ImportSubnets(subnetArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, jc.DeepEquals, expectResults.Results)
+	c.Check(apiCaller.CallCount, gc.Equals, 1)
+}
+
+func (s *SubnetsSuite) TestImportSubnetsNotSupported(c *gc.C) {
+	s.prepareAPICall(c, apitesting.APICall{})
+
+	_, err := s.api.ImportSubnets([]params.ImportSubnetParams{{CIDR: "10.0.0.0/24"}})
+	c.Assert(err, gc.ErrorMatches, "importing subnets with this version of Juju not supported")
+	c.Check(s.apiCaller.CallCount, gc.Equals, 0)
+}
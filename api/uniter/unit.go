@@ -660,6 +660,58 @@ func (u *Unit) LogActionMessage(tag names.ActionTag, message string) error {
 	return result.OneError()
 }
 
+// SetContainerHealth records the health of the named workload container
+// of the unit, along with the names of any failing Pebble checks.
+func (u *Unit) SetContainerHealth(containerName string, health status.Status, info string, failingChecks []string) error {
+	if u.st.facade.BestAPIVersion() < 18 {
+		return errors.NotSupportedf("SetContainerHealth() (need V18+)")
+	}
+
+	var result params.ErrorResults
+	args := params.SetContainerHealthArgs{
+		Args: []params.SetContainerHealthArg{{
+			Tag:           u.tag.String(),
+			ContainerName: containerName,
+			Status:        health.String(),
+			Info:          info,
+			FailingChecks: failingChecks,
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetContainerHealth", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// FailingContainerChecks returns the Pebble checks last recorded as
+// failing for the named workload container of the unit.
+func (u *Unit) FailingContainerChecks(containerName string) ([]string, error) {
+	if u.st.facade.BestAPIVersion() < 18 {
+		return nil, errors.NotSupportedf("ContainerHealth() (need V18+)")
+	}
+
+	var results params.ContainerHealthResults
+	args := params.ContainerHealthArgs{
+		Args: []params.ContainerHealthArg{{
+			Tag:           u.tag.String(),
+			ContainerName: containerName,
+		}},
+	}
+	err := u.st.facade.FacadeCall("ContainerHealth", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.FailingChecks, nil
+}
+
 // UpgradeSeriesStatus returns the upgrade series status of a unit from remote state
 func (u *Unit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	res, err := u.st.UpgradeSeriesUnitStatus()
@@ -100,6 +100,84 @@ func (s *unitSuite) TestSetUnitStatus(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "biff")
 }
 
+func (s *unitSuite) TestSetContainerHealth(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "SetContainerHealth")
+		c.Assert(arg, gc.DeepEquals, params.SetContainerHealthArgs{
+			Args: []params.SetContainerHealthArg{{
+				Tag:           "unit-mysql-0",
+				ContainerName: "workload",
+				Status:        "error",
+				Info:          "failing checks: ready",
+				FailingChecks: []string{"ready"},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{&params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 18}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.SetContainerHealth("workload", status.Error, "failing checks: ready", []string{"ready"})
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *unitSuite) TestSetContainerHealthNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 17}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.SetContainerHealth("workload", status.Active, "", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *unitSuite) TestFailingContainerChecks(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "ContainerHealth")
+		c.Assert(arg, gc.DeepEquals, params.ContainerHealthArgs{
+			Args: []params.ContainerHealthArg{{
+				Tag:           "unit-mysql-0",
+				ContainerName: "workload",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ContainerHealthResults{})
+		*(result.(*params.ContainerHealthResults)) = params.ContainerHealthResults{
+			Results: []params.ContainerHealthResult{{FailingChecks: []string{"ready"}}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 18}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	checks, err := unit.FailingContainerChecks("workload")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, jc.DeepEquals, []string{"ready"})
+}
+
+func (s *unitSuite) TestFailingContainerChecksNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 17}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.FailingContainerChecks("workload")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *unitSuite) TestUnitStatus(c *gc.C) {
 	now := time.Now()
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
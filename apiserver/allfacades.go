@@ -77,6 +77,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasapplicationprovisioner"
+	"github.com/juju/juju/apiserver/facades/controller/caasautoscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/apiserver/facades/controller/caasmodeloperator"
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
@@ -140,6 +141,7 @@ func AllFacades() *facade.Registry {
 	reg("Annotations", 2, annotations.NewAPI)
 
 	reg("Application", 13, application.NewFacadeV13)
+	reg("Application", 14, application.NewFacadeV14) // Adds SetAutoscalePolicies and AutoscalePolicies.
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
@@ -159,7 +161,8 @@ func AllFacades() *facade.Registry {
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacadeV1)
 	reg("Client", 2, client.NewFacadeV2)
-	reg("Client", 3, client.NewFacade)
+	reg("Client", 3, client.NewFacadeV3)
+	reg("Client", 4, client.NewFacade) // Adds SetCanaryAgentVersion, ClearCanaryAgentVersion.
 	reg("Cloud", 1, cloud.NewFacadeV1)
 	reg("Cloud", 2, cloud.NewFacadeV2) // adds AddCloud, AddCredentials, CredentialContents, RemoveClouds
 	reg("Cloud", 3, cloud.NewFacadeV3) // changes signature of UpdateCredentials, adds ModifyCloudAccess
@@ -170,8 +173,10 @@ func AllFacades() *facade.Registry {
 
 	// CAAS related facades.
 	// Move these to the correct place above once the feature flag disappears.
-	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacadeLegacy)
-	reg("CAASFirewallerEmbedded", 1, caasfirewaller.NewStateFacadeEmbedded)
+	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacadeLegacyV1)
+	reg("CAASFirewaller", 2, caasfirewaller.NewStateFacadeLegacy) // Adds RelatedApplications.
+	reg("CAASFirewallerEmbedded", 1, caasfirewaller.NewStateFacadeEmbeddedV1)
+	reg("CAASFirewallerEmbedded", 2, caasfirewaller.NewStateFacadeEmbedded) // Adds RelatedApplications.
 	reg("CAASOperator", 1, caasoperator.NewStateFacade)
 	reg("CAASAdmission", 1, caasadmission.NewStateFacade)
 	reg("CAASAgent", 1, caasagent.NewStateFacade)
@@ -181,6 +186,7 @@ func AllFacades() *facade.Registry {
 	reg("CAASUnitProvisioner", 2, caasunitprovisioner.NewStateFacade)
 	reg("CAASApplication", 1, caasapplication.NewStateFacade)
 	reg("CAASApplicationProvisioner", 1, caasapplicationprovisioner.NewStateCAASApplicationProvisionerAPI)
+	reg("CAASAutoscaler", 1, caasautoscaler.NewStateFacade)
 
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
@@ -189,6 +195,10 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 7, controller.NewControllerAPIv7)
 	reg("Controller", 8, controller.NewControllerAPIv8)
 	reg("Controller", 9, controller.NewControllerAPIv9)
+	reg("Controller", 10, controller.NewControllerAPIv10) // Adds MigrationPrechecks.
+	reg("Controller", 11, controller.NewControllerAPIv11) // Adds RetryMigrations.
+	reg("Controller", 12, controller.NewControllerAPIv12) // Adds CheckUpgrade.
+	reg("Controller", 13, controller.NewControllerAPIv13) // Adds ControllerHealth.
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPIV1)
 	reg("CrossModelRelations", 2, crossmodelrelations.NewStateCrossModelRelationsAPI) // Adds WatchRelationChanges, removes WatchRelationUnits
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
@@ -205,7 +215,8 @@ func AllFacades() *facade.Registry {
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
 	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6)
 	reg("FirewallRules", 1, firewallrules.NewFacade)
-	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
+	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPIV2)
+	reg("HighAvailability", 3, highavailability.NewHighAvailabilityAPI) // Adds RemoveControllerNodes and ReplaceControllerNodes.
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
 	reg("ImageMetadata", 3, imagemetadata.NewAPI)
@@ -225,7 +236,8 @@ func AllFacades() *facade.Registry {
 	reg("LifeFlag", 1, lifeflag.NewExternalFacade)
 	reg("Logger", 1, loggerapi.NewLoggerAPI)
 	reg("LogForwarding", 1, logfwd.NewFacade)
-	reg("MachineActions", 1, machineactions.NewExternalFacade)
+	reg("MachineActions", 1, machineactions.NewExternalFacadeV1)
+	reg("MachineActions", 2, machineactions.NewExternalFacade) // Adds NetworkCheckTargets.
 
 	reg("MachineManager", 2, machinemanager.NewFacade)
 	reg("MachineManager", 3, machinemanager.NewFacade)   // Adds DestroyMachine and ForceDestroyMachine.
@@ -245,9 +257,11 @@ func AllFacades() *facade.Registry {
 	reg("MigrationFlag", 1, migrationflag.NewFacade)
 	reg("MigrationMaster", 1, migrationmaster.NewMigrationMasterFacadeV1)
 	reg("MigrationMaster", 2, migrationmaster.NewMigrationMasterFacadeV2)
-	reg("MigrationMaster", 3, migrationmaster.NewMigrationMasterFacade) // Adds MinionReportTimeout.
+	reg("MigrationMaster", 3, migrationmaster.NewMigrationMasterFacadeV3) // Adds MinionReportTimeout.
+	reg("MigrationMaster", 4, migrationmaster.NewMigrationMasterFacade)   // Adds SetProgress and TransferRetryTimeout.
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
-	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 1, migrationtarget.NewFacadeV1)
+	reg("MigrationTarget", 2, migrationtarget.NewFacade) // Adds PrecheckIssues.
 
 	reg("ModelConfig", 1, modelconfig.NewFacadeV1)
 	reg("ModelConfig", 2, modelconfig.NewFacadeV2)
@@ -258,11 +272,13 @@ func AllFacades() *facade.Registry {
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
-	reg("ModelManager", 5, modelmanager.NewFacadeV5) // Adds ChangeModelCredential
-	reg("ModelManager", 6, modelmanager.NewFacadeV6) // Adds cloud specific default config
-	reg("ModelManager", 7, modelmanager.NewFacadeV7) // DestroyModels gains 'force' and max-wait' parameters.
-	reg("ModelManager", 8, modelmanager.NewFacadeV8) // ModelInfo gains credential validity in return.
-	reg("ModelManager", 9, modelmanager.NewFacadeV9) // Adds ValidateModelUpgrade
+	reg("ModelManager", 5, modelmanager.NewFacadeV5)   // Adds ChangeModelCredential
+	reg("ModelManager", 6, modelmanager.NewFacadeV6)   // Adds cloud specific default config
+	reg("ModelManager", 7, modelmanager.NewFacadeV7)   // DestroyModels gains 'force' and max-wait' parameters.
+	reg("ModelManager", 8, modelmanager.NewFacadeV8)   // ModelInfo gains credential validity in return.
+	reg("ModelManager", 9, modelmanager.NewFacadeV9)   // Adds ValidateModelUpgrade
+	reg("ModelManager", 10, modelmanager.NewFacadeV10) // Adds ExportModels
+	reg("ModelManager", 11, modelmanager.NewFacadeV11) // Adds CloneModels
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
@@ -308,13 +324,15 @@ func AllFacades() *facade.Registry {
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
 	reg("Subnets", 2, subnets.NewAPIv2)
 	reg("Subnets", 3, subnets.NewAPIv3)
-	reg("Subnets", 4, subnets.NewAPI) // Adds SubnetsByCIDR; removes AllSpaces.
+	reg("Subnets", 4, subnets.NewAPIv4) // Adds SubnetsByCIDR; removes AllSpaces.
+	reg("Subnets", 5, subnets.NewAPI)   // Adds ImportSubnets.
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
 
 	// Deprecated: V16 of the uniter facade retained to allow upgrading from 2.8.9 (LTS).
 	reg("Uniter", 16, uniter.NewUniterAPIV16)
-	reg("Uniter", 17, uniter.NewUniterAPI)
+	reg("Uniter", 17, uniter.NewUniterAPIV17)
+	reg("Uniter", 18, uniter.NewUniterAPI)
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 
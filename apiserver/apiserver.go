@@ -276,6 +276,8 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		leaseManager:        cfg.LeaseManager,
 		controllerConfig:    controllerConfig,
 		logger:              loggo.GetLogger("juju.apiserver"),
+		dataDir:             cfg.DataDir,
+		agentTag:            cfg.Tag,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
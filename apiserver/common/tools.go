@@ -81,6 +81,18 @@ func NewToolsGetter(
 
 // Tools finds the tools necessary for the given agents.
 func (t *ToolsGetter) Tools(args params.Entities) (params.ToolsResults, error) {
+	agentVersion, err := t.getGlobalAgentVersion()
+	if err != nil {
+		return params.ToolsResults{
+			Results: make([]params.ToolsResult, len(args.Entities)),
+		}, err
+	}
+	return t.ToolsForVersion(args, agentVersion)
+}
+
+// ToolsForVersion finds the tools of the given version necessary for
+// the given agents.
+func (t *ToolsGetter) ToolsForVersion(args params.Entities, agentVersion version.Number) (params.ToolsResults, error) {
 	result := params.ToolsResults{
 		Results: make([]params.ToolsResult, len(args.Entities)),
 	}
@@ -88,10 +100,6 @@ func (t *ToolsGetter) Tools(args params.Entities) (params.ToolsResults, error) {
 	if err != nil {
 		return result, err
 	}
-	agentVersion, err := t.getGlobalAgentVersion()
-	if err != nil {
-		return result, err
-	}
 
 	for i, entity := range args.Entities {
 		tag, err := names.ParseTag(entity.Tag)
@@ -5,6 +5,7 @@ package facadetest
 
 import (
 	"github.com/juju/clock"
+	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/core/cache"
@@ -26,6 +27,8 @@ type Context struct {
 	MultiwatcherFactory_ multiwatcher.Factory
 	ID_                  string
 	Cancel_              <-chan struct{}
+	DataDir_             string
+	AgentTag_            names.Tag
 
 	LeadershipClaimer_ leadership.Claimer
 	LeadershipRevoker_ leadership.Revoker
@@ -58,6 +61,16 @@ func (context Context) Hub() facade.Hub {
 	return context.Hub_
 }
 
+// DataDir is part of the facade.Context interface.
+func (context Context) DataDir() string {
+	return context.DataDir_
+}
+
+// AgentTag is part of the facade.Context interface.
+func (context Context) AgentTag() names.Tag {
+	return context.AgentTag_
+}
+
 // Controller is part of the facade.Context interface.
 func (context Context) Controller() *cache.Controller {
 	return context.Controller_
@@ -95,6 +95,14 @@ type Context interface {
 	// At least at this stage, facades only need to publish events.
 	Hub() Hub
 
+	// DataDir returns the data directory of the controller agent
+	// running the API server.
+	DataDir() string
+
+	// AgentTag returns the tag of the controller agent running the
+	// API server.
+	AgentTag() names.Tag
+
 	// ID returns a string that should almost always be "", unless
 	// this is a watcher facade, in which case it exists in lieu of
 	// actual arguments in the Next() call, and is used as a key
@@ -211,4 +219,5 @@ type ModelPresence interface {
 // Hub represents the central hub that the API server has.
 type Hub interface {
 	Publish(topic string, data interface{}) (<-chan struct{}, error)
+	Subscribe(topic string, handler interface{}) (func(), error)
 }
@@ -21,6 +21,7 @@ type Backend interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 	TagToActionReceiverFn(findEntity func(names.Tag) (state.Entity, error)) func(string) (state.ActionReceiver, error)
 	ConvertActions(ar state.ActionReceiver, fn common.GetActionsFn) ([]params.ActionResult, error)
+	NetworkCheckTargets(machineID string) ([]params.NetworkCheckTarget, error)
 }
 
 // Facade implements the machineactions interface and is the concrete
@@ -31,6 +32,11 @@ type Facade struct {
 	accessMachine common.AuthFunc
 }
 
+// FacadeV1 implements the V1 machineactions API.
+type FacadeV1 struct {
+	*Facade
+}
+
 // NewFacade creates a new server-side machineactions API end point.
 func NewFacade(
 	backend Backend,
@@ -47,6 +53,19 @@ func NewFacade(
 	}, nil
 }
 
+// NewFacadeV1 creates a new server-side V1 machineactions API end point.
+func NewFacadeV1(
+	backend Backend,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*FacadeV1, error) {
+	f, err := NewFacade(backend, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &FacadeV1{f}, nil
+}
+
 // Actions returns the Actions by Tags passed and ensures that the machine asking
 // for them is the machine that has the actions
 func (f *Facade) Actions(args params.Entities) params.ActionResults {
@@ -109,3 +128,37 @@ func (f *Facade) RunningActions(args params.Entities) params.ActionsByReceivers
 
 	return response
 }
+
+// NetworkCheckTargets returns, for each of the input machines, the addresses
+// of units related to the units deployed on the machine.
+// The addresses are those in the spaces to which the related units'
+// endpoints are bound.
+func (f *Facade) NetworkCheckTargets(args params.Entities) params.NetworkCheckTargetsResults {
+	results := params.NetworkCheckTargetsResults{
+		Results: make([]params.NetworkCheckTargetsResult, len(args.Entities)),
+	}
+
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrBadId)
+			continue
+		}
+		if !f.accessMachine(tag) {
+			results.Results[i].Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+
+		targets, err := f.backend.NetworkCheckTargets(tag.Id())
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Targets = targets
+	}
+
+	return results
+}
+
+// NetworkCheckTargets isn't on the V1 API.
+func (*FacadeV1) NetworkCheckTargets(_, _ struct{}) {}
@@ -75,6 +75,52 @@ func (*FacadeSuite) TestRunningActions(c *gc.C) {
 	stub.CheckCallNames(c, "TagToActionReceiverFn", "ConvertActions", "ConvertActions")
 }
 
+func (*FacadeSuite) TestNetworkCheckTargets(c *gc.C) {
+	stub := &testing.Stub{}
+	backend := &mockBackend{
+		stub: stub,
+	}
+
+	facade, err := machineactions.NewFacade(backend, nil, agentAuth{machine: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := facade.NetworkCheckTargets(entities(
+		"machine-0",
+		"invalid",
+		"machine-1",
+	))
+
+	c.Assert(results, gc.DeepEquals, params.NetworkCheckTargetsResults{
+		Results: []params.NetworkCheckTargetsResult{{
+			Targets: networkCheckTargets,
+		}, {
+			Error: apiservererrors.ServerError(apiservererrors.ErrBadId),
+		}, {
+			Error: apiservererrors.ServerError(apiservererrors.ErrPerm),
+		}},
+	})
+	stub.CheckCalls(c, []testing.StubCall{{"NetworkCheckTargets", []interface{}{"0"}}})
+}
+
+func (*FacadeSuite) TestNetworkCheckTargetsError(c *gc.C) {
+	stub := &testing.Stub{}
+	backend := &mockBackend{
+		stub: stub,
+	}
+
+	facade, err := machineactions.NewFacade(backend, nil, agentAuth{machine: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.SetErrors(errors.New("boom"))
+	results := facade.NetworkCheckTargets(entities("machine-0"))
+
+	c.Assert(results, gc.DeepEquals, params.NetworkCheckTargetsResults{
+		Results: []params.NetworkCheckTargetsResult{{
+			Error: apiservererrors.ServerError(errors.New("boom")),
+		}},
+	})
+}
+
 // entities is a convenience constructor for params.Entities.
 func entities(tags ...string) params.Entities {
 	entities := params.Entities{
@@ -98,7 +144,8 @@ func (auth agentAuth) AuthMachineAgent() bool {
 }
 
 func (auth agentAuth) AuthOwner(tag names.Tag) bool {
-	if tag.String() == "valid" {
+	switch tag.String() {
+	case "valid", "machine-0":
 		return true
 	}
 	return false
@@ -158,3 +205,19 @@ func (mock *mockBackend) ConvertActions(ar state.ActionReceiver, fn common.GetAc
 }
 
 var actions = []params.ActionResult{{Action: &params.Action{Name: "foo"}}}
+
+func (mock *mockBackend) NetworkCheckTargets(machineID string) ([]params.NetworkCheckTarget, error) {
+	mock.stub.AddCall("NetworkCheckTargets", machineID)
+	if err := mock.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return networkCheckTargets, nil
+}
+
+var networkCheckTargets = []params.NetworkCheckTarget{{
+	Unit:        "mysql/0",
+	Endpoint:    "db",
+	RelatedUnit: "wordpress/0",
+	Space:       "alpha",
+	Address:     "10.0.0.2",
+}}
@@ -5,14 +5,21 @@
 package machineactions
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/state"
 )
 
+// NewExternalFacadeV1 is used for API registration.
+func NewExternalFacadeV1(st *state.State, res facade.Resources, auth facade.Authorizer) (*FacadeV1, error) {
+	return NewFacadeV1(backendShim{st}, res, auth)
+}
+
 // NewExternalFacade is used for API registration.
 func NewExternalFacade(st *state.State, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
 	return NewFacade(backendShim{st}, res, auth)
@@ -42,3 +49,132 @@ func (shim backendShim) TagToActionReceiverFn(findEntity func(names.Tag) (state.
 func (shim backendShim) ConvertActions(ar state.ActionReceiver, fn common.GetActionsFn) ([]params.ActionResult, error) {
 	return common.ConvertActions(ar, fn)
 }
+
+func (shim backendShim) NetworkCheckTargets(machineID string) ([]params.NetworkCheckTarget, error) {
+	machine, err := shim.st.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := machine.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spaceInfos, err := shim.st.AllSpaceInfos()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var targets []params.NetworkCheckTarget
+	for _, unit := range units {
+		relations, err := unit.RelationsJoined()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, rel := range relations {
+			relTargets, err := shim.relationNetworkCheckTargets(unit, rel, spaceInfos)
+			if err != nil {
+				return nil, errors.Annotatef(err, "determining targets for %q in relation %q", unit.Name(), rel)
+			}
+			targets = append(targets, relTargets...)
+		}
+	}
+	return targets, nil
+}
+
+// relationNetworkCheckTargets returns the addresses of the units at the
+// other end of the input relation, in the spaces to which their endpoints
+// are bound.
+func (shim backendShim) relationNetworkCheckTargets(
+	unit *state.Unit, rel *state.Relation, spaceInfos network.SpaceInfos,
+) ([]params.NetworkCheckTarget, error) {
+	ep, err := rel.Endpoint(unit.ApplicationName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relatedEps, err := rel.RelatedEndpoints(unit.ApplicationName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var targets []params.NetworkCheckTarget
+	for _, relatedEp := range relatedEps {
+		app, err := shim.st.Application(relatedEp.ApplicationName)
+		if errors.IsNotFound(err) {
+			// Cross-model relations have remote applications
+			// with no addresses that we can determine here.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		bindings, err := app.EndpointBindings()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		spaceID, ok := bindings.Map()[relatedEp.Name]
+		if !ok {
+			spaceID = network.AlphaSpaceId
+		}
+		spaceName := network.AlphaSpaceName
+		if space := spaceInfos.GetByID(spaceID); space != nil {
+			spaceName = string(space.Name)
+		}
+
+		relatedUnits, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, relatedUnit := range relatedUnits {
+			if relatedUnit.Name() == unit.Name() {
+				continue
+			}
+			addrs, err := shim.unitAddressesInSpace(relatedUnit, spaceID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, addr := range addrs {
+				targets = append(targets, params.NetworkCheckTarget{
+					Unit:        unit.Name(),
+					Endpoint:    ep.Name,
+					RelatedUnit: relatedUnit.Name(),
+					Space:       spaceName,
+					Address:     addr.Value,
+				})
+			}
+		}
+	}
+	return targets, nil
+}
+
+// unitAddressesInSpace returns the addresses of the input unit's machine
+// that are in the space with the input ID.
+// If the space is the alpha space and the machine has no addresses known to
+// be in it, the machine's preferred private address is returned.
+func (shim backendShim) unitAddressesInSpace(unit *state.Unit, spaceID string) (network.SpaceAddresses, error) {
+	machineID, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := shim.st.Machine(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	addrsBySpace, err := machine.AddressesBySpaceID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if addrs := addrsBySpace[spaceID]; len(addrs) > 0 || spaceID != network.AlphaSpaceId {
+		return addrs, nil
+	}
+
+	addr, err := machine.PrivateAddress()
+	if network.IsNoAddressError(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return network.SpaceAddresses{addr}, nil
+}
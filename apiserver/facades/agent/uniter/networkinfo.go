@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"net"
+	"sort"
 	"time"
 
 	"github.com/juju/clock"
@@ -51,6 +52,10 @@ type NetworkInfoBase struct {
 	app           *state.Application
 	defaultEgress []string
 	bindings      map[string]string
+
+	// preferIPv6 indicates whether IPv6 addresses should be sorted ahead of
+	// IPv4 addresses when populating ingress and egress addresses.
+	preferIPv6 bool
 }
 
 // NewNetworkInfo initialises and returns a new NetworkInfo
@@ -127,6 +132,7 @@ func NewNetworkInfoForStrategy(
 		app:           app,
 		bindings:      allBindings,
 		defaultEgress: cfg.EgressSubnets(),
+		preferIPv6:    cfg.PreferIPv6(),
 		retryFactory:  retryFactory,
 		lookupHost:    lookupHost,
 	}
@@ -327,6 +333,30 @@ func subnetsForAddresses(addrs []string) []string {
 	return nil
 }
 
+// sortAddresses sorts the input addresses in place, in order of preference
+// for use as ingress addresses. The model's IPv6 preference determines which
+// address family is chosen when addresses are otherwise of equal weight.
+func (n *NetworkInfoBase) sortAddresses(addrs network.SpaceAddresses) {
+	if n.preferIPv6 {
+		network.SortAddressesPreferringIPv6(addrs)
+		return
+	}
+	network.SortAddresses(addrs)
+}
+
+// sortInterfaceAddressesPreferringIPv6 reorders the addresses of each
+// device in the input so that IPv6 addresses precede all others.
+// The relative order of addresses within each family is preserved.
+func sortInterfaceAddressesPreferringIPv6(netInfos []params.NetworkInfo) {
+	for _, info := range netInfos {
+		addrs := info.Addresses
+		sort.SliceStable(addrs, func(i, j int) bool {
+			return network.DeriveAddressType(addrs[i].Address) == network.IPv6Address &&
+				network.DeriveAddressType(addrs[j].Address) != network.IPv6Address
+		})
+	}
+}
+
 func (n *NetworkInfoBase) pollForAddress(
 	fetcher func() (network.SpaceAddress, error),
 ) (network.SpaceAddress, error) {
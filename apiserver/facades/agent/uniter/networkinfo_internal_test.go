@@ -137,3 +137,42 @@ func (s *networkInfoSuite) TestUniqueNetworkInfoResults(c *gc.C) {
 	filteredRes := uniqueNetworkInfoResults(resWithDups)
 	c.Assert(filteredRes, gc.DeepEquals, expRes)
 }
+
+func (s *networkInfoSuite) TestSortInterfaceAddressesPreferringIPv6(c *gc.C) {
+	info := []params.NetworkInfo{
+		{
+			InterfaceName: "eth0",
+			Addresses: []params.InterfaceAddress{
+				{Address: "10.0.0.10", CIDR: "10.0.0.0/24"},
+				{Address: "2001:db8::10", CIDR: "2001:db8::/64"},
+				{Address: "10.0.0.11", CIDR: "10.0.0.0/24"},
+				{Address: "2001:db8::11", CIDR: "2001:db8::/64"},
+			},
+		},
+		{
+			InterfaceName: "eth1",
+			Addresses: []params.InterfaceAddress{
+				{Address: "192.168.0.10", CIDR: "192.168.0.0/24"},
+			},
+		},
+	}
+
+	sortInterfaceAddressesPreferringIPv6(info)
+	c.Assert(info, gc.DeepEquals, []params.NetworkInfo{
+		{
+			InterfaceName: "eth0",
+			Addresses: []params.InterfaceAddress{
+				{Address: "2001:db8::10", CIDR: "2001:db8::/64"},
+				{Address: "2001:db8::11", CIDR: "2001:db8::/64"},
+				{Address: "10.0.0.10", CIDR: "10.0.0.0/24"},
+				{Address: "10.0.0.11", CIDR: "10.0.0.0/24"},
+			},
+		},
+		{
+			InterfaceName: "eth1",
+			Addresses: []params.InterfaceAddress{
+				{Address: "192.168.0.10", CIDR: "192.168.0.0/24"},
+			},
+		},
+	})
+}
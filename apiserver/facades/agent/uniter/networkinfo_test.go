@@ -259,6 +259,64 @@ func (s *networkInfoSuite) TestAPIRequestForRelationCAASHostNameNoIngress(c *gc.
 	c.Check(ingress[0], gc.Equals, host)
 }
 
+func (s *networkInfoSuite) TestAPIRequestCAASSecondaryNetwork(c *gc.C) {
+	s.PatchValue(&provider.NewK8sClients, k8stesting.NoopFakeK8sClients)
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer func() { _ = st.Close() }()
+
+	space, err := st.AddSpace("storage", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.AddSubnet(network.SubnetInfo{
+		CIDR:       "192.168.1.0/24",
+		ProviderId: "storage",
+		SpaceID:    space.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "mysql", Series: "kubernetes"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{
+		Name:             "mysql",
+		Charm:            ch,
+		EndpointBindings: map[string]string{"server": "storage"},
+	})
+	u := f.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	podAddress := "10.1.1.5"
+	err = app.UpdateUnits(&state.UpdateUnitsOperation{
+		Updates: []*state.UpdateUnitOperation{u.UpdateOperation(state.UnitUpdateProperties{
+			Address:          &podAddress,
+			NetworkAddresses: &[]string{"192.168.1.10"},
+		})},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// We need to instantiate this with the new CAAS model state.
+	netInfo, err := uniter.NewNetworkInfoForStrategy(st, u.UnitTag(), nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := netInfo.ProcessAPIRequest(params.NetworkInfoParams{
+		Unit:      u.UnitTag().String(),
+		Endpoints: []string{"server", "server-admin"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	res := result.Results
+	c.Assert(res, gc.HasLen, 2)
+
+	// The endpoint bound to the space uses the secondary network.
+	c.Check(res["server"].Info, gc.DeepEquals, []params.NetworkInfo{{
+		Addresses: []params.InterfaceAddress{{Address: "192.168.1.10"}},
+	}})
+	c.Check(res["server"].IngressAddresses, gc.DeepEquals, []string{"192.168.1.10"})
+	c.Check(res["server"].EgressSubnets, gc.DeepEquals, []string{"192.168.1.10/32"})
+
+	// Other endpoints use the pod network.
+	c.Check(res["server-admin"].Info, gc.DeepEquals, []params.NetworkInfo{{
+		Addresses: []params.InterfaceAddress{{Address: "10.1.1.5"}},
+	}})
+}
+
 func (s *networkInfoSuite) TestNetworksForRelationWithSpaces(c *gc.C) {
 	_ = s.setupSpace(c, "space-1", "1.2.0.0/16")
 	_ = s.setupSpace(c, "space-2", "2.2.0.0/16")
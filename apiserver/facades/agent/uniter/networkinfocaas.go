@@ -19,6 +19,10 @@ type NetworkInfoCAAS struct {
 
 	// addresses contains all services and container addresses for the unit.
 	addresses network.SpaceAddresses
+
+	// networkAddresses contains the container addresses on secondary
+	// networks, keyed by the ID of the space that they are in.
+	networkAddresses map[string]network.SpaceAddresses
 }
 
 // newNetworkInfoCAAS returns a NetworkInfo implementation for a CAAS unit.
@@ -28,14 +32,74 @@ func newNetworkInfoCAAS(base *NetworkInfoBase) (*NetworkInfoCAAS, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	network.SortAddresses(addrs)
+	base.sortAddresses(addrs)
+
+	networkAddrs, err := spaceNetworkAddresses(base)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	return &NetworkInfoCAAS{
-		NetworkInfoBase: base,
-		addresses:       addrs,
+		NetworkInfoBase:  base,
+		addresses:        addrs,
+		networkAddresses: networkAddrs,
 	}, nil
 }
 
+// spaceNetworkAddresses returns the unit's secondary network addresses,
+// keyed by the ID of the space containing the subnet of each address.
+func spaceNetworkAddresses(base *NetworkInfoBase) (map[string]network.SpaceAddresses, error) {
+	addrs, err := base.unit.NetworkAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	spaceInfos, err := base.st.AllSpaceInfos()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make(map[string]network.SpaceAddresses)
+	for _, addr := range addrs {
+		space, err := spaceInfos.InferSpaceFromAddress(addr.Value)
+		if errors.IsNotFound(err) {
+			logger.Debugf("ignoring address %q of unit %q: %v", addr.Value, base.unit.Name(), err)
+			continue
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		addr.SpaceID = space.ID
+		result[space.ID] = append(result[space.ID], addr)
+	}
+	for _, spaceAddrs := range result {
+		base.sortAddresses(spaceAddrs)
+	}
+	return result, nil
+}
+
+// boundNetworkAddresses returns the unit's secondary network addresses
+// in the space that the input endpoint is bound to.
+// Nothing is returned for endpoints bound to the default space, which
+// is served by the pod network.
+func (n *NetworkInfoCAAS) boundNetworkAddresses(endpoint string) network.SpaceAddresses {
+	spaceID, ok := n.bindings[endpoint]
+	if !ok || spaceID == network.AlphaSpaceId {
+		return nil
+	}
+	return n.networkAddresses[spaceID]
+}
+
+func interfaceAddresses(addrs network.SpaceAddresses) []params.InterfaceAddress {
+	result := make([]params.InterfaceAddress, len(addrs))
+	for i, a := range addrs {
+		result[i] = params.InterfaceAddress{Address: a.Value}
+	}
+	return result
+}
+
 // ProcessAPIRequest handles a request to the uniter API NetworkInfo method.
 func (n *NetworkInfoCAAS) ProcessAPIRequest(args params.NetworkInfoParams) (params.NetworkInfoResults, error) {
 	validEndpoints, result := n.validateEndpoints(args.Endpoints)
@@ -68,8 +132,12 @@ func (n *NetworkInfoCAAS) ProcessAPIRequest(args params.NetworkInfoParams) (para
 			return params.NetworkInfoResults{}, err
 		}
 
+		addrs := interfaceAddr
+		if boundAddrs := n.boundNetworkAddresses(endpoint); len(boundAddrs) > 0 {
+			addrs = interfaceAddresses(boundAddrs)
+		}
 		result.Results[endpoint] = params.NetworkInfoResult{
-			Info:             []params.NetworkInfo{{Addresses: interfaceAddr}},
+			Info:             []params.NetworkInfo{{Addresses: addrs}},
 			EgressSubnets:    egress,
 			IngressAddresses: ingress.Values(),
 		}
@@ -85,6 +153,15 @@ func (n *NetworkInfoCAAS) ProcessAPIRequest(args params.NetworkInfoParams) (para
 				IngressAddresses: defaultIngressAddresses,
 				EgressSubnets:    defaultEgress,
 			}
+			// Endpoints bound to a space backed by a secondary
+			// network use the addresses on that network.
+			if boundAddrs := n.boundNetworkAddresses(endpoint); len(boundAddrs) > 0 {
+				info = params.NetworkInfoResult{
+					Info:             []params.NetworkInfo{{Addresses: interfaceAddresses(boundAddrs)}},
+					IngressAddresses: boundAddrs.Values(),
+					EgressSubnets:    subnetsForAddresses(boundAddrs.Values()),
+				}
+			}
 		}
 
 		// We only resolve the `Info` member addresses for CAAS.
@@ -145,6 +222,12 @@ func (n *NetworkInfoCAAS) NetworksForRelation(
 		}
 	}
 
+	spaceID := network.AlphaSpaceId
+	if boundAddrs := n.boundNetworkAddresses(endpoint); len(ingress) == 0 && len(boundAddrs) > 0 {
+		spaceID = n.bindings[endpoint]
+		ingress = append(ingress, boundAddrs...)
+	}
+
 	if len(ingress) == 0 {
 		for _, addr := range n.addresses {
 			if addr.Scope != network.ScopeMachineLocal {
@@ -153,12 +236,12 @@ func (n *NetworkInfoCAAS) NetworksForRelation(
 		}
 	}
 
-	network.SortAddresses(ingress)
+	n.sortAddresses(ingress)
 
 	egress, err := n.getEgressForRelation(rel, ingress)
 	if err != nil {
 		return "", nil, nil, errors.Trace(err)
 	}
 
-	return network.AlphaSpaceId, ingress, egress, nil
+	return spaceID, ingress, egress, nil
 }
@@ -82,7 +82,7 @@ func (n *NetworkInfoIAAS) ProcessAPIRequest(args params.NetworkInfoParams) (para
 
 		if len(info.IngressAddresses) == 0 {
 			ingress := spaceAddressesFromNetworkInfo(n.machineNetworkInfos[space].Info)
-			network.SortAddresses(ingress)
+			n.sortAddresses(ingress)
 			info.IngressAddresses = ingress.Values()
 		}
 
@@ -143,7 +143,7 @@ func (n *NetworkInfoIAAS) NetworksForRelation(
 		ingress = spaceAddressesFromNetworkInfo(n.machineNetworkInfos[boundSpace].Info)
 	}
 
-	network.SortAddresses(ingress)
+	n.sortAddresses(ingress)
 
 	egress, err := n.getEgressForRelation(rel, ingress)
 	if err != nil {
@@ -297,6 +297,14 @@ func (n *NetworkInfoIAAS) populateMachineNetworkInfos() error {
 		}
 	}
 
+	// Ensure that the bind address for each space reflects
+	// the model's preferred address family.
+	if n.preferIPv6 {
+		for _, r := range results {
+			sortInterfaceAddressesPreferringIPv6(r.Info)
+		}
+	}
+
 	n.machineNetworkInfos = results
 	return nil
 }
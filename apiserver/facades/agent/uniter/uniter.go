@@ -42,9 +42,8 @@ var logger = loggo.GetLogger("juju.apiserver.uniter")
 // TODO (manadart 2020-10-21): Remove the ModelUUID method
 // from the next version of this facade.
 
-// UniterAPI implements the latest version (v17) of the Uniter API, which
-// augments the payload of the CommitHookChanges API call and introduces
-// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+// UniterAPI implements the latest version (v18) of the Uniter API, which
+// adds the SetContainerHealth call.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -83,9 +82,16 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV17 implements version (v17) of the Uniter API, which
+// augments the payload of the CommitHookChanges API call and introduces
+// the OpenedMachinePortRanges call as a replacement for AllMachinePorts.
+type UniterAPIV17 struct {
+	UniterAPI
+}
+
 // UniterAPIV16 implements version (v16) of the Uniter API.
 type UniterAPIV16 struct {
-	UniterAPI
+	UniterAPIV17
 }
 
 // NewUniterAPI creates a new instance of the core Uniter API.
@@ -181,11 +187,22 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 // NewUniterAPIV16 creates an instance of the V16 uniter API.
 // Deprecated: V16 of the uniter facade retained to allow upgrading from 2.8.9 (LTS).
 func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV17(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV16{
+		UniterAPIV17: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV17 creates an instance of the V17 uniter API.
+func NewUniterAPIV17(context facade.Context) (*UniterAPIV17, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV17{
 		UniterAPI: *uniterAPI,
 	}, nil
 }
@@ -843,6 +860,103 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 	return result, nil
 }
 
+// SetContainerHealth did not exist prior to v18.
+func (*UniterAPIV17) SetContainerHealth(_, _ struct{}) {}
+
+// ContainerHealth did not exist prior to v18.
+func (*UniterAPIV17) ContainerHealth(_, _ struct{}) {}
+
+// SetContainerHealth records the health of the given workload containers,
+// as reported by their Pebble checks and services.
+func (u *UniterAPI) SetContainerHealth(args params.SetContainerHealthArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		now := u.clock.Now()
+		health := status.StatusInfo{
+			Status:  status.Status(arg.Status),
+			Message: arg.Info,
+			Since:   &now,
+		}
+		if len(arg.FailingChecks) > 0 {
+			health.Data = map[string]interface{}{"failing-checks": arg.FailingChecks}
+		}
+		err = unit.SetContainerHealth(arg.ContainerName, health)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// ContainerHealth returns the Pebble checks last recorded as failing
+// for the given workload containers, so that the unit agent can tell
+// after a restart which checks it has already run hooks for.
+func (u *UniterAPI) ContainerHealth(args params.ContainerHealthArgs) (params.ContainerHealthResults, error) {
+	result := params.ContainerHealthResults{
+		Results: make([]params.ContainerHealthResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ContainerHealthResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = apiservererrors.ServerError(apiservererrors.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		health, err := unit.ContainerHealth(arg.ContainerName)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			resultItem.Error = apiservererrors.ServerError(err)
+			continue
+		}
+		switch checks := health.Data["failing-checks"].(type) {
+		case []string:
+			resultItem.FailingChecks = checks
+		case []interface{}:
+			for _, check := range checks {
+				if name, ok := check.(string); ok {
+					resultItem.FailingChecks = append(resultItem.FailingChecks, name)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -1003,6 +1003,57 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestSetContainerHealth(c *gc.C) {
+	args := params.SetContainerHealthArgs{Args: []params.SetContainerHealthArg{
+		{Tag: "unit-mysql-0", ContainerName: "workload", Status: "active"},
+		{Tag: "unit-wordpress-0", ContainerName: "workload", Status: "error",
+			Info: "failing checks: ready", FailingChecks: []string{"ready"}},
+		{Tag: "unit-wordpress-0", ContainerName: "workload", Status: "waiting"},
+		{Tag: "unit-foo-42", ContainerName: "workload", Status: "active"},
+	}}
+	result, err := s.uniter.SetContainerHealth(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{&params.Error{Message: `container health "waiting" not valid`}},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	health, err := s.wordpressUnit.ContainerHealth("workload")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health.Status, gc.Equals, status.Error)
+	c.Assert(health.Message, gc.Equals, "failing checks: ready")
+	c.Assert(health.Data["failing-checks"], jc.DeepEquals, []interface{}{"ready"})
+}
+
+func (s *uniterSuite) TestContainerHealth(c *gc.C) {
+	err := s.wordpressUnit.SetContainerHealth("workload", status.StatusInfo{
+		Status: status.Error,
+		Data:   map[string]interface{}{"failing-checks": []string{"live", "ready"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ContainerHealthArgs{Args: []params.ContainerHealthArg{
+		{Tag: "unit-mysql-0", ContainerName: "workload"},
+		{Tag: "unit-wordpress-0", ContainerName: "workload"},
+		{Tag: "unit-wordpress-0", ContainerName: "other"},
+		{Tag: "unit-foo-42", ContainerName: "workload"},
+	}}
+	result, err := s.uniter.ContainerHealth(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ContainerHealthResults{
+		Results: []params.ContainerHealthResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{FailingChecks: []string{"live", "ready"}},
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
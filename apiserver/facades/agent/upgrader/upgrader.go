@@ -109,7 +109,12 @@ func (u *UpgraderAPI) WatchAPIVersion(args params.Entities) (params.NotifyWatchR
 		}
 		err = apiservererrors.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
-			watch := u.m.WatchForModelConfigChanges()
+			// The desired version changes with the model's agent
+			// version, and with any canary upgrade of its machines.
+			watch := common.NewMultiNotifyWatcher(
+				u.m.WatchForModelConfigChanges(),
+				u.st.WatchAgentUpgradeCanary(),
+			)
 			// Consume the initial event. Technically, API
 			// calls to Watch 'transmit' the initial event
 			// in the Watch response. But NotifyWatchers
@@ -161,12 +166,14 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 	if len(args.Entities) == 0 {
 		return params.VersionResults{}, nil
 	}
-	agentVersion, _, err := u.getGlobalAgentVersion()
+	globalVersion, _, err := u.getGlobalAgentVersion()
+	if err != nil {
+		return params.VersionResults{}, apiservererrors.ServerError(err)
+	}
+	canary, err := u.agentUpgradeCanary()
 	if err != nil {
 		return params.VersionResults{}, apiservererrors.ServerError(err)
 	}
-	// Is the desired version greater than the current API server version?
-	isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
 	for i, entity := range args.Entities {
 		tag, err := names.ParseTag(entity.Tag)
 		if err != nil {
@@ -175,6 +182,12 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 		}
 		err = apiservererrors.ErrPerm
 		if u.authorizer.AuthOwner(tag) {
+			agentVersion := globalVersion
+			if isCanary(canary, tag, globalVersion) {
+				agentVersion = canary.TargetVersion()
+			}
+			// Is the desired version greater than the current API server version?
+			isNewerVersion := agentVersion.Compare(jujuversion.Current) > 0
 			// Only return the globally desired agent version if the
 			// asking entity is a machine agent with JobManageModel or
 			// if this API server is running the globally desired agent
@@ -197,3 +210,56 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 	}
 	return params.VersionResults{Results: results}, nil
 }
+
+// Tools finds the tools necessary for the given agents. The canary
+// machines of a canary upgrade get the tools of its target version.
+func (u *UpgraderAPI) Tools(args params.Entities) (params.ToolsResults, error) {
+	result, err := u.ToolsGetter.Tools(args)
+	if err != nil {
+		return result, err
+	}
+	canary, err := u.agentUpgradeCanary()
+	if err != nil || canary == nil {
+		return result, errors.Trace(err)
+	}
+	globalVersion, _, err := u.getGlobalAgentVersion()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil || !isCanary(canary, tag, globalVersion) {
+			continue
+		}
+		canaryResult, err := u.ToolsGetter.ToolsForVersion(
+			params.Entities{Entities: []params.Entity{entity}}, canary.TargetVersion(),
+		)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.Results[i] = canaryResult.Results[0]
+	}
+	return result, nil
+}
+
+// agentUpgradeCanary returns the model's agent upgrade canary, or nil
+// if no canary upgrade is in progress.
+func (u *UpgraderAPI) agentUpgradeCanary() (*state.AgentUpgradeCanary, error) {
+	canary, err := u.st.AgentUpgradeCanary()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	return canary, errors.Trace(err)
+}
+
+// isCanary returns whether the agent with the given tag is one of the
+// canary machines of the canary upgrade. Once the model has been
+// upgraded to the canary's target version the canary no longer
+// matters.
+func isCanary(canary *state.AgentUpgradeCanary, tag names.Tag, globalVersion version.Number) bool {
+	if canary == nil || canary.TargetVersion().Compare(globalVersion) <= 0 {
+		return false
+	}
+	machineTag, ok := tag.(names.MachineTag)
+	return ok && canary.IncludesMachine(machineTag.Id())
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 	jujuversion "github.com/juju/juju/version"
 )
 
@@ -384,3 +385,88 @@ func (s *upgraderSuite) TestDesiredVersionRestrictedForNonAPIAgents(c *gc.C) {
 	c.Assert(agentVersion, gc.NotNil)
 	c.Check(*agentVersion, gc.DeepEquals, jujuversion.Current)
 }
+
+type canaryUpgraderSuite struct {
+	jujutesting.JujuConnSuite
+
+	st        *state.State
+	canary    *state.Machine
+	other     *state.Machine
+	resources *common.Resources
+	older     version.Number
+}
+
+var _ = gc.Suite(&canaryUpgraderSuite{})
+
+func (s *canaryUpgraderSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+
+	s.st = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { _ = s.st.Close() })
+	f := factory.NewFactory(s.st, s.StatePool)
+	s.canary = f.MakeMachine(c, nil)
+	s.other = f.MakeMachine(c, nil)
+
+	s.older = jujuversion.Current
+	s.older.Minor--
+	err := s.st.SetModelAgentVersion(s.older, true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *canaryUpgraderSuite) upgraderFor(c *gc.C, m *state.Machine) *upgrader.UpgraderAPI {
+	api, err := upgrader.NewUpgraderAPI(s.st, s.resources, apiservertesting.FakeAuthorizer{Tag: m.Tag()})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *canaryUpgraderSuite) desiredVersion(c *gc.C, m *state.Machine) version.Number {
+	results, err := s.upgraderFor(c, m).DesiredVersion(params.Entities{
+		Entities: []params.Entity{{Tag: m.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	return *results.Results[0].Version
+}
+
+func (s *canaryUpgraderSuite) TestDesiredVersionCanary(c *gc.C) {
+	err := s.st.SetAgentUpgradeCanary(jujuversion.Current, []string{s.canary.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.desiredVersion(c, s.canary), gc.Equals, jujuversion.Current)
+	c.Check(s.desiredVersion(c, s.other), gc.Equals, s.older)
+
+	// Removing the canary rolls the canary machine back.
+	err = s.st.RemoveAgentUpgradeCanary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.desiredVersion(c, s.canary), gc.Equals, s.older)
+}
+
+func (s *canaryUpgraderSuite) TestDesiredVersionCanaryAfterModelUpgrade(c *gc.C) {
+	err := s.st.SetAgentUpgradeCanary(jujuversion.Current, []string{s.canary.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.st.SetModelAgentVersion(jujuversion.Current, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.desiredVersion(c, s.canary), gc.Equals, jujuversion.Current)
+	c.Check(s.desiredVersion(c, s.other), gc.Equals, jujuversion.Current)
+}
+
+func (s *canaryUpgraderSuite) TestWatchAPIVersionCanary(c *gc.C) {
+	results, err := s.upgraderFor(c, s.canary).WatchAPIVersion(params.Entities{
+		Entities: []params.Entity{{Tag: s.canary.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	w := s.resources.Get(results.Results[0].NotifyWatcherId).(state.NotifyWatcher)
+	wc := statetesting.NewNotifyWatcherC(c, s.st, w)
+	wc.AssertNoChange()
+
+	err = s.st.SetAgentUpgradeCanary(jujuversion.Current, []string{s.canary.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	statetesting.AssertStop(c, w)
+	wc.AssertClosed()
+}
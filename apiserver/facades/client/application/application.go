@@ -58,9 +58,14 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.application")
 
+// APIv14 provides the Application API facade for version 14.
+type APIv14 struct {
+	*APIBase
+}
+
 // APIv13 provides the Application API facade for version 13.
 type APIv13 struct {
-	*APIBase
+	*APIv14
 }
 
 // APIBase implements the shared application interface and is the concrete
@@ -93,11 +98,19 @@ type APIBase struct {
 	deployApplicationFunc func(ApplicationDeployer, DeployApplicationParams) (Application, error)
 }
 
-func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
 	api, err := newFacadeBase(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	return &APIv14{api}, nil
+}
+
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return &APIv13{api}, nil
 }
 
@@ -682,6 +695,12 @@ func parseCharmSettings(modelType state.ModelType, ch Charm, appName string, con
 	if err != nil {
 		return nil, nil, nil, errors.Trace(err)
 	}
+	if err := validateContainerResources(ch, appConfig.Attributes()); err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	if err := validateUpdateSettings(appConfig.Attributes()); err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
 
 	charmSettings := make(charm.Settings)
 	if len(charmYamlConfig) > 0 {
@@ -712,6 +731,48 @@ func parseCharmSettings(modelType state.ModelType, ch Charm, appName string, con
 	return appConfig, appCfgSchema, charmSettings, nil
 }
 
+// validateContainerResources checks that any container resources in the
+// application config are valid, and are for containers of the charm.
+func validateContainerResources(ch Charm, appConfig application.ConfigAttributes) error {
+	resources, err := caas.ParseContainerResources(appConfig.GetString(k8sconstants.ContainerResourcesConfigKey, ""))
+	if err != nil {
+		return errors.Annotatef(err, "invalid %s", k8sconstants.ContainerResourcesConfigKey)
+	}
+	for name := range resources {
+		if name == caas.CharmContainerName {
+			continue
+		}
+		if _, ok := ch.Meta().Containers[name]; !ok {
+			return errors.NotValidf("resources for container %q not defined by charm %q", name, ch.Meta().Name)
+		}
+	}
+	return nil
+}
+
+// validateUpdateSettings checks that any update strategy and disruption
+// budget in the application config are valid.
+func validateUpdateSettings(appConfig application.ConfigAttributes) error {
+	strategy := caas.UpdateStrategy{
+		MaxUnavailable: appConfig.GetString(k8sconstants.UpdateMaxUnavailableConfigKey, ""),
+		MaxSurge:       appConfig.GetString(k8sconstants.UpdateMaxSurgeConfigKey, ""),
+	}
+	if _, ok := appConfig[k8sconstants.UpdatePartitionConfigKey]; ok {
+		partition := int32(appConfig.GetInt(k8sconstants.UpdatePartitionConfigKey, 0))
+		strategy.Partition = &partition
+	}
+	if err := strategy.Validate(); err != nil {
+		return errors.Annotate(err, "invalid update strategy")
+	}
+	budget := caas.DisruptionBudget{
+		MinAvailable:   appConfig.GetString(k8sconstants.DisruptionBudgetMinAvailableConfigKey, ""),
+		MaxUnavailable: appConfig.GetString(k8sconstants.DisruptionBudgetMaxUnavailableConfigKey, ""),
+	}
+	if budget == (caas.DisruptionBudget{}) {
+		return nil
+	}
+	return errors.Trace(budget.Validate())
+}
+
 // checkMachinePlacement does a non-exhaustive validation of any supplied
 // placement directives.
 // If the placement scope is for a machine, ensure that the machine exists.
@@ -1688,17 +1749,13 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
-		ch, _, err := app.Charm()
-		if err != nil {
+		if err := checkCanScale(app); err != nil {
 			return nil, errors.Trace(err)
 		}
-		if ch.Meta().Deployment != nil {
-			if ch.Meta().Deployment.DeploymentMode == charm.ModeOperator {
-				return nil, errors.NotSupportedf("scale an %q application", charm.ModeOperator)
-			}
-			if ch.Meta().Deployment.DeploymentType == charm.DeploymentDaemon {
-				return nil, errors.NotSupportedf("scale a %q application", charm.DeploymentDaemon)
-			}
+		// A manual change would be undone by the autoscaler.
+		if app.AutoscalePolicy() != nil {
+			return nil, errors.Errorf(
+				"application %q is autoscaled, stop autoscaling it with autoscale-application --reset before scaling it", name)
 		}
 
 		var info params.ScaleApplicationInfo
@@ -1730,6 +1787,117 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 	}, nil
 }
 
+// checkCanScale returns an error if the application's charm can't be
+// scaled.
+func checkCanScale(app Application) error {
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ch.Meta().Deployment != nil {
+		if ch.Meta().Deployment.DeploymentMode == charm.ModeOperator {
+			return errors.NotSupportedf("scale an %q application", charm.ModeOperator)
+		}
+		if ch.Meta().Deployment.DeploymentType == charm.DeploymentDaemon {
+			return errors.NotSupportedf("scale a %q application", charm.DeploymentDaemon)
+		}
+	}
+	return nil
+}
+
+// SetAutoscalePolicies sets the policies used to scale the specified
+// applications automatically. A nil policy stops an application being
+// autoscaled, leaving it at its current scale.
+func (api *APIBase) SetAutoscalePolicies(args params.SetAutoscalePoliciesArgs) (params.ErrorResults, error) {
+	if api.modelType != state.ModelTypeCAAS {
+		return params.ErrorResults{}, errors.NotSupportedf("autoscaling applications on a non-container model")
+	}
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	setPolicy := func(arg params.SetAutoscalePolicyArg) error {
+		appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		name := appTag.Id()
+		app, err := api.backend.Application(name)
+		if errors.IsNotFound(err) {
+			return errors.Errorf("application %q does not exist", name)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if arg.Policy == nil {
+			return app.SetAutoscalePolicy(nil)
+		}
+		if err := checkCanScale(app); err != nil {
+			return errors.Trace(err)
+		}
+		return app.SetAutoscalePolicy(&application.AutoscalePolicy{
+			MinUnits:     arg.Policy.MinUnits,
+			MaxUnits:     arg.Policy.MaxUnits,
+			TargetCPU:    arg.Policy.TargetCPU,
+			TargetMemory: arg.Policy.TargetMemory,
+			Metric:       arg.Policy.Metric,
+			TargetMetric: arg.Policy.TargetMetric,
+		})
+	}
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		results[i].Error = apiservererrors.ServerError(setPolicy(arg))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// AutoscalePolicies returns the autoscaling policies of the specified
+// applications. Applications which aren't autoscaled have no policy.
+func (api *APIBase) AutoscalePolicies(args params.Entities) (params.AutoscalePolicyResults, error) {
+	if api.modelType != state.ModelTypeCAAS {
+		return params.AutoscalePolicyResults{}, errors.NotSupportedf("autoscaling applications on a non-container model")
+	}
+	if err := api.checkCanRead(); err != nil {
+		return params.AutoscalePolicyResults{}, errors.Trace(err)
+	}
+	getPolicy := func(entity params.Entity) (*params.AutoscalePolicy, error) {
+		appTag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := api.backend.Application(appTag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		policy := app.AutoscalePolicy()
+		if policy == nil {
+			return nil, nil
+		}
+		return &params.AutoscalePolicy{
+			MinUnits:     policy.MinUnits,
+			MaxUnits:     policy.MaxUnits,
+			TargetCPU:    policy.TargetCPU,
+			TargetMemory: policy.TargetMemory,
+			Metric:       policy.Metric,
+			TargetMetric: policy.TargetMetric,
+		}, nil
+	}
+	results := make([]params.AutoscalePolicyResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		policy, err := getPolicy(entity)
+		results[i].Policy = policy
+		results[i].Error = apiservererrors.ServerError(err)
+	}
+	return params.AutoscalePolicyResults{Results: results}, nil
+}
+
+// SetAutoscalePolicies isn't on the v13 API.
+func (api *APIv13) SetAutoscalePolicies(_, _ struct{}) {}
+
+// AutoscalePolicies isn't on the v13 API.
+func (api *APIv13) AutoscalePolicies(_, _ struct{}) {}
+
 // GetConstraints returns the constraints for a given application.
 func (api *APIBase) GetConstraints(args params.Entities) (params.ApplicationGetConstraintsResults, error) {
 	if err := api.checkCanRead(); err != nil {
@@ -2334,6 +2502,12 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 			continue
 		}
 
+		containerResources, err := applicationContainerResources(app)
+		if err != nil {
+			out[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+
 		var channel string
 		origin := app.CharmOrigin()
 		if origin != nil && origin.Channel != nil {
@@ -2344,16 +2518,17 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 		}
 
 		out[i].Result = &params.ApplicationResult{
-			Tag:              tag.String(),
-			Charm:            details.Charm,
-			Series:           details.Series,
-			Channel:          channel,
-			Constraints:      details.Constraints,
-			Principal:        app.IsPrincipal(),
-			Exposed:          app.IsExposed(),
-			Remote:           app.IsRemote(),
-			EndpointBindings: bindingsMap,
-			ExposedEndpoints: exposedEndpoints,
+			Tag:                tag.String(),
+			Charm:              details.Charm,
+			Series:             details.Series,
+			Channel:            channel,
+			Constraints:        details.Constraints,
+			Principal:          app.IsPrincipal(),
+			Exposed:            app.IsExposed(),
+			Remote:             app.IsRemote(),
+			EndpointBindings:   bindingsMap,
+			ExposedEndpoints:   exposedEndpoints,
+			ContainerResources: containerResources,
 		}
 	}
 	return params.ApplicationInfoResults{
@@ -2361,6 +2536,27 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 	}, nil
 }
 
+// applicationContainerResources returns the resource requests and
+// limits of the application's containers, if any are set.
+func applicationContainerResources(app Application) (map[string]params.CAASContainerResources, error) {
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resources, err := caas.ParseContainerResources(appConfig.GetString(k8sconstants.ContainerResourcesConfigKey, ""))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(resources) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]params.CAASContainerResources, len(resources))
+	for name, res := range resources {
+		result[name] = params.CAASContainerResources(res)
+	}
+	return result, nil
+}
+
 func (api *APIBase) mapExposedEndpointsFromState(exposedEndpoints map[string]state.ExposedEndpoint) (map[string]params.ExposedEndpoint, error) {
 	if len(exposedEndpoints) == 0 {
 		return nil, nil
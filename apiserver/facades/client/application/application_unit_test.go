@@ -61,7 +61,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv13
+	api          *application.APIv14
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -93,7 +93,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv13{api}
+	s.api = &application.APIv14{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -433,6 +433,80 @@ func (s *ApplicationSuite) TestSetCAASConfigSettings(c *gc.C) {
 	pgApp.CheckCall(c, 3, "UpdateApplicationConfig", appCfg.Attributes(), []string(nil), appCfgSchema, schema.Defaults(nil))
 }
 
+func (s *ApplicationSuite) TestSetCAASConfigContainerResources(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+	pgApp := s.backend.applications["postgresql"]
+	pgApp.charm.meta = &charm.Meta{
+		Name:       "charm-postgresql",
+		Containers: map[string]charm.Container{"postgresql": {}},
+	}
+
+	args := params.ConfigSetArgs{Args: []params.ConfigSet{{
+		ApplicationName: "postgresql",
+		Config: map[string]string{
+			"kubernetes-container-resources": "charm.mem-request=256M postgresql.mem-limit=1G",
+		},
+	}, {
+		ApplicationName: "postgresql",
+		Config: map[string]string{
+			"kubernetes-container-resources": "pgbouncer.mem-limit=1G",
+		},
+	}, {
+		ApplicationName: "postgresql",
+		Config: map[string]string{
+			"kubernetes-container-resources": "postgresql.mem-request=2G postgresql.mem-limit=1G",
+		},
+	}}}
+	results, err := s.api.SetConfigs(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches,
+		`parsing settings for application: resources for container "pgbouncer" not defined by charm "charm-postgresql" not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches,
+		`parsing settings for application: invalid kubernetes-container-resources: container "postgresql": mem request 2048 greater than limit 1024 not valid`)
+}
+
+func (s *ApplicationSuite) TestSetCAASConfigUpdateSettings(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+
+	args := params.ConfigSetArgs{Args: []params.ConfigSet{{
+		ApplicationName: "postgresql",
+		Config: map[string]string{
+			"kubernetes-update-max-surge":                "25%",
+			"kubernetes-disruption-budget-min-available": "1",
+		},
+	}, {
+		ApplicationName: "postgresql",
+		Config: map[string]string{
+			"kubernetes-update-max-unavailable": "some",
+		},
+	}, {
+		ApplicationName: "postgresql",
+		Config: map[string]string{
+			"kubernetes-disruption-budget-max-unavailable": "1x",
+		},
+	}, {
+		ApplicationName: "postgresql",
+		Config: map[string]string{
+			"kubernetes-disruption-budget-min-available":   "1",
+			"kubernetes-disruption-budget-max-unavailable": "1",
+		},
+	}}}
+	results, err := s.api.SetConfigs(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches,
+		`parsing settings for application: invalid update strategy: max unavailable: number or percentage of pods "some" not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches,
+		`parsing settings for application: disruption budget max unavailable: number or percentage of pods "1x" not valid`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches,
+		`parsing settings for application: disruption budget without exactly one of min available and max unavailable not valid`)
+}
+
 func (s *ApplicationSuite) TestSetCAASConfigSettingsInIAASModelTriggersError(c *gc.C) {
 	s.model.modelType = state.ModelTypeIAAS
 	s.setAPIUser(c, names.NewUserTag("admin"))
@@ -1215,7 +1289,23 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "Scale", 5)
+	app.CheckCall(c, 2, "Scale", 5)
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsAutoscaled(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.autoscale = &coreapplication.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetCPU: 70}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			ScaleChange:    1,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`application "postgresql" is autoscaled, stop autoscaling it with autoscale-application --reset before scaling it`)
+	app.CheckCallNames(c, "Charm", "AutoscalePolicy")
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsNotAllowedForOperator(c *gc.C) {
@@ -1294,7 +1384,7 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleChange(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "ChangeScale", 5)
+	app.CheckCall(c, 2, "ChangeScale", 5)
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleArgCheck(c *gc.C) {
@@ -1327,6 +1417,102 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleArgCheck(c *gc.C)
 	}
 }
 
+func (s *ApplicationSuite) TestSetAutoscalePoliciesCAASModel(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	results, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-postgresql",
+			Policy:         &params.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetCPU: 70},
+		}, {
+			ApplicationTag: "application-unknown",
+			Policy:         &params.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetCPU: 70},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "unknown" does not exist`)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 1, "SetAutoscalePolicy", &coreapplication.AutoscalePolicy{
+		MinUnits: 1, MaxUnits: 5, TargetCPU: 70,
+	})
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePoliciesClear(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	results, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "SetAutoscalePolicy")
+	app.CheckCall(c, 0, "SetAutoscalePolicy", (*coreapplication.AutoscalePolicy)(nil))
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePoliciesNotAllowedForDaemonSet(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.applications["postgresql"].charm = &mockCharm{
+		meta: &charm.Meta{
+			Deployment: &charm.Deployment{
+				DeploymentType: charm.DeploymentDaemon,
+			},
+		},
+	}
+	results, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-postgresql",
+			Policy:         &params.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetCPU: 70},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, `scale a "daemon" application not supported`)
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePoliciesNotCAASModel(c *gc.C) {
+	_, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "autoscaling applications on a non-container model not supported")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePoliciesBlocked(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.blockChecker.SetErrors(apiservererrors.ServerError(apiservererrors.OperationBlockedError("test block")))
+	_, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.Satisfies, params.IsCodeOperationBlocked)
+}
+
+func (s *ApplicationSuite) TestAutoscalePolicies(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.applications["postgresql"].autoscale = &coreapplication.AutoscalePolicy{
+		MinUnits: 2, MaxUnits: 10, Metric: "connections", TargetMetric: 50,
+	}
+	results, err := s.api.AutoscalePolicies(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-postgresql-subordinate"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.AutoscalePolicyResult{
+		Policy: &params.AutoscalePolicy{MinUnits: 2, MaxUnits: 10, Metric: "connections", TargetMetric: 50},
+	})
+	c.Assert(results.Results[1], jc.DeepEquals, params.AutoscalePolicyResult{})
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
 func (s *ApplicationSuite) TestScaleApplicationsIAASModel(c *gc.C) {
 	_, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
 		Applications: []params.ScaleApplicationParams{{
@@ -1905,7 +2091,7 @@ func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 		},
 	})
 	app := s.backend.applications["test-app-info"]
-	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "CharmOrigin", "IsPrincipal", "IsExposed", "IsRemote")
+	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "ApplicationConfig", "CharmOrigin", "IsPrincipal", "IsExposed", "IsRemote")
 }
 
 func (s *ApplicationSuite) TestApplicationsInfoOneWithExposedEndpoints(c *gc.C) {
@@ -1944,7 +2130,25 @@ func (s *ApplicationSuite) TestApplicationsInfoOneWithExposedEndpoints(c *gc.C)
 			},
 		},
 	})
-	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "CharmOrigin", "IsPrincipal", "IsExposed", "IsRemote")
+	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "ApplicationConfig", "CharmOrigin", "IsPrincipal", "IsExposed", "IsRemote")
+}
+
+func (s *ApplicationSuite) TestApplicationsInfoContainerResources(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-container-resources": "charm.mem-request=256M postgresql.cpu-limit=200",
+	}
+
+	entities := []params.Entity{{Tag: "application-postgresql"}}
+	result, err := s.api.ApplicationsInfo(params.Entities{Entities: entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, len(entities))
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	memRequest, cpuLimit := uint64(256), uint64(200)
+	c.Assert(result.Results[0].Result.ContainerResources, jc.DeepEquals, map[string]params.CAASContainerResources{
+		"charm":      {MemRequest: &memRequest},
+		"postgresql": {CPULimit: &cpuLimit},
+	})
 }
 
 func (s *ApplicationSuite) TestApplicationsInfoDetailsErr(c *gc.C) {
@@ -1995,7 +2199,7 @@ func (s *ApplicationSuite) TestApplicationsInfoMany(c *gc.C) {
 	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "wordpress" not found`)
 	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
 	app := s.backend.applications["postgresql"]
-	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "CharmOrigin", "IsPrincipal", "IsExposed", "IsRemote")
+	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "EndpointBindings", "Series", "Channel", "EndpointBindings", "ExposedEndpoints", "ApplicationConfig", "CharmOrigin", "IsPrincipal", "IsExposed", "IsRemote")
 }
 
 func (s *ApplicationSuite) TestApplicationMergeBindingsErr(c *gc.C) {
@@ -99,6 +99,8 @@ type Application interface {
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
 	SetScale(int, int64, bool) error
 	ChangeScale(int) (int, error)
+	AutoscalePolicy() *application.AutoscalePolicy
+	SetAutoscalePolicy(*application.AutoscalePolicy) error
 	AgentTools() (*tools.Tools, error)
 	MergeBindings(*state.Bindings, bool) error
 	Relations() ([]Relation, error)
@@ -114,6 +114,7 @@ type mockApplication struct {
 	exposed          bool
 	remote           bool
 	agentTools       *tools.Tools
+	autoscale        *coreapplication.AutoscalePolicy
 }
 
 func (m *mockApplication) Name() string {
@@ -212,6 +213,16 @@ func (a *mockApplication) SetScale(scale int, generation int64, force bool) erro
 	return nil
 }
 
+func (a *mockApplication) AutoscalePolicy() *coreapplication.AutoscalePolicy {
+	a.MethodCall(a, "AutoscalePolicy")
+	return a.autoscale
+}
+
+func (a *mockApplication) SetAutoscalePolicy(policy *coreapplication.AutoscalePolicy) error {
+	a.MethodCall(a, "SetAutoscalePolicy", policy)
+	return a.NextErr()
+}
+
 func (a *mockApplication) IsPrincipal() bool {
 	a.MethodCall(a, "IsPrincipal")
 	a.PopNoErr()
@@ -103,6 +103,20 @@ func (mr *MockApplicationMockRecorder) ApplicationConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplicationConfig", reflect.TypeOf((*MockApplication)(nil).ApplicationConfig))
 }
 
+// AutoscalePolicy mocks base method
+func (m *MockApplication) AutoscalePolicy() *application0.AutoscalePolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoscalePolicy")
+	ret0, _ := ret[0].(*application0.AutoscalePolicy)
+	return ret0
+}
+
+// AutoscalePolicy indicates an expected call of AutoscalePolicy
+func (mr *MockApplicationMockRecorder) AutoscalePolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoscalePolicy", reflect.TypeOf((*MockApplication)(nil).AutoscalePolicy))
+}
+
 // ChangeScale mocks base method
 func (m *MockApplication) ChangeScale(arg0 int) (int, error) {
 	m.ctrl.T.Helper()
@@ -406,6 +420,20 @@ func (mr *MockApplicationMockRecorder) Series() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Series", reflect.TypeOf((*MockApplication)(nil).Series))
 }
 
+// SetAutoscalePolicy mocks base method
+func (m *MockApplication) SetAutoscalePolicy(arg0 *application0.AutoscalePolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAutoscalePolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAutoscalePolicy indicates an expected call of SetAutoscalePolicy
+func (mr *MockApplicationMockRecorder) SetAutoscalePolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoscalePolicy", reflect.TypeOf((*MockApplication)(nil).SetAutoscalePolicy), arg0)
+}
+
 // SetCharm mocks base method
 func (m *MockApplication) SetCharm(arg0 state.SetCharmConfig) error {
 	m.ctrl.T.Helper()
@@ -35,6 +35,7 @@ type Backend interface {
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddOneMachine(state.MachineTemplate) (*state.Machine, error)
 	AddRelation(...state.Endpoint) (*state.Relation, error)
+	AgentUpgradeCanary() (*state.AgentUpgradeCanary, error)
 	AllApplications() ([]*state.Application, error)
 	AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error)
 	AllRemoteApplications() ([]*state.RemoteApplication, error)
@@ -68,7 +69,9 @@ type Backend interface {
 	MongoSession() MongoSession
 	RemoteApplication(string) (*state.RemoteApplication, error)
 	RemoteConnectionStatus(string) (*state.RemoteConnectionStatus, error)
+	RemoveAgentUpgradeCanary() error
 	RemoveUserAccess(names.UserTag, names.Tag) error
+	SetAgentUpgradeCanary(version.Number, []string) error
 	SetAnnotations(state.GlobalEntity, map[string]string) error
 	SetModelAgentVersion(version.Number, bool) error
 	SetModelConstraints(constraints.Value) error
@@ -88,6 +88,11 @@ type ClientV1 struct {
 
 // ClientV2 serves the (v2) client-specific API methods.
 type ClientV2 struct {
+	*ClientV3
+}
+
+// ClientV3 serves the (v3) client-specific API methods.
+type ClientV3 struct {
 	*Client
 }
 
@@ -155,13 +160,22 @@ func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
 
 // NewFacadeV2 creates a version 2 Client facade to handle API requests.
 func NewFacadeV2(ctx facade.Context) (*ClientV2, error) {
-	client, err := newFacade(ctx)
+	client, err := NewFacadeV3(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &ClientV2{client}, nil
 }
 
+// NewFacadeV3 creates a version 3 Client facade to handle API requests.
+func NewFacadeV3(ctx facade.Context) (*ClientV3, error) {
+	client, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV3{client}, nil
+}
+
 func newFacade(ctx facade.Context) (*Client, error) {
 	st := ctx.State()
 	resources := ctx.Resources()
@@ -757,6 +771,46 @@ func (c *Client) SetModelAgentVersion(args params.SetModelAgentVersion) error {
 	return c.api.stateAccessor.SetModelAgentVersion(args.Version, args.IgnoreAgentVersions)
 }
 
+// SetCanaryAgentVersion upgrades the agents of the given machines, and
+// of the units on them, to the given version, leaving the rest of the
+// model at its current agent version.
+func (c *Client) SetCanaryAgentVersion(args params.SetCanaryAgentVersion) error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	machineIds := make([]string, len(args.Machines))
+	for i, entity := range args.Machines {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		machineIds[i] = tag.Id()
+	}
+	return c.api.stateAccessor.SetAgentUpgradeCanary(args.Version, machineIds)
+}
+
+// ClearCanaryAgentVersion ends any canary upgrade of the model's
+// agents. Unless the model has been upgraded to the canary version,
+// the canary machines are rolled back to the model's agent version.
+func (c *Client) ClearCanaryAgentVersion() error {
+	if err := c.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.api.stateAccessor.RemoveAgentUpgradeCanary()
+}
+
+// SetCanaryAgentVersion isn't on the v3 API.
+func (c *ClientV3) SetCanaryAgentVersion(_, _ struct{}) {}
+
+// ClearCanaryAgentVersion isn't on the v3 API.
+func (c *ClientV3) ClearCanaryAgentVersion(_, _ struct{}) {}
+
 // CheckMongoStatusForUpgrade returns an error if the replicaset is not in a good
 // enough state for an upgrade to continue. Exported for testing.
 func (c *Client) CheckMongoStatusForUpgrade(session MongoSession) error {
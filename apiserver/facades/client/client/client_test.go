@@ -288,6 +288,50 @@ func (s *serverSuite) TestSetModelAgentVersion(c *gc.C) {
 	s.assertModelVersion(c, s.State, validVersion.String())
 }
 
+func (s *serverSuite) TestSetCanaryAgentVersion(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	older := jujuversion.Current
+	older.Minor--
+	err := st.SetModelAgentVersion(older, true)
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetAgentVersion(version.Binary{Number: jujuversion.Current, Release: "ubuntu", Arch: "amd64"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.clientForState(c, st)
+	err = client.SetCanaryAgentVersion(params.SetCanaryAgentVersion{
+		Version:  jujuversion.Current,
+		Machines: []params.Entity{{Tag: m.Tag().String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	canary, err := st.AgentUpgradeCanary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(canary.Machines(), jc.DeepEquals, []string{m.Id()})
+
+	status, err := client.FullStatus(params.StatusParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Model.CanaryUpgrade, jc.DeepEquals, &params.CanaryUpgradeStatus{
+		Version:  jujuversion.Current.String(),
+		Machines: []string{m.Id()},
+		Upgraded: []string{m.Id()},
+	})
+
+	err = client.ClearCanaryAgentVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.AgentUpgradeCanary()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *serverSuite) TestSetCanaryAgentVersionInvalidTag(c *gc.C) {
+	err := s.client.SetCanaryAgentVersion(params.SetCanaryAgentVersion{
+		Version:  jujuversion.Current,
+		Machines: []params.Entity{{Tag: "unit-wordpress-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `"unit-wordpress-0" is not a valid machine tag`)
+}
+
 func (s *serverSuite) TestSetModelAgentVersionOldModels(c *gc.C) {
 	err := s.State.SetModelAgentVersion(version.MustParse("2.8.0"), false)
 	c.Assert(err, jc.ErrorIsNil)
@@ -14,6 +14,7 @@ import (
 	corecharm "github.com/juju/juju/core/charm"
 	coreseries "github.com/juju/juju/core/series"
 	"github.com/juju/names/v4"
+	"github.com/juju/version/v2"
 
 	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
@@ -483,6 +484,9 @@ func (c *Client) modelStatus() (params.ModelStatusInfo, error) {
 		if current.Compare(latestVersion) < 0 {
 			info.AvailableVersion = latestVersion.String()
 		}
+		if info.CanaryUpgrade, err = c.canaryUpgradeStatus(current); err != nil {
+			return params.ModelStatusInfo{}, errors.Annotate(err, "cannot obtain canary upgrade status")
+		}
 	}
 
 	aStatus, err := m.Status()
@@ -509,6 +513,45 @@ func (c *Client) modelStatus() (params.ModelStatusInfo, error) {
 	return info, nil
 }
 
+// canaryUpgradeStatus returns the progress of any canary upgrade of the
+// model's agents to a version newer than the model's current version.
+func (c *Client) canaryUpgradeStatus(current version.Number) (*params.CanaryUpgradeStatus, error) {
+	canary, err := c.api.stateAccessor.AgentUpgradeCanary()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	target := canary.TargetVersion()
+	if target.Compare(current) <= 0 {
+		// The model has caught up with the canary machines.
+		return nil, nil
+	}
+	result := &params.CanaryUpgradeStatus{
+		Version:  target.String(),
+		Machines: canary.Machines(),
+		Upgraded: []string{},
+	}
+	for _, id := range result.Machines {
+		m, err := c.api.stateAccessor.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tools, err := m.AgentTools()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if tools.Version.Number == target {
+			result.Upgraded = append(result.Upgraded, id)
+		}
+	}
+	return result, nil
+}
+
 type applicationStatusInfo struct {
 	// application: application name -> application
 	applications map[string]*state.Application
@@ -524,6 +567,9 @@ type applicationStatusInfo struct {
 
 	// lxdProfiles: lxd profile name -> lxd profile
 	lxdProfiles map[string]*charm.LXDProfile
+
+	// containers: application name -> workload container names
+	containers map[string][]string
 }
 
 type statusContext struct {
@@ -781,6 +827,7 @@ func fetchAllApplicationsAndUnits(st Backend, model *state.Model, spaceInfos net
 	}
 
 	lxdProfiles := make(map[string]*charm.LXDProfile)
+	containers := make(map[string][]string)
 	for _, app := range applications {
 		appMap[app.Name()] = app
 		appUnits := allUnitsByApp[app.Name()]
@@ -810,6 +857,9 @@ func fetchAllApplicationsAndUnits(st Backend, model *state.Model, spaceInfos net
 				Devices:     profile.Devices,
 			}
 		}
+		for name := range ch.Meta().Containers {
+			containers[app.Name()] = append(containers[app.Name()], name)
+		}
 	}
 
 	for baseURL := range latestCharms {
@@ -829,6 +879,7 @@ func fetchAllApplicationsAndUnits(st Backend, model *state.Model, spaceInfos net
 		latestCharms:     latestCharms,
 		endpointBindings: allBindingsByApp,
 		lxdProfiles:      lxdProfiles,
+		containers:       containers,
 	}, nil
 }
 
@@ -1493,6 +1544,7 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 		} else {
 			logger.Tracef("container info not yet available for unit: %v", err)
 		}
+		result.Containers = context.processUnitContainers(unit)
 	}
 	if unit.IsPrincipal() {
 		result.Machine, _ = unit.AssignedMachineId()
@@ -1526,6 +1578,28 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	return result
 }
 
+// processUnitContainers returns the health of the unit's workload
+// containers, for those that have reported it.
+func (context *statusContext) processUnitContainers(unit *state.Unit) map[string]params.DetailedStatus {
+	var result map[string]params.DetailedStatus
+	for _, name := range context.allAppsUnitsCharmBindings.containers[unit.ApplicationName()] {
+		health, err := context.status.UnitContainerHealth(unit.Name(), name)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]params.DetailedStatus)
+		}
+		var containerStatus params.DetailedStatus
+		populateStatusFromStatusInfoAndErr(&containerStatus, health, err)
+		if checks, ok := health.Data["failing-checks"]; ok {
+			containerStatus.Data = map[string]interface{}{"failing-checks": checks}
+		}
+		result[name] = containerStatus
+	}
+	return result
+}
+
 func (context *statusContext) unitByName(name string) *state.Unit {
 	applicationName := strings.Split(name, "/")[0]
 	return context.allAppsUnitsCharmBindings.units[applicationName][name]
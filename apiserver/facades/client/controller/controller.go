@@ -55,12 +55,45 @@ type ControllerAPI struct {
 	controller *cache.Controller
 
 	multiwatcherFactory multiwatcher.Factory
+
+	// dataDir and agentTag identify the controller agent running
+	// the API server, whose configuration is used to check upgrades.
+	dataDir  string
+	agentTag names.Tag
+}
+
+// ControllerAPIv12 provides the v12 Controller API. The only difference
+// between this and v13 is that v12 doesn't have the ControllerHealth
+// method.
+type ControllerAPIv12 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv11 provides the v11 Controller API. The only difference
+// between this and v12 is that v11 doesn't have the CheckUpgrade
+// method.
+type ControllerAPIv11 struct {
+	*ControllerAPIv12
+}
+
+// ControllerAPIv10 provides the v10 Controller API. The only difference
+// between this and v11 is that v10 doesn't have the RetryMigrations
+// method.
+type ControllerAPIv10 struct {
+	*ControllerAPIv11
+}
+
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have the MigrationPrechecks
+// method.
+type ControllerAPIv9 struct {
+	*ControllerAPIv10
 }
 
 // ControllerAPIv8 provides the v8 Controller API. The only difference
 // between this and v9 is that v8 doesn't have the model summary watchers.
 type ControllerAPIv8 struct {
-	*ControllerAPI
+	*ControllerAPIv9
 }
 
 // ControllerAPIv7 provides the v7 Controller API. The only difference
@@ -95,10 +128,10 @@ type ControllerAPIv3 struct {
 
 // LatestAPI is used for testing purposes to create the latest
 // controller API.
-var LatestAPI = NewControllerAPIv9
+var LatestAPI = NewControllerAPIv13
 
-// NewControllerAPIv9 creates a new ControllerAPIv9.
-func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv13 creates a new ControllerAPIv13.
+func NewControllerAPIv13(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -108,7 +141,7 @@ func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 	factory := ctx.MultiwatcherFactory()
 	controller := ctx.Controller()
 
-	return NewControllerAPI(
+	api, err := NewControllerAPI(
 		st,
 		pool,
 		authorizer,
@@ -118,6 +151,48 @@ func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 		factory,
 		controller,
 	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.dataDir = ctx.DataDir()
+	api.agentTag = ctx.AgentTag()
+	return api, nil
+}
+
+// NewControllerAPIv12 creates a new ControllerAPIv12.
+func NewControllerAPIv12(ctx facade.Context) (*ControllerAPIv12, error) {
+	v13, err := NewControllerAPIv13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv12{v13}, nil
+}
+
+// NewControllerAPIv11 creates a new ControllerAPIv11.
+func NewControllerAPIv11(ctx facade.Context) (*ControllerAPIv11, error) {
+	v12, err := NewControllerAPIv12(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv11{v12}, nil
+}
+
+// NewControllerAPIv10 creates a new ControllerAPIv10.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPIv10, error) {
+	v11, err := NewControllerAPIv11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv10{v11}, nil
+}
+
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
 }
 
 // NewControllerAPIv8 creates a new ControllerAPIv8.
@@ -236,6 +311,33 @@ func (c *ControllerAPI) checkIsSuperUser() error {
 	return nil
 }
 
+// ControllerConfig returns the controller's configuration. The
+// credentials for the CAAS image repository are only returned to
+// superusers; everyone else just sees the repository path.
+func (c *ControllerAPI) ControllerConfig() (params.ControllerConfigResult, error) {
+	result, err := c.ControllerConfigAPI.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if _, ok := result.Config[corecontroller.CAASImageRepo]; !ok {
+		return result, nil
+	}
+	isAdmin, err := c.authorizer.HasPermission(permission.SuperuserAccess, c.state.ControllerTag())
+	if err != nil {
+		return params.ControllerConfigResult{}, errors.Trace(err)
+	}
+	if isAdmin {
+		return result, nil
+	}
+	details, err := corecontroller.Config(result.Config).CAASImageRepoDetails()
+	if err != nil || details.Repository == "" {
+		delete(result.Config, corecontroller.CAASImageRepo)
+	} else {
+		result.Config[corecontroller.CAASImageRepo] = details.Repository
+	}
+	return result, nil
+}
+
 // ControllerVersion isn't on the v7 API.
 func (c *ControllerAPIv7) ControllerVersion(_, _ struct{}) {}
 
@@ -601,38 +703,142 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, targetInfo, err := c.migrationTarget(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Release()
 
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.statePool.Get(modelTag.Id())
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// MigrationPrechecks runs all of the migration prechecks on the source
+// and target controllers for one or more models, without starting
+// the migrations. Every blocking issue found is reported.
+func (c *ControllerAPI) MigrationPrechecks(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationPrecheckResults, error,
+) {
+	out := params.MigrationPrecheckResults{
+		Results: make([]params.MigrationPrecheckResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		issues, err := c.oneMigrationPrecheckIssues(spec)
+		if err != nil {
+			result.Error = apiservererrors.ServerError(err)
+		} else {
+			result.Issues = issues
+		}
+	}
+	return out, nil
+}
+
+// MigrationPrechecks isn't on the v9 API.
+func (c *ControllerAPIv9) MigrationPrechecks(_, _ struct{}) {}
+
+// RetryMigrations requests that the failed model data transfer of the
+// active migrations of the given models is retried, rather than the
+// migrations being aborted.
+func (c *ControllerAPI) RetryMigrations(args params.Entities) (params.ErrorResults, error) {
+	out := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := c.checkIsSuperUser(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, entity := range args.Entities {
+		err := c.retryOneMigration(entity.Tag)
+		out.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return out, nil
+}
+
+// RetryMigrations isn't on the v10 API.
+func (c *ControllerAPIv10) RetryMigrations(_, _ struct{}) {}
+
+func (c *ControllerAPI) retryOneMigration(tag string) error {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	hostedState, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
 	defer hostedState.Release()
 
+	mig, err := hostedState.LatestMigration()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if phase, err := mig.Phase(); err != nil {
+		return errors.Trace(err)
+	} else if phase.IsTerminal() {
+		return errors.New("migration is not in progress")
+	}
+	return errors.Trace(mig.RetryTransfer())
+}
+
+func (c *ControllerAPI) oneMigrationPrecheckIssues(spec params.MigrationSpec) ([]string, error) {
+	hostedState, targetInfo, err := c.migrationTarget(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	issues, err := migrationPrecheckIssues(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+	return issues, errors.Trace(err)
+}
+
+// migrationTarget returns the state of the model to be migrated
+// along with the target controller details from the migration spec.
+// The caller is responsible for releasing the returned state.
+func (c *ControllerAPI) migrationTarget(spec params.MigrationSpec) (*state.PooledState, coremigration.TargetInfo, error) {
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, coremigration.TargetInfo{}, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return nil, coremigration.TargetInfo{}, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return nil, coremigration.TargetInfo{}, errors.NotFoundf("model")
+	}
+
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return nil, coremigration.TargetInfo{}, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return nil, coremigration.TargetInfo{}, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return nil, coremigration.TargetInfo{}, errors.Annotate(err, "invalid macaroons")
 		}
 	}
 	targetInfo := coremigration.TargetInfo{
@@ -645,20 +851,11 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Macaroons:       macs,
 	}
 
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+	hostedState, err := c.statePool.Get(modelTag.Id())
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, coremigration.TargetInfo{}, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	return hostedState, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -783,6 +980,68 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// migrationPrecheckIssues runs all of the prechecks on the migration,
+// returning every blocking issue found on the source and target
+// controllers. An error is returned only if the checks couldn't be
+// completed.
+var migrationPrecheckIssues = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) ([]string, error) {
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	sourceIssues, err := migration.SourcePrecheckIssues(backend, modelPresence, controllerPresence)
+	if err != nil {
+		return nil, errors.Annotate(err, "running source prechecks")
+	}
+	var issues []string
+	for _, issue := range sourceIssues {
+		issues = append(issues, "source: "+issue)
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return append(issues, "connect to target controller: "+err.Error()), nil
+	}
+	defer conn.Close()
+	modelInfo, srcUserList, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dstUserList, err := getTargetControllerUsers(conn)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = srcUserList.checkCompatibilityWith(dstUserList); err != nil {
+		issues = append(issues, "target: "+err.Error())
+	}
+	client := migrationtarget.NewClient(conn)
+	if _, err := client.CACert(); err != nil {
+		if !params.IsCodeNotImplemented(err) {
+			return nil, errors.Annotatef(err, "cannot retrieve CA certificate")
+		}
+		// If the call's not implemented, it indicates an earlier version
+		// of the controller, which we can't migrate to.
+		return append(issues, "target: controller API version is too old"), nil
+	}
+	targetIssues, err := client.PrecheckIssues(modelInfo)
+	if errors.IsNotSupported(err) {
+		// Older target controllers only report the first issue found.
+		if err := client.Prechecks(modelInfo); err != nil {
+			targetIssues = []string{err.Error()}
+		}
+	} else if err != nil {
+		return nil, errors.Annotate(err, "running target prechecks")
+	}
+	for _, issue := range targetIssues {
+		issues = append(issues, "target: "+issue)
+	}
+	return issues, nil
+}
+
 // userList encapsulates information about the users who have been granted
 // access to a model or the users known to a particular controller.
 type userList struct {
@@ -891,13 +1150,30 @@ func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, userList,
 		return empty, userList{}, errors.Trace(err)
 	}
 	ul.identityURL = coreConf.IdentityURL()
-	return coremigration.ModelInfo{
+
+	modelCloud, err := st.Cloud(model.CloudName())
+	if err != nil {
+		return empty, userList{}, errors.Trace(err)
+	}
+	info := coremigration.ModelInfo{
 		UUID:                   model.UUID(),
 		Name:                   model.Name(),
 		Owner:                  model.Owner(),
 		AgentVersion:           agentVersion,
 		ControllerAgentVersion: controllerVersion,
-	}, ul, nil
+		CloudName:              model.CloudName(),
+		CloudType:              modelCloud.Type,
+		CloudRegion:            model.CloudRegion(),
+	}
+	if credTag, ok := model.CloudCredentialTag(); ok {
+		cred, err := st.CloudCredential(credTag)
+		if err != nil {
+			return empty, userList{}, errors.Trace(err)
+		}
+		info.CloudCredential = credTag
+		info.CloudCredentialAuthType = cred.AuthType
+	}
+	return info, ul, nil
 }
 
 func getTargetControllerUsers(conn api.Connection) (userList, error) {
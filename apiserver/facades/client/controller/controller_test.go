@@ -15,6 +15,7 @@ import (
 	"github.com/juju/pubsub"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2"
+	"github.com/juju/version/v2"
 	"github.com/juju/worker/v2/workertest"
 	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
@@ -29,6 +30,7 @@ import (
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs"
 	environscloudspec "github.com/juju/juju/environs/cloudspec"
@@ -113,6 +115,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 		Controller_:          cacheController,
 		Hub_:                 s.hub,
 		MultiwatcherFactory_: multiWatcherWorker,
+		AgentTag_:            names.NewMachineTag("0"),
 	}
 	controller, err := controller.LatestAPI(s.context)
 	c.Assert(err, jc.ErrorIsNil)
@@ -330,6 +333,42 @@ func (s *controllerSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+const caasImageRepoWithCredentials = `{"repository": "registry.example.com/juju", "username": "jujuqa", "password": "secret"}`
+
+func (s *controllerSuite) TestControllerConfigImageRepoCredentials(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"caas-image-repo": caasImageRepoWithCredentials,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.controller.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Config["caas-image-repo"], gc.Equals, caasImageRepoWithCredentials)
+}
+
+func (s *controllerSuite) TestControllerConfigImageRepoCredentialsNonAdmin(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"caas-image-repo": caasImageRepoWithCredentials,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPIv4(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := endpoint.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Config["caas-image-repo"], gc.Equals, "registry.example.com/juju")
+	cfgFromDB, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Config["controller-uuid"], gc.Equals, cfgFromDB.ControllerUUID())
+}
+
 func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
@@ -618,6 +657,213 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestMigrationPrechecks(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetPrecheckIssues(s, []string{"source: cleanup needed", "target: upgrade in progress"}, nil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}, {
+			ModelTag: randomModelTag(), // Doesn't exist.
+		}},
+	}
+	out, err := s.controller.MigrationPrechecks(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0].ModelTag, gc.Equals, m.ModelTag().String())
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].Issues, jc.DeepEquals, []string{"source: cleanup needed", "target: upgrade in progress"})
+
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	// No migration is started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestRetryMigrations(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	mig, err := st.CreateMigration(state.MigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: coremigration.TargetInfo{
+			ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert1",
+			AuthTag:       names.NewUserTag("admin1"),
+			Password:      "secret1",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.IMPORT), jc.ErrorIsNil)
+
+	out, err := s.controller.RetryMigrations(params.Entities{
+		Entities: []params.Entity{
+			{Tag: m.ModelTag().String()},
+			{Tag: s.Model.ModelTag().String()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "migration not found")
+
+	c.Assert(mig.Refresh(), jc.ErrorIsNil)
+	c.Check(mig.Retries(), gc.Equals, 1)
+}
+
+func (s *controllerSuite) TestCheckUpgrade(c *gc.C) {
+	steps := []params.UpgradeStepCheck{{
+		Version:     "2.9.99",
+		Description: "add a thing",
+		Targets:     []string{"controller"},
+		Checked:     true,
+		Error:       "boom",
+	}}
+	controller.SetUpgradeCheckResult(s, steps, nil)
+
+	current, err := s.Model.AgentVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	target := current
+	target.Patch++
+	result, err := s.controller.CheckUpgrade(params.CheckUpgradeArgs{TargetVersion: target})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.CheckUpgradeResult{
+		FromVersion: current,
+		Steps:       steps,
+	})
+}
+
+func (s *controllerSuite) TestCheckUpgradeOlderVersion(c *gc.C) {
+	controller.SetUpgradeCheckResult(s, nil, errors.New("should not be called"))
+	_, err := s.controller.CheckUpgrade(params.CheckUpgradeArgs{TargetVersion: version.MustParse("2.0.0")})
+	c.Assert(err, gc.ErrorMatches, "target version 2.0.0 must be newer than the controller version .*")
+}
+
+func (s *controllerSuite) TestCheckUpgradeRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{Tag: user.Tag()}
+	endpoint, err := controller.NewControllerAPIv12(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.CheckUpgrade(params.CheckUpgradeArgs{TargetVersion: version.MustParse("9.9.9")})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestControllerHealth(c *gc.C) {
+	controller.SetHealthTimeout(s, testing.ShortWait)
+	members := []params.MongoMemberHealth{{
+		Id:      1,
+		Address: "10.0.0.1:37017",
+		State:   "PRIMARY",
+		Healthy: true,
+	}}
+	controller.SetMongoMemberHealth(s, members, nil)
+	m := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Jobs: []state.MachineJob{state.JobManageModel},
+	})
+
+	unsubscribe, err := s.hub.Subscribe(pscontroller.HealthRequestTopic,
+		func(_ string, req pscontroller.HealthRequest, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			_, err = s.hub.Publish(req.ResponseTopic, pscontroller.HealthReport{
+				Origin: m.Tag().String(),
+				Raft: pscontroller.RaftHealth{
+					State:        "Leader",
+					Leader:       m.Id(),
+					Term:         3,
+					AppliedIndex: 10,
+					LastIndex:    12,
+				},
+				LeaseClaims: pscontroller.LeaseClaimHealth{Count: 5, Median: 2, P99: 7.5},
+				Forwarders:  map[string]string{"machine-42": "connected"},
+			})
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	controllerIds, err := s.State.ControllerIds()
+	c.Assert(err, jc.ErrorIsNil)
+	expected := params.ControllerHealthResult{MongoMembers: members}
+	for _, id := range controllerIds {
+		agent := params.ControllerAgentHealth{ControllerId: id}
+		if id == m.Id() {
+			agent = params.ControllerAgentHealth{
+				ControllerId:     id,
+				Responded:        true,
+				RaftState:        "Leader",
+				RaftLeader:       id,
+				RaftTerm:         3,
+				RaftAppliedIndex: 10,
+				RaftLastIndex:    12,
+				RaftLag:          2,
+				LeaseClaims:      5,
+				LeaseClaimMedian: 2,
+				LeaseClaimP99:    7.5,
+				Forwarders:       map[string]string{"machine-42": "connected"},
+			}
+		}
+		expected.Agents = append(expected.Agents, agent)
+	}
+
+	result, err := s.controller.ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *controllerSuite) TestControllerHealthMongoError(c *gc.C) {
+	controller.SetHealthTimeout(s, testing.ShortWait)
+	controller.SetMongoMemberHealth(s, nil, errors.New("not running with --replSet"))
+
+	result, err := s.controller.ControllerHealth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.MongoError, gc.ErrorMatches, "not running with --replSet")
+	for _, agent := range result.Agents {
+		c.Check(agent.Responded, jc.IsFalse)
+	}
+}
+
+func (s *controllerSuite) TestControllerHealthRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	anAuthoriser := apiservertesting.FakeAuthorizer{Tag: user.Tag()}
+	endpoint, err := controller.NewControllerAPIv13(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = endpoint.ControllerHealth()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
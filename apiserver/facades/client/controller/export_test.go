@@ -4,7 +4,13 @@
 package controller
 
 import (
+	"time"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/version/v2"
+
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 )
@@ -18,3 +24,25 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetUpgradeCheckResult(p patcher, steps []params.UpgradeStepCheck, err error) {
+	p.PatchValue(&runUpgradeCheck, func(*state.State, string, names.Tag, version.Number, version.Number) ([]params.UpgradeStepCheck, error) {
+		return steps, err
+	})
+}
+
+func SetPrecheckIssues(p patcher, issues []string, err error) {
+	p.PatchValue(&migrationPrecheckIssues, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) ([]string, error) {
+		return issues, err
+	})
+}
+
+func SetHealthTimeout(p patcher, timeout time.Duration) {
+	p.PatchValue(&healthTimeout, timeout)
+}
+
+func SetMongoMemberHealth(p patcher, members []params.MongoMemberHealth, err error) {
+	p.PatchValue(&mongoMemberHealth, func(*state.State) ([]params.MongoMemberHealth, error) {
+		return members, err
+	})
+}
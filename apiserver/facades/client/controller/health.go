@@ -0,0 +1,190 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/replicaset/v2"
+	"github.com/juju/utils/v2"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/state"
+)
+
+// healthTimeout is how long ControllerHealth waits for the controller
+// agents to report their health.
+var healthTimeout = 5 * time.Second
+
+// mongoMemberHealth is overridden by tests, which don't run Mongo as a
+// replica set.
+var mongoMemberHealth = replicaSetHealth
+
+// ControllerHealth asks every controller agent to report on its raft
+// node, lease store and pubsub forwarding connections, and combines the
+// reports with the status of the Mongo replica set members. Controllers
+// which don't respond in time are reported as such.
+func (c *ControllerAPI) ControllerHealth() (params.ControllerHealthResult, error) {
+	var result params.ControllerHealthResult
+	if err := c.checkIsSuperUser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	controllerIds, err := c.state.ControllerIds()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	reports, err := c.requestHealthReports(controllerIds)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Agents = agentHealth(controllerIds, reports)
+
+	members, err := mongoMemberHealth(c.state)
+	if err != nil {
+		result.MongoError = apiservererrors.ServerError(err)
+	}
+	result.MongoMembers = members
+	return result, nil
+}
+
+// ControllerHealth isn't on the v12 API.
+func (c *ControllerAPIv12) ControllerHealth(_, _ struct{}) {}
+
+// requestHealthReports publishes a health request on the hub, and
+// collects the reports until every controller has responded or the
+// timeout expires. The reports are keyed by controller id.
+func (c *ControllerAPI) requestHealthReports(controllerIds []string) (map[string]controller.HealthReport, error) {
+	var mu sync.Mutex
+	reports := make(map[string]controller.HealthReport)
+	done := make(chan struct{})
+	responseTopic := controller.HealthRequestTopic + "." + utils.MustNewUUID().String()
+	unsubscribe, err := c.hub.Subscribe(
+		responseTopic,
+		func(_ string, report controller.HealthReport, err error) {
+			if err != nil {
+				logger.Warningf("health report: %v", err)
+				return
+			}
+			tag, err := names.ParseTag(report.Origin)
+			if err != nil {
+				logger.Warningf("health report from %q: %v", report.Origin, err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			reports[tag.Id()] = report
+			if len(reports) == len(controllerIds) {
+				select {
+				case <-done:
+				default:
+					close(done)
+				}
+			}
+		},
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "subscribing to health reports")
+	}
+	defer unsubscribe()
+
+	if _, err := c.hub.Publish(controller.HealthRequestTopic, controller.HealthRequest{
+		ResponseTopic: responseTopic,
+	}); err != nil {
+		return nil, errors.Annotate(err, "requesting health reports")
+	}
+	select {
+	case <-done:
+	case <-time.After(healthTimeout):
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	result := make(map[string]controller.HealthReport, len(reports))
+	for id, report := range reports {
+		result[id] = report
+	}
+	return result, nil
+}
+
+// agentHealth converts the health reports for the given controllers,
+// working out how far each controller's raft node is behind the most
+// up to date one.
+func agentHealth(controllerIds []string, reports map[string]controller.HealthReport) []params.ControllerAgentHealth {
+	var lastIndex uint64
+	for _, report := range reports {
+		if report.Raft.LastIndex > lastIndex {
+			lastIndex = report.Raft.LastIndex
+		}
+	}
+	ids := append([]string(nil), controllerIds...)
+	sort.Strings(ids)
+	result := make([]params.ControllerAgentHealth, len(ids))
+	for i, id := range ids {
+		report, ok := reports[id]
+		if !ok {
+			result[i] = params.ControllerAgentHealth{ControllerId: id}
+			continue
+		}
+		var lag uint64
+		if report.Raft.AppliedIndex < lastIndex {
+			lag = lastIndex - report.Raft.AppliedIndex
+		}
+		result[i] = params.ControllerAgentHealth{
+			ControllerId:     id,
+			Responded:        true,
+			RaftState:        report.Raft.State,
+			RaftLeader:       report.Raft.Leader,
+			RaftTerm:         report.Raft.Term,
+			RaftAppliedIndex: report.Raft.AppliedIndex,
+			RaftLastIndex:    report.Raft.LastIndex,
+			RaftLag:          lag,
+			RaftLastContact:  report.Raft.LastContact,
+			LeaseClaims:      report.LeaseClaims.Count,
+			LeaseClaimMedian: report.LeaseClaims.Median,
+			LeaseClaimP99:    report.LeaseClaims.P99,
+			Forwarders:       report.Forwarders,
+			Errors:           report.Errors,
+		}
+	}
+	return result
+}
+
+// replicaSetHealth returns the status of the members of the Mongo
+// replica set.
+func replicaSetHealth(st *state.State) ([]params.MongoMemberHealth, error) {
+	status, err := replicaset.CurrentStatus(st.MongoSession())
+	if err != nil {
+		return nil, errors.Annotate(err, "getting replica set status")
+	}
+	members, err := replicaset.CurrentMembers(st.MongoSession())
+	if err != nil {
+		return nil, errors.Annotate(err, "getting replica set members")
+	}
+	controllerIds := make(map[int]string)
+	for _, m := range members {
+		controllerIds[m.Id] = m.Tags["juju-machine-id"]
+	}
+	// Mongo reports uptime in seconds and ping times in milliseconds,
+	// which the replicaset package reads as nanoseconds.
+	result := make([]params.MongoMemberHealth, len(status.Members))
+	for i, m := range status.Members {
+		result[i] = params.MongoMemberHealth{
+			Id:           m.Id,
+			ControllerId: controllerIds[m.Id],
+			Address:      m.Address,
+			State:        m.State.String(),
+			Healthy:      m.Healthy,
+			Uptime:       m.Uptime * time.Second,
+			Ping:         m.Ping * time.Millisecond,
+			Message:      m.ErrMsg,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils/v2"
+	"github.com/juju/utils/v2/arch"
+	"github.com/juju/version/v2"
+
+	agenttools "github.com/juju/juju/agent/tools"
+	"github.com/juju/juju/apiserver/params"
+	coreos "github.com/juju/juju/core/os"
+	"github.com/juju/juju/state"
+	coretools "github.com/juju/juju/tools"
+)
+
+// runUpgradeCheck is overridden by tests, which can't run the agent
+// binaries of another version.
+var runUpgradeCheck = checkUpgradeSteps
+
+// upgradeCheckTimeout bounds the time taken to copy the database and
+// run the upgrade steps against the copy.
+const upgradeCheckTimeout = 30 * time.Minute
+
+// CheckUpgrade runs the upgrade steps needed to upgrade the controller
+// to the target version against a snapshot of the controller's
+// database, without changing the running version, and reports the
+// result of each pending step. The steps are those of the target
+// version, whose agent binaries must be available to the controller.
+func (c *ControllerAPI) CheckUpgrade(args params.CheckUpgradeArgs) (params.CheckUpgradeResult, error) {
+	var result params.CheckUpgradeResult
+	if err := c.checkIsSuperUser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	model, err := c.state.Model()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	current, err := model.AgentVersion()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.TargetVersion.Compare(current) <= 0 {
+		return result, errors.Errorf("target version %s must be newer than the controller version %s", args.TargetVersion, current)
+	}
+	if c.agentTag == nil {
+		return result, errors.NotSupportedf("checking upgrades on this controller")
+	}
+	steps, err := runUpgradeCheck(c.state, c.dataDir, c.agentTag, current, args.TargetVersion)
+	if err != nil {
+		return result, errors.Annotatef(err, "checking upgrade to %s", args.TargetVersion)
+	}
+	result.FromVersion = current
+	result.Steps = steps
+	return result, nil
+}
+
+// CheckUpgrade isn't on the v11 API.
+func (c *ControllerAPIv11) CheckUpgrade(_, _ struct{}) {}
+
+// checkUpgradeSteps unpacks the controller's agent binaries for the
+// target version and runs their check-upgrade command, which reports
+// the upgrade steps from the given version, checked against a
+// snapshot of the database.
+func checkUpgradeSteps(
+	st *state.State, dataDir string, agentTag names.Tag, from, to version.Number,
+) ([]params.UpgradeStepCheck, error) {
+	vers := version.Binary{
+		Number:  to,
+		Release: coreos.HostOSTypeName(),
+		Arch:    arch.HostArch(),
+	}
+	storage, err := st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = storage.Close() }()
+	md, r, err := storage.Open(vers.String())
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("agent binaries for %s on the controller (use sync-agent-binaries to add them)", vers)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = r.Close() }()
+
+	tmpDir, err := ioutil.TempDir(dataDir, "upgrade-check")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	tools := &coretools.Tools{Version: vers, Size: md.Size, SHA256: md.SHA256}
+	if err := agenttools.UnpackTools(tmpDir, tools, r); err != nil {
+		return nil, errors.Annotate(err, "unpacking agent binaries")
+	}
+
+	// The snapshot database is named here, so that it can be dropped
+	// if check-upgrade fails or is killed before dropping it itself.
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshot := state.UpgradeCheckDatabasePrefix + uuid.String()
+
+	ctx, cancel := context.WithTimeout(context.Background(), upgradeCheckTimeout)
+	defer cancel()
+	jujud := filepath.Join(agenttools.SharedToolsDir(tmpDir, vers), "jujud")
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, jujud, "check-upgrade",
+		"--data-dir", dataDir, "--from", from.String(), "--database", snapshot, agentTag.String())
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if dropErr := state.DropSnapshotDatabase(st.MongoSession(), snapshot); dropErr != nil {
+			logger.Warningf("cannot drop upgrade check snapshot: %v", dropErr)
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, errors.Errorf("check-upgrade timed out after %v", upgradeCheckTimeout)
+		}
+		return nil, errors.Annotatef(err, "running check-upgrade: %s", bytes.TrimSpace(stderr.Bytes()))
+	}
+	var steps []params.UpgradeStepCheck
+	if err := json.Unmarshal(stdout.Bytes(), &steps); err != nil {
+		return nil, errors.Annotate(err, "reading check-upgrade output")
+	}
+	return steps, nil
+}
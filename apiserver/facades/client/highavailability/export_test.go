@@ -0,0 +1,10 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+// SetAvailabilityZones sets the function the API uses to get the
+// available zones in the controller's cloud.
+func SetAvailabilityZones(api *HighAvailabilityAPI, zones func() ([]string, error)) {
+	api.availabilityZones = zones
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -22,7 +23,9 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.highavailability")
@@ -30,6 +33,8 @@ var logger = loggo.GetLogger("juju.apiserver.highavailability")
 // HighAvailability defines the methods on the highavailability API end point.
 type HighAvailability interface {
 	EnableHA(args params.ControllersSpecs) (params.ControllersChangeResults, error)
+	RemoveControllerNodes(args params.RemoveControllerNodesArgs) (params.ErrorResults, error)
+	ReplaceControllerNodes(args params.ReplaceControllerNodesArgs) (params.ControllersChangeResults, error)
 }
 
 // HighAvailabilityAPI implements the HighAvailability interface and is the concrete
@@ -38,10 +43,30 @@ type HighAvailabilityAPI struct {
 	state      *state.State
 	resources  facade.Resources
 	authorizer facade.Authorizer
+
+	// availabilityZones returns the names of the available zones
+	// in the controller's cloud.
+	availabilityZones func() ([]string, error)
 }
 
 var _ HighAvailability = (*HighAvailabilityAPI)(nil)
 
+// HighAvailabilityAPIV2 implements version 2 of the high availability
+// API, which doesn't have RemoveControllerNodes or ReplaceControllerNodes.
+type HighAvailabilityAPIV2 struct {
+	*HighAvailabilityAPI
+}
+
+// NewHighAvailabilityAPIV2 creates a new server-side highavailability
+// API end point for version 2.
+func NewHighAvailabilityAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*HighAvailabilityAPIV2, error) {
+	api, err := NewHighAvailabilityAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &HighAvailabilityAPIV2{api}, nil
+}
+
 // NewHighAvailabilityAPI creates a new server-side highavailability API end point.
 func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*HighAvailabilityAPI, error) {
 	// Only clients can access the high availability facade.
@@ -61,6 +86,9 @@ func NewHighAvailabilityAPI(st *state.State, resources facade.Resources, authori
 		state:      st,
 		resources:  resources,
 		authorizer: authorizer,
+		availabilityZones: func() ([]string, error) {
+			return availabilityZones(stateenvirons.EnvironConfigGetter{Model: model}, context.CallContext(st))
+		},
 	}, nil
 }
 
@@ -91,6 +119,115 @@ func (api *HighAvailabilityAPI) EnableHA(args params.ControllersSpecs) (params.C
 	return results, nil
 }
 
+// RemoveControllerNodes starts the removal of the given controller
+// machines. Each machine gives up its vote, and is removed from the
+// replica set and the set of controllers by the peergrouper once the
+// remaining controllers have taken over. Only one controller can be
+// removed at a time.
+func (api *HighAvailabilityAPI) RemoveControllerNodes(args params.RemoveControllerNodesArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if err := api.checkCanChangeControllers(); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.ErrorResult, len(args.Nodes))
+	for i, arg := range args.Nodes {
+		tag, err := names.ParseMachineTag(arg.MachineTag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		err = api.state.RemoveControllerNode(tag.Id(), arg.Force, common.MaxWait(arg.MaxWait))
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+// ReplaceControllerNodes removes each of the given controller machines,
+// as for RemoveControllerNodes, and starts a new controller machine in
+// its place. Unless specified, the new machine has the constraints and
+// series of the machine it replaces.
+func (api *HighAvailabilityAPI) ReplaceControllerNodes(args params.ReplaceControllerNodesArgs) (params.ControllersChangeResults, error) {
+	results := params.ControllersChangeResults{}
+	if err := api.checkCanChangeControllers(); err != nil {
+		return results, errors.Trace(err)
+	}
+	results.Results = make([]params.ControllersChangeResult, len(args.Nodes))
+	for i, arg := range args.Nodes {
+		changes, err := api.replaceControllerNode(arg)
+		results.Results[i].Result = changes
+		results.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *HighAvailabilityAPI) replaceControllerNode(arg params.ReplaceControllerNodeArg) (params.ControllersChanges, error) {
+	tag, err := names.ParseMachineTag(arg.MachineTag)
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	m, err := api.state.Machine(tag.Id())
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	cons := arg.Constraints
+	if constraints.IsEmpty(&cons) {
+		if cons, err = m.Constraints(); err != nil {
+			return params.ControllersChanges{}, errors.Trace(err)
+		}
+	}
+	series := arg.Series
+	if series == "" {
+		series = m.Series()
+	}
+	cfg, err := api.state.ControllerConfig()
+	if err != nil {
+		return params.ControllersChanges{}, errors.Annotate(err, "retrieving controller config")
+	}
+	cons.Spaces = cfg.AsSpaceConstraints(cons.Spaces)
+	if err := validatePlacementForSpaces(api.state, cons.Spaces, arg.Placement); err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	controllerIds, err := api.state.ControllerIds()
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	remaining := set.NewStrings(controllerIds...)
+	remaining.Remove(tag.Id())
+	placement, warnings, err := api.zonePlacement(api.state, remaining.SortedValues(), 0, arg.Placement)
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	changes, err := api.state.ReplaceControllerNode(tag.Id(), arg.Force, common.MaxWait(arg.MaxWait), cons, series, placement)
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+	result := controllersChanges(changes)
+	result.Warnings = warnings
+	return result, nil
+}
+
+// checkCanChangeControllers checks that the caller is a controller
+// superuser, and that changes are allowed.
+func (api *HighAvailabilityAPI) checkCanChangeControllers() error {
+	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !admin {
+		return apiservererrors.ServerError(apiservererrors.ErrPerm)
+	}
+	if !api.state.IsController() {
+		return errors.New("unsupported with hosted models")
+	}
+	return errors.Trace(common.NewBlockChecker(api.state).RemoveAllowed())
+}
+
+// RemoveControllerNodes isn't on the V2 API.
+func (*HighAvailabilityAPIV2) RemoveControllerNodes(_, _ struct{}) {}
+
+// ReplaceControllerNodes isn't on the V2 API.
+func (*HighAvailabilityAPIV2) ReplaceControllerNodes(_, _ struct{}) {}
+
 func (api *HighAvailabilityAPI) enableHASingle(st *state.State, spec params.ControllersSpec) (
 	params.ControllersChanges, error,
 ) {
@@ -142,12 +279,19 @@ func (api *HighAvailabilityAPI) enableHASingle(st *state.State, spec params.Cont
 		return params.ControllersChanges{}, errors.Trace(err)
 	}
 
+	placement, warnings, err := api.zonePlacement(st, controllerIds, spec.NumControllers, spec.Placement)
+	if err != nil {
+		return params.ControllersChanges{}, errors.Trace(err)
+	}
+
 	// Might be nicer to pass the spec itself to this method.
-	changes, err := st.EnableHA(spec.NumControllers, spec.Constraints, spec.Series, spec.Placement)
+	changes, err := st.EnableHA(spec.NumControllers, spec.Constraints, spec.Series, placement)
 	if err != nil {
 		return params.ControllersChanges{}, err
 	}
-	return controllersChanges(changes), nil
+	result := controllersChanges(changes)
+	result.Warnings = warnings
+	return result, nil
 }
 
 // getReferenceController looks up the ideal controller to use as a reference for Constraints and Release
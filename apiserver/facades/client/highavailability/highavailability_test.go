@@ -18,6 +18,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -600,3 +601,136 @@ func (s *clientSuite) TestHighAvailabilityCAASFails(c *gc.C) {
 	_, err := highavailability.NewHighAvailabilityAPI(st, s.resources, s.authoriser)
 	c.Assert(err, gc.ErrorMatches, "high availability on kubernetes controllers not supported")
 }
+
+func (s *clientSuite) TestRemoveControllerNodes(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.haServer.RemoveControllerNodes(params.RemoveControllerNodesArgs{
+		Nodes: []params.RemoveControllerNodeArg{
+			{MachineTag: "machine-1"},
+			{MachineTag: "machine-42"},
+			{MachineTag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "machine 42 not found")
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid machine tag`)
+
+	m, err := s.State.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.Life(), gc.Equals, state.Dying)
+	node, err := s.State.ControllerNode("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(node.WantsVote(), jc.IsFalse)
+}
+
+func (s *clientSuite) TestRemoveControllerNodesBlocked(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BlockRemoveObject(c, "TestRemoveControllerNodesBlocked")
+	_, err = s.haServer.RemoveControllerNodes(params.RemoveControllerNodesArgs{
+		Nodes: []params.RemoveControllerNodeArg{{MachineTag: "machine-1"}},
+	})
+	s.AssertBlocked(c, err, "TestRemoveControllerNodesBlocked")
+}
+
+func (s *clientSuite) TestReplaceControllerNodes(c *gc.C) {
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.haServer.ReplaceControllerNodes(params.ReplaceControllerNodesArgs{
+		Nodes: []params.ReplaceControllerNodeArg{{MachineTag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	changes := results.Results[0].Result
+	c.Check(changes.Added, gc.DeepEquals, []string{"machine-3"})
+	c.Check(changes.Removed, gc.DeepEquals, []string{"machine-0"})
+
+	// The new machine has the constraints and series of the one it replaces.
+	m, err := s.State.Machine("3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.Series(), gc.Equals, "quantal")
+	cons, err := m.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons, gc.DeepEquals, controllerCons)
+}
+
+func (s *clientSuite) TestRemoveControllerNodesPermission(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	authoriser := apiservertesting.FakeAuthorizer{Tag: user.Tag()}
+	haServer, err := highavailability.NewHighAvailabilityAPI(s.State, s.resources, authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = haServer.RemoveControllerNodes(params.RemoveControllerNodesArgs{
+		Nodes: []params.RemoveControllerNodeArg{{MachineTag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *clientSuite) setMachineZone(c *gc.C, machineId, zone string) {
+	m, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetProvisioned(instance.Id("i-"+machineId), "", "fake_nonce", &instance.HardwareCharacteristics{
+		AvailabilityZone: &zone,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestEnableHASpreadsZones(c *gc.C) {
+	s.setMachineZone(c, "0", "zone1")
+	enableHAResult, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enableHAResult.Added, gc.DeepEquals, []string{"machine-1", "machine-2"})
+	c.Assert(enableHAResult.Warnings, gc.HasLen, 0)
+
+	// The dummy provider has zones zone1, zone3 and zone4 available.
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 3)
+	expectedPlacement := []string{"", "zone=zone3", "zone=zone4"}
+	for i, m := range machines {
+		c.Check(m.Placement(), gc.Equals, expectedPlacement[i])
+	}
+}
+
+func (s *clientSuite) TestEnableHAWarnsOnTooFewZones(c *gc.C) {
+	highavailability.SetAvailabilityZones(s.haServer, func() ([]string, error) {
+		return []string{"zone1"}, nil
+	})
+	s.setMachineZone(c, "0", "zone1")
+	enableHAResult, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enableHAResult.Added, gc.DeepEquals, []string{"machine-1", "machine-2"})
+	c.Assert(enableHAResult.Warnings, jc.DeepEquals, []string{
+		`3 of 3 controllers will be in zone "zone1"; losing the zone would lose quorum`,
+	})
+}
+
+func (s *clientSuite) TestEnableHARefusesPlacementLosingQuorum(c *gc.C) {
+	s.setMachineZone(c, "0", "zone1")
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, []string{"zone=zone1"})
+	c.Assert(err, gc.ErrorMatches, `placement would leave a majority of controllers in zone "zone1"; `+
+		`losing the zone would lose quorum \(available zones: zone1, zone3, zone4\)`)
+
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+}
+
+func (s *clientSuite) TestEnableHAWithoutZones(c *gc.C) {
+	highavailability.SetAvailabilityZones(s.haServer, func() ([]string, error) {
+		return nil, errors.NotSupportedf("availability zones")
+	})
+	_, err := s.enableHA(c, 3, emptyCons, defaultSeries, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 3)
+	for _, m := range machines {
+		c.Check(m.Placement(), gc.Equals, "")
+	}
+}
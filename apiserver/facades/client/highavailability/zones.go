@@ -0,0 +1,190 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package highavailability
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/controller"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	providercommon "github.com/juju/juju/provider/common"
+	"github.com/juju/juju/state"
+)
+
+// zonePlacementPrefix is the prefix of placement directives which start
+// a machine in a given availability zone.
+const zonePlacementPrefix = "zone="
+
+// availabilityZones returns the names of the available zones in the
+// model's cloud. If the cloud doesn't support zones, an error satisfying
+// errors.IsNotSupported is returned.
+func availabilityZones(getter environs.EnvironConfigGetter, ctx context.ProviderCallContext) ([]string, error) {
+	env, err := environs.GetEnviron(getter, environs.New)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening environment")
+	}
+	zonedEnv, ok := env.(providercommon.ZonedEnviron)
+	if !ok {
+		return nil, errors.NotSupportedf("availability zones")
+	}
+	zones, err := zonedEnv.AvailabilityZones(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, zone := range zones {
+		if zone.Available() {
+			names = append(names, zone.Name())
+		}
+	}
+	return names, nil
+}
+
+// zonePlacement works out where the new controllers started by
+// enable-ha should go, and whether the controllers would then survive
+// the failure of any one availability zone.
+//
+// If no placement is given, new controllers are spread across the
+// available zones. Otherwise the placement is used as is, but is refused
+// if it would leave a zone whose failure loses quorum when spreading the
+// new controllers would not. A warning is returned when quorum can't be
+// kept through a zone failure, such as when there are too few zones.
+func (api *HighAvailabilityAPI) zonePlacement(
+	st *state.State, controllerIds []string, numControllers int, placement []string,
+) ([]string, []string, error) {
+	zones, err := api.availabilityZones()
+	if errors.IsNotSupported(err) || (err == nil && len(zones) == 0) {
+		return placement, nil, nil
+	} else if err != nil {
+		return nil, nil, errors.Annotate(err, "getting availability zones")
+	}
+
+	voters := make(map[string]int)
+	numVoters := 0
+	for _, id := range controllerIds {
+		node, err := st.ControllerNode(id)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if !node.WantsVote() {
+			continue
+		}
+		zone, err := machineZone(st, id)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		voters[zone]++
+		numVoters++
+	}
+	if numControllers == 0 {
+		numControllers = numVoters + (numVoters+1)%2
+		if numControllers <= 1 {
+			numControllers = 3
+		}
+	}
+	numNew := numControllers - numVoters
+	if numNew <= 0 {
+		return placement, nil, nil
+	}
+
+	spread := controller.SpreadZones(zones, voters, numNew)
+	spreadVoters := copyVoters(voters)
+	for _, zone := range spread {
+		spreadVoters[zone]++
+	}
+	if len(placement) == 0 {
+		placement = make([]string, len(spread))
+		for i, zone := range spread {
+			placement[i] = zonePlacementPrefix + zone
+		}
+		return placement, quorumWarnings(spreadVoters), nil
+	}
+
+	placedVoters := copyVoters(voters)
+	for i := 0; i < numNew; i++ {
+		zone := ""
+		if i < len(placement) {
+			if zone, err = placementZone(st, placement[i]); err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+		}
+		placedVoters[zone]++
+	}
+	zone, lost := controller.ZoneFailureLosesQuorum(placedVoters)
+	if !lost {
+		return placement, nil, nil
+	}
+	if _, spreadLost := controller.ZoneFailureLosesQuorum(spreadVoters); !spreadLost {
+		return nil, nil, errors.Errorf(
+			"placement would leave a majority of controllers in zone %q; "+
+				"losing the zone would lose quorum (available zones: %s)",
+			zone, strings.Join(zones, ", "),
+		)
+	}
+	return placement, quorumWarnings(placedVoters), nil
+}
+
+// machineZone returns the availability zone of the machine with the
+// given id, or the empty string if it isn't known.
+func machineZone(st *state.State, id string) (string, error) {
+	m, err := st.Machine(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	zone, err := m.AvailabilityZone()
+	if errors.IsNotProvisioned(err) {
+		return "", nil
+	}
+	return zone, errors.Trace(err)
+}
+
+// placementZone returns the availability zone implied by a placement
+// directive, or the empty string if it isn't known.
+func placementZone(st *state.State, placement string) (string, error) {
+	if strings.HasPrefix(placement, zonePlacementPrefix) {
+		return strings.TrimPrefix(placement, zonePlacementPrefix), nil
+	}
+	p, err := instance.ParsePlacement(placement)
+	if err != nil || p.Scope != instance.MachineScope {
+		return "", nil
+	}
+	zone, err := machineZone(st, p.Directive)
+	if errors.IsNotFound(err) {
+		// Let EnableHA report the missing machine.
+		return "", nil
+	}
+	return zone, errors.Trace(err)
+}
+
+func quorumWarnings(voters map[string]int) []string {
+	zone, lost := controller.ZoneFailureLosesQuorum(voters)
+	if !lost {
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"%d of %d controllers will be in zone %q; losing the zone would lose quorum",
+		voters[zone], totalVoters(voters), zone,
+	)}
+}
+
+func copyVoters(voters map[string]int) map[string]int {
+	result := make(map[string]int, len(voters))
+	for zone, n := range voters {
+		result[zone] = n
+	}
+	return result
+}
+
+func totalVoters(voters map[string]int) int {
+	total := 0
+	for _, n := range voters {
+		total += n
+	}
+	return total
+}
@@ -4,6 +4,7 @@
 package modelmanager
 
 import (
+	"github.com/juju/description/v3"
 	"github.com/juju/names/v4"
 	gc "gopkg.in/check.v1"
 )
@@ -12,3 +13,7 @@ func AuthCheck(c *gc.C, mm *ModelManagerAPI, user names.UserTag) bool {
 	mm.authCheck(user)
 	return mm.isAdmin
 }
+
+func SetCloneTopology(mm *ModelManagerAPI, f func(description.Model, string, int) error) {
+	mm.cloneTopology = f
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
@@ -185,7 +186,9 @@ func (s *modelInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 func (s *modelInfoSuite) TestModelInfoV7(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV7{
 		&modelmanager.ModelManagerAPIV8{
-			s.modelmanager,
+			&modelmanager.ModelManagerAPIV9{
+				&modelmanager.ModelManagerAPIV10{s.modelmanager},
+			},
 		},
 	}
 
@@ -426,6 +429,36 @@ func (s *modelInfoSuite) TestFailedMigration(c *gc.C) {
 	c.Assert(*migrationResult.End, gc.Equals, end)
 }
 
+func (s *modelInfoSuite) TestMigrationProgress(c *gc.C) {
+	start := time.Now().Add(-20 * time.Minute)
+	imported := time.Now().Add(-10 * time.Minute)
+	s.st.migration = &mockMigration{
+		status: "uploading model binaries into target controller",
+		start:  start,
+		phase:  migration.IMPORT,
+		phaseTimes: map[migration.Phase]time.Time{
+			migration.QUIESCE: start,
+			migration.IMPORT:  imported,
+		},
+		progress: migration.TransferProgress{Charms: 2, CharmsTotal: 5},
+		retries:  1,
+	}
+
+	results, err := s.modelmanager.ModelInfo(params.Entities{
+		Entities: []params.Entity{{coretesting.ModelTag.String()}},
+	})
+
+	c.Assert(err, jc.ErrorIsNil)
+	migrationResult := results.Results[0].Result.Migration
+	c.Assert(migrationResult.Phase, gc.Equals, "IMPORT")
+	c.Assert(migrationResult.PhaseTimes, jc.DeepEquals, map[string]time.Time{
+		"QUIESCE": start,
+		"IMPORT":  imported,
+	})
+	c.Assert(migrationResult.Progress, jc.DeepEquals, &params.MigrationProgress{Charms: 2, CharmsTotal: 5})
+	c.Assert(migrationResult.Retries, gc.Equals, 1)
+}
+
 func (s *modelInfoSuite) TestNoMigration(c *gc.C) {
 	results, err := s.modelmanager.ModelInfo(params.Entities{
 		Entities: []params.Entity{{coretesting.ModelTag.String()}},
@@ -687,6 +720,11 @@ func (st *mockState) Export() (description.Model, error) {
 	return &fakeModelDescription{UUID: st.model.UUID()}, nil
 }
 
+func (st *mockState) ExportConsistent(attempts int) (description.Model, error) {
+	st.MethodCall(st, "ExportConsistent", attempts)
+	return &fakeModelDescription{UUID: st.model.UUID()}, nil
+}
+
 func (st *mockState) ExportPartial(cfg state.ExportConfig) (description.Model, error) {
 	st.MethodCall(st, "ExportPartial", cfg)
 	if !cfg.IgnoreIncompleteModel {
@@ -1244,9 +1282,29 @@ type mockModelUser struct {
 type mockMigration struct {
 	state.ModelMigration
 
-	status string
-	start  time.Time
-	end    time.Time
+	status     string
+	start      time.Time
+	end        time.Time
+	phase      migration.Phase
+	phaseTimes map[migration.Phase]time.Time
+	progress   migration.TransferProgress
+	retries    int
+}
+
+func (m *mockMigration) Phase() (migration.Phase, error) {
+	return m.phase, nil
+}
+
+func (m *mockMigration) PhaseTimes() map[migration.Phase]time.Time {
+	return m.phaseTimes
+}
+
+func (m *mockMigration) Progress() migration.TransferProgress {
+	return m.progress
+}
+
+func (m *mockMigration) Retries() int {
+	return m.retries
 }
 
 func (m *mockMigration) StatusMessage() string {
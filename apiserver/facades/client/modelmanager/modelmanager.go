@@ -42,6 +42,20 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV10 defines the methods on the version 10 facade for the
+// modelmanager API endpoint.
+type ModelManagerV10 interface {
+	ModelManagerV9
+	ExportModels(args params.Entities) params.SerializedModelResults
+}
+
+// ModelManagerV11 defines the methods on the version 11 facade for the
+// modelmanager API endpoint.
+type ModelManagerV11 interface {
+	ModelManagerV10
+	CloneModels(args params.CloneModelArgs) params.ModelInfoResults
+}
+
 // ModelManagerV9 defines the methods on the version 9 facade for the
 // modelmanager API endpoint.
 type ModelManagerV9 interface {
@@ -155,12 +169,28 @@ type ModelManagerAPI struct {
 	model       common.Model
 	getBroker   newCaasBrokerFunc
 	callContext context.ProviderCallContext
+
+	// cloneTopology adds the applications, relations and offers of
+	// the source model description to the model with the target UUID.
+	cloneTopology func(source description.Model, targetUUID string, maxUnits int) error
+}
+
+// ModelManagerAPIV10 provides a way to wrap the different calls between
+// version 10 and version 11 of the model manager API
+type ModelManagerAPIV10 struct {
+	*ModelManagerAPI
+}
+
+// ModelManagerAPIV9 provides a way to wrap the different calls between
+// version 9 and version 10 of the model manager API
+type ModelManagerAPIV9 struct {
+	*ModelManagerAPIV10
 }
 
 // ModelManagerAPIV8 provides a way to wrap the different calls between
-// version 8 and version 8 of the model manager API
+// version 8 and version 9 of the model manager API
 type ModelManagerAPIV8 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV9
 }
 
 // ModelManagerAPIV7 provides a way to wrap the different calls between
@@ -200,18 +230,20 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV9 = (*ModelManagerAPI)(nil)
-	_ ModelManagerV8 = (*ModelManagerAPIV8)(nil)
-	_ ModelManagerV7 = (*ModelManagerAPIV7)(nil)
-	_ ModelManagerV6 = (*ModelManagerAPIV6)(nil)
-	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
-	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
-	_ ModelManagerV3 = (*ModelManagerAPIV3)(nil)
-	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
+	_ ModelManagerV11 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV10 = (*ModelManagerAPIV10)(nil)
+	_ ModelManagerV9  = (*ModelManagerAPIV9)(nil)
+	_ ModelManagerV8  = (*ModelManagerAPIV8)(nil)
+	_ ModelManagerV7  = (*ModelManagerAPIV7)(nil)
+	_ ModelManagerV6  = (*ModelManagerAPIV6)(nil)
+	_ ModelManagerV5  = (*ModelManagerAPIV5)(nil)
+	_ ModelManagerV4  = (*ModelManagerAPIV4)(nil)
+	_ ModelManagerV3  = (*ModelManagerAPIV3)(nil)
+	_ ModelManagerV2  = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV9 is used for API registration.
-func NewFacadeV9(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV11 is used for API registration.
+func NewFacadeV11(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -237,7 +269,7 @@ func NewFacadeV9(ctx facade.Context) (*ModelManagerAPI, error) {
 	}
 	apiUser, _ := auth.GetAuthTag().(names.UserTag)
 
-	return NewModelManagerAPI(
+	api, err := NewModelManagerAPI(
 		common.NewUserAwareModelManagerBackend(model, pool, apiUser),
 		common.NewModelManagerBackend(ctrlModel, pool),
 		statePoolShim{
@@ -249,6 +281,45 @@ func NewFacadeV9(ctx facade.Context) (*ModelManagerAPI, error) {
 		model,
 		context.CallContext(st),
 	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.cloneTopology = func(source description.Model, targetUUID string, maxUnits int) error {
+		srcSt, err := pool.Get(source.Tag().Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer srcSt.Release()
+		dstSt, err := pool.Get(targetUUID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer dstSt.Release()
+		return dstSt.CloneTopology(state.CloneTopologyArgs{
+			Source:   srcSt.State,
+			Model:    source,
+			MaxUnits: maxUnits,
+		})
+	}
+	return api, nil
+}
+
+// NewFacadeV10 is used for API registration.
+func NewFacadeV10(ctx facade.Context) (*ModelManagerAPIV10, error) {
+	v11, err := NewFacadeV11(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV10{v11}, nil
+}
+
+// NewFacadeV9 is used for API registration.
+func NewFacadeV9(ctx facade.Context) (*ModelManagerAPIV9, error) {
+	v10, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV9{v10}, nil
 }
 
 // NewFacadeV8 is used for API registration.
@@ -640,7 +711,8 @@ func (m *ModelManagerAPI) newCAASModel(
 				`
 the model cannot be created because a namespace with the proposed
 model name already exists in the k8s cluster.
-Please choose a different model name.
+Please choose a different model name, or set adopt-namespace=true
+to attach the model to the existing namespace.
 `[1:],
 			))
 		}
@@ -882,6 +954,187 @@ func (m *ModelManagerAPIV2) DumpModels(args params.Entities) params.MapResults {
 	return results
 }
 
+// exportModelAttempts is the number of times an export is retried when
+// the model changes while it is being read.
+const exportModelAttempts = 5
+
+// ExportModels serializes the specified models along with lists of
+// the charms, agent binaries and resources they use, so that they can
+// be archived and imported into another controller. The user needs to
+// either be a controller admin, or have admin privileges on the model
+// itself.
+func (m *ModelManagerAPI) ExportModels(args params.Entities) params.SerializedModelResults {
+	results := params.SerializedModelResults{
+		Results: make([]params.SerializedModelResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		serialized, err := m.exportModel(entity)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &serialized
+	}
+	return results
+}
+
+func (m *ModelManagerAPI) exportModel(args params.Entity) (params.SerializedModel, error) {
+	modelTag, err := names.ParseModelTag(args.Tag)
+	if err != nil {
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+
+	isModelAdmin, err := m.authorizer.HasPermission(permission.AdminAccess, modelTag)
+	if err != nil {
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+	if !isModelAdmin && !m.isAdmin {
+		return params.SerializedModel{}, apiservererrors.ErrPerm
+	}
+
+	st, release, err := m.state.GetBackend(modelTag.Id())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return params.SerializedModel{}, errors.Trace(apiservererrors.ErrBadId)
+		}
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+	defer release()
+
+	// Unlike a migration, an offline export doesn't lock the model, so
+	// make sure the export isn't torn by concurrent changes.
+	model, err := st.ExportConsistent(exportModelAttempts)
+	if err != nil {
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+	serialized, err := common.SerializeModel(model)
+	return serialized, errors.Trace(err)
+}
+
+// CloneModels creates new models with the same applications,
+// relations and offers as existing models. Machines, units, relation
+// data and storage are not copied; the new models are given fresh
+// machines for their units.
+func (m *ModelManagerAPI) CloneModels(args params.CloneModelArgs) params.ModelInfoResults {
+	results := params.ModelInfoResults{
+		Results: make([]params.ModelInfoResult, len(args.Models)),
+	}
+	for i, arg := range args.Models {
+		info, err := m.cloneModel(arg)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &info
+	}
+	return results
+}
+
+func (m *ModelManagerAPI) cloneModel(args params.CloneModelArg) (params.ModelInfo, error) {
+	if m.cloneTopology == nil {
+		return params.ModelInfo{}, errors.NotSupportedf("cloning models")
+	}
+	modelTag, err := names.ParseModelTag(args.SourceModelTag)
+	if err != nil {
+		return params.ModelInfo{}, errors.Trace(err)
+	}
+
+	isModelAdmin, err := m.authorizer.HasPermission(permission.AdminAccess, modelTag)
+	if err != nil {
+		return params.ModelInfo{}, errors.Trace(err)
+	}
+	if !isModelAdmin && !m.isAdmin {
+		return params.ModelInfo{}, apiservererrors.ErrPerm
+	}
+
+	st, release, err := m.state.GetBackend(modelTag.Id())
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return params.ModelInfo{}, errors.Trace(apiservererrors.ErrBadId)
+		}
+		return params.ModelInfo{}, errors.Trace(err)
+	}
+	defer release()
+
+	srcModel, err := st.Model()
+	if err != nil {
+		return params.ModelInfo{}, errors.Trace(err)
+	}
+	srcConfig, err := srcModel.Config()
+	if err != nil {
+		return params.ModelInfo{}, errors.Trace(err)
+	}
+	// The model topology is all that is cloned, so the parts of the
+	// export that refer to machines, units and their data are skipped.
+	topology, err := st.ExportPartial(state.ExportConfig{
+		IgnoreIncompleteModel:    true,
+		SkipActions:              true,
+		SkipCloudImageMetadata:   true,
+		SkipCredentials:          true,
+		SkipIPAddresses:          true,
+		SkipSSHHostKeys:          true,
+		SkipStatusHistory:        true,
+		SkipLinkLayerDevices:     true,
+		SkipRelationData:         true,
+		SkipInstanceData:         true,
+		SkipUnitAgentBinaries:    true,
+		SkipMachineAgentBinaries: true,
+		SkipOfferConnections:     true,
+		SkipExternalControllers:  true,
+	})
+	if err != nil {
+		return params.ModelInfo{}, errors.Trace(err)
+	}
+
+	cfg := srcConfig.AllAttrs()
+	for _, key := range []string{config.NameKey, config.UUIDKey, config.AgentVersionKey} {
+		delete(cfg, key)
+	}
+	for key, value := range args.Config {
+		cfg[key] = value
+	}
+	ownerTag := args.OwnerTag
+	if ownerTag == "" {
+		ownerTag = m.apiUser.String()
+	}
+	createArgs := params.ModelCreateArgs{
+		Name:        args.Name,
+		OwnerTag:    ownerTag,
+		Config:      cfg,
+		CloudTag:    names.NewCloudTag(srcModel.CloudName()).String(),
+		CloudRegion: srcModel.CloudRegion(),
+	}
+	if credentialTag, ok := srcModel.CloudCredentialTag(); ok {
+		createArgs.CloudCredentialTag = credentialTag.String()
+	}
+	info, err := m.CreateModel(createArgs)
+	if err != nil {
+		return params.ModelInfo{}, errors.Trace(err)
+	}
+
+	if err := m.cloneTopology(topology, info.UUID, args.MaxUnits); err != nil {
+		// Don't leave a half populated model behind.
+		if destroyErr := m.destroyClonedModel(info.UUID); destroyErr != nil {
+			logger.Errorf("cannot destroy partially cloned model %q: %v", args.Name, destroyErr)
+			return params.ModelInfo{}, errors.Annotatef(err, "model %q created but cloning applications failed", args.Name)
+		}
+		return params.ModelInfo{}, errors.Annotatef(err, "cloning applications into model %q", args.Name)
+	}
+	return info, nil
+}
+
+// destroyClonedModel forcibly destroys a model whose topology could not
+// be cloned. The model has no data of its own, so nothing is kept.
+func (m *ModelManagerAPI) destroyClonedModel(modelUUID string) error {
+	st, release, err := m.state.GetBackend(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+	destroyStorage, force := true, true
+	return errors.Trace(common.DestroyModel(st, &destroyStorage, &force, nil))
+}
+
 // DumpModelsDB will gather all documents from all model collections
 // for the specified model. The map result contains a map of collection
 // names to lists of documents represented as maps.
@@ -1282,10 +1535,32 @@ func (m *ModelManagerAPI) getModelInfo(tag names.ModelTag) (params.ModelInfo, er
 		if *endTime == zero {
 			endTime = nil
 		}
+		phase, err := migration.Phase()
+		if err != nil {
+			return params.ModelInfo{}, errors.Trace(err)
+		}
+		phaseTimes := make(map[string]time.Time)
+		for p, t := range migration.PhaseTimes() {
+			phaseTimes[p.String()] = t
+		}
+		progress := migration.Progress()
 		info.Migration = &params.ModelMigrationStatus{
-			Status: migration.StatusMessage(),
-			Start:  &startTime,
-			End:    endTime,
+			Status:     migration.StatusMessage(),
+			Start:      &startTime,
+			End:        endTime,
+			Phase:      phase.String(),
+			PhaseTimes: phaseTimes,
+			Progress: &params.MigrationProgress{
+				Charms:         progress.Charms,
+				CharmsTotal:    progress.CharmsTotal,
+				Tools:          progress.Tools,
+				ToolsTotal:     progress.ToolsTotal,
+				Resources:      progress.Resources,
+				ResourcesTotal: progress.ResourcesTotal,
+				LogMessages:    progress.LogMessages,
+				LogBytes:       progress.LogBytes,
+			},
+			Retries: migration.Retries(),
 		}
 	}
 	return info, nil
@@ -1762,3 +2037,9 @@ func (*ModelManagerAPIV5) ModelDefaultsForClouds(_, _ struct{}) {}
 
 // ValidateModelUpgrade did not exist prior to v9.
 func (*ModelManagerAPIV8) ValidateModelUpgrade(_, _ struct{}) {}
+
+// ExportModels did not exist prior to v10.
+func (*ModelManagerAPIV9) ExportModels(_, _ struct{}) {}
+
+// CloneModels did not exist prior to v11.
+func (*ModelManagerAPIV10) CloneModels(_, _ struct{}) {}
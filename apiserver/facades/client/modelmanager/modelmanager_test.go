@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/juju/description/v3"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -479,6 +480,7 @@ func (s *modelManagerSuite) TestCreateCAASModelArgs(c *gc.C) {
 		"something":                         "value",
 		"operator-storage":                  "",
 		"workload-storage":                  "",
+		"adopt-namespace":                   false,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -690,7 +692,9 @@ func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
 							&modelmanager.ModelManagerAPIV8{
-								s.api,
+								&modelmanager.ModelManagerAPIV9{
+									&modelmanager.ModelManagerAPIV10{s.api},
+								},
 							},
 						},
 					},
@@ -838,6 +842,173 @@ func (s *modelManagerSuite) TestDumpModelsDBUsers(c *gc.C) {
 	}
 }
 
+func (s *modelManagerSuite) TestExportModelsBadTags(c *gc.C) {
+	results := s.api.ExportModels(params.Entities{
+		Entities: []params.Entity{{
+			Tag: "bad-tag",
+		}, {
+			Tag: "application-foo",
+		}}})
+
+	c.Assert(results.Results, gc.HasLen, 2)
+	bad, notApp := results.Results[0], results.Results[1]
+	c.Check(bad.Result, gc.IsNil)
+	c.Check(bad.Error.Message, gc.Equals, `"bad-tag" is not a valid tag`)
+
+	c.Check(notApp.Result, gc.IsNil)
+	c.Check(notApp.Error.Message, gc.Equals, `"application-foo" is not a valid model tag`)
+}
+
+func (s *modelManagerSuite) TestExportModelsMissingModel(c *gc.C) {
+	s.st.SetErrors(errors.NotFoundf("boom"))
+	tag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f000")
+	results := s.api.ExportModels(params.Entities{Entities: []params.Entity{{Tag: tag.String()}}})
+	s.st.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ControllerTag", nil},
+		{"ModelUUID", nil},
+		{"Model", nil},
+		{"GetBackend", []interface{}{tag.Id()}},
+	})
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, gc.NotNil)
+	c.Check(result.Error.Code, gc.Equals, `not found`)
+	c.Check(result.Error.Message, gc.Equals, `id not found`)
+}
+
+func (s *modelManagerSuite) TestExportModelsUsers(c *gc.C) {
+	models := params.Entities{Entities: []params.Entity{{Tag: s.st.ModelTag().String()}}}
+	for _, user := range []names.UserTag{
+		names.NewUserTag("otheruser"),
+		names.NewUserTag("unknown"),
+	} {
+		s.setAPIUser(c, user)
+		results := s.api.ExportModels(models)
+		c.Assert(results.Results, gc.HasLen, 1)
+		result := results.Results[0]
+		c.Assert(result.Result, gc.IsNil)
+		c.Assert(result.Error, gc.NotNil)
+		c.Check(result.Error.Message, gc.Equals, `permission denied`)
+	}
+}
+
+func (s *modelManagerSuite) TestExportModelsNotInV9(c *gc.C) {
+	api := &modelmanager.ModelManagerAPIV9{&modelmanager.ModelManagerAPIV10{s.api}}
+	_, ok := interface{}(api).(modelmanager.ModelManagerV10)
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *modelManagerSuite) TestCloneModels(c *gc.C) {
+	var (
+		source     description.Model
+		targetUUID string
+		maxUnits   int
+	)
+	modelmanager.SetCloneTopology(s.api, func(m description.Model, uuid string, n int) error {
+		source, targetUUID, maxUnits = m, uuid, n
+		return nil
+	})
+	results := s.api.CloneModels(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: s.st.ModelTag().String(),
+			Name:           "staging",
+			Config:         map[string]interface{}{"bar": "baz"},
+			MaxUnits:       1,
+		}}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.NotNil)
+
+	newModelArgs := s.getModelArgs(c)
+	c.Check(newModelArgs.Config.Name(), gc.Equals, "staging")
+	c.Check(newModelArgs.Config.AllAttrs()["bar"], gc.Equals, "baz")
+	c.Check(newModelArgs.Owner, gc.Equals, names.NewUserTag("admin"))
+	c.Check(newModelArgs.CloudName, gc.Equals, "some-cloud")
+
+	c.Check(source.(*fakeModelDescription).UUID, gc.Equals, s.st.ModelUUID())
+	c.Check(targetUUID, gc.Equals, result.Result.UUID)
+	c.Check(maxUnits, gc.Equals, 1)
+}
+
+func (s *modelManagerSuite) TestCloneModelsFailure(c *gc.C) {
+	modelmanager.SetCloneTopology(s.api, func(description.Model, string, int) error {
+		return errors.New("boom")
+	})
+	results := s.api.CloneModels(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: s.st.ModelTag().String(),
+			Name:           "staging",
+		}}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cloning applications into model "staging": boom`)
+
+	// The partially cloned model is destroyed.
+	destroyStorage, force := true, true
+	calls := s.st.model.Calls()
+	c.Assert(calls, gc.Not(gc.HasLen), 0)
+	c.Check(calls[len(calls)-1], jc.DeepEquals, gitjujutesting.StubCall{
+		FuncName: "Destroy",
+		Args: []interface{}{state.DestroyModelParams{
+			DestroyStorage: &destroyStorage,
+			Force:          &force,
+			MaxWait:        common.MaxWait(nil),
+		}},
+	})
+}
+
+func (s *modelManagerSuite) TestCloneModelsFailureDestroyFails(c *gc.C) {
+	modelmanager.SetCloneTopology(s.api, func(description.Model, string, int) error {
+		s.st.SetErrors(errors.New("no backend"))
+		return errors.New("boom")
+	})
+	results := s.api.CloneModels(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: s.st.ModelTag().String(),
+			Name:           "staging",
+		}}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `model "staging" created but cloning applications failed: boom`)
+}
+
+func (s *modelManagerSuite) TestCloneModelsNotSupported(c *gc.C) {
+	results := s.api.CloneModels(params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: s.st.ModelTag().String(),
+			Name:           "staging",
+		}}})
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cloning models not supported`)
+}
+
+func (s *modelManagerSuite) TestCloneModelsUsers(c *gc.C) {
+	args := params.CloneModelArgs{
+		Models: []params.CloneModelArg{{
+			SourceModelTag: s.st.ModelTag().String(),
+			Name:           "staging",
+		}}}
+	for _, user := range []names.UserTag{
+		names.NewUserTag("otheruser"),
+		names.NewUserTag("unknown"),
+	} {
+		s.setAPIUser(c, user)
+		modelmanager.SetCloneTopology(s.api, func(description.Model, string, int) error {
+			c.Fatalf("unexpected clone")
+			return nil
+		})
+		results := s.api.CloneModels(args)
+		c.Assert(results.Results, gc.HasLen, 1)
+		c.Check(results.Results[0].Error, gc.ErrorMatches, `permission denied`)
+	}
+}
+
+func (s *modelManagerSuite) TestCloneModelsNotInV10(c *gc.C) {
+	api := &modelmanager.ModelManagerAPIV10{s.api}
+	_, ok := interface{}(api).(modelmanager.ModelManagerV11)
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *modelManagerSuite) TestAddModelCanCreateModel(c *gc.C) {
 	addModelUser := names.NewUserTag("add-model")
 	s.ctlrSt.cloudUsers[addModelUser.Id()] = permission.AddModelAccess
@@ -862,7 +1033,9 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
 						&modelmanager.ModelManagerAPIV8{
-							s.api,
+							&modelmanager.ModelManagerAPIV9{
+								&modelmanager.ModelManagerAPIV10{s.api},
+							},
 						},
 					},
 				},
@@ -1149,6 +1322,28 @@ func (s *modelManagerStateSuite) TestNonAdminModelManager(c *gc.C) {
 	c.Assert(modelmanager.AuthCheck(c, s.modelmanager, user), jc.IsFalse)
 }
 
+func (s *modelManagerStateSuite) TestExportModels(c *gc.C) {
+	s.setAPIUser(c, s.AdminUserTag(c))
+	f := factory.NewFactory(s.State, s.StatePool)
+	f.MakeApplication(c, &factory.ApplicationParams{
+		Charm: f.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+
+	results := s.modelmanager.ExportModels(params.Entities{
+		Entities: []params.Entity{{Tag: s.Model.ModelTag().String()}},
+	})
+	c.Assert(results.Results, gc.HasLen, 1)
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.NotNil)
+	c.Check(result.Result.Bytes, gc.Not(gc.HasLen), 0)
+	c.Check(result.Result.Charms, gc.HasLen, 1)
+
+	model, err := description.Deserialize(result.Result.Bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Tag(), gc.Equals, s.Model.ModelTag())
+}
+
 func (s *modelManagerStateSuite) TestDestroyOwnModel(c *gc.C) {
 	// TODO(perrito666) this test is not valid until we have
 	// proper controller permission since the only users that
@@ -1721,7 +1916,9 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
 							&modelmanager.ModelManagerAPIV8{
-								s.api,
+								&modelmanager.ModelManagerAPIV9{
+									&modelmanager.ModelManagerAPIV10{s.api},
+								},
 							},
 						},
 					},
@@ -1762,7 +1959,9 @@ func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
 						&modelmanager.ModelManagerAPIV8{
-							s.api,
+							&modelmanager.ModelManagerAPIV9{
+								&modelmanager.ModelManagerAPIV10{s.api},
+							},
 						},
 					},
 				},
@@ -1284,10 +1284,10 @@ func (mr *MockReloadSpacesEnvironMockRecorder) CloudSpec() *gomock.Call {
 }
 
 // GetEnviron mocks base method
-func (m *MockReloadSpacesEnviron) GetEnviron(arg0 environs.EnvironConfigGetter, arg1 environs.NewEnvironFunc) (environs.Environ, error) {
+func (m *MockReloadSpacesEnviron) GetEnviron(arg0 environs.EnvironConfigGetter, arg1 environs.NewEnvironFunc) (environs.BootstrapEnviron, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetEnviron", arg0, arg1)
-	ret0, _ := ret[0].(environs.Environ)
+	ret0, _ := ret[0].(environs.BootstrapEnviron)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
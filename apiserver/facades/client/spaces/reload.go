@@ -27,8 +27,8 @@ type ReloadSpacesEnviron interface {
 	environs.EnvironConfigGetter
 
 	// GetEnviron returns the environs.Environ ("provider") associated
-	// with the model.
-	GetEnviron(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error)
+	// with the model, or the CAAS broker for container models.
+	GetEnviron(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.BootstrapEnviron, error)
 }
 
 // EnvironSpaces defines methods for handling spaces within a environ setting.
@@ -113,9 +113,9 @@ type ReloadSpacesEnvirons struct {
 }
 
 // GetEnviron returns the environs.Environ ("provider") associated
-// with the model.
-func (ReloadSpacesEnvirons) GetEnviron(st environs.EnvironConfigGetter, fn environs.NewEnvironFunc) (environs.Environ, error) {
-	return environs.GetEnviron(st, fn)
+// with the model, or the CAAS broker for container models.
+func (ReloadSpacesEnvirons) GetEnviron(st environs.EnvironConfigGetter, fn environs.NewEnvironFunc) (environs.BootstrapEnviron, error) {
+	return getNetworkingEnviron(st, fn)
 }
 
 // DefaultReloadSpacesEnvirons creates a new ReloadSpacesEnviron from state.
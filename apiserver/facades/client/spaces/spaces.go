@@ -17,6 +17,7 @@ import (
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/environs"
@@ -396,7 +397,7 @@ func (api *API) ReloadSpaces() error {
 // checkSupportsSpaces checks if the environment implements NetworkingEnviron
 // and also if it supports spaces.
 func (api *API) checkSupportsSpaces() error {
-	env, err := environs.GetEnviron(api.backing, environs.New)
+	env, err := getNetworkingEnviron(api.backing, environs.New)
 	if err != nil {
 		return errors.Annotate(err, "getting environ")
 	}
@@ -406,6 +407,34 @@ func (api *API) checkSupportsSpaces() error {
 	return nil
 }
 
+// getNetworkingEnviron returns the provider that manages networking for
+// the model. For container models this is the CAAS broker, which is not
+// an environs.Environ but may still implement environs.Networking.
+func getNetworkingEnviron(
+	st environs.EnvironConfigGetter, newEnviron environs.NewEnvironFunc,
+) (environs.BootstrapEnviron, error) {
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cloudSpec, err := st.CloudSpec()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := environs.OpenParams{
+		Cloud:  cloudSpec,
+		Config: cfg,
+	}
+	if p, err := environs.Provider(cloudSpec.Type); err == nil {
+		if _, ok := p.(caas.ContainerEnvironProvider); ok {
+			broker, err := caas.Open(p, args)
+			return broker, errors.Trace(err)
+		}
+	}
+	env, err := newEnviron(args)
+	return env, errors.Trace(err)
+}
+
 func (api *API) getMachineCountBySpaceID(spaceID string) (int, error) {
 	var count int
 	machines, err := api.backing.AllMachines()
@@ -466,12 +495,12 @@ func (api *API) ensureSpacesAreMutable() error {
 // An error is returned if it is the provider and not the Juju operator
 // that determines the space topology.
 func (api *API) ensureSpacesNotProviderSourced() error {
-	env, err := environs.GetEnviron(api.backing, environs.New)
+	env, err := getNetworkingEnviron(api.backing, environs.New)
 	if err != nil {
 		return errors.Annotate(err, "retrieving environ")
 	}
 
-	netEnv, ok := env.(environs.NetworkingEnviron)
+	netEnv, ok := env.(environs.Networking)
 	if !ok {
 		return errors.NotSupportedf("provider networking")
 	}
@@ -63,10 +63,15 @@ type APIv2 struct {
 
 // APIv3 provides the subnets API facade for versions 3.
 type APIv3 struct {
+	*APIv4
+}
+
+// APIv4 provides the subnets API facade for version 4.
+type APIv4 struct {
 	*API
 }
 
-// API provides the subnets API facade for version 4.
+// API provides the subnets API facade for version 5.
 type API struct {
 	backing    Backing
 	resources  facade.Resources
@@ -85,13 +90,22 @@ func NewAPIv2(st *state.State, res facade.Resources, auth facade.Authorizer) (*A
 
 // NewAPIv3 is a wrapper that creates a V3 subnets API.
 func NewAPIv3(st *state.State, res facade.Resources, auth facade.Authorizer) (*APIv3, error) {
-	api, err := NewAPI(st, res, auth)
+	api, err := NewAPIv4(st, res, auth)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv3{api}, nil
 }
 
+// NewAPIv4 is a wrapper that creates a V4 subnets API.
+func NewAPIv4(st *state.State, res facade.Resources, auth facade.Authorizer) (*APIv4, error) {
+	api, err := NewAPI(st, res, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{api}, nil
+}
+
 // NewAPI creates a new Subnets API server-side facade with a
 // state.State backing.
 func NewAPI(st *state.State, res facade.Resources, auth facade.Authorizer) (*API, error) {
@@ -201,6 +215,59 @@ func (api *APIv2) AddSubnets(args params.AddSubnetsParamsV2) (params.ErrorResult
 	return api.addSubnets(newArgs)
 }
 
+// ImportSubnets adds subnets with the input CIDRs to the model,
+// without consulting the provider. This allows subnets to be recorded
+// for providers that can not discover them, such as manual.
+// Subnets with CIDRs already known to the model are not changed,
+// and an error satisfying errors.IsAlreadyExists is returned for them.
+func (api *API) ImportSubnets(args params.ImportSubnetsParams) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Subnets)),
+	}
+	cache := NewAddSubnetsCache(api.backing)
+	for i, arg := range args.Subnets {
+		if err := importOneSubnet(api.backing, arg, cache); err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
+// ImportSubnets is not available before version 5 of the API.
+func (*APIv4) ImportSubnets(_, _ struct{}) {}
+
+func importOneSubnet(api Backing, args params.ImportSubnetParams, cache *addSubnetsCache) error {
+	if !network.IsValidCIDR(args.CIDR) {
+		return errors.NotValidf("CIDR %q", args.CIDR)
+	}
+
+	var spaceID string
+	if args.SpaceTag != "" {
+		var err error
+		if spaceID, err = cache.validateSpace(api, args.SpaceTag); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	existing, err := api.SubnetsByCIDR(args.CIDR)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(existing) > 0 {
+		return errors.AlreadyExistsf("subnet %q in space %q", args.CIDR, existing[0].SpaceName())
+	}
+
+	_, err = api.AddSubnet(networkingcommon.BackingSubnetInfo{
+		CIDR:    args.CIDR,
+		SpaceID: spaceID,
+	})
+	return errors.Trace(err)
+}
+
 func (api *API) addSubnets(args params.AddSubnetsParams) (params.ErrorResults, error) {
 	results := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Subnets)),
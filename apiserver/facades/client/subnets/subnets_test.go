@@ -81,6 +81,46 @@ func (s *SubnetSuite) TestSubnetsByCIDR(c *gc.C) {
 	c.Check(results[2].Error.Message, gc.Equals, `CIDR "not-a-cidr" not valid`)
 }
 
+func (s *SubnetSuite) TestImportSubnets(c *gc.C) {
+	ctrl := s.setupSubnetsAPI(c)
+	defer ctrl.Finish()
+
+	existing := networkcommonmocks.NewMockBackingSubnet(ctrl)
+	existing.EXPECT().SpaceName().Return("alpha")
+
+	space := networkcommonmocks.NewMockBackingSpace(ctrl)
+	space.EXPECT().Name().Return("internal").AnyTimes()
+	space.EXPECT().Id().Return("1").AnyTimes()
+
+	bExp := s.mockBacking.EXPECT()
+	bExp.SubnetsByCIDR("10.0.0.0/24").Return(nil, nil)
+	bExp.AddSubnet(networkingcommon.BackingSubnetInfo{CIDR: "10.0.0.0/24"}).Return(nil, nil)
+	bExp.AllSpaces().Return([]networkingcommon.BackingSpace{space}, nil)
+	bExp.SubnetsByCIDR("10.0.1.0/24").Return(nil, nil)
+	bExp.AddSubnet(networkingcommon.BackingSubnetInfo{CIDR: "10.0.1.0/24", SpaceID: "1"}).Return(nil, nil)
+	bExp.SubnetsByCIDR("10.0.2.0/24").Return([]networkingcommon.BackingSubnet{existing}, nil)
+
+	res, err := s.api.ImportSubnets(params.ImportSubnetsParams{
+		Subnets: []params.ImportSubnetParams{
+			{CIDR: "10.0.0.0/24"},
+			{CIDR: "10.0.1.0/24", SpaceTag: "space-internal"},
+			{CIDR: "10.0.2.0/24"},
+			{CIDR: "10.0.3.0/24", SpaceTag: "space-missing"},
+			{CIDR: "not-a-cidr"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results := res.Results
+	c.Assert(results, gc.HasLen, 5)
+	c.Check(results[0].Error, gc.IsNil)
+	c.Check(results[1].Error, gc.IsNil)
+	c.Check(results[2].Error, gc.ErrorMatches, `subnet "10.0.2.0/24" in space "alpha" already exists`)
+	c.Check(results[2].Error, jc.Satisfies, params.IsCodeAlreadyExists)
+	c.Check(results[3].Error, gc.ErrorMatches, `space "missing" not found`)
+	c.Check(results[4].Error, gc.ErrorMatches, `CIDR "not-a-cidr" not valid`)
+}
+
 func (s *SubnetSuite) setupSubnetsAPI(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 	s.mockResource = facademocks.NewMockResources(ctrl)
@@ -392,7 +432,7 @@ func (s *SubnetsSuite) testAllSpacesSuccess(c *gc.C, withBackingSpaces apiserver
 		apiservertesting.WithSubnets,
 	)
 
-	api := &subnets.APIv3{API: s.facade}
+	api := &subnets.APIv3{APIv4: &subnets.APIv4{API: s.facade}}
 	results, err := api.AllSpaces()
 	c.Assert(err, jc.ErrorIsNil)
 	s.AssertAllSpacesResult(c, results, apiservertesting.BackingInstance.Spaces)
@@ -405,7 +445,7 @@ func (s *SubnetsSuite) testAllSpacesSuccess(c *gc.C, withBackingSpaces apiserver
 func (s *SubnetsSuite) TestAllSpacesFailure(c *gc.C) {
 	apiservertesting.SharedStub.SetErrors(errors.NotFoundf("boom"))
 
-	api := &subnets.APIv3{API: s.facade}
+	api := &subnets.APIv3{APIv4: &subnets.APIv4{API: s.facade}}
 	results, err := api.AllSpaces()
 	c.Assert(err, gc.ErrorMatches, "boom not found")
 	// Verify the cause is not obscured.
@@ -605,7 +645,7 @@ func (s *SubnetsSuite) TestAddSubnetAPI(c *gc.C) {
 func (s *SubnetsSuite) TestAddSubnetAPIv2(c *gc.C) {
 	apiservertesting.BackingInstance.SetUp(c, apiservertesting.StubNetworkingEnvironName,
 		apiservertesting.WithZones, apiservertesting.WithSpaces, apiservertesting.WithSubnets)
-	apiV2 := &subnets.APIv2{APIv3: &subnets.APIv3{API: s.facade}}
+	apiV2 := &subnets.APIv2{APIv3: &subnets.APIv3{APIv4: &subnets.APIv4{API: s.facade}}}
 	results, err := apiV2.AddSubnets(params.AddSubnetsParamsV2{
 		Subnets: []params.AddSubnetParamsV2{
 			{
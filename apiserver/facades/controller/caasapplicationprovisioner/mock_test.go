@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasapplicationprovisioner"
 	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/controller"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/resources"
@@ -42,6 +43,7 @@ type mockState struct {
 	app                *mockApplication
 	resource           *mockResources
 	operatorRepo       string
+	spaceInfos         network.SpaceInfos
 }
 
 func newMockState() *mockState {
@@ -99,6 +101,11 @@ func (st *mockState) ResolveConstraints(cons constraints.Value) (constraints.Val
 	return cons, nil
 }
 
+func (st *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
+	st.MethodCall(st, "AllSpaceInfos")
+	return st.spaceInfos, nil
+}
+
 func (st *mockState) Resources() (caasapplicationprovisioner.Resources, error) {
 	st.MethodCall(st, "Resources")
 	return st.resource, nil
@@ -191,6 +198,10 @@ type mockApplication struct {
 	storageConstraints   map[string]state.StorageConstraints
 	deviceConstraints    map[string]state.DeviceConstraints
 	charmModifiedVersion int
+	bindings             map[string]string
+	config               coreapplication.ConfigAttributes
+	status               status.StatusInfo
+	statusHistory        []status.StatusInfo
 }
 
 func (a *mockApplication) Tag() names.Tag {
@@ -281,11 +292,39 @@ func (a *mockApplication) SetStatus(statusInfo status.StatusInfo) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) Status() (status.StatusInfo, error) {
+	a.MethodCall(a, "Status")
+	return a.status, a.NextErr()
+}
+
+func (a *mockApplication) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	a.MethodCall(a, "StatusHistory", filter)
+	return a.statusHistory, a.NextErr()
+}
+
 func (a *mockApplication) CharmModifiedVersion() int {
 	a.MethodCall(a, "CharmModifiedVersion")
 	return a.charmModifiedVersion
 }
 
+func (a *mockApplication) EndpointBindings() (caasapplicationprovisioner.Bindings, error) {
+	a.MethodCall(a, "EndpointBindings")
+	return &mockBindings{bindings: a.bindings}, nil
+}
+
+func (a *mockApplication) ApplicationConfig() (coreapplication.ConfigAttributes, error) {
+	a.MethodCall(a, "ApplicationConfig")
+	return a.config, nil
+}
+
+type mockBindings struct {
+	bindings map[string]string
+}
+
+func (b *mockBindings) Map() map[string]string {
+	return b.bindings
+}
+
 func (a *mockApplication) CharmURL() (curl *charm.URL, force bool) {
 	a.MethodCall(a, "CharmURL")
 	return a.charm.URL(), false
@@ -29,6 +29,7 @@ import (
 	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
 	"github.com/juju/juju/cloudconfig/podcfg"
 	"github.com/juju/juju/controller"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/status"
@@ -227,6 +228,10 @@ func (a *API) provisioningInfo(appName names.ApplicationTag) (*params.CAASApplic
 		)
 	}
 	imagePath := podcfg.GetJujuOCIImagePath(cfg, vers.ToPatch(), version.OfficialBuild)
+	imageRepo, err := cfg.CAASImageRepoDetails()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	apiHostPorts, err := a.ctrlSt.APIHostPortsForAgents()
 	if err != nil {
@@ -241,6 +246,19 @@ func (a *API) provisioningInfo(appName names.ApplicationTag) (*params.CAASApplic
 			}
 		}
 	}
+	networkAttachments, err := a.networkAttachments(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	updateStrategy, disruptionBudget := updateSettings(appConfig)
+	containerResources, err := containerResources(appConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	caCert, _ := cfg.CACert()
 	charmURL, _ := app.CharmURL()
 	return &params.CAASApplicationProvisioningInfo{
@@ -253,12 +271,97 @@ func (a *API) provisioningInfo(appName names.ApplicationTag) (*params.CAASApplic
 		Devices:              devices,
 		Constraints:          mergedCons,
 		Series:               app.Series(),
-		ImageRepo:            cfg.CAASImageRepo(),
+		ImageRepo:            imageRepo.Repository,
+		IsPrivateImageRepo:   imageRepo.IsPrivate(),
 		CharmModifiedVersion: app.CharmModifiedVersion(),
 		CharmURL:             charmURL.String(),
+		NetworkAttachments:   networkAttachments,
+		UpdateStrategy:       updateStrategy,
+		DisruptionBudget:     disruptionBudget,
+		ContainerResources:   containerResources,
 	}, nil
 }
 
+// updateSettings returns the update strategy and disruption budget
+// set in the application config, if any.
+func updateSettings(appConfig coreapplication.ConfigAttributes) (*params.CAASUpdateStrategy, *params.CAASDisruptionBudget) {
+	var strategy *params.CAASUpdateStrategy
+	maxUnavailable := appConfig.GetString(k8sconstants.UpdateMaxUnavailableConfigKey, "")
+	maxSurge := appConfig.GetString(k8sconstants.UpdateMaxSurgeConfigKey, "")
+	_, partitioned := appConfig[k8sconstants.UpdatePartitionConfigKey]
+	if partitioned || maxUnavailable != "" || maxSurge != "" {
+		strategy = &params.CAASUpdateStrategy{
+			MaxUnavailable: maxUnavailable,
+			MaxSurge:       maxSurge,
+		}
+		if partitioned {
+			partition := int32(appConfig.GetInt(k8sconstants.UpdatePartitionConfigKey, 0))
+			strategy.Partition = &partition
+		}
+	}
+
+	var budget *params.CAASDisruptionBudget
+	minAvailable := appConfig.GetString(k8sconstants.DisruptionBudgetMinAvailableConfigKey, "")
+	budgetMaxUnavailable := appConfig.GetString(k8sconstants.DisruptionBudgetMaxUnavailableConfigKey, "")
+	if minAvailable != "" || budgetMaxUnavailable != "" {
+		budget = &params.CAASDisruptionBudget{
+			MinAvailable:   minAvailable,
+			MaxUnavailable: budgetMaxUnavailable,
+		}
+	}
+	return strategy, budget
+}
+
+// containerResources returns the resource requests and limits of the
+// application's containers set in the application config, if any.
+func containerResources(appConfig coreapplication.ConfigAttributes) (map[string]params.CAASContainerResources, error) {
+	resources, err := caas.ParseContainerResources(appConfig.GetString(k8sconstants.ContainerResourcesConfigKey, ""))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(resources) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]params.CAASContainerResources, len(resources))
+	for name, res := range resources {
+		result[name] = params.CAASContainerResources(res)
+	}
+	return result, nil
+}
+
+// networkAttachments returns the provider network ids of the subnets in
+// the spaces that the application endpoints are bound to. For Kubernetes
+// models these identify the secondary networks the pods are attached to.
+func (a *API) networkAttachments(app Application) ([]string, error) {
+	bindings, err := app.EndpointBindings()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spaceInfos, err := a.state.AllSpaceInfos()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachments := set.NewStrings()
+	for _, spaceID := range bindings.Map() {
+		if spaceID == network.AlphaSpaceId {
+			continue
+		}
+		space := spaceInfos.GetByID(spaceID)
+		if space == nil {
+			return nil, errors.NotFoundf("space with ID %q", spaceID)
+		}
+		for _, subnet := range space.Subnets {
+			if subnet.ProviderNetworkId != "" {
+				attachments.Add(string(subnet.ProviderNetworkId))
+			}
+		}
+	}
+	if attachments.IsEmpty() {
+		return nil, nil
+	}
+	return attachments.SortedValues(), nil
+}
+
 // SetOperatorStatus sets the status of each given entity.
 func (a *API) SetOperatorStatus(args params.SetStatus) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -771,19 +874,9 @@ func (a *API) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs) (pa
 			logger.Debugf("ignoring unit updates for dying application: %v", app.Name())
 			continue
 		}
-		appStatus := appUpdate.Status
-		if appStatus.Status != "" && appStatus.Status != status.Unknown {
-			now := a.clock.Now()
-			err = app.SetStatus(status.StatusInfo{
-				Status:  appStatus.Status,
-				Message: appStatus.Info,
-				Data:    appStatus.Data,
-				Since:   &now,
-			})
-			if err != nil {
-				result.Results[i].Error = apiservererrors.ServerError(err)
-				continue
-			}
+		if err := a.updateCloudStatus(app, appUpdate.Status); err != nil {
+			result.Results[i].Error = apiservererrors.ServerError(err)
+			continue
 		}
 		appUnitInfo, err := a.updateUnitsFromCloud(app, appUpdate.Units)
 		if err != nil {
@@ -802,6 +895,58 @@ func (a *API) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs) (pa
 	return result, nil
 }
 
+// cloudStatusKey is the key in the data of an application status
+// reported by the cloud, rather than set by the charm.
+const cloudStatusKey = "cloud-status"
+
+// cloudStatusHistorySize is how many application status history entries
+// are searched for the status set by the charm, to restore it once the
+// cloud no longer reports a problem.
+const cloudStatusHistorySize = 50
+
+// updateCloudStatus sets the application status reported by the cloud,
+// or restores the status last set by the charm if the cloud no longer
+// reports a problem.
+func (a *API) updateCloudStatus(app Application, appStatus params.EntityStatus) error {
+	current, err := app.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, currentFromCloud := current.Data[cloudStatusKey]
+	now := a.clock.Now()
+	if appStatus.Status == "" || appStatus.Status == status.Unknown {
+		if !currentFromCloud {
+			return nil
+		}
+		history, err := app.StatusHistory(status.StatusHistoryFilter{Size: cloudStatusHistorySize})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		restored := status.StatusInfo{Status: status.Unknown}
+		for _, h := range history {
+			if _, fromCloud := h.Data[cloudStatusKey]; !fromCloud {
+				restored = h
+				break
+			}
+		}
+		restored.Since = &now
+		return errors.Trace(app.SetStatus(restored))
+	}
+	if currentFromCloud && current.Status == appStatus.Status && current.Message == appStatus.Info {
+		return nil
+	}
+	data := map[string]interface{}{cloudStatusKey: true}
+	for k, v := range appStatus.Data {
+		data[k] = v
+	}
+	return errors.Trace(app.SetStatus(status.StatusInfo{
+		Status:  appStatus.Status,
+		Message: appStatus.Info,
+		Data:    data,
+		Since:   &now,
+	}))
+}
+
 type filesystemInfo struct {
 	unitTag      names.UnitTag
 	providerId   string
@@ -1212,6 +1357,7 @@ func processUnitParams(unitParams params.ApplicationUnitParams) *state.UnitUpdat
 	return &state.UnitUpdateProperties{
 		ProviderId:           &unitParams.ProviderId,
 		Address:              &unitParams.Address,
+		NetworkAddresses:     &unitParams.NetworkAddresses,
 		Ports:                &unitParams.Ports,
 		AgentStatus:          agentStatus,
 		CloudContainerStatus: cloudContainerStatus,
@@ -20,6 +20,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasapplicationprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/resources"
 	"github.com/juju/juju/core/status"
 	jujuresource "github.com/juju/juju/resource"
@@ -116,6 +118,114 @@ func (s *CAASApplicationProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	})
 }
 
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoNetworkAttachments(c *gc.C) {
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url: &charm.URL{
+				Schema:   "cs",
+				Name:     "gitlab",
+				Revision: -1,
+			},
+		},
+		bindings: map[string]string{
+			"":         network.AlphaSpaceId,
+			"db":       "1",
+			"replicas": "2",
+		},
+	}
+	s.st.spaceInfos = network.SpaceInfos{{
+		ID:   network.AlphaSpaceId,
+		Name: network.AlphaSpaceName,
+		Subnets: network.SubnetInfos{{
+			CIDR: "10.1.0.0/16",
+		}},
+	}, {
+		ID:   "1",
+		Name: "storage",
+		Subnets: network.SubnetInfos{{
+			CIDR:              "192.168.1.0/24",
+			ProviderId:        "storage/192.168.1.0/24",
+			ProviderNetworkId: "storage",
+		}},
+	}, {
+		ID:   "2",
+		Name: "backend",
+		Subnets: network.SubnetInfos{{
+			CIDR:              "172.16.0.0/16",
+			ProviderId:        "backend/172.16.0.0/16",
+			ProviderNetworkId: "backend",
+		}, {
+			CIDR:              "2001:db8::/64",
+			ProviderId:        "backend/2001:db8::/64",
+			ProviderNetworkId: "backend",
+		}},
+	}}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{Tag: "application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].NetworkAttachments, jc.DeepEquals, []string{"backend", "storage"})
+}
+
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoUpdateSettings(c *gc.C) {
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url: &charm.URL{
+				Schema:   "cs",
+				Name:     "gitlab",
+				Revision: -1,
+			},
+		},
+		config: coreapplication.ConfigAttributes{
+			"kubernetes-update-partition":                  2,
+			"kubernetes-update-max-unavailable":            "1",
+			"kubernetes-disruption-budget-max-unavailable": "25%",
+		},
+	}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{Tag: "application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	partition := int32(2)
+	c.Assert(result.Results[0].UpdateStrategy, jc.DeepEquals, &params.CAASUpdateStrategy{
+		Partition:      &partition,
+		MaxUnavailable: "1",
+	})
+	c.Assert(result.Results[0].DisruptionBudget, jc.DeepEquals, &params.CAASDisruptionBudget{
+		MaxUnavailable: "25%",
+	})
+}
+
+func (s *CAASApplicationProvisionerSuite) TestProvisioningInfoContainerResources(c *gc.C) {
+	s.st.app = &mockApplication{
+		life: state.Alive,
+		charm: &mockCharm{
+			meta: &charm.Meta{},
+			url: &charm.URL{
+				Schema:   "cs",
+				Name:     "gitlab",
+				Revision: -1,
+			},
+		},
+		config: coreapplication.ConfigAttributes{
+			"kubernetes-container-resources": "charm.mem-request=256M gitlab.cpu-limit=200 gitlab.gpu=1",
+		},
+	}
+	result, err := s.api.ProvisioningInfo(params.Entities{Entities: []params.Entity{{Tag: "application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	memRequest, cpuLimit := uint64(256), uint64(200)
+	c.Assert(result.Results[0].ContainerResources, jc.DeepEquals, map[string]params.CAASContainerResources{
+		"charm":  {MemRequest: &memRequest},
+		"gitlab": {CPULimit: &cpuLimit, GPUs: 1},
+	})
+}
+
 func (s *CAASApplicationProvisionerSuite) TestSetOperatorStatus(c *gc.C) {
 	s.st.app = &mockApplication{
 		life: state.Alive,
@@ -606,8 +716,8 @@ func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithStorage
 	s.storage.storageAttachments[names.NewUnitTag("gitlab/2")] = names.NewStorageTag("data/2")
 
 	units := []params.ApplicationUnitParams{
-		{ProviderId: "gitlab-0", Address: "address", Ports: []string{"port"},
-			Status: "running", Info: "message", Stateful: true,
+		{ProviderId: "gitlab-0", Address: "address", NetworkAddresses: []string{"192.168.1.10"},
+			Ports: []string{"port"}, Status: "running", Info: "message", Stateful: true,
 			FilesystemInfo: []params.KubernetesFilesystemInfo{
 				{StorageName: "data", FilesystemId: "fs-id", Size: 100, MountPoint: "/path/to/here", ReadOnly: true,
 					Status: "pending", Info: "not ready",
@@ -645,18 +755,22 @@ func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithStorage
 			},
 		},
 	})
-	s.st.app.CheckCallNames(c, "Life", "AllUnits", "UpdateUnits", "Name")
+	s.st.app.CheckCallNames(c, "Life", "Status", "AllUnits", "UpdateUnits", "Name")
 	s.st.app.units[0].CheckCallNames(c, "UpdateOperation")
 	s.st.app.units[0].CheckCall(c, 0, "UpdateOperation", state.UnitUpdateProperties{
-		ProviderId: strPtr("gitlab-0"),
-		Address:    strPtr("address"), Ports: &[]string{"port"},
+		ProviderId:           strPtr("gitlab-0"),
+		Address:              strPtr("address"),
+		NetworkAddresses:     &[]string{"192.168.1.10"},
+		Ports:                &[]string{"port"},
 		CloudContainerStatus: &status.StatusInfo{Status: status.Running, Message: "message"},
 		AgentStatus:          &status.StatusInfo{Status: status.Idle},
 	})
 	s.st.app.units[1].CheckCallNames(c, "UpdateOperation")
 	s.st.app.units[1].CheckCall(c, 0, "UpdateOperation", state.UnitUpdateProperties{
-		ProviderId: strPtr("gitlab-1"),
-		Address:    strPtr("another-address"), Ports: &[]string{"another-port"},
+		ProviderId:           strPtr("gitlab-1"),
+		Address:              strPtr("another-address"),
+		NetworkAddresses:     new([]string),
+		Ports:                &[]string{"another-port"},
 		CloudContainerStatus: &status.StatusInfo{Status: status.Running, Message: "another message"},
 		AgentStatus:          &status.StatusInfo{Status: status.Idle},
 	})
@@ -807,18 +921,22 @@ func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithoutStor
 			},
 		},
 	})
-	s.st.app.CheckCallNames(c, "Life", "AllUnits", "UpdateUnits", "Name")
+	s.st.app.CheckCallNames(c, "Life", "Status", "AllUnits", "UpdateUnits", "Name")
 	s.st.app.units[0].CheckCallNames(c, "UpdateOperation")
 	s.st.app.units[0].CheckCall(c, 0, "UpdateOperation", state.UnitUpdateProperties{
-		ProviderId: strPtr("gitlab-0"),
-		Address:    strPtr("address"), Ports: &[]string{"port"},
+		ProviderId:           strPtr("gitlab-0"),
+		Address:              strPtr("address"),
+		NetworkAddresses:     new([]string),
+		Ports:                &[]string{"port"},
 		CloudContainerStatus: &status.StatusInfo{Status: status.Running, Message: "message"},
 		AgentStatus:          &status.StatusInfo{Status: status.Idle},
 	})
 	s.st.app.units[1].CheckCallNames(c, "UpdateOperation")
 	s.st.app.units[1].CheckCall(c, 0, "UpdateOperation", state.UnitUpdateProperties{
-		ProviderId: strPtr("gitlab-1"),
-		Address:    strPtr("another-address"), Ports: &[]string{"another-port"},
+		ProviderId:           strPtr("gitlab-1"),
+		Address:              strPtr("another-address"),
+		NetworkAddresses:     new([]string),
+		Ports:                &[]string{"another-port"},
 		CloudContainerStatus: &status.StatusInfo{Status: status.Running, Message: "another message"},
 		AgentStatus:          &status.StatusInfo{Status: status.Idle},
 	})
@@ -830,6 +948,62 @@ func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsWithoutStor
 	s.st.model.CheckCall(c, 0, "Containers", []string{"gitlab-0", "gitlab-1"})
 }
 
+func (s *CAASApplicationProvisionerSuite) TestUpdateApplicationsUnitsCloudStatus(c *gc.C) {
+	s.st.app = &mockApplication{
+		tag:  names.NewApplicationTag("gitlab"),
+		life: state.Alive,
+		status: status.StatusInfo{
+			Status:  status.Active,
+			Message: "ready",
+		},
+	}
+	updateStatus := func(appStatus params.EntityStatus) {
+		s.st.app.ResetCalls()
+		results, err := s.api.UpdateApplicationsUnits(params.UpdateApplicationUnitArgs{
+			Args: []params.UpdateApplicationUnits{
+				{ApplicationTag: "application-gitlab", Status: appStatus},
+			},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(results.Results[0].Error, gc.IsNil)
+	}
+	now := s.clock.Now()
+
+	// A problem reported by the cloud replaces the status set by the charm.
+	updateStatus(params.EntityStatus{Status: status.Waiting, Info: "back-off: restarting failed container"})
+	s.st.app.CheckCallNames(c, "Life", "Status", "SetStatus", "AllUnits", "UpdateUnits", "Name")
+	cloudStatus := status.StatusInfo{
+		Status:  status.Waiting,
+		Message: "back-off: restarting failed container",
+		Data:    map[string]interface{}{"cloud-status": true},
+		Since:   &now,
+	}
+	s.st.app.CheckCall(c, 2, "SetStatus", cloudStatus)
+
+	// The same problem isn't set again.
+	s.st.app.status = cloudStatus
+	updateStatus(params.EntityStatus{Status: status.Waiting, Info: "back-off: restarting failed container"})
+	s.st.app.CheckCallNames(c, "Life", "Status", "AllUnits", "UpdateUnits", "Name")
+
+	// Once the problem clears, the status last set by the charm is restored.
+	s.st.app.statusHistory = []status.StatusInfo{cloudStatus, {
+		Status:  status.Active,
+		Message: "ready",
+	}}
+	updateStatus(params.EntityStatus{})
+	s.st.app.CheckCallNames(c, "Life", "Status", "StatusHistory", "SetStatus", "AllUnits", "UpdateUnits", "Name")
+	s.st.app.CheckCall(c, 3, "SetStatus", status.StatusInfo{
+		Status:  status.Active,
+		Message: "ready",
+		Since:   &now,
+	})
+
+	// Nothing is restored over a status set by the charm.
+	s.st.app.status = status.StatusInfo{Status: status.Active, Message: "ready"}
+	updateStatus(params.EntityStatus{})
+	s.st.app.CheckCallNames(c, "Life", "Status", "AllUnits", "UpdateUnits", "Name")
+}
+
 func strPtr(s string) *string {
 	return &s
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -26,6 +27,7 @@ type CAASApplicationProvisionerState interface {
 	Application(string) (Application, error)
 	ResolveConstraints(cons constraints.Value) (constraints.Value, error)
 	Resources() (Resources, error)
+	AllSpaceInfos() (network.SpaceInfos, error)
 }
 
 // CAASApplicationProvisionerState provides the subset of controller state
@@ -56,8 +58,16 @@ type Application interface {
 	Life() state.Life
 	Series() string
 	SetStatus(statusInfo status.StatusInfo) error
+	Status() (status.StatusInfo, error)
+	StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error)
 	CharmModifiedVersion() int
 	CharmURL() (curl *charm.URL, force bool)
+	EndpointBindings() (Bindings, error)
+	ApplicationConfig() (application.ConfigAttributes, error)
+}
+
+type Bindings interface {
+	Map() map[string]string
 }
 
 type Charm interface {
@@ -109,6 +119,10 @@ func (a *applicationShim) Charm() (Charm, bool, error) {
 	return a.Application.Charm()
 }
 
+func (a *applicationShim) EndpointBindings() (Bindings, error) {
+	return a.Application.EndpointBindings()
+}
+
 func (a *applicationShim) AllUnits() ([]Unit, error) {
 	units, err := a.Application.AllUnits()
 	if err != nil {
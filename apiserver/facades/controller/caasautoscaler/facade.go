@@ -0,0 +1,176 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler
+
+import (
+	"strconv"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	apiservererrors "github.com/juju/juju/apiserver/errors"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+	// AllApplications returns the applications in the model.
+	AllApplications() ([]Application, error)
+
+	// Application returns the named application.
+	Application(name string) (Application, error)
+
+	// MetricBatchesForApplication returns the metric batches
+	// reported by the units of the named application.
+	MetricBatchesForApplication(name string) ([]MetricBatch, error)
+}
+
+// Application exposes the application functionality required by
+// Facade.
+type Application interface {
+	Name() string
+	AutoscalePolicy() *application.AutoscalePolicy
+	GetScale() int
+	SetScale(scale int, generation int64, force bool) error
+	UnitNames() ([]string, error)
+}
+
+// MetricBatch exposes the metric batch functionality required by
+// Facade.
+type MetricBatch interface {
+	Unit() string
+	UniqueMetrics() []state.Metric
+}
+
+// Facade allows the caasautoscaler worker to read the autoscaling
+// policies of the applications in a model, and to scale them.
+type Facade struct {
+	backend Backend
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, apiservererrors.ErrPerm
+	}
+	return &Facade{backend: backend}, nil
+}
+
+// AutoscaledApplications returns the applications in the model which
+// have an autoscaling policy, along with their current scale and the
+// average value of the charm metric their policy targets, if any.
+func (f *Facade) AutoscaledApplications() (params.AutoscaledApplicationResults, error) {
+	var result params.AutoscaledApplicationResults
+	apps, err := f.backend.AllApplications()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, app := range apps {
+		policy := app.AutoscalePolicy()
+		if policy == nil {
+			continue
+		}
+		autoscaled := params.AutoscaledApplication{
+			ApplicationTag: names.NewApplicationTag(app.Name()).String(),
+			Policy: params.AutoscalePolicy{
+				MinUnits:     policy.MinUnits,
+				MaxUnits:     policy.MaxUnits,
+				TargetCPU:    policy.TargetCPU,
+				TargetMemory: policy.TargetMemory,
+				Metric:       policy.Metric,
+				TargetMetric: policy.TargetMetric,
+			},
+			Scale: app.GetScale(),
+		}
+		if policy.Metric != "" {
+			average, err := f.metricAverage(app, policy.Metric)
+			autoscaled.MetricAverage = average
+			autoscaled.Error = apiservererrors.ServerError(err)
+		}
+		result.Results = append(result.Results, autoscaled)
+	}
+	return result, nil
+}
+
+// metricAverage returns the average of the latest value of the named
+// metric reported by each of the application's current units, or nil
+// if no unit has reported it. Metrics reported by units which have
+// since been removed are ignored.
+func (f *Facade) metricAverage(app Application, metric string) (*float64, error) {
+	unitNames, err := app.UnitNames()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units := set.NewStrings(unitNames...)
+	batches, err := f.backend.MetricBatchesForApplication(app.Name())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	latest := make(map[string]state.Metric)
+	for _, batch := range batches {
+		if !units.Contains(batch.Unit()) {
+			continue
+		}
+		for _, m := range batch.UniqueMetrics() {
+			if m.Key != metric || len(m.Labels) > 0 {
+				continue
+			}
+			if current, ok := latest[batch.Unit()]; !ok || m.Time.After(current.Time) {
+				latest[batch.Unit()] = m
+			}
+		}
+	}
+	if len(latest) == 0 {
+		return nil, nil
+	}
+	var total float64
+	for unit, m := range latest {
+		value, err := strconv.ParseFloat(m.Value, 64)
+		if err != nil {
+			return nil, errors.Annotatef(err, "metric %q of unit %q", metric, unit)
+		}
+		total += value
+	}
+	average := total / float64(len(latest))
+	return &average, nil
+}
+
+// SetScale sets the scale of the supplied applications, as decided by
+// their autoscaling policies.
+func (f *Facade) SetScale(args params.ScaleApplicationsParams) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Applications)),
+	}
+	for i, arg := range args.Applications {
+		err := f.setScale(arg)
+		result.Results[i].Error = apiservererrors.ServerError(err)
+	}
+	return result
+}
+
+func (f *Facade) setScale(arg params.ScaleApplicationParams) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := f.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy := app.AutoscalePolicy()
+	if policy == nil {
+		return errors.NotValidf("scaling application %q without an autoscale policy", tag.Id())
+	}
+	if arg.Scale < policy.MinUnits || arg.Scale > policy.MaxUnits {
+		return errors.NotValidf(
+			"scaling application %q to %d units outside its autoscale policy of %d to %d units",
+			tag.Id(), arg.Scale, policy.MinUnits, policy.MaxUnits,
+		)
+	}
+	return app.SetScale(arg.Scale, 0, true)
+}
@@ -0,0 +1,221 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/caasautoscaler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+)
+
+type FacadeSuite struct {
+	testing.IsolationSuite
+	backend *mockBackend
+	facade  *caasautoscaler.Facade
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		apps: map[string]*mockApplication{
+			"gitlab": {
+				name:  "gitlab",
+				scale: 3,
+				units: []string{"gitlab/0", "gitlab/1", "gitlab/2"},
+				policy: &application.AutoscalePolicy{
+					MinUnits: 1, MaxUnits: 5, TargetCPU: 70,
+				},
+			},
+			"mysql": {name: "mysql", scale: 1},
+			"rabbitmq": {
+				name:  "rabbitmq",
+				scale: 2,
+				units: []string{"rabbitmq/0", "rabbitmq/1"},
+				policy: &application.AutoscalePolicy{
+					MinUnits: 2, MaxUnits: 10, Metric: "queue-length", TargetMetric: 100,
+				},
+			},
+		},
+	}
+	var err error
+	s.facade, err = caasautoscaler.NewFacade(s.backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FacadeSuite) TestPermission(c *gc.C) {
+	_, err := caasautoscaler.NewFacade(s.backend, apiservertesting.FakeAuthorizer{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *FacadeSuite) TestAutoscaledApplications(c *gc.C) {
+	now := time.Now()
+	s.backend.batches = []caasautoscaler.MetricBatch{
+		mockBatch{unit: "rabbitmq/0", metrics: []state.Metric{
+			{Key: "queue-length", Value: "50", Time: now.Add(-time.Minute)},
+		}},
+		mockBatch{unit: "rabbitmq/0", metrics: []state.Metric{
+			{Key: "queue-length", Value: "150", Time: now},
+			{Key: "queue-length", Value: "999", Time: now, Labels: map[string]string{"queue": "x"}},
+			{Key: "other", Value: "7", Time: now},
+		}},
+		mockBatch{unit: "rabbitmq/1", metrics: []state.Metric{
+			{Key: "queue-length", Value: "250", Time: now},
+		}},
+		// Metrics of removed units are ignored.
+		mockBatch{unit: "rabbitmq/2", metrics: []state.Metric{
+			{Key: "queue-length", Value: "1000", Time: now},
+		}},
+	}
+
+	result, err := s.facade.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	average := 200.0
+	c.Assert(result, jc.DeepEquals, params.AutoscaledApplicationResults{
+		Results: []params.AutoscaledApplication{{
+			ApplicationTag: "application-gitlab",
+			Policy:         params.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetCPU: 70},
+			Scale:          3,
+		}, {
+			ApplicationTag: "application-rabbitmq",
+			Policy: params.AutoscalePolicy{
+				MinUnits: 2, MaxUnits: 10, Metric: "queue-length", TargetMetric: 100,
+			},
+			Scale:         2,
+			MetricAverage: &average,
+		}},
+	})
+	c.Assert(s.backend.metricsApp, gc.Equals, "rabbitmq")
+}
+
+func (s *FacadeSuite) TestAutoscaledApplicationsMetricError(c *gc.C) {
+	delete(s.backend.apps, "gitlab")
+	s.backend.batches = []caasautoscaler.MetricBatch{
+		mockBatch{unit: "rabbitmq/0", metrics: []state.Metric{
+			{Key: "queue-length", Value: "lots", Time: time.Now()},
+		}},
+	}
+
+	result, err := s.facade.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].MetricAverage, gc.IsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `metric "queue-length" of unit "rabbitmq/0": .*invalid syntax`)
+}
+
+func (s *FacadeSuite) TestSetScale(c *gc.C) {
+	result := s.facade.SetScale(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{
+			{ApplicationTag: "application-gitlab", Scale: 5},
+			{ApplicationTag: "application-mysql", Scale: 2},
+			{ApplicationTag: "application-unknown", Scale: 2},
+			{ApplicationTag: "unit-gitlab-0", Scale: 2},
+		},
+	})
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `scaling application "mysql" without an autoscale policy not valid`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `application "unknown" not found`)
+	c.Assert(result.Results[3].Error, gc.ErrorMatches, `"unit-gitlab-0" is not a valid application tag`)
+
+	gitlab := s.backend.apps["gitlab"]
+	c.Assert(gitlab.scale, gc.Equals, 5)
+	c.Assert(gitlab.force, jc.IsTrue)
+	c.Assert(s.backend.apps["mysql"].scale, gc.Equals, 1)
+}
+
+func (s *FacadeSuite) TestSetScaleOutsidePolicy(c *gc.C) {
+	result := s.facade.SetScale(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{
+			{ApplicationTag: "application-gitlab", Scale: 0},
+			{ApplicationTag: "application-gitlab", Scale: 6},
+		},
+	})
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `scaling application "gitlab" to 0 units outside its autoscale policy of 1 to 5 units not valid`)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `scaling application "gitlab" to 6 units outside its autoscale policy of 1 to 5 units not valid`)
+	c.Assert(s.backend.apps["gitlab"].scale, gc.Equals, 3)
+}
+
+type mockBackend struct {
+	apps       map[string]*mockApplication
+	batches    []caasautoscaler.MetricBatch
+	metricsApp string
+}
+
+func (b *mockBackend) AllApplications() ([]caasautoscaler.Application, error) {
+	var result []caasautoscaler.Application
+	for _, name := range []string{"gitlab", "mysql", "rabbitmq"} {
+		if app, ok := b.apps[name]; ok {
+			result = append(result, app)
+		}
+	}
+	return result, nil
+}
+
+func (b *mockBackend) Application(name string) (caasautoscaler.Application, error) {
+	app, ok := b.apps[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+func (b *mockBackend) MetricBatchesForApplication(name string) ([]caasautoscaler.MetricBatch, error) {
+	b.metricsApp = name
+	return b.batches, nil
+}
+
+type mockApplication struct {
+	name   string
+	scale  int
+	force  bool
+	units  []string
+	policy *application.AutoscalePolicy
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) AutoscalePolicy() *application.AutoscalePolicy {
+	return a.policy
+}
+
+func (a *mockApplication) GetScale() int {
+	return a.scale
+}
+
+func (a *mockApplication) UnitNames() ([]string, error) {
+	return a.units, nil
+}
+
+func (a *mockApplication) SetScale(scale int, _ int64, force bool) error {
+	a.scale = scale
+	a.force = force
+	return nil
+}
+
+type mockBatch struct {
+	unit    string
+	metrics []state.Metric
+}
+
+func (b mockBatch) Unit() string {
+	return b.unit
+}
+
+func (b mockBatch) UniqueMetrics() []state.Metric {
+	return b.metrics
+}
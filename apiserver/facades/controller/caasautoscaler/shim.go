@@ -0,0 +1,54 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// NewStateFacade provides the required signature for facade registration.
+func NewStateFacade(ctx facade.Context) (*Facade, error) {
+	return NewFacade(backendShim{ctx.State()}, ctx.Auth())
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// AllApplications is part of the Backend interface.
+func (shim backendShim) AllApplications() ([]Application, error) {
+	apps, err := shim.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = app
+	}
+	return result, nil
+}
+
+// Application is part of the Backend interface.
+func (shim backendShim) Application(name string) (Application, error) {
+	return shim.st.Application(name)
+}
+
+// MetricBatchesForApplication is part of the Backend interface.
+func (shim backendShim) MetricBatchesForApplication(name string) ([]MetricBatch, error) {
+	batches, err := shim.st.MetricBatchesForApplication(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]MetricBatch, len(batches))
+	for i := range batches {
+		result[i] = &batches[i]
+	}
+	return result, nil
+}
@@ -23,6 +23,24 @@ type Facade struct {
 	*common.ApplicationWatcherFacade
 }
 
+// FacadeV1 provides v1 of the CAASFirewaller API facade.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewStateFacadeLegacyV1 provides the signature required for
+// v1 facade registration.
+func NewStateFacadeLegacyV1(ctx facade.Context) (*FacadeV1, error) {
+	f, err := NewStateFacadeLegacy(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
+}
+
+// RelatedApplications was added in v2 of the facade.
+func (*FacadeV1) RelatedApplications(_, _ struct{}) {}
+
 // NewStateFacadeLegacy provides the signature required for facade registration.
 func NewStateFacadeLegacy(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -92,6 +110,35 @@ func (f *Facade) isExposed(backend CAASFirewallerState, tagString string) (bool,
 	return app.IsExposed(), nil
 }
 
+// RelatedApplications returns the names of the applications
+// related to each of the specified applications.
+func (f *Facade) RelatedApplications(args params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		related, err := f.relatedApplications(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = apiservererrors.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = related
+	}
+	return results, nil
+}
+
+func (f *Facade) relatedApplications(tagString string) ([]string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.RelatedApplications()
+}
+
 // ApplicationsConfig returns the config for the specified applications.
 func (f *Facade) ApplicationsConfig(args params.Entities) (params.ApplicationGetConfigResults, error) {
 	results := params.ApplicationGetConfigResults{
@@ -125,6 +172,24 @@ type FacadeEmbedded struct {
 	accessModel common.GetAuthFunc
 }
 
+// FacadeEmbeddedV1 provides v1 of the CAASFirewallerEmbedded API facade.
+type FacadeEmbeddedV1 struct {
+	*FacadeEmbedded
+}
+
+// NewStateFacadeEmbeddedV1 provides the signature required for
+// v1 facade registration.
+func NewStateFacadeEmbeddedV1(ctx facade.Context) (*FacadeEmbeddedV1, error) {
+	f, err := NewStateFacadeEmbedded(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeEmbeddedV1{f}, nil
+}
+
+// RelatedApplications was added in v2 of the facade.
+func (*FacadeEmbeddedV1) RelatedApplications(_, _ struct{}) {}
+
 // NewStateFacadeEmbedded provides the signature required for facade registration.
 func NewStateFacadeEmbedded(ctx facade.Context) (*FacadeEmbedded, error) {
 	authorizer := ctx.Auth()
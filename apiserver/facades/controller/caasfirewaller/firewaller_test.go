@@ -70,6 +70,29 @@ func firewallerStateToAppWatcherState(st *mockState) *mockAppWatcherState {
 	}
 }
 
+func (s *firewallerLegacySuite) TestRelatedApplications(c *gc.C) {
+	s.st.application.related = []string{"mariadb", "redis"}
+	legacyFacade, ok := s.facade.(*caasfirewaller.Facade)
+	c.Assert(ok, jc.IsTrue)
+
+	results, err := legacyFacade.RelatedApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"mariadb", "redis"},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+}
+
 type firewallerEmbeddedSuite struct {
 	firewallerBaseSuite
 
@@ -112,6 +135,20 @@ func (s *firewallerEmbeddedSuite) SetUpTest(c *gc.C) {
 	c.Assert(ok, jc.IsTrue)
 }
 
+func (s *firewallerEmbeddedSuite) TestRelatedApplications(c *gc.C) {
+	s.st.application.related = []string{"mariadb"}
+
+	results, err := s.facade.RelatedApplications(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"mariadb"},
+		}},
+	})
+}
+
 func (s *firewallerEmbeddedSuite) TestWatchOpenedPorts(c *gc.C) {
 	openPortsChanges := []string{"port1", "port2"}
 	s.openPortsChanges <- openPortsChanges
@@ -152,6 +189,7 @@ type facadeEmbedded interface {
 	facadeCommon
 	WatchOpenedPorts(args params.Entities) (params.StringsWatchResults, error)
 	ApplicationCharmURLs(args params.Entities) (params.StringResults, error)
+	RelatedApplications(args params.Entities) (params.StringsResults, error)
 }
 
 func (s *firewallerBaseSuite) SetUpTest(c *gc.C) {
@@ -69,6 +69,7 @@ type mockApplication struct {
 	testing.Stub
 	life    state.Life
 	exposed bool
+	related []string
 	watcher state.NotifyWatcher
 
 	charm mockAppWatcherCharm
@@ -103,6 +104,11 @@ func (a *mockApplication) Charm() (caasfirewaller.Charm, bool, error) {
 	return &a.charm, false, nil
 }
 
+func (a *mockApplication) RelatedApplications() ([]string, error) {
+	a.MethodCall(a, "RelatedApplications")
+	return a.related, a.NextErr()
+}
+
 type mockAppWatcherState struct {
 	testing.Stub
 	app     *mockAppWatcherApplication
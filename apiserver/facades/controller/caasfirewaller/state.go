@@ -5,6 +5,8 @@ package caasfirewaller
 
 import (
 	"github.com/juju/charm/v9"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/core/application"
@@ -28,6 +30,7 @@ type Application interface {
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Watch() state.NotifyWatcher
 	Charm() (ch Charm, force bool, err error)
+	RelatedApplications() ([]string, error)
 }
 
 type Charm interface {
@@ -54,3 +57,30 @@ type applicationShim struct {
 func (a *applicationShim) Charm() (Charm, bool, error) {
 	return a.Application.Charm()
 }
+
+// RelatedApplications returns the names of the applications in the
+// model that are related to the application, excluding the application
+// itself and any remote applications.
+func (a *applicationShim) RelatedApplications() ([]string, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	related := set.NewStrings()
+	for _, rel := range relations {
+		remoteApp, isCrossModel, err := rel.RemoteApplication()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range rel.Endpoints() {
+			if ep.ApplicationName == a.Name() {
+				continue
+			}
+			if isCrossModel && ep.ApplicationName == remoteApp.Name() {
+				continue
+			}
+			related.Add(ep.ApplicationName)
+		}
+	}
+	return related.SortedValues(), nil
+}
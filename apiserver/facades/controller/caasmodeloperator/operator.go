@@ -93,12 +93,28 @@ func (a *API) ModelOperatorProvisioningInfo() (params.ModelOperatorInfo, error)
 		return result, errors.Annotate(err, "getting api addresses")
 	}
 
+	imageRepo, err := controllerConf.CAASImageRepoDetails()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
 	result = params.ModelOperatorInfo{
 		APIAddresses: apiAddresses.Result,
 		ImagePath: podcfg.GetJujuOCIImagePath(controllerConf,
 			vers.ToPatch(), version.OfficialBuild),
 		Version: vers,
 	}
+	if imageRepo.IsPrivate() {
+		result.ImageRepo = &params.ImageRepoDetails{
+			Repository:    imageRepo.Repository,
+			ServerAddress: imageRepo.ServerAddress,
+			Username:      imageRepo.Username,
+			Password:      imageRepo.Password,
+			Auth:          imageRepo.Auth,
+			IdentityToken: imageRepo.IdentityToken,
+			RegistryToken: imageRepo.RegistryToken,
+		}
+	}
 	return result, nil
 }
 
@@ -139,6 +139,10 @@ func (a *API) OperatorProvisioningInfo(args params.Entities) (params.OperatorPro
 	)
 
 	imagePath := podcfg.GetJujuOCIImagePath(cfg, vers.ToPatch(), version.OfficialBuild)
+	imageRepo, err := cfg.CAASImageRepoDetails()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
 	apiAddresses, err := a.APIAddresses()
 	if err == nil && apiAddresses.Error != nil {
 		err = apiAddresses.Error
@@ -166,11 +170,12 @@ func (a *API) OperatorProvisioningInfo(args params.Entities) (params.OperatorPro
 			}
 		}
 		return params.OperatorProvisioningInfo{
-			ImagePath:    imagePath,
-			Version:      vers,
-			APIAddresses: apiAddresses.Result,
-			CharmStorage: charmStorageParams,
-			Tags:         resourceTags,
+			ImagePath:          imagePath,
+			Version:            vers,
+			APIAddresses:       apiAddresses.Result,
+			CharmStorage:       charmStorageParams,
+			Tags:               resourceTags,
+			IsPrivateImageRepo: imageRepo.IsPrivate(),
 		}
 	}
 	result.Results = make([]params.OperatorProvisioningInfo, len(args.Entities))
@@ -6,19 +6,15 @@ package migrationmaster
 import (
 	"encoding/json"
 
-	"github.com/juju/collections/set"
-	"github.com/juju/description/v3"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/naturalsort"
-	"github.com/juju/version/v2"
 
 	"github.com/juju/juju/apiserver/common"
 	apiservererrors "github.com/juju/juju/apiserver/errors"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
-	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state/watcher"
 )
@@ -40,6 +36,11 @@ type APIV1 struct {
 
 // APIV2 implements version 2 of the migration master API.
 type APIV2 struct {
+	*APIV3
+}
+
+// APIV3 implements version 3 of the migration master API.
+type APIV3 struct {
 	*API
 }
 
@@ -56,13 +57,23 @@ func NewMigrationMasterFacadeV1(ctx facade.Context) (*APIV1, error) {
 // NewMigrationMasterFacadeV2 exists to provide the required signature for API
 // registration, converting st to backend.
 func NewMigrationMasterFacadeV2(ctx facade.Context) (*APIV2, error) {
-	v3, err := NewMigrationMasterFacade(ctx)
+	v3, err := NewMigrationMasterFacadeV3(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIV2{v3}, nil
 }
 
+// NewMigrationMasterFacadeV3 exists to provide the required signature for API
+// registration, converting st to backend.
+func NewMigrationMasterFacadeV3(ctx facade.Context) (*APIV3, error) {
+	v4, err := NewMigrationMasterFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV3{v4}, nil
+}
+
 // NewMigrationMasterFacadeV2 exists to provide the required signature for API
 // registration, converting st to backend.
 func NewMigrationMasterFacade(ctx facade.Context) (*API, error) {
@@ -155,9 +166,24 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 		MigrationId:      mig.Id(),
 		Phase:            phase.String(),
 		PhaseChangedTime: mig.PhaseChangedTime(),
+		Progress:         migrationProgressToParams(mig.Progress()),
+		Retries:          mig.Retries(),
 	}, nil
 }
 
+func migrationProgressToParams(progress coremigration.TransferProgress) params.MigrationProgress {
+	return params.MigrationProgress{
+		Charms:         progress.Charms,
+		CharmsTotal:    progress.CharmsTotal,
+		Tools:          progress.Tools,
+		ToolsTotal:     progress.ToolsTotal,
+		Resources:      progress.Resources,
+		ResourcesTotal: progress.ResourcesTotal,
+		LogMessages:    progress.LogMessages,
+		LogBytes:       progress.LogBytes,
+	}
+}
+
 // ModelInfo returns essential information about the model to be
 // migrated.
 func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
@@ -238,6 +264,31 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 	return errors.Annotate(err, "failed to set status message")
 }
 
+// SetProgress records how much of the model's data has been
+// transferred to the target controller. This is shown to the end user
+// by show-migration.
+func (api *API) SetProgress(args params.SetMigrationProgressArgs) error {
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return errors.Annotate(err, "could not get migration")
+	}
+	p := args.Progress
+	err = mig.SetProgress(coremigration.TransferProgress{
+		Charms:         p.Charms,
+		CharmsTotal:    p.CharmsTotal,
+		Tools:          p.Tools,
+		ToolsTotal:     p.ToolsTotal,
+		Resources:      p.Resources,
+		ResourcesTotal: p.ResourcesTotal,
+		LogMessages:    p.LogMessages,
+		LogBytes:       p.LogBytes,
+	})
+	return errors.Annotate(err, "failed to set progress")
+}
+
+// SetProgress is not available via the V3 API.
+func (api *APIV3) SetProgress(_, _ struct{}) {}
+
 // Export serializes the model associated with the API connection.
 func (api *API) Export() (params.SerializedModel, error) {
 	var serialized params.SerializedModel
@@ -247,17 +298,7 @@ func (api *API) Export() (params.SerializedModel, error) {
 		return serialized, err
 	}
 
-	bytes, err := description.Serialize(model)
-	if err != nil {
-		return serialized, err
-	}
-	serialized.Bytes = bytes
-	serialized.Charms = getUsedCharms(model)
-	serialized.Resources = getUsedResources(model)
-	if model.Type() == string(coremodel.IAAS) {
-		serialized.Tools = getUsedTools(model)
-	}
-	return serialized, nil
+	return common.SerializeModel(model)
 }
 
 // ProcessRelations is masked on older versions of the migration master API
@@ -368,99 +409,19 @@ func (api *API) MinionReportTimeout() (params.StringResult, error) {
 	return params.StringResult{Result: cfg.MigrationMinionWaitMax().String()}, nil
 }
 
-func getUsedCharms(model description.Model) []string {
-	result := set.NewStrings()
-	for _, application := range model.Applications() {
-		result.Add(application.CharmURL())
-	}
-	return result.Values()
-}
-
-func getUsedTools(model description.Model) []params.SerializedModelTools {
-	// Iterate through the model for all tools, and make a map of them.
-	usedVersions := make(map[version.Binary]bool)
-	// It is most likely that the preconditions will limit the number of
-	// tools versions in use, but that is not relied on here.
-	for _, machine := range model.Machines() {
-		addToolsVersionForMachine(machine, usedVersions)
-	}
-
-	for _, application := range model.Applications() {
-		for _, unit := range application.Units() {
-			tools := unit.Tools()
-			usedVersions[tools.Version()] = true
-		}
-	}
-
-	out := make([]params.SerializedModelTools, 0, len(usedVersions))
-	for v := range usedVersions {
-		out = append(out, params.SerializedModelTools{
-			Version: v.String(),
-			URI:     common.ToolsURL("", v),
-		})
-	}
-	return out
-}
-
-func addToolsVersionForMachine(machine description.Machine, usedVersions map[version.Binary]bool) {
-	tools := machine.Tools()
-	usedVersions[tools.Version()] = true
-	for _, container := range machine.Containers() {
-		addToolsVersionForMachine(container, usedVersions)
-	}
-}
-
-func getUsedResources(model description.Model) []params.SerializedModelResource {
-	var out []params.SerializedModelResource
-	for _, app := range model.Applications() {
-		for _, resource := range app.Resources() {
-			outRes := resourceToSerialized(app.Name(), resource)
-
-			// Hunt through the application's units and look for
-			// revisions of this resource. This is particularly
-			// efficient or clever but will be fine even with 1000's
-			// of units and 10's of resources.
-			outRes.UnitRevisions = make(map[string]params.SerializedModelResourceRevision)
-			for _, unit := range app.Units() {
-				for _, unitResource := range unit.Resources() {
-					if unitResource.Name() == resource.Name() {
-						outRes.UnitRevisions[unit.Name()] = revisionToSerialized(unitResource.Revision())
-					}
-				}
-			}
-
-			out = append(out, outRes)
-		}
-
-	}
-	return out
-}
-
-func resourceToSerialized(app string, desc description.Resource) params.SerializedModelResource {
-	return params.SerializedModelResource{
-		Application:         app,
-		Name:                desc.Name(),
-		ApplicationRevision: revisionToSerialized(desc.ApplicationRevision()),
-		CharmStoreRevision:  revisionToSerialized(desc.CharmStoreRevision()),
+// TransferRetryTimeout returns the configuration value for this
+// controller that indicates how long the migration master worker should
+// wait for a retry to be requested after a model data transfer fails.
+func (api *API) TransferRetryTimeout() (params.StringResult, error) {
+	cfg, err := api.backend.ControllerConfig()
+	if err != nil {
+		return params.StringResult{Error: apiservererrors.ServerError(err)}, nil
 	}
+	return params.StringResult{Result: cfg.MigrationRetryWait().String()}, nil
 }
 
-func revisionToSerialized(rr description.ResourceRevision) params.SerializedModelResourceRevision {
-	if rr == nil {
-		return params.SerializedModelResourceRevision{}
-	}
-	return params.SerializedModelResourceRevision{
-		Revision:       rr.Revision(),
-		Type:           rr.Type(),
-		Path:           rr.Path(),
-		Description:    rr.Description(),
-		Origin:         rr.Origin(),
-		FingerprintHex: rr.FingerprintHex(),
-		Size:           rr.Size(),
-		Timestamp:      rr.Timestamp(),
-		Username:       rr.Username(),
-	}
-}
+// TransferRetryTimeout is not available via the V3 API.
+func (api *APIV3) TransferRetryTimeout(_, _ struct{}) {}
 
 // MinionReportTimeout is not available via the V2 API.
 func (api *APIV2) MinionReportTimeout(_, _ struct{}) {}
@@ -130,6 +130,8 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 	exp.Id().Return("ID")
 	now := time.Now()
 	exp.PhaseChangedTime().Return(now)
+	exp.Progress().Return(coremigration.TransferProgress{Charms: 1, CharmsTotal: 2})
+	exp.Retries().Return(1)
 
 	s.backend.EXPECT().LatestMigration().Return(mig, nil)
 
@@ -152,6 +154,8 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 		MigrationId:      "ID",
 		Phase:            "IMPORT",
 		PhaseChangedTime: now,
+		Progress:         params.MigrationProgress{Charms: 1, CharmsTotal: 2},
+		Retries:          1,
 	})
 }
 
@@ -249,6 +253,44 @@ func (s *Suite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
 }
 
+func (s *Suite) TestSetProgress(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	mig := mocks.NewMockModelMigration(ctrl)
+	mig.EXPECT().SetProgress(coremigration.TransferProgress{
+		Charms:      2,
+		CharmsTotal: 2,
+		LogMessages: 10,
+		LogBytes:    100,
+	}).Return(nil)
+
+	s.backend.EXPECT().LatestMigration().Return(mig, nil)
+
+	err := s.mustMakeAPI(c).SetProgress(params.SetMigrationProgressArgs{
+		Progress: params.MigrationProgress{
+			Charms:      2,
+			CharmsTotal: 2,
+			LogMessages: 10,
+			LogBytes:    100,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *Suite) TestSetProgressError(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	mig := mocks.NewMockModelMigration(ctrl)
+	mig.EXPECT().SetProgress(coremigration.TransferProgress{}).Return(errors.New("blam"))
+
+	s.backend.EXPECT().LatestMigration().Return(mig, nil)
+
+	err := s.mustMakeAPI(c).SetProgress(params.SetMigrationProgressArgs{})
+	c.Assert(err, gc.ErrorMatches, "failed to set progress: blam")
+}
+
 func (s *Suite) TestPrechecksModelError(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -538,6 +580,20 @@ func (s *Suite) TestMinionReportTimeout(c *gc.C) {
 	c.Check(res.Result, gc.Equals, timeout)
 }
 
+func (s *Suite) TestTransferRetryTimeout(c *gc.C) {
+	ctrl := s.setupMocks(c)
+	defer ctrl.Finish()
+
+	s.backend.EXPECT().ControllerConfig().Return(controller.Config{
+		controller.MigrationRetryWait: "5m",
+	}, nil)
+
+	res, err := s.mustMakeAPI(c).TransferRetryTimeout()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Error, gc.IsNil)
+	c.Check(res.Result, gc.Equals, "5m0s")
+}
+
 func (s *Suite) setupMocks(c *gc.C) *gomock.Controller {
 	ctrl := gomock.NewController(c)
 
@@ -166,6 +166,34 @@ func (mr *MockModelMigrationMockRecorder) PhaseChangedTime() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PhaseChangedTime", reflect.TypeOf((*MockModelMigration)(nil).PhaseChangedTime))
 }
 
+// PhaseTimes mocks base method
+func (m *MockModelMigration) PhaseTimes() map[migration.Phase]time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PhaseTimes")
+	ret0, _ := ret[0].(map[migration.Phase]time.Time)
+	return ret0
+}
+
+// PhaseTimes indicates an expected call of PhaseTimes
+func (mr *MockModelMigrationMockRecorder) PhaseTimes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PhaseTimes", reflect.TypeOf((*MockModelMigration)(nil).PhaseTimes))
+}
+
+// Progress mocks base method
+func (m *MockModelMigration) Progress() migration.TransferProgress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress")
+	ret0, _ := ret[0].(migration.TransferProgress)
+	return ret0
+}
+
+// Progress indicates an expected call of Progress
+func (mr *MockModelMigrationMockRecorder) Progress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockModelMigration)(nil).Progress))
+}
+
 // Refresh mocks base method
 func (m *MockModelMigration) Refresh() error {
 	m.ctrl.T.Helper()
@@ -180,6 +208,34 @@ func (mr *MockModelMigrationMockRecorder) Refresh() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockModelMigration)(nil).Refresh))
 }
 
+// Retries mocks base method
+func (m *MockModelMigration) Retries() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retries")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Retries indicates an expected call of Retries
+func (mr *MockModelMigrationMockRecorder) Retries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retries", reflect.TypeOf((*MockModelMigration)(nil).Retries))
+}
+
+// RetryTransfer mocks base method
+func (m *MockModelMigration) RetryTransfer() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryTransfer")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryTransfer indicates an expected call of RetryTransfer
+func (mr *MockModelMigrationMockRecorder) RetryTransfer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryTransfer", reflect.TypeOf((*MockModelMigration)(nil).RetryTransfer))
+}
+
 // SetPhase mocks base method
 func (m *MockModelMigration) SetPhase(arg0 migration.Phase) error {
 	m.ctrl.T.Helper()
@@ -194,6 +250,20 @@ func (mr *MockModelMigrationMockRecorder) SetPhase(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPhase", reflect.TypeOf((*MockModelMigration)(nil).SetPhase), arg0)
 }
 
+// SetProgress mocks base method
+func (m *MockModelMigration) SetProgress(arg0 migration.TransferProgress) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProgress", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProgress indicates an expected call of SetProgress
+func (mr *MockModelMigrationMockRecorder) SetProgress(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProgress", reflect.TypeOf((*MockModelMigration)(nil).SetProgress), arg0)
+}
+
 // SetStatusMessage mocks base method
 func (m *MockModelMigration) SetStatusMessage(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -37,6 +37,12 @@ type API struct {
 	getCAASBroker stateenvirons.NewCAASBrokerFunc
 }
 
+// APIV1 implements the V1 MigrationTarget API. The only difference
+// between this and V2 is that V1 doesn't have the PrecheckIssues method.
+type APIV1 struct {
+	*API
+}
+
 // NewFacade is used for API registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	return NewAPI(
@@ -45,6 +51,15 @@ func NewFacade(ctx facade.Context) (*API, error) {
 		stateenvirons.GetNewCAASBrokerFunc(caas.New))
 }
 
+// NewFacadeV1 is used for V1 API registration.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, getCAASBroker stateenvirons.NewCAASBrokerFunc) (*API, error) {
@@ -82,10 +97,50 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	backend, modelInfo, err := api.precheckArgs(model)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return migration.TargetPrecheck(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		api.presence.ModelPresence(api.pool.SystemState().ModelUUID()),
+	)
+}
+
+// PrecheckIssues runs all of the checks which ensure that the target
+// controller is ready to accept a model migration, reporting every
+// blocking issue found rather than failing at the first.
+func (api *API) PrecheckIssues(model params.MigrationModelInfo) (params.MigrationPrecheckResult, error) {
+	result := params.MigrationPrecheckResult{
+		ModelTag: names.NewModelTag(model.UUID).String(),
+	}
+	backend, modelInfo, err := api.precheckArgs(model)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	issues, err := migration.TargetPrecheckIssues(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		api.presence.ModelPresence(api.pool.SystemState().ModelUUID()),
+	)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Issues = issues
+	return result, nil
+}
+
+// PrecheckIssues isn't on the V1 API.
+func (*APIV1) PrecheckIssues(_, _ struct{}) {}
+
+func (api *API) precheckArgs(model params.MigrationModelInfo) (migration.PrecheckBackend, coremigration.ModelInfo, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return nil, coremigration.ModelInfo{}, errors.Trace(err)
+	}
 	controllerState := api.pool.SystemState()
 	// NOTE (thumper): it isn't clear to me why api.state would be different
 	// from the controllerState as I had thought that the Precheck call was
@@ -93,20 +148,26 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	// controllerState.
 	backend, err := migration.PrecheckShim(api.state, controllerState)
 	if err != nil {
-		return errors.Annotate(err, "creating backend")
+		return nil, coremigration.ModelInfo{}, errors.Annotate(err, "creating backend")
 	}
-	return migration.TargetPrecheck(
-		backend,
-		migration.PoolShim(api.pool),
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
-		api.presence.ModelPresence(controllerState.ModelUUID()),
-	)
+	modelInfo := coremigration.ModelInfo{
+		UUID:                    model.UUID,
+		Name:                    model.Name,
+		Owner:                   ownerTag,
+		AgentVersion:            model.AgentVersion,
+		ControllerAgentVersion:  model.ControllerAgentVersion,
+		CloudName:               model.CloudName,
+		CloudType:               model.CloudType,
+		CloudRegion:             model.CloudRegion,
+		CloudCredentialAuthType: model.CloudCredentialAuthType,
+	}
+	if model.CloudCredentialTag != "" {
+		modelInfo.CloudCredential, err = names.ParseCloudCredentialTag(model.CloudCredentialTag)
+		if err != nil {
+			return nil, coremigration.ModelInfo{}, errors.Trace(err)
+		}
+	}
+	return backend, modelInfo, nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
@@ -421,19 +421,57 @@ type ScaleApplicationInfo struct {
 	Scale int `json:"num-units"`
 }
 
+// AutoscalePolicy holds the policy used to scale an application
+// automatically between a minimum and maximum number of units.
+type AutoscalePolicy struct {
+	MinUnits     int     `json:"min-units"`
+	MaxUnits     int     `json:"max-units"`
+	TargetCPU    int     `json:"target-cpu,omitempty"`
+	TargetMemory int     `json:"target-memory,omitempty"`
+	Metric       string  `json:"metric,omitempty"`
+	TargetMetric float64 `json:"target-metric,omitempty"`
+}
+
+// SetAutoscalePoliciesArgs holds the parameters for the
+// Application.SetAutoscalePolicies call.
+type SetAutoscalePoliciesArgs struct {
+	Args []SetAutoscalePolicyArg `json:"args"`
+}
+
+// SetAutoscalePolicyArg holds the autoscaling policy to set on an
+// application. A nil policy stops the application being autoscaled.
+type SetAutoscalePolicyArg struct {
+	ApplicationTag string           `json:"application-tag"`
+	Policy         *AutoscalePolicy `json:"policy,omitempty"`
+}
+
+// AutoscalePolicyResults holds the results of the
+// Application.AutoscalePolicies call.
+type AutoscalePolicyResults struct {
+	Results []AutoscalePolicyResult `json:"results"`
+}
+
+// AutoscalePolicyResult holds an application's autoscaling policy,
+// which is nil if the application isn't autoscaled.
+type AutoscalePolicyResult struct {
+	Policy *AutoscalePolicy `json:"policy,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
 // ApplicationResult holds an application info.
 // NOTE: we should look to combine ApplicationResult and ApplicationInfo.
 type ApplicationResult struct {
-	Tag              string                     `json:"tag"`
-	Charm            string                     `json:"charm,omitempty"`
-	Series           string                     `json:"series,omitempty"`
-	Channel          string                     `json:"channel,omitempty"`
-	Constraints      constraints.Value          `json:"constraints,omitempty"`
-	Principal        bool                       `json:"principal"`
-	Exposed          bool                       `json:"exposed"`
-	Remote           bool                       `json:"remote"`
-	EndpointBindings map[string]string          `json:"endpoint-bindings,omitempty"`
-	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+	Tag                string                            `json:"tag"`
+	Charm              string                            `json:"charm,omitempty"`
+	Series             string                            `json:"series,omitempty"`
+	Channel            string                            `json:"channel,omitempty"`
+	Constraints        constraints.Value                 `json:"constraints,omitempty"`
+	Principal          bool                              `json:"principal"`
+	Exposed            bool                              `json:"exposed"`
+	Remote             bool                              `json:"remote"`
+	EndpointBindings   map[string]string                 `json:"endpoint-bindings,omitempty"`
+	ExposedEndpoints   map[string]ExposedEndpoint        `json:"exposed-endpoints,omitempty"`
+	ContainerResources map[string]CAASContainerResources `json:"container-resources,omitempty"`
 }
 
 // ApplicationInfoResults holds an application info result or a retrieval error.
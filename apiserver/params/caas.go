@@ -34,20 +34,54 @@ type CAASApplicationProvisioningInfoResults struct {
 
 // CAASApplicationProvisioningInfo holds info needed to provision a caas application.
 type CAASApplicationProvisioningInfo struct {
-	ImagePath            string                       `json:"image-path"`
-	Version              version.Number               `json:"version"`
-	APIAddresses         []string                     `json:"api-addresses"`
-	CACert               string                       `json:"ca-cert"`
-	Constraints          constraints.Value            `json:"constraints"`
-	Tags                 map[string]string            `json:"tags,omitempty"`
-	Filesystems          []KubernetesFilesystemParams `json:"filesystems,omitempty"`
-	Volumes              []KubernetesVolumeParams     `json:"volumes,omitempty"`
-	Devices              []KubernetesDeviceParams     `json:"devices,omitempty"`
-	Series               string                       `json:"series,omitempty"`
-	ImageRepo            string                       `json:"image-repo,omitempty"`
-	CharmModifiedVersion int                          `json:"charm-modified-version,omitempty"`
-	CharmURL             string                       `json:"charm-url,omitempty"`
-	Error                *Error                       `json:"error,omitempty"`
+	ImagePath            string                            `json:"image-path"`
+	Version              version.Number                    `json:"version"`
+	APIAddresses         []string                          `json:"api-addresses"`
+	CACert               string                            `json:"ca-cert"`
+	Constraints          constraints.Value                 `json:"constraints"`
+	Tags                 map[string]string                 `json:"tags,omitempty"`
+	Filesystems          []KubernetesFilesystemParams      `json:"filesystems,omitempty"`
+	Volumes              []KubernetesVolumeParams          `json:"volumes,omitempty"`
+	Devices              []KubernetesDeviceParams          `json:"devices,omitempty"`
+	Series               string                            `json:"series,omitempty"`
+	ImageRepo            string                            `json:"image-repo,omitempty"`
+	IsPrivateImageRepo   bool                              `json:"is-private-image-repo,omitempty"`
+	CharmModifiedVersion int                               `json:"charm-modified-version,omitempty"`
+	CharmURL             string                            `json:"charm-url,omitempty"`
+	NetworkAttachments   []string                          `json:"network-attachments,omitempty"`
+	UpdateStrategy       *CAASUpdateStrategy               `json:"update-strategy,omitempty"`
+	DisruptionBudget     *CAASDisruptionBudget             `json:"disruption-budget,omitempty"`
+	ContainerResources   map[string]CAASContainerResources `json:"container-resources,omitempty"`
+	Error                *Error                            `json:"error,omitempty"`
+}
+
+// CAASUpdateStrategy holds how the pods of a caas application are
+// replaced when the application is updated.
+type CAASUpdateStrategy struct {
+	Partition      *int32 `json:"partition,omitempty"`
+	MaxUnavailable string `json:"max-unavailable,omitempty"`
+	MaxSurge       string `json:"max-surge,omitempty"`
+}
+
+// CAASDisruptionBudget holds the limits on the voluntary disruption
+// of the pods of a caas application.
+type CAASDisruptionBudget struct {
+	MinAvailable   string `json:"min-available,omitempty"`
+	MaxUnavailable string `json:"max-unavailable,omitempty"`
+}
+
+// CAASContainerResources holds the resources requested by, and the limits
+// on, a container of a caas application. CPU is in units of cpu-power,
+// memory and ephemeral storage are in MiB.
+type CAASContainerResources struct {
+	CPURequest              *uint64 `json:"cpu-request,omitempty"`
+	CPULimit                *uint64 `json:"cpu-limit,omitempty"`
+	MemRequest              *uint64 `json:"mem-request,omitempty"`
+	MemLimit                *uint64 `json:"mem-limit,omitempty"`
+	EphemeralStorageRequest *uint64 `json:"ephemeral-storage-request,omitempty"`
+	EphemeralStorageLimit   *uint64 `json:"ephemeral-storage-limit,omitempty"`
+	GPUs                    uint64  `json:"gpus,omitempty"`
+	GPUClass                string  `json:"gpu-class,omitempty"`
 }
 
 // CAASApplicationGarbageCollectArg holds info needed to cleanup units that have
@@ -78,6 +112,18 @@ type DockerImageInfo struct {
 	Password string `json:"password,omitempty"`
 }
 
+// ImageRepoDetails holds the location of, and the credentials for, the
+// OCI image repository Juju pulls its own images from.
+type ImageRepoDetails struct {
+	Repository    string `json:"repository"`
+	ServerAddress string `json:"serveraddress,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	Auth          string `json:"auth,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
 // CAASApplicationOCIResourceResults holds all the image results for queried applications.
 type CAASApplicationOCIResourceResults struct {
 	Results []CAASApplicationOCIResourceResult `json:"results"`
@@ -93,3 +139,20 @@ type CAASApplicationOCIResourceResult struct {
 type CAASApplicationOCIResources struct {
 	Images map[string]DockerImageInfo `json:"images"`
 }
+
+// AutoscaledApplicationResults holds the autoscaled applications in a
+// model, as returned by the CAASAutoscaler facade.
+type AutoscaledApplicationResults struct {
+	Results []AutoscaledApplication `json:"results"`
+}
+
+// AutoscaledApplication holds an application's autoscaling policy and
+// its current scale. MetricAverage is the average of the latest value
+// of the policy's charm metric reported by each unit, if any.
+type AutoscaledApplication struct {
+	ApplicationTag string          `json:"application-tag"`
+	Policy         AutoscalePolicy `json:"policy"`
+	Scale          int             `json:"scale"`
+	MetricAverage  *float64        `json:"metric-average,omitempty"`
+	Error          *Error          `json:"error,omitempty"`
+}
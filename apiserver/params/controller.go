@@ -3,7 +3,13 @@
 
 package params
 
-import "github.com/juju/juju/core/life"
+import (
+	"time"
+
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/core/life"
+)
 
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
@@ -115,3 +121,98 @@ type ControllerVersionResults struct {
 	Version   string `json:"version"`
 	GitCommit string `json:"git-commit"`
 }
+
+// CheckUpgradeArgs holds the arguments for checking an upgrade of the
+// controller.
+type CheckUpgradeArgs struct {
+	TargetVersion version.Number `json:"target-version"`
+}
+
+// CheckUpgradeResult holds the result of checking an upgrade of the
+// controller.
+type CheckUpgradeResult struct {
+	FromVersion version.Number     `json:"from-version"`
+	Steps       []UpgradeStepCheck `json:"steps"`
+}
+
+// UpgradeStepCheck holds the result of checking a pending upgrade
+// step.
+type UpgradeStepCheck struct {
+	// Version is the version whose upgrade runs the step.
+	Version string `json:"version"`
+
+	// Description is the step's description.
+	Description string `json:"description"`
+
+	// Targets are the types of machine the step is run on.
+	Targets []string `json:"targets"`
+
+	// Checked is true if the step was run against a snapshot of the
+	// controller's database.
+	Checked bool `json:"checked"`
+
+	// Error holds the error returned by the step, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// ControllerHealthResult holds the health of each controller, as
+// reported by the controller agents and the Mongo replica set.
+type ControllerHealthResult struct {
+	Agents       []ControllerAgentHealth `json:"agents"`
+	MongoMembers []MongoMemberHealth     `json:"mongo-members"`
+
+	// MongoError holds the error getting the replica set status, if
+	// any.
+	MongoError *Error `json:"mongo-error,omitempty"`
+}
+
+// ControllerAgentHealth holds the health reported by a controller
+// agent.
+type ControllerAgentHealth struct {
+	// ControllerId is the id of the controller machine.
+	ControllerId string `json:"controller-id"`
+
+	// Responded is false if the agent didn't report its health in
+	// time, in which case only ControllerId is set.
+	Responded bool `json:"responded"`
+
+	RaftState        string `json:"raft-state,omitempty"`
+	RaftLeader       string `json:"raft-leader,omitempty"`
+	RaftTerm         uint64 `json:"raft-term"`
+	RaftAppliedIndex uint64 `json:"raft-applied-index"`
+	RaftLastIndex    uint64 `json:"raft-last-index"`
+
+	// RaftLag is how many log entries the agent has yet to apply,
+	// compared with the most up to date controller.
+	RaftLag uint64 `json:"raft-lag"`
+
+	// RaftLastContact is when the agent last heard from the raft
+	// leader, in RFC3339 format.
+	RaftLastContact string `json:"raft-last-contact,omitempty"`
+
+	// LeaseClaims is the number of successful lease claims made
+	// through the agent, and the median and 99th percentile of their
+	// latencies in milliseconds.
+	LeaseClaims      uint64  `json:"lease-claims"`
+	LeaseClaimMedian float64 `json:"lease-claim-median"`
+	LeaseClaimP99    float64 `json:"lease-claim-p99"`
+
+	// Forwarders maps the other controllers to the status of the
+	// agent's pubsub forwarding connection to them.
+	Forwarders map[string]string `json:"forwarders,omitempty"`
+
+	// Errors holds any errors the agent had working out its health.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// MongoMemberHealth holds the status of a Mongo replica set member.
+type MongoMemberHealth struct {
+	Id           int           `json:"id"`
+	ControllerId string        `json:"controller-id,omitempty"`
+	Address      string        `json:"address"`
+	State        string        `json:"state"`
+	Healthy      bool          `json:"healthy"`
+	Uptime       time.Duration `json:"uptime"`
+	Ping         time.Duration `json:"ping"`
+	Message      string        `json:"message,omitempty"`
+}
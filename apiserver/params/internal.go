@@ -146,6 +146,33 @@ type ModelCreateArgs struct {
 	CloudCredentialTag string `json:"credential,omitempty"`
 }
 
+// CloneModelArgs holds the arguments for cloning models.
+type CloneModelArgs struct {
+	Models []CloneModelArg `json:"models"`
+}
+
+// CloneModelArg holds the arguments for creating a new model with the
+// same applications, relations and offers as an existing model.
+type CloneModelArg struct {
+	// SourceModelTag is the tag of the model to clone.
+	SourceModelTag string `json:"source-model-tag"`
+
+	// Name is the name for the new model.
+	Name string `json:"name"`
+
+	// OwnerTag represents the user that will own the new model. If
+	// this is empty, the new model is owned by the authenticated user.
+	OwnerTag string `json:"owner-tag,omitempty"`
+
+	// Config holds model config values which override those copied
+	// from the source model.
+	Config map[string]interface{} `json:"config,omitempty"`
+
+	// MaxUnits, if greater than zero, caps the number of units of
+	// each application in the new model.
+	MaxUnits int `json:"max-units,omitempty"`
+}
+
 // Model holds the result of an API call returning a name and UUID
 // for a model and the tag of the server in which it is running.
 type Model struct {
@@ -429,6 +456,46 @@ type EntityWorkloadVersions struct {
 	Entities []EntityWorkloadVersion `json:"entities"`
 }
 
+// SetContainerHealthArg holds the health of a unit's workload container,
+// as reported by its Pebble checks and services.
+type SetContainerHealthArg struct {
+	Tag           string   `json:"tag"`
+	ContainerName string   `json:"container-name"`
+	Status        string   `json:"status"`
+	Info          string   `json:"info"`
+	FailingChecks []string `json:"failing-checks,omitempty"`
+}
+
+// SetContainerHealthArgs holds the parameters for setting the health of
+// a set of workload containers.
+type SetContainerHealthArgs struct {
+	Args []SetContainerHealthArg `json:"args"`
+}
+
+// ContainerHealthArg identifies a unit's workload container.
+type ContainerHealthArg struct {
+	Tag           string `json:"tag"`
+	ContainerName string `json:"container-name"`
+}
+
+// ContainerHealthArgs holds the workload containers whose health is
+// requested.
+type ContainerHealthArgs struct {
+	Args []ContainerHealthArg `json:"args"`
+}
+
+// ContainerHealthResult holds the Pebble checks last recorded as
+// failing for a workload container.
+type ContainerHealthResult struct {
+	FailingChecks []string `json:"failing-checks,omitempty"`
+	Error         *Error   `json:"error,omitempty"`
+}
+
+// ContainerHealthResults holds the results of a ContainerHealth call.
+type ContainerHealthResults struct {
+	Results []ContainerHealthResult `json:"results"`
+}
+
 // BytesResult holds the result of an API call that returns a slice
 // of bytes.
 type BytesResult struct {
@@ -53,6 +53,21 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationPrecheckResults is used to return the results of checking
+// whether one or more models can be migrated.
+type MigrationPrecheckResults struct {
+	Results []MigrationPrecheckResult `json:"results"`
+}
+
+// MigrationPrecheckResult holds the blocking issues found when
+// checking whether a model can be migrated. Error is set if the
+// checks couldn't be completed.
+type MigrationPrecheckResult struct {
+	ModelTag string   `json:"model-tag"`
+	Issues   []string `json:"issues,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -74,6 +89,19 @@ type SerializedModel struct {
 	Resources []SerializedModelResource `json:"resources"`
 }
 
+// SerializedModelResults holds the results of exporting one or more
+// models.
+type SerializedModelResults struct {
+	Results []SerializedModelResult `json:"results"`
+}
+
+// SerializedModelResult holds a serialized model, or an error if it
+// couldn't be exported.
+type SerializedModelResult struct {
+	Result *SerializedModel `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
 // SerializedModelTools holds the version and URI for a given tools
 // version.
 type SerializedModelTools struct {
@@ -123,6 +151,30 @@ type MasterMigrationStatus struct {
 	MigrationId      string        `json:"migration-id"`
 	Phase            string        `json:"phase"`
 	PhaseChangedTime time.Time     `json:"phase-changed-time"`
+
+	// Progress and Retries are only populated by version 4 and
+	// later of the MigrationMaster facade.
+	Progress MigrationProgress `json:"progress"`
+	Retries  int               `json:"retries"`
+}
+
+// MigrationProgress reports how much of a model's data has been
+// transferred to the target controller during a migration.
+type MigrationProgress struct {
+	Charms         int   `json:"charms"`
+	CharmsTotal    int   `json:"charms-total"`
+	Tools          int   `json:"tools"`
+	ToolsTotal     int   `json:"tools-total"`
+	Resources      int   `json:"resources"`
+	ResourcesTotal int   `json:"resources-total"`
+	LogMessages    int   `json:"log-messages"`
+	LogBytes       int64 `json:"log-bytes"`
+}
+
+// SetMigrationProgressArgs provides the progress of a model data
+// transfer to the migrationmaster facade's SetProgress method.
+type SetMigrationProgressArgs struct {
+	Progress MigrationProgress `json:"progress"`
 }
 
 // MigrationModelInfo is used to report basic model information to the
@@ -133,6 +185,12 @@ type MigrationModelInfo struct {
 	OwnerTag               string         `json:"owner-tag"`
 	AgentVersion           version.Number `json:"agent-version"`
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+
+	CloudName               string `json:"cloud-name,omitempty"`
+	CloudType               string `json:"cloud-type,omitempty"`
+	CloudRegion             string `json:"cloud-region,omitempty"`
+	CloudCredentialTag      string `json:"cloud-credential-tag,omitempty"`
+	CloudCredentialAuthType string `json:"cloud-credential-auth-type,omitempty"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -128,12 +128,30 @@ type SetModelAgentVersion struct {
 	IgnoreAgentVersions bool           `json:"force,omitempty"`
 }
 
+// SetCanaryAgentVersion contains the arguments for the
+// SetCanaryAgentVersion client API call.
+type SetCanaryAgentVersion struct {
+	// Version is the agent version the canary machines are
+	// upgraded to.
+	Version version.Number `json:"version"`
+
+	// Machines holds the tags of the canary machines.
+	Machines []Entity `json:"machines"`
+}
+
 // ModelMigrationStatus holds information about the progress of a (possibly
 // failed) migration.
 type ModelMigrationStatus struct {
 	Status string     `json:"status"`
 	Start  *time.Time `json:"start"`
 	End    *time.Time `json:"end,omitempty"`
+
+	// Phase, PhaseTimes, Progress and Retries are only populated by
+	// ModelInfo, not by ListModelSummaries.
+	Phase      string               `json:"phase,omitempty"`
+	PhaseTimes map[string]time.Time `json:"phase-times,omitempty"`
+	Progress   *MigrationProgress   `json:"progress,omitempty"`
+	Retries    int                  `json:"retries,omitempty"`
 }
 
 // ModelInfo holds information about the Juju model.
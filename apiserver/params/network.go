@@ -976,6 +976,19 @@ type AddSubnetParams struct {
 	Zones             []string `json:"zones,omitempty"`
 }
 
+// ImportSubnetsParams holds the arguments of the ImportSubnets API call.
+type ImportSubnetsParams struct {
+	Subnets []ImportSubnetParams `json:"subnets"`
+}
+
+// ImportSubnetParams holds the CIDR of a subnet to be added to the model,
+// along with the tag of the space in which to place it. If SpaceTag is
+// empty, the subnet is placed in the default space.
+type ImportSubnetParams struct {
+	CIDR     string `json:"cidr"`
+	SpaceTag string `json:"space-tag,omitempty"`
+}
+
 // AddSubnetsParams holds the arguments of AddSubnets APIv2 call.
 type AddSubnetsParamsV2 struct {
 	Subnets []AddSubnetParamsV2 `json:"subnets"`
@@ -1361,3 +1374,36 @@ func ToNetworkSpaceInfos(allInfos SpaceInfos) network.SpaceInfos {
 
 	return res
 }
+
+// NetworkCheckTarget describes an address of a related unit that should be
+// probed by a machine agent when performing a network check.
+type NetworkCheckTarget struct {
+	// Unit is the name of the unit on the probing machine.
+	Unit string `json:"unit"`
+
+	// Endpoint is the name of the unit's endpoint in the relation.
+	Endpoint string `json:"endpoint"`
+
+	// RelatedUnit is the name of the unit at the other end of the relation.
+	RelatedUnit string `json:"related-unit"`
+
+	// Space is the name of the space to which the related
+	// unit's endpoint is bound.
+	Space string `json:"space"`
+
+	// Address is the related unit's address in the bound space.
+	Address string `json:"address"`
+}
+
+// NetworkCheckTargetsResult holds the network check targets
+// for a single machine, or an error.
+type NetworkCheckTargetsResult struct {
+	Targets []NetworkCheckTarget `json:"targets,omitempty"`
+	Error   *Error               `json:"error,omitempty"`
+}
+
+// NetworkCheckTargetsResults holds the network check
+// targets for a collection of machines.
+type NetworkCheckTargetsResults struct {
+	Results []NetworkCheckTargetsResult `json:"results"`
+}
@@ -353,9 +353,10 @@ type ConfigResult struct {
 
 // ModelOperatorInfo
 type ModelOperatorInfo struct {
-	APIAddresses []string       `json:"api-addresses"`
-	ImagePath    string         `json:"image-path"`
-	Version      version.Number `json:"version"`
+	APIAddresses []string          `json:"api-addresses"`
+	ImagePath    string            `json:"image-path"`
+	Version      version.Number    `json:"version"`
+	ImageRepo    *ImageRepoDetails `json:"image-repo,omitempty"`
 }
 
 // OperatorProvisioningInfoResults holds OperatorProvisioningInfo results.
@@ -370,7 +371,11 @@ type OperatorProvisioningInfo struct {
 	APIAddresses []string                    `json:"api-addresses"`
 	Tags         map[string]string           `json:"tags,omitempty"`
 	CharmStorage *KubernetesFilesystemParams `json:"charm-storage,omitempty"`
-	Error        *Error                      `json:"error,omitempty"`
+
+	// IsPrivateImageRepo is true if the operator image is pulled from
+	// a private registry, using the model's image repo pull secret.
+	IsPrivateImageRepo bool   `json:"is-private-image-repo,omitempty"`
+	Error              *Error `json:"error,omitempty"`
 }
 
 // IssueOperatorCertificateResult contains an x509 certificate
@@ -468,15 +473,16 @@ type UpdateApplicationUnits struct {
 
 // ApplicationUnitParams holds unit parameters used to update a unit.
 type ApplicationUnitParams struct {
-	ProviderId     string                     `json:"provider-id"`
-	UnitTag        string                     `json:"unit-tag"`
-	Address        string                     `json:"address"`
-	Ports          []string                   `json:"ports"`
-	Stateful       bool                       `json:"stateful,omitempty"`
-	FilesystemInfo []KubernetesFilesystemInfo `json:"filesystem-info,omitempty"`
-	Status         string                     `json:"status"`
-	Info           string                     `json:"info"`
-	Data           map[string]interface{}     `json:"data,omitempty"`
+	ProviderId       string                     `json:"provider-id"`
+	UnitTag          string                     `json:"unit-tag"`
+	Address          string                     `json:"address"`
+	NetworkAddresses []string                   `json:"network-addresses,omitempty"`
+	Ports            []string                   `json:"ports"`
+	Stateful         bool                       `json:"stateful,omitempty"`
+	FilesystemInfo   []KubernetesFilesystemInfo `json:"filesystem-info,omitempty"`
+	Status           string                     `json:"status"`
+	Info             string                     `json:"info"`
+	Data             map[string]interface{}     `json:"data,omitempty"`
 }
 
 // UpdateApplicationUnitResults holds results from UpdateApplicationUnits
@@ -947,6 +953,49 @@ type ControllersChanges struct {
 	Maintained []string `json:"maintained,omitempty"`
 	Removed    []string `json:"removed,omitempty"`
 	Converted  []string `json:"converted,omitempty"`
+
+	// Warnings holds any concerns about the resulting controllers,
+	// such as when a single availability zone holds a majority of them.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// RemoveControllerNodeArg holds the arguments for removing a
+// controller machine.
+type RemoveControllerNodeArg struct {
+	MachineTag string `json:"machine-tag"`
+
+	// Force removes the machine even if its agent isn't running.
+	Force bool `json:"force,omitempty"`
+
+	// MaxWait is how long a forced removal waits for each step to
+	// complete normally before forcing it.
+	MaxWait *time.Duration `json:"max-wait,omitempty"`
+}
+
+// RemoveControllerNodesArgs holds the arguments for the
+// RemoveControllerNodes API call.
+type RemoveControllerNodesArgs struct {
+	Nodes []RemoveControllerNodeArg `json:"nodes"`
+}
+
+// ReplaceControllerNodeArg holds the arguments for replacing a
+// controller machine with a new one.
+type ReplaceControllerNodeArg struct {
+	MachineTag string         `json:"machine-tag"`
+	Force      bool           `json:"force,omitempty"`
+	MaxWait    *time.Duration `json:"max-wait,omitempty"`
+
+	// Constraints, Series and Placement are used to start the new
+	// controller machine, as for EnableHA.
+	Constraints constraints.Value `json:"constraints,omitempty"`
+	Series      string            `json:"series,omitempty"`
+	Placement   []string          `json:"placement,omitempty"`
+}
+
+// ReplaceControllerNodesArgs holds the arguments for the
+// ReplaceControllerNodes API call.
+type ReplaceControllerNodesArgs struct {
+	Nodes []ReplaceControllerNodeArg `json:"nodes"`
 }
 
 // FindToolsParams defines parameters for the FindTools method.
@@ -55,6 +55,25 @@ type ModelStatusInfo struct {
 	ModelStatus      DetailedStatus `json:"model-status"`
 	MeterStatus      MeterStatus    `json:"meter-status"`
 	SLA              string         `json:"sla"`
+
+	// CanaryUpgrade holds the progress of any canary upgrade of the
+	// model's agents.
+	CanaryUpgrade *CanaryUpgradeStatus `json:"canary-upgrade,omitempty"`
+}
+
+// CanaryUpgradeStatus holds the progress of a canary upgrade of a
+// model's agents.
+type CanaryUpgradeStatus struct {
+	// Version is the agent version the canary machines are
+	// upgraded to.
+	Version string `json:"version"`
+
+	// Machines holds the ids of the canary machines.
+	Machines []string `json:"machines"`
+
+	// Upgraded holds the ids of the canary machines whose agents
+	// are running the canary version.
+	Upgraded []string `json:"upgraded"`
 }
 
 // NetworkInterfaceStatus holds a /etc/network/interfaces-type data and the
@@ -225,6 +244,10 @@ type UnitStatus struct {
 	// The following are for CAAS models.
 	ProviderId string `json:"provider-id,omitempty"`
 	Address    string `json:"address,omitempty"`
+
+	// Containers holds the health of the unit's workload containers,
+	// keyed by container name.
+	Containers map[string]DetailedStatus `json:"containers,omitempty"`
 }
 
 // RelationStatus holds status info about a relation.
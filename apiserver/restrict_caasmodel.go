@@ -73,6 +73,7 @@ var commonModelFacadeNames = set.NewStrings(
 var caasModelFacadeNames = set.NewStrings(
 	"CAASAdmission",
 	"CAASAgent",
+	"CAASAutoscaler",
 	"CAASFirewaller",
 	"CAASModelOperator",
 	"CAASOperator",
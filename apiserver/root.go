@@ -455,6 +455,16 @@ func (ctx *facadeContext) Hub() facade.Hub {
 	return ctx.r.shared.centralHub
 }
 
+// DataDir is part of the facade.Context interface.
+func (ctx *facadeContext) DataDir() string {
+	return ctx.r.shared.dataDir
+}
+
+// AgentTag is part of the facade.Context interface.
+func (ctx *facadeContext) AgentTag() names.Tag {
+	return ctx.r.shared.agentTag
+}
+
 // Controller implements facade.Context.
 func (ctx *facadeContext) Controller() *cache.Controller {
 	return ctx.r.shared.controller
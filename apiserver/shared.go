@@ -10,6 +10,7 @@ import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
 
 	jujucontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
@@ -44,6 +45,8 @@ type sharedServerContext struct {
 	leaseManager        lease.Manager
 	logger              loggo.Logger
 	cancel              <-chan struct{}
+	dataDir             string
+	agentTag            names.Tag
 
 	configMutex      sync.RWMutex
 	controllerConfig jujucontroller.Config
@@ -61,6 +64,8 @@ type sharedServerConfig struct {
 	leaseManager        lease.Manager
 	controllerConfig    jujucontroller.Config
 	logger              loggo.Logger
+	dataDir             string
+	agentTag            names.Tag
 }
 
 func (c *sharedServerConfig) validate() error {
@@ -101,6 +106,8 @@ func newSharedServerContext(config sharedServerConfig) (*sharedServerContext, er
 		leaseManager:        config.leaseManager,
 		logger:              config.logger,
 		controllerConfig:    config.controllerConfig,
+		dataDir:             config.dataDir,
+		agentTag:            config.agentTag,
 	}
 	ctx.features = config.controllerConfig.Features()
 	// We are able to get the current controller config before subscribing to changes
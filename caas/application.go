@@ -4,11 +4,16 @@
 package caas
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
 	"github.com/juju/version/v2"
 
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/storage"
 )
@@ -35,6 +40,10 @@ type Application interface {
 	State() (ApplicationState, error)
 	Units() ([]Unit, error)
 
+	// Utilisation returns the average resource usage of the
+	// Application's units.
+	Utilisation() (ApplicationUtilisation, error)
+
 	ServiceInterface
 }
 
@@ -64,6 +73,23 @@ type ServiceInterface interface {
 type ApplicationState struct {
 	DesiredReplicas int
 	Replicas        []string
+
+	// Status is the status reported by the most recent relevant warning
+	// event of the application's workload resource when it isn't ready,
+	// and empty otherwise.
+	Status status.StatusInfo
+}
+
+// ApplicationUtilisation holds the average resource usage of an
+// application's units, as a percentage of the resources requested by
+// their pods. A nil value means the usage isn't known, either because
+// no usage was reported or the pods don't request that resource.
+type ApplicationUtilisation struct {
+	// Units is the number of units whose usage was reported.
+	Units int
+
+	CPU    *float64
+	Memory *float64
 }
 
 // ApplicationConfig is the config passed to the application units.
@@ -72,6 +98,9 @@ type ApplicationConfig struct {
 	AgentVersion version.Number
 	// AgentImagePath is the docker registry URL for the image.
 	AgentImagePath string
+	// IsPrivateImageRepo is true if the agent and charm base images are
+	// pulled using the image repo pull secret.
+	IsPrivateImageRepo bool
 
 	// CharmModifiedVersion is a monotonically incrementing version number
 	// that represents the version of the charm and resources with regards to
@@ -85,6 +114,10 @@ type ApplicationConfig struct {
 	// Containers is the list of containers that make up the container (excluding uniter and init containers).
 	Containers map[string]ContainerConfig
 
+	// CharmContainerResources holds the resources requested by, and the
+	// limits on, the container running the charm.
+	CharmContainerResources ContainerResources
+
 	// IntroductionSecret
 	IntroductionSecret string
 	// ControllerAddresses is a comma separated list of controller addresses.
@@ -106,6 +139,87 @@ type ApplicationConfig struct {
 
 	// Devices is a set of parameters for Devices that is required.
 	Devices []devices.KubernetesDeviceParams
+
+	// NetworkAttachments holds the names of the secondary networks
+	// the pods are to be attached to, backing the spaces that the
+	// application endpoints are bound to.
+	NetworkAttachments []string
+
+	// UpdateStrategy describes how the application's pods are
+	// replaced when the application is updated.
+	UpdateStrategy UpdateStrategy
+
+	// DisruptionBudget, if set, limits how many of the application's
+	// pods may be evicted at once, such as when nodes are drained.
+	DisruptionBudget *DisruptionBudget
+}
+
+// UpdateStrategy describes how the pods of an application are replaced
+// when the application is updated. Zero values leave the choice to the
+// provider.
+type UpdateStrategy struct {
+	// Partition, for stateful applications, is the ordinal at or above
+	// which pods are updated. Pods with a lower ordinal are left as they
+	// are, allowing an update to be rolled out to some units first.
+	Partition *int32
+
+	// MaxUnavailable is the number, or percentage, of pods which may be
+	// unavailable while the application is updated.
+	MaxUnavailable string
+
+	// MaxSurge, for stateless applications, is the number, or
+	// percentage, of pods which may be created above the scale of the
+	// application while it is updated.
+	MaxSurge string
+}
+
+// Validate returns an error if the update strategy is not valid.
+func (s UpdateStrategy) Validate() error {
+	if s.Partition != nil && *s.Partition < 0 {
+		return errors.NotValidf("update partition %d", *s.Partition)
+	}
+	if err := validateIntOrPercent(s.MaxUnavailable); err != nil {
+		return errors.Annotate(err, "max unavailable")
+	}
+	if err := validateIntOrPercent(s.MaxSurge); err != nil {
+		return errors.Annotate(err, "max surge")
+	}
+	return nil
+}
+
+// DisruptionBudget limits the voluntary disruption of an application's
+// pods. Only one of MinAvailable and MaxUnavailable is set, each of which
+// is a number or a percentage of the application's pods.
+type DisruptionBudget struct {
+	MinAvailable   string
+	MaxUnavailable string
+}
+
+// Validate returns an error if the disruption budget is not valid.
+func (b DisruptionBudget) Validate() error {
+	if (b.MinAvailable == "") == (b.MaxUnavailable == "") {
+		return errors.NotValidf("disruption budget without exactly one of min available and max unavailable")
+	}
+	if err := validateIntOrPercent(b.MinAvailable); err != nil {
+		return errors.Annotate(err, "disruption budget min available")
+	}
+	if err := validateIntOrPercent(b.MaxUnavailable); err != nil {
+		return errors.Annotate(err, "disruption budget max unavailable")
+	}
+	return nil
+}
+
+// validateIntOrPercent returns an error if the value is neither empty,
+// a number of pods nor a percentage of pods.
+func validateIntOrPercent(value string) error {
+	if value == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+	if err != nil || n < 0 {
+		return errors.NotValidf("number or percentage of pods %q", value)
+	}
+	return nil
 }
 
 // ContainerConfig describes a container that is deployed alonside the uniter/charm container.
@@ -118,6 +232,10 @@ type ContainerConfig struct {
 
 	// Mounts to storage that are to be provided within this container.
 	Mounts []MountConfig
+
+	// Resources holds the resources requested by, and the limits on,
+	// the container.
+	Resources ContainerResources
 }
 
 // MountConfig describes a storage that should be mounted to a container.
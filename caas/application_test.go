@@ -0,0 +1,78 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/testing"
+)
+
+type UpdateSettingsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&UpdateSettingsSuite{})
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func (s *UpdateSettingsSuite) TestUpdateStrategyValidate(c *gc.C) {
+	for i, test := range []struct {
+		strategy caas.UpdateStrategy
+		err      string
+	}{{
+		strategy: caas.UpdateStrategy{},
+	}, {
+		strategy: caas.UpdateStrategy{Partition: int32Ptr(2), MaxUnavailable: "1", MaxSurge: "25%"},
+	}, {
+		strategy: caas.UpdateStrategy{Partition: int32Ptr(-1)},
+		err:      "update partition -1 not valid",
+	}, {
+		strategy: caas.UpdateStrategy{MaxUnavailable: "one"},
+		err:      `max unavailable: number or percentage of pods "one" not valid`,
+	}, {
+		strategy: caas.UpdateStrategy{MaxSurge: "-10%"},
+		err:      `max surge: number or percentage of pods "-10%" not valid`,
+	}} {
+		c.Logf("test %d: %+v", i, test.strategy)
+		err := test.strategy.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *UpdateSettingsSuite) TestDisruptionBudgetValidate(c *gc.C) {
+	for i, test := range []struct {
+		budget caas.DisruptionBudget
+		err    string
+	}{{
+		budget: caas.DisruptionBudget{MinAvailable: "2"},
+	}, {
+		budget: caas.DisruptionBudget{MaxUnavailable: "50%"},
+	}, {
+		budget: caas.DisruptionBudget{},
+		err:    "disruption budget without exactly one of min available and max unavailable not valid",
+	}, {
+		budget: caas.DisruptionBudget{MinAvailable: "1", MaxUnavailable: "1"},
+		err:    "disruption budget without exactly one of min available and max unavailable not valid",
+	}, {
+		budget: caas.DisruptionBudget{MinAvailable: "half"},
+		err:    `disruption budget min available: number or percentage of pods "half" not valid`,
+	}} {
+		c.Logf("test %d: %+v", i, test.budget)
+		err := test.budget.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
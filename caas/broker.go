@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/docker"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/storage"
 )
@@ -250,6 +251,11 @@ type ModelOperatorManager interface {
 	// ModelOperator return the model operator config used to create the current
 	// model operator for this broker
 	ModelOperator() (*ModelOperatorConfig, error)
+
+	// EnsureImageRepoSecret creates or updates the image pull secret
+	// used by the pods Juju creates to pull images from a private
+	// registry.
+	EnsureImageRepoSecret(docker.ImageRepoDetails) error
 }
 
 // ApplicationOperatorManager provides an API for deploying operators for
@@ -314,6 +320,10 @@ type ServiceManager interface {
 	// UnexposeService removes external access to the specified service.
 	UnexposeService(appName string) error
 
+	// EnsureNetworkPolicy creates, updates or removes the network policy
+	// restricting the traffic to and from the specified application.
+	EnsureNetworkPolicy(appName string, params NetworkPolicyParams, config application.ConfigAttributes) error
+
 	// GetService returns the service for the specified application.
 	GetService(appName string, mode DeploymentMode, includeClusterIP bool) (*Service, error)
 
@@ -322,6 +332,18 @@ type ServiceManager interface {
 	WatchService(appName string, mode DeploymentMode) (watcher.NotifyWatcher, error)
 }
 
+// NetworkPolicyParams describes the traffic to be permitted
+// to and from the pods of an application.
+type NetworkPolicyParams struct {
+	// RelatedApplications are the names of the applications related
+	// to the application, whose pods may exchange traffic with it.
+	RelatedApplications []string
+
+	// Exposed is true if the application is exposed, in which case
+	// ingress traffic is permitted from any source.
+	Exposed bool
+}
+
 // Service represents information about the status of a caas service entity.
 type Service struct {
 	Id         string
@@ -354,13 +376,14 @@ type VolumeInfo struct {
 
 // Unit represents information about the status of a "pod".
 type Unit struct {
-	Id             string
-	Address        string
-	Ports          []string
-	Dying          bool
-	Stateful       bool
-	Status         status.StatusInfo
-	FilesystemInfo []FilesystemInfo
+	Id               string
+	Address          string
+	NetworkAddresses []string
+	Ports            []string
+	Dying            bool
+	Stateful         bool
+	Status           status.StatusInfo
+	FilesystemInfo   []FilesystemInfo
 }
 
 // Operator represents information about the status of an "operator pod".
@@ -397,6 +420,10 @@ type ModelOperatorConfig struct {
 	// OperatorImagePath is the docker registry URL for the image.
 	OperatorImagePath string
 
+	// IsPrivateImageRepo is true if the image is pulled using the
+	// image repo pull secret.
+	IsPrivateImageRepo bool
+
 	// Port is the socket port that the operator model will be listening on
 	Port int32
 }
@@ -406,6 +433,10 @@ type OperatorConfig struct {
 	// OperatorImagePath is the docker registry URL for the image.
 	OperatorImagePath string
 
+	// IsPrivateImageRepo is true if the image is pulled using the
+	// image repo pull secret.
+	IsPrivateImageRepo bool
+
 	// Version is the Juju version of the operator image.
 	Version version.Number
 
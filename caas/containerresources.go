@@ -0,0 +1,209 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2"
+)
+
+// CharmContainerName is the name of the container running the charm and
+// the unit agent in the pods of a sidecar application.
+const CharmContainerName = "charm"
+
+// DefaultGPUClass is the resource used to request GPUs when no class is
+// specified.
+const DefaultGPUClass = "nvidia.com/gpu"
+
+// ContainerResources holds the compute resources requested by, and the
+// limits on, a container. As with constraints, CPU is in units of
+// cpu-power, where 100 is a tenth of a core (100 millicores), and memory
+// and ephemeral storage are in MiB. A nil value is not set.
+type ContainerResources struct {
+	CPURequest *uint64
+	CPULimit   *uint64
+
+	MemRequest *uint64
+	MemLimit   *uint64
+
+	EphemeralStorageRequest *uint64
+	EphemeralStorageLimit   *uint64
+
+	// GPUs is the number of GPUs allocated to the container, of the
+	// resource class GPUClass.
+	GPUs     uint64
+	GPUClass string
+}
+
+const (
+	cpuRequestKey              = "cpu-request"
+	cpuLimitKey                = "cpu-limit"
+	memRequestKey              = "mem-request"
+	memLimitKey                = "mem-limit"
+	ephemeralStorageRequestKey = "ephemeral-storage-request"
+	ephemeralStorageLimitKey   = "ephemeral-storage-limit"
+	gpuKey                     = "gpu"
+	gpuClassKey                = "gpu-class"
+)
+
+// Empty returns true if no resources are set.
+func (r ContainerResources) Empty() bool {
+	return r == ContainerResources{}
+}
+
+// String returns the resources in the form accepted by
+// ParseContainerResources, without the container name.
+func (r ContainerResources) String() string {
+	var strs []string
+	add := func(key string, value *uint64, suffix string) {
+		if value != nil {
+			strs = append(strs, fmt.Sprintf("%s=%d%s", key, *value, suffix))
+		}
+	}
+	add(cpuRequestKey, r.CPURequest, "")
+	add(cpuLimitKey, r.CPULimit, "")
+	add(memRequestKey, r.MemRequest, "M")
+	add(memLimitKey, r.MemLimit, "M")
+	add(ephemeralStorageRequestKey, r.EphemeralStorageRequest, "M")
+	add(ephemeralStorageLimitKey, r.EphemeralStorageLimit, "M")
+	if r.GPUs > 0 {
+		strs = append(strs, fmt.Sprintf("%s=%d", gpuKey, r.GPUs))
+	}
+	if r.GPUClass != "" {
+		strs = append(strs, fmt.Sprintf("%s=%s", gpuClassKey, r.GPUClass))
+	}
+	return strings.Join(strs, " ")
+}
+
+// Validate returns an error if the resources are not valid.
+func (r ContainerResources) Validate() error {
+	check := func(name string, request, limit *uint64) error {
+		if request != nil && limit != nil && *request > *limit {
+			return errors.NotValidf("%s request %d greater than limit %d", name, *request, *limit)
+		}
+		return nil
+	}
+	if err := check("cpu", r.CPURequest, r.CPULimit); err != nil {
+		return errors.Trace(err)
+	}
+	if err := check("mem", r.MemRequest, r.MemLimit); err != nil {
+		return errors.Trace(err)
+	}
+	if err := check("ephemeral-storage", r.EphemeralStorageRequest, r.EphemeralStorageLimit); err != nil {
+		return errors.Trace(err)
+	}
+	if r.GPUClass != "" && r.GPUs == 0 {
+		return errors.NotValidf("gpu class %q without a gpu count", r.GPUClass)
+	}
+	return nil
+}
+
+// ParseContainerResources parses the resources of the containers of an
+// application, keyed by container name. The string is a space separated
+// list of <container>.<resource>=<value> pairs, such as
+// "charm.mem-request=256M workload.cpu-limit=200 workload.gpu=1".
+// The resources are cpu-request and cpu-limit, in units of cpu-power,
+// where 1000 is one core; mem-request, mem-limit,
+// ephemeral-storage-request and ephemeral-storage-limit, with an optional M/G/T/P suffix; gpu, the
+// number of GPUs; and gpu-class, the resource used to request them.
+// Charm metadata can't declare container resources, so this config is
+// the only source of them.
+func ParseContainerResources(str string) (map[string]ContainerResources, error) {
+	result := make(map[string]ContainerResources)
+	for _, field := range strings.Fields(str) {
+		keyValue := strings.SplitN(field, "=", 2)
+		if len(keyValue) != 2 {
+			return nil, errors.NotValidf("container resource %q, expected <container>.<resource>=<value>", field)
+		}
+		containerKey := strings.SplitN(keyValue[0], ".", 2)
+		if len(containerKey) != 2 || containerKey[0] == "" {
+			return nil, errors.NotValidf("container resource %q, expected <container>.<resource>=<value>", field)
+		}
+		name, key, value := containerKey[0], containerKey[1], keyValue[1]
+		resources := result[name]
+		if err := resources.set(key, value); err != nil {
+			return nil, errors.Annotatef(err, "container %q", name)
+		}
+		result[name] = resources
+	}
+	for _, name := range sortedContainerNames(result) {
+		if err := result[name].Validate(); err != nil {
+			return nil, errors.Annotatef(err, "container %q", name)
+		}
+	}
+	return result, nil
+}
+
+func (r *ContainerResources) set(key, value string) error {
+	parseCPU := func(target **uint64) error {
+		if *target != nil {
+			return errors.Errorf("%s already set", key)
+		}
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return errors.NotValidf("%s %q, expected a non-negative integer", key, value)
+		}
+		*target = &v
+		return nil
+	}
+	parseSize := func(target **uint64) error {
+		if *target != nil {
+			return errors.Errorf("%s already set", key)
+		}
+		v, err := utils.ParseSize(value)
+		if err != nil {
+			return errors.NotValidf("%s %q, expected a size with optional M/G/T/P suffix", key, value)
+		}
+		*target = &v
+		return nil
+	}
+	switch key {
+	case cpuRequestKey:
+		return parseCPU(&r.CPURequest)
+	case cpuLimitKey:
+		return parseCPU(&r.CPULimit)
+	case memRequestKey:
+		return parseSize(&r.MemRequest)
+	case memLimitKey:
+		return parseSize(&r.MemLimit)
+	case ephemeralStorageRequestKey:
+		return parseSize(&r.EphemeralStorageRequest)
+	case ephemeralStorageLimitKey:
+		return parseSize(&r.EphemeralStorageLimit)
+	case gpuKey:
+		if r.GPUs != 0 {
+			return errors.Errorf("%s already set", key)
+		}
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || v == 0 {
+			return errors.NotValidf("%s %q, expected a positive integer", key, value)
+		}
+		r.GPUs = v
+	case gpuClassKey:
+		if r.GPUClass != "" {
+			return errors.Errorf("%s already set", key)
+		}
+		if value == "" {
+			return errors.NotValidf("empty %s", key)
+		}
+		r.GPUClass = value
+	default:
+		return errors.NotSupportedf("container resource %q", key)
+	}
+	return nil
+}
+
+func sortedContainerNames(resources map[string]ContainerResources) []string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
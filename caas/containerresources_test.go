@@ -0,0 +1,88 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/testing"
+)
+
+type ContainerResourcesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&ContainerResourcesSuite{})
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+func (s *ContainerResourcesSuite) TestParse(c *gc.C) {
+	resources, err := caas.ParseContainerResources(
+		"charm.mem-request=256M workload.cpu-request=100 workload.cpu-limit=250 " +
+			"workload.mem-limit=2G workload.ephemeral-storage-limit=1G workload.gpu=2 workload.gpu-class=amd.com/gpu")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, map[string]caas.ContainerResources{
+		"charm": {
+			MemRequest: uint64Ptr(256),
+		},
+		"workload": {
+			CPURequest:            uint64Ptr(100),
+			CPULimit:              uint64Ptr(250),
+			MemLimit:              uint64Ptr(2048),
+			EphemeralStorageLimit: uint64Ptr(1024),
+			GPUs:                  2,
+			GPUClass:              "amd.com/gpu",
+		},
+	})
+	c.Assert(resources["workload"].String(), gc.Equals,
+		"cpu-request=100 cpu-limit=250 mem-limit=2048M ephemeral-storage-limit=1024M gpu=2 gpu-class=amd.com/gpu")
+}
+
+func (s *ContainerResourcesSuite) TestParseEmpty(c *gc.C) {
+	resources, err := caas.ParseContainerResources("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 0)
+}
+
+func (s *ContainerResourcesSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "mem-limit=1G",
+		err:   `container resource "mem-limit=1G", expected <container>.<resource>=<value> not valid`,
+	}, {
+		value: "workload.mem-limit",
+		err:   `container resource "workload.mem-limit", expected <container>.<resource>=<value> not valid`,
+	}, {
+		value: "workload.disk=1G",
+		err:   `container "workload": container resource "disk" not supported`,
+	}, {
+		value: "workload.cpu-limit=1.5",
+		err:   `container "workload": cpu-limit "1.5", expected a non-negative integer not valid`,
+	}, {
+		value: "workload.mem-request=lots",
+		err:   `container "workload": mem-request "lots", expected a size with optional M/G/T/P suffix not valid`,
+	}, {
+		value: "workload.mem-limit=1G workload.mem-limit=2G",
+		err:   `container "workload": mem-limit already set`,
+	}, {
+		value: "workload.gpu=0",
+		err:   `container "workload": gpu "0", expected a positive integer not valid`,
+	}, {
+		value: "workload.mem-request=2G workload.mem-limit=1G",
+		err:   `container "workload": mem request 2048 greater than limit 1024 not valid`,
+	}, {
+		value: "workload.gpu-class=nvidia.com/gpu",
+		err:   `container "workload": gpu class "nvidia.com/gpu" without a gpu count not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.value)
+		_, err := caas.ParseContainerResources(test.value)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
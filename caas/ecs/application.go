@@ -711,6 +711,10 @@ func (a *app) Scale(scaleTo int) error {
 	return errors.NotImplementedf("scale with ecs")
 }
 
+func (a *app) Utilisation() (caas.ApplicationUtilisation, error) {
+	return caas.ApplicationUtilisation{}, errors.NotSupportedf("utilisation with ecs")
+}
+
 func (a *app) Trust(bool) error {
 	return errors.NotImplementedf("trust with ecs")
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/docker"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/cloudspec"
 	"github.com/juju/juju/environs/config"
@@ -220,6 +221,13 @@ func (env *environ) ExposeService(appName string, resourceTags map[string]string
 	return nil
 }
 
+// EnsureNetworkPolicy creates, updates or removes the network
+// policy for the specified application.
+func (env *environ) EnsureNetworkPolicy(appName string, params caas.NetworkPolicyParams, config application.ConfigAttributes) error {
+	// TODO(ecs): remove from caas.Broker?
+	return nil
+}
+
 // GetAnnotations returns current namespace's annotations.
 func (env *environ) GetAnnotations() annotations.Annotation {
 	// TODO(ecs): remove from caas.Broker?
@@ -338,6 +346,11 @@ func (*environ) ModelOperatorExists() (bool, error) {
 	return false, nil
 }
 
+// EnsureImageRepoSecret implements caas broker's interface.
+func (*environ) EnsureImageRepoSecret(docker.ImageRepoDetails) error {
+	return errors.NotSupportedf("image repo secret on ECS")
+}
+
 // PrecheckInstance performs a preflight check on the specified
 // series and constraints, ensuring that they are possibly valid for
 // creating an instance in this model.
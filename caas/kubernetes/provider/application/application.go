@@ -45,7 +45,7 @@ import (
 var logger = loggo.GetLogger("juju.kubernetes.provider.application")
 
 const (
-	unitContainerName            = "charm"
+	unitContainerName            = caas.CharmContainerName
 	charmVolumeName              = "charm-data"
 	agentProbeInitialDelay int32 = 30
 	agentProbePeriod       int32 = 10
@@ -68,6 +68,10 @@ type app struct {
 	randomPrefix k8sutils.RandomPrefixFunc
 
 	newApplier func() resources.Applier
+
+	// podMetrics fetches the metrics of the pods matching a label
+	// selector from the resource metrics API.
+	podMetrics func(ctx context.Context, labelSelector string) ([]byte, error)
 }
 
 // NewApplication returns an application.
@@ -111,7 +115,7 @@ func newApplication(
 	randomPrefix k8sutils.RandomPrefixFunc,
 	newApplier func() resources.Applier,
 ) caas.Application {
-	return &app{
+	a := &app{
 		name:           name,
 		namespace:      namespace,
 		modelUUID:      modelUUID,
@@ -124,6 +128,8 @@ func newApplication(
 		randomPrefix:   randomPrefix,
 		newApplier:     newApplier,
 	}
+	a.podMetrics = a.getPodMetrics
+	return a
 }
 
 // Ensure creates or updates an application pod with the given application
@@ -304,6 +310,10 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 		if !exists {
 			numPods = int32Ptr(1)
 		}
+		updateStrategy, err := statefulSetUpdateStrategy(config.UpdateStrategy)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		statefulset := resources.StatefulSet{
 			StatefulSet: appsv1.StatefulSet{
 				ObjectMeta: metav1.ObjectMeta{
@@ -321,11 +331,12 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 					Template: corev1.PodTemplateSpec{
 						ObjectMeta: metav1.ObjectMeta{
 							Labels:      a.selectorLabels(),
-							Annotations: a.annotations(config),
+							Annotations: a.podAnnotations(config),
 						},
 						Spec: *podSpec,
 					},
 					PodManagementPolicy: appsv1.ParallelPodManagement,
+					UpdateStrategy:      updateStrategy,
 				},
 			},
 		}
@@ -369,6 +380,10 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 		if err = configureStorage(storageUniqueID, handlePVCForStatelessResource); err != nil {
 			return errors.Trace(err)
 		}
+		strategy, err := deploymentStrategy(config.UpdateStrategy)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		deployment := resources.Deployment{
 			Deployment: appsv1.Deployment{
 				ObjectMeta: metav1.ObjectMeta{
@@ -386,10 +401,11 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 					Template: corev1.PodTemplateSpec{
 						ObjectMeta: metav1.ObjectMeta{
 							Labels:      a.selectorLabels(),
-							Annotations: a.annotations(config),
+							Annotations: a.podAnnotations(config),
 						},
 						Spec: *podSpec,
 					},
+					Strategy: strategy,
 				},
 			},
 		}
@@ -406,6 +422,10 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 		if err = configureStorage(storageUniqueID, handlePVCForStatelessResource); err != nil {
 			return errors.Trace(err)
 		}
+		updateStrategy, err := daemonSetUpdateStrategy(config.UpdateStrategy)
+		if err != nil {
+			return errors.Trace(err)
+		}
 		daemonset := resources.DaemonSet{
 			DaemonSet: appsv1.DaemonSet{
 				ObjectMeta: metav1.ObjectMeta{
@@ -422,10 +442,11 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 					Template: corev1.PodTemplateSpec{
 						ObjectMeta: metav1.ObjectMeta{
 							Labels:      a.selectorLabels(),
-							Annotations: a.annotations(config),
+							Annotations: a.podAnnotations(config),
 						},
 						Spec: *podSpec,
 					},
+					UpdateStrategy: updateStrategy,
 				},
 			},
 		}
@@ -434,6 +455,18 @@ func (a *app) Ensure(config caas.ApplicationConfig) (err error) {
 		return errors.NotSupportedf("unknown deployment type")
 	}
 
+	// The disruption budget is managed by Juju, so it is removed when
+	// no longer configured.
+	if config.DisruptionBudget != nil {
+		pdb, err := a.podDisruptionBudget(config)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		applier.Apply(pdb)
+	} else {
+		applier.Delete(resources.NewPodDisruptionBudget(a.name, a.namespace, nil))
+	}
+
 	return applier.Run(context.Background(), a.client, false)
 }
 
@@ -772,6 +805,8 @@ func (a *app) Delete() error {
 	default:
 		return errors.NotSupportedf("unknown deployment type")
 	}
+	applier.Delete(resources.NewPodDisruptionBudget(a.name, a.namespace, nil))
+	applier.Delete(resources.NewNetworkPolicy(a.name, a.namespace, nil))
 	applier.Delete(resources.NewService(a.name, a.namespace, nil))
 	applier.Delete(resources.NewSecret(a.secretName(), a.namespace, nil))
 	applier.Delete(resources.NewRoleBinding(a.serviceAccountName(), a.namespace, nil))
@@ -810,11 +845,77 @@ func (a *app) WatchReplicas() (watcher.NotifyWatcher, error) {
 			o.LabelSelector = a.labelSelector()
 		}),
 	)
-	return a.newWatcher(factory.Core().V1().Pods().Informer(), a.name, a.clock)
+	podsInformer := factory.Core().V1().Pods().Informer()
+	podsWatcher, err := a.newWatcher(podsInformer, a.name, a.clock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Warning events don't necessarily change the pods they are about,
+	// so watch them too. Events can't be selected by the labels of their
+	// object, so only the warning events about the application's workload
+	// or its pods are passed on.
+	eventsFactory := informers.NewSharedInformerFactoryWithOptions(a.client, 0,
+		informers.WithNamespace(a.namespace),
+		informers.WithTweakListOptions(func(o *metav1.ListOptions) {
+			o.FieldSelector = fields.OneTermEqualSelector("type", corev1.EventTypeWarning).String()
+		}),
+	)
+	eventsInformer := &filteredInformer{
+		SharedIndexInformer: eventsFactory.Core().V1().Events().Informer(),
+		filter:              a.isReplicaEvent(podsInformer.GetStore()),
+	}
+	eventsWatcher, err := a.newWatcher(eventsInformer, a.name, a.clock)
+	if err != nil {
+		podsWatcher.Kill()
+		return nil, errors.Trace(err)
+	}
+	return watcher.NewMultiNotifyWatcher(podsWatcher, eventsWatcher), nil
+}
+
+// isReplicaEvent returns a filter accepting the events about the
+// application's workload or about one of its pods in the pods store.
+func (a *app) isReplicaEvent(pods cache.Store) func(interface{}) bool {
+	return func(obj interface{}) bool {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		event, ok := obj.(*corev1.Event)
+		if !ok {
+			return false
+		}
+		involved := event.InvolvedObject
+		switch involved.Kind {
+		case "Pod":
+			_, exists, err := pods.GetByKey(a.namespace + "/" + involved.Name)
+			return err == nil && exists
+		case "StatefulSet", "Deployment", "DaemonSet":
+			return involved.Name == a.name
+		}
+		return false
+	}
+}
+
+// filteredInformer only passes the changes to the objects accepted by
+// filter on to its event handlers.
+type filteredInformer struct {
+	cache.SharedIndexInformer
+	filter func(interface{}) bool
+}
+
+// AddEventHandler is part of cache.SharedInformer.
+func (i *filteredInformer) AddEventHandler(handler cache.ResourceEventHandler) {
+	i.SharedIndexInformer.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: i.filter,
+		Handler:    handler,
+	})
 }
 
 func (a *app) State() (caas.ApplicationState, error) {
 	state := caas.ApplicationState{}
+	var (
+		kind          string
+		readyReplicas int
+	)
 	switch a.deploymentType {
 	case caas.DeploymentStateful:
 		ss := resources.NewStatefulSet(a.name, a.namespace, nil)
@@ -826,6 +927,7 @@ func (a *app) State() (caas.ApplicationState, error) {
 			return caas.ApplicationState{}, errors.Errorf("missing replicas")
 		}
 		state.DesiredReplicas = int(*ss.Spec.Replicas)
+		kind, readyReplicas = "StatefulSet", int(ss.Status.ReadyReplicas)
 	case caas.DeploymentStateless:
 		d := resources.NewDeployment(a.name, a.namespace, nil)
 		err := d.Get(context.Background(), a.client)
@@ -836,6 +938,7 @@ func (a *app) State() (caas.ApplicationState, error) {
 			return caas.ApplicationState{}, errors.Errorf("missing replicas")
 		}
 		state.DesiredReplicas = int(*d.Spec.Replicas)
+		kind, readyReplicas = "Deployment", int(d.Status.ReadyReplicas)
 	case caas.DeploymentDaemon:
 		d := resources.NewDaemonSet(a.name, a.namespace, nil)
 		err := d.Get(context.Background(), a.client)
@@ -843,9 +946,25 @@ func (a *app) State() (caas.ApplicationState, error) {
 			return caas.ApplicationState{}, errors.Trace(err)
 		}
 		state.DesiredReplicas = int(d.Status.DesiredNumberScheduled)
+		kind, readyReplicas = "DaemonSet", int(d.Status.NumberReady)
 	default:
 		return caas.ApplicationState{}, errors.NotSupportedf("unknown deployment type")
 	}
+	// Warning events are only of interest while the application isn't
+	// ready, as they outlive the problems they report.
+	if readyReplicas < state.DesiredReplicas {
+		events, err := resources.ListEventsForObject(context.Background(), a.client, a.namespace, a.name, kind)
+		if err != nil {
+			return caas.ApplicationState{}, errors.Trace(err)
+		}
+		if message, eventStatus, since, ok := resources.EventStatus(events); ok {
+			state.Status = status.StatusInfo{
+				Status:  eventStatus,
+				Message: message,
+				Since:   &since,
+			}
+		}
+	}
 	next := ""
 	for {
 		res, err := a.client.CoreV1().Pods(a.namespace).List(context.Background(), metav1.ListOptions{
@@ -890,12 +1009,17 @@ func (a *app) Units() ([]caas.Unit, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		networkAddresses, err := k8sutils.SecondaryNetworkAddresses(p.Annotations)
+		if err != nil {
+			logger.Warningf("cannot get secondary network addresses of pod %q: %v", p.Name, err)
+		}
 		unitInfo := caas.Unit{
-			Id:       p.Name,
-			Address:  p.Status.PodIP,
-			Ports:    ports,
-			Dying:    terminated,
-			Stateful: a.deploymentType == caas.DeploymentStateful,
+			Id:               p.Name,
+			Address:          p.Status.PodIP,
+			NetworkAddresses: networkAddresses,
+			Ports:            ports,
+			Dying:            terminated,
+			Stateful:         a.deploymentType == caas.DeploymentStateful,
 			Status: status.StatusInfo{
 				Status:  unitStatus,
 				Message: statusMessage,
@@ -967,25 +1091,22 @@ func (a *app) applicationPodSpec(config caas.ApplicationConfig) (*corev1.PodSpec
 		return containers[i].Name < containers[j].Name
 	})
 
+	// The application constraints are applied as resource requests on
+	// the charm container, which is sufficient for scheduling purposes.
+	// Requests and limits for each container are set by the container
+	// resources.
 	resourceRequests := corev1.ResourceList(nil)
-	millicores := 0
-	// TODO(allow resource limits to be applied to each container).
-	// For now we only do resource requests, one container is sufficient for
-	// scheduling purposes.
 	if config.Constraints.HasCpuPower() {
 		if resourceRequests == nil {
 			resourceRequests = corev1.ResourceList{}
 		}
-		// 100 cpu power is 100 millicores.
-		millicores = int(*config.Constraints.CpuPower)
-		resourceRequests[corev1.ResourceCPU] = *resource.NewMilliQuantity(int64(millicores), resource.DecimalSI)
+		resourceRequests[corev1.ResourceCPU] = cpuQuantity(*config.Constraints.CpuPower)
 	}
 	if config.Constraints.HasMem() {
 		if resourceRequests == nil {
 			resourceRequests = corev1.ResourceList{}
 		}
-		bytes := *config.Constraints.Mem * 1024 * 1024
-		resourceRequests[corev1.ResourceMemory] = *resource.NewQuantity(int64(bytes), resource.BinarySI)
+		resourceRequests[corev1.ResourceMemory] = sizeQuantity(*config.Constraints.Mem)
 	}
 
 	containerSpecs := []corev1.Container{{
@@ -1068,9 +1189,7 @@ func (a *app) applicationPodSpec(config caas.ApplicationConfig) (*corev1.PodSpec
 				SubPath:   "charm/containers",
 			},
 		},
-		Resources: corev1.ResourceRequirements{
-			Requests: resourceRequests,
-		},
+		Resources: resourceRequirements(resourceRequests, config.CharmContainerResources),
 	}}
 
 	for _, v := range containers {
@@ -1109,6 +1228,7 @@ func (a *app) applicationPodSpec(config caas.ApplicationConfig) (*corev1.PodSpec
 					SubPath:   fmt.Sprintf("charm/containers/%s", v.Name),
 				},
 			},
+			Resources: resourceRequirements(nil, v.Resources),
 		}
 		containerSpecs = append(containerSpecs, container)
 	}
@@ -1133,9 +1253,15 @@ func (a *app) applicationPodSpec(config caas.ApplicationConfig) (*corev1.PodSpec
 		nodeSelector = map[string]string{"kubernetes.io/arch": cpuArch}
 	}
 
+	var imagePullSecrets []corev1.LocalObjectReference
+	if config.IsPrivateImageRepo {
+		imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{Name: constants.CAASImageRepoSecretName})
+	}
+
 	automountToken := true
-	return &corev1.PodSpec{
+	spec := &corev1.PodSpec{
 		AutomountServiceAccountToken: &automountToken,
+		ImagePullSecrets:             imagePullSecrets,
 		ServiceAccountName:           a.serviceAccountName(),
 		NodeSelector:                 nodeSelector,
 		InitContainers: []corev1.Container{{
@@ -1204,7 +1330,11 @@ func (a *app) applicationPodSpec(config caas.ApplicationConfig) (*corev1.PodSpec
 				},
 			},
 		},
-	}, nil
+	}
+	if err := k8sutils.ApplyPlacement(spec, config.Constraints, a.legacyLabels); err != nil {
+		return nil, errors.Annotatef(err, "configuring placement for %s", a.name)
+	}
+	return spec, nil
 }
 
 func (a *app) annotations(config caas.ApplicationConfig) annotations.Annotation {
@@ -1212,6 +1342,16 @@ func (a *app) annotations(config caas.ApplicationConfig) annotations.Annotation
 		Merge(k8sutils.AnnotationsForVersion(config.AgentVersion.String(), a.legacyLabels))
 }
 
+// podAnnotations returns the annotations for the application pods,
+// which also request any secondary network attachments.
+func (a *app) podAnnotations(config caas.ApplicationConfig) annotations.Annotation {
+	result := a.annotations(config)
+	if len(config.NetworkAttachments) > 0 {
+		result.Add(k8sutils.AnnotationKeyNetworks, k8sutils.NetworksAnnotationValue(config.NetworkAttachments))
+	}
+	return result
+}
+
 func (a *app) labels() labels.Set {
 	// TODO: add modelUUID for global resources?
 	return k8sutils.LabelsForApp(a.name, a.legacyLabels)
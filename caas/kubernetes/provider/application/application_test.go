@@ -18,6 +18,8 @@ import (
 	gc "gopkg.in/check.v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8sresource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -106,6 +108,53 @@ func (s *applicationSuite) getApp(c *gc.C, deploymentType caas.DeploymentType, m
 	), ctrl
 }
 
+func ensureConfig(cons constraints.Value) caas.ApplicationConfig {
+	return caas.ApplicationConfig{
+		AgentImagePath: "operator/image-path",
+		CharmBaseImage: coreresources.DockerImageDetails{
+			RegistryPath: "ubuntu:20.04",
+		},
+		CharmModifiedVersion: 9001,
+		Filesystems: []storage.KubernetesFilesystemParams{
+			{
+				StorageName: "database",
+				Size:        100,
+				Provider:    "kubernetes",
+				Attributes:  map[string]interface{}{"storage-class": "workload-storage"},
+				Attachment: &storage.KubernetesFilesystemAttachmentParams{
+					Path: "path/to/here",
+				},
+				ResourceTags: map[string]string{"foo": "bar"},
+			},
+			// TODO(embedded): fix here - all filesystems will not be mounted if it's not in `Containers[*].Mounts`
+			// {
+			// 	StorageName: "logs",
+			// 	Size:        200,
+			// 	Provider:    "tmpfs",
+			// 	Attributes:  map[string]interface{}{"storage-medium": "Memory"},
+			// 	Attachment: &storage.KubernetesFilesystemAttachmentParams{
+			// 		Path: "path/to/there",
+			// 	},
+			// },
+		},
+		Containers: map[string]caas.ContainerConfig{
+			"gitlab": {
+				Name: "gitlab",
+				Image: coreresources.DockerImageDetails{
+					RegistryPath: "gitlab-image:latest",
+				},
+				Mounts: []caas.MountConfig{
+					{
+						StorageName: "database",
+						Path:        "path/to/here",
+					},
+				},
+			},
+		},
+		Constraints: cons,
+	}
+}
+
 func (s *applicationSuite) assertEnsure(c *gc.C, deploymentType caas.DeploymentType, cons constraints.Value, checkMainResource func()) {
 	appSecret := corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -147,52 +196,7 @@ func (s *applicationSuite) assertEnsure(c *gc.C, deploymentType caas.DeploymentT
 
 	app, _ := s.getApp(c, deploymentType, false)
 
-	c.Assert(app.Ensure(
-		caas.ApplicationConfig{
-			AgentImagePath: "operator/image-path",
-			CharmBaseImage: coreresources.DockerImageDetails{
-				RegistryPath: "ubuntu:20.04",
-			},
-			CharmModifiedVersion: 9001,
-			Filesystems: []storage.KubernetesFilesystemParams{
-				{
-					StorageName: "database",
-					Size:        100,
-					Provider:    "kubernetes",
-					Attributes:  map[string]interface{}{"storage-class": "workload-storage"},
-					Attachment: &storage.KubernetesFilesystemAttachmentParams{
-						Path: "path/to/here",
-					},
-					ResourceTags: map[string]string{"foo": "bar"},
-				},
-				// TODO(embedded): fix here - all filesystems will not be mounted if it's not in `Containers[*].Mounts`
-				// {
-				// 	StorageName: "logs",
-				// 	Size:        200,
-				// 	Provider:    "tmpfs",
-				// 	Attributes:  map[string]interface{}{"storage-medium": "Memory"},
-				// 	Attachment: &storage.KubernetesFilesystemAttachmentParams{
-				// 		Path: "path/to/there",
-				// 	},
-				// },
-			},
-			Containers: map[string]caas.ContainerConfig{
-				"gitlab": {
-					Name: "gitlab",
-					Image: coreresources.DockerImageDetails{
-						RegistryPath: "gitlab-image:latest",
-					},
-					Mounts: []caas.MountConfig{
-						{
-							StorageName: "database",
-							Path:        "path/to/here",
-						},
-					},
-				},
-			},
-			Constraints: cons,
-		},
-	), jc.ErrorIsNil)
+	c.Assert(app.Ensure(ensureConfig(cons)), jc.ErrorIsNil)
 
 	secret, err := s.client.CoreV1().Secrets("test").Get(context.TODO(), "gitlab-application-config", metav1.GetOptions{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -620,6 +624,221 @@ func (s *applicationSuite) TestEnsureDaemon(c *gc.C) {
 	)
 }
 
+func (s *applicationSuite) TestEnsureNetworkAttachments(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateless, false)
+
+	config := ensureConfig(constraints.Value{})
+	config.NetworkAttachments = []string{"storage", "backend"}
+	c.Assert(app.Ensure(config), jc.ErrorIsNil)
+
+	deployment, err := s.client.AppsV1().Deployments("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployment.Spec.Template.Annotations, jc.DeepEquals, map[string]string{
+		"juju.is/version":             "0.0.0",
+		"k8s.v1.cni.cncf.io/networks": "storage,backend",
+	})
+	// Only the pods are attached to the networks.
+	c.Assert(deployment.Annotations, jc.DeepEquals, map[string]string{
+		"juju.is/version":  "0.0.0",
+		"app.juju.is/uuid": "appuuid",
+	})
+}
+
+func (s *applicationSuite) TestEnsureUpdateStrategyStateful(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+
+	config := ensureConfig(constraints.Value{})
+	partition := int32(2)
+	config.UpdateStrategy = caas.UpdateStrategy{Partition: &partition}
+	c.Assert(app.Ensure(config), jc.ErrorIsNil)
+
+	ss, err := s.client.AppsV1().StatefulSets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ss.Spec.UpdateStrategy, jc.DeepEquals, appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: application.Int32Ptr(2),
+		},
+	})
+}
+
+func (s *applicationSuite) TestEnsureUpdateStrategyStateless(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateless, false)
+
+	config := ensureConfig(constraints.Value{})
+	config.UpdateStrategy = caas.UpdateStrategy{MaxUnavailable: "1", MaxSurge: "25%"}
+	c.Assert(app.Ensure(config), jc.ErrorIsNil)
+
+	maxUnavailable := intstr.FromInt(1)
+	maxSurge := intstr.FromString("25%")
+	deployment, err := s.client.AppsV1().Deployments("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployment.Spec.Strategy, jc.DeepEquals, appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &maxUnavailable,
+			MaxSurge:       &maxSurge,
+		},
+	})
+}
+
+func (s *applicationSuite) TestEnsureUpdateStrategyDaemon(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentDaemon, false)
+
+	config := ensureConfig(constraints.Value{})
+	config.UpdateStrategy = caas.UpdateStrategy{MaxUnavailable: "10%"}
+	c.Assert(app.Ensure(config), jc.ErrorIsNil)
+
+	maxUnavailable := intstr.FromString("10%")
+	daemonset, err := s.client.AppsV1().DaemonSets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(daemonset.Spec.UpdateStrategy, jc.DeepEquals, appsv1.DaemonSetUpdateStrategy{
+		Type: appsv1.RollingUpdateDaemonSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+			MaxUnavailable: &maxUnavailable,
+		},
+	})
+}
+
+func (s *applicationSuite) TestEnsureUpdateStrategyNotValid(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateless, false)
+
+	config := ensureConfig(constraints.Value{})
+	config.UpdateStrategy = caas.UpdateStrategy{MaxSurge: "lots"}
+	err := app.Ensure(config)
+	c.Assert(err, gc.ErrorMatches, `max surge: number or percentage of pods "lots" not valid`)
+}
+
+func (s *applicationSuite) TestEnsureDisruptionBudget(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+
+	config := ensureConfig(constraints.Value{})
+	config.DisruptionBudget = &caas.DisruptionBudget{MaxUnavailable: "1"}
+	c.Assert(app.Ensure(config), jc.ErrorIsNil)
+
+	maxUnavailable := intstr.FromInt(1)
+	pdb, err := s.client.PolicyV1beta1().PodDisruptionBudgets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pdb, jc.DeepEquals, &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gitlab",
+			Namespace: "test",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "gitlab",
+				"app.kubernetes.io/managed-by": "juju",
+			},
+			Annotations: map[string]string{"juju.is/version": "0.0.0"},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+			},
+			MaxUnavailable: &maxUnavailable,
+		},
+	})
+
+	// The budget is removed once it's no longer configured.
+	config.DisruptionBudget = nil
+	c.Assert(app.Ensure(config), jc.ErrorIsNil)
+	_, err = s.client.PolicyV1beta1().PodDisruptionBudgets("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.Satisfies, k8serrors.IsNotFound)
+}
+
+func (s *applicationSuite) TestEnsureDisruptionBudgetNotValid(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+
+	config := ensureConfig(constraints.Value{})
+	config.DisruptionBudget = &caas.DisruptionBudget{MinAvailable: "2", MaxUnavailable: "1"}
+	err := app.Ensure(config)
+	c.Assert(err, gc.ErrorMatches, `disruption budget without exactly one of min available and max unavailable not valid`)
+}
+
+func (s *applicationSuite) TestEnsureContainerResources(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateless, false)
+
+	config := ensureConfig(constraints.MustParse("mem=1G cpu-power=500"))
+	memRequest := uint64(256)
+	config.CharmContainerResources = caas.ContainerResources{MemRequest: &memRequest}
+	cpuLimit, memLimit, storageRequest := uint64(200), uint64(2048), uint64(1024)
+	gitlab := config.Containers["gitlab"]
+	gitlab.Resources = caas.ContainerResources{
+		CPULimit:                &cpuLimit,
+		MemLimit:                &memLimit,
+		EphemeralStorageRequest: &storageRequest,
+		GPUs:                    1,
+	}
+	config.Containers["gitlab"] = gitlab
+	c.Assert(app.Ensure(config), jc.ErrorIsNil)
+
+	deployment, err := s.client.AppsV1().Deployments("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	containers := deployment.Spec.Template.Spec.Containers
+	c.Assert(containers, gc.HasLen, 2)
+
+	// The container resources override the requests from the constraints.
+	c.Assert(containers[0].Name, gc.Equals, "charm")
+	c.Assert(quantities(containers[0].Resources.Requests), jc.DeepEquals, map[string]string{
+		"cpu":    "500m",
+		"memory": "256Mi",
+	})
+	c.Assert(containers[0].Resources.Limits, gc.HasLen, 0)
+
+	c.Assert(containers[1].Name, gc.Equals, "gitlab")
+	c.Assert(quantities(containers[1].Resources.Requests), jc.DeepEquals, map[string]string{
+		"ephemeral-storage": "1Gi",
+		"nvidia.com/gpu":    "1",
+	})
+	c.Assert(quantities(containers[1].Resources.Limits), jc.DeepEquals, map[string]string{
+		"cpu":            "200m",
+		"memory":         "2Gi",
+		"nvidia.com/gpu": "1",
+	})
+}
+
+func (s *applicationSuite) TestEnsurePlacement(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateless, false)
+
+	config := ensureConfig(constraints.MustParse(
+		"arch=amd64 tags=node-selector.pool=fast,anti-pod.application=gitlab,toleration.dedicated=juju:NoSchedule"))
+	c.Assert(app.Ensure(config), jc.ErrorIsNil)
+
+	deployment, err := s.client.AppsV1().Deployments("test").Get(context.TODO(), "gitlab", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := deployment.Spec.Template.Spec
+	c.Assert(podSpec.NodeSelector, jc.DeepEquals, map[string]string{
+		"kubernetes.io/arch": "amd64",
+		"pool":               "fast",
+	})
+	c.Assert(podSpec.Tolerations, jc.DeepEquals, []corev1.Toleration{{
+		Key:      "dedicated",
+		Operator: corev1.TolerationOpEqual,
+		Value:    "juju",
+		Effect:   corev1.TaintEffectNoSchedule,
+	}})
+	c.Assert(podSpec.Affinity, jc.DeepEquals, &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+				LabelSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "app.kubernetes.io/name",
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"gitlab"},
+					}},
+				},
+				TopologyKey: "kubernetes.io/hostname",
+			}},
+		},
+	})
+}
+
+func quantities(list corev1.ResourceList) map[string]string {
+	result := make(map[string]string)
+	for name, quantity := range list {
+		result[string(name)] = quantity.String()
+	}
+	return result
+}
+
 func (s *applicationSuite) TestExistsNotSupported(c *gc.C) {
 	app, _ := s.getApp(c, "notsupported", false)
 	_, err := app.Exists()
@@ -752,6 +971,8 @@ func (s *applicationSuite) TestDeleteStateful(c *gc.C) {
 	gomock.InOrder(
 		s.applier.EXPECT().Delete(resources.NewStatefulSet("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab-endpoints", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewPodDisruptionBudget("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewNetworkPolicy("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewSecret("gitlab-application-config", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewRoleBinding("gitlab", "test", nil)),
@@ -768,6 +989,8 @@ func (s *applicationSuite) TestDeleteStateless(c *gc.C) {
 
 	gomock.InOrder(
 		s.applier.EXPECT().Delete(resources.NewDeployment("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewPodDisruptionBudget("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewNetworkPolicy("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewSecret("gitlab-application-config", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewRoleBinding("gitlab", "test", nil)),
@@ -784,6 +1007,8 @@ func (s *applicationSuite) TestDeleteDaemon(c *gc.C) {
 
 	gomock.InOrder(
 		s.applier.EXPECT().Delete(resources.NewDaemonSet("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewPodDisruptionBudget("gitlab", "test", nil)),
+		s.applier.EXPECT().Delete(resources.NewNetworkPolicy("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewService("gitlab", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewSecret("gitlab-application-config", "test", nil)),
 		s.applier.EXPECT().Delete(resources.NewRoleBinding("gitlab", "test", nil)),
@@ -847,6 +1072,81 @@ func (s *applicationSuite) TestWatchReplicas(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestWatchReplicasFiltersEvents(c *gc.C) {
+	app, ctrl := s.getApp(c, caas.DeploymentStateful, true)
+	defer ctrl.Finish()
+
+	var informers []cache.SharedIndexInformer
+	s.k8sWatcherFn = func(i cache.SharedIndexInformer, _ string, _ jujuclock.Clock) (k8swatcher.KubernetesNotifyWatcher, error) {
+		informers = append(informers, i)
+		w, _ := k8swatchertest.NewKubernetesTestWatcher()
+		return w, nil
+	}
+	_, err := app.WatchReplicas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(informers, gc.HasLen, 2)
+	podsInformer, eventsInformer := informers[0], informers[1]
+
+	seen := make(chan string, 10)
+	eventsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			seen <- obj.(*corev1.Event).Name
+		},
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	go podsInformer.Run(stop)
+	go eventsInformer.Run(stop)
+
+	_, err = s.client.CoreV1().Pods("test").Create(context.TODO(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gitlab-0",
+			Namespace: "test",
+			Labels:    map[string]string{"app.kubernetes.io/name": "gitlab"},
+		},
+	}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	for a := testing.LongAttempt.Start(); ; {
+		if _, exists, _ := podsInformer.GetStore().GetByKey("test/gitlab-0"); exists {
+			break
+		}
+		c.Assert(a.Next(), jc.IsTrue, gc.Commentf("pod not seen by informer"))
+	}
+
+	for _, involved := range []corev1.ObjectReference{
+		{Kind: "Pod", Name: "mariadb-0"},
+		{Kind: "StatefulSet", Name: "mariadb"},
+		{Kind: "Pod", Name: "gitlab-0"},
+		{Kind: "StatefulSet", Name: "gitlab"},
+	} {
+		_, err = s.client.CoreV1().Events("test").Create(context.TODO(), &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      strings.ToLower(involved.Kind) + "-" + involved.Name,
+				Namespace: "test",
+			},
+			InvolvedObject: involved,
+			Type:           corev1.EventTypeWarning,
+		}, metav1.CreateOptions{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	var names []string
+	for len(names) < 2 {
+		select {
+		case name := <-seen:
+			names = append(names, name)
+		case <-time.After(testing.LongWait):
+			c.Fatalf("timed out waiting for events, saw %v", names)
+		}
+	}
+	select {
+	case name := <-seen:
+		c.Fatalf("unexpected event %q", name)
+	case <-time.After(testing.ShortWait):
+	}
+	c.Assert(names, jc.SameContents, []string{"pod-gitlab-0", "statefulset-gitlab"})
+}
+
 func (s *applicationSuite) TestStateNotSupported(c *gc.C) {
 	app, _ := s.getApp(c, "notsupported", false)
 	_, err := app.State()
@@ -978,6 +1278,48 @@ func (s *applicationSuite) TestStateDaemon(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestStateWarningEvent(c *gc.C) {
+	app, ctrl := s.getApp(c, caas.DeploymentStateless, false)
+	defer ctrl.Finish()
+
+	dmr := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gitlab",
+			Namespace: "test",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: application.Int32Ptr(2),
+		},
+	}
+	_, err := s.client.AppsV1().Deployments("test").Create(context.TODO(),
+		dmr, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	lastSeen := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err = s.client.CoreV1().Events("test").Create(context.TODO(), &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gitlab.1",
+			Namespace: "test",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Name: "gitlab",
+			Kind: "Deployment",
+		},
+		Type:          corev1.EventTypeWarning,
+		Reason:        "FailedCreate",
+		Message:       `pods "gitlab-0" is forbidden: exceeded quota`,
+		LastTimestamp: metav1.NewTime(lastSeen),
+	}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	appState, err := app.State()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appState.DesiredReplicas, gc.Equals, 2)
+	c.Assert(appState.Status.Status, gc.Equals, status.Blocked)
+	c.Assert(appState.Status.Message, gc.Equals, `failed to create pods: pods "gitlab-0" is forbidden: exceeded quota`)
+	c.Assert(appState.Status.Since.Equal(lastSeen), jc.IsTrue)
+}
+
 func getDefaultSvc() *corev1.Service {
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1470,6 +1812,10 @@ func (s *applicationSuite) TestUnits(c *gc.C) {
 		switch i {
 		case 0:
 			pod.Status.Phase = corev1.PodRunning
+			pod.Annotations["k8s.v1.cni.cncf.io/network-status"] = `[
+				{"name": "k8s-pod-network", "ips": ["10.10.10.0"], "default": true},
+				{"name": "test/storage", "interface": "net1", "ips": ["192.168.1.10"]}
+			]`
 		case 1:
 			pod.Status.Phase = corev1.PodPending
 		case 2:
@@ -1542,11 +1888,12 @@ func (s *applicationSuite) TestUnits(c *gc.C) {
 
 	c.Assert(units, mc, []caas.Unit{
 		{
-			Id:       "gitlab-0",
-			Address:  "10.10.10.0",
-			Ports:    []string(nil),
-			Dying:    false,
-			Stateful: true,
+			Id:               "gitlab-0",
+			Address:          "10.10.10.0",
+			NetworkAddresses: []string{"192.168.1.10"},
+			Ports:            []string(nil),
+			Dying:            false,
+			Stateful:         true,
 			Status: status.StatusInfo{
 				Status: "running",
 			},
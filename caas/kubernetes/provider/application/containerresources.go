@@ -0,0 +1,66 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/juju/juju/caas"
+)
+
+// cpuQuantity returns the quantity of cpu for a cpu-power value, where
+// 100 cpu-power is 100 millicores.
+func cpuQuantity(cpuPower uint64) resource.Quantity {
+	return *resource.NewMilliQuantity(int64(cpuPower), resource.DecimalSI)
+}
+
+// sizeQuantity returns the quantity of memory or storage for a size
+// in MiB.
+func sizeQuantity(mib uint64) resource.Quantity {
+	return *resource.NewQuantity(int64(mib*1024*1024), resource.BinarySI)
+}
+
+// resourceRequirements returns the resource requests and limits for a
+// container, with the container's resources overriding the requests
+// derived from the application constraints.
+func resourceRequirements(requests corev1.ResourceList, res caas.ContainerResources) corev1.ResourceRequirements {
+	var result corev1.ResourceRequirements
+	if len(requests) > 0 {
+		result.Requests = requests.DeepCopy()
+	}
+	set := func(list *corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+		if *list == nil {
+			*list = corev1.ResourceList{}
+		}
+		(*list)[name] = quantity
+	}
+	setSize := func(list *corev1.ResourceList, name corev1.ResourceName, mib *uint64) {
+		if mib != nil {
+			set(list, name, sizeQuantity(*mib))
+		}
+	}
+	if res.CPURequest != nil {
+		set(&result.Requests, corev1.ResourceCPU, cpuQuantity(*res.CPURequest))
+	}
+	if res.CPULimit != nil {
+		set(&result.Limits, corev1.ResourceCPU, cpuQuantity(*res.CPULimit))
+	}
+	setSize(&result.Requests, corev1.ResourceMemory, res.MemRequest)
+	setSize(&result.Limits, corev1.ResourceMemory, res.MemLimit)
+	setSize(&result.Requests, corev1.ResourceEphemeralStorage, res.EphemeralStorageRequest)
+	setSize(&result.Limits, corev1.ResourceEphemeralStorage, res.EphemeralStorageLimit)
+	if res.GPUs > 0 {
+		gpuClass := res.GPUClass
+		if gpuClass == "" {
+			gpuClass = caas.DefaultGPUClass
+		}
+		// GPUs can't be overcommitted, so the request has to be the
+		// same as the limit.
+		gpus := *resource.NewQuantity(int64(res.GPUs), resource.DecimalSI)
+		set(&result.Limits, corev1.ResourceName(gpuClass), gpus)
+		set(&result.Requests, corev1.ResourceName(gpuClass), gpus)
+	}
+	return result
+}
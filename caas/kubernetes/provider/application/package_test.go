@@ -4,9 +4,12 @@
 package application
 
 import (
+	"context"
 	"testing"
 
 	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
 )
 
 func Test(t *testing.T) {
@@ -20,3 +23,7 @@ var (
 	StrPtr                = strPtr
 	NewApplicationForTest = newApplication
 )
+
+func SetPodMetrics(a caas.Application, f func(context.Context, string) ([]byte, error)) {
+	a.(*app).podMetrics = f
+}
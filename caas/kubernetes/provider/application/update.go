@@ -0,0 +1,119 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
+)
+
+// parseIntOrPercent parses a number or percentage of pods, returning
+// nil if the value is empty.
+func parseIntOrPercent(value string) (*intstr.IntOrString, error) {
+	if value == "" {
+		return nil, nil
+	}
+	result := intstr.Parse(value)
+	if _, err := intstr.GetValueFromIntOrPercent(&result, 100, true); err != nil {
+		return nil, errors.NotValidf("number or percentage of pods %q", value)
+	}
+	if result.Type == intstr.Int && result.IntVal < 0 {
+		return nil, errors.NotValidf("number of pods %d", result.IntVal)
+	}
+	return &result, nil
+}
+
+// statefulSetUpdateStrategy returns the rolling update strategy for a
+// stateful application, partitioned if requested.
+func statefulSetUpdateStrategy(strategy caas.UpdateStrategy) (appsv1.StatefulSetUpdateStrategy, error) {
+	if strategy.Partition == nil {
+		return appsv1.StatefulSetUpdateStrategy{}, nil
+	}
+	if *strategy.Partition < 0 {
+		return appsv1.StatefulSetUpdateStrategy{}, errors.NotValidf("update partition %d", *strategy.Partition)
+	}
+	return appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: int32Ptr(*strategy.Partition),
+		},
+	}, nil
+}
+
+// deploymentStrategy returns the rolling update strategy for a
+// stateless application.
+func deploymentStrategy(strategy caas.UpdateStrategy) (appsv1.DeploymentStrategy, error) {
+	maxUnavailable, err := parseIntOrPercent(strategy.MaxUnavailable)
+	if err != nil {
+		return appsv1.DeploymentStrategy{}, errors.Annotate(err, "max unavailable")
+	}
+	maxSurge, err := parseIntOrPercent(strategy.MaxSurge)
+	if err != nil {
+		return appsv1.DeploymentStrategy{}, errors.Annotate(err, "max surge")
+	}
+	if maxUnavailable == nil && maxSurge == nil {
+		return appsv1.DeploymentStrategy{}, nil
+	}
+	return appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: maxUnavailable,
+			MaxSurge:       maxSurge,
+		},
+	}, nil
+}
+
+// daemonSetUpdateStrategy returns the rolling update strategy for a
+// daemon application.
+func daemonSetUpdateStrategy(strategy caas.UpdateStrategy) (appsv1.DaemonSetUpdateStrategy, error) {
+	maxUnavailable, err := parseIntOrPercent(strategy.MaxUnavailable)
+	if err != nil {
+		return appsv1.DaemonSetUpdateStrategy{}, errors.Annotate(err, "max unavailable")
+	}
+	if maxUnavailable == nil {
+		return appsv1.DaemonSetUpdateStrategy{}, nil
+	}
+	return appsv1.DaemonSetUpdateStrategy{
+		Type: appsv1.RollingUpdateDaemonSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+			MaxUnavailable: maxUnavailable,
+		},
+	}, nil
+}
+
+// podDisruptionBudget returns the pod disruption budget covering the
+// application's pods.
+func (a *app) podDisruptionBudget(config caas.ApplicationConfig) (*resources.PodDisruptionBudget, error) {
+	budget := config.DisruptionBudget
+	if err := budget.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	minAvailable, err := parseIntOrPercent(budget.MinAvailable)
+	if err != nil {
+		return nil, errors.Annotate(err, "disruption budget min available")
+	}
+	maxUnavailable, err := parseIntOrPercent(budget.MaxUnavailable)
+	if err != nil {
+		return nil, errors.Annotate(err, "disruption budget max unavailable")
+	}
+	return resources.NewPodDisruptionBudget(a.name, a.namespace, &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      a.labels(),
+			Annotations: a.annotations(config),
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: a.selectorLabels(),
+			},
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+		},
+	}), nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/juju/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+// podMetricsList is the subset of the metrics.k8s.io PodMetricsList
+// needed to work out the usage of an application's pods.
+type podMetricsList struct {
+	Items []podMetrics `json:"items"`
+}
+
+type podMetrics struct {
+	Metadata   metav1.ObjectMeta  `json:"metadata"`
+	Containers []containerMetrics `json:"containers"`
+}
+
+type containerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+// getPodMetrics fetches the metrics for the application's pods from the
+// resource metrics API served by the cluster's metrics server.
+func (a *app) getPodMetrics(ctx context.Context, labelSelector string) ([]byte, error) {
+	return a.client.CoreV1().RESTClient().Get().
+		AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", a.namespace, "pods").
+		Param("labelSelector", labelSelector).
+		DoRaw(ctx)
+}
+
+// Utilisation returns the average CPU and memory usage of the
+// application's running pods, as a percentage of the resources their
+// containers request. Pods without metrics are left out.
+func (a *app) Utilisation() (caas.ApplicationUtilisation, error) {
+	ctx := context.Background()
+	selector := a.labelSelector()
+	pods, err := a.client.CoreV1().Pods(a.namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return caas.ApplicationUtilisation{}, errors.Trace(err)
+	}
+	requests := make(map[string]corev1.ResourceList)
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			continue
+		}
+		podRequests := corev1.ResourceList{}
+		for _, container := range pod.Spec.Containers {
+			addResources(podRequests, container.Resources.Requests)
+		}
+		requests[pod.Name] = podRequests
+	}
+
+	data, err := a.podMetrics(ctx, selector)
+	if err != nil {
+		return caas.ApplicationUtilisation{}, errors.Annotate(err, "getting pod metrics")
+	}
+	var metrics podMetricsList
+	if err := json.Unmarshal(data, &metrics); err != nil {
+		return caas.ApplicationUtilisation{}, errors.Annotate(err, "decoding pod metrics")
+	}
+
+	var result caas.ApplicationUtilisation
+	totalRequests := corev1.ResourceList{}
+	totalUsage := corev1.ResourceList{}
+	for _, m := range metrics.Items {
+		podRequests, ok := requests[m.Metadata.Name]
+		if !ok {
+			continue
+		}
+		result.Units++
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			request, ok := podRequests[name]
+			if !ok || request.IsZero() {
+				continue
+			}
+			for _, container := range m.Containers {
+				if usage, ok := container.Usage[name]; ok {
+					addQuantity(totalUsage, name, usage)
+				}
+			}
+			addQuantity(totalRequests, name, request)
+		}
+	}
+	result.CPU = percentage(totalUsage, totalRequests, corev1.ResourceCPU)
+	result.Memory = percentage(totalUsage, totalRequests, corev1.ResourceMemory)
+	return result, nil
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for name, quantity := range add {
+		addQuantity(total, name, quantity)
+	}
+}
+
+func addQuantity(total corev1.ResourceList, name corev1.ResourceName, quantity resource.Quantity) {
+	sum := total[name]
+	sum.Add(quantity)
+	total[name] = sum
+}
+
+// percentage returns the usage of the named resource as a percentage of
+// the amount requested, or nil if none was requested.
+func percentage(usage, requests corev1.ResourceList, name corev1.ResourceName) *float64 {
+	request, ok := requests[name]
+	if !ok || request.IsZero() {
+		return nil
+	}
+	used := usage[name]
+	p := float64(used.MilliValue()) / float64(request.MilliValue()) * 100
+	return &p
+}
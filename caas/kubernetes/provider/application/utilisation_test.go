@@ -0,0 +1,111 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/application"
+)
+
+const podMetricsJSON = `{
+  "kind": "PodMetricsList",
+  "apiVersion": "metrics.k8s.io/v1beta1",
+  "items": [{
+    "metadata": {"name": "gitlab-0", "namespace": "test"},
+    "containers": [
+      {"name": "charm", "usage": {"cpu": "50m", "memory": "32Mi"}},
+      {"name": "gitlab", "usage": {"cpu": "250m", "memory": "96Mi"}}
+    ]
+  }, {
+    "metadata": {"name": "gitlab-1", "namespace": "test"},
+    "containers": [
+      {"name": "charm", "usage": {"cpu": "50m", "memory": "32Mi"}},
+      {"name": "gitlab", "usage": {"cpu": "50m", "memory": "64Mi"}}
+    ]
+  }, {
+    "metadata": {"name": "gitlab-2", "namespace": "test"},
+    "containers": [
+      {"name": "gitlab", "usage": {"cpu": "1", "memory": "1Gi"}}
+    ]
+  }]
+}`
+
+func (s *applicationSuite) createPod(c *gc.C, name string, phase corev1.PodPhase, requests corev1.ResourceList) {
+	_, err := s.client.CoreV1().Pods(s.namespace).Create(context.Background(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"app.kubernetes.io/name": s.appName},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:      "charm",
+				Resources: corev1.ResourceRequirements{Requests: requests},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestUtilisation(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	requests := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("200m"),
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	}
+	s.createPod(c, "gitlab-0", corev1.PodRunning, requests)
+	s.createPod(c, "gitlab-1", corev1.PodRunning, requests)
+	s.createPod(c, "gitlab-2", corev1.PodPending, requests)
+
+	var selector string
+	application.SetPodMetrics(app, func(_ context.Context, labelSelector string) ([]byte, error) {
+		selector = labelSelector
+		return []byte(podMetricsJSON), nil
+	})
+
+	utilisation, err := app.Utilisation()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(selector, gc.Equals, "app.kubernetes.io/name=gitlab")
+	c.Assert(utilisation.Units, gc.Equals, 2)
+	c.Assert(utilisation.CPU, gc.NotNil)
+	c.Assert(*utilisation.CPU, gc.Equals, 100.0)
+	c.Assert(utilisation.Memory, gc.NotNil)
+	c.Assert(*utilisation.Memory, gc.Equals, 87.5)
+}
+
+func (s *applicationSuite) TestUtilisationNoRequests(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	s.createPod(c, "gitlab-0", corev1.PodRunning, corev1.ResourceList{
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	})
+	application.SetPodMetrics(app, func(context.Context, string) ([]byte, error) {
+		return []byte(podMetricsJSON), nil
+	})
+
+	utilisation, err := app.Utilisation()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(utilisation.Units, gc.Equals, 1)
+	c.Assert(utilisation.CPU, gc.IsNil)
+	c.Assert(utilisation.Memory, gc.NotNil)
+	c.Assert(*utilisation.Memory, gc.Equals, 100.0)
+}
+
+func (s *applicationSuite) TestUtilisationMetricsError(c *gc.C) {
+	app, _ := s.getApp(c, caas.DeploymentStateful, false)
+	application.SetPodMetrics(app, func(context.Context, string) ([]byte, error) {
+		return nil, errors.New("the server could not find the requested resource")
+	})
+
+	_, err := app.Utilisation()
+	c.Assert(err, gc.ErrorMatches, "getting pod metrics: the server could not find the requested resource")
+}
@@ -69,6 +69,7 @@ type BaseSuite struct {
 	mockIngressClasses         *mocks.MockIngressClassInterface
 	mockIngressV1Beta1         *mocks.MockIngressV1Beta1Interface
 	mockIngressV1              *mocks.MockIngressV1Interface
+	mockNetworkPolicies        *mocks.MockNetworkPolicyInterface
 	mockNodes                  *mocks.MockNodeInterface
 	mockEvents                 *mocks.MockEventInterface
 
@@ -169,6 +170,7 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 		config.NameKey:                  "test",
 		k8sconstants.OperatorStorageKey: "",
 		k8sconstants.WorkloadStorageKey: "",
+		k8sconstants.AdoptNamespaceKey:  false,
 	}))
 	c.Assert(err, jc.ErrorIsNil)
 	s.cfg = cfg
@@ -304,6 +306,8 @@ func (s *BaseSuite) setupK8sRestClient(
 	s.mockNetworkingV1 = mocks.NewMockNetworkingV1Interface(ctrl)
 	s.mockNetworkingV1.EXPECT().Ingresses(namespace).AnyTimes().Return(s.mockIngressV1)
 	s.mockNetworkingV1.EXPECT().IngressClasses().AnyTimes().Return(s.mockIngressClasses)
+	s.mockNetworkPolicies = mocks.NewMockNetworkPolicyInterface(ctrl)
+	s.mockNetworkingV1.EXPECT().NetworkPolicies(namespace).AnyTimes().Return(s.mockNetworkPolicies)
 	s.k8sClient.EXPECT().NetworkingV1().AnyTimes().Return(s.mockNetworkingV1)
 
 	s.mockStorage = mocks.NewMockStorageV1Interface(ctrl)
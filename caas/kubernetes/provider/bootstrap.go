@@ -215,6 +215,13 @@ func newcontrollerStack(
 	agentConfig.SetStateServingInfo(si)
 	pcfg.Bootstrap.StateServingInfo = si
 
+	// The controller uses an externally managed mongo rather than
+	// running its own, if one is configured.
+	if addrs := pcfg.Controller.Config.ExternalMongoAddresses(); len(addrs) > 0 {
+		agentConfig.SetValue(agent.ExternalMongoAddresses, strings.Join(addrs, ","))
+		agentConfig.SetValue(agent.ExternalMongoCACert, pcfg.Controller.Config.ExternalMongoCACert())
+	}
+
 	selectorLabels := providerutils.SelectorLabelsForApp(stackName, false)
 	labels := providerutils.LabelsForApp(stackName, false)
 
@@ -394,6 +401,14 @@ func (c *controllerStack) Deploy() (err error) {
 		return bootstrap.Cancelled()
 	}
 
+	// create image pull secret for controller pod.
+	if err = c.createControllerImageRepoSecret(); err != nil {
+		return errors.Annotate(err, "creating image repo secret for controller")
+	}
+	if isDone() {
+		return bootstrap.Cancelled()
+	}
+
 	// create bootstrap-params configmap for controller pod.
 	if err = c.ensureControllerConfigmapBootstrapParams(); err != nil {
 		return errors.Annotate(err, "creating bootstrap-params configmap for controller")
@@ -634,6 +649,27 @@ func (c *controllerStack) createControllerSecretMongoAdmin() error {
 	return nil
 }
 
+func (c *controllerStack) createControllerImageRepoSecret() error {
+	imageRepo, err := c.pcfg.Controller.Config.CAASImageRepoDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !imageRepo.IsPrivate() {
+		return nil
+	}
+	// Registries issuing short lived pull tokens are refreshed by the
+	// controller model's operator once the controller is running.
+	imageRepo, _, err = imageRepo.RefreshAuth()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.addCleanUp(func() {
+		logger.Debugf("deleting %q image repo secret", constants.CAASImageRepoSecretName)
+		_ = c.broker.deleteSecret(constants.CAASImageRepoSecretName, "")
+	})
+	return c.broker.EnsureImageRepoSecret(imageRepo)
+}
+
 func (c *controllerStack) ensureControllerConfigmapBootstrapParams() error {
 	bootstrapParamsFileContent, err := c.pcfg.Bootstrap.StateInitializationParams.Marshal()
 	if err != nil {
@@ -770,6 +806,16 @@ func (c *controllerStack) createControllerStatefulset() error {
 		},
 	}
 
+	imageRepo, err := c.pcfg.Controller.Config.CAASImageRepoDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if imageRepo.IsPrivate() {
+		spec.Spec.Template.Spec.ImagePullSecrets = []core.LocalObjectReference{
+			{Name: constants.CAASImageRepoSecretName},
+		}
+	}
+
 	if err := c.buildStorageSpecForController(spec); err != nil {
 		return errors.Trace(err)
 	}
@@ -777,7 +823,7 @@ func (c *controllerStack) createControllerStatefulset() error {
 		return errors.Trace(err)
 	}
 
-	if err := processConstraints(&spec.Spec.Template.Spec, c.stackName, c.pcfg.Bootstrap.BootstrapMachineConstraints); err != nil {
+	if err := processConstraints(&spec.Spec.Template.Spec, c.stackName, c.pcfg.Bootstrap.BootstrapMachineConstraints, c.broker.IsLegacyLabels()); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -1041,105 +1087,13 @@ func (c *controllerStack) buildStorageSpecForController(statefulset *apps.Statef
 }
 
 func (c *controllerStack) buildContainerSpecForController(statefulset *apps.StatefulSet) error {
-	var wiredTigerCacheSize float32
-	if c.pcfg.Controller.Config.MongoMemoryProfile() == string(mongo.MemoryProfileLow) {
-		wiredTigerCacheSize = mongo.LowCacheSize
-	}
 	generateContainerSpecs := func(jujudCmd string) []core.Container {
 		var containerSpec []core.Container
-		// add container mongoDB.
-		// TODO(bootstrap): refactor mongo package to make it usable for IAAS and CAAS,
-		// then generate mongo config from EnsureServerParams.
-		probCmds := &core.ExecAction{
-			Command: []string{
-				"mongo",
-				fmt.Sprintf("--port=%d", c.portMongoDB),
-				"--tls",
-				"--tlsAllowInvalidHostnames",
-				"--tlsAllowInvalidCertificates",
-				fmt.Sprintf("--tlsCertificateKeyFile=%s/%s", c.pcfg.DataDir, c.fileNameSSLKey),
-				"--eval",
-				"db.adminCommand('ping')",
-			},
-		}
-		args := []string{
-			fmt.Sprintf("--dbpath=%s/db", c.pcfg.DataDir),
-			fmt.Sprintf("--tlsCertificateKeyFile=%s/%s", c.pcfg.DataDir, c.fileNameSSLKey),
-			"--tlsCertificateKeyFilePassword=ignored",
-			"--tlsMode=requireTLS",
-			fmt.Sprintf("--port=%d", c.portMongoDB),
-			"--journal",
-			fmt.Sprintf("--replSet=%s", mongo.ReplicaSetName),
-			"--quiet",
-			"--oplogSize=1024",
-			"--ipv6",
-			"--auth",
-			fmt.Sprintf("--keyFile=%s/%s", c.pcfg.DataDir, c.fileNameSharedSecret),
-			"--storageEngine=wiredTiger",
-			"--bind_ip_all",
-		}
-		if wiredTigerCacheSize > 0 {
-			args = append(args, fmt.Sprintf("--wiredTigerCacheSizeGB=%v", wiredTigerCacheSize))
+		// add container mongoDB, unless the controller uses an
+		// externally managed mongo.
+		if len(c.pcfg.Controller.Config.ExternalMongoAddresses()) == 0 {
+			containerSpec = append(containerSpec, c.buildMongoDBContainerSpec())
 		}
-		containerSpec = append(containerSpec, core.Container{
-			Name:            mongoDBContainerName,
-			ImagePullPolicy: core.PullIfNotPresent,
-			Image:           c.pcfg.GetJujuDbOCIImagePath(),
-			Command: []string{
-				"mongod",
-			},
-			Args: args,
-			Ports: []core.ContainerPort{
-				{
-					Name:          "mongodb",
-					ContainerPort: int32(c.portMongoDB),
-					Protocol:      "TCP",
-				},
-			},
-			ReadinessProbe: &core.Probe{
-				Handler: core.Handler{
-					Exec: probCmds,
-				},
-				FailureThreshold:    3,
-				InitialDelaySeconds: 5,
-				PeriodSeconds:       10,
-				SuccessThreshold:    1,
-				TimeoutSeconds:      1,
-			},
-			LivenessProbe: &core.Probe{
-				Handler: core.Handler{
-					Exec: probCmds,
-				},
-				FailureThreshold:    3,
-				InitialDelaySeconds: 30,
-				PeriodSeconds:       10,
-				SuccessThreshold:    1,
-				TimeoutSeconds:      5,
-			},
-			VolumeMounts: []core.VolumeMount{
-				{
-					Name:      c.pvcNameControllerPodStorage,
-					MountPath: c.pcfg.DataDir,
-				},
-				{
-					Name:      c.pvcNameControllerPodStorage,
-					MountPath: filepath.Join(c.pcfg.DataDir, "db"),
-					SubPath:   "db",
-				},
-				{
-					Name:      c.resourceNameVolSSLKey,
-					MountPath: filepath.Join(c.pcfg.DataDir, c.fileNameSSLKeyMount),
-					SubPath:   c.fileNameSSLKeyMount,
-					ReadOnly:  true,
-				},
-				{
-					Name:      c.resourceNameVolSharedSecret,
-					MountPath: filepath.Join(c.pcfg.DataDir, c.fileNameSharedSecret),
-					SubPath:   c.fileNameSharedSecret,
-					ReadOnly:  true,
-				},
-			},
-		})
 
 		// add container API server.
 		containerSpec = append(containerSpec, core.Container{
@@ -1237,6 +1191,107 @@ func (c *controllerStack) buildContainerSpecForController(statefulset *apps.Stat
 	return nil
 }
 
+// buildMongoDBContainerSpec returns the spec of the container running the
+// controller's own mongo server.
+func (c *controllerStack) buildMongoDBContainerSpec() core.Container {
+	var wiredTigerCacheSize float32
+	if c.pcfg.Controller.Config.MongoMemoryProfile() == string(mongo.MemoryProfileLow) {
+		wiredTigerCacheSize = mongo.LowCacheSize
+	}
+	// TODO(bootstrap): refactor mongo package to make it usable for IAAS and CAAS,
+	// then generate mongo config from EnsureServerParams.
+	probCmds := &core.ExecAction{
+		Command: []string{
+			"mongo",
+			fmt.Sprintf("--port=%d", c.portMongoDB),
+			"--tls",
+			"--tlsAllowInvalidHostnames",
+			"--tlsAllowInvalidCertificates",
+			fmt.Sprintf("--tlsCertificateKeyFile=%s/%s", c.pcfg.DataDir, c.fileNameSSLKey),
+			"--eval",
+			"db.adminCommand('ping')",
+		},
+	}
+	args := []string{
+		fmt.Sprintf("--dbpath=%s/db", c.pcfg.DataDir),
+		fmt.Sprintf("--tlsCertificateKeyFile=%s/%s", c.pcfg.DataDir, c.fileNameSSLKey),
+		"--tlsCertificateKeyFilePassword=ignored",
+		"--tlsMode=requireTLS",
+		fmt.Sprintf("--port=%d", c.portMongoDB),
+		"--journal",
+		fmt.Sprintf("--replSet=%s", mongo.ReplicaSetName),
+		"--quiet",
+		"--oplogSize=1024",
+		"--ipv6",
+		"--auth",
+		fmt.Sprintf("--keyFile=%s/%s", c.pcfg.DataDir, c.fileNameSharedSecret),
+		"--storageEngine=wiredTiger",
+		"--bind_ip_all",
+	}
+	if wiredTigerCacheSize > 0 {
+		args = append(args, fmt.Sprintf("--wiredTigerCacheSizeGB=%v", wiredTigerCacheSize))
+	}
+	return core.Container{
+		Name:            mongoDBContainerName,
+		ImagePullPolicy: core.PullIfNotPresent,
+		Image:           c.pcfg.GetJujuDbOCIImagePath(),
+		Command: []string{
+			"mongod",
+		},
+		Args: args,
+		Ports: []core.ContainerPort{
+			{
+				Name:          "mongodb",
+				ContainerPort: int32(c.portMongoDB),
+				Protocol:      "TCP",
+			},
+		},
+		ReadinessProbe: &core.Probe{
+			Handler: core.Handler{
+				Exec: probCmds,
+			},
+			FailureThreshold:    3,
+			InitialDelaySeconds: 5,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			TimeoutSeconds:      1,
+		},
+		LivenessProbe: &core.Probe{
+			Handler: core.Handler{
+				Exec: probCmds,
+			},
+			FailureThreshold:    3,
+			InitialDelaySeconds: 30,
+			PeriodSeconds:       10,
+			SuccessThreshold:    1,
+			TimeoutSeconds:      5,
+		},
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      c.pvcNameControllerPodStorage,
+				MountPath: c.pcfg.DataDir,
+			},
+			{
+				Name:      c.pvcNameControllerPodStorage,
+				MountPath: filepath.Join(c.pcfg.DataDir, "db"),
+				SubPath:   "db",
+			},
+			{
+				Name:      c.resourceNameVolSSLKey,
+				MountPath: filepath.Join(c.pcfg.DataDir, c.fileNameSSLKeyMount),
+				SubPath:   c.fileNameSSLKeyMount,
+				ReadOnly:  true,
+			},
+			{
+				Name:      c.resourceNameVolSharedSecret,
+				MountPath: filepath.Join(c.pcfg.DataDir, c.fileNameSharedSecret),
+				SubPath:   c.fileNameSharedSecret,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
+
 func (c *controllerStack) setUpDashboardCommand() (string, error) {
 	if c.pcfg.Bootstrap.Dashboard == nil {
 		return "", nil
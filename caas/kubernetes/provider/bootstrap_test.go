@@ -163,6 +163,20 @@ type svcSpecTC struct {
 	cfg       *podcfg.BootstrapConfig
 }
 
+func (s *bootstrapSuite) TestExternalMongo(c *gc.C) {
+	s.pcfg.Controller.Config[controller.ExternalMongoAddresses] = "db-0.example.com:27017,db-1.example.com:27017"
+	s.pcfg.Controller.Config[controller.ExternalMongoCACert] = testing.CACert
+	controllerStacker := s.controllerStackerGetter()
+
+	agentConfig := controllerStacker.GetAgentConfigContent(c)
+	c.Assert(agentConfig, jc.Contains, "EXTERNAL_MONGO_ADDRESSES: db-0.example.com:27017,db-1.example.com:27017")
+	c.Assert(agentConfig, jc.Contains, "EXTERNAL_MONGO_CA_CERT:")
+
+	containers := controllerStacker.GetContainerSpecs(c)
+	c.Assert(containers, gc.HasLen, 1)
+	c.Assert(containers[0].Name, gc.Equals, "api-server")
+}
+
 func (s *bootstrapSuite) TestGetControllerSvcSpec(c *gc.C) {
 	s.namespace = "controller-1"
 	ctrl := s.setupController(c)
@@ -7,6 +7,8 @@ import (
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
 	core "k8s.io/api/core/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
 )
 
 const (
@@ -15,6 +17,7 @@ const (
 	defaultIngressSSLRedirect    = false
 	defaultIngressSSLPassthrough = false
 	defaultIngressAllowHTTPKey   = false
+	defaultNetworkPolicy         = false
 
 	ServiceTypeConfigKey               = "kubernetes-service-type"
 	serviceExternalIPsConfigKey        = "kubernetes-service-external-ips"
@@ -28,6 +31,10 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	networkPolicyKey             = "kubernetes-network-policy"
+	networkPolicyIngressCIDRsKey = "kubernetes-network-policy-ingress-cidrs"
+	networkPolicyEgressCIDRsKey  = "kubernetes-network-policy-egress-cidrs"
 )
 
 var configFields = environschema.Fields{
@@ -86,6 +93,53 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	networkPolicyKey: {
+		Description: "whether to restrict ingress traffic to the application's pods to related applications",
+		Type:        environschema.Tbool,
+		Group:       environschema.ProviderGroup,
+	},
+	networkPolicyIngressCIDRsKey: {
+		Description: "a space separated list of CIDRs from which ingress traffic is also allowed",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	networkPolicyEgressCIDRsKey: {
+		Description: "a space separated list of CIDRs to which egress traffic is also allowed; if set, other egress traffic is denied",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	constants.UpdatePartitionConfigKey: {
+		Description: "for stateful applications, only pods with an ordinal at or above the partition are updated",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	constants.UpdateMaxUnavailableConfigKey: {
+		Description: "the number or percentage of pods which may be unavailable during an update of a stateless or daemon application",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	constants.UpdateMaxSurgeConfigKey: {
+		Description: "the number or percentage of pods which may be created above the scale during an update of a stateless application",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	constants.DisruptionBudgetMinAvailableConfigKey: {
+		Description: "if set, a pod disruption budget keeps at least this number or percentage of pods available",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	constants.DisruptionBudgetMaxUnavailableConfigKey: {
+		Description: "if set, a pod disruption budget allows at most this number or percentage of pods to be unavailable",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	constants.ContainerResourcesConfigKey: {
+		Description: "a space separated list of <container>.<resource>=<value> resource requests and limits for the application's containers, " +
+			"such as \"workload.cpu-limit=500 workload.mem-request=256M\"; cpu is in millicores and sizes take an optional M/G/T/P suffix; " +
+			"resources can't be set in the charm's metadata.yaml",
+		Type:  environschema.Tstring,
+		Group: environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
@@ -95,6 +149,7 @@ var schemaDefaults = schema.Defaults{
 	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
 	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
 	ingressAllowHTTPKey:      defaultIngressAllowHTTPKey,
+	networkPolicyKey:         defaultNetworkPolicy,
 }
 
 // ConfigSchema returns the configuration schema for
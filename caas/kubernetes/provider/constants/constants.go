@@ -39,6 +39,48 @@ const (
 
 	// CAASProviderType is the provider type for k8s.
 	CAASProviderType = "kubernetes"
+
+	// CAASImageRepoSecretName is the name of the secret used by the pods
+	// Juju creates to pull images from the controller's image repo.
+	CAASImageRepoSecretName = "juju-image-pull-secret"
+)
+
+// Application config keys controlling how the pods of an application
+// are updated and disrupted.
+const (
+	// UpdatePartitionConfigKey is the ordinal at or above which the pods
+	// of a stateful application are updated.
+	UpdatePartitionConfigKey = "kubernetes-update-partition"
+
+	// UpdateMaxUnavailableConfigKey is the number or percentage of pods
+	// which may be unavailable during an update.
+	UpdateMaxUnavailableConfigKey = "kubernetes-update-max-unavailable"
+
+	// UpdateMaxSurgeConfigKey is the number or percentage of pods which
+	// may be created above the application scale during an update.
+	UpdateMaxSurgeConfigKey = "kubernetes-update-max-surge"
+
+	// DisruptionBudgetMinAvailableConfigKey is the number or percentage
+	// of pods which must remain available when pods are evicted.
+	DisruptionBudgetMinAvailableConfigKey = "kubernetes-disruption-budget-min-available"
+
+	// DisruptionBudgetMaxUnavailableConfigKey is the number or percentage
+	// of pods which may be unavailable when pods are evicted.
+	DisruptionBudgetMaxUnavailableConfigKey = "kubernetes-disruption-budget-max-unavailable"
+)
+
+// ContainerResourcesConfigKey is the application config key holding the
+// resource requests and limits of each of the application's containers.
+const ContainerResourcesConfigKey = "kubernetes-container-resources"
+
+const (
+	// AdoptNamespaceKey is the model config attribute used to attach a
+	// model to an existing namespace instead of creating one.
+	AdoptNamespaceKey = "adopt-namespace"
+
+	// ModelConfigMapName is the name of the config map recording which
+	// model has adopted a namespace.
+	ModelConfigMapName = "juju-model"
 )
 
 // DefaultPropagationPolicy returns the default propagation policy.
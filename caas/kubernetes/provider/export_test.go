@@ -9,6 +9,7 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -66,6 +67,7 @@ type ControllerStackerForTest interface {
 	GetSharedSecretAndSSLKey(*gc.C) (string, string)
 	GetStorageSize() resource.Quantity
 	GetControllerSvcSpec(string, *podcfg.BootstrapConfig) (*controllerServiceSpec, error)
+	GetContainerSpecs(*gc.C) []core.Container
 	SetContext(ctx environs.BootstrapContext)
 }
 
@@ -89,6 +91,13 @@ func (cs *controllerStack) GetControllerSvcSpec(cloudType string, cfg *podcfg.Bo
 	return cs.getControllerSvcSpec(cloudType, cfg)
 }
 
+func (cs *controllerStack) GetContainerSpecs(c *gc.C) []core.Container {
+	statefulset := &apps.StatefulSet{}
+	err := cs.buildContainerSpecForController(statefulset)
+	c.Assert(err, jc.ErrorIsNil)
+	return statefulset.Spec.Template.Spec.Containers
+}
+
 func (cs *controllerStack) SetContext(ctx environs.BootstrapContext) {
 	cs.ctx = ctx
 }
@@ -8,7 +8,6 @@ import (
 	"context"
 	"fmt"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -127,6 +126,10 @@ type kubernetesClient struct {
 	// labels or new ones
 	isLegacyLabels bool
 
+	// adoptedNamespace is true if the model was attached to an existing
+	// namespace rather than creating its own.
+	adoptedNamespace bool
+
 	// randomPrefix generates an annotation for stateful sets.
 	randomPrefix utils.RandomPrefixFunc
 }
@@ -138,7 +141,7 @@ type kubernetesClient struct {
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface,DaemonSetInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 EventInterface,CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/networkingv1beta1_mock.go -mock_names=IngressInterface=MockIngressV1Beta1Interface k8s.io/client-go/kubernetes/typed/networking/v1beta1 NetworkingV1beta1Interface,IngressInterface
-//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/networkingv1_mock.go -mock_names=IngressInterface=MockIngressV1Interface k8s.io/client-go/kubernetes/typed/networking/v1 NetworkingV1Interface,IngressInterface,IngressClassInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/networkingv1_mock.go -mock_names=IngressInterface=MockIngressV1Interface k8s.io/client-go/kubernetes/typed/networking/v1 NetworkingV1Interface,IngressInterface,IngressClassInterface,NetworkPolicyInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,ClusterRoleBindingInterface,ClusterRoleInterface,RoleInterface,RoleBindingInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/apiextensionsv1beta1_mock.go -mock_names=CustomResourceDefinitionInterface=MockCustomResourceDefinitionV1Beta1Interface k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1 ApiextensionsV1beta1Interface,CustomResourceDefinitionInterface
@@ -184,10 +187,15 @@ func newK8sBroker(
 		return nil, errors.NotValidf("modelUUID is required")
 	}
 
-	isLegacy, err := utils.IsLegacyModelLabels(
-		newCfg.Config.Name(), k8sClient.CoreV1().Namespaces())
-	if err != nil {
-		return nil, errors.Trace(err)
+	// An adopted namespace is never labelled by a legacy Juju, and the
+	// credentials may not be able to read the namespace itself.
+	isLegacy := false
+	if !newCfg.adoptNamespace() {
+		isLegacy, err = utils.IsLegacyModelLabels(
+			newCfg.Config.Name(), k8sClient.CoreV1().Namespaces())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	client := &kubernetesClient{
@@ -211,7 +219,8 @@ func newK8sBroker(
 		randomPrefix:      randomPrefix,
 		annotations: k8sannotations.New(nil).
 			Add(utils.AnnotationModelUUIDKey(isLegacy), modelUUID),
-		isLegacyLabels: isLegacy,
+		isLegacyLabels:   isLegacy,
+		adoptedNamespace: newCfg.adoptNamespace(),
 	}
 	if controllerUUID != "" {
 		// controllerUUID could be empty in add-k8s without -c because there might be no controller yet.
@@ -362,6 +371,9 @@ Please bootstrap again and choose a different controller name.`, controllerName)
 
 // Create implements environs.BootstrapEnviron.
 func (k *kubernetesClient) Create(envcontext.ProviderCallContext, environs.CreateParams) error {
+	if k.adoptedNamespace {
+		return k.adoptNamespace(k.namespace)
+	}
 	// must raise errors.AlreadyExistsf if it's already exist.
 	return k.createNamespace(k.namespace)
 }
@@ -493,10 +505,17 @@ func (k *kubernetesClient) Destroy(callbacks envcontext.ProviderCallContext) (er
 	defer cancel()
 
 	var wg sync.WaitGroup
-	wg.Add(1)
-	go k.deleteClusterScopeResourcesModelTeardown(ctx, &wg, errChan)
-	wg.Add(1)
-	go k.deleteNamespaceModelTeardown(ctx, &wg, errChan)
+	if k.adoptedNamespace {
+		// The namespace wasn't created by Juju, and credentials scoped
+		// to it can't manage cluster wide resources.
+		wg.Add(1)
+		go k.deleteAdoptedNamespaceModelTeardown(ctx, &wg, errChan)
+	} else {
+		wg.Add(1)
+		go k.deleteClusterScopeResourcesModelTeardown(ctx, &wg, errChan)
+		wg.Add(1)
+		go k.deleteNamespaceModelTeardown(ctx, &wg, errChan)
+	}
 
 	go func() {
 		wg.Wait()
@@ -761,6 +780,9 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteIngressResources(appName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deleteNetworkPolicies(appName); err != nil {
+		return errors.Trace(err)
+	}
 
 	if err := k.deleteDaemonSets(appName); err != nil {
 		return errors.Trace(err)
@@ -768,7 +790,7 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	return nil
 }
 
-func processConstraints(pod *core.PodSpec, appName string, cons constraints.Value) error {
+func processConstraints(pod *core.PodSpec, appName string, cons constraints.Value, legacyLabels bool) error {
 	// TODO(caas): Allow constraints to be set at the container level.
 	if mem := cons.Mem; mem != nil {
 		if err := configureConstraint(pod, "memory", fmt.Sprintf("%dMi", *mem)); err != nil {
@@ -807,201 +829,8 @@ func processConstraints(pod *core.PodSpec, appName string, cons constraints.Valu
 		pod.NodeSelector = nodeSelector
 	}
 
-	// Translate tags to pod or node affinity.
-	// Tag names are prefixed with "pod.", "anti-pod.", or "node."
-	// with the default being "node".
-	// The tag 'topology-key', if set, is used for the affinity topology key value.
-	if cons.Tags != nil {
-		affinityLabels := make(map[string]string)
-		for _, labelPair := range *cons.Tags {
-			parts := strings.Split(labelPair, "=")
-			if len(parts) != 2 {
-				return errors.Errorf("invalid affinity constraints: %v", affinityLabels)
-			}
-			key := strings.Trim(parts[0], " ")
-			value := strings.Trim(parts[1], " ")
-			affinityLabels[key] = value
-		}
-
-		if err := processNodeAffinity(pod, affinityLabels); err != nil {
-			return errors.Annotatef(err, "configuring node affinity for %s", appName)
-		}
-		if err := processPodAffinity(pod, affinityLabels); err != nil {
-			return errors.Annotatef(err, "configuring pod affinity for %s", appName)
-		}
-	}
-	if cons.Zones != nil {
-		zones := *cons.Zones
-		affinity := pod.Affinity
-		if affinity == nil {
-			affinity = &core.Affinity{
-				NodeAffinity: &core.NodeAffinity{
-					RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
-						NodeSelectorTerms: []core.NodeSelectorTerm{{}},
-					},
-				},
-			}
-			pod.Affinity = affinity
-		}
-		nodeSelector := &affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
-		nodeSelector.MatchExpressions = append(nodeSelector.MatchExpressions,
-			core.NodeSelectorRequirement{
-				Key:      "failure-domain.beta.kubernetes.io/zone",
-				Operator: core.NodeSelectorOpIn,
-				Values:   zones,
-			})
-	}
-	return nil
-}
-
-const (
-	podPrefix      = "pod."
-	antiPodPrefix  = "anti-pod."
-	topologyKeyTag = "topology-key"
-	nodePrefix     = "node."
-)
-
-func processNodeAffinity(pod *core.PodSpec, affinityLabels map[string]string) error {
-	affinityTags := make(map[string]string)
-	for key, value := range affinityLabels {
-		keyVal := key
-		if strings.HasPrefix(key, "^") {
-			if len(key) == 1 {
-				return errors.Errorf("invalid affinity constraints: %v", affinityLabels)
-			}
-			key = key[1:]
-		}
-		if strings.HasPrefix(key, podPrefix) || strings.HasPrefix(key, antiPodPrefix) {
-			continue
-		}
-		key = strings.TrimPrefix(keyVal, nodePrefix)
-		affinityTags[key] = value
-	}
-
-	updateSelectorTerms := func(nodeSelectorTerm *core.NodeSelectorTerm, tags map[string]string) {
-		// Sort for stable ordering.
-		var keys []string
-		for k := range tags {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		for _, tag := range keys {
-			allValues := strings.Split(tags[tag], "|")
-			for i, v := range allValues {
-				allValues[i] = strings.Trim(v, " ")
-			}
-			op := core.NodeSelectorOpIn
-			if strings.HasPrefix(tag, "^") {
-				tag = tag[1:]
-				op = core.NodeSelectorOpNotIn
-			}
-			nodeSelectorTerm.MatchExpressions = append(nodeSelectorTerm.MatchExpressions, core.NodeSelectorRequirement{
-				Key:      tag,
-				Operator: op,
-				Values:   allValues,
-			})
-		}
-	}
-	var nodeSelectorTerm core.NodeSelectorTerm
-	updateSelectorTerms(&nodeSelectorTerm, affinityTags)
-	if pod.Affinity == nil {
-		pod.Affinity = &core.Affinity{}
-	}
-	pod.Affinity.NodeAffinity = &core.NodeAffinity{
-		RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
-			NodeSelectorTerms: []core.NodeSelectorTerm{nodeSelectorTerm},
-		},
-	}
-	return nil
-}
-
-func processPodAffinity(pod *core.PodSpec, affinityLabels map[string]string) error {
-	affinityTags := make(map[string]string)
-	antiAffinityTags := make(map[string]string)
-	for key, value := range affinityLabels {
-		notVal := false
-		if strings.HasPrefix(key, "^") {
-			if len(key) == 1 {
-				return errors.Errorf("invalid affinity constraints: %v", affinityLabels)
-			}
-			notVal = true
-			key = key[1:]
-		}
-		if !strings.HasPrefix(key, podPrefix) && !strings.HasPrefix(key, antiPodPrefix) {
-			continue
-		}
-		if strings.HasPrefix(key, podPrefix) {
-			key = strings.TrimPrefix(key, podPrefix)
-			if notVal {
-				key = "^" + key
-			}
-			affinityTags[key] = value
-		}
-		if strings.HasPrefix(key, antiPodPrefix) {
-			key = strings.TrimPrefix(key, antiPodPrefix)
-			if notVal {
-				key = "^" + key
-			}
-			antiAffinityTags[key] = value
-		}
-	}
-	if len(affinityTags) == 0 && len(antiAffinityTags) == 0 {
-		return nil
-	}
-
-	updateAffinityTerm := func(affinityTerm *core.PodAffinityTerm, tags map[string]string) {
-		// Sort for stable ordering.
-		var keys []string
-		for k := range tags {
-			keys = append(keys, k)
-		}
-		sort.Strings(keys)
-		var (
-			labelSelector v1.LabelSelector
-			topologyKey   string
-		)
-		for _, tag := range keys {
-			if tag == topologyKeyTag {
-				topologyKey = tags[tag]
-				continue
-			}
-			allValues := strings.Split(tags[tag], "|")
-			for i, v := range allValues {
-				allValues[i] = strings.Trim(v, " ")
-			}
-			op := v1.LabelSelectorOpIn
-			if strings.HasPrefix(tag, "^") {
-				tag = tag[1:]
-				op = v1.LabelSelectorOpNotIn
-			}
-			labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, v1.LabelSelectorRequirement{
-				Key:      tag,
-				Operator: op,
-				Values:   allValues,
-			})
-		}
-		affinityTerm.LabelSelector = &labelSelector
-		if topologyKey != "" {
-			affinityTerm.TopologyKey = topologyKey
-		}
-	}
-	if pod.Affinity == nil {
-		pod.Affinity = &core.Affinity{}
-	}
-	var affinityTerm core.PodAffinityTerm
-	updateAffinityTerm(&affinityTerm, affinityTags)
-	if len(affinityTerm.LabelSelector.MatchExpressions) > 0 {
-		pod.Affinity.PodAffinity = &core.PodAffinity{
-			RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{affinityTerm},
-		}
-	}
-
-	var antiAffinityTerm core.PodAffinityTerm
-	updateAffinityTerm(&antiAffinityTerm, antiAffinityTags)
-	if len(antiAffinityTerm.LabelSelector.MatchExpressions) > 0 {
-		pod.Affinity.PodAntiAffinity = &core.PodAntiAffinity{
-			RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{antiAffinityTerm},
-		}
+	if err := utils.ApplyPlacement(pod, cons, legacyLabels); err != nil {
+		return errors.Annotatef(err, "configuring placement for %s", appName)
 	}
 	return nil
 }
@@ -1218,7 +1047,7 @@ func (k *kubernetesClient) ensureService(
 			return errors.Annotatef(err, "configuring devices for %s", appName)
 		}
 	}
-	if err := processConstraints(&workloadSpec.Pod.PodSpec, appName, params.Constraints); err != nil {
+	if err := processConstraints(&workloadSpec.Pod.PodSpec, appName, params.Constraints, k.IsLegacyLabels()); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -2318,6 +2147,12 @@ func (k *kubernetesClient) WatchService(appName string, mode caas.DeploymentMode
 // CheckCloudCredentials verifies the the cloud credentials provided to the
 // broker are functioning.
 func (k *kubernetesClient) CheckCloudCredentials() error {
+	if k.adoptedNamespace {
+		// Credentials scoped to an adopted namespace can't list the
+		// namespaces of the cluster.
+		_, err := k.getModelConfigMap()
+		return errors.Trace(err)
+	}
 	if _, err := k.Namespaces(); err != nil {
 		// If this call could not be made with provided credential, we
 		// know that the credential is invalid.
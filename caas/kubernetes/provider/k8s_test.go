@@ -10,6 +10,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	jujuclock "github.com/juju/clock"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
@@ -20,20 +21,32 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	k8smeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	k8sversion "k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	k8sscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
+	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
 	"github.com/juju/juju/caas/kubernetes/provider/utils"
 	k8swatcher "github.com/juju/juju/caas/kubernetes/provider/watcher"
@@ -45,6 +58,7 @@ import (
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/docker"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	envtesting "github.com/juju/juju/environs/testing"
@@ -1430,6 +1444,45 @@ func (s *K8sBrokerSuite) TestGetNamespaceNotFound(c *gc.C) {
 	c.Assert(out, gc.IsNil)
 }
 
+func (s *K8sBrokerSuite) TestEnsureImageRepoSecret(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	imageRepo := docker.ImageRepoDetails{
+		Repository: "registry.example.com/juju",
+		Username:   "jujuqa",
+		Password:   "secret",
+	}
+	secretData, err := imageRepo.SecretData()
+	c.Assert(err, jc.ErrorIsNil)
+	secret := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-image-pull-secret",
+			Namespace: "test",
+			Labels:    utils.LabelsForModel("test", false),
+		},
+		Type: core.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			core.DockerConfigJsonKey: secretData,
+		},
+	}
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Create(gomock.Any(), secret, v1.CreateOptions{}).
+			Return(secret, nil),
+	)
+
+	err = s.broker.EnsureImageRepoSecret(imageRepo)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureImageRepoSecretPublicRepo(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	err := s.broker.EnsureImageRepoSecret(docker.ImageRepoDetails{Repository: "jujusolutions"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestNamespaces(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -1791,6 +1844,200 @@ func (s *K8sBrokerSuite) TestCreateAlreadyExists(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
 }
 
+func (s *K8sBrokerSuite) setupAdoptingController(c *gc.C) *gomock.Controller {
+	var err error
+	s.cfg, err = s.cfg.Apply(map[string]interface{}{k8sconstants.AdoptNamespaceKey: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The namespace is not read to check for legacy labels.
+	ctrl := gomock.NewController(c)
+	newK8sClientFunc, newK8sRestFunc := s.setupK8sRestClient(c, ctrl, s.getNamespace())
+	randomPrefixFunc := func() (string, error) {
+		return "appuuid", nil
+	}
+	return s.setupBroker(c, ctrl, newK8sClientFunc, newK8sRestFunc, randomPrefixFunc)
+}
+
+func (s *K8sBrokerSuite) modelConfigMap() *core.ConfigMap {
+	return &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-model",
+			Labels: map[string]string{"app.kubernetes.io/managed-by": "juju", "model.juju.is/name": "test"},
+			Annotations: map[string]string{
+				"controller.juju.is/id": testing.ControllerTag.Id(),
+				"model.juju.is/id":      s.cfg.UUID(),
+			},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestCreateAdoptNamespace(c *gc.C) {
+	ctrl := s.setupAdoptingController(c)
+	defer ctrl.Finish()
+
+	cm := s.modelConfigMap()
+	s.mockConfigMaps.EXPECT().Create(gomock.Any(), cm, v1.CreateOptions{}).Return(cm, nil)
+
+	err := s.broker.Create(&context.CloudCallContext{}, environs.CreateParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestCreateAdoptNamespaceNotFound(c *gc.C) {
+	ctrl := s.setupAdoptingController(c)
+	defer ctrl.Finish()
+
+	s.mockConfigMaps.EXPECT().Create(gomock.Any(), s.modelConfigMap(), v1.CreateOptions{}).
+		Return(nil, s.k8sNotFoundError())
+
+	err := s.broker.Create(&context.CloudCallContext{}, environs.CreateParams{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `namespace "test" to adopt not found`)
+}
+
+func (s *K8sBrokerSuite) TestCreateAdoptNamespaceAdoptedByAnotherModel(c *gc.C) {
+	ctrl := s.setupAdoptingController(c)
+	defer ctrl.Finish()
+
+	other := s.modelConfigMap()
+	other.Annotations["model.juju.is/id"] = "another-model-uuid"
+	gomock.InOrder(
+		s.mockConfigMaps.EXPECT().Create(gomock.Any(), s.modelConfigMap(), v1.CreateOptions{}).
+			Return(nil, s.k8sAlreadyExistsError()),
+		s.mockConfigMaps.EXPECT().Get(gomock.Any(), "juju-model", v1.GetOptions{}).
+			Return(other, nil),
+	)
+
+	err := s.broker.Create(&context.CloudCallContext{}, environs.CreateParams{})
+	c.Assert(err, gc.ErrorMatches, `namespace "test" already adopted by another model not valid`)
+}
+
+func (s *K8sBrokerSuite) TestDestroyAdoptedNamespace(c *gc.C) {
+	var err error
+	s.cfg, err = s.cfg.Apply(map[string]interface{}{k8sconstants.AdoptNamespaceKey: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	jujuLabels := map[string]string{"app.kubernetes.io/managed-by": "juju", "model.juju.is/name": "test"}
+	modelLabels := map[string]string{"model.juju.is/name": "test"}
+	meta := func(name string, labels map[string]string) v1.ObjectMeta {
+		return v1.ObjectMeta{Name: name, Namespace: "test", Labels: labels}
+	}
+	k8sClient := k8sfake.NewSimpleClientset(
+		&appsv1.Deployment{ObjectMeta: meta("modeloperator", jujuLabels)},
+		&core.Service{ObjectMeta: meta("gitlab", jujuLabels)},
+		&policyv1beta1.PodDisruptionBudget{ObjectMeta: meta("gitlab", jujuLabels)},
+		&networkingv1.NetworkPolicy{ObjectMeta: meta("gitlab", jujuLabels)},
+		// The image pull secret only carries the model labels.
+		&core.Secret{ObjectMeta: meta("juju-image-pull-secret", modelLabels)},
+		&rbacv1.ClusterRole{ObjectMeta: v1.ObjectMeta{Name: "test-modeloperator", Labels: modelLabels}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: v1.ObjectMeta{Name: "test-modeloperator", Labels: modelLabels}},
+		// Resources not managed by Juju are left alone.
+		&core.ConfigMap{ObjectMeta: meta("unmanaged", nil)},
+	)
+	k8sClient.PrependReactor("delete-collection", "*", deleteCollectionReactor(k8sClient.Tracker()))
+
+	s.clock = testclock.NewClock(time.Time{})
+	broker, err := provider.NewK8sBroker(testing.ControllerTag.Id(), s.k8sRestConfig, s.cfg, s.getNamespace(),
+		func(*rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, dynamic.Interface, error) {
+			return k8sClient, nil, nil, nil
+		}, nil, nil, nil, nil, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- broker.Destroy(context.NewCloudCallContext())
+	}()
+
+	err = s.clock.WaitAdvance(time.Second, testing.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-errCh:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for destroy to return")
+	}
+
+	var remaining []string
+	for _, gvr := range []schema.GroupVersionResource{
+		appsv1.SchemeGroupVersion.WithResource("deployments"),
+		core.SchemeGroupVersion.WithResource("services"),
+		policyv1beta1.SchemeGroupVersion.WithResource("poddisruptionbudgets"),
+		networkingv1.SchemeGroupVersion.WithResource("networkpolicies"),
+		core.SchemeGroupVersion.WithResource("secrets"),
+		core.SchemeGroupVersion.WithResource("configmaps"),
+		rbacv1.SchemeGroupVersion.WithResource("clusterroles"),
+		rbacv1.SchemeGroupVersion.WithResource("clusterrolebindings"),
+	} {
+		for _, obj := range listTrackedObjects(c, k8sClient.Tracker(), gvr) {
+			remaining = append(remaining, gvr.Resource+"/"+obj.GetName())
+		}
+	}
+	c.Assert(remaining, jc.DeepEquals, []string{"configmaps/unmanaged"})
+}
+
+// deleteCollectionReactor implements DeleteCollection for the fake
+// clientset, which otherwise doesn't delete anything.
+func deleteCollectionReactor(tracker k8stesting.ObjectTracker) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		deleteAction := action.(k8stesting.DeleteCollectionAction)
+		selector := deleteAction.GetListRestrictions().Labels
+		list, err := tracker.List(deleteAction.GetResource(), kindForResource(deleteAction.GetResource()), deleteAction.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		items, err := k8smeta.ExtractList(list)
+		if err != nil {
+			return true, nil, err
+		}
+		for _, item := range items {
+			obj, err := k8smeta.Accessor(item)
+			if err != nil {
+				return true, nil, err
+			}
+			if !selector.Matches(k8slabels.Set(obj.GetLabels())) {
+				continue
+			}
+			if err := tracker.Delete(deleteAction.GetResource(), obj.GetNamespace(), obj.GetName()); err != nil {
+				return true, nil, err
+			}
+		}
+		return true, nil, nil
+	}
+}
+
+// kindForResource returns the kind of the objects of the resource.
+func kindForResource(gvr schema.GroupVersionResource) schema.GroupVersionKind {
+	for gvk := range k8sscheme.Scheme.AllKnownTypes() {
+		if plural, _ := k8smeta.UnsafeGuessKindToResource(gvk); plural == gvr {
+			return gvk
+		}
+	}
+	return schema.GroupVersionKind{}
+}
+
+func listTrackedObjects(c *gc.C, tracker k8stesting.ObjectTracker, gvr schema.GroupVersionResource) []v1.Object {
+	list, err := tracker.List(gvr, kindForResource(gvr), "")
+	c.Assert(err, jc.ErrorIsNil)
+	items, err := k8smeta.ExtractList(list)
+	c.Assert(err, jc.ErrorIsNil)
+	objs := make([]v1.Object, len(items))
+	for i, item := range items {
+		objs[i], err = k8smeta.Accessor(item)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return objs
+}
+
+func (s *K8sBrokerSuite) TestCheckCloudCredentialsAdoptedNamespace(c *gc.C) {
+	ctrl := s.setupAdoptingController(c)
+	defer ctrl.Finish()
+
+	s.mockConfigMaps.EXPECT().Get(gomock.Any(), "juju-model", v1.GetOptions{}).
+		Return(s.modelConfigMap(), nil)
+
+	c.Assert(s.broker.CheckCloudCredentials(), jc.ErrorIsNil)
+}
+
 func unitStatefulSetArg(numUnits int32, scName string, podSpec core.PodSpec) *appsv1.StatefulSet {
 	return &appsv1.StatefulSet{
 		ObjectMeta: v1.ObjectMeta{
@@ -2017,6 +2264,12 @@ func (s *K8sBrokerSuite) TestDeleteServiceForApplication(c *gc.C) {
 			v1.ListOptions{LabelSelector: "app.kubernetes.io/managed-by=juju,app.kubernetes.io/name=test"},
 		).Return(nil),
 
+		// delete all network policy resources.
+		s.mockNetworkPolicies.EXPECT().DeleteCollection(gomock.Any(),
+			s.deleteOptions(v1.DeletePropagationForeground, ""),
+			v1.ListOptions{LabelSelector: "app.kubernetes.io/managed-by=juju,app.kubernetes.io/name=test"},
+		).Return(nil),
+
 		// delete all daemon set resources.
 		s.mockDaemonSets.EXPECT().DeleteCollection(gomock.Any(),
 			s.deleteOptions(v1.DeletePropagationForeground, ""),
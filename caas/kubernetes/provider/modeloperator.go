@@ -74,6 +74,10 @@ type ModelOperatorBroker interface {
 
 	// IsLegacyLabels indicates if this provider is operating on a legacy label schema
 	IsLegacyLabels() bool
+
+	// IsAdoptedNamespace indicates if the model was attached to an existing
+	// namespace, where Juju doesn't manage cluster wide resources.
+	IsAdoptedNamespace() bool
 }
 
 // modelOperatorBrokerBridge provides a pluggable struct of funcs to implement
@@ -90,6 +94,7 @@ type modelOperatorBrokerBridge struct {
 	model                    func() string
 	namespace                func() string
 	isLegacyLabels           func() bool
+	isAdoptedNamespace       func() bool
 }
 
 const (
@@ -195,6 +200,14 @@ func (m *modelOperatorBrokerBridge) IsLegacyLabels() bool {
 	return m.isLegacyLabels()
 }
 
+// IsAdoptedNamespace implements ModelOperatorBroker
+func (m *modelOperatorBrokerBridge) IsAdoptedNamespace() bool {
+	if m.isAdoptedNamespace == nil {
+		return false
+	}
+	return m.isAdoptedNamespace()
+}
+
 func ensureModelOperator(
 	modelUUID,
 	agentPath string,
@@ -288,6 +301,10 @@ func ensureModelOperator(
 	if err != nil {
 		return errors.Annotate(err, "building juju model operator deployment")
 	}
+	if config.IsPrivateImageRepo {
+		deployment.Spec.Template.Spec.ImagePullSecrets = append(deployment.Spec.Template.Spec.ImagePullSecrets,
+			core.LocalObjectReference{Name: constants.CAASImageRepoSecretName})
+	}
 
 	c, err = broker.EnsureDeployment(deployment)
 	cleanUpFuncs = append(cleanUpFuncs, c...)
@@ -337,9 +354,10 @@ func (k *kubernetesClient) EnsureModelOperator(
 			_, c, err := k.ensureServiceAccount(sa)
 			return c, err
 		},
-		namespace:      func() string { return k.namespace },
-		model:          func() string { return k.CurrentModel() },
-		isLegacyLabels: k.IsLegacyLabels,
+		namespace:          func() string { return k.namespace },
+		model:              func() string { return k.CurrentModel() },
+		isLegacyLabels:     k.IsLegacyLabels,
+		isAdoptedNamespace: k.IsAdoptedNamespace,
 	}
 
 	return ensureModelOperator(modelUUID, agentPath, config, bridge)
@@ -567,60 +585,64 @@ func ensureModelOperatorRBAC(
 		return sa.Name, cleanUpFuncs, errors.Annotate(err, "ensuring service account")
 	}
 
-	clusterRole := &rbac.ClusterRole{
-		ObjectMeta: meta.ObjectMeta{
-			Name:   globalName,
-			Labels: labels,
-		},
-		Rules: []rbac.PolicyRule{
-			{
-				APIGroups: []string{""},
-				Resources: []string{"namespaces"},
-				Verbs:     []string{"get", "list"},
+	// The model operator in an adopted namespace is only granted access
+	// to that namespace, and doesn't run the admission webhook.
+	if !broker.IsAdoptedNamespace() {
+		clusterRole := &rbac.ClusterRole{
+			ObjectMeta: meta.ObjectMeta{
+				Name:   globalName,
+				Labels: labels,
 			},
-			{
-				APIGroups: []string{"admissionregistration.k8s.io"},
-				Resources: []string{"mutatingwebhookconfigurations"},
-				Verbs: []string{
-					"create",
-					"delete",
-					"get",
-					"list",
-					"update",
+			Rules: []rbac.PolicyRule{
+				{
+					APIGroups: []string{""},
+					Resources: []string{"namespaces"},
+					Verbs:     []string{"get", "list"},
+				},
+				{
+					APIGroups: []string{"admissionregistration.k8s.io"},
+					Resources: []string{"mutatingwebhookconfigurations"},
+					Verbs: []string{
+						"create",
+						"delete",
+						"get",
+						"list",
+						"update",
+					},
 				},
 			},
-		},
-	}
+		}
 
-	c, err = broker.EnsureClusterRole(clusterRole)
-	cleanUpFuncs = append(cleanUpFuncs, c...)
-	if err != nil {
-		return sa.Name, cleanUpFuncs, errors.Annotate(err, "ensuring cluster role")
-	}
+		c, err = broker.EnsureClusterRole(clusterRole)
+		cleanUpFuncs = append(cleanUpFuncs, c...)
+		if err != nil {
+			return sa.Name, cleanUpFuncs, errors.Annotate(err, "ensuring cluster role")
+		}
 
-	clusterRoleBinding := &rbac.ClusterRoleBinding{
-		ObjectMeta: meta.ObjectMeta{
-			Name:   globalName,
-			Labels: labels,
-		},
-		RoleRef: rbac.RoleRef{
-			APIGroup: "rbac.authorization.k8s.io",
-			Kind:     "ClusterRole",
-			Name:     clusterRole.Name,
-		},
-		Subjects: []rbac.Subject{
-			{
-				Kind:      "ServiceAccount",
-				Name:      sa.Name,
-				Namespace: sa.Namespace,
+		clusterRoleBinding := &rbac.ClusterRoleBinding{
+			ObjectMeta: meta.ObjectMeta{
+				Name:   globalName,
+				Labels: labels,
 			},
-		},
-	}
+			RoleRef: rbac.RoleRef{
+				APIGroup: "rbac.authorization.k8s.io",
+				Kind:     "ClusterRole",
+				Name:     clusterRole.Name,
+			},
+			Subjects: []rbac.Subject{
+				{
+					Kind:      "ServiceAccount",
+					Name:      sa.Name,
+					Namespace: sa.Namespace,
+				},
+			},
+		}
 
-	c, err = broker.EnsureClusterRoleBinding(clusterRoleBinding)
-	cleanUpFuncs = append(cleanUpFuncs, c...)
-	if err != nil {
-		return sa.Name, cleanUpFuncs, errors.Annotate(err, "ensuring cluster role binding")
+		c, err = broker.EnsureClusterRoleBinding(clusterRoleBinding)
+		cleanUpFuncs = append(cleanUpFuncs, c...)
+		if err != nil {
+			return sa.Name, cleanUpFuncs, errors.Annotate(err, "ensuring cluster role binding")
+		}
 	}
 
 	role := &rbac.Role{
@@ -144,3 +144,53 @@ func (m *ModelOperatorSuite) Test(c *gc.C) {
 	c.Assert(ensureServiceCalled, jc.IsTrue)
 	c.Assert(namespaceCalled, jc.IsTrue)
 }
+
+func (m *ModelOperatorSuite) TestAdoptedNamespace(c *gc.C) {
+	var ensureRoleCalled, ensureRoleBindingCalled bool
+	bridge := &modelOperatorBrokerBridge{
+		ensureClusterRole: func(cr *rbac.ClusterRole) ([]func(), error) {
+			c.Fatalf("unexpected cluster role %q", cr.Name)
+			return nil, nil
+		},
+		ensureClusterRoleBinding: func(crb *rbac.ClusterRoleBinding) ([]func(), error) {
+			c.Fatalf("unexpected cluster role binding %q", crb.Name)
+			return nil, nil
+		},
+		ensureConfigMap: func(cm *core.ConfigMap) ([]func(), error) {
+			return nil, nil
+		},
+		ensureDeployment: func(d *apps.Deployment) ([]func(), error) {
+			return nil, nil
+		},
+		ensureRole: func(r *rbac.Role) ([]func(), error) {
+			ensureRoleCalled = true
+			c.Assert(r.Namespace, gc.Equals, "adopted")
+			return nil, nil
+		},
+		ensureRoleBinding: func(rb *rbac.RoleBinding) ([]func(), error) {
+			ensureRoleBindingCalled = true
+			c.Assert(rb.Namespace, gc.Equals, "adopted")
+			c.Assert(rb.RoleRef.Kind, gc.Equals, "Role")
+			return nil, nil
+		},
+		ensureServiceAccount: func(s *core.ServiceAccount) ([]func(), error) {
+			return nil, nil
+		},
+		ensureService: func(s *core.Service) ([]func(), error) {
+			return nil, nil
+		},
+		model:              func() string { return "test-model" },
+		namespace:          func() string { return "adopted" },
+		isAdoptedNamespace: func() bool { return true },
+	}
+
+	config := caas.ModelOperatorConfig{
+		AgentConf:         []byte("testconf"),
+		OperatorImagePath: "juju/juju:123",
+		Port:              int32(5497),
+	}
+	err := ensureModelOperator("abcd-efff-face", "/var/app/juju", &config, bridge)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ensureRoleCalled, jc.IsTrue)
+	c.Assert(ensureRoleBindingCalled, jc.IsTrue)
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	envcontext "github.com/juju/juju/environs/context"
+)
+
+// Spaces in Kubernetes models are backed by secondary pod networks,
+// as defined by Multus network attachment definitions.
+// Each network attachment definition with an IPAM configured range
+// is modelled as a subnet, identified by the name of the definition.
+
+const networkAttachmentDefinitionCRDName = "network-attachment-definitions.k8s.cni.cncf.io"
+
+var networkAttachmentDefinitionGVR = schema.GroupVersionResource{
+	Group:    "k8s.cni.cncf.io",
+	Version:  "v1",
+	Resource: "network-attachment-definitions",
+}
+
+var _ environs.Networking = (*kubernetesClient)(nil)
+
+// cniConfig holds the parts of a CNI network configuration (or
+// configuration list) used to determine the CIDRs of the network.
+type cniConfig struct {
+	Plugins []cniConfig `json:"plugins,omitempty"`
+	IPAM    struct {
+		Subnet string `json:"subnet,omitempty"`
+		Range  string `json:"range,omitempty"`
+		Ranges [][]struct {
+			Subnet string `json:"subnet"`
+		} `json:"ranges,omitempty"`
+	} `json:"ipam"`
+}
+
+// cidrs returns the normalised CIDRs of the IPAM configuration.
+func (c cniConfig) cidrs() ([]string, error) {
+	candidates := []string{c.IPAM.Subnet, c.IPAM.Range}
+	for _, rangeSet := range c.IPAM.Ranges {
+		for _, r := range rangeSet {
+			candidates = append(candidates, r.Subnet)
+		}
+	}
+
+	var cidrs []string
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(candidate)
+		if err != nil {
+			return nil, errors.NotValidf("IPAM subnet %q", candidate)
+		}
+		cidrs = append(cidrs, ipNet.String())
+	}
+	for _, plugin := range c.Plugins {
+		pluginCIDRs, err := plugin.cidrs()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cidrs = append(cidrs, pluginCIDRs...)
+	}
+	return cidrs, nil
+}
+
+// Subnets is part of the environs.Networking interface.
+// It returns a subnet for each CIDR of the network attachment
+// definitions in the model namespace. As a definition may have
+// several CIDRs, the subnet provider ID is "<name>/<cidr>"; the
+// definition name is the provider network ID.
+func (k *kubernetesClient) Subnets(
+	_ envcontext.ProviderCallContext, _ instance.Id, subnetIds []network.Id,
+) ([]network.SubnetInfo, error) {
+	list, err := k.dynamicClient().Resource(networkAttachmentDefinitionGVR).Namespace(k.namespace).
+		List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Annotate(err, "listing network attachment definitions")
+	}
+
+	wanted := network.MakeIDSet(subnetIds...)
+	var result []network.SubnetInfo
+	for _, item := range list.Items {
+		name := item.GetName()
+		cidrs, err := networkAttachmentCIDRs(item)
+		if err != nil {
+			return nil, errors.Annotatef(err, "network attachment definition %q", name)
+		}
+		if len(cidrs) == 0 {
+			logger.Debugf("ignoring network attachment definition %q without IPAM subnets", name)
+			continue
+		}
+		for _, cidr := range cidrs {
+			id := network.Id(name + "/" + cidr)
+			if wanted.Size() > 0 && !wanted.Contains(id) {
+				continue
+			}
+			result = append(result, network.SubnetInfo{
+				CIDR:              cidr,
+				ProviderId:        id,
+				ProviderNetworkId: network.Id(name),
+			})
+		}
+	}
+	return result, nil
+}
+
+func networkAttachmentCIDRs(nad unstructured.Unstructured) ([]string, error) {
+	rawConfig, found, err := unstructured.NestedString(nad.Object, "spec", "config")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !found || rawConfig == "" {
+		return nil, nil
+	}
+	var config cniConfig
+	if err := json.Unmarshal([]byte(rawConfig), &config); err != nil {
+		return nil, errors.Annotate(err, "parsing CNI config")
+	}
+	return config.cidrs()
+}
+
+// SuperSubnets is part of the environs.Networking interface.
+func (k *kubernetesClient) SuperSubnets(envcontext.ProviderCallContext) ([]string, error) {
+	return nil, errors.NotSupportedf("super subnets")
+}
+
+// NetworkInterfaces is part of the environs.Networking interface.
+func (k *kubernetesClient) NetworkInterfaces(envcontext.ProviderCallContext, []instance.Id) ([]network.InterfaceInfos, error) {
+	return nil, errors.NotSupportedf("network interfaces")
+}
+
+// SupportsSpaces is part of the environs.Networking interface.
+// Spaces are supported when Multus is installed in the cluster.
+func (k *kubernetesClient) SupportsSpaces(envcontext.ProviderCallContext) (bool, error) {
+	_, err := k.getCustomResourceDefinition(networkAttachmentDefinitionCRDName)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// SupportsSpaceDiscovery is part of the environs.Networking interface.
+func (k *kubernetesClient) SupportsSpaceDiscovery(envcontext.ProviderCallContext) (bool, error) {
+	return false, nil
+}
+
+// Spaces is part of the environs.Networking interface.
+func (k *kubernetesClient) Spaces(envcontext.ProviderCallContext) ([]network.SpaceInfo, error) {
+	return nil, errors.NotSupportedf("spaces discovery")
+}
+
+// ProviderSpaceInfo is part of the environs.Networking interface.
+func (k *kubernetesClient) ProviderSpaceInfo(
+	envcontext.ProviderCallContext, *network.SpaceInfo,
+) (*environs.ProviderSpaceInfo, error) {
+	return nil, errors.NotSupportedf("provider space info")
+}
+
+// AreSpacesRoutable is part of the environs.Networking interface.
+func (k *kubernetesClient) AreSpacesRoutable(
+	_ envcontext.ProviderCallContext, _, _ *environs.ProviderSpaceInfo,
+) (bool, error) {
+	return false, nil
+}
+
+// SupportsContainerAddresses is part of the environs.Networking interface.
+func (k *kubernetesClient) SupportsContainerAddresses(envcontext.ProviderCallContext) (bool, error) {
+	return false, errors.NotSupportedf("container addresses")
+}
+
+// AllocateContainerAddresses is part of the environs.Networking interface.
+func (k *kubernetesClient) AllocateContainerAddresses(
+	envcontext.ProviderCallContext, instance.Id, names.MachineTag, network.InterfaceInfos,
+) (network.InterfaceInfos, error) {
+	return nil, errors.NotSupportedf("container address allocation")
+}
+
+// ReleaseContainerAddresses is part of the environs.Networking interface.
+func (k *kubernetesClient) ReleaseContainerAddresses(envcontext.ProviderCallContext, []network.ProviderInterfaceInfo) error {
+	return errors.NotSupportedf("container address allocation")
+}
+
+// SSHAddresses is part of the environs.Networking interface.
+func (k *kubernetesClient) SSHAddresses(
+	_ envcontext.ProviderCallContext, addresses network.SpaceAddresses,
+) (network.SpaceAddresses, error) {
+	return addresses, nil
+}
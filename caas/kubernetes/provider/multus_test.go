@@ -0,0 +1,163 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var nadGVR = schema.GroupVersionResource{
+	Group:    "k8s.cni.cncf.io",
+	Version:  "v1",
+	Resource: "network-attachment-definitions",
+}
+
+func networkAttachmentDefinition(name, config string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "k8s.cni.cncf.io/v1",
+		"kind":       "NetworkAttachmentDefinition",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": "test",
+		},
+		"spec": map[string]interface{}{
+			"config": config,
+		},
+	}}
+}
+
+func (s *K8sBrokerSuite) TestSupportsSpaces(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	var netEnv environs.Networking = s.broker
+
+	gomock.InOrder(
+		s.mockCustomResourceDefinitionV1.EXPECT().Get(gomock.Any(), "network-attachment-definitions.k8s.cni.cncf.io", v1.GetOptions{}).
+			Return(&apiextensionsv1.CustomResourceDefinition{}, nil),
+		s.mockCustomResourceDefinitionV1.EXPECT().Get(gomock.Any(), "network-attachment-definitions.k8s.cni.cncf.io", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	supported, err := netEnv.SupportsSpaces(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsTrue)
+
+	supported, err = netEnv.SupportsSpaces(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsFalse)
+}
+
+func (s *K8sBrokerSuite) TestSubnets(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	var netEnv environs.Networking = s.broker
+
+	gomock.InOrder(
+		s.mockDynamicClient.EXPECT().Resource(nadGVR).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().List(gomock.Any(), v1.ListOptions{}).Return(&unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{
+				networkAttachmentDefinition("storage", `{
+					"cniVersion": "0.3.1",
+					"type": "macvlan",
+					"master": "eth1",
+					"ipam": {"type": "host-local", "subnet": "192.168.1.0/24"}
+				}`),
+				networkAttachmentDefinition("backend", `{
+					"cniVersion": "0.3.1",
+					"plugins": [{
+						"type": "bridge",
+						"ipam": {"type": "whereabouts", "range": "10.10.0.225/28"}
+					}, {
+						"type": "tuning"
+					}]
+				}`),
+				networkAttachmentDefinition("dual", `{
+					"type": "ipvlan",
+					"ipam": {
+						"type": "host-local",
+						"ranges": [[{"subnet": "172.16.0.0/16"}], [{"subnet": "2001:db8::/64"}]]
+					}
+				}`),
+				networkAttachmentDefinition("dhcp", `{"type": "macvlan", "ipam": {"type": "dhcp"}}`),
+			},
+		}, nil),
+	)
+
+	subnets, err := netEnv.Subnets(context.NewCloudCallContext(), instance.UnknownId, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		CIDR:              "192.168.1.0/24",
+		ProviderId:        "storage/192.168.1.0/24",
+		ProviderNetworkId: "storage",
+	}, {
+		CIDR:              "10.10.0.224/28",
+		ProviderId:        "backend/10.10.0.224/28",
+		ProviderNetworkId: "backend",
+	}, {
+		CIDR:              "172.16.0.0/16",
+		ProviderId:        "dual/172.16.0.0/16",
+		ProviderNetworkId: "dual",
+	}, {
+		CIDR:              "2001:db8::/64",
+		ProviderId:        "dual/2001:db8::/64",
+		ProviderNetworkId: "dual",
+	}})
+}
+
+func (s *K8sBrokerSuite) TestSubnetsFiltered(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	var netEnv environs.Networking = s.broker
+
+	gomock.InOrder(
+		s.mockDynamicClient.EXPECT().Resource(nadGVR).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().List(gomock.Any(), v1.ListOptions{}).Return(&unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{
+				networkAttachmentDefinition("storage", `{"ipam": {"subnet": "192.168.1.0/24"}}`),
+				networkAttachmentDefinition("backend", `{"ipam": {"subnet": "10.10.0.0/24"}}`),
+			},
+		}, nil),
+	)
+
+	subnets, err := netEnv.Subnets(context.NewCloudCallContext(), instance.UnknownId, []network.Id{"backend/10.10.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, jc.DeepEquals, []network.SubnetInfo{{
+		CIDR:              "10.10.0.0/24",
+		ProviderId:        "backend/10.10.0.0/24",
+		ProviderNetworkId: "backend",
+	}})
+}
+
+func (s *K8sBrokerSuite) TestSubnetsInvalidConfig(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	var netEnv environs.Networking = s.broker
+
+	gomock.InOrder(
+		s.mockDynamicClient.EXPECT().Resource(nadGVR).Return(s.mockNamespaceableResourceClient),
+		s.mockResourceClient.EXPECT().List(gomock.Any(), v1.ListOptions{}).Return(&unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{
+				networkAttachmentDefinition("storage", `{"ipam": {"subnet": "192.168.1.1"}}`),
+			},
+		}, nil),
+	)
+
+	_, err := netEnv.Subnets(context.NewCloudCallContext(), instance.UnknownId, nil)
+	c.Assert(err, gc.ErrorMatches, `network attachment definition "storage": IPAM subnet "192.168.1.1" not valid`)
+}
@@ -137,6 +137,58 @@ func (k *kubernetesClient) createNamespace(name string) error {
 	return errors.Trace(err)
 }
 
+// adoptNamespace attaches the model to an existing namespace. Ownership of
+// the namespace is recorded by a config map in the namespace rather than by
+// annotating the namespace, so only namespace scoped permissions are needed.
+func (k *kubernetesClient) adoptNamespace(name string) error {
+	cm := &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name: constants.ModelConfigMapName,
+			Labels: utils.LabelsMerge(
+				utils.LabelsForModel(k.CurrentModel(), false),
+				utils.LabelsJuju),
+			Annotations: k.annotations.ToMap(),
+		},
+	}
+	_, err := k.client().CoreV1().ConfigMaps(name).Create(context.TODO(), cm, v1.CreateOptions{})
+	if err == nil {
+		return nil
+	}
+	if k8serrors.IsNotFound(err) {
+		return errors.NotFoundf("namespace %q to adopt", name)
+	}
+	if !k8serrors.IsAlreadyExists(err) {
+		return errors.Annotatef(err, "adopting namespace %q", name)
+	}
+	existing, err := k.getModelConfigMap()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !k8sannotations.New(existing.GetAnnotations()).HasAll(k.annotations) {
+		return errors.NotValidf("namespace %q already adopted by another model", name)
+	}
+	return nil
+}
+
+// IsAdoptedNamespace returns true if the model was attached to an existing
+// namespace rather than one created by Juju.
+func (k *kubernetesClient) IsAdoptedNamespace() bool {
+	return k.adoptedNamespace
+}
+
+// getModelConfigMap returns the config map recording the model's adoption
+// of its namespace.
+func (k *kubernetesClient) getModelConfigMap() (*core.ConfigMap, error) {
+	cm, err := k.client().CoreV1().ConfigMaps(k.namespace).Get(context.TODO(), constants.ModelConfigMapName, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("config map %q", constants.ModelConfigMapName)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting config map %q", constants.ModelConfigMapName)
+	}
+	return cm, nil
+}
+
 func (k *kubernetesClient) deleteNamespace() error {
 	// deleteNamespace is used as a means to implement Destroy().
 	// All model resources are provisioned in the namespace;
@@ -0,0 +1,162 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/core/application"
+)
+
+// EnsureNetworkPolicy creates, updates or removes the network policy
+// restricting the traffic to and from the specified application.
+// The policy only exists while the kubernetes-network-policy
+// application config is true.
+func (k *kubernetesClient) EnsureNetworkPolicy(
+	appName string, params caas.NetworkPolicyParams, config application.ConfigAttributes,
+) error {
+	name := k.deploymentName(appName, true)
+	if !config.GetBool(networkPolicyKey, defaultNetworkPolicy) {
+		logger.Debugf("deleting network policy for %s", appName)
+		return errors.Trace(k.deleteNetworkPolicy(name))
+	}
+
+	ingressCIDRs, err := configCIDRs(config, networkPolicyIngressCIDRsKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	egressCIDRs, err := configCIDRs(config, networkPolicyEgressCIDRsKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Units of the application may always talk to each other,
+	// as well as to the units of related applications.
+	peers := []networkingv1.NetworkPolicyPeer{k.applicationPeer(appName)}
+	for _, related := range params.RelatedApplications {
+		if related == appName {
+			continue
+		}
+		peers = append(peers, k.applicationPeer(related))
+	}
+
+	spec := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: utils.SelectorLabelsForApp(appName, k.IsLegacyLabels()),
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	}
+	if params.Exposed {
+		// An exposed application accepts traffic from anywhere.
+		spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{}}
+	} else {
+		spec.Ingress = []networkingv1.NetworkPolicyIngressRule{{
+			From: append(append([]networkingv1.NetworkPolicyPeer(nil), peers...), cidrPeers(ingressCIDRs)...),
+		}}
+	}
+	if len(egressCIDRs) > 0 {
+		spec.PolicyTypes = append(spec.PolicyTypes, networkingv1.PolicyTypeEgress)
+		spec.Egress = []networkingv1.NetworkPolicyEgressRule{{
+			To: append(append([]networkingv1.NetworkPolicyPeer(nil), peers...), cidrPeers(egressCIDRs)...),
+		}, {
+			// Name resolution must keep working for the
+			// related applications' services to be reachable.
+			Ports: dnsPolicyPorts(),
+		}}
+	}
+
+	logger.Debugf("creating/updating network policy for %s", appName)
+	return errors.Trace(k.ensureNetworkPolicy(&networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels:    utils.LabelsForApp(appName, k.IsLegacyLabels()),
+		},
+		Spec: spec,
+	}))
+}
+
+func (k *kubernetesClient) applicationPeer(appName string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: utils.SelectorLabelsForApp(appName, k.IsLegacyLabels()),
+		},
+	}
+}
+
+func cidrPeers(cidrs []string) []networkingv1.NetworkPolicyPeer {
+	var peers []networkingv1.NetworkPolicyPeer
+	for _, cidr := range cidrs {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+		})
+	}
+	return peers
+}
+
+func dnsPolicyPorts() []networkingv1.NetworkPolicyPort {
+	udp, tcp := core.ProtocolUDP, core.ProtocolTCP
+	port := intstr.FromInt(53)
+	return []networkingv1.NetworkPolicyPort{
+		{Protocol: &udp, Port: &port},
+		{Protocol: &tcp, Port: &port},
+	}
+}
+
+// configCIDRs returns the space separated CIDRs held by the specified
+// application config attribute.
+func configCIDRs(config application.ConfigAttributes, key string) ([]string, error) {
+	cidrs := strings.Fields(config.GetString(key, ""))
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, errors.NotValidf("CIDR %q in %s", cidr, key)
+		}
+	}
+	return cidrs, nil
+}
+
+// ensureNetworkPolicy ensures a k8s network policy resource.
+func (k *kubernetesClient) ensureNetworkPolicy(spec *networkingv1.NetworkPolicy) error {
+	api := k.client().NetworkingV1().NetworkPolicies(k.namespace)
+	_, err := api.Update(context.TODO(), spec, metav1.UpdateOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(context.TODO(), spec, metav1.CreateOptions{})
+	}
+	return errors.Trace(err)
+}
+
+// deleteNetworkPolicy deletes a network policy resource.
+func (k *kubernetesClient) deleteNetworkPolicy(name string) error {
+	err := k.client().NetworkingV1().NetworkPolicies(k.namespace).Delete(context.TODO(), name, metav1.DeleteOptions{
+		PropagationPolicy: constants.DefaultPropagationPolicy(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteNetworkPolicies(appName string) error {
+	err := k.client().NetworkingV1().NetworkPolicies(k.namespace).DeleteCollection(context.TODO(), metav1.DeleteOptions{
+		PropagationPolicy: constants.DefaultPropagationPolicy(),
+	}, metav1.ListOptions{
+		LabelSelector: utils.LabelsToSelector(utils.LabelsForApp(appName, k.IsLegacyLabels())).String(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
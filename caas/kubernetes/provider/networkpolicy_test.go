@@ -0,0 +1,154 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
+
+func appPeer(appName string) networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &v1.LabelSelector{
+			MatchLabels: map[string]string{"app.kubernetes.io/name": appName},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyDisabled(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-gitlab", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Delete(gomock.Any(), "gitlab", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("gitlab", caas.NetworkPolicyParams{
+		RelatedApplications: []string{"mariadb"},
+	}, application.ConfigAttributes{
+		"kubernetes-network-policy": false,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicy(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	udp, tcp := core.ProtocolUDP, core.ProtocolTCP
+	dnsPort := intstr.FromInt(53)
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "gitlab",
+			Namespace: "test",
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "juju", "app.kubernetes.io/name": "gitlab"},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{
+					appPeer("gitlab"),
+					appPeer("mariadb"),
+					appPeer("redis"),
+					{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}},
+				},
+			}},
+			Egress: []networkingv1.NetworkPolicyEgressRule{{
+				To: []networkingv1.NetworkPolicyPeer{
+					appPeer("gitlab"),
+					appPeer("mariadb"),
+					appPeer("redis"),
+					{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.1.0/24"}},
+					{IPBlock: &networkingv1.IPBlock{CIDR: "2001:db8::/32"}},
+				},
+			}, {
+				Ports: []networkingv1.NetworkPolicyPort{
+					{Protocol: &udp, Port: &dnsPort},
+					{Protocol: &tcp, Port: &dnsPort},
+				},
+			}},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-gitlab", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Update(gomock.Any(), policy, v1.UpdateOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Create(gomock.Any(), policy, v1.CreateOptions{}).
+			Return(policy, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("gitlab", caas.NetworkPolicyParams{
+		RelatedApplications: []string{"mariadb", "redis"},
+	}, application.ConfigAttributes{
+		"kubernetes-network-policy":               true,
+		"kubernetes-network-policy-ingress-cidrs": "10.0.0.0/8",
+		"kubernetes-network-policy-egress-cidrs":  "192.168.1.0/24 2001:db8::/32",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyExposed(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "gitlab",
+			Namespace: "test",
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "juju", "app.kubernetes.io/name": "gitlab"},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{"app.kubernetes.io/name": "gitlab"},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{{}},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-gitlab", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Update(gomock.Any(), policy, v1.UpdateOptions{}).
+			Return(policy, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("gitlab", caas.NetworkPolicyParams{
+		RelatedApplications: []string{"mariadb"},
+		Exposed:             true,
+	}, application.ConfigAttributes{
+		"kubernetes-network-policy": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyInvalidCIDR(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockStatefulSets.EXPECT().Get(gomock.Any(), "juju-operator-gitlab", v1.GetOptions{}).
+		Return(nil, s.k8sNotFoundError())
+
+	err := s.broker.EnsureNetworkPolicy("gitlab", caas.NetworkPolicyParams{}, application.ConfigAttributes{
+		"kubernetes-network-policy":              true,
+		"kubernetes-network-policy-egress-cidrs": "10.0.0.1",
+	})
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.1" in kubernetes-network-policy-egress-cidrs not valid`)
+}
@@ -268,6 +268,10 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 	if err != nil {
 		return errors.Annotate(err, "generating operator podspec")
 	}
+	if config.IsPrivateImageRepo {
+		pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets,
+			core.LocalObjectReference{Name: constants.CAASImageRepoSecretName})
+	}
 	// Take a copy for use with statefulset.
 	podWithoutStorage := pod
 
@@ -11,34 +11,24 @@ import (
 	core "k8s.io/api/core/v1"
 
 	k8spod "github.com/juju/juju/caas/kubernetes/pod"
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
 	"github.com/juju/juju/core/status"
 )
 
 type EventGetter func() ([]core.Event, error)
 
-const (
-	PodReasonCompleted                = "Completed"
-	PodReasonContainerCreating        = "ContainerCreating"
-	PodReasonContainersNotInitialized = "ContainersNotInitialized"
-	PodReasonContainersNotReady       = "ContainersNotReady"
-	PodReasonCrashLoopBackoff         = "CrashLoopBackOff"
-	PodReasonError                    = "Error"
-	PodReasonImagePull                = "ErrImagePull"
-	PodReasonInitializing             = "PodInitializing"
-)
-
 var (
 	podContainersReadyReasonsMap = map[string]status.Status{
-		PodReasonContainersNotReady: status.Maintenance,
+		resources.PodReasonContainersNotReady: status.Maintenance,
 	}
 
 	podInitializedReasonsMap = map[string]status.Status{
-		PodReasonContainersNotInitialized: status.Maintenance,
+		resources.PodReasonContainersNotInitialized: status.Maintenance,
 	}
 
 	podReadyReasonMap = map[string]status.Status{
-		PodReasonContainersNotReady:       status.Maintenance,
-		PodReasonContainersNotInitialized: status.Maintenance,
+		resources.PodReasonContainersNotReady:       status.Maintenance,
+		resources.PodReasonContainersNotInitialized: status.Maintenance,
 	}
 
 	podScheduledReasonsMap = map[string]status.Status{
@@ -187,17 +177,21 @@ func interrogatePodContainerStatus(containers []core.ContainerStatus) (string, b
 // description and false.
 func isContainerReasonError(reason string) (string, bool) {
 	switch reason {
-	case PodReasonContainerCreating:
+	case resources.PodReasonContainerCreating:
 		return "creating pod container(s)", false
-	case PodReasonError:
+	case resources.PodReasonError:
 		return "container error", true
-	case PodReasonImagePull:
+	case resources.PodReasonImagePull:
 		return "OCI image pull error", true
-	case PodReasonCrashLoopBackoff:
+	case resources.PodReasonImagePullBackOff:
+		return "OCI image pull back-off", true
+	case resources.PodReasonOOMKilled:
+		return "container out of memory", true
+	case resources.PodReasonCrashLoopBackoff:
 		return "crash loop backoff", true
-	case PodReasonCompleted:
+	case resources.PodReasonCompleted:
 		return "", false
-	case PodReasonInitializing:
+	case resources.PodReasonInitializing:
 		return "pod initializing", false
 	default:
 		return fmt.Sprintf("unknown container reason %q", reason), true
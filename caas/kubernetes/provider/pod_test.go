@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
 	"github.com/juju/juju/core/status"
 
 	core "k8s.io/api/core/v1"
@@ -127,7 +128,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 						{
 							Type:    core.PodInitialized,
 							Status:  core.ConditionFalse,
-							Reason:  PodReasonContainersNotInitialized,
+							Reason:  resources.PodReasonContainersNotInitialized,
 							Message: "initializing containers",
 						},
 					},
@@ -150,7 +151,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 						{
 							Type:    core.PodInitialized,
 							Status:  core.ConditionFalse,
-							Reason:  PodReasonInitializing,
+							Reason:  resources.PodReasonInitializing,
 							Message: "initializing containers",
 						},
 					},
@@ -174,7 +175,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 						{
 							Type:    core.PodInitialized,
 							Status:  core.ConditionFalse,
-							Reason:  PodReasonContainersNotInitialized,
+							Reason:  resources.PodReasonContainersNotInitialized,
 							Message: "initializing containers",
 						},
 					},
@@ -183,7 +184,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 							Name: "test-init-container",
 							State: core.ContainerState{
 								Waiting: &core.ContainerStateWaiting{
-									Reason:  PodReasonCrashLoopBackoff,
+									Reason:  resources.PodReasonCrashLoopBackoff,
 									Message: "I am broken",
 								},
 							},
@@ -209,7 +210,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 						{
 							Type:    core.ContainersReady,
 							Status:  core.ConditionFalse,
-							Reason:  PodReasonContainersNotReady,
+							Reason:  resources.PodReasonContainersNotReady,
 							Message: "starting containers",
 						},
 					},
@@ -233,7 +234,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 						{
 							Type:    core.ContainersReady,
 							Status:  core.ConditionFalse,
-							Reason:  PodReasonContainersNotReady,
+							Reason:  resources.PodReasonContainersNotReady,
 							Message: "starting containers",
 						},
 					},
@@ -242,7 +243,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 							Name: "test-container",
 							State: core.ContainerState{
 								Waiting: &core.ContainerStateWaiting{
-									Reason:  PodReasonCrashLoopBackoff,
+									Reason:  resources.PodReasonCrashLoopBackoff,
 									Message: "I am broken",
 								},
 							},
@@ -267,7 +268,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 						{
 							Type:    core.PodInitialized,
 							Status:  core.ConditionFalse,
-							Reason:  PodReasonContainersNotInitialized,
+							Reason:  resources.PodReasonContainersNotInitialized,
 							Message: "initializing containers",
 						},
 					},
@@ -276,7 +277,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 							Name: "test-container",
 							State: core.ContainerState{
 								Waiting: &core.ContainerStateWaiting{
-									Reason: PodReasonContainerCreating,
+									Reason: resources.PodReasonContainerCreating,
 								},
 							},
 						},
@@ -300,7 +301,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 						{
 							Type:    core.ContainersReady,
 							Status:  core.ConditionFalse,
-							Reason:  PodReasonContainersNotReady,
+							Reason:  resources.PodReasonContainersNotReady,
 							Message: "creating containers",
 						},
 					},
@@ -309,7 +310,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 							Name: "test-container",
 							State: core.ContainerState{
 								Waiting: &core.ContainerStateWaiting{
-									Reason: PodReasonContainerCreating,
+									Reason: resources.PodReasonContainerCreating,
 								},
 							},
 						},
@@ -333,7 +334,7 @@ func TestPodConditionListJujuStatus(t *testing.T) {
 						{
 							Type:    core.ContainersReady,
 							Status:  core.ConditionFalse,
-							Reason:  PodReasonContainersNotReady,
+							Reason:  resources.PodReasonContainersNotReady,
 							Message: "starting containers",
 						},
 					},
@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 )
@@ -42,19 +43,8 @@ func (k *kubernetesClient) PrecheckInstance(ctx context.ProviderCallContext, par
 	if params.Constraints.Tags == nil {
 		return nil
 	}
-	affinityLabels := *params.Constraints.Tags
-	labelsString := strings.Join(affinityLabels, ",")
-	for _, labelPair := range affinityLabels {
-		parts := strings.Split(labelPair, "=")
-		if len(parts) != 2 {
-			return errors.Errorf("invalid node affinity constraints: %v", labelsString)
-		}
-		key := strings.Trim(parts[0], " ")
-		if strings.HasPrefix(key, "^") {
-			if len(key) == 1 {
-				return errors.Errorf("invalid node affinity constraints: %v", labelsString)
-			}
-		}
+	if err := utils.ValidatePlacementTags(*params.Constraints.Tags); err != nil {
+		return errors.Annotate(err, "invalid placement constraints")
 	}
 	return nil
 }
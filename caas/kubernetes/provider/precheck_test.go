@@ -78,10 +78,21 @@ func (s *PrecheckSuite) TestInvalidConstraints(c *gc.C) {
 		Series:      "kubernetes",
 		Constraints: constraints.MustParse("tags=foo"),
 	})
-	c.Assert(err, gc.ErrorMatches, `invalid node affinity constraints: foo`)
+	c.Assert(err, gc.ErrorMatches, `invalid placement constraints: placement tag "foo" not valid`)
 	err = s.broker.PrecheckInstance(context.NewCloudCallContext(), environs.PrecheckInstanceParams{
 		Series:      "kubernetes",
 		Constraints: constraints.MustParse("tags=^=bar"),
 	})
-	c.Assert(err, gc.ErrorMatches, `invalid node affinity constraints: \^=bar`)
+	c.Assert(err, gc.ErrorMatches, `invalid placement constraints: placement tag "\^=bar" not valid`)
+}
+
+func (s *PrecheckSuite) TestInvalidPlacementConstraints(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	err := s.broker.PrecheckInstance(context.NewCloudCallContext(), environs.PrecheckInstanceParams{
+		Series:      "kubernetes",
+		Constraints: constraints.MustParse("tags=toleration.dedicated=juju:Never"),
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid placement constraints: toleration "toleration.dedicated=juju:Never": taint effect "Never" not valid`)
 }
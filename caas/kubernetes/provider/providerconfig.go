@@ -38,6 +38,13 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	k8sconstants.AdoptNamespaceKey: {
+		Description: "Whether the model adopts an existing namespace, named after the model, instead of creating one. " +
+			"An adopted namespace is not deleted with the model, and only needs credentials scoped to the namespace.",
+		Type:      environschema.Tbool,
+		Group:     environschema.AccountGroup,
+		Immutable: true,
+	},
 }
 
 var providerConfigFields = func() schema.Fields {
@@ -51,6 +58,7 @@ var providerConfigFields = func() schema.Fields {
 var providerConfigDefaults = schema.Defaults{
 	k8sconstants.WorkloadStorageKey: "",
 	k8sconstants.OperatorStorageKey: "",
+	k8sconstants.AdoptNamespaceKey:  false,
 }
 
 type brokerConfig struct {
@@ -66,6 +74,10 @@ func (c *brokerConfig) operatorStorage() string {
 	return c.attrs[k8sconstants.OperatorStorageKey].(string)
 }
 
+func (c *brokerConfig) adoptNamespace() bool {
+	return c.attrs[k8sconstants.AdoptNamespaceKey].(bool)
+}
+
 func (p kubernetesEnvironProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
 	newCfg, err := validateConfig(cfg, old)
 	if err != nil {
@@ -5,12 +5,16 @@ package resources
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/juju/juju/core/status"
 )
 
 const maxEventsToPage = 100
@@ -42,3 +46,64 @@ func ListEventsForObject(ctx context.Context, client kubernetes.Interface,
 	}
 	return items, nil
 }
+
+// Reasons of the warning events reported as the status of the object
+// they are about.
+const (
+	EventReasonFailedScheduling = "FailedScheduling"
+	EventReasonBackOff          = "BackOff"
+	EventReasonFailed           = "Failed"
+	EventReasonFailedCreate     = "FailedCreate"
+	EventReasonFailedMount      = "FailedMount"
+)
+
+type eventReasonStatus struct {
+	status      status.Status
+	description string
+}
+
+var eventReasonStatuses = map[string]eventReasonStatus{
+	EventReasonFailedScheduling: {status.Blocked, "unschedulable"},
+	EventReasonBackOff:          {status.Waiting, "back-off"},
+	EventReasonFailed:           {status.Blocked, "failed"},
+	EventReasonFailedCreate:     {status.Blocked, "failed to create pods"},
+	EventReasonFailedMount:      {status.Blocked, "failed to mount volumes"},
+}
+
+// EventStatus returns the status message, juju status and time of the most
+// recent warning event with a reason that is reported as a status, and
+// false if there is no such event.
+func EventStatus(events []corev1.Event) (string, status.Status, time.Time, bool) {
+	var (
+		latest *corev1.Event
+		since  time.Time
+	)
+	for i, ev := range events {
+		if ev.Type != corev1.EventTypeWarning {
+			continue
+		}
+		if _, ok := eventReasonStatuses[ev.Reason]; !ok {
+			continue
+		}
+		if t := eventTime(ev); latest == nil || !t.Before(since) {
+			latest = &events[i]
+			since = t
+		}
+	}
+	if latest == nil {
+		return "", "", time.Time{}, false
+	}
+	reasonStatus := eventReasonStatuses[latest.Reason]
+	return fmt.Sprintf("%s: %s", reasonStatus.description, latest.Message), reasonStatus.status, since, true
+}
+
+// eventTime returns the time an event last occurred.
+func eventTime(ev corev1.Event) time.Time {
+	if !ev.LastTimestamp.IsZero() {
+		return ev.LastTimestamp.Time
+	}
+	if !ev.EventTime.IsZero() {
+		return ev.EventTime.Time
+	}
+	return ev.CreationTimestamp.Time
+}
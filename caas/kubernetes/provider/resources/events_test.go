@@ -7,6 +7,7 @@ import (
 	"context"
 	"sort"
 	"strconv"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -14,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/juju/juju/caas/kubernetes/provider/resources"
+	"github.com/juju/juju/core/status"
 )
 
 type eventsSuite struct {
@@ -53,3 +55,55 @@ func (s *eventsSuite) TestList(c *gc.C) {
 	})
 	c.Assert(events, jc.DeepEquals, res)
 }
+
+func (s *eventsSuite) TestEventStatus(c *gc.C) {
+	t0 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := []corev1.Event{{
+		Type:          corev1.EventTypeWarning,
+		Reason:        resources.EventReasonFailedScheduling,
+		Message:       "0/1 nodes are available: 1 Insufficient memory.",
+		LastTimestamp: metav1.NewTime(t0.Add(time.Minute)),
+	}, {
+		Type:          corev1.EventTypeWarning,
+		Reason:        resources.EventReasonFailedMount,
+		Message:       "timed out waiting for the condition",
+		LastTimestamp: metav1.NewTime(t0),
+	}, {
+		Type:          corev1.EventTypeNormal,
+		Reason:        "Scheduled",
+		Message:       "successfully assigned",
+		LastTimestamp: metav1.NewTime(t0.Add(2 * time.Minute)),
+	}, {
+		Type:          corev1.EventTypeWarning,
+		Reason:        "SomethingElse",
+		Message:       "not reported",
+		LastTimestamp: metav1.NewTime(t0.Add(3 * time.Minute)),
+	}}
+	message, eventStatus, since, ok := resources.EventStatus(events)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(message, gc.Equals, "unschedulable: 0/1 nodes are available: 1 Insufficient memory.")
+	c.Assert(eventStatus, gc.Equals, status.Blocked)
+	c.Assert(since, gc.Equals, t0.Add(time.Minute))
+
+	_, _, _, ok = resources.EventStatus(events[2:])
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *eventsSuite) TestEventStatusIsWorkloadStatus(c *gc.C) {
+	// The statuses are set as application statuses, so must be valid
+	// workload statuses.
+	for _, reason := range []string{
+		resources.EventReasonFailedScheduling,
+		resources.EventReasonBackOff,
+		resources.EventReasonFailed,
+		resources.EventReasonFailedCreate,
+		resources.EventReasonFailedMount,
+	} {
+		_, eventStatus, _, ok := resources.EventStatus([]corev1.Event{{
+			Type:   corev1.EventTypeWarning,
+			Reason: reason,
+		}})
+		c.Check(ok, jc.IsTrue)
+		c.Check(status.ValidWorkloadStatus(eventStatus), jc.IsTrue, gc.Commentf("reason %q", reason))
+	}
+}
@@ -5,6 +5,7 @@ package resources
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -120,6 +121,14 @@ func (p *Pod) ComputeStatus(ctx context.Context, client kubernetes.Interface, no
 	if p.DeletionTimestamp != nil {
 		return "", status.Terminated, p.DeletionTimestamp.Time, nil
 	}
+	// Containers which can't start or keep crashing are errors, whatever
+	// the phase of the pod.
+	if message, ok := containerErrorMessage(p.Status.InitContainerStatuses); ok {
+		return message, status.Error, now, nil
+	}
+	if message, ok := containerErrorMessage(p.Status.ContainerStatuses); ok {
+		return message, status.Error, now, nil
+	}
 	jujuStatus := status.Unknown
 	switch p.Status.Phase {
 	case corev1.PodRunning:
@@ -141,17 +150,73 @@ func (p *Pod) ComputeStatus(ctx context.Context, client kubernetes.Interface, no
 			}
 		}
 	}
-	if statusMessage == "" {
-		// If there are any events for this pod we can use the
-		// most recent to set the status.
+	if statusMessage == "" || jujuStatus == status.Allocating {
 		eventList, err := p.Events(ctx, client)
 		if err != nil {
 			return "", "", time.Time{}, errors.Trace(err)
 		}
-		// Take the most recent event.
-		if count := len(eventList); count > 0 {
+		// A pending pod may be stuck, in which case the warning
+		// events say why.
+		if jujuStatus == status.Allocating {
+			if message, eventStatus, eventSince, ok := EventStatus(eventList); ok {
+				return message, eventStatus, eventSince, nil
+			}
+		}
+		// If there are any events for this pod we can use the
+		// most recent to set the status.
+		if count := len(eventList); count > 0 && statusMessage == "" {
 			statusMessage = eventList[count-1].Message
 		}
 	}
 	return statusMessage, jujuStatus, since, nil
 }
+
+// Reasons reported in the conditions of a pod and the statuses of its
+// containers.
+const (
+	PodReasonCompleted                  = "Completed"
+	PodReasonContainerCreating          = "ContainerCreating"
+	PodReasonContainersNotInitialized   = "ContainersNotInitialized"
+	PodReasonContainersNotReady         = "ContainersNotReady"
+	PodReasonCrashLoopBackoff           = "CrashLoopBackOff"
+	PodReasonCreateContainerConfigError = "CreateContainerConfigError"
+	PodReasonError                      = "Error"
+	PodReasonImagePull                  = "ErrImagePull"
+	PodReasonImagePullBackOff           = "ImagePullBackOff"
+	PodReasonInvalidImageName           = "InvalidImageName"
+	PodReasonOOMKilled                  = "OOMKilled"
+	PodReasonInitializing               = "PodInitializing"
+)
+
+// containerErrorMessage returns a message describing the first container
+// which is failing to run, and false if there is no such container.
+func containerErrorMessage(statuses []corev1.ContainerStatus) (string, bool) {
+	for _, cs := range statuses {
+		if waiting := cs.State.Waiting; waiting != nil {
+			switch waiting.Reason {
+			case PodReasonCrashLoopBackoff:
+				reason := waiting.Reason
+				// The reason the container last terminated is more
+				// useful, particularly when it ran out of memory.
+				if terminated := cs.LastTerminationState.Terminated; terminated != nil && terminated.Reason != "" {
+					reason = fmt.Sprintf("%s (%s)", reason, terminated.Reason)
+				}
+				return containerMessage(cs.Name, reason, waiting.Message), true
+			case PodReasonImagePullBackOff, PodReasonImagePull,
+				PodReasonInvalidImageName, PodReasonCreateContainerConfigError:
+				return containerMessage(cs.Name, waiting.Reason, waiting.Message), true
+			}
+		}
+		if terminated := cs.State.Terminated; terminated != nil && terminated.Reason == PodReasonOOMKilled {
+			return containerMessage(cs.Name, terminated.Reason, terminated.Message), true
+		}
+	}
+	return "", false
+}
+
+func containerMessage(name, reason, message string) string {
+	if message == "" {
+		return fmt.Sprintf("container %q %s", name, reason)
+	}
+	return fmt.Sprintf("container %q %s: %s", name, reason, message)
+}
@@ -5,6 +5,7 @@ package resources_test
 
 import (
 	"context"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/juju/juju/caas/kubernetes/provider/resources"
+	"github.com/juju/juju/core/status"
 )
 
 type podSuite struct {
@@ -93,3 +95,92 @@ func (s *podSuite) TestDelete(c *gc.C) {
 	_, err = s.client.CoreV1().Pods("test").Get(context.TODO(), "ds1", metav1.GetOptions{})
 	c.Assert(err, jc.Satisfies, k8serrors.IsNotFound)
 }
+
+func (s *podSuite) TestComputeStatusContainerErrors(c *gc.C) {
+	now := time.Now()
+	for i, test := range []struct {
+		containerStatus corev1.ContainerStatus
+		message         string
+	}{{
+		containerStatus: corev1.ContainerStatus{
+			Name: "workload",
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{
+					Reason:  resources.PodReasonCrashLoopBackoff,
+					Message: "back-off 10s restarting failed container",
+				},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Reason: resources.PodReasonOOMKilled,
+				},
+			},
+		},
+		message: `container "workload" CrashLoopBackOff (OOMKilled): back-off 10s restarting failed container`,
+	}, {
+		containerStatus: corev1.ContainerStatus{
+			Name: "workload",
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{
+					Reason:  resources.PodReasonImagePullBackOff,
+					Message: `Back-off pulling image "nginx:nope"`,
+				},
+			},
+		},
+		message: `container "workload" ImagePullBackOff: Back-off pulling image "nginx:nope"`,
+	}, {
+		containerStatus: corev1.ContainerStatus{
+			Name: "workload",
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					Reason: resources.PodReasonOOMKilled,
+				},
+			},
+		},
+		message: `container "workload" OOMKilled`,
+	}} {
+		c.Logf("test %d", i)
+		pod := resources.NewPod("pod1", "test", &corev1.Pod{
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{test.containerStatus},
+			},
+		})
+		message, podStatus, since, err := pod.ComputeStatus(context.TODO(), s.client, now)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(message, gc.Equals, test.message)
+		c.Check(podStatus, gc.Equals, status.Error)
+		c.Check(since, gc.Equals, now)
+	}
+}
+
+func (s *podSuite) TestComputeStatusPendingWithWarningEvent(c *gc.C) {
+	lastSeen := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := s.client.CoreV1().Events("test").Create(context.TODO(), &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod1.1",
+			Namespace: "test",
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Name: "pod1",
+			Kind: "Pod",
+		},
+		Type:          corev1.EventTypeWarning,
+		Reason:        resources.EventReasonFailedScheduling,
+		Message:       "0/1 nodes are available: 1 Insufficient cpu.",
+		LastTimestamp: metav1.NewTime(lastSeen),
+	}, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pod := resources.NewPod("pod1", "test", &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase:   corev1.PodPending,
+			Message: "pending",
+		},
+	})
+	message, podStatus, since, err := pod.ComputeStatus(context.TODO(), s.client, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(message, gc.Equals, "unschedulable: 0/1 nodes are available: 1 Insufficient cpu.")
+	c.Assert(podStatus, gc.Equals, status.Blocked)
+	c.Assert(since.Equal(lastSeen), jc.IsTrue)
+}
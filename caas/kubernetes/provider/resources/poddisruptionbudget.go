@@ -0,0 +1,223 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"time"
+
+	"github.com/juju/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	k8sconstants "github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/core/status"
+)
+
+// policyV1GroupVersion is the API group version serving pod disruption
+// budgets from Kubernetes 1.21, replacing policy/v1beta1. The vendored
+// client only has the v1beta1 types, whose fields are the same, so the
+// v1 API is used through raw requests when the cluster serves it.
+const policyV1GroupVersion = "policy/v1"
+
+// PodDisruptionBudget extends the k8s pod disruption budget.
+type PodDisruptionBudget struct {
+	policyv1beta1.PodDisruptionBudget
+}
+
+// usePolicyV1 returns whether the cluster serves pod disruption budgets
+// from the policy/v1 API.
+func usePolicyV1(client kubernetes.Interface) bool {
+	resources, err := client.Discovery().ServerResourcesForGroupVersion(policyV1GroupVersion)
+	if err != nil {
+		return false
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == "poddisruptionbudgets" {
+			return true
+		}
+	}
+	return false
+}
+
+// policyV1Path returns the policy/v1 API path of the pod disruption
+// budgets in the namespace, or of the named budget.
+func policyV1Path(namespace string, name ...string) string {
+	return path.Join(append([]string{"/apis", policyV1GroupVersion, "namespaces", namespace, "poddisruptionbudgets"}, name...)...)
+}
+
+// policyV1Do sends a request to the policy/v1 API, and decodes the
+// pod disruption budget in the response, if any, into out.
+func policyV1Do(ctx context.Context, req *rest.Request, out *policyv1beta1.PodDisruptionBudget) error {
+	data, err := req.SetHeader("Content-Type", "application/json").DoRaw(ctx)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	var res policyv1beta1.PodDisruptionBudget
+	if err := json.Unmarshal(data, &res); err != nil {
+		return errors.Trace(err)
+	}
+	res.TypeMeta = metav1.TypeMeta{}
+	*out = res
+	return nil
+}
+
+// NewPodDisruptionBudget creates a new pod disruption budget resource.
+func NewPodDisruptionBudget(name string, namespace string, in *policyv1beta1.PodDisruptionBudget) *PodDisruptionBudget {
+	if in == nil {
+		in = &policyv1beta1.PodDisruptionBudget{}
+	}
+	in.SetName(name)
+	in.SetNamespace(namespace)
+	return &PodDisruptionBudget{*in}
+}
+
+// Clone returns a copy of the resource.
+func (pdb *PodDisruptionBudget) Clone() Resource {
+	clone := *pdb
+	return &clone
+}
+
+// Apply patches the resource change.
+func (pdb *PodDisruptionBudget) Apply(ctx context.Context, client kubernetes.Interface) error {
+	if usePolicyV1(client) {
+		return errors.Trace(pdb.applyV1(ctx, client))
+	}
+	api := client.PolicyV1beta1().PodDisruptionBudgets(pdb.Namespace)
+	data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, &pdb.PodDisruptionBudget)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	res, err := api.Patch(ctx, pdb.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{
+		FieldManager: JujuFieldManager,
+	})
+	if k8serrors.IsNotFound(err) {
+		res, err = api.Create(ctx, &pdb.PodDisruptionBudget, metav1.CreateOptions{
+			FieldManager: JujuFieldManager,
+		})
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	pdb.PodDisruptionBudget = *res
+	return nil
+}
+
+func (pdb *PodDisruptionBudget) applyV1(ctx context.Context, client kubernetes.Interface) error {
+	obj := pdb.PodDisruptionBudget
+	obj.TypeMeta = metav1.TypeMeta{APIVersion: policyV1GroupVersion, Kind: "PodDisruptionBudget"}
+	data, err := json.Marshal(&obj)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	restClient := client.PolicyV1beta1().RESTClient()
+	err = policyV1Do(ctx, restClient.Patch(types.StrategicMergePatchType).
+		AbsPath(policyV1Path(pdb.Namespace, pdb.Name)).
+		Param("fieldManager", JujuFieldManager).
+		Body(data), &pdb.PodDisruptionBudget)
+	if k8serrors.IsNotFound(err) {
+		err = policyV1Do(ctx, restClient.Post().
+			AbsPath(policyV1Path(pdb.Namespace)).
+			Param("fieldManager", JujuFieldManager).
+			Body(data), &pdb.PodDisruptionBudget)
+	}
+	return errors.Trace(err)
+}
+
+// Get refreshes the resource.
+func (pdb *PodDisruptionBudget) Get(ctx context.Context, client kubernetes.Interface) error {
+	var (
+		res *policyv1beta1.PodDisruptionBudget
+		err error
+	)
+	if usePolicyV1(client) {
+		res = &policyv1beta1.PodDisruptionBudget{}
+		err = policyV1Do(ctx, client.PolicyV1beta1().RESTClient().Get().
+			AbsPath(policyV1Path(pdb.Namespace, pdb.Name)), res)
+	} else {
+		res, err = client.PolicyV1beta1().PodDisruptionBudgets(pdb.Namespace).Get(ctx, pdb.Name, metav1.GetOptions{})
+	}
+	if k8serrors.IsNotFound(err) {
+		return errors.NewNotFound(err, "k8s")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	pdb.PodDisruptionBudget = *res
+	return nil
+}
+
+// Delete removes the resource.
+func (pdb *PodDisruptionBudget) Delete(ctx context.Context, client kubernetes.Interface) error {
+	opts := metav1.DeleteOptions{
+		PropagationPolicy: k8sconstants.DefaultPropagationPolicy(),
+	}
+	var err error
+	if usePolicyV1(client) {
+		data, marshalErr := json.Marshal(&opts)
+		if marshalErr != nil {
+			return errors.Trace(marshalErr)
+		}
+		err = policyV1Do(ctx, client.PolicyV1beta1().RESTClient().Delete().
+			AbsPath(policyV1Path(pdb.Namespace, pdb.Name)).
+			Body(data), nil)
+	} else {
+		err = client.PolicyV1beta1().PodDisruptionBudgets(pdb.Namespace).Delete(ctx, pdb.Name, opts)
+	}
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// Events emitted by the resource.
+func (pdb *PodDisruptionBudget) Events(ctx context.Context, client kubernetes.Interface) ([]corev1.Event, error) {
+	return ListEventsForObject(ctx, client, pdb.Namespace, pdb.Name, "PodDisruptionBudget")
+}
+
+// ComputeStatus returns a juju status for the resource.
+func (pdb *PodDisruptionBudget) ComputeStatus(_ context.Context, _ kubernetes.Interface, now time.Time) (string, status.Status, time.Time, error) {
+	if pdb.DeletionTimestamp != nil {
+		return "", status.Terminated, pdb.DeletionTimestamp.Time, nil
+	}
+	return "", status.Active, now, nil
+}
+
+// DeletePodDisruptionBudgets removes the pod disruption budgets in the
+// namespace matching the list options.
+func DeletePodDisruptionBudgets(ctx context.Context, client kubernetes.Interface, namespace string, listOpts metav1.ListOptions) error {
+	opts := metav1.DeleteOptions{
+		PropagationPolicy: k8sconstants.DefaultPropagationPolicy(),
+	}
+	var err error
+	if usePolicyV1(client) {
+		data, marshalErr := json.Marshal(&opts)
+		if marshalErr != nil {
+			return errors.Trace(marshalErr)
+		}
+		err = policyV1Do(ctx, client.PolicyV1beta1().RESTClient().Delete().
+			AbsPath(policyV1Path(namespace)).
+			Param("labelSelector", listOpts.LabelSelector).
+			Body(data), nil)
+	} else {
+		err = client.PolicyV1beta1().PodDisruptionBudgets(namespace).DeleteCollection(ctx, opts, listOpts)
+	}
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,188 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
+)
+
+type podDisruptionBudgetSuite struct {
+	resourceSuite
+}
+
+var _ = gc.Suite(&podDisruptionBudgetSuite{})
+
+func (s *podDisruptionBudgetSuite) TestApply(c *gc.C) {
+	maxUnavailable := intstr.FromInt(1)
+	pdb := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pdb1",
+			Namespace: "test",
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+	// Create.
+	pdbResource := resources.NewPodDisruptionBudget("pdb1", "test", pdb)
+	c.Assert(pdbResource.Apply(context.TODO(), s.client), jc.ErrorIsNil)
+	result, err := s.client.PolicyV1beta1().PodDisruptionBudgets("test").Get(context.TODO(), "pdb1", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Spec.MaxUnavailable, jc.DeepEquals, &maxUnavailable)
+
+	// Update.
+	maxUnavailable = intstr.FromString("50%")
+	pdbResource = resources.NewPodDisruptionBudget("pdb1", "test", pdb)
+	c.Assert(pdbResource.Apply(context.TODO(), s.client), jc.ErrorIsNil)
+
+	result, err = s.client.PolicyV1beta1().PodDisruptionBudgets("test").Get(context.TODO(), "pdb1", metav1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.GetName(), gc.Equals, `pdb1`)
+	c.Assert(result.GetNamespace(), gc.Equals, `test`)
+	c.Assert(result.Spec.MaxUnavailable, jc.DeepEquals, &maxUnavailable)
+}
+
+func (s *podDisruptionBudgetSuite) TestGet(c *gc.C) {
+	template := policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pdb1",
+			Namespace: "test",
+		},
+	}
+	pdb1 := template
+	pdb1.SetAnnotations(map[string]string{"a": "b"})
+	_, err := s.client.PolicyV1beta1().PodDisruptionBudgets("test").Create(context.TODO(), &pdb1, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pdbResource := resources.NewPodDisruptionBudget("pdb1", "test", &template)
+	c.Assert(len(pdbResource.GetAnnotations()), gc.Equals, 0)
+	err = pdbResource.Get(context.TODO(), s.client)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pdbResource.GetName(), gc.Equals, `pdb1`)
+	c.Assert(pdbResource.GetNamespace(), gc.Equals, `test`)
+	c.Assert(pdbResource.GetAnnotations(), gc.DeepEquals, map[string]string{"a": "b"})
+}
+
+func (s *podDisruptionBudgetSuite) TestDelete(c *gc.C) {
+	pdb := policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pdb1",
+			Namespace: "test",
+		},
+	}
+	_, err := s.client.PolicyV1beta1().PodDisruptionBudgets("test").Create(context.TODO(), &pdb, metav1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	pdbResource := resources.NewPodDisruptionBudget("pdb1", "test", &pdb)
+	err = pdbResource.Delete(context.TODO(), s.client)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = pdbResource.Get(context.TODO(), s.client)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = s.client.PolicyV1beta1().PodDisruptionBudgets("test").Get(context.TODO(), "pdb1", metav1.GetOptions{})
+	c.Assert(err, jc.Satisfies, k8serrors.IsNotFound)
+
+	// Deleting a missing budget is not an error.
+	c.Assert(pdbResource.Delete(context.TODO(), s.client), jc.ErrorIsNil)
+}
+
+// policyV1Server is a fake API server serving pod disruption budgets
+// from the policy/v1 API only.
+type policyV1Server struct {
+	mu     sync.Mutex
+	budget map[string]interface{}
+	verbs  []string
+}
+
+func (s *policyV1Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if req.URL.Path == "/apis/policy/v1" {
+		_ = json.NewEncoder(w).Encode(metav1.APIResourceList{
+			TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+			GroupVersion: "policy/v1",
+			APIResources: []metav1.APIResource{{Name: "poddisruptionbudgets", Namespaced: true, Kind: "PodDisruptionBudget"}},
+		})
+		return
+	}
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(k8serrors.NewNotFound(policyv1beta1.Resource("poddisruptionbudgets"), "pdb1").ErrStatus)
+	}
+	switch {
+	case req.URL.Path == "/apis/policy/v1/namespaces/test/poddisruptionbudgets" && req.Method == http.MethodPost:
+		body, _ := ioutil.ReadAll(req.Body)
+		_ = json.Unmarshal(body, &s.budget)
+	case req.URL.Path != "/apis/policy/v1/namespaces/test/poddisruptionbudgets/pdb1":
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	case s.budget == nil:
+		s.verbs = append(s.verbs, req.Method)
+		notFound()
+		return
+	case req.Method == http.MethodPatch:
+		body, _ := ioutil.ReadAll(req.Body)
+		_ = json.Unmarshal(body, &s.budget)
+	case req.Method == http.MethodDelete:
+		s.budget = nil
+		s.verbs = append(s.verbs, req.Method)
+		_ = json.NewEncoder(w).Encode(metav1.Status{Status: metav1.StatusSuccess})
+		return
+	}
+	s.verbs = append(s.verbs, req.Method)
+	_ = json.NewEncoder(w).Encode(s.budget)
+}
+
+func (s *podDisruptionBudgetSuite) TestPolicyV1(c *gc.C) {
+	server := &policyV1Server{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	client, err := kubernetes.NewForConfig(&rest.Config{Host: httpServer.URL})
+	c.Assert(err, jc.ErrorIsNil)
+
+	maxUnavailable := intstr.FromInt(1)
+	pdbResource := resources.NewPodDisruptionBudget("pdb1", "test", &policyv1beta1.PodDisruptionBudget{
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+		},
+	})
+	c.Assert(pdbResource.Apply(context.TODO(), client), jc.ErrorIsNil)
+	c.Assert(server.budget["apiVersion"], gc.Equals, "policy/v1")
+	c.Assert(pdbResource.Spec.MaxUnavailable, jc.DeepEquals, &maxUnavailable)
+
+	// Update.
+	percentUnavailable := intstr.FromString("50%")
+	pdbResource.Spec.MaxUnavailable = &percentUnavailable
+	c.Assert(pdbResource.Apply(context.TODO(), client), jc.ErrorIsNil)
+	c.Assert(server.budget["spec"], jc.DeepEquals, map[string]interface{}{"maxUnavailable": "50%"})
+
+	c.Assert(pdbResource.Get(context.TODO(), client), jc.ErrorIsNil)
+	c.Assert(pdbResource.GetName(), gc.Equals, "pdb1")
+
+	c.Assert(pdbResource.Delete(context.TODO(), client), jc.ErrorIsNil)
+	err = pdbResource.Get(context.TODO(), client)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(pdbResource.Delete(context.TODO(), client), jc.ErrorIsNil)
+
+	c.Assert(server.verbs, jc.DeepEquals, []string{"PATCH", "POST", "PATCH", "GET", "DELETE", "GET", "DELETE"})
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider/utils"
 	"github.com/juju/juju/caas/specs"
 	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/docker"
 )
 
 func getSecretLabels(appName string, legacy bool) map[string]string {
@@ -91,6 +92,33 @@ func (k *kubernetesClient) ensureOCIImageSecret(
 	return errors.Trace(err)
 }
 
+// EnsureImageRepoSecret ensures the secret used to pull images from the
+// controller's private image repo exists in the namespace, and holds the
+// current credentials.
+func (k *kubernetesClient) EnsureImageRepoSecret(imageRepo docker.ImageRepoDetails) error {
+	if !imageRepo.IsPrivate() {
+		return nil
+	}
+	secretData, err := imageRepo.SecretData()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newSecret := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      constants.CAASImageRepoSecretName,
+			Namespace: k.namespace,
+			Labels:    utils.LabelsForModel(k.CurrentModel(), k.IsLegacyLabels()),
+		},
+		Type: core.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			core.DockerConfigJsonKey: secretData,
+		},
+	}
+	logger.Debugf("ensuring image repo secret %q", newSecret.GetName())
+	_, err = k.ensureSecret(newSecret)
+	return errors.Trace(err)
+}
+
 func (k *kubernetesClient) ensureSecret(sec *core.Secret) (func(), error) {
 	cleanUp := func() {}
 	out, err := k.createSecret(sec)
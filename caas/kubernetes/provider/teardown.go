@@ -11,8 +11,12 @@ import (
 	jujuclock "github.com/juju/clock"
 	"github.com/juju/errors"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/caas/kubernetes/provider/resources"
 	"github.com/juju/juju/caas/kubernetes/provider/utils"
 	"github.com/juju/juju/core/watcher"
 )
@@ -283,3 +287,100 @@ func (k *kubernetesClient) deleteNamespaceModelTeardown(ctx context.Context, wg
 		}
 	}
 }
+
+// deleteAdoptedNamespaceModelTeardown deletes the resources created by Juju
+// in an adopted namespace, leaving the namespace itself and any workloads
+// not managed by Juju in place.
+func (k *kubernetesClient) deleteAdoptedNamespaceModelTeardown(ctx context.Context, wg *sync.WaitGroup, errChan chan<- error) {
+	// Most resources are labelled as managed by Juju, but some, like the
+	// image pull secret, only carry the model labels.
+	modelSelector := utils.LabelsToSelector(utils.LabelsForModel(k.CurrentModel(), k.IsLegacyLabels()))
+	selectors := []k8slabels.Selector{
+		utils.LabelsToSelector(utils.LabelsJuju),
+		modelSelector,
+	}
+	ensureResourcesDeletedFunc(ctx, modelSelector, k.clock, wg, errChan,
+		func(selector k8slabels.Selector) error {
+			for _, s := range selectors {
+				if err := k.deleteAdoptedNamespaceResources(s); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			// Models created before adopted namespaces were kept
+			// namespace scoped may have cluster wide RBAC resources.
+			if err := ignoreForbidden(k.deleteClusterRoleBindings(selector)); err != nil {
+				return errors.Trace(err)
+			}
+			return errors.Trace(ignoreForbidden(k.deleteClusterRoles(selector)))
+		}, func(_ k8slabels.Selector) error {
+			for _, s := range selectors {
+				pods, err := k.client().CoreV1().Pods(k.namespace).List(context.TODO(), v1.ListOptions{
+					LabelSelector: s.String(),
+				})
+				if err != nil {
+					return errors.Trace(err)
+				}
+				if len(pods.Items) > 0 {
+					return nil
+				}
+			}
+			return errors.NotFoundf("pods for selectors %q", selectors)
+		},
+	)
+}
+
+// ignoreForbidden returns nil if the error is because the credential
+// isn't allowed to manage the resources, as is the case for credentials
+// scoped to an adopted namespace.
+func ignoreForbidden(err error) error {
+	if k8serrors.IsForbidden(errors.Cause(err)) {
+		return nil
+	}
+	return err
+}
+
+// collectionDeleter is implemented by the clients of namespaced resources.
+type collectionDeleter interface {
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+}
+
+func (k *kubernetesClient) deleteAdoptedNamespaceResources(selector k8slabels.Selector) error {
+	listOpts := v1.ListOptions{LabelSelector: selector.String()}
+	collections := []collectionDeleter{
+		k.client().AppsV1().Deployments(k.namespace),
+		k.client().AppsV1().StatefulSets(k.namespace),
+		k.client().AppsV1().DaemonSets(k.namespace),
+		k.client().CoreV1().PersistentVolumeClaims(k.namespace),
+		k.client().CoreV1().ConfigMaps(k.namespace),
+		k.client().CoreV1().Secrets(k.namespace),
+		k.client().CoreV1().ServiceAccounts(k.namespace),
+		k.client().RbacV1().RoleBindings(k.namespace),
+		k.client().RbacV1().Roles(k.namespace),
+		k.client().NetworkingV1().Ingresses(k.namespace),
+		k.client().NetworkingV1().NetworkPolicies(k.namespace),
+	}
+	for _, collection := range collections {
+		err := collection.DeleteCollection(context.TODO(), v1.DeleteOptions{
+			PropagationPolicy: constants.DefaultPropagationPolicy(),
+		}, listOpts)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting resources in namespace %q", k.namespace)
+		}
+	}
+
+	if err := resources.DeletePodDisruptionBudgets(context.TODO(), k.client(), k.namespace, listOpts); err != nil {
+		return errors.Annotatef(err, "deleting resources in namespace %q", k.namespace)
+	}
+
+	// Service API does not have `DeleteCollection` implemented, so we have to do it like this.
+	services, err := k.client().CoreV1().Services(k.namespace).List(context.TODO(), listOpts)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, svc := range services.Items {
+		if err := k.deleteService(svc.GetName()); err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utils
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// AnnotationKeyNetworks is the Multus annotation listing the network
+	// attachment definitions a pod is to be attached to.
+	AnnotationKeyNetworks = "k8s.v1.cni.cncf.io/networks"
+
+	// AnnotationKeyNetworkStatus is the Multus annotation reporting the
+	// networks a pod has been attached to.
+	AnnotationKeyNetworkStatus = "k8s.v1.cni.cncf.io/network-status"
+)
+
+// NetworkStatus describes a pod network attachment, as reported by Multus.
+type NetworkStatus struct {
+	Name      string   `json:"name"`
+	Interface string   `json:"interface,omitempty"`
+	IPs       []string `json:"ips,omitempty"`
+	Default   bool     `json:"default,omitempty"`
+}
+
+// NetworksAnnotationValue returns the value of the Multus networks
+// annotation attaching a pod to the specified network attachment definitions.
+func NetworksAnnotationValue(networks []string) string {
+	return strings.Join(networks, ",")
+}
+
+// SecondaryNetworkAddresses returns the addresses assigned to a pod on
+// the secondary networks recorded in the input pod annotations.
+// The address of the default pod network is not included.
+func SecondaryNetworkAddresses(annotations map[string]string) ([]string, error) {
+	value, ok := annotations[AnnotationKeyNetworkStatus]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	var statuses []NetworkStatus
+	if err := json.Unmarshal([]byte(value), &statuses); err != nil {
+		return nil, errors.Annotatef(err, "parsing %s annotation", AnnotationKeyNetworkStatus)
+	}
+	var addrs []string
+	for _, status := range statuses {
+		if status.Default {
+			continue
+		}
+		for _, ip := range status.IPs {
+			if net.ParseIP(ip) == nil {
+				return nil, errors.NotValidf("address %q for network %q", ip, status.Name)
+			}
+			addrs = append(addrs, ip)
+		}
+	}
+	return addrs, nil
+}
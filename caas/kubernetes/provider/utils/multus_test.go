@@ -0,0 +1,57 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utils_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/testing"
+)
+
+type multusSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&multusSuite{})
+
+func (s *multusSuite) TestNetworksAnnotationValue(c *gc.C) {
+	c.Assert(utils.NetworksAnnotationValue([]string{"storage", "backend"}), gc.Equals, "storage,backend")
+	c.Assert(utils.NetworksAnnotationValue(nil), gc.Equals, "")
+}
+
+func (s *multusSuite) TestSecondaryNetworkAddresses(c *gc.C) {
+	addrs, err := utils.SecondaryNetworkAddresses(map[string]string{
+		utils.AnnotationKeyNetworkStatus: `[{
+			"name": "k8s-pod-network",
+			"ips": ["10.1.1.5"],
+			"default": true
+		}, {
+			"name": "test/storage",
+			"interface": "net1",
+			"ips": ["192.168.1.205", "2001:db8::5"]
+		}]`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []string{"192.168.1.205", "2001:db8::5"})
+}
+
+func (s *multusSuite) TestSecondaryNetworkAddressesNoAnnotation(c *gc.C) {
+	addrs, err := utils.SecondaryNetworkAddresses(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, gc.HasLen, 0)
+}
+
+func (s *multusSuite) TestSecondaryNetworkAddressesInvalid(c *gc.C) {
+	_, err := utils.SecondaryNetworkAddresses(map[string]string{
+		utils.AnnotationKeyNetworkStatus: `[{"name": "test/storage", "ips": ["bad"]}]`,
+	})
+	c.Assert(err, gc.ErrorMatches, `address "bad" for network "test/storage" not valid`)
+
+	_, err = utils.SecondaryNetworkAddresses(map[string]string{
+		utils.AnnotationKeyNetworkStatus: `{`,
+	})
+	c.Assert(err, gc.ErrorMatches, `parsing k8s.v1.cni.cncf.io/network-status annotation: .*`)
+}
@@ -0,0 +1,284 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/constants"
+	"github.com/juju/juju/core/constraints"
+)
+
+// Placement tags are constraint tags of the form [^]<prefix><label>=<value>,
+// where the prefix selects how the tag is used to place the pods of an
+// application. "node." tags, the default with no prefix, are node affinity
+// and "pod." and "anti-pod." tags are pod affinity and anti-affinity, all
+// with "|" separated values, and negated by a leading "^". The pod
+// (anti-)affinity label "application" matches the pods of the named Juju
+// applications, and the label "topology-key" sets the topology key, which
+// defaults to the node hostname. "node-selector." tags are node selectors.
+// "toleration.<key>=<value>[:<effect>]" tags tolerate a node taint, where a
+// value of "*" tolerates any value.
+const (
+	nodePrefix         = "node."
+	nodeSelectorPrefix = "node-selector."
+	podPrefix          = "pod."
+	antiPodPrefix      = "anti-pod."
+	tolerationPrefix   = "toleration."
+
+	topologyKeyTag = "topology-key"
+	applicationTag = "application"
+
+	// DefaultTopologyKey is the topology key used for pod (anti-)affinity
+	// when no topology-key tag is specified.
+	DefaultTopologyKey = "kubernetes.io/hostname"
+
+	// ZoneLabel is the node label holding the availability zone of a node.
+	ZoneLabel = "failure-domain.beta.kubernetes.io/zone"
+)
+
+type placementTags struct {
+	nodeAffinity    map[string]string
+	podAffinity     map[string]string
+	podAntiAffinity map[string]string
+	nodeSelector    map[string]string
+	tolerations     []core.Toleration
+}
+
+// ValidatePlacementTags returns an error if any of the constraint tags
+// are not valid placement tags.
+func ValidatePlacementTags(tags []string) error {
+	_, err := parsePlacementTags(tags, false)
+	return errors.Trace(err)
+}
+
+func parsePlacementTags(tags []string, legacy bool) (*placementTags, error) {
+	result := &placementTags{
+		nodeAffinity:    make(map[string]string),
+		podAffinity:     make(map[string]string),
+		podAntiAffinity: make(map[string]string),
+		nodeSelector:    make(map[string]string),
+	}
+	for _, labelPair := range tags {
+		parts := strings.Split(labelPair, "=")
+		if len(parts) != 2 {
+			return nil, errors.NotValidf("placement tag %q", labelPair)
+		}
+		key := strings.Trim(parts[0], " ")
+		value := strings.Trim(parts[1], " ")
+		negate := strings.HasPrefix(key, "^")
+		key = strings.TrimPrefix(key, "^")
+		if key == "" {
+			return nil, errors.NotValidf("placement tag %q", labelPair)
+		}
+
+		switch {
+		case strings.HasPrefix(key, nodeSelectorPrefix):
+			label := strings.TrimPrefix(key, nodeSelectorPrefix)
+			if negate || label == "" || strings.Contains(value, "|") {
+				return nil, errors.NotValidf("node selector %q", labelPair)
+			}
+			result.nodeSelector[label] = value
+		case strings.HasPrefix(key, tolerationPrefix):
+			if negate {
+				return nil, errors.NotValidf("toleration %q", labelPair)
+			}
+			toleration, err := parseToleration(strings.TrimPrefix(key, tolerationPrefix), value)
+			if err != nil {
+				return nil, errors.Annotatef(err, "toleration %q", labelPair)
+			}
+			result.tolerations = append(result.tolerations, toleration)
+		case strings.HasPrefix(key, podPrefix), strings.HasPrefix(key, antiPodPrefix):
+			tags := result.podAffinity
+			label := strings.TrimPrefix(key, podPrefix)
+			if strings.HasPrefix(key, antiPodPrefix) {
+				tags = result.podAntiAffinity
+				label = strings.TrimPrefix(key, antiPodPrefix)
+			}
+			switch label {
+			case "":
+				return nil, errors.NotValidf("pod affinity %q", labelPair)
+			case topologyKeyTag:
+				if negate || value == "" {
+					return nil, errors.NotValidf("topology key %q", labelPair)
+				}
+			case applicationTag:
+				label = appNameLabelKey(legacy)
+			}
+			if negate {
+				label = "^" + label
+			}
+			tags[label] = value
+		default:
+			label := strings.TrimPrefix(key, nodePrefix)
+			if label == "" {
+				return nil, errors.NotValidf("node affinity %q", labelPair)
+			}
+			if negate {
+				label = "^" + label
+			}
+			result.nodeAffinity[label] = value
+		}
+	}
+	return result, nil
+}
+
+func appNameLabelKey(legacy bool) string {
+	if legacy {
+		return constants.LegacyLabelKubernetesAppName
+	}
+	return constants.LabelKubernetesAppName
+}
+
+func parseToleration(key, value string) (core.Toleration, error) {
+	var effect string
+	if i := strings.LastIndex(value, ":"); i >= 0 {
+		value, effect = value[:i], value[i+1:]
+	}
+	toleration := core.Toleration{
+		Key:      key,
+		Operator: core.TolerationOpEqual,
+		Value:    value,
+		Effect:   core.TaintEffect(effect),
+	}
+	if value == "*" {
+		toleration.Operator = core.TolerationOpExists
+		toleration.Value = ""
+	}
+	switch toleration.Effect {
+	case "", core.TaintEffectNoSchedule, core.TaintEffectPreferNoSchedule, core.TaintEffectNoExecute:
+	default:
+		return core.Toleration{}, errors.NotValidf("taint effect %q", effect)
+	}
+	if key == "" && toleration.Operator != core.TolerationOpExists {
+		return core.Toleration{}, errors.NotValidf("empty key with value %q", value)
+	}
+	return toleration, nil
+}
+
+// ApplyPlacement configures the node selector, node and pod (anti-)affinity
+// and tolerations of a pod from the tags and zones constraints.
+func ApplyPlacement(pod *core.PodSpec, cons constraints.Value, legacy bool) error {
+	tags := &placementTags{}
+	if cons.Tags != nil {
+		var err error
+		if tags, err = parsePlacementTags(*cons.Tags, legacy); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if len(tags.nodeSelector) > 0 {
+		if pod.NodeSelector == nil {
+			pod.NodeSelector = make(map[string]string)
+		}
+		for k, v := range tags.nodeSelector {
+			pod.NodeSelector[k] = v
+		}
+	}
+	pod.Tolerations = append(pod.Tolerations, tags.tolerations...)
+
+	var nodeSelectorTerm core.NodeSelectorTerm
+	for _, tag := range sortedKeys(tags.nodeAffinity) {
+		values := splitValues(tags.nodeAffinity[tag])
+		op := core.NodeSelectorOpIn
+		if strings.HasPrefix(tag, "^") {
+			tag = tag[1:]
+			op = core.NodeSelectorOpNotIn
+		}
+		nodeSelectorTerm.MatchExpressions = append(nodeSelectorTerm.MatchExpressions, core.NodeSelectorRequirement{
+			Key:      tag,
+			Operator: op,
+			Values:   values,
+		})
+	}
+	if cons.Zones != nil {
+		nodeSelectorTerm.MatchExpressions = append(nodeSelectorTerm.MatchExpressions, core.NodeSelectorRequirement{
+			Key:      ZoneLabel,
+			Operator: core.NodeSelectorOpIn,
+			Values:   *cons.Zones,
+		})
+	}
+	if len(nodeSelectorTerm.MatchExpressions) > 0 {
+		if pod.Affinity == nil {
+			pod.Affinity = &core.Affinity{}
+		}
+		pod.Affinity.NodeAffinity = &core.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
+				NodeSelectorTerms: []core.NodeSelectorTerm{nodeSelectorTerm},
+			},
+		}
+	}
+
+	if affinityTerm := podAffinityTerm(tags.podAffinity); affinityTerm != nil {
+		if pod.Affinity == nil {
+			pod.Affinity = &core.Affinity{}
+		}
+		pod.Affinity.PodAffinity = &core.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{*affinityTerm},
+		}
+	}
+	if antiAffinityTerm := podAffinityTerm(tags.podAntiAffinity); antiAffinityTerm != nil {
+		if pod.Affinity == nil {
+			pod.Affinity = &core.Affinity{}
+		}
+		pod.Affinity.PodAntiAffinity = &core.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{*antiAffinityTerm},
+		}
+	}
+	return nil
+}
+
+// podAffinityTerm returns the pod affinity term for the specified tags, or
+// nil if there are no label expressions.
+func podAffinityTerm(tags map[string]string) *core.PodAffinityTerm {
+	var labelSelector v1.LabelSelector
+	topologyKey := DefaultTopologyKey
+	for _, tag := range sortedKeys(tags) {
+		if tag == topologyKeyTag {
+			topologyKey = tags[tag]
+			continue
+		}
+		op := v1.LabelSelectorOpIn
+		values := splitValues(tags[tag])
+		if strings.HasPrefix(tag, "^") {
+			tag = tag[1:]
+			op = v1.LabelSelectorOpNotIn
+		}
+		labelSelector.MatchExpressions = append(labelSelector.MatchExpressions, v1.LabelSelectorRequirement{
+			Key:      tag,
+			Operator: op,
+			Values:   values,
+		})
+	}
+	if len(labelSelector.MatchExpressions) == 0 {
+		return nil
+	}
+	return &core.PodAffinityTerm{
+		LabelSelector: &labelSelector,
+		TopologyKey:   topologyKey,
+	}
+}
+
+func splitValues(value string) []string {
+	values := strings.Split(value, "|")
+	for i, v := range values {
+		values[i] = strings.Trim(v, " ")
+	}
+	return values
+}
+
+// sortedKeys returns the keys of the map, sorted for stable ordering.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
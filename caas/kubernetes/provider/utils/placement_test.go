@@ -0,0 +1,163 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package utils_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/utils"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/testing"
+)
+
+type placementSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&placementSuite{})
+
+func (s *placementSuite) TestApplyPlacement(c *gc.C) {
+	pod := &core.PodSpec{
+		NodeSelector: map[string]string{"kubernetes.io/arch": "amd64"},
+	}
+	cons := constraints.MustParse(
+		`tags=node.disk=ssd|nvme,^gpu=none,node-selector.pool=fast,` +
+			`pod.application=mysql,anti-pod.application=wordpress,anti-pod.topology-key=topology.kubernetes.io/zone,` +
+			`toleration.dedicated=juju:NoSchedule,toleration.spot=* zones=a,b`)
+	err := utils.ApplyPlacement(pod, cons, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod, jc.DeepEquals, &core.PodSpec{
+		NodeSelector: map[string]string{
+			"kubernetes.io/arch": "amd64",
+			"pool":               "fast",
+		},
+		Tolerations: []core.Toleration{{
+			Key:      "dedicated",
+			Operator: core.TolerationOpEqual,
+			Value:    "juju",
+			Effect:   core.TaintEffectNoSchedule,
+		}, {
+			Key:      "spot",
+			Operator: core.TolerationOpExists,
+		}},
+		Affinity: &core.Affinity{
+			NodeAffinity: &core.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
+					NodeSelectorTerms: []core.NodeSelectorTerm{{
+						MatchExpressions: []core.NodeSelectorRequirement{{
+							Key:      "gpu",
+							Operator: core.NodeSelectorOpNotIn,
+							Values:   []string{"none"},
+						}, {
+							Key:      "disk",
+							Operator: core.NodeSelectorOpIn,
+							Values:   []string{"ssd", "nvme"},
+						}, {
+							Key:      utils.ZoneLabel,
+							Operator: core.NodeSelectorOpIn,
+							Values:   []string{"a", "b"},
+						}},
+					}},
+				},
+			},
+			PodAffinity: &core.PodAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{{
+					LabelSelector: &v1.LabelSelector{
+						MatchExpressions: []v1.LabelSelectorRequirement{{
+							Key:      "app.kubernetes.io/name",
+							Operator: v1.LabelSelectorOpIn,
+							Values:   []string{"mysql"},
+						}},
+					},
+					TopologyKey: utils.DefaultTopologyKey,
+				}},
+			},
+			PodAntiAffinity: &core.PodAntiAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{{
+					LabelSelector: &v1.LabelSelector{
+						MatchExpressions: []v1.LabelSelectorRequirement{{
+							Key:      "app.kubernetes.io/name",
+							Operator: v1.LabelSelectorOpIn,
+							Values:   []string{"wordpress"},
+						}},
+					},
+					TopologyKey: "topology.kubernetes.io/zone",
+				}},
+			},
+		},
+	})
+}
+
+func (s *placementSuite) TestApplyPlacementLegacyLabels(c *gc.C) {
+	pod := &core.PodSpec{}
+	err := utils.ApplyPlacement(pod, constraints.MustParse("tags=^anti-pod.application=mysql"), true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Affinity, jc.DeepEquals, &core.Affinity{
+		PodAntiAffinity: &core.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{{
+				LabelSelector: &v1.LabelSelector{
+					MatchExpressions: []v1.LabelSelectorRequirement{{
+						Key:      "juju-app",
+						Operator: v1.LabelSelectorOpNotIn,
+						Values:   []string{"mysql"},
+					}},
+				},
+				TopologyKey: utils.DefaultTopologyKey,
+			}},
+		},
+	})
+}
+
+func (s *placementSuite) TestApplyPlacementNoConstraints(c *gc.C) {
+	pod := &core.PodSpec{}
+	err := utils.ApplyPlacement(pod, constraints.MustParse("mem=1G"), false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod, jc.DeepEquals, &core.PodSpec{})
+}
+
+func (s *placementSuite) TestValidatePlacementTags(c *gc.C) {
+	for i, test := range []struct {
+		tag string
+		err string
+	}{{
+		tag: "foo",
+		err: `placement tag "foo" not valid`,
+	}, {
+		tag: "^=bar",
+		err: `placement tag "\^=bar" not valid`,
+	}, {
+		tag: "node.=bar",
+		err: `node affinity "node.=bar" not valid`,
+	}, {
+		tag: "pod.=bar",
+		err: `pod affinity "pod.=bar" not valid`,
+	}, {
+		tag: "^pod.topology-key=zone",
+		err: `topology key "\^pod.topology-key=zone" not valid`,
+	}, {
+		tag: "^node-selector.pool=fast",
+		err: `node selector "\^node-selector.pool=fast" not valid`,
+	}, {
+		tag: "node-selector.pool=fast|slow",
+		err: `node selector "node-selector.pool=fast\|slow" not valid`,
+	}, {
+		tag: "^toleration.dedicated=juju",
+		err: `toleration "\^toleration.dedicated=juju" not valid`,
+	}, {
+		tag: "toleration.dedicated=juju:NoWay",
+		err: `toleration "toleration.dedicated=juju:NoWay": taint effect "NoWay" not valid`,
+	}, {
+		tag: "toleration.=juju",
+		err: `toleration "toleration.=juju": empty key with value "juju" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.tag)
+		c.Check(utils.ValidatePlacementTags([]string{test.tag}), gc.ErrorMatches, test.err)
+	}
+	c.Assert(utils.ValidatePlacementTags([]string{
+		"foo=bar", "^node.foo=baz", "pod.application=mysql", "toleration.=*:NoExecute",
+	}), jc.ErrorIsNil)
+}
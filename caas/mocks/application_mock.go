@@ -163,6 +163,21 @@ func (mr *MockApplicationMockRecorder) UpdateService(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateService", reflect.TypeOf((*MockApplication)(nil).UpdateService), arg0)
 }
 
+// Utilisation mocks base method
+func (m *MockApplication) Utilisation() (caas.ApplicationUtilisation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Utilisation")
+	ret0, _ := ret[0].(caas.ApplicationUtilisation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Utilisation indicates an expected call of Utilisation
+func (mr *MockApplicationMockRecorder) Utilisation() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Utilisation", reflect.TypeOf((*MockApplication)(nil).Utilisation))
+}
+
 // Watch mocks base method
 func (m *MockApplication) Watch() (watcher.NotifyWatcher, error) {
 	m.ctrl.T.Helper()
@@ -10,6 +10,7 @@ import (
 	application "github.com/juju/juju/core/application"
 	constraints "github.com/juju/juju/core/constraints"
 	watcher "github.com/juju/juju/core/watcher"
+	docker "github.com/juju/juju/docker"
 	environs "github.com/juju/juju/environs"
 	config "github.com/juju/juju/environs/config"
 	context "github.com/juju/juju/environs/context"
@@ -227,6 +228,20 @@ func (mr *MockBrokerMockRecorder) DestroyController(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DestroyController", reflect.TypeOf((*MockBroker)(nil).DestroyController), arg0, arg1)
 }
 
+// EnsureImageRepoSecret mocks base method
+func (m *MockBroker) EnsureImageRepoSecret(arg0 docker.ImageRepoDetails) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureImageRepoSecret", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureImageRepoSecret indicates an expected call of EnsureImageRepoSecret
+func (mr *MockBrokerMockRecorder) EnsureImageRepoSecret(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureImageRepoSecret", reflect.TypeOf((*MockBroker)(nil).EnsureImageRepoSecret), arg0)
+}
+
 // EnsureModelOperator mocks base method
 func (m *MockBroker) EnsureModelOperator(arg0, arg1 string, arg2 *caas.ModelOperatorConfig) error {
 	m.ctrl.T.Helper()
@@ -255,6 +270,20 @@ func (mr *MockBrokerMockRecorder) EnsureOperator(arg0, arg1, arg2 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureOperator", reflect.TypeOf((*MockBroker)(nil).EnsureOperator), arg0, arg1, arg2)
 }
 
+// EnsureNetworkPolicy mocks base method
+func (m *MockBroker) EnsureNetworkPolicy(arg0 string, arg1 caas.NetworkPolicyParams, arg2 application.ConfigAttributes) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureNetworkPolicy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureNetworkPolicy indicates an expected call of EnsureNetworkPolicy
+func (mr *MockBrokerMockRecorder) EnsureNetworkPolicy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureNetworkPolicy", reflect.TypeOf((*MockBroker)(nil).EnsureNetworkPolicy), arg0, arg1, arg2)
+}
+
 // EnsureService mocks base method
 func (m *MockBroker) EnsureService(arg0 string, arg1 caas.StatusCallbackFunc, arg2 *caas.ServiceParams, arg3 int, arg4 application.ConfigAttributes) error {
 	m.ctrl.T.Helper()
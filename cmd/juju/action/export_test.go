@@ -178,3 +178,14 @@ func NewListOperationsCommandForTest(store jujuclient.ClientStore) (cmd.Command,
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &ListOperationsCommand{c}
 }
+
+type NetworkCheckStatusAPI = networkCheckStatusAPI
+
+func NewNetworkCheckCommandForTest(store jujuclient.ClientStore, clock clock.Clock, statusAPI NetworkCheckStatusAPI) cmd.Command {
+	c := &networkCheckCommand{
+		statusAPI: statusAPI,
+		clock:     clock,
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
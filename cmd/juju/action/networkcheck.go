@@ -0,0 +1,373 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"github.com/juju/naturalsort"
+
+	actionapi "github.com/juju/juju/api/action"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/model"
+)
+
+// NewNetworkCheckCommand returns a command that checks connectivity
+// between related units across the machines in a model.
+func NewNetworkCheckCommand() cmd.Command {
+	return modelcmd.Wrap(&networkCheckCommand{
+		clock: clock.WallClock,
+	})
+}
+
+// networkCheckStatusAPI describes the API used to determine
+// the machines in the model.
+type networkCheckStatusAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
+// networkCheckCommand asks machine agents to probe the addresses of
+// related units on their bound spaces, and reports the results.
+type networkCheckCommand struct {
+	ActionCommandBase
+	api       APIClient
+	statusAPI networkCheckStatusAPI
+	out       cmd.Output
+	clock     clock.Clock
+
+	machines []string
+	port     int
+	timeout  time.Duration
+	wait     time.Duration
+}
+
+const networkCheckDoc = `
+Check network connectivity between related units in the model.
+
+Each targeted machine agent attempts a TCP connection to the address of
+every unit related to the units it hosts, using the space to which the
+relation endpoint is bound. A target is reported as reachable if the
+connection succeeds or is actively refused, as either indicates that the
+target host responded.
+
+If no machines are specified, all machines in the model are checked.
+
+The command exits with an error if any target could not be reached,
+making it suitable for use in automated smoke tests.
+
+Examples:
+    juju network-check
+    juju network-check 0 1
+    juju network-check --port 80 --timeout 2s
+    juju network-check --format yaml
+
+See also:
+    spaces
+    show-space
+`
+
+// Info implements Command.Info.
+func (c *networkCheckCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "network-check",
+		Args:    "[<machine id> ...]",
+		Purpose: "Check connectivity between related units on their bound spaces.",
+		Doc:     networkCheckDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *networkCheckCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatNetworkCheckTabular,
+	})
+	f.IntVar(&c.port, "port", 22, "TCP port to probe on each target address")
+	f.DurationVar(&c.timeout, "timeout", 5*time.Second, "Maximum time allowed for each probe")
+	f.DurationVar(&c.wait, "wait", time.Minute, "Maximum wait time for the checks to complete")
+}
+
+// Init implements Command.Init.
+func (c *networkCheckCommand) Init(args []string) error {
+	var nameErrors []string
+	for _, machineId := range args {
+		if !names.IsValidMachine(machineId) {
+			nameErrors = append(nameErrors, fmt.Sprintf("  %q is not a valid machine id", machineId))
+		}
+	}
+	if len(nameErrors) > 0 {
+		return errors.Errorf("The following network check targets are not valid:\n%s",
+			strings.Join(nameErrors, "\n"))
+	}
+	if c.port < 1 || c.port > 65535 {
+		return errors.NotValidf("port %d", c.port)
+	}
+	if c.timeout <= 0 {
+		return errors.NotValidf("timeout %v", c.timeout)
+	}
+	if c.wait <= 0 {
+		return errors.NotValidf("wait %v", c.wait)
+	}
+	c.machines = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *networkCheckCommand) Run(ctx *cmd.Context) error {
+	modelType, err := c.ModelType()
+	if err != nil {
+		return errors.Annotatef(err, "unable to get model type")
+	}
+	if modelType == model.CAAS {
+		return errors.Errorf("network-check is not supported on k8s models")
+	}
+
+	machines := c.machines
+	if len(machines) == 0 {
+		if machines, err = c.modelMachines(); err != nil {
+			return errors.Trace(err)
+		}
+		if len(machines) == 0 {
+			return errors.New("no machines to check")
+		}
+	}
+
+	if c.api == nil {
+		if c.api, err = c.NewActionAPIClient(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	defer c.api.Close()
+
+	actionParams := map[string]interface{}{
+		"port":    c.port,
+		"timeout": c.timeout.Nanoseconds(),
+	}
+	toEnqueue := make([]actionapi.Action, len(machines))
+	for i, machineId := range machines {
+		toEnqueue[i] = actionapi.Action{
+			Receiver:   names.NewMachineTag(machineId).String(),
+			Name:       actions.JujuNetworkCheckActionName,
+			Parameters: actionParams,
+		}
+	}
+	enqueued, err := c.api.EnqueueOperation(toEnqueue)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Running network check operation %s on %d machine(s)", enqueued.OperationID, len(enqueued.Actions))
+
+	wait := c.clock.NewTimer(c.wait)
+	results := make(map[string]networkCheckMachineResult, len(enqueued.Actions))
+	for _, a := range enqueued.Actions {
+		if a.Error != nil {
+			return errors.Trace(a.Error)
+		}
+		if a.Action == nil {
+			return errors.New("network check failed to enqueue")
+		}
+		machineId := machineIdFromReceiver(a.Action.Receiver)
+		tick := c.clock.NewTimer(resultPollTime)
+		actionResult, err := GetActionResult(c.api, a.Action.ID, tick, wait)
+		if errors.IsTimeout(err) {
+			results[machineId] = networkCheckMachineResult{
+				Status:  params.ActionPending,
+				Message: fmt.Sprintf("timed out waiting for task %s", a.Action.ID),
+			}
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		results[machineId] = newNetworkCheckMachineResult(actionResult)
+	}
+
+	if err := c.out.Write(ctx, results); err != nil {
+		return errors.Trace(err)
+	}
+
+	var failed, total int
+	for _, r := range results {
+		if r.Status != params.ActionCompleted {
+			failed++
+			total++
+			continue
+		}
+		for _, p := range r.Probes {
+			total++
+			if !p.Reachable {
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d network checks failed", failed, total)
+	}
+	return nil
+}
+
+// modelMachines returns the IDs of all machines and containers
+// in the model.
+func (c *networkCheckCommand) modelMachines() ([]string, error) {
+	if c.statusAPI == nil {
+		client, err := c.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		c.statusAPI = client
+	}
+	defer c.statusAPI.Close()
+
+	status, err := c.statusAPI.Status(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var machines []string
+	var addMachines func(map[string]params.MachineStatus)
+	addMachines = func(ms map[string]params.MachineStatus) {
+		for id, m := range ms {
+			machines = append(machines, id)
+			addMachines(m.Containers)
+		}
+	}
+	addMachines(status.Machines)
+	naturalsort.Sort(machines)
+	return machines, nil
+}
+
+func machineIdFromReceiver(receiver string) string {
+	tag, err := names.ParseMachineTag(receiver)
+	if err != nil {
+		return receiver
+	}
+	return tag.Id()
+}
+
+// networkCheckMachineResult holds the outcome of a network
+// check run by a single machine agent.
+type networkCheckMachineResult struct {
+	Status  string              `yaml:"status" json:"status"`
+	Message string              `yaml:"message,omitempty" json:"message,omitempty"`
+	Probes  []networkCheckProbe `yaml:"probes,omitempty" json:"probes,omitempty"`
+}
+
+// networkCheckProbe holds the outcome of a single probe
+// from a unit to the address of a related unit.
+type networkCheckProbe struct {
+	Unit        string `yaml:"unit" json:"unit"`
+	Endpoint    string `yaml:"endpoint" json:"endpoint"`
+	RelatedUnit string `yaml:"related-unit" json:"related-unit"`
+	Space       string `yaml:"space" json:"space"`
+	Address     string `yaml:"address" json:"address"`
+	Port        int    `yaml:"port" json:"port"`
+	Reachable   bool   `yaml:"reachable" json:"reachable"`
+	Latency     string `yaml:"latency,omitempty" json:"latency,omitempty"`
+	Error       string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+func newNetworkCheckMachineResult(result actionapi.ActionResult) networkCheckMachineResult {
+	out := networkCheckMachineResult{
+		Status:  result.Status,
+		Message: result.Message,
+	}
+	if result.Error != nil && out.Message == "" {
+		out.Message = result.Error.Error()
+	}
+	probes, _ := result.Output["probes"].([]interface{})
+	for _, p := range probes {
+		probe, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		out.Probes = append(out.Probes, networkCheckProbe{
+			Unit:        stringValue(probe["unit"]),
+			Endpoint:    stringValue(probe["endpoint"]),
+			RelatedUnit: stringValue(probe["related-unit"]),
+			Space:       stringValue(probe["space"]),
+			Address:     stringValue(probe["address"]),
+			Port:        intValue(probe["port"]),
+			Reachable:   probe["reachable"] == true,
+			Latency:     stringValue(probe["latency"]),
+			Error:       stringValue(probe["error"]),
+		})
+	}
+	return out
+}
+
+func stringValue(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// intValue returns the integer represented by the input value,
+// which may have been serialized as a float.
+func intValue(v interface{}) int {
+	switch i := v.(type) {
+	case int:
+		return i
+	case int64:
+		return int(i)
+	case float64:
+		return int(i)
+	}
+	return 0
+}
+
+func formatNetworkCheckTabular(writer io.Writer, value interface{}) error {
+	results, ok := value.(map[string]networkCheckMachineResult)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", results, value)
+	}
+
+	machines := make([]string, 0, len(results))
+	for id := range results {
+		machines = append(machines, id)
+	}
+	naturalsort.Sort(machines)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Machine", "Unit", "Endpoint", "Related unit", "Space", "Address", "Result", "Latency")
+	for _, id := range machines {
+		r := results[id]
+		if r.Status != params.ActionCompleted {
+			w.Print(id, "", "", "", "", "")
+			w.PrintColor(output.ErrorHighlight, r.Status)
+			w.Println(r.Message)
+			continue
+		}
+		if len(r.Probes) == 0 {
+			w.Println(id, "", "", "", "", "", "no targets", "")
+			continue
+		}
+		for _, p := range r.Probes {
+			w.Print(id, p.Unit, p.Endpoint, p.RelatedUnit, p.Space, fmt.Sprintf("%s:%d", p.Address, p.Port))
+			if p.Reachable {
+				w.PrintColor(output.GoodHighlight, "ok")
+				w.Println(p.Latency)
+			} else {
+				w.PrintColor(output.ErrorHighlight, "failed")
+				w.Println(p.Error)
+			}
+		}
+	}
+	return tw.Flush()
+}
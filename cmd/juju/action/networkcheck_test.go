@@ -0,0 +1,181 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	actionapi "github.com/juju/juju/api/action"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/core/model"
+)
+
+type NetworkCheckSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&NetworkCheckSuite{})
+
+type fakeNetworkCheckStatusAPI struct {
+	status *params.FullStatus
+}
+
+func (f *fakeNetworkCheckStatusAPI) Status(_ []string) (*params.FullStatus, error) {
+	return f.status, nil
+}
+
+func (f *fakeNetworkCheckStatusAPI) Close() error {
+	return nil
+}
+
+func networkCheckResult(id, machineId string, probes ...interface{}) actionapi.ActionResult {
+	return actionapi.ActionResult{
+		Action: &actionapi.Action{
+			ID:       id,
+			Receiver: "machine-" + machineId,
+		},
+		Status: params.ActionCompleted,
+		Output: map[string]interface{}{"probes": probes},
+	}
+}
+
+var (
+	reachableProbe = map[string]interface{}{
+		"unit":         "mysql/0",
+		"endpoint":     "db",
+		"related-unit": "wordpress/0",
+		"space":        "alpha",
+		"address":      "10.0.0.2",
+		"port":         float64(22),
+		"reachable":    true,
+		"latency":      "1.2ms",
+	}
+	unreachableProbe = map[string]interface{}{
+		"unit":         "wordpress/0",
+		"endpoint":     "db",
+		"related-unit": "mysql/0",
+		"space":        "alpha",
+		"address":      "10.0.0.1",
+		"port":         float64(22),
+		"reachable":    false,
+		"error":        "i/o timeout",
+	}
+)
+
+func (s *NetworkCheckSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args     []string
+		errMatch string
+	}{{
+		args: []string{},
+	}, {
+		args: []string{"0", "1/lxd/0"},
+	}, {
+		args:     []string{"foo"},
+		errMatch: `(?s)The following network check targets are not valid:\n  "foo" is not a valid machine id`,
+	}, {
+		args:     []string{"--port", "0"},
+		errMatch: "port 0 not valid",
+	}, {
+		args:     []string{"--timeout", "0s"},
+		errMatch: "timeout 0s not valid",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		cmd := action.NewNetworkCheckCommandForTest(minimalStore(model.IAAS), testClock(), nil)
+		err := cmdtesting.InitCommand(cmd, test.args)
+		if test.errMatch == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errMatch)
+		}
+	}
+}
+
+func (s *NetworkCheckSuite) TestNetworkCheckMachines(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []actionapi.ActionResult{
+			networkCheckResult(validActionId, "0", reachableProbe),
+		},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	cmd := action.NewNetworkCheckCommandForTest(minimalStore(model.IAAS), testClock(), nil)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "0", "--port", "8080", "--timeout", "2s")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(fakeClient.enqueuedActions, jc.DeepEquals, []actionapi.Action{{
+		Receiver: "machine-0",
+		Name:     "juju-network-check",
+		Parameters: map[string]interface{}{
+			"port":    8080,
+			"timeout": int64(2000000000),
+		},
+	}})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Machine  Unit     Endpoint  Related unit  Space  Address      Result  Latency
+0        mysql/0  db        wordpress/0   alpha  10.0.0.2:22  ok      1.2ms
+
+`[1:])
+}
+
+func (s *NetworkCheckSuite) TestNetworkCheckAllMachinesWithFailure(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []actionapi.ActionResult{
+			networkCheckResult(validActionId, "0", reachableProbe),
+			networkCheckResult(validActionId2, "1", unreachableProbe),
+		},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	statusAPI := &fakeNetworkCheckStatusAPI{
+		status: &params.FullStatus{
+			Machines: map[string]params.MachineStatus{
+				"0": {},
+				"1": {},
+			},
+		},
+	}
+	cmd := action.NewNetworkCheckCommandForTest(minimalStore(model.IAAS), testClock(), statusAPI)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--format", "yaml")
+	c.Assert(err, gc.ErrorMatches, "1 of 2 network checks failed")
+
+	c.Assert(fakeClient.enqueuedActions, gc.HasLen, 2)
+	c.Check(fakeClient.enqueuedActions[0].Receiver, gc.Equals, "machine-0")
+	c.Check(fakeClient.enqueuedActions[1].Receiver, gc.Equals, "machine-1")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"0":
+  status: completed
+  probes:
+  - unit: mysql/0
+    endpoint: db
+    related-unit: wordpress/0
+    space: alpha
+    address: 10.0.0.2
+    port: 22
+    reachable: true
+    latency: 1.2ms
+"1":
+  status: completed
+  probes:
+  - unit: wordpress/0
+    endpoint: db
+    related-unit: mysql/0
+    space: alpha
+    address: 10.0.0.1
+    port: 22
+    reachable: false
+    error: i/o timeout
+`[1:])
+}
+
+func (s *NetworkCheckSuite) TestNetworkCheckCAAS(c *gc.C) {
+	cmd := action.NewNetworkCheckCommandForTest(minimalStore(model.CAAS), testClock(), nil)
+	_, err := cmdtesting.RunCommand(c, cmd)
+	c.Assert(err, gc.ErrorMatches, "network-check is not supported on k8s models")
+}
@@ -0,0 +1,217 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	coreapplication "github.com/juju/juju/core/application"
+)
+
+// NewAutoscaleApplicationCommand returns a command which sets or shows
+// an application's autoscaling policy.
+func NewAutoscaleApplicationCommand() modelcmd.ModelCommand {
+	cmd := &autoscaleApplicationCommand{}
+	cmd.newAPIFunc = func() (autoscaleApplicationAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// autoscaleApplicationCommand sets, clears or shows the policy used to
+// scale a k8s application automatically.
+type autoscaleApplicationCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.CAASOnlyCommand
+
+	newAPIFunc      func() (autoscaleApplicationAPI, error)
+	out             cmd.Output
+	applicationName string
+
+	minUnits     int
+	maxUnits     int
+	targetCPU    int
+	targetMemory int
+	metric       string
+	reset        bool
+
+	policy *coreapplication.AutoscalePolicy
+}
+
+const autoscaleApplicationDoc = `
+Scale a k8s application automatically between a minimum and maximum
+number of units, aiming to keep the average usage of its units near
+one or more targets.
+
+CPU and memory targets are percentages of the resources requested by
+the units' pods, and require a metrics server in the cluster. A metric
+target is the average value of a metric reported by the charm's units.
+When several targets are given, the application is scaled to the largest
+number of units needed to meet any of them.
+
+Units are added and removed through Juju, so they are set up and torn
+down with the usual hooks. Scaling down waits until the lower scale has
+been recommended for several minutes, to avoid removing units for brief
+dips in usage. While the application is autoscaled, a scale set with
+scale-application is adjusted at the next check to meet the policy.
+
+With no options, the current policy is shown. Use --reset to stop the
+application being autoscaled; it remains at its current scale.
+
+Examples:
+
+    juju autoscale-application mariadb --min 2 --max 10 --cpu 70
+    juju autoscale-application rabbitmq --max 8 --metric queue-length=100
+    juju autoscale-application mariadb
+    juju autoscale-application mariadb --reset
+
+See also:
+    scale-application
+`
+
+// Info implements cmd.Command.
+func (c *autoscaleApplicationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "autoscale-application",
+		Args:    "<application>",
+		Purpose: "Set or show the policy used to scale an application automatically.",
+		Doc:     autoscaleApplicationDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *autoscaleApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters.Formatters())
+	f.IntVar(&c.minUnits, "min", 0, "Minimum number of units")
+	f.IntVar(&c.maxUnits, "max", 0, "Maximum number of units")
+	f.IntVar(&c.targetCPU, "cpu", 0, "Target average CPU usage, as a percentage of the requested CPU")
+	f.IntVar(&c.targetMemory, "memory", 0, "Target average memory usage, as a percentage of the requested memory")
+	f.StringVar(&c.metric, "metric", "", "Target average value of a charm metric, as <name>=<value>")
+	f.BoolVar(&c.reset, "reset", false, "Stop autoscaling the application")
+}
+
+// Init implements cmd.Command.
+func (c *autoscaleApplicationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no application specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+
+	setting := c.minUnits != 0 || c.maxUnits != 0 || c.targetCPU != 0 || c.targetMemory != 0 || c.metric != ""
+	if c.reset {
+		if setting {
+			return errors.New("cannot specify --reset with a policy")
+		}
+		return nil
+	}
+	if !setting {
+		return nil
+	}
+	if c.maxUnits == 0 {
+		return errors.New("--max must be specified")
+	}
+	policy := &coreapplication.AutoscalePolicy{
+		MinUnits:     c.minUnits,
+		MaxUnits:     c.maxUnits,
+		TargetCPU:    c.targetCPU,
+		TargetMemory: c.targetMemory,
+	}
+	if c.metric != "" {
+		parts := strings.SplitN(c.metric, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("invalid metric target %q, expected <name>=<value>", c.metric)
+		}
+		value, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return errors.Errorf("invalid metric target %q, expected <name>=<value>", c.metric)
+		}
+		policy.Metric = parts[0]
+		policy.TargetMetric = value
+	}
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	c.policy = policy
+	return nil
+}
+
+type autoscaleApplicationAPI interface {
+	Close() error
+	SetAutoscalePolicy(string, *coreapplication.AutoscalePolicy) error
+	AutoscalePolicy(string) (*coreapplication.AutoscalePolicy, error)
+}
+
+// autoscalePolicy holds an autoscaling policy for output.
+type autoscalePolicy struct {
+	MinUnits     int     `yaml:"min-units" json:"min-units"`
+	MaxUnits     int     `yaml:"max-units" json:"max-units"`
+	TargetCPU    int     `yaml:"target-cpu,omitempty" json:"target-cpu,omitempty"`
+	TargetMemory int     `yaml:"target-memory,omitempty" json:"target-memory,omitempty"`
+	Metric       string  `yaml:"metric,omitempty" json:"metric,omitempty"`
+	TargetMetric float64 `yaml:"target-metric,omitempty" json:"target-metric,omitempty"`
+}
+
+// Run implements cmd.Command.
+func (c *autoscaleApplicationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.policy == nil && !c.reset {
+		policy, err := client.AutoscalePolicy(c.applicationName)
+		if errors.IsNotSupported(err) {
+			return errors.New("autoscaling applications is not supported by this controller")
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if policy == nil {
+			ctx.Infof("application %q is not autoscaled", c.applicationName)
+			return nil
+		}
+		return c.out.Write(ctx, autoscalePolicy{
+			MinUnits:     policy.MinUnits,
+			MaxUnits:     policy.MaxUnits,
+			TargetCPU:    policy.TargetCPU,
+			TargetMemory: policy.TargetMemory,
+			Metric:       policy.Metric,
+			TargetMetric: policy.TargetMetric,
+		})
+	}
+
+	err = client.SetAutoscalePolicy(c.applicationName, c.policy)
+	if errors.IsNotSupported(err) {
+		return errors.New("autoscaling applications is not supported by this controller")
+	} else if err != nil {
+		return block.ProcessBlockedError(errors.Annotatef(err, "could not autoscale application %q", c.applicationName), block.BlockChange)
+	}
+	if c.reset {
+		ctx.Infof("%v is no longer autoscaled", c.applicationName)
+	} else {
+		ctx.Infof("%v autoscaled between %d and %d units", c.applicationName, c.policy.MinUnits, c.policy.MaxUnits)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type AutoscaleApplicationSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockAutoscaleApplicationAPI
+}
+
+var _ = gc.Suite(&AutoscaleApplicationSuite{})
+
+type mockAutoscaleApplicationAPI struct {
+	*testing.Stub
+	policy *coreapplication.AutoscalePolicy
+}
+
+func (s *mockAutoscaleApplicationAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockAutoscaleApplicationAPI) SetAutoscalePolicy(name string, policy *coreapplication.AutoscalePolicy) error {
+	s.MethodCall(s, "SetAutoscalePolicy", name, policy)
+	return s.NextErr()
+}
+
+func (s *mockAutoscaleApplicationAPI) AutoscalePolicy(name string) (*coreapplication.AutoscalePolicy, error) {
+	s.MethodCall(s, "AutoscalePolicy", name)
+	return s.policy, s.NextErr()
+}
+
+func (s *AutoscaleApplicationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockAutoscaleApplicationAPI{Stub: &testing.Stub{}}
+}
+
+func (s *AutoscaleApplicationSuite) runAutoscaleApplication(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: model.CAAS,
+		}},
+	}
+	return cmdtesting.RunCommand(c, NewAutoscaleCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *AutoscaleApplicationSuite) TestSetPolicy(c *gc.C) {
+	ctx, err := s.runAutoscaleApplication(c, "foo", "--min", "2", "--max", "10", "--cpu", "70", "--metric", "queue-length=100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.TrimSpace(cmdtesting.Stderr(ctx)), gc.Equals, "foo autoscaled between 2 and 10 units")
+	s.mockAPI.CheckCall(c, 0, "SetAutoscalePolicy", "foo", &coreapplication.AutoscalePolicy{
+		MinUnits:     2,
+		MaxUnits:     10,
+		TargetCPU:    70,
+		Metric:       "queue-length",
+		TargetMetric: 100,
+	})
+}
+
+func (s *AutoscaleApplicationSuite) TestReset(c *gc.C) {
+	ctx, err := s.runAutoscaleApplication(c, "foo", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.TrimSpace(cmdtesting.Stderr(ctx)), gc.Equals, "foo is no longer autoscaled")
+	s.mockAPI.CheckCall(c, 0, "SetAutoscalePolicy", "foo", (*coreapplication.AutoscalePolicy)(nil))
+}
+
+func (s *AutoscaleApplicationSuite) TestShowPolicy(c *gc.C) {
+	s.mockAPI.policy = &coreapplication.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetMemory: 80}
+	ctx, err := s.runAutoscaleApplication(c, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+min-units: 1
+max-units: 5
+target-memory: 80
+`[1:])
+	s.mockAPI.CheckCallNames(c, "AutoscalePolicy", "Close")
+}
+
+func (s *AutoscaleApplicationSuite) TestShowNoPolicy(c *gc.C) {
+	ctx, err := s.runAutoscaleApplication(c, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(strings.TrimSpace(cmdtesting.Stderr(ctx)), gc.Equals, `application "foo" is not autoscaled`)
+}
+
+func (s *AutoscaleApplicationSuite) TestBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(&params.Error{Code: params.CodeOperationBlocked, Message: "nope"})
+	_, err := s.runAutoscaleApplication(c, "foo", "--max", "3", "--cpu", "50")
+	c.Assert(err.Error(), jc.Contains, `could not autoscale application "foo": nope`)
+	c.Assert(err.Error(), jc.Contains, `All operations that change model have been disabled for the current model.`)
+}
+
+func (s *AutoscaleApplicationSuite) TestNotSupported(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotSupportedf("autoscaling applications"))
+	_, err := s.runAutoscaleApplication(c, "foo", "--max", "3", "--cpu", "50")
+	c.Assert(err, gc.ErrorMatches, "autoscaling applications is not supported by this controller")
+}
+
+func (s *AutoscaleApplicationSuite) TestWrongModel(c *gc.C) {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, NewAutoscaleCommandForTest(s.mockAPI, store), "foo")
+	c.Assert(err, gc.ErrorMatches, `Juju command "autoscale-application" not supported on non-container models`)
+}
+
+func (s *AutoscaleApplicationSuite) TestInvalidArgs(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application specified",
+	}, {
+		args: []string{"foo/0"},
+		err:  `invalid application name "foo/0"`,
+	}, {
+		args: []string{"foo", "bar"},
+		err:  `unrecognized args: \["bar"\]`,
+	}, {
+		args: []string{"foo", "--reset", "--max", "3"},
+		err:  "cannot specify --reset with a policy",
+	}, {
+		args: []string{"foo", "--cpu", "50"},
+		err:  "--max must be specified",
+	}, {
+		args: []string{"foo", "--max", "3"},
+		err:  "autoscale policy without a target not valid",
+	}, {
+		args: []string{"foo", "--min", "4", "--max", "3", "--cpu", "50"},
+		err:  "maximum units 3 with minimum units 4 not valid",
+	}, {
+		args: []string{"foo", "--max", "3", "--metric", "queue-length"},
+		err:  `invalid metric target "queue-length", expected <name>=<value>`,
+	}, {
+		args: []string{"foo", "--max", "3", "--metric", "queue-length=lots"},
+		err:  `invalid metric target "queue-length=lots", expected <name>=<value>`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runAutoscaleApplication(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
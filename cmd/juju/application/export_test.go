@@ -205,6 +205,16 @@ func NewScaleCommandForTest(api scaleApplicationAPI, store jujuclient.ClientStor
 	return modelcmd.Wrap(cmd)
 }
 
+// NewAutoscaleCommandForTest returns an autoscale-application command
+// with the api provided as specified.
+func NewAutoscaleCommandForTest(api autoscaleApplicationAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &autoscaleApplicationCommand{newAPIFunc: func() (autoscaleApplicationAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewDiffBundleCommandForTest(api base.APICallCloser,
 	charmStoreFn func(base.APICallCloser, *charm.URL) (BundleResolver, error),
 	modelConsFn func() (ModelConstraintsClient, error),
@@ -45,6 +45,9 @@ Scale a k8s application by specifying how many units there should be.
 The new number of units can be greater or less than the current number, thus
 allowing both scale up and scale down.
 
+An application scaled automatically with autoscale-application can't be
+scaled manually until its autoscaling is stopped with --reset.
+
 Examples:
 
     juju scale-application mariadb 2
@@ -13,6 +13,7 @@ import (
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/constraints"
@@ -170,15 +171,16 @@ func formatApplicationInfos(all []params.ApplicationResult) (map[string]Applicat
 
 // ApplicationInfo defines the serialization behaviour of the application information.
 type ApplicationInfo struct {
-	Charm            string                     `yaml:"charm,omitempty" json:"charm,omitempty"`
-	Series           string                     `yaml:"series,omitempty" json:"series,omitempty"`
-	Channel          string                     `yaml:"channel,omitempty" json:"channel,omitempty"`
-	Constraints      constraints.Value          `yaml:"constraints,omitempty" json:"constraints,omitempty"`
-	Principal        bool                       `yaml:"principal" json:"principal"`
-	Exposed          bool                       `yaml:"exposed" json:"exposed"`
-	ExposedEndpoints map[string]ExposedEndpoint `yaml:"exposed-endpoints,omitempty" json:"exposed-endpoints,omitempty"`
-	Remote           bool                       `yaml:"remote" json:"remote"`
-	EndpointBindings map[string]string          `yaml:"endpoint-bindings,omitempty" json:"endpoint-bindings,omitempty"`
+	Charm              string                     `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series             string                     `yaml:"series,omitempty" json:"series,omitempty"`
+	Channel            string                     `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Constraints        constraints.Value          `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Principal          bool                       `yaml:"principal" json:"principal"`
+	Exposed            bool                       `yaml:"exposed" json:"exposed"`
+	ExposedEndpoints   map[string]ExposedEndpoint `yaml:"exposed-endpoints,omitempty" json:"exposed-endpoints,omitempty"`
+	Remote             bool                       `yaml:"remote" json:"remote"`
+	EndpointBindings   map[string]string          `yaml:"endpoint-bindings,omitempty" json:"endpoint-bindings,omitempty"`
+	ContainerResources map[string]string          `yaml:"container-resources,omitempty" json:"container-resources,omitempty"`
 }
 
 // ExposedEndpoint defines the serialization behavior of the expose settings
@@ -206,16 +208,25 @@ func createApplicationInfo(details params.ApplicationResult) (names.ApplicationT
 
 	}
 
+	var containerResources map[string]string
+	if len(details.ContainerResources) != 0 {
+		containerResources = make(map[string]string, len(details.ContainerResources))
+		for name, res := range details.ContainerResources {
+			containerResources[name] = caas.ContainerResources(res).String()
+		}
+	}
+
 	info := ApplicationInfo{
-		Charm:            details.Charm,
-		Series:           details.Series,
-		Channel:          details.Channel,
-		Constraints:      details.Constraints,
-		Principal:        details.Principal,
-		Exposed:          details.Exposed,
-		ExposedEndpoints: exposedEndpoints,
-		Remote:           details.Remote,
-		EndpointBindings: details.EndpointBindings,
+		Charm:              details.Charm,
+		Series:             details.Series,
+		Channel:            details.Channel,
+		Constraints:        details.Constraints,
+		Principal:          details.Principal,
+		Exposed:            details.Exposed,
+		ExposedEndpoints:   exposedEndpoints,
+		Remote:             details.Remote,
+		EndpointBindings:   details.EndpointBindings,
+		ContainerResources: containerResources,
 	}
 	return tag, info, nil
 }
@@ -192,6 +192,37 @@ wordpress:
 	})
 }
 
+func (s *ShowSuite) TestShowContainerResources(c *gc.C) {
+	memRequest := uint64(256)
+	cpuLimit := uint64(200)
+	app := s.createTestApplicationInfo("gitlab", "")
+	app.Constraints = constraints.Value{}
+	app.ContainerResources = map[string]params.CAASContainerResources{
+		"charm":  {MemRequest: &memRequest},
+		"gitlab": {CPULimit: &cpuLimit, GPUs: 1, GPUClass: "nvidia.com/gpu"},
+	}
+	s.mockAPI.applicationsInfoFunc = func([]names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
+		return []params.ApplicationInfoResult{{Result: app}}, nil
+	}
+	s.assertRunShow(c, showTest{
+		args: []string{"gitlab"},
+		stdout: `
+gitlab:
+  charm: charm-gitlab
+  series: quantal
+  channel: development
+  principal: true
+  exposed: false
+  remote: false
+  endpoint-bindings:
+    juju-info: myspace
+  container-resources:
+    charm: mem-request=256M
+    gitlab: cpu-limit=200 gpu=1 gpu-class=nvidia.com/gpu
+`[1:],
+	})
+}
+
 func (s *ShowSuite) TestShowJSON(c *gc.C) {
 	s.mockAPI.applicationsInfoFunc = func([]names.ApplicationTag) ([]params.ApplicationInfoResult, error) {
 		return []params.ApplicationInfoResult{
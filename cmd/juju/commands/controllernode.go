@@ -0,0 +1,241 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/constraints"
+)
+
+// ControllerNodeClient defines the methods on the high availability
+// API used to remove and replace controller machines.
+type ControllerNodeClient interface {
+	Close() error
+	RemoveControllerNode(machineId string, force bool, maxWait *time.Duration) error
+	ReplaceControllerNode(
+		machineId string, force bool, maxWait *time.Duration, cons constraints.Value, placement []string,
+	) (params.ControllersChanges, error)
+}
+
+// controllerNodeCommand holds what is common to the commands which
+// remove and replace controller machines.
+type controllerNodeCommand struct {
+	modelcmd.ControllerCommandBase
+
+	// newClientFunc returns the client used by the command.
+	newClientFunc func() (ControllerNodeClient, error)
+
+	machineId string
+	force     bool
+}
+
+func (c *controllerNodeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "Remove the controller machine even if its agent is not running")
+}
+
+func (c *controllerNodeCommand) init(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no controller machine specified")
+	}
+	c.machineId, args = args[0], args[1:]
+	if !names.IsValidMachine(c.machineId) || names.IsContainerMachine(c.machineId) {
+		return nil, errors.NotValidf("controller machine %q", c.machineId)
+	}
+	return args, nil
+}
+
+// client validates the controller and returns the client used to
+// change its controller machines.
+func (c *controllerNodeCommand) client(cmdName string) (ControllerNodeClient, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := common.ValidateIaasController(c.CommandBase, cmdName, controllerName, c.ClientStore()); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.newClientFunc()
+}
+
+func (c *controllerNodeCommand) newClient() (ControllerNodeClient, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get API connection")
+	}
+	return highavailability.NewClient(root), nil
+}
+
+func newRemoveControllerNodeCommand() cmd.Command {
+	command := &removeControllerNodeCommand{}
+	command.newClientFunc = command.newClient
+	return modelcmd.WrapController(command)
+}
+
+// removeControllerNodeCommand removes a machine from the controllers.
+type removeControllerNodeCommand struct {
+	controllerNodeCommand
+}
+
+const removeControllerNodeDoc = `
+Remove a machine from the set of controllers of a highly available
+controller, and then remove the machine.
+
+The machine first gives up its vote, then the remaining controllers
+remove it from the database replica set and the raft cluster, and stop
+publishing its API addresses to agents and clients. The machine is
+removed once this is done. Only one controller machine can be removed
+at a time, and the last controller can't be removed.
+
+If the machine has failed and its agent is not running, use --force.
+
+To keep the controller highly available, use replace-controller-node
+instead, or run enable-ha afterwards.
+
+Examples:
+    juju remove-controller-node 2
+    juju remove-controller-node 2 --force
+
+See also:
+    enable-ha
+    replace-controller-node
+`
+
+func (c *removeControllerNodeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-controller-node",
+		Args:    "<machine>",
+		Purpose: "Remove a machine from the controllers.",
+		Doc:     removeControllerNodeDoc,
+	})
+}
+
+func (c *removeControllerNodeCommand) Init(args []string) error {
+	args, err := c.init(args)
+	if err != nil {
+		return err
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *removeControllerNodeCommand) Run(ctx *cmd.Context) error {
+	client, err := c.client(c.Info().Name)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	if err := client.RemoveControllerNode(c.machineId, c.force, nil); err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	ctx.Infof("removing controller machine %s", c.machineId)
+	return nil
+}
+
+func newReplaceControllerNodeCommand() cmd.Command {
+	command := &replaceControllerNodeCommand{}
+	command.newClientFunc = command.newClient
+	return modelcmd.WrapController(command)
+}
+
+// replaceControllerNodeCommand replaces a controller machine with a new
+// one.
+type replaceControllerNodeCommand struct {
+	controllerNodeCommand
+	out cmd.Output
+
+	constraintsStr string
+	placementSpec  string
+	placement      []string
+}
+
+const replaceControllerNodeDoc = `
+Remove a machine from the set of controllers of a highly available
+controller, as remove-controller-node does, and add a new controller
+machine in its place.
+
+Unless --constraints is specified, the new machine has the constraints
+and series of the machine it replaces. An existing machine can be made a
+controller instead with --to. As with enable-ha, the new machine is
+placed to keep the controllers spread across availability zones.
+
+Examples:
+    juju replace-controller-node 2
+    juju replace-controller-node 2 --force --constraints mem=8G
+    juju replace-controller-node 2 --to 5
+
+See also:
+    enable-ha
+    remove-controller-node
+`
+
+func (c *replaceControllerNodeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "replace-controller-node",
+		Args:    "<machine>",
+		Purpose: "Replace a controller machine with a new one.",
+		Doc:     replaceControllerNodeDoc,
+	})
+}
+
+func (c *replaceControllerNodeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.controllerNodeCommand.SetFlags(f)
+	f.StringVar(&c.placementSpec, "to", "", "The machine to become the new controller, bypasses constraints")
+	f.StringVar(&c.constraintsStr, "constraints", "", "Constraints for the new controller machine")
+	c.out.AddFlags(f, "simple", map[string]cmd.Formatter{
+		"yaml":   cmd.FormatYaml,
+		"json":   cmd.FormatJson,
+		"simple": formatSimple,
+	})
+}
+
+func (c *replaceControllerNodeCommand) Init(args []string) error {
+	args, err := c.init(args)
+	if err != nil {
+		return err
+	}
+	if c.placement, err = parseControllerPlacement("replace-controller-node", c.placementSpec); err != nil {
+		return err
+	}
+	if len(c.placement) > 1 {
+		return errors.New("only one placement directive can be used with replace-controller-node")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *replaceControllerNodeCommand) Run(ctx *cmd.Context) error {
+	cons, err := common.ParseConstraints(ctx, c.constraintsStr)
+	if err != nil {
+		return err
+	}
+	client, err := c.client(c.Info().Name)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+	changes, err := client.ReplaceControllerNode(c.machineId, c.force, nil, cons, c.placement)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	for _, warning := range changes.Warnings {
+		ctx.Warningf("%s", warning)
+	}
+	return c.out.Write(ctx, availabilityInfo{
+		Added:      machineTagsToIds(changes.Added...),
+		Removed:    machineTagsToIds(changes.Removed...),
+		Maintained: machineTagsToIds(changes.Maintained...),
+		Converted:  machineTagsToIds(changes.Converted...),
+	})
+}
@@ -0,0 +1,169 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+)
+
+type ControllerNodeSuite struct {
+	testing.IsolationSuite
+	fake  *fakeControllerNodeClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ControllerNodeSuite{})
+
+func (s *ControllerNodeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.fake = &fakeControllerNodeClient{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "kontroll"
+	s.store.Controllers["kontroll"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["kontroll"] = jujuclient.AccountDetails{User: "admin"}
+	s.store.Models["kontroll"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{"admin/controller": {
+			ModelUUID: "controller-uuid",
+			ModelType: model.IAAS,
+		}},
+	}
+}
+
+func (s *ControllerNodeSuite) run(c *gc.C, command modelcmd.ControllerCommand, args ...string) (*cmd.Context, error) {
+	command.SetClientStore(s.store)
+	return cmdtesting.RunCommand(c, modelcmd.WrapController(command), args...)
+}
+
+func (s *ControllerNodeSuite) removeCommand() *removeControllerNodeCommand {
+	command := &removeControllerNodeCommand{}
+	command.newClientFunc = func() (ControllerNodeClient, error) { return s.fake, nil }
+	return command
+}
+
+func (s *ControllerNodeSuite) replaceCommand() *replaceControllerNodeCommand {
+	command := &replaceControllerNodeCommand{}
+	command.newClientFunc = func() (ControllerNodeClient, error) { return s.fake, nil }
+	return command
+}
+
+func (s *ControllerNodeSuite) TestRemoveInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no controller machine specified",
+	}, {
+		args: []string{"foo"},
+		err:  `controller machine "foo" not valid`,
+	}, {
+		args: []string{"0/lxd/1"},
+		err:  `controller machine "0/lxd/1" not valid`,
+	}, {
+		args: []string{"1", "2"},
+		err:  `unrecognized args: \["2"\]`,
+	}} {
+		c.Logf("test %d", i)
+		err := cmdtesting.InitCommand(s.removeCommand(), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ControllerNodeSuite) TestRemove(c *gc.C) {
+	ctx, err := s.run(c, s.removeCommand(), "2", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.machineId, gc.Equals, "2")
+	c.Check(s.fake.force, jc.IsTrue)
+	c.Check(s.fake.closed, jc.IsTrue)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "removing controller machine 2\n")
+}
+
+func (s *ControllerNodeSuite) TestRemoveBlocked(c *gc.C) {
+	s.fake.err = &params.Error{Code: params.CodeOperationBlocked, Message: "TestBlockRemove"}
+	_, err := s.run(c, s.removeCommand(), "2")
+	c.Assert(err, gc.ErrorMatches, "(?s)TestBlockRemove.*juju enable-command remove-object.*")
+}
+
+func (s *ControllerNodeSuite) TestRemoveCAASController(c *gc.C) {
+	details := s.store.Models["kontroll"].Models["admin/controller"]
+	details.ModelType = model.CAAS
+	s.store.Models["kontroll"].Models["admin/controller"] = details
+	_, err := s.run(c, s.removeCommand(), "2")
+	c.Assert(err, gc.ErrorMatches, `Juju command "remove-controller-node" not supported on container controllers`)
+}
+
+func (s *ControllerNodeSuite) TestReplaceInitErrors(c *gc.C) {
+	err := cmdtesting.InitCommand(s.replaceCommand(), []string{"2", "--to", "3,4"})
+	c.Assert(err, gc.ErrorMatches, "only one placement directive can be used with replace-controller-node")
+	err = cmdtesting.InitCommand(s.replaceCommand(), []string{"2", "--to", "lxd:3"})
+	c.Assert(err, gc.ErrorMatches, `unsupported replace-controller-node placement directive "lxd:3"`)
+}
+
+func (s *ControllerNodeSuite) TestReplace(c *gc.C) {
+	s.fake.changes = params.ControllersChanges{
+		Added:   []string{"machine-3"},
+		Removed: []string{"machine-2"},
+	}
+	ctx, err := s.run(c, s.replaceCommand(), "2", "--constraints", "mem=8G", "--to", "zone=a")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.fake.machineId, gc.Equals, "2")
+	c.Check(s.fake.force, jc.IsFalse)
+	c.Check(s.fake.cons, jc.DeepEquals, constraints.MustParse("mem=8G"))
+	c.Check(s.fake.placement, jc.DeepEquals, []string{"zone=a"})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+adding machines: 3
+removing machines: 2
+
+`[1:])
+}
+
+func (s *ControllerNodeSuite) TestReplaceWarnings(c *gc.C) {
+	s.fake.changes = params.ControllersChanges{
+		Added:    []string{"machine-3"},
+		Removed:  []string{"machine-2"},
+		Warnings: []string{`2 of 3 controllers will be in zone "a"; losing the zone would lose quorum`},
+	}
+	_, err := s.run(c, s.replaceCommand(), "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(c.GetTestLog(), jc.Contains,
+		`WARNING cmd 2 of 3 controllers will be in zone "a"; losing the zone would lose quorum`)
+}
+
+type fakeControllerNodeClient struct {
+	machineId string
+	force     bool
+	cons      constraints.Value
+	placement []string
+	changes   params.ControllersChanges
+	err       error
+	closed    bool
+}
+
+func (f *fakeControllerNodeClient) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeControllerNodeClient) RemoveControllerNode(machineId string, force bool, _ *time.Duration) error {
+	f.machineId, f.force = machineId, force
+	return f.err
+}
+
+func (f *fakeControllerNodeClient) ReplaceControllerNode(
+	machineId string, force bool, _ *time.Duration, cons constraints.Value, placement []string,
+) (params.ControllersChanges, error) {
+	f.machineId, f.force, f.cons, f.placement = machineId, force, cons, placement
+	return f.changes, f.err
+}
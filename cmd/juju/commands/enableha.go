@@ -73,6 +73,12 @@ controller.
 
 An odd number of controllers is required.
 
+Where the cloud has availability zones, new controller machines are
+spread across the zones, so that the controllers keep quorum if a single
+zone fails. Placement with --to is refused if it would leave a majority
+of the controllers in one zone when spreading them would not, and a
+warning is shown when there are too few zones to spread them.
+
 Examples:
     # Ensure that the controller is still in highly available mode. If
     # there is only 1 controller running, this will ensure there
@@ -158,31 +164,42 @@ func (c *enableHACommand) Init(args []string) error {
 	if c.NumControllers < 0 || (c.NumControllers%2 != 1 && c.NumControllers != 0) {
 		return errors.Errorf("must specify a number of controllers odd and non-negative")
 	}
-	if c.PlacementSpec != "" {
-		placementSpecs := strings.Split(c.PlacementSpec, ",")
-		c.Placement = make([]string, len(placementSpecs))
-		for i, spec := range placementSpecs {
-			p, err := instance.ParsePlacement(strings.TrimSpace(spec))
-			if err == nil && p == nil {
-				return errors.New("empty placement directive passed to enable-ha")
-			}
-			if err == nil && names.IsContainerMachine(p.Directive) {
-				return errors.New("enable-ha cannot be used with container placement directives")
-			}
-			if err == nil && p.Scope == instance.MachineScope {
-				// Targeting machines is ok.
-				c.Placement[i] = p.String()
-				continue
-			}
-			if err != instance.ErrPlacementScopeMissing {
-				return errors.Errorf("unsupported enable-ha placement directive %q", spec)
-			}
-			c.Placement[i] = spec
-		}
+	var err error
+	if c.Placement, err = parseControllerPlacement("enable-ha", c.PlacementSpec); err != nil {
+		return err
 	}
 	return cmd.CheckEmpty(args)
 }
 
+// parseControllerPlacement parses the comma separated placement
+// directives for new controller machines.
+func parseControllerPlacement(cmdName, placementSpec string) ([]string, error) {
+	if placementSpec == "" {
+		return nil, nil
+	}
+	placementSpecs := strings.Split(placementSpec, ",")
+	placement := make([]string, len(placementSpecs))
+	for i, spec := range placementSpecs {
+		p, err := instance.ParsePlacement(strings.TrimSpace(spec))
+		if err == nil && p == nil {
+			return nil, errors.Errorf("empty placement directive passed to %s", cmdName)
+		}
+		if err == nil && names.IsContainerMachine(p.Directive) {
+			return nil, errors.Errorf("%s cannot be used with container placement directives", cmdName)
+		}
+		if err == nil && p.Scope == instance.MachineScope {
+			// Targeting machines is ok.
+			placement[i] = p.String()
+			continue
+		}
+		if err != instance.ErrPlacementScopeMissing {
+			return nil, errors.Errorf("unsupported %s placement directive %q", cmdName, spec)
+		}
+		placement[i] = spec
+	}
+	return placement, nil
+}
+
 type availabilityInfo struct {
 	Maintained []string `json:"maintained,omitempty" yaml:"maintained,flow,omitempty"`
 	Removed    []string `json:"removed,omitempty" yaml:"removed,flow,omitempty"`
@@ -236,6 +253,9 @@ func (c *enableHACommand) Run(ctx *cmd.Context) error {
 		Maintained: machineTagsToIds(enableHAResult.Maintained...),
 		Converted:  machineTagsToIds(enableHAResult.Converted...),
 	}
+	for _, warning := range enableHAResult.Warnings {
+		ctx.Warningf("%s", warning)
+	}
 	return c.out.Write(ctx, result)
 }
 
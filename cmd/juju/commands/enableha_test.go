@@ -114,6 +114,14 @@ func (s *EnableHASuite) TestEnableHA(c *gc.C) {
 	c.Assert(len(s.fake.placement), gc.Equals, 0)
 }
 
+func (s *EnableHASuite) TestEnableHAWarnings(c *gc.C) {
+	s.fake.result.Warnings = []string{`3 of 3 controllers will be in zone "a"; losing the zone would lose quorum`}
+	_, err := s.runEnableHA(c, "-n", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains,
+		`WARNING cmd 3 of 3 controllers will be in zone "a"; losing the zone would lose quorum`)
+}
+
 func (s *EnableHASuite) TestBlockEnableHA(c *gc.C) {
 	s.fake.err = apiservererrors.OperationBlockedError("TestBlockEnableHA")
 	_, err := s.runEnableHA(c, "-n", "1")
@@ -372,6 +372,7 @@ func registerCommands(r commandRegistry) {
 
 	// Error resolution and debugging commands.
 	r.Register(action.NewExecCommand(nil))
+	r.Register(action.NewNetworkCheckCommand())
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil, nil))
 	r.Register(application.NewResolvedCommand())
@@ -450,7 +451,12 @@ func registerCommands(r commandRegistry) {
 	}
 
 	r.Register(newMigrateCommand())
+	r.Register(model.NewShowMigrationCommand())
+	r.Register(model.NewRetryMigrationCommand())
 	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewExportModelCommand())
+	r.Register(model.NewImportModelCommand())
+	r.Register(model.NewCloneModelCommand())
 
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
@@ -468,6 +474,8 @@ func registerCommands(r commandRegistry) {
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
+	r.Register(newRemoveControllerNodeCommand())
+	r.Register(newReplaceControllerNodeCommand())
 
 	// Manage and control applications
 	r.Register(application.NewAddUnitCommand())
@@ -523,6 +531,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewControllerHealthCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -553,6 +562,7 @@ func registerCommands(r commandRegistry) {
 	r.Register(caas.NewUpdateCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewRemoveCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(application.NewScaleApplicationCommand())
+	r.Register(application.NewAutoscaleApplicationCommand())
 
 	// Manage Application Credential Access
 	r.Register(application.NewTrustCommand())
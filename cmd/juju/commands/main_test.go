@@ -304,6 +304,7 @@ var commandNames = []string{
 	"attach-resource",
 	"attach-storage",
 	"autoload-credentials",
+	"autoscale-application",
 	"backups",
 	"bind",
 	"bootstrap",
@@ -312,11 +313,13 @@ var commandNames = []string{
 	"cancel-task",
 	"change-user-password",
 	"charm-resources",
+	"clone-model",
 	"clouds",
 	"collect-metrics",
 	"config",
 	"consume",
 	"controller-config",
+	"controller-health",
 	"controllers",
 	"create-backup",
 	"create-storage-pool",
@@ -345,6 +348,7 @@ var commandNames = []string{
 	"enable-user",
 	"exec",
 	"export-bundle",
+	"export-model",
 	"expose",
 	"find",
 	"find-offers",
@@ -358,6 +362,7 @@ var commandNames = []string{
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
 	"info",
 	"kill-controller",
@@ -396,6 +401,7 @@ var commandNames = []string{
 	"model-defaults",
 	"models",
 	"move-to-space",
+	"network-check",
 	"offer",
 	"offers",
 	"operations",
@@ -411,6 +417,7 @@ var commandNames = []string{
 	"remove-cached-images",
 	"remove-cloud",
 	"remove-consumed-application",
+	"remove-controller-node",
 	"remove-credential",
 	"remove-k8s",
 	"remove-machine",
@@ -424,10 +431,12 @@ var commandNames = []string{
 	"remove-unit",
 	"remove-user",
 	"rename-space",
+	"replace-controller-node",
 	"resolved",
 	"resolve",
 	"resources",
 	"resume-relation",
+	"retry-migration",
 	"retry-provisioning",
 	"revoke",
 	"revoke-cloud",
@@ -452,6 +461,7 @@ var commandNames = []string{
 	"show-credential",
 	"show-credentials",
 	"show-machine",
+	"show-migration",
 	"show-model",
 	"show-offer",
 	"show-operation",
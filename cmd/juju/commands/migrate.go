@@ -4,16 +4,22 @@
 package commands
 
 import (
+	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/httpbakery"
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 	"gopkg.in/macaroon.v2"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/api/usermanager"
@@ -26,6 +32,7 @@ import (
 func newMigrateCommand() modelcmd.ModelCommand {
 	var cmd migrateCommand
 	cmd.newAPIRoot = cmd.CommandBase.NewAPIRoot
+	cmd.clock = clock.WallClock
 	return modelcmd.Wrap(&cmd,
 		modelcmd.WrapSkipModelFlags,
 	)
@@ -35,16 +42,29 @@ func newMigrateCommand() modelcmd.ModelCommand {
 type migrateCommand struct {
 	modelcmd.ModelCommandBase
 	targetController string
+	dryRun           bool
+
+	// allModels, owner and modelsMatching select the models migrated
+	// by a batch migration.
+	allModels      bool
+	owner          string
+	modelsMatching string
+	modelsPattern  *regexp.Regexp
+	concurrency    int
+	timeout        time.Duration
 
 	// Overridden by tests
 	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error)
+	clock      clock.Clock
 	migAPI     map[string]migrateAPI
 	modelAPI   modelInfoAPI
 	userAPI    userListAPI
 }
 
 type migrateAPI interface {
+	AllModels() ([]base.UserModel, error)
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationPrechecks(spec controller.MigrationSpec) ([]string, error)
 	IdentityProviderURL() (string, error)
 	Close() error
 }
@@ -80,26 +100,78 @@ for details of how to do this.
 
 This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
-"status" command and by consulting the logs.
+"status" and "show-migration" commands and by consulting the logs. If
+transferring the model to the target controller fails, the migration
+waits for a while before being aborted so that the problem can be fixed
+and the transfer retried with the "retry-migration" command.
+
+Use the --dry-run option to run all of the migration prechecks on both
+the source and target controllers without starting the migration. All
+of the issues which would block the migration are reported at once.
+
+Several models can be migrated at once by selecting them with the
+--all-models, --owner or --models-matching options instead of naming a
+model. --all-models selects all of the hosted models on the current
+controller, --owner selects the models owned by the given user, and
+--models-matching selects the models whose names match the given
+regular expression. --owner and --models-matching may be combined.
+
+When migrating several models, at most --concurrency migrations are run
+at the same time; the remaining models are migrated as the running
+migrations complete. Unlike the migration of a single model, the command
+waits for all of the migrations to complete, reporting their progress as
+they go, and finishes with a report of the result of each migration.
+Use the --timeout option to limit how long the command waits; any
+migrations still running when it expires are left running, and both
+they and the models not yet migrated are reported as failed.
+
+Examples:
+
+    juju migrate mymodel target-controller
+    juju migrate mymodel target-controller --dry-run
+    juju migrate --all-models target-controller
+    juju migrate --owner bob --models-matching '^staging-' target-controller --concurrency 5
 
 See also:
     login
     controllers
     status
+    show-migration
+    retry-migration
 `
 
 // Info implements cmd.Command.
 func (c *migrateCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "migrate",
-		Args:    "<model-name> <target-controller-name>",
+		Args:    "[<model-name>] <target-controller-name>",
 		Purpose: "Migrate a hosted model to another controller.",
 		Doc:     migrateDoc,
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model can be migrated, without migrating it")
+	f.BoolVar(&c.allModels, "all-models", false, "Migrate all of the hosted models on the controller")
+	f.StringVar(&c.owner, "owner", "", "Migrate the models owned by this user")
+	f.StringVar(&c.modelsMatching, "models-matching", "", "Migrate the models with names matching this regular expression")
+	f.IntVar(&c.concurrency, "concurrency", 2, "The maximum number of migrations to run at once when migrating several models")
+	f.DurationVar(&c.timeout, "timeout", 0, "The maximum time to wait for the migrations to complete when migrating several models (0 means no limit)")
+}
+
+// isBatch reports whether the command migrates a selection of models
+// rather than a single named model.
+func (c *migrateCommand) isBatch() bool {
+	return c.allModels || c.owner != "" || c.modelsMatching != ""
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
+	if c.isBatch() {
+		return c.initBatch(args)
+	}
 	if len(args) < 1 {
 		return errors.New("model not specified")
 	}
@@ -120,6 +192,9 @@ func (c *migrateCommand) Init(args []string) error {
 
 // Run implements cmd.Command.
 func (c *migrateCommand) Run(ctx *cmd.Context) error {
+	if c.isBatch() {
+		return c.runBatch(ctx)
+	}
 	spec, err := c.getMigrationSpec()
 	if err != nil {
 		return err
@@ -133,6 +208,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
+	if c.dryRun {
+		return c.runPrechecks(ctx, modelName, spec)
+	}
 	if err := c.checkMigrationFeasibility(spec); err != nil {
 		return errors.Trace(err)
 	}
@@ -153,6 +231,59 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// runPrechecks runs the migration prechecks on the source and target
+// controllers, reporting all of the issues which would block the
+// migration.
+func (c *migrateCommand) runPrechecks(ctx *cmd.Context, modelName string, spec *controller.MigrationSpec) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return err
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+	issues, err := c.precheckIssues(api, controllerName, spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !c.reportPrecheckIssues(ctx, modelName, issues) {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// precheckIssues returns all of the issues which would block the
+// migration described by spec.
+func (c *migrateCommand) precheckIssues(api migrateAPI, controllerName string, spec *controller.MigrationSpec) ([]string, error) {
+	var issues []string
+	if err := c.checkMigrationFeasibility(spec); err != nil {
+		issues = append(issues, err.Error())
+	}
+	precheckIssues, err := api.MigrationPrechecks(*spec)
+	if errors.IsNotSupported(err) {
+		return nil, errors.Errorf("controller %q does not support migration dry runs", controllerName)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(issues, precheckIssues...), nil
+}
+
+// reportPrecheckIssues writes the issues blocking the migration of
+// the model, returning whether the model can be migrated.
+func (c *migrateCommand) reportPrecheckIssues(ctx *cmd.Context, modelName string, issues []string) bool {
+	if len(issues) == 0 {
+		ctx.Infof("Model %q can be migrated to controller %q", modelName, c.targetController)
+		return true
+	}
+	fmt.Fprintf(ctx.Stdout, "Migration of model %q to controller %q is blocked by:\n", modelName, c.targetController)
+	for _, issue := range issues {
+		fmt.Fprintf(ctx.Stdout, "  - %s\n", strings.Replace(issue, "\n", "\n    ", -1))
+	}
+	return false
+}
+
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	store := c.ClientStore()
 
@@ -186,6 +317,16 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	}, nil
 }
 
+// sourceControllerName returns the name of the controller the models
+// are migrated from. Batch migrations always migrate models from the
+// current controller.
+func (c *migrateCommand) sourceControllerName() (string, error) {
+	if c.isBatch() {
+		return modelcmd.DetermineCurrentController(c.ClientStore())
+	}
+	return c.ControllerName()
+}
+
 func (c *migrateCommand) getMigrationAPI(controllerName string) (migrateAPI, error) {
 	if c.migAPI != nil && c.migAPI[controllerName] != nil {
 		return c.migAPI[controllerName], nil
@@ -204,7 +345,7 @@ func (c *migrateCommand) getModelAPI() (modelInfoAPI, error) {
 		return c.modelAPI, nil
 	}
 
-	controllerName, err := c.ControllerName()
+	controllerName, err := c.sourceControllerName()
 	if err != nil {
 		return nil, err
 	}
@@ -270,7 +411,7 @@ func (c *migrateCommand) checkMigrationFeasibility(spec *controller.MigrationSpe
 	})
 
 	if srcExtUsers.Size() != 0 {
-		if srcControllerName, err = c.ControllerName(); err != nil {
+		if srcControllerName, err = c.sourceControllerName(); err != nil {
 			return err
 		}
 		srcIdentityURL, err := c.getIdentityProviderURL(srcControllerName)
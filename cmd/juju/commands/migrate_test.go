@@ -9,8 +9,11 @@ import (
 	"time"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/httpbakery"
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -417,6 +420,251 @@ func (s *MigrateSuite) TestControllerDoesNotExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"model\" can be migrated to controller \"target\"\n")
+	c.Check(s.api.initiated, jc.IsFalse)
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:             modelUUID,
+		TargetControllerUUID:  targetControllerUUID,
+		TargetControllerAlias: "target",
+		TargetAddrs:           []string{"1.2.3.4:5"},
+		TargetCACert:          "cert",
+		TargetUser:            "targetuser",
+		TargetPassword:        "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunIssues(c *gc.C) {
+	s.api.precheckIssues = []string{
+		"source: machine 0 is dying",
+		"target: upgrade in progress",
+	}
+	ctx, err := s.makeAndRun(c, "model-with-extra-local-users", "target", "--dry-run")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Migration of model "model-with-extra-local-users" to controller "target" is blocked by:
+  - cannot initiate migration as the users granted access to the model do not exist
+    on the destination controller. To resolve this issue you can add the following
+    users to the destination controller or remove them from the current model:
+      - foo
+  - source: machine 0 is dying
+  - target: upgrade in progress
+`[1:])
+	c.Check(s.api.initiated, jc.IsFalse)
+}
+
+func (s *MigrateSuite) TestDryRunNotSupported(c *gc.C) {
+	s.api.precheckErr = errors.NotSupportedf("migration prechecks")
+	_, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, `controller "source" does not support migration dry runs`)
+	c.Check(s.api.initiated, jc.IsFalse)
+}
+
+func (s *MigrateSuite) TestBatchInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--all-models"},
+		err:  "target controller not specified",
+	}, {
+		args: []string{"--all-models", "model", "target"},
+		err:  "too many arguments specified",
+	}, {
+		args: []string{"--owner", "not/valid", "target"},
+		err:  `user "not/valid" not valid`,
+	}, {
+		args: []string{"--models-matching", "[", "target"},
+		err:  "invalid --models-matching expression: .*",
+	}, {
+		args: []string{"--all-models", "--concurrency", "0", "target"},
+		err:  "--concurrency must be at least 1",
+	}, {
+		args: []string{"--all-models", "--timeout", "-1s", "target"},
+		err:  "--timeout must not be negative",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.makeAndRun(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *MigrateSuite) setUpBatch() {
+	s.api.models = []base.UserModel{
+		{Name: "controller", UUID: "controller-uuid", Type: model.IAAS, Owner: "admin"},
+		{Name: "model", UUID: modelUUID, Type: model.IAAS, Owner: "sourceuser"},
+		{Name: "production", UUID: "prod-1-uuid", Type: model.IAAS, Owner: "alpha"},
+		{Name: "production", UUID: "prod-2-uuid", Type: model.IAAS, Owner: "sourceuser"},
+		{Name: "model-with-extra-external-users", UUID: "extra-external-users-uuid", Type: model.IAAS, Owner: "sourceuser"},
+	}
+	s.api.initiateErrs = map[string]error{
+		"extra-external-users-uuid": errors.New("model is already being migrated"),
+	}
+	s.modelAPI.migrateAPI = s.api
+	s.modelAPI.migrations = map[string][]*params.ModelMigrationStatus{
+		modelUUID: {
+			{Status: "uploading model binaries into target controller", Phase: "IMPORT"},
+			{Status: "successful, removing model from source controller", Phase: "DONE", End: &time.Time{}},
+		},
+		"prod-1-uuid": {
+			{Status: "exporting model", Phase: "QUIESCE"},
+			nil,
+		},
+		"prod-2-uuid": {
+			{Status: "model data transfer failed, boom", Phase: "ABORTDONE", End: &time.Time{}},
+		},
+	}
+}
+
+func (s *MigrateSuite) TestBatchMigrate(c *gc.C) {
+	s.setUpBatch()
+	ctx, err := s.makeAndRun(c, "--all-models", "target")
+	c.Assert(err, gc.ErrorMatches, "2 of 4 migrations did not succeed")
+
+	// The controller model is never migrated, and no more than two
+	// migrations are run at once.
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-1-uuid", modelUUID, "prod-2-uuid"})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Migration of model "alpha/production" started with ID "uuid:0"
+Migration of model "sourceuser/model" started with ID "uuid:0"
+Migration of model "alpha/production" is in the QUIESCE phase
+Migration of model "sourceuser/model" is in the IMPORT phase
+0 of 4 migrations complete, 2 running, 2 waiting
+Migration of model "alpha/production" succeeded
+Migration of model "sourceuser/model" succeeded
+2 of 4 migrations complete, 0 running, 2 waiting
+Migration of model "sourceuser/model-with-extra-external-users" could not be started: model is already being migrated
+Migration of model "sourceuser/production" started with ID "uuid:0"
+Migration of model "sourceuser/production" aborted
+4 of 4 migrations complete, 0 running, 0 waiting
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model                                       Result     Details
+alpha/production                            succeeded  
+sourceuser/model                            succeeded  
+sourceuser/model-with-extra-external-users  failed     model is already being migrated
+sourceuser/production                       aborted    model data transfer failed, boom
+`[1:])
+}
+
+func (s *MigrateSuite) TestBatchMigrateConcurrency(c *gc.C) {
+	s.setUpBatch()
+	ctx, err := s.makeAndRun(c, "--owner", "sourceuser", "--models-matching", "^(model|production)$", "target", "--concurrency", "1")
+	c.Assert(err, gc.ErrorMatches, "1 of 2 migrations did not succeed")
+
+	c.Check(s.api.started, jc.DeepEquals, []string{modelUUID, "prod-2-uuid"})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Migration of model "sourceuser/model" started with ID "uuid:0"
+Migration of model "sourceuser/model" is in the IMPORT phase
+0 of 2 migrations complete, 1 running, 1 waiting
+Migration of model "sourceuser/model" succeeded
+1 of 2 migrations complete, 0 running, 1 waiting
+Migration of model "sourceuser/production" started with ID "uuid:0"
+Migration of model "sourceuser/production" aborted
+2 of 2 migrations complete, 0 running, 0 waiting
+`[1:])
+}
+
+func (s *MigrateSuite) TestBatchMigrateStatusErrors(c *gc.C) {
+	s.setUpBatch()
+	s.modelAPI.infoErrs = map[string]*params.Error{
+		"prod-1-uuid": {Message: "boom"},
+	}
+	ctx, err := s.makeAndRun(c, "--models-matching", "^production$", "target")
+	c.Assert(err, gc.ErrorMatches, "2 of 2 migrations did not succeed")
+
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model                  Result   Details
+alpha/production       failed   cannot check migration progress: boom
+sourceuser/production  aborted  model data transfer failed, boom
+`[1:])
+}
+
+func (s *MigrateSuite) TestBatchMigrateUnconfirmed(c *gc.C) {
+	s.setUpBatch()
+	s.modelAPI.infoErrs = map[string]*params.Error{
+		"prod-1-uuid": {Code: params.CodeNotFound},
+		"prod-2-uuid": {
+			Code: params.CodeRedirect,
+			Info: params.RedirectErrorInfo{
+				ControllerTag: names.NewControllerTag("deadbeef-0bad-400d-8000-4b1d0d06f00d").String(),
+			}.AsMap(),
+		},
+	}
+	ctx, err := s.makeAndRun(c, "--models-matching", "^production$", "target")
+	c.Assert(err, gc.ErrorMatches, "2 of 2 migrations did not succeed")
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Migration of model "alpha/production" started with ID "uuid:0"
+Migration of model "sourceuser/production" started with ID "uuid:0"
+Result of the migration of model "alpha/production" is unknown: model no longer found on the source controller, check the target controller
+Result of the migration of model "sourceuser/production" is unknown: model redirected to "controller-deadbeef-0bad-400d-8000-4b1d0d06f00d", not the target controller
+2 of 2 migrations complete, 0 running, 0 waiting
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model                  Result   Details
+alpha/production       unknown  model no longer found on the source controller, check the target controller
+sourceuser/production  unknown  model redirected to "controller-deadbeef-0bad-400d-8000-4b1d0d06f00d", not the target controller
+`[1:])
+}
+
+func (s *MigrateSuite) TestBatchMigrateModelInfoFails(c *gc.C) {
+	s.setUpBatch()
+	s.modelAPI.infoErr = errors.New("boom")
+	ctx, err := s.makeAndRun(c, "--models-matching", "^production$", "target", "--concurrency", "1")
+	c.Assert(err, gc.ErrorMatches, "2 of 2 migrations did not succeed")
+
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-1-uuid"})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model                  Result  Details
+alpha/production       failed  cannot check migration progress: boom
+sourceuser/production  failed  not started: cannot check migration progress: boom
+`[1:])
+}
+
+func (s *MigrateSuite) TestBatchMigrateTimeout(c *gc.C) {
+	s.setUpBatch()
+	s.modelAPI.migrations["prod-1-uuid"] = []*params.ModelMigrationStatus{
+		{Status: "exporting model", Phase: "QUIESCE"},
+	}
+	ctx, err := s.makeAndRun(c, "--models-matching", "^production$", "target", "--concurrency", "1", "--timeout", "12s")
+	c.Assert(err, gc.ErrorMatches, "2 of 2 migrations did not succeed")
+
+	c.Check(s.api.started, jc.DeepEquals, []string{"prod-1-uuid"})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Migration of model "alpha/production" started with ID "uuid:0"
+Migration of model "alpha/production" is in the QUIESCE phase
+0 of 2 migrations complete, 1 running, 1 waiting
+Not waiting for the remaining migrations: timed out after 12s
+`[1:])
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model                  Result  Details
+alpha/production       failed  timed out after 12s
+sourceuser/production  failed  not started: timed out after 12s
+`[1:])
+}
+
+func (s *MigrateSuite) TestBatchDryRun(c *gc.C) {
+	s.setUpBatch()
+	ctx, err := s.makeAndRun(c, "--owner", "sourceuser", "--models-matching", "^prod", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"sourceuser/production\" can be migrated to controller \"target\"\n")
+	c.Check(s.api.specSeen.ModelUUID, gc.Equals, "prod-2-uuid")
+	c.Check(s.api.initiated, jc.IsFalse)
+}
+
+func (s *MigrateSuite) TestBatchNoModelsSelected(c *gc.C) {
+	s.setUpBatch()
+	_, err := s.makeAndRun(c, "--owner", "nobody", "target")
+	c.Assert(err, gc.ErrorMatches, "no models selected for migration")
+	c.Check(s.api.initiated, jc.IsFalse)
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, s.makeCommand(), args...)
 }
@@ -433,6 +681,7 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 	}
 	inner.modelAPI = s.modelAPI
 	inner.userAPI = s.userAPI
+	inner.clock = &advancingClock{}
 	inner.newAPIRoot = func(jujuclient.ClientStore, string, string) (api.Connection, error) {
 		return s.targetControllerAPI, nil
 	}
@@ -440,15 +689,35 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen    *controller.MigrationSpec
-	identityURL string
+	specSeen       *controller.MigrationSpec
+	identityURL    string
+	precheckIssues []string
+	precheckErr    error
+	initiated      bool
+	models         []base.UserModel
+	started        []string
+	initiateErrs   map[string]error
+}
+
+func (a *fakeMigrateAPI) AllModels() ([]base.UserModel, error) {
+	return a.models, nil
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
 	a.specSeen = &spec
+	if err := a.initiateErrs[spec.ModelUUID]; err != nil {
+		return "", err
+	}
+	a.initiated = true
+	a.started = append(a.started, spec.ModelUUID)
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) MigrationPrechecks(spec controller.MigrationSpec) ([]string, error) {
+	a.specSeen = &spec
+	return a.precheckIssues, a.precheckErr
+}
+
 func (a *fakeMigrateAPI) IdentityProviderURL() (string, error) {
 	return a.identityURL, nil
 }
@@ -460,6 +729,18 @@ func (*fakeMigrateAPI) Close() error {
 type fakeModelAPI struct {
 	models    []base.UserModel
 	modelInfo []params.ModelInfo
+
+	// migrations holds the successive statuses reported for the
+	// migrations of models once they have been started by migrateAPI.
+	// A nil status reports the model as having been migrated away.
+	migrations map[string][]*params.ModelMigrationStatus
+	migrateAPI *fakeMigrateAPI
+
+	// infoErr is returned by ModelInfo once a migration has been
+	// started, and infoErrs holds the errors reported for the models
+	// once their migrations have been started.
+	infoErr  error
+	infoErrs map[string]*params.Error
 }
 
 func (m *fakeModelAPI) ListModels(user string) ([]base.UserModel, error) {
@@ -467,31 +748,54 @@ func (m *fakeModelAPI) ListModels(user string) ([]base.UserModel, error) {
 }
 
 func (m *fakeModelAPI) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error) {
-	var (
-		mi  *params.ModelInfo
-		err *params.Error
-	)
+	if m.infoErr != nil && m.migrateAPI != nil && len(m.migrateAPI.started) > 0 {
+		return nil, m.infoErr
+	}
+	results := make([]params.ModelInfoResult, len(tags))
+	for i, tag := range tags {
+		results[i] = m.modelInfoResult(tag.Id())
+	}
+	return results, nil
+}
 
-	modelUUID := tags[0].Id()
+func (m *fakeModelAPI) modelInfoResult(modelUUID string) params.ModelInfoResult {
+	var mi *params.ModelInfo
 	for _, model := range m.modelInfo {
 		if model.UUID == modelUUID {
+			model := model
 			mi = &model
 			break
 		}
 	}
-
 	if mi == nil {
-		err = &params.Error{
-			Code: params.CodeNotFound,
+		return params.ModelInfoResult{
+			Error: &params.Error{Code: params.CodeNotFound},
 		}
 	}
 
-	return []params.ModelInfoResult{
-		{
-			Result: mi,
-			Error:  err,
-		},
-	}, nil
+	statuses := m.migrations[modelUUID]
+	if len(statuses) == 0 || m.migrateAPI == nil || !set.NewStrings(m.migrateAPI.started...).Contains(modelUUID) {
+		return params.ModelInfoResult{Result: mi}
+	}
+	if err, ok := m.infoErrs[modelUUID]; ok {
+		return params.ModelInfoResult{Error: err}
+	}
+	status := statuses[0]
+	if len(statuses) > 1 {
+		m.migrations[modelUUID] = statuses[1:]
+	}
+	if status == nil {
+		return params.ModelInfoResult{
+			Error: &params.Error{
+				Code: params.CodeRedirect,
+				Info: params.RedirectErrorInfo{
+					ControllerTag: names.NewControllerTag(targetControllerUUID).String(),
+				}.AsMap(),
+			},
+		}
+	}
+	mi.Migration = status
+	return params.ModelInfoResult{Result: mi}
 }
 
 func (m *fakeModelAPI) Close() error {
@@ -523,3 +827,32 @@ func (a *fakeTargetControllerAPI) CookieURL() *url.URL {
 func (a *fakeTargetControllerAPI) Close() error {
 	return nil
 }
+
+// instantClock is a clock whose timers fire immediately.
+type instantClock struct {
+	clock.Clock
+}
+
+func (instantClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- time.Now()
+	return ch
+}
+
+// advancingClock is a clock whose timers fire immediately, advancing
+// the clock by their duration.
+type advancingClock struct {
+	clock.Clock
+	now time.Time
+}
+
+func (c *advancingClock) Now() time.Time {
+	return c.now
+}
+
+func (c *advancingClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
@@ -0,0 +1,383 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/bootstrap"
+)
+
+// migrationPollInterval is how often the progress of the migrations
+// started by a batch migration is checked.
+const migrationPollInterval = 5 * time.Second
+
+// maxMigrationStatusErrors is the number of consecutive times the
+// progress of a migration may fail to be checked before the migration
+// is reported as failed.
+const maxMigrationStatusErrors = 5
+
+// Results of the migrations run by a batch migration.
+const (
+	migrationSucceeded = "succeeded"
+	migrationFailed    = "failed"
+	migrationAborted   = "aborted"
+	migrationUnknown   = "unknown"
+)
+
+// batchMigration tracks the migration of one of the models selected
+// by a batch migration.
+type batchMigration struct {
+	model   base.UserModel
+	phase   string
+	result  string
+	details string
+
+	// statusErrors counts the consecutive failures to check the
+	// progress of the migration.
+	statusErrors int
+}
+
+// name returns the qualified name of the migrated model.
+func (m *batchMigration) name() string {
+	return m.model.Owner + "/" + m.model.Name
+}
+
+func (c *migrateCommand) initBatch(args []string) error {
+	if len(args) < 1 {
+		return errors.New("target controller not specified")
+	}
+	if len(args) > 1 {
+		return errors.New("too many arguments specified")
+	}
+	if c.owner != "" && !names.IsValidUser(c.owner) {
+		return errors.NotValidf("user %q", c.owner)
+	}
+	if c.modelsMatching != "" {
+		pattern, err := regexp.Compile(c.modelsMatching)
+		if err != nil {
+			return errors.Annotate(err, "invalid --models-matching expression")
+		}
+		c.modelsPattern = pattern
+	}
+	if c.concurrency < 1 {
+		return errors.New("--concurrency must be at least 1")
+	}
+	if c.timeout < 0 {
+		return errors.New("--timeout must not be negative")
+	}
+	c.targetController = args[0]
+	return nil
+}
+
+// selectModels returns the models selected for migration, ordered by
+// owner and name. The controller model is never selected.
+func (c *migrateCommand) selectModels(models []base.UserModel) []base.UserModel {
+	var selected []base.UserModel
+	for _, m := range models {
+		if m.Name == bootstrap.ControllerModelName && m.Owner == environs.AdminUser {
+			continue
+		}
+		if c.owner != "" && m.Owner != c.owner {
+			continue
+		}
+		if c.modelsPattern != nil && !c.modelsPattern.MatchString(m.Name) {
+			continue
+		}
+		selected = append(selected, m)
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		if selected[i].Owner != selected[j].Owner {
+			return selected[i].Owner < selected[j].Owner
+		}
+		return selected[i].Name < selected[j].Name
+	})
+	return selected
+}
+
+// runBatch migrates the selected models, running at most
+// c.concurrency migrations at once and waiting for all of them to
+// complete.
+func (c *migrateCommand) runBatch(ctx *cmd.Context) error {
+	spec, err := c.getMigrationSpec()
+	if err != nil {
+		return err
+	}
+	controllerName, err := c.sourceControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	api, err := c.getMigrationAPI(controllerName)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = api.Close() }()
+
+	allModels, err := api.AllModels()
+	if err != nil {
+		return errors.Annotate(err, "listing models")
+	}
+	models := c.selectModels(allModels)
+	if len(models) == 0 {
+		return errors.New("no models selected for migration")
+	}
+
+	if c.dryRun {
+		blocked := 0
+		for _, m := range models {
+			spec.ModelUUID = m.UUID
+			issues, err := c.precheckIssues(api, controllerName, spec)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !c.reportPrecheckIssues(ctx, m.Owner+"/"+m.Name, issues) {
+				blocked++
+			}
+		}
+		if blocked > 0 {
+			return errors.Errorf("migration of %d of %d models is blocked", blocked, len(models))
+		}
+		return nil
+	}
+
+	modelAPI, err := c.getModelAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = modelAPI.Close() }()
+
+	var (
+		waiting    = models
+		running    = make(map[string]*batchMigration)
+		finished   []*batchMigration
+		reported   string
+		pollErrors int
+		deadline   time.Time
+	)
+	if c.timeout > 0 {
+		deadline = c.clock.Now().Add(c.timeout)
+	}
+	// abandon stops waiting for the remaining migrations, reporting
+	// them as failed. Running migrations are not aborted.
+	abandon := func(reason string) {
+		ctx.Infof("Not waiting for the remaining migrations: %s", reason)
+		for _, mig := range running {
+			mig.result = migrationFailed
+			mig.details = reason
+			finished = append(finished, mig)
+		}
+		running = nil
+		for _, m := range waiting {
+			finished = append(finished, &batchMigration{
+				model:   m,
+				result:  migrationFailed,
+				details: "not started: " + reason,
+			})
+		}
+		waiting = nil
+	}
+	for {
+		for len(running) < c.concurrency && len(waiting) > 0 {
+			mig := &batchMigration{model: waiting[0]}
+			waiting = waiting[1:]
+			spec.ModelUUID = mig.model.UUID
+			if err := c.startBatchMigration(ctx, api, mig, spec); err != nil {
+				mig.result = migrationFailed
+				mig.details = err.Error()
+				ctx.Infof("Migration of model %q could not be started: %v", mig.name(), err)
+				finished = append(finished, mig)
+				continue
+			}
+			running[mig.model.UUID] = mig
+		}
+		if len(running) == 0 {
+			break
+		}
+
+		<-c.clock.After(migrationPollInterval)
+		done, err := c.pollBatchMigrations(ctx, modelAPI, running, spec.TargetControllerUUID)
+		if err != nil {
+			ctx.Warningf("checking migration progress: %v", err)
+			pollErrors++
+			if pollErrors >= maxMigrationStatusErrors {
+				abandon(fmt.Sprintf("cannot check migration progress: %v", err))
+				break
+			}
+		} else {
+			pollErrors = 0
+		}
+		for _, mig := range done {
+			delete(running, mig.model.UUID)
+			finished = append(finished, mig)
+		}
+		progress := fmt.Sprintf("%d of %d migrations complete, %d running, %d waiting",
+			len(finished), len(models), len(running), len(waiting))
+		if progress != reported {
+			ctx.Infof("%s", progress)
+			reported = progress
+		}
+		if !deadline.IsZero() && !c.clock.Now().Before(deadline) {
+			abandon(fmt.Sprintf("timed out after %v", c.timeout))
+			break
+		}
+	}
+	return c.reportBatch(ctx, finished)
+}
+
+// startBatchMigration checks that the model can be migrated and starts
+// its migration.
+func (c *migrateCommand) startBatchMigration(
+	ctx *cmd.Context, api migrateAPI, mig *batchMigration, spec *controller.MigrationSpec,
+) error {
+	if err := c.checkMigrationFeasibility(spec); err != nil {
+		return errors.Trace(err)
+	}
+	id, err := api.InitiateMigration(*spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Migration of model %q started with ID %q", mig.name(), id)
+	return nil
+}
+
+// pollBatchMigrations updates the running migrations from the status
+// of their models, returning the migrations which have completed.
+func (c *migrateCommand) pollBatchMigrations(
+	ctx *cmd.Context, api modelInfoAPI, running map[string]*batchMigration, targetControllerUUID string,
+) ([]*batchMigration, error) {
+	var (
+		migs []*batchMigration
+		tags []names.ModelTag
+	)
+	for _, mig := range running {
+		migs = append(migs, mig)
+	}
+	sort.Slice(migs, func(i, j int) bool {
+		return migs[i].name() < migs[j].name()
+	})
+	for _, mig := range migs {
+		tags = append(tags, names.NewModelTag(mig.model.UUID))
+	}
+
+	results, err := api.ModelInfo(tags)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results) != len(migs) {
+		return nil, errors.Errorf("expected %d results, got %d", len(migs), len(results))
+	}
+	var done []*batchMigration
+	for i, result := range results {
+		mig := migs[i]
+		previousPhase := mig.phase
+		if !updateBatchMigration(mig, result, targetControllerUUID) {
+			if mig.phase != previousPhase {
+				ctx.Infof("Migration of model %q is in the %s phase", mig.name(), mig.phase)
+			}
+			continue
+		}
+		if mig.result == migrationUnknown {
+			ctx.Infof("Result of the migration of model %q is unknown: %s", mig.name(), mig.details)
+		} else {
+			ctx.Infof("Migration of model %q %s", mig.name(), mig.result)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// updateBatchMigration records the progress of the migration from the
+// status of its model, returning whether the migration has completed.
+func updateBatchMigration(mig *batchMigration, result params.ModelInfoResult, targetControllerUUID string) bool {
+	if result.Error != nil {
+		// Once a migration has succeeded the source controller
+		// redirects requests for the model to the target controller.
+		if params.IsRedirect(result.Error) {
+			var info params.RedirectErrorInfo
+			if err := result.Error.UnmarshalInfo(&info); err != nil {
+				mig.result = migrationUnknown
+				mig.details = "model redirected: " + err.Error()
+			} else if info.ControllerTag != names.NewControllerTag(targetControllerUUID).String() {
+				mig.result = migrationUnknown
+				mig.details = fmt.Sprintf("model redirected to %q, not the target controller", info.ControllerTag)
+			} else {
+				mig.result = migrationSucceeded
+			}
+			return true
+		}
+		// A model which has gone without a redirect may have been
+		// removed rather than migrated; only the target controller
+		// can tell.
+		if params.IsCodeNotFound(result.Error) {
+			mig.result = migrationUnknown
+			mig.details = "model no longer found on the source controller, check the target controller"
+			return true
+		}
+		mig.details = result.Error.Error()
+		mig.statusErrors++
+		if mig.statusErrors >= maxMigrationStatusErrors {
+			mig.result = migrationFailed
+			mig.details = "cannot check migration progress: " + mig.details
+			return true
+		}
+		return false
+	}
+	mig.statusErrors = 0
+	status := result.Result.Migration
+	if status == nil {
+		return false
+	}
+	mig.phase = status.Phase
+	mig.details = status.Status
+	if status.End == nil {
+		return false
+	}
+	if phase, ok := coremigration.ParsePhase(status.Phase); ok && phase == coremigration.ABORTDONE {
+		mig.result = migrationAborted
+	} else {
+		mig.result = migrationSucceeded
+	}
+	return true
+}
+
+// reportBatch writes the result of each migration, returning an error
+// if any of the migrations did not succeed.
+func (c *migrateCommand) reportBatch(ctx *cmd.Context, finished []*batchMigration) error {
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].name() < finished[j].name()
+	})
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Model", "Result", "Details")
+	failed := 0
+	for _, mig := range finished {
+		details := mig.details
+		if mig.result == migrationSucceeded {
+			details = ""
+		} else {
+			failed++
+		}
+		w.Println(mig.name(), mig.result, details)
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d migrations did not succeed", failed, len(finished))
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/naturalsort"
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/core/status"
+)
+
+const (
+	// canaryPollInterval is how often the status of the model is
+	// checked during a canary upgrade.
+	canaryPollInterval = 10 * time.Second
+
+	// canaryUpgradeTimeout is how long a canary upgrade waits for the
+	// agents of a batch of machines to run the new version.
+	canaryUpgradeTimeout = 30 * time.Minute
+
+	// defaultCanarySoak is how long the upgraded machines are watched
+	// after each batch, unless --soak is specified.
+	defaultCanarySoak = 10 * time.Minute
+)
+
+type canaryUpgradeAPI interface {
+	SetCanaryAgentVersion(version version.Number, machineIds []string) error
+	ClearCanaryAgentVersion() error
+}
+
+func (c *upgradeJujuCommand) getCanaryAPI(client jujuClientAPI) (canaryUpgradeAPI, error) {
+	if c.canaryAPI != nil {
+		return c.canaryAPI, nil
+	}
+	api, ok := client.(canaryUpgradeAPI)
+	if !ok {
+		return nil, errors.NotSupportedf("canary upgrades")
+	}
+	return api, nil
+}
+
+// initCanary validates the canary upgrade flags.
+func (c *upgradeJujuCommand) initCanary() error {
+	if c.abortCanary && c.canary != "" {
+		return errors.New("--abort-canary cannot be used with --canary")
+	}
+	if c.canary == "" {
+		if c.batchSize != 0 {
+			return errors.New("--batch-size can only be used with --canary")
+		}
+		return nil
+	}
+	if c.BuildAgent {
+		return errors.New("--canary cannot be used with --build-agent")
+	}
+	if c.batchSize < 0 {
+		return errors.New("--batch-size must not be negative")
+	}
+	if c.soak < 0 {
+		return errors.New("--soak must not be negative")
+	}
+	c.canaryEntities = nil
+	for _, entity := range strings.Split(c.canary, ",") {
+		entity = strings.TrimSpace(entity)
+		if !names.IsValidMachine(entity) && !names.IsValidUnit(entity) {
+			return errors.NotValidf("canary %q", entity)
+		}
+		c.canaryEntities = append(c.canaryEntities, entity)
+	}
+	return nil
+}
+
+// canaryMachines returns the ids of the machines hosting the canary
+// machines and units, and of the rest of the model's machines.
+func (c *upgradeJujuCommand) canaryMachines(fullStatus *params.FullStatus) (canaries, rest []string, _ error) {
+	all := set.NewStrings()
+	for id := range canaryMachineStatuses(fullStatus) {
+		all.Add(id)
+	}
+
+	unitMachines := make(map[string]string)
+	for _, app := range fullStatus.Applications {
+		for name, unit := range app.Units {
+			unitMachines[name] = unit.Machine
+			for subName := range unit.Subordinates {
+				unitMachines[subName] = unit.Machine
+			}
+		}
+	}
+
+	selected := set.NewStrings()
+	for _, entity := range c.canaryEntities {
+		if names.IsValidMachine(entity) {
+			if !all.Contains(entity) {
+				return nil, nil, errors.NotFoundf("machine %s", entity)
+			}
+			selected.Add(entity)
+			continue
+		}
+		machine, ok := unitMachines[entity]
+		if !ok {
+			return nil, nil, errors.NotFoundf("unit %s", entity)
+		}
+		if machine == "" {
+			return nil, nil, errors.Errorf("unit %s is not assigned to a machine", entity)
+		}
+		selected.Add(machine)
+	}
+	return naturalsort.Sort(selected.Values()), naturalsort.Sort(all.Difference(selected).Values()), nil
+}
+
+// upgradeCanaries upgrades the canary machines to the target version
+// and watches their health for the soak period, before upgrading the
+// rest of the model's machines in batches and then the model itself.
+// If any upgraded machine becomes unhealthy, or fails to upgrade in
+// time, the upgraded machines are rolled back.
+func (c *upgradeJujuCommand) upgradeCanaries(ctx *cmd.Context, client jujuClientAPI, target version.Number) error {
+	canaryAPI, err := c.getCanaryAPI(client)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fullStatus, err := client.Status(nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	canaries, rest, err := c.canaryMachines(fullStatus)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	upgraded := canaries
+	batch := canaries
+	ctx.Infof("upgrading canary machines %s to %s", strings.Join(canaries, ", "), target)
+	for {
+		if err := canaryAPI.SetCanaryAgentVersion(target, upgraded); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		if err := c.soakCanaries(ctx, client, target, batch, upgraded); err != nil {
+			if clearErr := canaryAPI.ClearCanaryAgentVersion(); clearErr != nil {
+				return errors.Annotatef(clearErr, "canary upgrade to %s failed (%v) and could not be rolled back", target, err)
+			}
+			return errors.Errorf("canary upgrade to %s failed and was rolled back: %v", target, err)
+		}
+		if len(rest) == 0 {
+			break
+		}
+		size := c.batchSize
+		if size == 0 || size > len(rest) {
+			size = len(rest)
+		}
+		batch, rest = rest[:size], rest[size:]
+		upgraded = append(append([]string(nil), upgraded...), batch...)
+		ctx.Infof("upgrading machines %s to %s (%d machines remaining)", strings.Join(batch, ", "), target, len(rest))
+	}
+
+	if err := client.SetModelAgentVersion(target, c.IgnoreAgentVersions); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if err := canaryAPI.ClearCanaryAgentVersion(); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("started upgrade to %s", target)
+	return nil
+}
+
+// abortCanaryUpgrade ends any canary upgrade in progress, returning the
+// upgraded machines to the model's agent version.
+func (c *upgradeJujuCommand) abortCanaryUpgrade(ctx *cmd.Context, client jujuClientAPI) error {
+	canaryAPI, err := c.getCanaryAPI(client)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := canaryAPI.ClearCanaryAgentVersion(); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("canary upgrade ended, upgraded machines rolled back")
+	return nil
+}
+
+// soakCanaries waits for the machines in the batch to run the target
+// version, then watches the health of all the upgraded machines for
+// the soak period.
+func (c *upgradeJujuCommand) soakCanaries(
+	ctx *cmd.Context, client statusAPI, target version.Number, batch, upgraded []string,
+) error {
+	for waited := time.Duration(0); ; waited += canaryPollInterval {
+		fullStatus, err := client.Status(nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := checkCanaryHealth(fullStatus, upgraded); err != nil {
+			return errors.Trace(err)
+		}
+		pending := pendingCanaries(fullStatus, target, batch)
+		if len(pending) == 0 {
+			break
+		}
+		if waited >= canaryUpgradeTimeout {
+			return errors.Errorf("machines %s did not upgrade within %v", strings.Join(pending, ", "), canaryUpgradeTimeout)
+		}
+		<-c.clock.After(canaryPollInterval)
+	}
+
+	ctx.Infof("machines %s upgraded, watching for %v", strings.Join(batch, ", "), c.soak)
+	for soaked := time.Duration(0); soaked < c.soak; soaked += canaryPollInterval {
+		<-c.clock.After(canaryPollInterval)
+		fullStatus, err := client.Status(nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := checkCanaryHealth(fullStatus, upgraded); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// pendingCanaries returns the machines whose agents are not yet
+// running the target version.
+func pendingCanaries(fullStatus *params.FullStatus, target version.Number, machineIds []string) []string {
+	machines := canaryMachineStatuses(fullStatus)
+	var pending []string
+	for _, id := range machineIds {
+		if machines[id].AgentStatus.Version != target.String() {
+			pending = append(pending, id)
+		}
+	}
+	return pending
+}
+
+// checkCanaryHealth returns an error if the agent of any of the given
+// machines, or of the units on them, is unhealthy.
+func checkCanaryHealth(fullStatus *params.FullStatus, machineIds []string) error {
+	machines := canaryMachineStatuses(fullStatus)
+	canaries := set.NewStrings(machineIds...)
+	for _, id := range machineIds {
+		m, ok := machines[id]
+		if !ok {
+			return errors.Errorf("machine %s has been removed", id)
+		}
+		switch status.Status(m.AgentStatus.Status) {
+		case status.Error, status.Down, status.Lost:
+			return errors.Errorf("machine %s agent is %s", id, m.AgentStatus.Status)
+		}
+	}
+	var unhealthy func(name string, unit params.UnitStatus) error
+	unhealthy = func(name string, unit params.UnitStatus) error {
+		if status.Status(unit.WorkloadStatus.Status) == status.Error {
+			return errors.Errorf("unit %s is in error", name)
+		}
+		if status.Status(unit.AgentStatus.Status) == status.Lost {
+			return errors.Errorf("unit %s agent is lost", name)
+		}
+		return nil
+	}
+	for _, app := range fullStatus.Applications {
+		for name, unit := range app.Units {
+			if !canaries.Contains(unit.Machine) {
+				continue
+			}
+			if err := unhealthy(name, unit); err != nil {
+				return err
+			}
+			for subName, sub := range unit.Subordinates {
+				if err := unhealthy(subName, sub); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// canaryMachineStatuses returns the status of the model's machines and
+// containers, keyed by id.
+func canaryMachineStatuses(fullStatus *params.FullStatus) map[string]params.MachineStatus {
+	result := make(map[string]params.MachineStatus)
+	var add func(map[string]params.MachineStatus)
+	add = func(machines map[string]params.MachineStatus) {
+		for id, m := range machines {
+			result[id] = m
+			add(m.Containers)
+		}
+	}
+	add(fullStatus.Machines)
+	return result
+}
@@ -0,0 +1,265 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	coretools "github.com/juju/juju/tools"
+)
+
+type UpgradeCanarySuite struct {
+	testing.IsolationSuite
+	api *fakeCanaryAPI
+}
+
+var _ = gc.Suite(&UpgradeCanarySuite{})
+
+var (
+	canaryFrom   = version.MustParse("2.9.0")
+	canaryTarget = version.MustParse("2.9.1")
+)
+
+func (s *UpgradeCanarySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &fakeCanaryAPI{
+		machines: []string{"0", "1", "2", "3"},
+		units:    map[string]string{"mysql/0": "1", "wordpress/0": "0/lxd/0"},
+	}
+}
+
+func (s *UpgradeCanarySuite) makeCommand(c *gc.C, canary string, batchSize int, soak time.Duration) *upgradeJujuCommand {
+	command := &upgradeJujuCommand{
+		clock:     instantClock{},
+		canaryAPI: s.api,
+		canary:    canary,
+		batchSize: batchSize,
+		soak:      soak,
+	}
+	c.Assert(command.initCanary(), jc.ErrorIsNil)
+	return command
+}
+
+func (s *UpgradeCanarySuite) TestInitCanary(c *gc.C) {
+	for i, test := range []struct {
+		canary    string
+		batchSize int
+		soak      time.Duration
+		abort     bool
+		err       string
+	}{{
+		batchSize: 2,
+		err:       "--batch-size can only be used with --canary",
+	}, {
+		canary:    "0",
+		batchSize: -1,
+		err:       "--batch-size must not be negative",
+	}, {
+		canary: "0",
+		soak:   -time.Second,
+		err:    "--soak must not be negative",
+	}, {
+		canary: "0,mysql",
+		err:    `canary "mysql" not valid`,
+	}, {
+		canary: "0",
+		abort:  true,
+		err:    "--abort-canary cannot be used with --canary",
+	}, {
+		canary: "0, 1/lxd/0,mysql/0",
+	}, {
+		abort: true,
+	}} {
+		c.Logf("test %d: %q", i, test.canary)
+		command := &upgradeJujuCommand{canary: test.canary, batchSize: test.batchSize, soak: test.soak, abortCanary: test.abort}
+		err := command.initCanary()
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+		} else {
+			c.Check(err, jc.ErrorIsNil)
+		}
+	}
+}
+
+func (s *UpgradeCanarySuite) TestUpgradeCanaries(c *gc.C) {
+	command := s.makeCommand(c, "mysql/0,wordpress/0", 1, 20*time.Second)
+	ctx := cmdtesting.Context(c)
+	err := command.upgradeCanaries(ctx, s.api, canaryTarget)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.api.canaries, jc.DeepEquals, [][]string{
+		{"0/lxd/0", "1"},
+		{"0/lxd/0", "1", "0"},
+		{"0/lxd/0", "1", "0", "2"},
+		{"0/lxd/0", "1", "0", "2", "3"},
+	})
+	c.Check(s.api.modelVersion, gc.Equals, canaryTarget)
+	c.Check(s.api.current, gc.IsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+upgrading canary machines 0/lxd/0, 1 to 2.9.1
+machines 0/lxd/0, 1 upgraded, watching for 20s
+upgrading machines 0 to 2.9.1 (2 machines remaining)
+machines 0 upgraded, watching for 20s
+upgrading machines 2 to 2.9.1 (1 machines remaining)
+machines 2 upgraded, watching for 20s
+upgrading machines 3 to 2.9.1 (0 machines remaining)
+machines 3 upgraded, watching for 20s
+started upgrade to 2.9.1
+`[1:])
+}
+
+func (s *UpgradeCanarySuite) TestUpgradeCanariesAllRemaining(c *gc.C) {
+	command := s.makeCommand(c, "2", 0, time.Minute)
+	err := command.upgradeCanaries(cmdtesting.Context(c), s.api, canaryTarget)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.canaries, jc.DeepEquals, [][]string{
+		{"2"},
+		{"2", "0", "0/lxd/0", "1", "3"},
+	})
+	c.Check(s.api.modelVersion, gc.Equals, canaryTarget)
+}
+
+func (s *UpgradeCanarySuite) TestUpgradeCanariesUnhealthyRollsBack(c *gc.C) {
+	s.api.unhealthyAfter = 3
+	command := s.makeCommand(c, "mysql/0", 1, time.Minute)
+	err := command.upgradeCanaries(cmdtesting.Context(c), s.api, canaryTarget)
+	c.Assert(err, gc.ErrorMatches, "canary upgrade to 2.9.1 failed and was rolled back: unit mysql/0 is in error")
+	c.Check(s.api.canaries, jc.DeepEquals, [][]string{{"1"}})
+	c.Check(s.api.current, gc.IsNil)
+	c.Check(s.api.modelVersion, gc.Equals, version.Zero)
+}
+
+func (s *UpgradeCanarySuite) TestUpgradeCanariesTimeout(c *gc.C) {
+	s.api.stuck = "1"
+	command := s.makeCommand(c, "1", 1, time.Minute)
+	err := command.upgradeCanaries(cmdtesting.Context(c), s.api, canaryTarget)
+	c.Assert(err, gc.ErrorMatches, "canary upgrade to 2.9.1 failed and was rolled back: machines 1 did not upgrade within 30m0s")
+	c.Check(s.api.current, gc.IsNil)
+}
+
+func (s *UpgradeCanarySuite) TestUpgradeCanariesUnknownUnit(c *gc.C) {
+	command := s.makeCommand(c, "mysql/1", 1, time.Minute)
+	err := command.upgradeCanaries(cmdtesting.Context(c), s.api, canaryTarget)
+	c.Assert(err, gc.ErrorMatches, "unit mysql/1 not found")
+	c.Check(s.api.canaries, gc.HasLen, 0)
+}
+
+func (s *UpgradeCanarySuite) TestAbortCanaryUpgrade(c *gc.C) {
+	s.api.current = []string{"1"}
+	command := &upgradeJujuCommand{canaryAPI: s.api, abortCanary: true}
+	ctx := cmdtesting.Context(c)
+	err := command.abortCanaryUpgrade(ctx, s.api)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.current, gc.IsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "canary upgrade ended, upgraded machines rolled back\n")
+}
+
+// fakeCanaryAPI simulates a model whose machine agents upgrade as
+// soon as they are made canaries.
+type fakeCanaryAPI struct {
+	machines []string
+	units    map[string]string
+
+	// stuck is a machine whose agent never upgrades.
+	stuck string
+	// unhealthyAfter is the number of status calls after which the
+	// mysql/0 unit goes into error.
+	unhealthyAfter int
+
+	statusCalls  int
+	current      []string
+	canaries     [][]string
+	modelVersion version.Number
+}
+
+func (a *fakeCanaryAPI) SetCanaryAgentVersion(v version.Number, machineIds []string) error {
+	if v != canaryTarget {
+		return errors.Errorf("unexpected version %s", v)
+	}
+	a.current = machineIds
+	a.canaries = append(a.canaries, machineIds)
+	return nil
+}
+
+func (a *fakeCanaryAPI) ClearCanaryAgentVersion() error {
+	a.current = nil
+	return nil
+}
+
+func (a *fakeCanaryAPI) Status(patterns []string) (*params.FullStatus, error) {
+	a.statusCalls++
+	machineStatus := func(id string) params.MachineStatus {
+		v := canaryFrom
+		for _, canary := range a.current {
+			if canary == id && id != a.stuck {
+				v = canaryTarget
+			}
+		}
+		return params.MachineStatus{
+			AgentStatus: params.DetailedStatus{
+				Status:  status.Started.String(),
+				Version: v.String(),
+			},
+			Containers: make(map[string]params.MachineStatus),
+		}
+	}
+	result := &params.FullStatus{
+		Machines:     make(map[string]params.MachineStatus),
+		Applications: make(map[string]params.ApplicationStatus),
+	}
+	for _, id := range a.machines {
+		result.Machines[id] = machineStatus(id)
+	}
+	for name, machine := range a.units {
+		if machine == "0/lxd/0" {
+			result.Machines["0"].Containers[machine] = machineStatus(machine)
+		}
+		workload := status.Active
+		if name == "mysql/0" && a.unhealthyAfter > 0 && a.statusCalls > a.unhealthyAfter {
+			workload = status.Error
+		}
+		appName := name[:len(name)-2]
+		result.Applications[appName] = params.ApplicationStatus{
+			Units: map[string]params.UnitStatus{
+				name: {
+					Machine:        machine,
+					WorkloadStatus: params.DetailedStatus{Status: workload.String()},
+					AgentStatus:    params.DetailedStatus{Status: status.Idle.String()},
+				},
+			},
+		}
+	}
+	return result, nil
+}
+
+func (a *fakeCanaryAPI) SetModelAgentVersion(v version.Number, ignoreAgentVersions bool) error {
+	a.modelVersion = v
+	return nil
+}
+
+func (a *fakeCanaryAPI) FindTools(majorVersion, minorVersion int, osType, arch, agentStream string) (params.FindToolsResult, error) {
+	return params.FindToolsResult{}, errors.NotImplementedf("FindTools")
+}
+
+func (a *fakeCanaryAPI) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (coretools.List, error) {
+	return nil, errors.NotImplementedf("UploadTools")
+}
+
+func (a *fakeCanaryAPI) AbortCurrentUpgrade() error {
+	return errors.NotImplementedf("AbortCurrentUpgrade")
+}
+
+func (a *fakeCanaryAPI) Close() error {
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version/v2"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+)
+
+type upgradeCheckAPI interface {
+	CheckUpgrade(target version.Number) (params.CheckUpgradeResult, error)
+	Close() error
+}
+
+func (c *upgradeJujuCommand) getUpgradeCheckAPI() (upgradeCheckAPI, error) {
+	if c.checkAPI != nil {
+		return c.checkAPI, nil
+	}
+	api, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apicontroller.NewClient(api), nil
+}
+
+// checkUpgrade runs the upgrade steps to the target version against a
+// copy of the controller's database, and reports the result of each
+// pending step. The running version isn't changed.
+func (c *upgradeJujuCommand) checkUpgrade(ctx *cmd.Context, target version.Number) error {
+	api, err := c.getUpgradeCheckAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	fmt.Fprintf(ctx.Stderr, "checking upgrade steps to %s against a copy of the controller database\n", target)
+	result, err := api.CheckUpgrade(target)
+	if errors.IsNotFound(err) || params.IsCodeNotFound(err) {
+		return errors.Errorf("%v\nagent binaries for %s are needed on the controller to check the upgrade", err, target)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Steps) == 0 {
+		fmt.Fprintf(ctx.Stderr, "no upgrade steps pending from %s to %s\n", result.FromVersion, target)
+		return nil
+	}
+
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Version", "Step", "Result")
+	failed := 0
+	lastVersion := ""
+	for _, step := range result.Steps {
+		stepVersion := step.Version
+		if stepVersion == lastVersion {
+			stepVersion = ""
+		}
+		lastVersion = step.Version
+		w.Println(stepVersion, step.Description, upgradeStepResult(step))
+		if step.Error != "" {
+			failed++
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d upgrade steps would fail", failed, len(result.Steps))
+	}
+	fmt.Fprintf(ctx.Stderr, "upgrade steps from %s to %s checked; %s\n", result.FromVersion, target, c.upgradeMessage)
+	return nil
+}
+
+// upgradeStepResult describes the result of checking an upgrade step.
+// Only the steps run against the database by the controller are
+// checked; other steps are run by each agent as it upgrades.
+func upgradeStepResult(step params.UpgradeStepCheck) string {
+	switch {
+	case !step.Checked:
+		return "not checked"
+	case step.Error != "":
+		return "failed: " + step.Error
+	}
+	return "ok"
+}
@@ -0,0 +1,128 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type UpgradeCheckSuite struct {
+	testing.IsolationSuite
+	api *fakeUpgradeCheckAPI
+}
+
+var _ = gc.Suite(&UpgradeCheckSuite{})
+
+func (s *UpgradeCheckSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &fakeUpgradeCheckAPI{
+		result: params.CheckUpgradeResult{
+			FromVersion: version.MustParse("2.9.0"),
+			Steps: []params.UpgradeStepCheck{{
+				Version:     "2.9.1",
+				Description: "add a thing",
+				Targets:     []string{"databaseMaster"},
+				Checked:     true,
+			}, {
+				Version:     "2.9.1",
+				Description: "change a file",
+				Targets:     []string{"allMachines"},
+			}, {
+				Version:     "2.9.2",
+				Description: "remove a thing",
+				Targets:     []string{"databaseMaster"},
+				Checked:     true,
+			}},
+		},
+	}
+}
+
+func (s *UpgradeCheckSuite) makeCommand() *upgradeJujuCommand {
+	return &upgradeJujuCommand{
+		baseUpgradeCommand: baseUpgradeCommand{
+			upgradeMessage: "upgrade to this version by running\n    juju upgrade-controller",
+		},
+		checkAPI: s.api,
+		check:    true,
+	}
+}
+
+func (s *UpgradeCheckSuite) TestCheckUpgrade(c *gc.C) {
+	ctx := cmdtesting.Context(c)
+	err := s.makeCommand().checkUpgrade(ctx, version.MustParse("2.9.2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.target, gc.Equals, version.MustParse("2.9.2"))
+	c.Check(s.api.closed, jc.IsTrue)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Version  Step            Result
+2.9.1    add a thing     ok
+         change a file   not checked
+2.9.2    remove a thing  ok
+`[1:])
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+checking upgrade steps to 2.9.2 against a copy of the controller database
+upgrade steps from 2.9.0 to 2.9.2 checked; upgrade to this version by running
+    juju upgrade-controller
+`[1:])
+}
+
+func (s *UpgradeCheckSuite) TestCheckUpgradeFailedSteps(c *gc.C) {
+	s.api.result.Steps[2].Error = "boom"
+	ctx := cmdtesting.Context(c)
+	err := s.makeCommand().checkUpgrade(ctx, version.MustParse("2.9.2"))
+	c.Assert(err, gc.ErrorMatches, "1 of 3 upgrade steps would fail")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Version  Step            Result
+2.9.1    add a thing     ok
+         change a file   not checked
+2.9.2    remove a thing  failed: boom
+`[1:])
+}
+
+func (s *UpgradeCheckSuite) TestCheckUpgradeNoSteps(c *gc.C) {
+	s.api.result.Steps = nil
+	ctx := cmdtesting.Context(c)
+	err := s.makeCommand().checkUpgrade(ctx, version.MustParse("2.9.2"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "no upgrade steps pending from 2.9.0 to 2.9.2\n")
+}
+
+func (s *UpgradeCheckSuite) TestCheckUpgradeMissingBinaries(c *gc.C) {
+	s.api.err = errors.NotFoundf("agent binaries for 2.9.2-ubuntu-amd64 on the controller")
+	err := s.makeCommand().checkUpgrade(cmdtesting.Context(c), version.MustParse("2.9.2"))
+	c.Assert(err, gc.ErrorMatches, `agent binaries for 2.9.2-ubuntu-amd64 on the controller not found
+agent binaries for 2.9.2 are needed on the controller to check the upgrade`)
+}
+
+func (s *UpgradeCheckSuite) TestCheckWithDryRun(c *gc.C) {
+	command := &upgradeControllerCommand{check: true}
+	command.DryRun = true
+	err := command.Init(nil)
+	c.Assert(err, gc.ErrorMatches, "--check cannot be used with --dry-run")
+}
+
+type fakeUpgradeCheckAPI struct {
+	result params.CheckUpgradeResult
+	err    error
+	target version.Number
+	closed bool
+}
+
+func (a *fakeUpgradeCheckAPI) CheckUpgrade(target version.Number) (params.CheckUpgradeResult, error) {
+	a.target = target
+	return a.result, a.err
+}
+
+func (a *fakeUpgradeCheckAPI) Close() error {
+	a.closed = true
+	return nil
+}
@@ -52,8 +52,15 @@ The command will abort if an upgrade is in progress. It will also abort if
 a previous upgrade was not fully completed (e.g.: if one of the
 controllers in a high availability model failed to upgrade).
 
+The '--check' option lists the upgrade steps pending for the selected
+version, without changing the running version. The steps which the
+controller runs against its database are tried on a copy of the database,
+and any which would fail are reported. The agent binaries for the selected
+version must be available to the controller.
+
 Examples:
     juju upgrade-controller --dry-run
+    juju upgrade-controller --check --agent-version 2.0.1
     juju upgrade-controller --agent-version 2.0.1
     
 See also: 
@@ -71,6 +78,10 @@ type upgradeControllerCommand struct {
 
 	upgradeJujuAPI jujuClientAPI
 	rawArgs        []string
+
+	// check is true if the pending upgrade steps should be checked
+	// rather than the controller upgraded.
+	check bool
 }
 
 func (c *upgradeControllerCommand) Info() *cmd.Info {
@@ -84,6 +95,17 @@ func (c *upgradeControllerCommand) Info() *cmd.Info {
 func (c *upgradeControllerCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ControllerCommandBase.SetFlags(f)
 	c.baseUpgradeCommand.SetFlags(f)
+	f.BoolVar(&c.check, "check", false, "Don't upgrade, just list the pending upgrade steps and check them against a copy of the controller database")
+}
+
+func (c *upgradeControllerCommand) Init(args []string) error {
+	if err := c.baseUpgradeCommand.Init(args); err != nil {
+		return err
+	}
+	if c.check && c.DryRun {
+		return errors.New("--check cannot be used with --dry-run")
+	}
+	return nil
 }
 
 func (c *upgradeControllerCommand) getUpgradeJujuAPI() (jujuClientAPI, error) {
@@ -191,6 +213,9 @@ func (c *upgradeControllerCommand) upgradeCAASController(ctx *cmd.Context) error
 	if c.BuildAgent {
 		return errors.NotSupportedf("--build-agent for k8s controller upgrades")
 	}
+	if c.check {
+		return errors.NotSupportedf("--check for k8s controller upgrades")
+	}
 	client, err := c.getUpgradeJujuAPI()
 	if err != nil {
 		return err
@@ -313,9 +338,12 @@ func (c *baseUpgradeCommand) initCAASVersions(
 }
 
 func (c *upgradeControllerCommand) upgradeIAASController(ctx *cmd.Context, controllerModel string) error {
-	jcmd := &upgradeJujuCommand{baseUpgradeCommand: baseUpgradeCommand{
-		upgradeMessage: "upgrade to this version by running\n    juju upgrade-controller",
-	}}
+	jcmd := &upgradeJujuCommand{
+		baseUpgradeCommand: baseUpgradeCommand{
+			upgradeMessage: "upgrade to this version by running\n    juju upgrade-controller",
+		},
+		check: c.check,
+	}
 	jcmd.SetClientStore(c.ClientStore())
 	wrapped := modelcmd.Wrap(jcmd)
 	args := append(c.rawArgs, "-m", controllerModel)
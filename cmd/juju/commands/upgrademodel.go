@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -68,17 +69,29 @@ the lifetime of this upgrade using --agent-stream
 If a failed upgrade has been resolved, '--reset-previous-upgrade' can be
 used to allow the upgrade to proceed.
 Backups are recommended prior to upgrading.
+The upgrade can be staged with '--canary': the given machines, and the
+machines hosting the given units, are upgraded first and their health is
+watched for the '--soak' period. The rest of the model's machines are then
+upgraded in batches of '--batch-size' machines, each watched in the same
+way, before the model itself is upgraded. If an upgraded machine's agent,
+or a unit on it, goes into error or is lost, the upgraded machines are
+rolled back to the model's agent version. The progress of a canary upgrade
+is shown by ` + "`juju status`" + `. If the command is interrupted, the canary
+upgrade stays in progress until it is ended with '--abort-canary', which
+rolls the upgraded machines back to the model's agent version.
 
 Examples:
     juju upgrade-model --dry-run
     juju upgrade-model --agent-version 2.0.1
     juju upgrade-model --agent-stream proposed
+    juju upgrade-model --canary 0,mysql/1 --batch-size 5 --soak 30m
+    juju upgrade-model --abort-canary
     
 See also: 
     sync-agent-binaries`
 
 func newUpgradeJujuCommand() cmd.Command {
-	command := &upgradeJujuCommand{}
+	command := &upgradeJujuCommand{clock: clock.WallClock}
 	return modelcmd.Wrap(command)
 }
 
@@ -96,6 +109,7 @@ func newUpgradeJujuCommandForTest(
 			controllerAPI:   controllerAPI,
 		},
 		jujuClientAPI: jujuClientAPI,
+		clock:         clock.WallClock,
 	}
 	command.SetClientStore(store)
 	return modelcmd.Wrap(command, options...)
@@ -226,6 +240,23 @@ type upgradeJujuCommand struct {
 	baseUpgradeCommand
 
 	jujuClientAPI jujuClientAPI
+	canaryAPI     canaryUpgradeAPI
+	checkAPI      upgradeCheckAPI
+	clock         clock.Clock
+
+	// check is set by upgrade-controller --check to check the upgrade
+	// steps to the chosen version instead of upgrading.
+	check bool
+
+	// canary holds the comma separated machines and units upgraded
+	// first by a canary upgrade.
+	canary         string
+	canaryEntities []string
+	batchSize      int
+	soak           time.Duration
+
+	// abortCanary is set to end a canary upgrade left in progress.
+	abortCanary bool
 }
 
 func (c *upgradeJujuCommand) Info() *cmd.Info {
@@ -240,6 +271,17 @@ func (c *upgradeJujuCommand) Info() *cmd.Info {
 func (c *upgradeJujuCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	c.baseUpgradeCommand.SetFlags(f)
+	f.StringVar(&c.canary, "canary", "", "Upgrade these comma separated machines and units first, watching their health before upgrading the rest of the model")
+	f.IntVar(&c.batchSize, "batch-size", 0, "The number of machines upgraded in each batch after the canaries (0 upgrades all remaining machines at once)")
+	f.DurationVar(&c.soak, "soak", defaultCanarySoak, "How long to watch the upgraded machines after each batch")
+	f.BoolVar(&c.abortCanary, "abort-canary", false, "End a canary upgrade left in progress, rolling back the upgraded machines")
+}
+
+func (c *upgradeJujuCommand) Init(args []string) error {
+	if err := c.baseUpgradeCommand.Init(args); err != nil {
+		return err
+	}
+	return c.initCanary()
 }
 
 var (
@@ -366,6 +408,14 @@ func (c *upgradeJujuCommand) getControllerAPI() (controllerAPI, error) {
 
 // Run changes the version proposed for the juju envtools.
 func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
+	if c.abortCanary {
+		client, err := c.getJujuClientAPI()
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		return c.abortCanaryUpgrade(ctx, client)
+	}
 	modelType, err := c.ModelType()
 	if err != nil {
 		return errors.Trace(err)
@@ -436,6 +486,9 @@ func (c *upgradeJujuCommand) upgradeModel(ctx *cmd.Context, implicitUploadAllowe
 	}
 	haveControllerModelPermission := err == nil
 	isControllerModel := haveControllerModelPermission && cfg.UUID() == controllerModelConfig[config.UUIDKey]
+	if c.canary != "" && isControllerModel {
+		return errors.New("--canary cannot be used with the controller model")
+	}
 	if c.BuildAgent {
 		// For UploadTools, model must be the "controller" model,
 		// that is, modelUUID == controllerUUID
@@ -534,12 +587,17 @@ func (c *upgradeJujuCommand) upgradeModel(ctx *cmd.Context, implicitUploadAllowe
 	if warnCompat {
 		fmt.Fprintf(ctx.Stderr, "version %s incompatible with this client (%s)\n", upgradeCtx.chosen, jujuversion.Current)
 	}
+	if c.check {
+		return c.checkUpgrade(ctx, upgradeCtx.chosen)
+	}
 	if c.DryRun {
 		if c.BuildAgent {
 			fmt.Fprintf(ctx.Stderr, "%s --build-agent\n", c.upgradeMessage)
 		} else {
 			fmt.Fprintf(ctx.Stderr, "%s\n", c.upgradeMessage)
 		}
+	} else if c.canary != "" {
+		return c.upgradeCanaries(ctx, client, upgradeCtx.chosen)
 	} else {
 		return c.notifyControllerUpgrade(ctx, client, upgradeCtx)
 	}
@@ -189,3 +189,13 @@ func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, er
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewControllerHealthCommandForTest returns a controllerHealthCommand
+// with the API mocked out.
+func NewControllerHealthCommandForTest(api ControllerHealthAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &controllerHealthCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -0,0 +1,306 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewControllerHealthCommand returns a command that reports on the
+// health of the controllers.
+func NewControllerHealthCommand() cmd.Command {
+	return modelcmd.WrapController(&controllerHealthCommand{})
+}
+
+// ControllerHealthAPI defines the methods on the controller API used
+// by the controller-health command.
+type ControllerHealthAPI interface {
+	Close() error
+	ControllerHealth() (params.ControllerHealthResult, error)
+}
+
+type controllerHealthCommand struct {
+	modelcmd.ControllerCommandBase
+	api ControllerHealthAPI
+	out cmd.Output
+}
+
+const controllerHealthDoc = `
+Report on the health of each of the controllers of the current or
+specified controller.
+
+For each controller, the state of its raft node is shown along with the
+raft leader and term, and how many log entries the node has yet to apply
+compared with the most up to date controller. The number of successful
+lease claims made through the controller and their median and 99th
+percentile latencies are shown, as is whether the controller is
+connected to each of the other controllers to forward hub messages.
+Controllers which don't respond are reported as such.
+
+The state of each member of the Mongo replica set is also shown.
+
+Examples:
+    juju controller-health
+    juju controller-health -c mycontroller --format yaml
+
+See also:
+    enable-ha
+    show-controller
+`
+
+// Info implements Command.Info.
+func (c *controllerHealthCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "controller-health",
+		Purpose: "Reports on the health of the controllers.",
+		Doc:     controllerHealthDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *controllerHealthCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatControllerHealthTabular,
+	})
+}
+
+func (c *controllerHealthCommand) getAPI() (ControllerHealthAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *controllerHealthCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.ControllerHealth()
+	if errors.IsNotSupported(err) {
+		return errors.New("controller-health is not supported by this controller")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, convertControllerHealth(result))
+}
+
+// ControllerHealth holds the health of the controllers for output.
+type ControllerHealth struct {
+	Controllers []ControllerAgentHealth `yaml:"controllers" json:"controllers"`
+	Mongo       []MongoMemberHealth     `yaml:"mongo,omitempty" json:"mongo,omitempty"`
+	MongoError  string                  `yaml:"mongo-error,omitempty" json:"mongo-error,omitempty"`
+}
+
+// ControllerAgentHealth holds the health reported by a controller
+// agent for output.
+type ControllerAgentHealth struct {
+	Id          string            `yaml:"id" json:"id"`
+	Responded   bool              `yaml:"responded" json:"responded"`
+	Raft        *RaftHealth       `yaml:"raft,omitempty" json:"raft,omitempty"`
+	LeaseClaims *LeaseClaimHealth `yaml:"lease-claims,omitempty" json:"lease-claims,omitempty"`
+	Forwarders  map[string]string `yaml:"forwarders,omitempty" json:"forwarders,omitempty"`
+	Errors      []string          `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+// RaftHealth holds the state of a controller's raft node for output.
+type RaftHealth struct {
+	State        string `yaml:"state" json:"state"`
+	Leader       string `yaml:"leader,omitempty" json:"leader,omitempty"`
+	Term         uint64 `yaml:"term" json:"term"`
+	AppliedIndex uint64 `yaml:"applied-index" json:"applied-index"`
+	LastIndex    uint64 `yaml:"last-index" json:"last-index"`
+	Lag          uint64 `yaml:"lag" json:"lag"`
+	LastContact  string `yaml:"last-contact,omitempty" json:"last-contact,omitempty"`
+}
+
+// LeaseClaimHealth holds the successful lease claims made through a
+// controller for output. Latencies are in milliseconds.
+type LeaseClaimHealth struct {
+	Count  uint64  `yaml:"count" json:"count"`
+	Median float64 `yaml:"median-ms" json:"median-ms"`
+	P99    float64 `yaml:"p99-ms" json:"p99-ms"`
+}
+
+// MongoMemberHealth holds the status of a Mongo replica set member for
+// output.
+type MongoMemberHealth struct {
+	Id           int    `yaml:"id" json:"id"`
+	ControllerId string `yaml:"controller-id,omitempty" json:"controller-id,omitempty"`
+	Address      string `yaml:"address" json:"address"`
+	State        string `yaml:"state" json:"state"`
+	Healthy      bool   `yaml:"healthy" json:"healthy"`
+	Uptime       string `yaml:"uptime" json:"uptime"`
+	Ping         string `yaml:"ping" json:"ping"`
+	Message      string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func convertControllerHealth(result params.ControllerHealthResult) ControllerHealth {
+	var health ControllerHealth
+	for _, agent := range result.Agents {
+		out := ControllerAgentHealth{
+			Id:         agent.ControllerId,
+			Responded:  agent.Responded,
+			Forwarders: agent.Forwarders,
+			Errors:     agent.Errors,
+		}
+		if agent.Responded {
+			out.Raft = &RaftHealth{
+				State:        agent.RaftState,
+				Leader:       agent.RaftLeader,
+				Term:         agent.RaftTerm,
+				AppliedIndex: agent.RaftAppliedIndex,
+				LastIndex:    agent.RaftLastIndex,
+				Lag:          agent.RaftLag,
+				LastContact:  agent.RaftLastContact,
+			}
+			out.LeaseClaims = &LeaseClaimHealth{
+				Count:  agent.LeaseClaims,
+				Median: agent.LeaseClaimMedian,
+				P99:    agent.LeaseClaimP99,
+			}
+		}
+		health.Controllers = append(health.Controllers, out)
+	}
+	for _, member := range result.MongoMembers {
+		health.Mongo = append(health.Mongo, MongoMemberHealth{
+			Id:           member.Id,
+			ControllerId: member.ControllerId,
+			Address:      member.Address,
+			State:        member.State,
+			Healthy:      member.Healthy,
+			Uptime:       member.Uptime.String(),
+			Ping:         member.Ping.String(),
+			Message:      member.Message,
+		})
+	}
+	if result.MongoError != nil {
+		health.MongoError = result.MongoError.Error()
+	}
+	return health
+}
+
+func formatControllerHealthTabular(writer io.Writer, value interface{}) error {
+	health, ok := value.(ControllerHealth)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", health, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Controller", "Raft", "Leader", "Term", "Lag", "Claims", "Claim p50", "Claim p99", "Forwarding")
+	tw.SetColumnAlignRight(3)
+	tw.SetColumnAlignRight(4)
+	tw.SetColumnAlignRight(5)
+	tw.SetColumnAlignRight(6)
+	tw.SetColumnAlignRight(7)
+	var errs []string
+	for _, agent := range health.Controllers {
+		for _, err := range agent.Errors {
+			errs = append(errs, fmt.Sprintf("controller %s: %s", agent.Id, err))
+		}
+		if !agent.Responded {
+			w.Println(agent.Id, "no response")
+			continue
+		}
+		leader := agent.Raft.Leader
+		if leader == "" {
+			leader = noValueDisplay
+		}
+		w.Println(
+			agent.Id,
+			strings.ToLower(agent.Raft.State),
+			leader,
+			agent.Raft.Term,
+			agent.Raft.Lag,
+			agent.LeaseClaims.Count,
+			formatMilliseconds(agent.LeaseClaims.Median),
+			formatMilliseconds(agent.LeaseClaims.P99),
+			formatForwarders(agent.Forwarders),
+		)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+
+	fmt.Fprintln(writer)
+	if health.MongoError != "" {
+		fmt.Fprintf(writer, "Mongo: %s\n", health.MongoError)
+	} else {
+		tw = output.TabWriter(writer)
+		w = output.Wrapper{tw}
+		w.Println("Mongo", "Controller", "Address", "State", "Healthy", "Uptime", "Ping", "Message")
+		for _, member := range health.Mongo {
+			controllerId := member.ControllerId
+			if controllerId == "" {
+				controllerId = noValueDisplay
+			}
+			healthy := "no"
+			if member.Healthy {
+				healthy = "yes"
+			}
+			w.Println(
+				member.Id, controllerId, member.Address, member.State, healthy,
+				member.Uptime, member.Ping, member.Message,
+			)
+		}
+		if err := tw.Flush(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(errs) > 0 {
+		fmt.Fprintln(writer)
+		for _, err := range errs {
+			fmt.Fprintln(writer, err)
+		}
+	}
+	return nil
+}
+
+func formatMilliseconds(ms float64) string {
+	return time.Duration(ms * float64(time.Millisecond)).String()
+}
+
+// formatForwarders summarises the pubsub forwarding connections to the
+// other controllers, listing those which aren't connected.
+func formatForwarders(forwarders map[string]string) string {
+	if len(forwarders) == 0 {
+		return noValueDisplay
+	}
+	var connected int
+	var disconnected []string
+	for target, status := range forwarders {
+		if status == "connected" {
+			connected++
+		} else {
+			disconnected = append(disconnected, target)
+		}
+	}
+	summary := fmt.Sprintf("%d/%d connected", connected, len(forwarders))
+	if len(disconnected) > 0 {
+		sort.Strings(disconnected)
+		summary += " (" + strings.Join(disconnected, ", ") + " not connected)"
+	}
+	return summary
+}
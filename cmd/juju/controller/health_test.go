@@ -0,0 +1,165 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type controllerHealthSuite struct {
+	baseControllerSuite
+	api   *fakeControllerHealthAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&controllerHealthSuite{})
+
+func (s *controllerHealthSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeControllerHealthAPI{result: params.ControllerHealthResult{
+		Agents: []params.ControllerAgentHealth{{
+			ControllerId:     "0",
+			Responded:        true,
+			RaftState:        "Leader",
+			RaftLeader:       "0",
+			RaftTerm:         3,
+			RaftAppliedIndex: 12,
+			RaftLastIndex:    12,
+			LeaseClaims:      20,
+			LeaseClaimMedian: 1.5,
+			LeaseClaimP99:    12,
+			Forwarders:       map[string]string{"machine-1": "connected", "machine-2": "connected"},
+		}, {
+			ControllerId:     "1",
+			Responded:        true,
+			RaftState:        "Follower",
+			RaftLeader:       "0",
+			RaftTerm:         3,
+			RaftAppliedIndex: 9,
+			RaftLastIndex:    12,
+			RaftLag:          3,
+			RaftLastContact:  "2021-05-01T12:00:00Z",
+			Forwarders:       map[string]string{"machine-0": "connected", "machine-2": "disconnected"},
+			Errors:           []string{"lease claims: boom"},
+		}, {
+			ControllerId: "2",
+		}},
+		MongoMembers: []params.MongoMemberHealth{{
+			Id:           1,
+			ControllerId: "0",
+			Address:      "10.0.0.1:37017",
+			State:        "PRIMARY",
+			Healthy:      true,
+			Uptime:       time.Hour,
+		}, {
+			Id:           2,
+			ControllerId: "1",
+			Address:      "10.0.0.2:37017",
+			State:        "SECONDARY",
+			Healthy:      true,
+			Uptime:       time.Hour,
+			Ping:         2 * time.Millisecond,
+		}, {
+			Id:           3,
+			ControllerId: "2",
+			Address:      "10.0.0.3:37017",
+			State:        "(not reachable/healthy)",
+			Message:      "no route to host",
+		}},
+	}}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *controllerHealthSuite) newCommand() cmd.Command {
+	return controller.NewControllerHealthCommandForTest(s.api, s.store)
+}
+
+func (s *controllerHealthSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Controller  Raft      Leader  Term  Lag  Claims  Claim p50  Claim p99  Forwarding\n"+
+		"0           leader    0          3    0      20      1.5ms       12ms  2/2 connected\n"+
+		"1           follower  0          3    3       0         0s         0s  1/2 connected (machine-2 not connected)\n"+
+		"2           no response\n"+
+		"\n"+
+		"Mongo  Controller  Address         State                    Healthy  Uptime  Ping  Message\n"+
+		"1      0           10.0.0.1:37017  PRIMARY                  yes      1h0m0s  0s    \n"+
+		"2      1           10.0.0.2:37017  SECONDARY                yes      1h0m0s  2ms   \n"+
+		"3      2           10.0.0.3:37017  (not reachable/healthy)  no       0s      0s    no route to host\n"+
+		"\n"+
+		"controller 1: lease claims: boom\n"+
+		"\n",
+	)
+}
+
+func (s *controllerHealthSuite) TestYAML(c *gc.C) {
+	s.api.result.Agents = s.api.result.Agents[1:]
+	s.api.result.MongoMembers = nil
+	s.api.result.MongoError = &params.Error{Message: "not running with --replSet"}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+controllers:
+- id: "1"
+  responded: true
+  raft:
+    state: Follower
+    leader: "0"
+    term: 3
+    applied-index: 9
+    last-index: 12
+    lag: 3
+    last-contact: "2021-05-01T12:00:00Z"
+  lease-claims:
+    count: 0
+    median-ms: 0
+    p99-ms: 0
+  forwarders:
+    machine-0: connected
+    machine-2: disconnected
+  errors:
+  - 'lease claims: boom'
+- id: "2"
+  responded: false
+mongo-error: not running with --replSet
+`[1:])
+}
+
+func (s *controllerHealthSuite) TestNotSupported(c *gc.C) {
+	s.api.err = errors.NotSupportedf("controller health")
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "controller-health is not supported by this controller")
+}
+
+func (s *controllerHealthSuite) TestUnrecognizedArg(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "whoops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
+}
+
+type fakeControllerHealthAPI struct {
+	result params.ControllerHealthResult
+	err    error
+}
+
+func (f *fakeControllerHealthAPI) Close() error {
+	return nil
+}
+
+func (f *fakeControllerHealthAPI) ControllerHealth() (params.ControllerHealthResult, error) {
+	return f.result, f.err
+}
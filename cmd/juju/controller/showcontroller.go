@@ -5,6 +5,7 @@ package controller
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/juju/cmd"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	corecontroller "github.com/juju/juju/core/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/core/status"
@@ -287,6 +289,10 @@ type ShowControllerDetails struct {
 	// Nodes is a collection of all k8s pods forming the controller cluster.
 	Nodes map[string]MachineDetails `yaml:"controller-nodes,omitempty" json:"controller-nodes,omitempty"`
 
+	// Zones describes how the controller machines are spread across
+	// availability zones.
+	Zones *ControllerZones `yaml:"controller-zones,omitempty" json:"controller-zones,omitempty"`
+
 	// Models is a collection of all models for this controller.
 	Models map[string]ModelDetails `yaml:"models,omitempty" json:"models,omitempty"`
 
@@ -358,6 +364,23 @@ type MachineDetails struct {
 
 	// HAPrimary is set to true for a primary controller machine in HA.
 	HAPrimary bool `yaml:"ha-primary,omitempty" json:"ha-primary,omitempty"`
+
+	// AvailabilityZone holds the availability zone of the machine.
+	AvailabilityZone string `yaml:"availability-zone,omitempty" json:"availability-zone,omitempty"`
+}
+
+// ControllerZones describes how the controller machines are spread
+// across availability zones.
+type ControllerZones struct {
+	// Machines holds the ids of the controller machines in each zone.
+	Machines map[string][]string `yaml:"machines" json:"machines"`
+
+	// SurvivesZoneFailure is true if the controllers keep quorum when
+	// any one zone fails.
+	SurvivesZoneFailure bool `yaml:"survives-zone-failure" json:"survives-zone-failure"`
+
+	// Warning describes which zone's failure would lose quorum.
+	Warning string `yaml:"warning,omitempty" json:"warning,omitempty"`
 }
 
 // ModelDetails holds details of a model to show.
@@ -545,8 +568,42 @@ func (c *showControllerCommand) convertMachinesForShow(
 				details.HAPrimary = *m.HAPrimary
 			}
 		}
+		if m.Hardware != nil && m.Hardware.AvailabilityZone != nil {
+			details.AvailabilityZone = *m.Hardware.AvailabilityZone
+		}
 		nodes[m.Id] = details
 	}
+	if numControllers > 1 {
+		controller.Zones = controllerZones(nodes)
+	}
+}
+
+// controllerZones returns how the given controller machines are spread
+// across availability zones, or nil if none of their zones are known.
+func controllerZones(nodes map[string]MachineDetails) *ControllerZones {
+	zones := &ControllerZones{Machines: make(map[string][]string)}
+	voters := make(map[string]int)
+	for id, details := range nodes {
+		voters[details.AvailabilityZone]++
+		if details.AvailabilityZone == "" {
+			continue
+		}
+		zones.Machines[details.AvailabilityZone] = append(zones.Machines[details.AvailabilityZone], id)
+	}
+	if len(zones.Machines) == 0 {
+		return nil
+	}
+	for _, ids := range zones.Machines {
+		sort.Strings(ids)
+	}
+	zone, lost := corecontroller.ZoneFailureLosesQuorum(voters)
+	zones.SurvivesZoneFailure = !lost
+	if lost {
+		zones.Warning = fmt.Sprintf(
+			"%d of %d controllers are in zone %q; losing the zone would lose quorum",
+			voters[zone], len(nodes), zone)
+	}
+	return zones
 }
 
 func haStatus(hasVote bool, wantsVote bool, statusStr string) string {
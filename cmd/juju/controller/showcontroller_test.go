@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apicontroller "github.com/juju/juju/api/controller"
 	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/permission"
 	"github.com/juju/juju/jujuclient"
@@ -285,6 +286,63 @@ aws-test:
 	s.assertShowController(c, "aws-test")
 }
 
+func (s *ShowControllerSuite) TestShowControllerZones(c *gc.C) {
+	zoneA, zoneB := "zone-a", "zone-b"
+	machines := s.fakeController.machines["ghi"]
+	machines[0].Hardware = &instance.HardwareCharacteristics{AvailabilityZone: &zoneA}
+	machines[1].Hardware = &instance.HardwareCharacteristics{AvailabilityZone: &zoneA}
+	machines[2].Hardware = &instance.HardwareCharacteristics{AvailabilityZone: &zoneB}
+	s.createTestClientStore(c)
+
+	s.expectedOutput = `
+aws-test:
+  details:
+    uuid: this-is-the-aws-test-uuid
+    controller-uuid: this-is-the-aws-test-uuid
+    api-endpoints: [this-is-aws-test-of-many-api-endpoints]
+    cloud: aws
+    region: us-east-1
+    agent-version: 999.99.99
+    agent-git-commit: badf00d0badf00d0badf00d0badf00d0badf00d0
+    controller-model-version: 999.99.99
+    mongo-version: 3.5.12
+    ca-cert: this-is-aws-test-ca-cert
+  controller-machines:
+    "0":
+      instance-id: id-0
+      ha-status: ha-pending
+      availability-zone: zone-a
+    "1":
+      instance-id: id-1
+      ha-status: down, lost connection
+      availability-zone: zone-a
+    "2":
+      instance-id: id-2
+      ha-status: ha-enabled
+      availability-zone: zone-b
+  controller-zones:
+    machines:
+      zone-a:
+      - "0"
+      - "1"
+      zone-b:
+      - "2"
+    survives-zone-failure: false
+    warning: 2 of 3 controllers are in zone "zone-a"; losing the zone would lose quorum
+  models:
+    controller:
+      uuid: ghi
+      model-uuid: ghi
+      machine-count: 2
+      core-count: 4
+  current-model: admin/controller
+  account:
+    user: admin
+    access: superuser
+`[1:]
+	s.assertShowController(c, "aws-test")
+}
+
 func (s *ShowControllerSuite) TestShowSomeControllerMoreInStore(c *gc.C) {
 	s.createTestClientStore(c)
 	s.expectedOutput = `
@@ -6,17 +6,22 @@ package machine
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 	"github.com/juju/utils/v2/winrm"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/api/spaces"
+	"github.com/juju/juju/api/subnets"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
@@ -77,6 +82,22 @@ Manual provisioning is the process of installing Juju on an existing machine
 and bringing it under Juju's management. The Juju controller must be able to
 access the new machine over the network.
 
+When provisioning via SSH, the --import-network option reads the machine's
+netplan or ifupdown configuration and adds the subnets of any statically
+configured addresses to the model. Subnets are placed in the default space,
+unless a YAML file is supplied with --space-map, mapping space names to the
+interface names or CIDRs of the subnets to place in them:
+
+    internal: [eth0, 10.10.0.0/24]
+    storage: [bond0]
+
+Spaces named in the file are created if they do not already exist. Subnets
+already known to the model are left unchanged. Each interface or CIDR may
+appear only once in the file. The file is checked against the machine's
+subnets before it is provisioned, and the machine is not added if an
+interface or CIDR in the file matches none of them.
+Supplying --space-map implies --import-network.
+
 
 Container creation
 
@@ -122,6 +143,10 @@ Examples:
 	# Allocate a machine to the model via SSH
 	juju add-machine ssh:user@10.10.0.3
 
+	# Allocate a machine to the model via SSH, importing its subnets into
+	# the spaces named in a mapping file
+	juju add-machine ssh:user@10.10.0.3 --space-map spaces.yaml
+
 	# Allocate a machine to the model via WinRM
 	juju add-machine winrm:user@10.10.0.3
 
@@ -162,6 +187,15 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// ImportNetwork indicates whether the subnets configured on a machine
+	// provisioned via SSH are to be imported into the model.
+	ImportNetwork bool
+	// SpaceMap is a YAML file mapping space names to the interface names
+	// or CIDRs of the imported subnets to place in them.
+	SpaceMap cmd.FileVar
+
+	subnetsAPI SubnetImportAPI
+	spacesAPI  SpaceAPI
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -179,6 +213,8 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Machine constraints that overwrite those available from 'juju get-model-constraints' and provider's defaults")
 	f.Var(disksFlag{&c.Disks}, "disks", "Storage constraints for disks to attach to the machine(s)")
+	f.BoolVar(&c.ImportNetwork, "import-network", false, "Import the subnets configured on a machine added via SSH")
+	f.Var(&c.SpaceMap, "space-map", "YAML file mapping space names to the interfaces or CIDRs of imported subnets")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -200,6 +236,12 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.SpaceMap.Path != "" {
+		c.ImportNetwork = true
+	}
+	if c.ImportNetwork && (c.Placement == nil || c.Placement.Scope != sshScope) {
+		return errors.New("--import-network is only supported when adding a machine via SSH")
+	}
 	return nil
 }
 
@@ -223,6 +265,21 @@ type MachineManagerAPI interface {
 	Close() error
 }
 
+// SubnetImportAPI describes the API used to import the
+// subnets of a manually provisioned machine.
+type SubnetImportAPI interface {
+	ImportSubnets([]params.ImportSubnetParams) ([]params.ErrorResult, error)
+	Close() error
+}
+
+// SpaceAPI describes the API used to ensure that the spaces
+// into which subnets are imported exist.
+type SpaceAPI interface {
+	ListSpaces() ([]params.Space, error)
+	CreateSpace(name string, cidrs []string, public bool) error
+	Close() error
+}
+
 // splitUserHost given a host string of example user@192.168.122.122
 // it will return user and 192.168.122.122
 func splitUserHost(host string) (string, string) {
@@ -266,12 +323,40 @@ func (c *addCommand) getMachineManagerAPI() (MachineManagerAPI, error) {
 	return c.NewMachineManagerClient()
 }
 
+func (c *addCommand) getSubnetImportAPI() (SubnetImportAPI, error) {
+	if c.subnetsAPI != nil {
+		return c.subnetsAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return subnets.NewAPI(root), nil
+}
+
+func (c *addCommand) getSpaceAPI() (SpaceAPI, error) {
+	if c.spacesAPI != nil {
+		return c.spacesAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return spaces.NewAPI(root), nil
+}
+
 func (c *addCommand) Run(ctx *cmd.Context) error {
 	var err error
 	c.Constraints, err = common.ParseConstraints(ctx, c.ConstraintsStr)
 	if err != nil {
 		return err
 	}
+	var spaceMap map[string][]string
+	if c.SpaceMap.Path != "" {
+		if spaceMap, err = readSpaceMap(ctx, c.SpaceMap); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	client, err := c.getClientAPI()
 	if err != nil {
 		return errors.Trace(err)
@@ -306,9 +391,22 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	// The host's subnets are checked against the space map before the
+	// machine is provisioned; --import-network implies SSH placement.
+	var networkPlan *networkImport
+	if c.ImportNetwork {
+		_, host := splitUserHost(c.Placement.Directive)
+		if networkPlan, err = planNetworkImport(host, spaceMap); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	if c.Placement != nil {
 		err := c.tryManualProvision(client, cfg, ctx)
 		if err != errNonManualScope {
+			if err == nil && networkPlan != nil {
+				err = c.importNetwork(ctx, networkPlan)
+			}
 			return err
 		}
 	}
@@ -380,6 +478,7 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 
 var (
 	sshProvisioner    = sshprovisioner.ProvisionMachine
+	detectHostSubnets = sshprovisioner.DetectHostSubnets
 	winrmProvisioner  = winrmprovisioner.ProvisionMachine
 	errNonManualScope = errors.New("non-manual scope")
 	sshScope          = "ssh"
@@ -465,3 +564,167 @@ func (c *addCommand) provisionWinRM(args manual.ProvisionMachineArgs) (string, e
 	}
 	return winrmProvisioner(args)
 }
+
+// readSpaceMap reads the YAML file mapping space names to the
+// interface names or CIDRs of the subnets to place in them.
+func readSpaceMap(ctx *cmd.Context, file cmd.FileVar) (map[string][]string, error) {
+	data, err := file.Read(ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading space map")
+	}
+	var spaceMap map[string][]string
+	if err := yaml.Unmarshal(data, &spaceMap); err != nil {
+		return nil, errors.Annotate(err, "parsing space map")
+	}
+	spaces := make([]string, 0, len(spaceMap))
+	for space := range spaceMap {
+		spaces = append(spaces, space)
+	}
+	sort.Strings(spaces)
+	// An interface or CIDR may only be placed in one space.
+	seen := make(map[string]string)
+	for _, space := range spaces {
+		if !names.IsValidSpace(space) {
+			return nil, errors.NotValidf("space name %q in space map", space)
+		}
+		for _, key := range spaceMap[space] {
+			if other, ok := seen[key]; ok && other != space {
+				return nil, errors.NotValidf("space map entry %q in both spaces %q and %q", key, other, space)
+			} else if ok {
+				return nil, errors.NotValidf("space map entry %q repeated in space %q", key, space)
+			}
+			seen[key] = space
+		}
+	}
+	return spaceMap, nil
+}
+
+// networkImport describes the subnets to import from a machine
+// added via SSH, and the spaces they are to be placed in.
+type networkImport struct {
+	hostSubnets []manual.HostSubnet
+	args        []params.ImportSubnetParams
+	spaces      set.Strings
+}
+
+// planNetworkImport detects the subnets configured on the host and
+// matches them against the space map. It is run before the machine is
+// provisioned, so that a space map which does not fit the host is
+// reported without leaving a half added machine behind.
+func planNetworkImport(host string, spaceMap map[string][]string) (*networkImport, error) {
+	hostSubnets, err := detectHostSubnets(host)
+	if err != nil {
+		return nil, errors.Annotate(err, "importing network configuration")
+	}
+
+	spaceFor := make(map[string]string)
+	for space, keys := range spaceMap {
+		for _, key := range keys {
+			spaceFor[key] = space
+		}
+	}
+	plan := &networkImport{
+		hostSubnets: hostSubnets,
+		args:        make([]params.ImportSubnetParams, len(hostSubnets)),
+		spaces:      set.NewStrings(),
+	}
+	unmatched := set.NewStrings()
+	for key := range spaceFor {
+		unmatched.Add(key)
+	}
+	for i, subnet := range hostSubnets {
+		space, ok := spaceFor[subnet.CIDR]
+		if !ok {
+			space = spaceFor[subnet.Interface]
+		}
+		unmatched.Remove(subnet.CIDR)
+		unmatched.Remove(subnet.Interface)
+		plan.args[i].CIDR = subnet.CIDR
+		if space != "" {
+			plan.args[i].SpaceTag = names.NewSpaceTag(space).String()
+			plan.spaces.Add(space)
+		}
+	}
+
+	if !unmatched.IsEmpty() {
+		return nil, errors.Errorf(
+			"importing network configuration: space map entries %s match no subnet on the machine",
+			strings.Join(unmatched.SortedValues(), ", "))
+	}
+	return plan, nil
+}
+
+// importNetwork imports the planned subnets into the model, creating
+// any spaces named in the space map that do not yet exist.
+func (c *addCommand) importNetwork(ctx *cmd.Context, plan *networkImport) error {
+	if len(plan.hostSubnets) == 0 {
+		ctx.Infof("no subnets found to import")
+		return nil
+	}
+	if !plan.spaces.IsEmpty() {
+		if err := c.ensureSpaces(ctx, plan.spaces); err != nil {
+			return errors.Annotate(err, "importing network configuration")
+		}
+	}
+
+	subnetsAPI, err := c.getSubnetImportAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer subnetsAPI.Close()
+
+	results, err := subnetsAPI.ImportSubnets(plan.args)
+	if err != nil {
+		return errors.Annotate(err, "importing network configuration")
+	}
+	var failed int
+	for i, result := range results {
+		subnet := plan.hostSubnets[i]
+		switch {
+		case result.Error == nil:
+			ctx.Infof("imported subnet %s (%s) into %s", subnet.CIDR, subnet.Interface, describeSpace(plan.args[i].SpaceTag))
+		case params.IsCodeAlreadyExists(result.Error):
+			ctx.Infof("subnet %s (%s) already known: %v", subnet.CIDR, subnet.Interface, result.Error)
+		default:
+			failed++
+			ctx.Warningf("cannot import subnet %s (%s): %v", subnet.CIDR, subnet.Interface, result.Error)
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to import %d of %d subnets", failed, len(results))
+	}
+	return nil
+}
+
+// ensureSpaces creates any of the input spaces that
+// do not already exist in the model.
+func (c *addCommand) ensureSpaces(ctx *cmd.Context, required set.Strings) error {
+	spacesAPI, err := c.getSpaceAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer spacesAPI.Close()
+
+	existing, err := spacesAPI.ListSpaces()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, space := range existing {
+		required.Remove(space.Name)
+	}
+	for _, space := range required.SortedValues() {
+		if err := spacesAPI.CreateSpace(space, nil, true); err != nil {
+			return errors.Annotatef(err, "creating space %q", space)
+		}
+		ctx.Infof("created space %q", space)
+	}
+	return nil
+}
+
+func describeSpace(spaceTag string) string {
+	tag, err := names.ParseSpaceTag(spaceTag)
+	if err != nil {
+		return "the default space"
+	}
+	return fmt.Sprintf("space %q", tag.Id())
+}
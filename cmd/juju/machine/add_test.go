@@ -4,6 +4,8 @@
 package machine_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -90,6 +92,16 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"something:special"},
 			count:     1,
 			placement: "something:special",
+		}, {
+			args:      []string{"ssh:user@10.10.0.3", "--import-network"},
+			count:     1,
+			placement: "ssh:user@10.10.0.3",
+		}, {
+			args:        []string{"--import-network"},
+			errorString: "--import-network is only supported when adding a machine via SSH",
+		}, {
+			args:        []string{"winrm:user@10.10.0.3", "--space-map", "spaces.yaml"},
+			errorString: "--import-network is only supported when adding a machine via SSH",
 		},
 	} {
 		c.Logf("test %d", i)
@@ -153,6 +165,119 @@ func (s *AddMachineSuite) TestSSHPlacementError(c *gc.C) {
 	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
 }
 
+func (s *AddMachineSuite) TestSSHPlacementImportNetwork(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		return "42", nil
+	})
+	s.PatchValue(machine.DetectHostSubnets, func(host string) ([]manual.HostSubnet, error) {
+		c.Check(host, gc.Equals, "10.1.2.3")
+		return []manual.HostSubnet{
+			{Interface: "eth0", CIDR: "10.1.2.0/24"},
+			{Interface: "eth1", CIDR: "192.168.0.0/24"},
+			{Interface: "eth2", CIDR: "172.16.0.0/16"},
+		}, nil
+	})
+	spaceMap := filepath.Join(c.MkDir(), "spaces.yaml")
+	err := ioutil.WriteFile(spaceMap, []byte(`
+internal: [eth0]
+storage: [192.168.0.0/24]
+`[1:]), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnetsAPI := &fakeSubnetImportAPI{
+		results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Code: params.CodeAlreadyExists, Message: `subnet "192.168.0.0/24" in space "alpha" already exists`}},
+			{},
+		},
+	}
+	spacesAPI := &fakeSpaceAPI{spaces: []params.Space{{Name: "alpha"}, {Name: "internal"}}}
+	add := machine.NewAddCommandWithNetworkAPIsForTest(
+		s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, subnetsAPI, spacesAPI)
+	context, err := cmdtesting.RunCommand(c, add, "ssh:10.1.2.3", "--space-map", spaceMap)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(spacesAPI.created, jc.DeepEquals, []string{"storage"})
+	c.Check(subnetsAPI.args, jc.DeepEquals, []params.ImportSubnetParams{
+		{CIDR: "10.1.2.0/24", SpaceTag: "space-internal"},
+		{CIDR: "192.168.0.0/24", SpaceTag: "space-storage"},
+		{CIDR: "172.16.0.0/16"},
+	})
+	c.Check(cmdtesting.Stderr(context), gc.Equals, `
+created machine 42
+created space "storage"
+imported subnet 10.1.2.0/24 (eth0) into space "internal"
+subnet 192.168.0.0/24 (eth1) already known: subnet "192.168.0.0/24" in space "alpha" already exists
+imported subnet 172.16.0.0/16 (eth2) into the default space
+`[1:])
+}
+
+func (s *AddMachineSuite) TestSSHPlacementSpaceMapUnmatched(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Errorf("machine provisioned despite invalid space map")
+		return "42", nil
+	})
+	s.PatchValue(machine.DetectHostSubnets, func(host string) ([]manual.HostSubnet, error) {
+		return []manual.HostSubnet{
+			{Interface: "eth0", CIDR: "10.1.2.0/24"},
+		}, nil
+	})
+	spaceMap := filepath.Join(c.MkDir(), "spaces.yaml")
+	err := ioutil.WriteFile(spaceMap, []byte(`
+internal: [eth0]
+storage: [bond0, 192.168.0.0/24]
+`[1:]), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	subnetsAPI := &fakeSubnetImportAPI{}
+	spacesAPI := &fakeSpaceAPI{}
+	add := machine.NewAddCommandWithNetworkAPIsForTest(
+		s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, subnetsAPI, spacesAPI)
+	_, err = cmdtesting.RunCommand(c, add, "ssh:10.1.2.3", "--space-map", spaceMap)
+	c.Assert(err, gc.ErrorMatches, "importing network configuration: space map entries 192.168.0.0/24, bond0 match no subnet on the machine")
+	c.Check(spacesAPI.created, gc.HasLen, 0)
+	c.Check(subnetsAPI.args, gc.HasLen, 0)
+}
+
+func (s *AddMachineSuite) TestSSHPlacementSpaceMapDuplicate(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		c.Errorf("machine provisioned despite invalid space map")
+		return "42", nil
+	})
+	for i, content := range []string{`
+internal: [eth0]
+storage: [eth1, eth0]
+`, `
+internal: [10.1.2.0/24, 10.1.2.0/24]
+`} {
+		c.Logf("test %d", i)
+		spaceMap := filepath.Join(c.MkDir(), "spaces.yaml")
+		err := ioutil.WriteFile(spaceMap, []byte(content[1:]), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+
+		add := machine.NewAddCommandWithNetworkAPIsForTest(
+			s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, &fakeSubnetImportAPI{}, &fakeSpaceAPI{})
+		_, err = cmdtesting.RunCommand(c, add, "ssh:10.1.2.3", "--space-map", spaceMap)
+		c.Check(err, gc.ErrorMatches, []string{
+			`space map entry "eth0" in both spaces "internal" and "storage" not valid`,
+			`space map entry "10.1.2.0/24" repeated in space "internal" not valid`,
+		}[i])
+	}
+}
+
+func (s *AddMachineSuite) TestSSHPlacementImportNetworkError(c *gc.C) {
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		return "42", nil
+	})
+	s.PatchValue(machine.DetectHostSubnets, func(host string) ([]manual.HostSubnet, error) {
+		return nil, errors.New("permission denied")
+	})
+	add := machine.NewAddCommandWithNetworkAPIsForTest(
+		s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager, &fakeSubnetImportAPI{}, &fakeSpaceAPI{})
+	_, err := cmdtesting.RunCommand(c, add, "ssh:10.1.2.3", "--import-network")
+	c.Assert(err, gc.ErrorMatches, "importing network configuration: permission denied")
+}
+
 func (s *AddMachineSuite) TestParamsPassedOn(c *gc.C) {
 	_, err := s.run(c, "--constraints", "mem=8G", "--series=special", "zone=nz")
 	c.Assert(err, jc.ErrorIsNil)
@@ -284,3 +409,35 @@ type fakeMachineManagerAPI struct {
 func (f *fakeMachineManagerAPI) BestAPIVersion() int {
 	return f.apiVersion
 }
+
+type fakeSubnetImportAPI struct {
+	args    []params.ImportSubnetParams
+	results []params.ErrorResult
+}
+
+func (f *fakeSubnetImportAPI) ImportSubnets(args []params.ImportSubnetParams) ([]params.ErrorResult, error) {
+	f.args = args
+	return f.results, nil
+}
+
+func (f *fakeSubnetImportAPI) Close() error {
+	return nil
+}
+
+type fakeSpaceAPI struct {
+	spaces  []params.Space
+	created []string
+}
+
+func (f *fakeSpaceAPI) ListSpaces() ([]params.Space, error) {
+	return f.spaces, nil
+}
+
+func (f *fakeSpaceAPI) CreateSpace(name string, cidrs []string, public bool) error {
+	f.created = append(f.created, name)
+	return nil
+}
+
+func (f *fakeSpaceAPI) Close() error {
+	return nil
+}
@@ -14,7 +14,8 @@ import (
 )
 
 var (
-	SSHProvisioner = &sshProvisioner
+	SSHProvisioner    = &sshProvisioner
+	DetectHostSubnets = &detectHostSubnets
 )
 
 type AddCommand struct {
@@ -32,6 +33,23 @@ func NewAddCommandForTest(api AddMachineAPI, mcAPI ModelConfigAPI, mmAPI Machine
 	return modelcmd.Wrap(command), &AddCommand{command}
 }
 
+// NewAddCommandWithNetworkAPIsForTest returns an AddCommand with the
+// apis used for importing network configuration as specified.
+func NewAddCommandWithNetworkAPIsForTest(
+	api AddMachineAPI, mcAPI ModelConfigAPI, mmAPI MachineManagerAPI,
+	subnetsAPI SubnetImportAPI, spacesAPI SpaceAPI,
+) cmd.Command {
+	command := &addCommand{
+		api:               api,
+		machineManagerAPI: mmAPI,
+		modelConfigAPI:    mcAPI,
+		subnetsAPI:        subnetsAPI,
+		spacesAPI:         spacesAPI,
+	}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
 // NewListCommandForTest returns a listMachineCommand with specified api
 func NewListCommandForTest(api statusAPI) cmd.Command {
 	command := newListMachinesCommand(api)
@@ -0,0 +1,173 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewCloneModelCommand returns a fully constructed clone-model command.
+func NewCloneModelCommand() cmd.Command {
+	return modelcmd.WrapController(&cloneModelCommand{})
+}
+
+type cloneModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api CloneModelAPI
+
+	source   string
+	name     string
+	owner    string
+	maxUnits int
+	config   common.ConfigFlag
+}
+
+const cloneModelHelpDoc = `
+Creates a new model on the same controller, running the same applications
+as an existing model. The applications are deployed with the same charms,
+charm and application config, constraints, endpoint bindings and exposure,
+and the same relations and offers are created between them.
+
+None of the data of the source model is copied: the new model gets fresh
+machines for its units, and relation data, storage and the state of the
+units are not cloned. Relations with applications in other models are
+not cloned either.
+
+By default each application gets as many units as it has in the source
+model. Use --max-units to cap the number of units of each application,
+for example to create a scaled down staging copy of a production model.
+Model config is copied from the source model; use --config to override
+individual values. Only model config can be overridden: application config
+is always copied as is, and can be changed with "juju config" once the
+model has been cloned.
+
+If the applications can not all be cloned, the new model is destroyed.
+
+Examples:
+
+    juju clone-model production staging
+    juju clone-model production staging --max-units 1
+    juju clone-model production staging --config logging-config="<root>=DEBUG"
+
+See also:
+    add-model
+    export-bundle
+`
+
+// Info implements Command.
+func (c *cloneModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "clone-model",
+		Args:    "<source model name> <new model name>",
+		Purpose: "Creates a new model with the same applications as an existing model.",
+		Doc:     cloneModelHelpDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *cloneModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.owner, "owner", "", "The owner of the new model if not the current user")
+	f.IntVar(&c.maxUnits, "max-units", 0, "The maximum number of units of each application in the new model")
+	f.Var(&c.config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...]) overriding the source model config")
+}
+
+// Init implements Command.
+func (c *cloneModelCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no source model specified")
+	case 1:
+		return errors.New("no new model name specified")
+	}
+	c.source, c.name, args = args[0], args[1], args[2:]
+	if !names.IsValidModelName(c.name) {
+		return errors.Errorf("%q is not a valid name: model names may only contain lowercase letters, digits and hyphens", c.name)
+	}
+	if c.owner != "" && !names.IsValidUser(c.owner) {
+		return errors.Errorf("%q is not a valid user", c.owner)
+	}
+	if c.maxUnits < 0 {
+		return errors.New("--max-units must not be negative")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// CloneModelAPI specifies the used function calls of the ModelManager.
+type CloneModelAPI interface {
+	Close() error
+	CloneModel(source names.ModelTag, name, owner string, config map[string]interface{}, maxUnits int) (base.ModelInfo, error)
+}
+
+func (c *cloneModelCommand) getAPI() (CloneModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewModelManagerAPIClient()
+}
+
+// Run implements Command.
+func (c *cloneModelCommand) Run(ctx *cmd.Context) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	store := c.ClientStore()
+	accountDetails, err := store.AccountDetails(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelOwner := accountDetails.User
+	if c.owner != "" {
+		modelOwner = c.owner
+	}
+
+	uuids, err := c.ModelUUIDs([]string{c.source})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	attrs, err := c.config.ReadAttrs(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	model, err := client.CloneModel(names.NewModelTag(uuids[0]), c.name, c.owner, attrs, c.maxUnits)
+	if errors.IsNotImplemented(err) {
+		return errors.New("controller does not support cloning models")
+	} else if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "clone a model")
+		}
+		return errors.Trace(err)
+	}
+
+	if modelOwner == accountDetails.User {
+		details := jujuclient.ModelDetails{
+			ModelUUID: model.UUID,
+			ModelType: model.Type,
+		}
+		if err := store.UpdateModel(controllerName, c.name, details); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	ctx.Infof("Cloned model %q to %q", c.source, c.name)
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type CloneModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   fakeCloneModelClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&CloneModelCommandSuite{})
+
+type fakeCloneModelClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeCloneModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeCloneModelClient) CloneModel(
+	source names.ModelTag, name, owner string, config map[string]interface{}, maxUnits int,
+) (base.ModelInfo, error) {
+	f.MethodCall(f, "CloneModel", source, name, owner, config, maxUnits)
+	return base.ModelInfo{
+		Name: name,
+		UUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Type: coremodel.IAAS,
+	}, f.NextErr()
+}
+
+func (s *CloneModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = fakeCloneModelClient{}
+	s.store = newTestStore(c)
+}
+
+func (s *CloneModelCommandSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewCloneModelCommandForTest(&s.api, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stderr(ctx), nil
+}
+
+func (s *CloneModelCommandSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no source model specified",
+	}, {
+		args: []string{"mymodel"},
+		err:  "no new model name specified",
+	}, {
+		args: []string{"mymodel", "Bad_Name"},
+		err:  `"Bad_Name" is not a valid name: .*`,
+	}, {
+		args: []string{"mymodel", "staging", "--max-units", "-1"},
+		err:  "--max-units must not be negative",
+	}, {
+		args: []string{"mymodel", "staging", "--owner", "not/valid"},
+		err:  `"not/valid" is not a valid user`,
+	}, {
+		args: []string{"mymodel", "staging", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *CloneModelCommandSuite) TestCloneModel(c *gc.C) {
+	stderr, err := s.run(c, "mymodel", "staging", "--max-units", "1", "--config", "logging-config=<root>=DEBUG")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stderr, gc.Equals, "Cloned model \"mymodel\" to \"staging\"\n")
+
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CloneModel", []interface{}{
+			testing.ModelTag, "staging", "",
+			map[string]interface{}{"logging-config": "<root>=DEBUG"}, 1,
+		}},
+		{"Close", nil},
+	})
+	details, err := s.store.ModelByName("testing", "admin/staging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(details.ModelUUID, gc.Equals, "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	c.Check(s.store.Models["testing"].CurrentModel, gc.Equals, "admin/mymodel")
+}
+
+func (s *CloneModelCommandSuite) TestCloneModelForOtherOwner(c *gc.C) {
+	_, err := s.run(c, "mymodel", "staging", "--owner", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "CloneModel", testing.ModelTag, "staging", "bob", map[string]interface{}{}, 0)
+	_, err = s.store.ModelByName("testing", "bob/staging")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloneModelCommandSuite) TestCloneModelNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotImplementedf("CloneModel in version 10"))
+	_, err := s.run(c, "mymodel", "staging")
+	c.Assert(err, gc.ErrorMatches, "controller does not support cloning models")
+}
+
+func (s *CloneModelCommandSuite) TestCloneModelFailed(c *gc.C) {
+	s.api.SetErrors(errors.New(`model "staging" created but cloning applications failed: boom`))
+	_, err := s.run(c, "mymodel", "staging")
+	c.Assert(err, gc.ErrorMatches, `model "staging" created but cloning applications failed: boom`)
+	_, err = s.store.ModelByName("testing", "admin/staging")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -77,6 +77,41 @@ func NewExportBundleCommandForTest(bundleAPI ExportBundleAPI, cfgAPI ConfigAPI,
 	return modelcmd.Wrap(cmd)
 }
 
+// NewExportModelCommandForTest returns an ExportModelCommand with the apis provided as specified.
+func NewExportModelCommandForTest(api ExportModelAPI, binaries ModelBinariesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportModelCommand{api: api, binaries: binaries}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewImportModelCommandForTest returns an ImportModelCommand with the api provided as specified.
+func NewImportModelCommandForTest(api ImportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importModelCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewCloneModelCommandForTest returns a CloneModelCommand with the api provided as specified.
+func NewCloneModelCommandForTest(api CloneModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &cloneModelCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
+// NewShowMigrationCommandForTest returns a ShowMigrationCommand with the api provided as specified.
+func NewShowMigrationCommandForTest(api ShowModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &showMigrationCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd, modelcmd.WrapSkipModelFlags)
+}
+
+// NewRetryMigrationCommandForTest returns a RetryMigrationCommand with the api provided as specified.
+func NewRetryMigrationCommandForTest(api RetryMigrationAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &retryMigrationCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd, modelcmd.WrapSkipModelFlags)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the api provided as specified.
 func NewDestroyCommandForTest(
 	api DestroyModelAPI,
@@ -0,0 +1,190 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewExportModelCommand returns a fully constructed export-model command.
+func NewExportModelCommand() cmd.Command {
+	return modelcmd.Wrap(&exportModelCommand{})
+}
+
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	api      ExportModelAPI
+	binaries ModelBinariesAPI
+
+	output string
+}
+
+const exportModelHelpDoc = `
+Writes the model, along with the charms, agent binaries and resources it
+uses, to an archive file. The archive can then be imported into another
+controller with the import-model command, without the two controllers
+needing to be able to reach each other.
+
+The source model is left untouched. Once the model has been imported
+elsewhere, the agents in the model still need to be pointed at the new
+controller, and the source model should not be destroyed as that would
+also destroy the machines now managed by the new controller.
+
+If --output is not specified, the archive is written to
+<model name>.tar.gz in the current directory.
+
+Examples:
+
+    juju export-model
+    juju export-model -m mymodel --output mymodel.tar.gz
+
+See also:
+    import-model
+    migrate
+`
+
+// Info implements Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-model",
+		Purpose: "Exports a model to an archive file.",
+		Doc:     exportModelHelpDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *exportModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.output, "output", "", "The file to write the model archive to")
+}
+
+// Init implements Command.
+func (c *exportModelCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// ExportModelAPI specifies the used function calls of the ModelManager.
+type ExportModelAPI interface {
+	Close() error
+	ExportModel(names.ModelTag) (params.SerializedModel, error)
+}
+
+// ModelBinariesAPI specifies the calls used to download the binaries
+// used by a model.
+type ModelBinariesAPI interface {
+	Close() error
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+	OpenURI(string, url.Values) (io.ReadCloser, error)
+	ServerVersion() (version.Number, bool)
+}
+
+// modelBinariesClient implements ModelBinariesAPI using a model
+// API connection.
+type modelBinariesClient struct {
+	api.Connection
+	client *api.Client
+}
+
+// OpenCharm is part of the ModelBinariesAPI interface.
+func (c *modelBinariesClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return c.client.OpenCharm(curl)
+}
+
+// OpenURI is part of the ModelBinariesAPI interface.
+func (c *modelBinariesClient) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	return c.client.OpenURI(uri, query)
+}
+
+func (c *exportModelCommand) getAPI() (ExportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.ModelCommandBase.NewModelManagerAPIClient()
+}
+
+func (c *exportModelCommand) getBinariesAPI() (ModelBinariesAPI, error) {
+	if c.binaries != nil {
+		return c.binaries, nil
+	}
+	root, err := c.ModelCommandBase.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &modelBinariesClient{Connection: root, client: root.Client()}, nil
+}
+
+// Run implements Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) (err error) {
+	modelName, modelDetails, err := c.ModelCommandBase.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	binaries, err := c.getBinariesAPI()
+	if err != nil {
+		return err
+	}
+	defer binaries.Close()
+
+	controllerVersion, ok := binaries.ServerVersion()
+	if !ok {
+		return errors.New("cannot determine controller version")
+	}
+
+	serialized, err := client.ExportModel(names.NewModelTag(modelDetails.ModelUUID))
+	if errors.IsNotImplemented(err) {
+		return errors.New("controller does not support exporting models")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	output := c.output
+	if output == "" {
+		shortName, _, err := jujuclient.SplitModelName(modelName)
+		if err != nil {
+			shortName = modelName
+		}
+		output = shortName + ".tar.gz"
+	}
+	output = ctx.AbsPath(output)
+
+	f, err := os.Create(output)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = errors.Trace(closeErr)
+		}
+		if err != nil {
+			os.Remove(output)
+		}
+	}()
+	if err := writeModelArchive(f, serialized, controllerVersion, binaries); err != nil {
+		return errors.Annotate(err, "writing model archive")
+	}
+	ctx.Infof("Model %q exported to %s", modelName, output)
+	return nil
+}
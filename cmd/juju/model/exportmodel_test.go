@@ -0,0 +1,229 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description/v3"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ExportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      fakeExportModelClient
+	binaries fakeModelBinariesClient
+	store    *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ExportModelCommandSuite{})
+
+type fakeExportModelClient struct {
+	gitjujutesting.Stub
+	serialized params.SerializedModel
+}
+
+func (f *fakeExportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportModelClient) ExportModel(model names.ModelTag) (params.SerializedModel, error) {
+	f.MethodCall(f, "ExportModel", model)
+	return f.serialized, f.NextErr()
+}
+
+type fakeModelBinariesClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeModelBinariesClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeModelBinariesClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl.String())
+	return ioutil.NopCloser(bytes.NewBufferString("charm:" + curl.String())), f.NextErr()
+}
+
+func (f *fakeModelBinariesClient) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenURI", uri)
+	return ioutil.NopCloser(bytes.NewBufferString("uri:" + uri)), f.NextErr()
+}
+
+func (f *fakeModelBinariesClient) ServerVersion() (version.Number, bool) {
+	f.MethodCall(f, "ServerVersion")
+	return version.MustParse("2.9.1"), true
+}
+
+// testSerializedModel returns a serialized model using a charm, agent
+// binaries and a resource, along with a placeholder resource.
+func testSerializedModel(c *gc.C) params.SerializedModel {
+	m := description.NewModel(description.ModelArgs{
+		Type:  string(coremodel.IAAS),
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name":          "mymodel",
+			"uuid":          testing.ModelTag.Id(),
+			"agent-version": "2.9.0",
+		},
+	})
+	m.SetStatus(description.StatusArgs{
+		Value:   "available",
+		Updated: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+	})
+	bytes, err := description.Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	revision := params.SerializedModelResourceRevision{
+		Revision:  1,
+		Type:      "file",
+		Path:      "data.txt",
+		Origin:    "upload",
+		Size:      20,
+		Timestamp: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		Username:  "admin",
+	}
+	placeholder := params.SerializedModelResourceRevision{
+		Type:   "file",
+		Path:   "other.txt",
+		Origin: "upload",
+	}
+	return params.SerializedModel{
+		Bytes:  bytes,
+		Charms: []string{"cs:ubuntu-1"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.9.0-ubuntu-amd64",
+			URI:     "/tools/2.9.0-ubuntu-amd64",
+		}},
+		Resources: []params.SerializedModelResource{{
+			Application:         "ubuntu",
+			Name:                "data",
+			ApplicationRevision: revision,
+			CharmStoreRevision:  placeholder,
+		}, {
+			Application:         "ubuntu",
+			Name:                "other",
+			ApplicationRevision: placeholder,
+			CharmStoreRevision:  placeholder,
+		}},
+	}
+}
+
+func newTestStore(c *gc.C) *jujuclient.MemStore {
+	store := jujuclient.NewMemStore()
+	store.CurrentControllerName = "testing"
+	store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	store.Models["testing"].CurrentModel = "admin/mymodel"
+	return store
+}
+
+// readArchive returns the contents of each file in a model archive.
+func readArchive(c *gc.C, path string) map[string]string {
+	f, err := os.Open(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	gzr, err := gzip.NewReader(f)
+	c.Assert(err, jc.ErrorIsNil)
+	tr := tar.NewReader(gzr)
+	contents := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		data, err := ioutil.ReadAll(tr)
+		c.Assert(err, jc.ErrorIsNil)
+		contents[hdr.Name] = string(data)
+	}
+	return contents
+}
+
+func (s *ExportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = fakeExportModelClient{serialized: testSerializedModel(c)}
+	s.binaries = fakeModelBinariesClient{}
+	s.store = newTestStore(c)
+}
+
+func (s *ExportModelCommandSuite) TestExportModel(c *gc.C) {
+	output := filepath.Join(c.MkDir(), "exported.tar.gz")
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.api, &s.binaries, s.store), "--output", output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `Model "admin/mymodel" exported to `+output+"\n")
+
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag}},
+		{"Close", nil},
+	})
+	s.binaries.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ServerVersion", nil},
+		{"OpenCharm", []interface{}{"cs:ubuntu-1"}},
+		{"OpenURI", []interface{}{"/tools/2.9.0-ubuntu-amd64"}},
+		{"OpenURI", []interface{}{"/applications/ubuntu/resources/data"}},
+		{"Close", nil},
+	})
+
+	contents := readArchive(c, output)
+	c.Check(contents, gc.HasLen, 5)
+	c.Check(contents["model.yaml"], gc.Equals, string(s.api.serialized.Bytes))
+	c.Check(contents["charms/cs:ubuntu-1"], gc.Equals, "charm:cs:ubuntu-1")
+	c.Check(contents["tools/2.9.0-ubuntu-amd64.tar.gz"], gc.Equals, "uri:/tools/2.9.0-ubuntu-amd64")
+	c.Check(contents["resources/ubuntu/data"], gc.Equals, "uri:/applications/ubuntu/resources/data")
+	c.Check(contents["metadata.json"], jc.Contains, `"controller-agent-version":"2.9.1"`)
+}
+
+func (s *ExportModelCommandSuite) TestExportModelDefaultOutput(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.api, &s.binaries, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(filepath.Join(ctx.Dir, "mymodel.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ExportModelCommandSuite) TestExportModelNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotImplementedf("ExportModel in version 9"))
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.api, &s.binaries, s.store))
+	c.Assert(err, gc.ErrorMatches, "controller does not support exporting models")
+	_, err = os.Stat(filepath.Join(ctx.Dir, "mymodel.tar.gz"))
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *ExportModelCommandSuite) TestExportModelRemovesPartialArchive(c *gc.C) {
+	s.binaries.SetErrors(errors.New("boom"))
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.api, &s.binaries, s.store))
+	c.Assert(err, gc.ErrorMatches, "writing model archive: cannot open charm cs:ubuntu-1: boom")
+	_, err = os.Stat(filepath.Join(ctx.Dir, "mymodel.tar.gz"))
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
@@ -0,0 +1,230 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/api/migrationtarget"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// NewImportModelCommand returns a fully constructed import-model command.
+func NewImportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&importModelCommand{})
+}
+
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api ImportModelAPI
+
+	archivePath string
+}
+
+const importModelHelpDoc = `
+Imports a model from an archive file created by the export-model command
+into the controller. This is the same process used by migrate, but does
+not require the source controller to be reachable.
+
+The same prechecks are run as for a migration, and the import is aborted
+if any of them fail. The agents in the imported model need to be pointed
+at this controller before the model is fully operational.
+
+Only controller administrators may import models.
+
+Examples:
+
+    juju import-model mymodel.tar.gz
+    juju import-model -c mycontroller mymodel.tar.gz
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements Command.
+func (c *importModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import-model",
+		Args:    "<archive file>",
+		Purpose: "Imports a model from an archive file.",
+		Doc:     importModelHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no archive file specified")
+	}
+	c.archivePath, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ImportModelAPI specifies the used function calls of the
+// MigrationTarget facade.
+type ImportModelAPI interface {
+	Close() error
+	Prechecks(coremigration.ModelInfo) error
+	Import([]byte) error
+	Abort(string) error
+	Activate(string) error
+	AdoptResources(string) error
+	CheckMachines(string) ([]error, error)
+	UploadCharm(string, *charm.URL, io.ReadSeeker) (*charm.URL, error)
+	UploadTools(string, io.ReadSeeker, version.Binary) (tools.List, error)
+	UploadResource(string, resource.Resource, io.ReadSeeker) error
+	SetPlaceholderResource(string, resource.Resource) error
+	SetUnitResource(string, string, resource.Resource) error
+}
+
+type importModelClient struct {
+	*migrationtarget.Client
+	io.Closer
+}
+
+func (c *importModelCommand) getAPI() (ImportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &importModelClient{
+		Client: migrationtarget.NewClient(root),
+		Closer: root,
+	}, nil
+}
+
+// Run implements Command.
+func (c *importModelCommand) Run(ctx *cmd.Context) (err error) {
+	f, err := os.Open(ctx.AbsPath(c.archivePath))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	archive, err := readModelArchive(f)
+	f.Close()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	modelInfo, err := archive.ModelInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	serialized, err := archive.SerializedModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Prechecks(modelInfo); err != nil {
+		return errors.Annotate(err, "import prechecks failed")
+	}
+
+	ctx.Infof("Importing model %q", modelInfo.Name)
+	if err := client.Import(serialized.Bytes); err != nil {
+		return errors.Annotate(err, "model import failed")
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if abortErr := client.Abort(modelInfo.UUID); abortErr != nil {
+			logger.Errorf("cannot remove partially imported model: %v", abortErr)
+		}
+	}()
+
+	ctx.Infof("Uploading charms, agent binaries and resources")
+	uploader := &importUploader{client: client, modelUUID: modelInfo.UUID}
+	err = migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:          serialized.Charms,
+		CharmDownloader: archive,
+		CharmUploader:   uploader,
+
+		Tools:           serialized.Tools,
+		ToolsDownloader: archive,
+		ToolsUploader:   uploader,
+
+		Resources:          serialized.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	})
+	if err != nil {
+		return errors.Annotate(err, "failed to upload binaries")
+	}
+
+	machineErrs, err := client.CheckMachines(modelInfo.UUID)
+	if err != nil {
+		return errors.Annotate(err, "checking machines")
+	}
+	if len(machineErrs) > 0 {
+		messages := make([]string, len(machineErrs))
+		for i, machineErr := range machineErrs {
+			messages[i] = machineErr.Error()
+		}
+		return errors.Errorf("model validation failed:\n  %s", strings.Join(messages, "\n  "))
+	}
+
+	if err := client.Activate(modelInfo.UUID); err != nil {
+		return errors.Annotate(err, "activating model")
+	}
+	if err := client.AdoptResources(modelInfo.UUID); err != nil {
+		// The model is usable at this point, so don't abort the import.
+		logger.Warningf("cannot adopt cloud resources for model %q: %v", modelInfo.Name, err)
+	}
+	ctx.Infof("Model %q imported", modelInfo.Name)
+	return nil
+}
+
+// importUploader adds the model UUID to the upload calls made to the
+// MigrationTarget facade.
+type importUploader struct {
+	client    ImportModelAPI
+	modelUUID string
+}
+
+// UploadCharm is part of the migration.CharmUploader interface.
+func (u *importUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadTools is part of the migration.ToolsUploader interface.
+func (u *importUploader) UploadTools(r io.ReadSeeker, vers version.Binary) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, r, vers)
+}
+
+// UploadResource is part of the migration.ResourceUploader interface.
+func (u *importUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, content)
+}
+
+// SetPlaceholderResource is part of the migration.ResourceUploader interface.
+func (u *importUploader) SetPlaceholderResource(res resource.Resource) error {
+	return u.client.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource is part of the migration.ResourceUploader interface.
+func (u *importUploader) SetUnitResource(unitName string, res resource.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unitName, res)
+}
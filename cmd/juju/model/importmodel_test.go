@@ -0,0 +1,193 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ImportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api     fakeImportModelClient
+	store   *jujuclient.MemStore
+	archive string
+}
+
+var _ = gc.Suite(&ImportModelCommandSuite{})
+
+type fakeImportModelClient struct {
+	gitjujutesting.Stub
+	machineErrs []error
+	uploaded    map[string]string
+}
+
+func (f *fakeImportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Prechecks(model coremigration.ModelInfo) error {
+	f.MethodCall(f, "Prechecks", model)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Import(bytes []byte) error {
+	f.MethodCall(f, "Import")
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) AdoptResources(modelUUID string) error {
+	f.MethodCall(f, "AdoptResources", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) CheckMachines(modelUUID string) ([]error, error) {
+	f.MethodCall(f, "CheckMachines", modelUUID)
+	return f.machineErrs, f.NextErr()
+}
+
+func (f *fakeImportModelClient) upload(name string, r io.Reader) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		panic(err)
+	}
+	f.uploaded[name] = string(data)
+}
+
+func (f *fakeImportModelClient) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	f.MethodCall(f, "UploadCharm", modelUUID, curl.String())
+	f.upload(curl.String(), content)
+	return curl, f.NextErr()
+}
+
+func (f *fakeImportModelClient) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary) (tools.List, error) {
+	f.MethodCall(f, "UploadTools", modelUUID, vers.String())
+	f.upload(vers.String(), r)
+	return nil, f.NextErr()
+}
+
+func (f *fakeImportModelClient) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res.ApplicationID, res.Name)
+	f.upload(res.ApplicationID+"/"+res.Name, r)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	f.MethodCall(f, "SetPlaceholderResource", modelUUID, res.ApplicationID, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) SetUnitResource(modelUUID, unitName string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unitName, res.Name)
+	return f.NextErr()
+}
+
+func (s *ImportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = fakeImportModelClient{uploaded: make(map[string]string)}
+	s.store = newTestStore(c)
+
+	// Build the archive to import using the export-model command.
+	s.archive = filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	exportAPI := &fakeExportModelClient{serialized: testSerializedModel(c)}
+	_, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(exportAPI, &fakeModelBinariesClient{}, s.store), "--output", s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ImportModelCommandSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewImportModelCommandForTest(&s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "no archive file specified")
+
+	_, err = cmdtesting.RunCommand(c, model.NewImportModelCommandForTest(&s.api, s.store), "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
+
+func (s *ImportModelCommandSuite) TestImportModel(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewImportModelCommandForTest(&s.api, s.store), s.archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Importing model "mymodel"
+Uploading charms, agent binaries and resources
+Model "mymodel" imported
+`[1:])
+
+	uuid := testing.ModelTag.Id()
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Prechecks", []interface{}{coremigration.ModelInfo{
+			UUID:                   uuid,
+			Owner:                  names.NewUserTag("admin"),
+			Name:                   "mymodel",
+			AgentVersion:           version.MustParse("2.9.0"),
+			ControllerAgentVersion: version.MustParse("2.9.1"),
+		}}},
+		{"Import", nil},
+		{"UploadCharm", []interface{}{uuid, "cs:ubuntu-1"}},
+		{"UploadTools", []interface{}{uuid, "2.9.0-ubuntu-amd64"}},
+		{"UploadResource", []interface{}{uuid, "ubuntu", "data"}},
+		{"CheckMachines", []interface{}{uuid}},
+		{"Activate", []interface{}{uuid}},
+		{"AdoptResources", []interface{}{uuid}},
+		{"Close", nil},
+	})
+	c.Check(s.api.uploaded, jc.DeepEquals, map[string]string{
+		"cs:ubuntu-1":        "charm:cs:ubuntu-1",
+		"2.9.0-ubuntu-amd64": "uri:/tools/2.9.0-ubuntu-amd64",
+		"ubuntu/data":        "uri:/applications/ubuntu/resources/data",
+	})
+}
+
+func (s *ImportModelCommandSuite) TestImportModelPrechecksFailed(c *gc.C) {
+	s.api.SetErrors(errors.New("model with same UUID already exists"))
+	_, err := cmdtesting.RunCommand(c, model.NewImportModelCommandForTest(&s.api, s.store), s.archive)
+	c.Assert(err, gc.ErrorMatches, "import prechecks failed: model with same UUID already exists")
+	s.api.CheckCallNames(c, "Prechecks", "Close")
+}
+
+func (s *ImportModelCommandSuite) TestImportModelAbortsOnFailure(c *gc.C) {
+	s.api.machineErrs = []error{errors.New("machine 0 not found")}
+	_, err := cmdtesting.RunCommand(c, model.NewImportModelCommandForTest(&s.api, s.store), s.archive)
+	c.Assert(err, gc.ErrorMatches, "model validation failed:\n  machine 0 not found")
+	s.api.CheckCallNames(c,
+		"Prechecks", "Import", "UploadCharm", "UploadTools", "UploadResource",
+		"CheckMachines", "Abort", "Close",
+	)
+	s.api.CheckCall(c, 6, "Abort", testing.ModelTag.Id())
+}
+
+func (s *ImportModelCommandSuite) TestImportModelBadArchive(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "bad.tar.gz")
+	err := ioutil.WriteFile(path, []byte("not an archive"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, model.NewImportModelCommandForTest(&s.api, s.store), path)
+	c.Assert(err, gc.ErrorMatches, "reading model archive: .*")
+	s.api.CheckNoCalls(c)
+}
@@ -0,0 +1,336 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/description/v3"
+	"github.com/juju/errors"
+	"github.com/juju/version/v2"
+
+	apicommon "github.com/juju/juju/api/common"
+	"github.com/juju/juju/api/resources"
+	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
+)
+
+const (
+	// modelArchiveFormat is the version of the layout of model
+	// archives written by export-model.
+	modelArchiveFormat = 1
+
+	archiveMetadataFile = "metadata.json"
+	archiveModelFile    = "model.yaml"
+	archiveCharmsDir    = "charms"
+	archiveToolsDir     = "tools"
+	archiveResourcesDir = "resources"
+)
+
+// modelArchiveMetadata describes the contents of a model archive. The
+// serialized model itself is stored alongside it in model.yaml.
+type modelArchiveMetadata struct {
+	Format                 int                              `json:"format"`
+	ControllerAgentVersion version.Number                   `json:"controller-agent-version"`
+	Charms                 []string                         `json:"charms,omitempty"`
+	Tools                  []params.SerializedModelTools    `json:"tools,omitempty"`
+	Resources              []params.SerializedModelResource `json:"resources,omitempty"`
+}
+
+// modelBinarySource is used to retrieve the binaries referenced by a
+// serialized model when writing a model archive.
+type modelBinarySource interface {
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+	OpenURI(string, url.Values) (io.ReadCloser, error)
+}
+
+func archiveCharmPath(curl string) string {
+	return path.Join(archiveCharmsDir, url.PathEscape(curl))
+}
+
+func archiveToolsPath(vers string) string {
+	return path.Join(archiveToolsDir, vers+".tar.gz")
+}
+
+func archiveResourcePath(application, name string) string {
+	return path.Join(archiveResourcesDir, url.PathEscape(application), url.PathEscape(name))
+}
+
+// writeModelArchive writes a gzipped tarball containing the serialized
+// model along with every charm, agent binary and resource it uses.
+func writeModelArchive(w io.Writer, serialized params.SerializedModel, controllerVersion version.Number, source modelBinarySource) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+
+	metadata, err := json.Marshal(modelArchiveMetadata{
+		Format:                 modelArchiveFormat,
+		ControllerAgentVersion: controllerVersion,
+		Charms:                 serialized.Charms,
+		Tools:                  serialized.Tools,
+		Resources:              serialized.Resources,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := writeArchiveBytes(tw, archiveMetadataFile, metadata); err != nil {
+		return errors.Trace(err)
+	}
+	if err := writeArchiveBytes(tw, archiveModelFile, serialized.Bytes); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, charmURL := range serialized.Charms {
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := source.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open charm %s", charmURL)
+		}
+		err = writeArchiveStream(tw, archiveCharmPath(charmURL), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive charm %s", charmURL)
+		}
+	}
+
+	for _, tools := range serialized.Tools {
+		reader, err := source.OpenURI(tools.URI, nil)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open agent binaries %s", tools.Version)
+		}
+		err = writeArchiveStream(tw, archiveToolsPath(tools.Version), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive agent binaries %s", tools.Version)
+		}
+	}
+
+	for _, res := range serialized.Resources {
+		if res.ApplicationRevision.Timestamp.IsZero() {
+			// Placeholder resources have no content to archive.
+			continue
+		}
+		uri := fmt.Sprintf(resources.HTTPEndpointPath, res.Application, res.Name)
+		reader, err := source.OpenURI(uri, nil)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open resource %s/%s", res.Application, res.Name)
+		}
+		err = writeArchiveStream(tw, archiveResourcePath(res.Application, res.Name), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive resource %s/%s", res.Application, res.Name)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+func writeArchiveBytes(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(data)),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := tw.Write(data)
+	return errors.Trace(err)
+}
+
+// writeArchiveStream spools the content through a temporary file, as
+// the size of each entry must be known before it can be written.
+func writeArchiveStream(tw *tar.Writer, name string, r io.Reader) error {
+	tempFile, err := ioutil.TempFile("", "juju-export-model")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
+	size, err := io.Copy(tempFile, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := tempFile.Seek(0, 0); err != nil {
+		return errors.Trace(err)
+	}
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: size,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(tw, tempFile)
+	return errors.Trace(err)
+}
+
+// modelArchive is a model archive which has been unpacked into a
+// temporary directory. It serves the archived binaries to the
+// migration upload machinery.
+type modelArchive struct {
+	dir      string
+	metadata modelArchiveMetadata
+	model    []byte
+}
+
+// readModelArchive unpacks the model archive read from r.
+// The caller is responsible for closing the returned archive.
+func readModelArchive(r io.Reader) (_ *modelArchive, err error) {
+	dir, err := ioutil.TempDir("", "juju-import-model")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archive := &modelArchive{dir: dir}
+	defer func() {
+		if err != nil {
+			archive.Close()
+		}
+	}()
+
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model archive")
+	}
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Annotate(err, "reading model archive")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := archive.extract(hdr.Name, tr); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	metadata, err := ioutil.ReadFile(filepath.Join(dir, archiveMetadataFile))
+	if os.IsNotExist(err) {
+		return nil, errors.NotValidf("model archive without %s", archiveMetadataFile)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := json.Unmarshal(metadata, &archive.metadata); err != nil {
+		return nil, errors.Annotatef(err, "parsing %s", archiveMetadataFile)
+	}
+	if archive.metadata.Format != modelArchiveFormat {
+		return nil, errors.NotSupportedf("model archive format %d", archive.metadata.Format)
+	}
+	archive.model, err = ioutil.ReadFile(filepath.Join(dir, archiveModelFile))
+	if os.IsNotExist(err) {
+		return nil, errors.NotValidf("model archive without %s", archiveModelFile)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return archive, nil
+}
+
+func (a *modelArchive) extract(name string, r io.Reader) error {
+	clean := path.Clean(name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return errors.NotValidf("model archive entry %q", name)
+	}
+	target := filepath.Join(a.dir, filepath.FromSlash(clean))
+	if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r)
+	return errors.Trace(err)
+}
+
+func (a *modelArchive) open(name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(a.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%s in model archive", name)
+	}
+	return f, errors.Trace(err)
+}
+
+// OpenCharm is part of the migration.CharmDownloader interface.
+func (a *modelArchive) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.open(archiveCharmPath(curl.String()))
+}
+
+// OpenURI is part of the migration.ToolsDownloader interface.
+func (a *modelArchive) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	for _, tools := range a.metadata.Tools {
+		if tools.URI == uri {
+			return a.open(archiveToolsPath(tools.Version))
+		}
+	}
+	return nil, errors.NotFoundf("agent binaries %q in model archive", uri)
+}
+
+// OpenResource is part of the migration.ResourceDownloader interface.
+func (a *modelArchive) OpenResource(application, name string) (io.ReadCloser, error) {
+	return a.open(archiveResourcePath(application, name))
+}
+
+// Close removes the unpacked archive.
+func (a *modelArchive) Close() error {
+	return errors.Trace(os.RemoveAll(a.dir))
+}
+
+// SerializedModel returns the archived model in the form used by the
+// migration machinery.
+func (a *modelArchive) SerializedModel() (coremigration.SerializedModel, error) {
+	return apicommon.SerializedModelFromParams(params.SerializedModel{
+		Bytes:     a.model,
+		Charms:    a.metadata.Charms,
+		Tools:     a.metadata.Tools,
+		Resources: a.metadata.Resources,
+	})
+}
+
+// ModelInfo returns the details of the archived model needed for the
+// migration prechecks.
+func (a *modelArchive) ModelInfo() (coremigration.ModelInfo, error) {
+	model, err := description.Deserialize(a.model)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Annotate(err, "parsing archived model")
+	}
+	name, _ := model.Config()["name"].(string)
+	agentVersion, _ := model.Config()["agent-version"].(string)
+	modelVersion, err := version.Parse(agentVersion)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Annotate(err, "parsing archived model agent version")
+	}
+	info := coremigration.ModelInfo{
+		UUID:                   model.Tag().Id(),
+		Owner:                  model.Owner(),
+		Name:                   name,
+		AgentVersion:           modelVersion,
+		ControllerAgentVersion: a.metadata.ControllerAgentVersion,
+	}
+	return info, errors.Trace(info.Validate())
+}
@@ -0,0 +1,116 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRetryMigrationCommand returns a fully constructed retry-migration
+// command.
+func NewRetryMigrationCommand() cmd.Command {
+	return modelcmd.Wrap(&retryMigrationCommand{},
+		modelcmd.WrapSkipModelFlags,
+	)
+}
+
+type retryMigrationCommand struct {
+	modelcmd.ModelCommandBase
+	api RetryMigrationAPI
+}
+
+const retryMigrationHelpDoc = `
+Retries transferring a model to the target controller of its migration.
+
+When transferring the model, or its charms, agent binaries or resources,
+to the target controller fails, the migration waits for a while before
+being aborted. During that time the problem (for example a network
+outage or a full disk on the target controller) can be fixed and the
+transfer retried with this command. Only the parts of the model which
+were not transferred before the failure are transferred again.
+
+Use show-migration to see the progress of the migration.
+
+Examples:
+
+    juju retry-migration mymodel
+
+See also:
+    migrate
+    show-migration
+`
+
+// Info implements Command.
+func (c *retryMigrationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "retry-migration",
+		Args:    "[<model name>]",
+		Purpose: "Retries a failed transfer of a migrating model.",
+		Doc:     retryMigrationHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *retryMigrationCommand) Init(args []string) error {
+	modelName := ""
+	if len(args) > 0 {
+		modelName = args[0]
+		args = args[1:]
+	}
+	if err := c.SetModelIdentifier(modelName, true); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// RetryMigrationAPI specifies the used function calls of the Controller
+// facade.
+type RetryMigrationAPI interface {
+	Close() error
+	RetryMigration(modelUUID string) error
+}
+
+func (c *retryMigrationCommand) getAPI() (RetryMigrationAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controller.NewClient(root), nil
+}
+
+// Run implements Command.
+func (c *retryMigrationCommand) Run(ctx *cmd.Context) error {
+	modelName, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.RetryMigration(modelDetails.ModelUUID)
+	if errors.IsNotSupported(err) {
+		return errors.New("controller does not support retrying migrations")
+	} else if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "retry a migration")
+		}
+		return errors.Trace(err)
+	}
+	ctx.Infof("Retrying migration of model %q", modelName)
+	return nil
+}
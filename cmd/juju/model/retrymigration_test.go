@@ -0,0 +1,74 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type RetryMigrationCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   fakeRetryMigrationClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&RetryMigrationCommandSuite{})
+
+type fakeRetryMigrationClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeRetryMigrationClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeRetryMigrationClient) RetryMigration(modelUUID string) error {
+	f.MethodCall(f, "RetryMigration", modelUUID)
+	return f.NextErr()
+}
+
+func (s *RetryMigrationCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = fakeRetryMigrationClient{}
+	s.store = newTestStore(c)
+}
+
+func (s *RetryMigrationCommandSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewRetryMigrationCommandForTest(&s.api, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stderr(ctx), nil
+}
+
+func (s *RetryMigrationCommandSuite) TestRetryMigration(c *gc.C) {
+	stderr, err := s.run(c, "mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stderr, gc.Equals, "Retrying migration of model \"mymodel\"\n")
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"RetryMigration", []interface{}{testing.ModelTag.Id()}},
+		{"Close", nil},
+	})
+}
+
+func (s *RetryMigrationCommandSuite) TestRetryMigrationError(c *gc.C) {
+	s.api.SetErrors(errors.New("migration is in the QUIESCE phase, not IMPORT"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "migration is in the QUIESCE phase, not IMPORT")
+}
+
+func (s *RetryMigrationCommandSuite) TestRetryMigrationNotSupported(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("retrying migrations"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "controller does not support retrying migrations")
+}
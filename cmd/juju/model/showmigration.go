@@ -0,0 +1,211 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewShowMigrationCommand returns a fully constructed show-migration
+// command.
+func NewShowMigrationCommand() cmd.Command {
+	return modelcmd.Wrap(&showMigrationCommand{},
+		modelcmd.WrapSkipModelFlags,
+	)
+}
+
+type showMigrationCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api ShowModelAPI
+}
+
+const showMigrationHelpDoc = `
+Shows the progress of the latest migration of the current or specified
+model: the phase the migration is in, when each phase was started and
+how long it took, and how many of the model's charms, agent binaries,
+resources and log messages have been transferred to the target
+controller.
+
+If transferring the model to the target controller fails, the migration
+waits for a while before being aborted, so that the problem can be fixed
+and the transfer retried with the retry-migration command.
+
+Examples:
+
+    juju show-migration
+    juju show-migration mymodel --format json
+
+See also:
+    migrate
+    retry-migration
+    show-model
+`
+
+// Info implements Command.
+func (c *showMigrationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-migration",
+		Args:    "[<model name>]",
+		Purpose: "Shows the progress of a model migration.",
+		Doc:     showMigrationHelpDoc,
+	})
+}
+
+// SetFlags implements Command.
+func (c *showMigrationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements Command.
+func (c *showMigrationCommand) Init(args []string) error {
+	modelName := ""
+	if len(args) > 0 {
+		modelName = args[0]
+		args = args[1:]
+	}
+	if err := c.SetModelIdentifier(modelName, true); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *showMigrationCommand) getAPI() (ShowModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelmanager.NewClient(root), nil
+}
+
+// MigrationInfo holds the details of a model migration shown by
+// show-migration.
+type MigrationInfo struct {
+	Model    string                 `yaml:"model" json:"model"`
+	Phase    string                 `yaml:"phase,omitempty" json:"phase,omitempty"`
+	Status   string                 `yaml:"status" json:"status"`
+	Started  string                 `yaml:"started,omitempty" json:"started,omitempty"`
+	Ended    string                 `yaml:"ended,omitempty" json:"ended,omitempty"`
+	Phases   []MigrationPhaseInfo   `yaml:"phases,omitempty" json:"phases,omitempty"`
+	Progress *MigrationProgressInfo `yaml:"progress,omitempty" json:"progress,omitempty"`
+	Retries  int                    `yaml:"retries,omitempty" json:"retries,omitempty"`
+}
+
+// MigrationPhaseInfo holds when a migration phase was started and,
+// once the migration has moved on, how long the phase took.
+type MigrationPhaseInfo struct {
+	Phase    string `yaml:"phase" json:"phase"`
+	Started  string `yaml:"started" json:"started"`
+	Duration string `yaml:"duration,omitempty" json:"duration,omitempty"`
+}
+
+// MigrationProgressInfo holds how much of a model's data has been
+// transferred to the target controller.
+type MigrationProgressInfo struct {
+	Charms        string `yaml:"charms,omitempty" json:"charms,omitempty"`
+	AgentBinaries string `yaml:"agent-binaries,omitempty" json:"agent-binaries,omitempty"`
+	Resources     string `yaml:"resources,omitempty" json:"resources,omitempty"`
+	LogMessages   int    `yaml:"log-messages,omitempty" json:"log-messages,omitempty"`
+	LogBytes      int64  `yaml:"log-bytes,omitempty" json:"log-bytes,omitempty"`
+}
+
+// Run implements Command.
+func (c *showMigrationCommand) Run(ctx *cmd.Context) error {
+	modelName, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.ModelInfo([]names.ModelTag{names.NewModelTag(modelDetails.ModelUUID)})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return maybeEmitRedirectError(results[0].Error)
+	}
+	migration := results[0].Result.Migration
+	if migration == nil {
+		return errors.Errorf("model %q has not been migrated", modelName)
+	}
+	return c.out.Write(ctx, migrationInfoFromParams(modelName, migration))
+}
+
+func migrationInfoFromParams(modelName string, migration *params.ModelMigrationStatus) MigrationInfo {
+	info := MigrationInfo{
+		Model:   modelName,
+		Phase:   migration.Phase,
+		Status:  migration.Status,
+		Retries: migration.Retries,
+	}
+	if migration.Start != nil {
+		info.Started = common.FormatTime(migration.Start, true)
+	}
+	if migration.End != nil {
+		info.Ended = common.FormatTime(migration.End, true)
+	}
+
+	type phaseTime struct {
+		phase string
+		start time.Time
+	}
+	var phases []phaseTime
+	for phase, start := range migration.PhaseTimes {
+		phases = append(phases, phaseTime{phase, start})
+	}
+	sort.Slice(phases, func(i, j int) bool {
+		return phases[i].start.Before(phases[j].start)
+	})
+	for i, p := range phases {
+		phaseInfo := MigrationPhaseInfo{
+			Phase:   p.phase,
+			Started: common.FormatTime(&p.start, true),
+		}
+		if i+1 < len(phases) {
+			phaseInfo.Duration = phases[i+1].start.Sub(p.start).String()
+		}
+		info.Phases = append(info.Phases, phaseInfo)
+	}
+
+	if p := migration.Progress; p != nil {
+		info.Progress = &MigrationProgressInfo{
+			Charms:        formatCount(p.Charms, p.CharmsTotal),
+			AgentBinaries: formatCount(p.Tools, p.ToolsTotal),
+			Resources:     formatCount(p.Resources, p.ResourcesTotal),
+			LogMessages:   p.LogMessages,
+			LogBytes:      p.LogBytes,
+		}
+	}
+	return info
+}
+
+func formatCount(done, total int) string {
+	if total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", done, total)
+}
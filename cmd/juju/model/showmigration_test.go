@@ -0,0 +1,110 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/names/v4"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ShowMigrationCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   fakeShowMigrationClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ShowMigrationCommandSuite{})
+
+type fakeShowMigrationClient struct {
+	gitjujutesting.Stub
+	migration *params.ModelMigrationStatus
+}
+
+func (f *fakeShowMigrationClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeShowMigrationClient) ModelInfo(tags []names.ModelTag) ([]params.ModelInfoResult, error) {
+	f.MethodCall(f, "ModelInfo", tags)
+	return []params.ModelInfoResult{{
+		Result: &params.ModelInfo{Migration: f.migration},
+	}}, f.NextErr()
+}
+
+func (s *ShowMigrationCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = fakeShowMigrationClient{}
+	s.store = newTestStore(c)
+}
+
+func (s *ShowMigrationCommandSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewShowMigrationCommandForTest(&s.api, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *ShowMigrationCommandSuite) TestShowMigration(c *gc.C) {
+	start := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	s.api.migration = &params.ModelMigrationStatus{
+		Status: "uploading model binaries into target controller",
+		Start:  &start,
+		Phase:  "IMPORT",
+		PhaseTimes: map[string]time.Time{
+			"QUIESCE": start,
+			"IMPORT":  start.Add(90 * time.Second),
+		},
+		Progress: &params.MigrationProgress{
+			Charms:      2,
+			CharmsTotal: 5,
+			ToolsTotal:  1,
+		},
+		Retries: 1,
+	}
+
+	out, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, gc.Equals, `
+model: admin/mymodel
+phase: IMPORT
+status: uploading model binaries into target controller
+started: 2021-06-01 10:00:00Z
+phases:
+- phase: QUIESCE
+  started: 2021-06-01 10:00:00Z
+  duration: 1m30s
+- phase: IMPORT
+  started: 2021-06-01 10:01:30Z
+progress:
+  charms: 2/5
+  agent-binaries: 0/1
+retries: 1
+`[1:])
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ModelInfo", []interface{}{[]names.ModelTag{testing.ModelTag}}},
+		{"Close", nil},
+	})
+}
+
+func (s *ShowMigrationCommandSuite) TestShowMigrationNoMigration(c *gc.C) {
+	_, err := s.run(c, "mymodel")
+	c.Assert(err, gc.ErrorMatches, `model "mymodel" has not been migrated`)
+}
+
+func (s *ShowMigrationCommandSuite) TestShowMigrationTooManyArgs(c *gc.C) {
+	_, err := s.run(c, "mymodel", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
@@ -45,6 +45,13 @@ type modelStatus struct {
 	Status           statusInfoContents `json:"model-status,omitempty" yaml:"model-status,omitempty"`
 	MeterStatus      *meterStatus       `json:"meter-status,omitempty" yaml:"meter-status,omitempty"`
 	SLA              string             `json:"sla,omitempty" yaml:"sla,omitempty"`
+	CanaryUpgrade    *canaryUpgrade     `json:"canary-upgrade,omitempty" yaml:"canary-upgrade,omitempty"`
+}
+
+type canaryUpgrade struct {
+	Version  string   `json:"version" yaml:"version"`
+	Machines []string `json:"machines" yaml:"machines"`
+	Upgraded []string `json:"upgraded" yaml:"upgraded"`
 }
 
 type controllerStatus struct {
@@ -232,6 +239,9 @@ type unitStatus struct {
 	ProviderId    string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
 	Branch        string                `json:"branch,omitempty" yaml:"branch,omitempty"`
+
+	// Containers holds the health of a sidecar unit's workload containers.
+	Containers map[string]statusInfoContents `json:"containers,omitempty" yaml:"containers,omitempty"`
 }
 
 func (s *formattedStatus) applicationScale(name string) (string, bool) {
@@ -109,6 +109,13 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 			Message: sf.status.Model.MeterStatus.Message,
 		}
 	}
+	if canary := sf.status.Model.CanaryUpgrade; canary != nil {
+		out.Model.CanaryUpgrade = &canaryUpgrade{
+			Version:  canary.Version,
+			Machines: canary.Machines,
+			Upgraded: canary.Upgraded,
+		}
+	}
 	if sf.status.ControllerTimestamp != nil {
 		out.Controller = &controllerStatus{
 			Timestamp: common.FormatTimeAsTimestamp(sf.status.ControllerTimestamp, sf.isoTime),
@@ -439,6 +446,13 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		Branch:             info.branchRef,
 	}
 
+	if len(info.unit.Containers) > 0 {
+		out.Containers = make(map[string]statusInfoContents)
+		for name, containerStatus := range info.unit.Containers {
+			out.Containers[name] = sf.getStatusInfoContents(containerStatus)
+		}
+	}
+
 	if ms, ok := info.meterStatuses[info.unitName]; ok {
 		out.MeterStatus = &meterStatus{
 			Color:   ms.Color,
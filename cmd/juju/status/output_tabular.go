@@ -380,6 +380,9 @@ func getModelMessage(model modelStatus) string {
 	switch {
 	case model.Status.Message != "":
 		return model.Status.Message
+	case model.CanaryUpgrade != nil:
+		return fmt.Sprintf("canary upgrade to %s: %d of %d machines upgraded",
+			model.CanaryUpgrade.Version, len(model.CanaryUpgrade.Upgraded), len(model.CanaryUpgrade.Machines))
 	case model.AvailableVersion != "":
 		return "upgrade available: " + model.AvailableVersion
 	default:
@@ -5396,6 +5396,28 @@ foo/0  waiting   allocating  10.0.0.1  80/TCP
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularCanaryUpgrade(c *gc.C) {
+	fStatus := formattedStatus{
+		Model: modelStatus{
+			Name:             "default",
+			Version:          "2.9.0",
+			AvailableVersion: "2.9.1",
+			CanaryUpgrade: &canaryUpgrade{
+				Version:  "2.9.1",
+				Machines: []string{"0", "1"},
+				Upgraded: []string{"1"},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, fStatus)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model    Controller  Cloud/Region  Version  Notes
+default                            2.9.0    canary upgrade to 2.9.1: 1 of 2 machines upgraded
+`[1:])
+}
+
 func (s *StatusSuite) TestStatusWithNilStatusAPI(c *gc.C) {
 	ctx := s.newContext(c)
 	defer s.resetContext(c, ctx)
@@ -6034,6 +6056,38 @@ func (s *StatusSuite) TestFormatProvisioningError(c *gc.C) {
 	})
 }
 
+func (s *StatusSuite) TestFormatUnitContainers(c *gc.C) {
+	status := &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Type: "caas",
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"foo": {
+				Charm: "cs:foo-1",
+				Units: map[string]params.UnitStatus{
+					"foo/0": {
+						Containers: map[string]params.DetailedStatus{
+							"web": {Status: "active"},
+							"db": {
+								Status: "error",
+								Info:   "failing checks: ready, online",
+								Data:   map[string]interface{}{"failing-checks": []interface{}{"ready", "online"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	formatter := NewStatusFormatter(status, true)
+	formatted, err := formatter.format()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(formatted.Applications["foo"].Units["foo/0"].Containers, jc.DeepEquals, map[string]statusInfoContents{
+		"web": {Current: "active"},
+		"db":  {Current: "error", Message: "failing checks: ready, online"},
+	})
+}
+
 func (s *StatusSuite) TestMissingControllerTimestampInFullStatus(c *gc.C) {
 	status := &params.FullStatus{
 		Model: params.ModelStatusInfo{
@@ -62,6 +62,8 @@ var (
 	agentInitializeState = agentbootstrap.InitializeState
 	sshGenerateKey       = ssh.GenerateKey
 	minSocketTimeout     = 1 * time.Minute
+	mongoDialWithInfo    = mongo.DialWithInfo
+	externalMongoTimeout = 1 * time.Minute
 )
 
 const adminUserName = "admin"
@@ -313,8 +315,9 @@ func (c *BootstrapCommand) Run(_ *cmd.Context) error {
 			dialOpts.SocketTimeout = minSocketTimeout
 		}
 
-		// We shouldn't attempt to dial peers until we have some.
-		dialOpts.Direct = true
+		// We shouldn't attempt to dial peers until we have some,
+		// unless the replica set is externally managed.
+		dialOpts.Direct = agentConfig.Value(agent.ExternalMongoAddresses) == ""
 
 		adminTag := names.NewLocalUserTag(adminUserName)
 		controller, stateErr = agentInitializeState(
@@ -567,6 +570,11 @@ func (c *BootstrapCommand) startMongo(isCAAS bool, addrs network.ProviderAddress
 	if !ok {
 		return fmt.Errorf("no state info available")
 	}
+	if agentConfig.Value(agent.ExternalMongoAddresses) != "" {
+		// An externally managed mongo is already running with its
+		// replica set initiated, so just check it can be reached.
+		return checkExternalMongo(info.Info)
+	}
 	// When bootstrapping, we need to allow enough time for mongo
 	// to start as there's no retry loop in place.
 	// 5 minutes should suffice.
@@ -617,6 +625,22 @@ func (c *BootstrapCommand) startMongo(isCAAS bool, addrs network.ProviderAddress
 	return nil
 }
 
+// checkExternalMongo verifies that the controller can connect to the
+// externally managed mongo servers, validating their certificates.
+func checkExternalMongo(info mongo.Info) error {
+	logger.Debugf("checking external mongo at %v", info.Addrs)
+	session, err := mongoDialWithInfo(mongo.MongoInfo{Info: info}, mongo.DialOpts{Timeout: externalMongoTimeout})
+	if err != nil {
+		return errors.Annotatef(err, "cannot connect to external mongo at %v", info.Addrs)
+	}
+	defer session.Close()
+	if err := session.Ping(); err != nil {
+		return errors.Annotatef(err, "cannot ping external mongo at %v", info.Addrs)
+	}
+	logger.Infof("connected to external mongo")
+	return nil
+}
+
 // populateTools stores uploaded tools in provider storage
 // and updates the tools metadata.
 func (c *BootstrapCommand) populateTools(st *state.State) error {
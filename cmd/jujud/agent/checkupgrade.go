@@ -0,0 +1,177 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils/v2"
+	"github.com/juju/version/v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/jujud/agent/agentconf"
+	agenterrors "github.com/juju/juju/cmd/jujud/agent/errors"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/upgrades"
+)
+
+// CheckUpgradeFunc runs the upgrade steps from the given version against
+// a snapshot of the controller's database, held in the named database,
+// using the agent's config.
+type CheckUpgradeFunc func(agent.Config, version.Number, string) ([]upgrades.CheckedStep, error)
+
+type checkUpgradeCommand struct {
+	cmd.CommandBase
+	config  agentconf.AgentConf
+	check   CheckUpgradeFunc
+	from     string
+	fromVer  version.Number
+	database string
+}
+
+// NewCheckUpgradeCommand returns a command that reports the result of
+// running this version's pending upgrade steps against a snapshot of
+// the controller's database, as used by upgrade-controller --check.
+func NewCheckUpgradeCommand(config agentconf.AgentConf, check CheckUpgradeFunc) cmd.Command {
+	return &checkUpgradeCommand{
+		config: config,
+		check:  check,
+	}
+}
+
+// Info is part of cmd.Command.
+func (c *checkUpgradeCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "check-upgrade",
+		Args:    "<agent-name>",
+		Purpose: "check the upgrade steps to this version against a copy of the controller database",
+	})
+}
+
+// SetFlags is part of cmd.Command.
+func (c *checkUpgradeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.config.AddFlags(f)
+	f.StringVar(&c.from, "from", "", "the version being upgraded from")
+	f.StringVar(&c.database, "database", "", "the name of the database to hold the snapshot")
+}
+
+// Init is part of cmd.Command.
+func (c *checkUpgradeCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return &agenterrors.FatalError{"agent-name argument is required"}
+	}
+	agentName, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return err
+	}
+	tag, err := names.ParseTag(agentName)
+	if err != nil {
+		return errors.Annotatef(err, "agent-name")
+	}
+	if tag.Kind() != names.MachineTagKind && tag.Kind() != names.ControllerAgentTagKind {
+		return &agenterrors.FatalError{"agent-name must be a controller machine tag"}
+	}
+	if c.from == "" {
+		return errors.New("--from is required")
+	}
+	if c.fromVer, err = version.Parse(c.from); err != nil {
+		return errors.Annotate(err, "--from")
+	}
+	if c.database == "" {
+		uuid, err := utils.NewUUID()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.database = state.UpgradeCheckDatabasePrefix + uuid.String()
+	} else if !strings.HasPrefix(c.database, state.UpgradeCheckDatabasePrefix) {
+		return errors.Errorf("--database must start with %q", state.UpgradeCheckDatabasePrefix)
+	}
+	if err := c.config.CheckArgs(nil); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.config.ReadConfig(agentName))
+}
+
+// Run is part of cmd.Command.
+func (c *checkUpgradeCommand) Run(ctx *cmd.Context) error {
+	checked, err := c.check(c.config.CurrentConfig(), c.fromVer, c.database)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results := make([]params.UpgradeStepCheck, len(checked))
+	for i, step := range checked {
+		result := params.UpgradeStepCheck{
+			Version:     step.TargetVersion.String(),
+			Description: step.Description,
+			Checked:     step.Checked,
+		}
+		for _, target := range step.Targets {
+			result.Targets = append(result.Targets, string(target))
+		}
+		if step.Err != nil {
+			result.Error = step.Err.Error()
+		}
+		results[i] = result
+	}
+	return errors.Trace(json.NewEncoder(ctx.Stdout).Encode(results))
+}
+
+// CheckUpgradeOnSnapshot copies the controller's database into the
+// named database and runs the upgrade steps from the given version
+// against the copy, which is removed afterwards.
+func CheckUpgradeOnSnapshot(
+	agentConfig agent.Config, from version.Number, upgradeCheckDatabase string,
+) (_ []upgrades.CheckedStep, err error) {
+	info, ok := agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.Errorf("no state info available")
+	}
+	session, err := mongo.DialWithInfo(*info, mongo.DefaultDialOpts())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer session.Close()
+
+	if err := state.SnapshotDatabase(session, upgradeCheckDatabase); err != nil {
+		return nil, errors.Annotate(err, "copying controller database")
+	}
+	defer func() {
+		if dropErr := state.DropSnapshotDatabase(session, upgradeCheckDatabase); dropErr != nil && err == nil {
+			err = errors.Trace(dropErr)
+		}
+	}()
+
+	pool, err := state.OpenStatePool(state.OpenParams{
+		Clock:              clock.WallClock,
+		ControllerTag:      agentConfig.Controller(),
+		ControllerModelTag: agentConfig.Model(),
+		MongoSession:       session,
+		DatabaseName:       upgradeCheckDatabase,
+		NewPolicy:          stateenvirons.GetNewPolicyFunc(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = pool.Close() }()
+
+	// The steps may change the agent config, but the config isn't
+	// written back.
+	configSetter, ok := agentConfig.(agent.ConfigSetter)
+	if !ok {
+		return nil, errors.Errorf("agent config %T is not a config setter", agentConfig)
+	}
+	context := upgrades.NewContext(configSetter, nil, upgrades.NewStateBackend(pool))
+	targets := []upgrades.Target{upgrades.Controller, upgrades.DatabaseMaster}
+	return upgrades.CheckUpgrade(from, targets, context), nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	agentcmd "github.com/juju/juju/cmd/jujud/agent"
+	"github.com/juju/juju/upgrades"
+)
+
+type checkUpgradeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&checkUpgradeSuite{})
+
+func (s *checkUpgradeSuite) TestInit(c *gc.C) {
+	cmd := agentcmd.NewCheckUpgradeCommand(newUpgradeAgentConf(), nil)
+	err := cmd.Init(nil)
+	c.Assert(err, gc.ErrorMatches, "agent-name argument is required")
+	err = cmd.Init([]string{"unit-demeter-0"})
+	c.Assert(err, gc.ErrorMatches, "agent-name must be a controller machine tag")
+	err = cmd.Init([]string{"machine-0"})
+	c.Assert(err, gc.ErrorMatches, "--from is required")
+	_, err = cmdtesting.RunCommand(c, cmd, "--from", "2.9.0", "--database", "juju", "machine-0")
+	c.Assert(err, gc.ErrorMatches, `--database must start with "juju-upgrade-check-"`)
+}
+
+func (s *checkUpgradeSuite) TestRun(c *gc.C) {
+	from := version.MustParse("2.9.0")
+	agentConf := newUpgradeAgentConf()
+	cmd := agentcmd.NewCheckUpgradeCommand(agentConf,
+		func(_ agent.Config, v version.Number, database string) ([]upgrades.CheckedStep, error) {
+			c.Check(v, gc.Equals, from)
+			c.Check(database, gc.Equals, "juju-upgrade-check-test")
+			return []upgrades.CheckedStep{{
+				PendingStep: upgrades.PendingStep{
+					TargetVersion: version.MustParse("2.9.1"),
+					Description:   "add a thing",
+					Targets:       []upgrades.Target{upgrades.DatabaseMaster},
+					State:         true,
+				},
+				Checked: true,
+				Err:     errors.New("boom"),
+			}, {
+				PendingStep: upgrades.PendingStep{
+					TargetVersion: version.MustParse("2.9.1"),
+					Description:   "change a file",
+					Targets:       []upgrades.Target{upgrades.AllMachines},
+				},
+			}}, nil
+		})
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--from", "2.9.0", "--database", "juju-upgrade-check-test", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	agentConf.stub.CheckCall(c, 0, "ReadConfig", "machine-0")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `[`+
+		`{"version":"2.9.1","description":"add a thing","targets":["databaseMaster"],"checked":true,"error":"boom"},`+
+		`{"version":"2.9.1","description":"change a file","targets":["allMachines"],"checked":false}`+
+		"]\n")
+}
+
+func newUpgradeAgentConf() *mockUpgradeAgentConf {
+	return &mockUpgradeAgentConf{mockAgentConf: newAgentConf()}
+}
+
+type mockUpgradeAgentConf struct {
+	*mockAgentConf
+}
+
+func (c *mockUpgradeAgentConf) AddFlags(f *gnuflag.FlagSet) {}
+
+func (c *mockUpgradeAgentConf) CheckArgs(args []string) error {
+	return nil
+}
+
+func (c *mockUpgradeAgentConf) CurrentConfig() agent.Config {
+	return nil
+}
@@ -582,6 +582,7 @@ func (a *MachineAgent) makeEngineCreator(
 			CentralHub:              a.centralHub,
 			LocalHub:                localHub,
 			PubSubReporter:          pubsubReporter,
+			PrometheusGatherer:      a.prometheusRegistry,
 			PresenceRecorder:        presenceRecorder,
 			UpdateLoggerConfig:      updateAgentConfLogging,
 			UpdateControllerAPIPort: updateControllerAPIPort,
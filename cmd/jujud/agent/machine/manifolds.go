@@ -48,6 +48,7 @@ import (
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/common"
 	lxdbroker "github.com/juju/juju/worker/containerbroker"
+	"github.com/juju/juju/worker/controllerhealth"
 	"github.com/juju/juju/worker/controllerport"
 	"github.com/juju/juju/worker/credentialvalidator"
 	"github.com/juju/juju/worker/deployer"
@@ -198,6 +199,10 @@ type ManifoldsConfig struct {
 	// worker.
 	PubSubReporter psworker.Reporter
 
+	// PrometheusGatherer is a prometheus.Gatherer that may be used by
+	// workers to read the metrics registered with PrometheusRegisterer.
+	PrometheusGatherer prometheus.Gatherer
+
 	// PresenceRecorder
 	PresenceRecorder presence.Recorder
 
@@ -788,6 +793,18 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewTarget:            raftforwarder.NewTarget,
 		})),
 
+		// The controller health worker reports on the raft node, lease
+		// store and pubsub forwarding of this controller when asked
+		// over the hub.
+		controllerHealthName: controllerhealth.Manifold(controllerhealth.ManifoldConfig{
+			RaftName:           raftName,
+			CentralHubName:     centralHubName,
+			PubSubReporter:     config.PubSubReporter,
+			PrometheusGatherer: config.PrometheusGatherer,
+			Logger:             loggo.GetLogger("juju.worker.controllerhealth"),
+			NewWorker:          controllerhealth.NewWorker,
+		}),
+
 		// The global lease manager tracks lease information in the raft
 		// cluster rather than in mongo.
 		leaseManagerName: ifController(leasemanager.Manifold(leasemanager.ManifoldConfig{
@@ -987,7 +1004,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 // CAASManifolds returns a set of co-configured manifolds covering the
 // various responsibilities of a CAAS machine agent.
 func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
-	return mergeManifolds(config, dependency.Manifolds{
+	manifolds := mergeManifolds(config, dependency.Manifolds{
 		// TODO(caas) - when we support HA, only want this on primary
 		upgraderName: caasupgrader.Manifold(caasupgrader.ManifoldConfig{
 			AgentName:            agentName,
@@ -997,6 +1014,12 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			PreviousAgentVersion: config.PreviousAgentVersion,
 		}),
 	})
+	// The replica set of an external mongo is managed by its operator,
+	// not the controller.
+	if config.Agent.CurrentConfig().Value(coreagent.ExternalMongoAddresses) != "" {
+		delete(manifolds, peergrouperName)
+	}
+	return manifolds
 }
 
 func mergeManifolds(config ManifoldsConfig, manifolds dependency.Manifolds) dependency.Manifolds {
@@ -1142,6 +1165,8 @@ const (
 	raftBackstopName  = "raft-backstop"
 	raftForwarderName = "raft-forwarder"
 
+	controllerHealthName = "controller-health"
+
 	validCredentialFlagName = "valid-credential-flag"
 
 	brokerTrackerName = "broker-tracker"
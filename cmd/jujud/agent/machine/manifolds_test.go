@@ -70,6 +70,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"certificate-updater",
 			"certificate-watcher",
 			"clock",
+			"controller-health",
 			"controller-port",
 			"deployer",
 			"disk-manager",
@@ -146,6 +147,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"central-hub",
 			"certificate-watcher",
 			"clock",
+			"controller-health",
 			"controller-port",
 			"external-controller-updater",
 			"http-server",
@@ -195,6 +197,18 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 	)
 }
 
+func (ms *ManifoldsSuite) TestManifoldNamesCAASExternalMongo(c *gc.C) {
+	manifolds := machine.CAASManifolds(machine.ManifoldsConfig{
+		Agent: &mockAgent{conf: mockConfig{values: map[string]string{
+			agent.ExternalMongoAddresses: "db-0.example.com:27017",
+		}}},
+	})
+	_, ok := manifolds["peer-grouper"]
+	c.Assert(ok, jc.IsFalse)
+	_, ok = manifolds["state"]
+	c.Assert(ok, jc.IsTrue)
+}
+
 func (*ManifoldsSuite) assertManifoldNames(c *gc.C, manifolds dependency.Manifolds, expectedKeys []string) {
 	keys := make([]string, 0, len(manifolds))
 	for k := range manifolds {
@@ -226,6 +240,7 @@ func (ms *ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"certificate-watcher",
 		"central-hub",
 		"clock",
+		"controller-health",
 		"controller-port",
 		"deployer",
 		"global-clock-updater",
@@ -499,6 +514,23 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 
 	"clock": {},
 
+	"controller-health": {
+		"agent",
+		"central-hub",
+		"clock",
+		"controller-port",
+		"http-server-args",
+		"is-controller-flag",
+		"raft",
+		"raft-transport",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"controller-port": {
 		"agent",
 		"central-hub",
@@ -1100,6 +1132,11 @@ type mockConfig struct {
 	ssiSet   bool
 	ssi      controller.StateServingInfo
 	dataPath string
+	values   map[string]string
+}
+
+func (mc *mockConfig) Value(key string) string {
+	return mc.values[key]
 }
 
 func (mc *mockConfig) Tag() names.Tag {
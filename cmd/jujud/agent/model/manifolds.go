@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
 	"github.com/juju/juju/worker/caasapplicationprovisioner"
+	"github.com/juju/juju/worker/caasautoscaler"
 	"github.com/juju/juju/worker/caasbroker"
 	"github.com/juju/juju/worker/caasenvironupgrader"
 	"github.com/juju/juju/worker/caasfirewaller"
@@ -511,6 +512,7 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
 			BrokerName:    caasBrokerTrackerName,
+			Clock:         config.Clock,
 			Logger:        loggo.GetLogger("juju.worker.caasmodeloperator"),
 			ModelUUID:     agentConfig.Model().Id(),
 		})),
@@ -536,6 +538,16 @@ func CAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		)),
 
+		caasAutoscalerName: ifNotMigrating(caasautoscaler.Manifold(
+			caasautoscaler.ManifoldConfig{
+				APICallerName: apiCallerName,
+				BrokerName:    caasBrokerTrackerName,
+				ClockName:     clockName,
+				NewWorker:     caasautoscaler.NewWorker,
+				Logger:        config.LoggingContext.GetLogger("juju.worker.caasautoscaler"),
+			},
+		)),
+
 		caasUnitProvisionerName: ifNotMigrating(caasunitprovisioner.Manifold(
 			caasunitprovisioner.ManifoldConfig{
 				APICallerName: apiCallerName,
@@ -691,6 +703,7 @@ const (
 	caasModelOperatorName          = "caas-model-operator"
 	caasOperatorProvisionerName    = "caas-operator-provisioner"
 	caasApplicationProvisionerName = "caas-application-provisioner"
+	caasAutoscalerName             = "caas-autoscaler"
 	caasUnitProvisionerName        = "caas-unit-provisioner"
 	caasStorageProvisionerName     = "caas-storage-provisioner"
 	caasBrokerTrackerName          = "caas-broker-tracker"
@@ -86,6 +86,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"caas-application-provisioner",
+		"caas-autoscaler",
 		"caas-broker-tracker",
 		"caas-firewaller-embedded",
 		"caas-firewaller-legacy",
@@ -273,6 +274,18 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"caas-autoscaler": {
+		"agent",
+		"api-caller",
+		"caas-broker-tracker",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"caas-storage-provisioner": {
 		"agent",
 		"api-caller",
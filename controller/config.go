@@ -5,8 +5,10 @@ package controller
 
 import (
 	"fmt"
+	"net"
 	"net/url"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/go-macaroon-bakery/macaroon-bakery/v3/bakery"
@@ -17,9 +19,12 @@ import (
 	"github.com/juju/romulus"
 	"github.com/juju/schema"
 	"github.com/juju/utils/v2"
+	"github.com/juju/utils/v2/cert"
 	"gopkg.in/juju/environschema.v1"
 
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/docker"
 	"github.com/juju/juju/pki"
 )
 
@@ -137,6 +142,17 @@ const (
 	// snaps for focal or later. The value is ignored for older releases.
 	JujuDBSnapChannel = "juju-db-snap-channel"
 
+	// ExternalMongoAddresses is a comma separated list of the host:port
+	// addresses of an externally managed MongoDB replica set. When set,
+	// Kubernetes controllers use it for their database rather than running
+	// their own mongo server. The replica set's admin user must already
+	// exist, with the admin-secret given at bootstrap as its password.
+	ExternalMongoAddresses = "external-mongo-addresses"
+
+	// ExternalMongoCACert is the CA certificate, in PEM format, used to
+	// validate the TLS certificates of the external mongo servers.
+	ExternalMongoCACert = "external-mongo-ca-cert"
+
 	// MaxDebugLogDuration is used to provide a backstop to the execution of a
 	// debug-log command. If someone starts a debug-log session in a remote
 	// screen for example, it is very easy to disconnect from the screen while
@@ -208,6 +224,12 @@ const (
 	// executing a model migration.
 	MigrationMinionWaitMax = "migration-agent-wait-time"
 
+	// MigrationRetryWait is how long the migration-master worker
+	// waits for a retry to be requested after a model migration's data
+	// transfer fails, before aborting the migration. A zero duration
+	// aborts the migration immediately.
+	MigrationRetryWait = "migration-transfer-retry-wait"
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -222,7 +244,9 @@ const (
 	CAASOperatorImagePath = "caas-operator-image-path"
 
 	// CAASImageRepo sets the docker repo to use
-	// for the jujud operator and mongo images.
+	// for the jujud operator and mongo images. It is either the
+	// path of a public repository, or a JSON object holding the
+	// repository and the credentials used to pull from it.
 	CAASImageRepo = "caas-image-repo"
 
 	// Features allows a list of runtime changeable features to be updated.
@@ -335,6 +359,10 @@ const (
 
 	// DefaultMigrationMinionMaxWait is the default value for
 	DefaultMigrationMinionWaitMax = "15m"
+
+	// DefaultMigrationRetryWait is the default value for
+	// MigrationRetryWait.
+	DefaultMigrationRetryWait = "15m"
 )
 
 var (
@@ -359,6 +387,8 @@ var (
 		StatePort,
 		MongoMemoryProfile,
 		JujuDBSnapChannel,
+		ExternalMongoAddresses,
+		ExternalMongoCACert,
 		MaxDebugLogDuration,
 		MaxTxnLogSize,
 		MaxPruneTxnBatchSize,
@@ -384,6 +414,7 @@ var (
 		MaxAgentStateSize,
 		NonSyncedWritesToRaftLog,
 		MigrationMinionWaitMax,
+		MigrationRetryWait,
 	}
 
 	// For backwards compatibility, we must include "anything", "juju-apiserver"
@@ -431,6 +462,7 @@ var (
 		MaxAgentStateSize,
 		NonSyncedWritesToRaftLog,
 		MigrationMinionWaitMax,
+		MigrationRetryWait,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -736,6 +768,24 @@ func (c Config) JujuDBSnapChannel() string {
 	return c.asString(JujuDBSnapChannel)
 }
 
+// ExternalMongoAddresses returns the addresses of the externally managed
+// mongo servers, or nil if the controller runs its own.
+func (c Config) ExternalMongoAddresses() []string {
+	var addrs []string
+	for _, addr := range strings.Split(c.asString(ExternalMongoAddresses), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// ExternalMongoCACert returns the CA certificate used to validate the
+// external mongo servers.
+func (c Config) ExternalMongoCACert() string {
+	return c.asString(ExternalMongoCACert)
+}
+
 // NUMACtlPreference returns if numactl is preferred.
 func (c Config) NUMACtlPreference() bool {
 	if numa, ok := c[SetNUMAControlPolicyKey]; ok {
@@ -839,7 +889,25 @@ func (c Config) CAASOperatorImagePath() string {
 // CAASImageRepo sets the url of the docker repo
 // used for the jujud operator and mongo images.
 func (c Config) CAASImageRepo() string {
-	return c.asString(CAASImageRepo)
+	details, err := c.CAASImageRepoDetails()
+	if err != nil {
+		return c.asString(CAASImageRepo)
+	}
+	return details.Repository
+}
+
+// CAASImageRepoDetails returns the location of, and the credentials
+// for, the docker repo used for the jujud operator and mongo images.
+func (c Config) CAASImageRepoDetails() (docker.ImageRepoDetails, error) {
+	content := c.asString(CAASImageRepo)
+	if content == "" {
+		return docker.ImageRepoDetails{}, nil
+	}
+	details, err := docker.NewImageRepoDetails(content)
+	if err != nil {
+		return docker.ImageRepoDetails{}, errors.Trace(err)
+	}
+	return *details, nil
 }
 
 // MeteringURL returns the URL to use for metering api calls.
@@ -889,6 +957,18 @@ func (c Config) MigrationMinionWaitMax() time.Duration {
 	return val
 }
 
+// MigrationRetryWait returns how long the migration-master
+// worker should wait for a retry to be requested after a model data
+// transfer fails.
+func (c Config) MigrationRetryWait() time.Duration {
+	asStr, ok := c[MigrationRetryWait].(string)
+	if !ok {
+		asStr = DefaultMigrationRetryWait
+	}
+	val, _ := time.ParseDuration(asStr)
+	return val
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -949,6 +1029,10 @@ func Validate(c Config) error {
 		}
 	}
 
+	if err := c.validateExternalMongo(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if v, ok := c[MaxDebugLogDuration].(time.Duration); ok {
 		if v == 0 {
 			return errors.Errorf("%s cannot be zero", MaxDebugLogDuration)
@@ -1007,7 +1091,7 @@ func Validate(c Config) error {
 	}
 
 	if v, ok := c[CAASImageRepo].(string); ok && v != "" {
-		if err := resources.ValidateDockerRegistryPath(v); err != nil {
+		if _, err := docker.NewImageRepoDetails(v); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -1099,6 +1183,49 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[MigrationRetryWait].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Errorf("%s value %q must be a valid duration", MigrationRetryWait, v)
+		}
+		if d < 0 {
+			return errors.Errorf("%s value %q must not be negative", MigrationRetryWait, v)
+		}
+	}
+
+	return nil
+}
+
+// ValidateCloudType checks that the settings which only apply to some
+// types of cloud are not set for a controller on another cloud type. An
+// external mongo is only supported by controllers on Kubernetes.
+func (c Config) ValidateCloudType(cloudType string) error {
+	if len(c.ExternalMongoAddresses()) > 0 && cloudType != cloud.CloudTypeKubernetes {
+		return errors.NotValidf("%s on %q cloud", ExternalMongoAddresses, cloudType)
+	}
+	return nil
+}
+
+func (c Config) validateExternalMongo() error {
+	addrs := c.ExternalMongoAddresses()
+	caCert := c.ExternalMongoCACert()
+	if len(addrs) == 0 {
+		if caCert != "" {
+			return errors.NotValidf("%s without %s", ExternalMongoCACert, ExternalMongoAddresses)
+		}
+		return nil
+	}
+	for _, addr := range addrs {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return errors.NotValidf("%s address %q", ExternalMongoAddresses, addr)
+		}
+	}
+	if caCert == "" {
+		return errors.NotValidf("%s without %s", ExternalMongoAddresses, ExternalMongoCACert)
+	}
+	if _, err := cert.ParseCert(caCert); err != nil {
+		return errors.Annotatef(err, "invalid %s", ExternalMongoCACert)
+	}
 	return nil
 }
 
@@ -1172,6 +1299,8 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AllowModelAccessKey:      schema.Bool(),
 	MongoMemoryProfile:       schema.String(),
 	JujuDBSnapChannel:        schema.String(),
+	ExternalMongoAddresses:   schema.String(),
+	ExternalMongoCACert:      schema.String(),
 	MaxDebugLogDuration:      schema.TimeDuration(),
 	MaxTxnLogSize:            schema.String(),
 	MaxPruneTxnBatchSize:     schema.ForceInt(),
@@ -1193,6 +1322,7 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxAgentStateSize:        schema.ForceInt(),
 	NonSyncedWritesToRaftLog: schema.Bool(),
 	MigrationMinionWaitMax:   schema.String(),
+	MigrationRetryWait:       schema.String(),
 }, schema.Defaults{
 	AgentRateLimitMax:        schema.Omit,
 	AgentRateLimitRate:       schema.Omit,
@@ -1214,6 +1344,8 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AllowModelAccessKey:      schema.Omit,
 	MongoMemoryProfile:       DefaultMongoMemoryProfile,
 	JujuDBSnapChannel:        DefaultJujuDBSnapChannel,
+	ExternalMongoAddresses:   schema.Omit,
+	ExternalMongoCACert:      schema.Omit,
 	MaxDebugLogDuration:      DefaultMaxDebugLogDuration,
 	MaxTxnLogSize:            fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
 	MaxPruneTxnBatchSize:     DefaultMaxPruneTxnBatchSize,
@@ -1235,6 +1367,7 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxAgentStateSize:        DefaultMaxAgentStateSize,
 	NonSyncedWritesToRaftLog: DefaultNonSyncedWritesToRaftLog,
 	MigrationMinionWaitMax:   DefaultMigrationMinionWaitMax,
+	MigrationRetryWait:       DefaultMigrationRetryWait,
 })
 
 // ConfigSchema holds information on all the fields defined by
@@ -1325,6 +1458,15 @@ they don't have any access rights to the controller itself`,
 		Type:        environschema.Tstring,
 		Description: `Sets channel for installing mongo snaps when bootstrapping on focal or later`,
 	},
+	ExternalMongoAddresses: {
+		Type: environschema.Tstring,
+		Description: `A comma separated list of host:port addresses of an externally 
+managed mongo replica set, used by Kubernetes controllers instead of their own mongo server`,
+	},
+	ExternalMongoCACert: {
+		Type:        environschema.Tstring,
+		Description: `The CA certificate used to validate the external mongo servers`,
+	},
 	MaxDebugLogDuration: {
 		Type:        environschema.Tstring,
 		Description: `The maximum duration that a debug-log session is allowed to run`,
@@ -1379,8 +1521,11 @@ they don't have any access rights to the controller itself`,
 Use "caas-image-repo" instead.`,
 	},
 	CAASImageRepo: {
-		Type:        environschema.Tstring,
-		Description: `The docker repo to use for the jujud operator and mongo images`,
+		Type: environschema.Tstring,
+		Description: `The docker repo to use for the jujud operator and mongo images.
+Either a repository path, or a JSON object with "repository" and the
+"username" and "password", "auth" or "registrytoken" credentials of
+a private registry`,
 	},
 	Features: {
 		Type:        environschema.FieldType("list of strings"),
@@ -1410,4 +1555,8 @@ Use "caas-image-repo" instead.`,
 		Type:        environschema.Tstring,
 		Description: `The maximum during model migrations that the migration worker will wait for agents to report on phases of the migration`,
 	},
+	MigrationRetryWait: {
+		Type:        environschema.Tstring,
+		Description: `How long a model migration waits for a retry to be requested after its data transfer fails, before aborting. Zero aborts immediately`,
+	},
 }
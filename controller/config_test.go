@@ -9,12 +9,14 @@ import (
 
 	"github.com/juju/charmrepo/v7/csclient"
 	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/romulus"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/docker"
 	"github.com/juju/juju/testing"
 )
 
@@ -203,6 +205,12 @@ var newConfigTests = []struct {
 		controller.CAASImageRepo: "foo//bar",
 	},
 	expectError: `docker image path "foo//bar" not valid`,
+}, {
+	about: "invalid CAAS docker image repo - credentials without password",
+	config: controller.Config{
+		controller.CAASImageRepo: `{"repository": "registry.foo.com/juju", "username": "jujuqa"}`,
+	},
+	expectError: `username and password must both be set or both be empty, basic auth not valid`,
 }, {
 	about: "negative controller-api-port",
 	config: controller.Config{
@@ -351,6 +359,44 @@ var newConfigTests = []struct {
 		controller.MigrationMinionWaitMax: "15",
 	},
 	expectError: `migration-agent-wait-time value "15" must be a valid duration`,
+}, {
+	about: "migration-transfer-retry-wait not a duration",
+	config: controller.Config{
+		controller.MigrationRetryWait: "15",
+	},
+	expectError: `migration-transfer-retry-wait value "15" must be a valid duration`,
+}, {
+	about: "migration-transfer-retry-wait negative",
+	config: controller.Config{
+		controller.MigrationRetryWait: "-1m",
+	},
+	expectError: `migration-transfer-retry-wait value "-1m" must not be negative`,
+}, {
+	about: "external-mongo-addresses without port",
+	config: controller.Config{
+		controller.ExternalMongoAddresses: "db-0.example.com:27017,db-1.example.com",
+		controller.ExternalMongoCACert:    testing.CACert,
+	},
+	expectError: `external-mongo-addresses address "db-1.example.com" not valid`,
+}, {
+	about: "external-mongo-addresses without external-mongo-ca-cert",
+	config: controller.Config{
+		controller.ExternalMongoAddresses: "db-0.example.com:27017",
+	},
+	expectError: `external-mongo-addresses without external-mongo-ca-cert not valid`,
+}, {
+	about: "external-mongo-ca-cert without external-mongo-addresses",
+	config: controller.Config{
+		controller.ExternalMongoCACert: testing.CACert,
+	},
+	expectError: `external-mongo-ca-cert without external-mongo-addresses not valid`,
+}, {
+	about: "invalid external-mongo-ca-cert",
+	config: controller.Config{
+		controller.ExternalMongoAddresses: "db-0.example.com:27017",
+		controller.ExternalMongoCACert:    "foo",
+	},
+	expectError: `invalid external-mongo-ca-cert: .*`,
 }, {}}
 
 func (s *ConfigSuite) TestNewConfig(c *gc.C) {
@@ -610,6 +656,25 @@ func (s *ConfigSuite) TestCAASImageRepo(c *gc.C) {
 	}
 }
 
+func (s *ConfigSuite) TestCAASImageRepoDetails(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.CAASImageRepo: `{"repository": "registry.foo.com/juju", "username": "jujuqa", "password": "secret"}`,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.CAASImageRepo(), gc.Equals, "registry.foo.com/juju")
+	details, err := cfg.CAASImageRepoDetails()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details, jc.DeepEquals, docker.ImageRepoDetails{
+		Repository: "registry.foo.com/juju",
+		Username:   "jujuqa",
+		Password:   "secret",
+	})
+}
+
 func (s *ConfigSuite) TestCharmstoreURLDefault(c *gc.C) {
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -781,6 +846,49 @@ func (s *ConfigSuite) TestJujuDBSnapChannel(c *gc.C) {
 	c.Assert(cfg.JujuDBSnapChannel(), gc.Equals, "latest/candidate")
 }
 
+func (s *ConfigSuite) TestExternalMongo(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ExternalMongoAddresses(), gc.HasLen, 0)
+	c.Assert(cfg.ExternalMongoCACert(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"external-mongo-addresses": "db-0.example.com:27017, db-1.example.com:27017",
+			"external-mongo-ca-cert":   testing.CACert,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ExternalMongoAddresses(), jc.DeepEquals, []string{"db-0.example.com:27017", "db-1.example.com:27017"})
+	c.Assert(cfg.ExternalMongoCACert(), gc.Equals, testing.CACert)
+}
+
+func (s *ConfigSuite) TestExternalMongoCloudType(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"external-mongo-addresses": "db-0.example.com:27017",
+			"external-mongo-ca-cert":   testing.CACert,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ValidateCloudType("kubernetes"), jc.ErrorIsNil)
+	err = cfg.ValidateCloudType("ec2")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `external-mongo-addresses on "ec2" cloud not valid`)
+
+	cfg, err = controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.ValidateCloudType("ec2"), jc.ErrorIsNil)
+}
+
 func (s *ConfigSuite) TestMigrationMinionWaitMax(c *gc.C) {
 	cfg, err := controller.NewConfig(
 		testing.ControllerTag.Id(),
@@ -794,3 +902,14 @@ func (s *ConfigSuite) TestMigrationMinionWaitMax(c *gc.C) {
 	cfg[controller.MigrationMinionWaitMax] = "500ms"
 	c.Assert(cfg.MigrationMinionWaitMax(), gc.Equals, 500*time.Millisecond)
 }
+
+func (s *ConfigSuite) TestMigrationRetryWait(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MigrationRetryWait(), gc.Equals, 15*time.Minute)
+
+	cfg[controller.MigrationRetryWait] = "0s"
+	c.Assert(cfg.MigrationRetryWait(), gc.Equals, time.Duration(0))
+}
@@ -23,6 +23,16 @@ func IsJujuExecAction(name string) bool {
 	return name == JujuExecActionName || name == legacyJujuRunActionName
 }
 
+// JujuNetworkCheckActionName defines the name of the predefined machine
+// action used to probe the addresses of units related to those on a machine.
+const JujuNetworkCheckActionName = "juju-network-check"
+
+// IsJujuNetworkCheckAction returns true if name is the
+// "juju-network-check" action.
+func IsJujuNetworkCheckAction(name string) bool {
+	return name == JujuNetworkCheckActionName
+}
+
 // HasJujuExecAction returns true if the "juju-exec" binary name appears
 // anywhere in the specified commands.
 func HasJujuExecAction(commands string) bool {
@@ -56,3 +66,38 @@ var PredefinedActionsSpec = map[string]charm.ActionSpec{
 		},
 	},
 }
+
+// PredefinedMachineActionsSpec defines a spec for each predefined action
+// that can only be run on a machine.
+var PredefinedMachineActionsSpec = map[string]charm.ActionSpec{
+	JujuNetworkCheckActionName: {
+		Description: "predefined juju-network-check action",
+		Parallel:    true,
+		Params: map[string]interface{}{
+			"type":        "object",
+			"title":       JujuNetworkCheckActionName,
+			"description": "predefined juju-network-check action params",
+			"properties": map[string]interface{}{
+				"port": map[string]interface{}{
+					"type":        "integer",
+					"description": "TCP port used to probe related unit addresses",
+					"default":     22,
+				},
+				"timeout": map[string]interface{}{
+					"type":        "number",
+					"description": "timeout for each address probe",
+				},
+			},
+		},
+	},
+}
+
+// MachineActionSpec returns the spec for the predefined action with the
+// input name, if it is able to be run on a machine.
+func MachineActionSpec(name string) (charm.ActionSpec, bool) {
+	if spec, ok := PredefinedActionsSpec[name]; ok {
+		return spec, true
+	}
+	spec, ok := PredefinedMachineActionsSpec[name]
+	return spec, ok
+}
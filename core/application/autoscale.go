@@ -0,0 +1,108 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"math"
+
+	"github.com/juju/errors"
+)
+
+// AutoscaleTolerance is how far the observed usage of an application
+// may be from its target, as a fraction of the target, before the
+// application is scaled.
+const AutoscaleTolerance = 0.1
+
+// AutoscalePolicy describes how Juju scales a Kubernetes application
+// between a minimum and maximum number of units to keep the usage of
+// its units near the targets. At least one target must be set.
+type AutoscalePolicy struct {
+	// MinUnits and MaxUnits bound the number of units.
+	MinUnits int
+	MaxUnits int
+
+	// TargetCPU and TargetMemory are the average CPU and memory usage
+	// of the units to aim for, as a percentage of the resources
+	// requested by their pods. Zero means no target.
+	TargetCPU    int
+	TargetMemory int
+
+	// Metric is the name of a metric reported by the charm, and
+	// TargetMetric is its average value across the units to aim for.
+	Metric       string
+	TargetMetric float64
+}
+
+// Validate returns an error if the policy is not valid.
+func (p AutoscalePolicy) Validate() error {
+	if p.MinUnits < 0 {
+		return errors.NotValidf("minimum units %d", p.MinUnits)
+	}
+	if p.MaxUnits < 1 || p.MaxUnits < p.MinUnits {
+		return errors.NotValidf("maximum units %d with minimum units %d", p.MaxUnits, p.MinUnits)
+	}
+	if p.TargetCPU < 0 {
+		return errors.NotValidf("target CPU %d%%", p.TargetCPU)
+	}
+	if p.TargetMemory < 0 {
+		return errors.NotValidf("target memory %d%%", p.TargetMemory)
+	}
+	if (p.Metric == "") != (p.TargetMetric == 0) {
+		return errors.NotValidf("metric %q with target %v", p.Metric, p.TargetMetric)
+	}
+	if p.TargetMetric < 0 {
+		return errors.NotValidf("target metric value %v", p.TargetMetric)
+	}
+	if p.TargetCPU == 0 && p.TargetMemory == 0 && p.Metric == "" {
+		return errors.NotValidf("autoscale policy without a target")
+	}
+	return nil
+}
+
+// AutoscaleUsage holds the observed average usage of an application's
+// units. A nil value means the usage isn't known.
+type AutoscaleUsage struct {
+	// CPU and Memory are percentages of the resources requested by
+	// the units' pods.
+	CPU    *float64
+	Memory *float64
+
+	// Metric is the average value of the policy's charm metric.
+	Metric *float64
+}
+
+// DesiredScale returns the number of units the application should have
+// for its units to reach the policy's targets, given its current scale
+// and the usage of its units. The scale is proportional to how far the
+// usage is from each target, taking the largest of the scales for each
+// target that is known, and is kept within the policy's bounds.
+func (p AutoscalePolicy) DesiredScale(current int, usage AutoscaleUsage) int {
+	desired := -1
+	consider := func(observed *float64, target float64) {
+		if observed == nil || target <= 0 || current == 0 {
+			return
+		}
+		ratio := *observed / target
+		scale := current
+		if math.Abs(ratio-1) > AutoscaleTolerance {
+			scale = int(math.Ceil(float64(current) * ratio))
+		}
+		if scale > desired {
+			desired = scale
+		}
+	}
+	consider(usage.CPU, float64(p.TargetCPU))
+	consider(usage.Memory, float64(p.TargetMemory))
+	consider(usage.Metric, p.TargetMetric)
+	if desired < 0 {
+		desired = current
+	}
+	if desired < p.MinUnits {
+		desired = p.MinUnits
+	}
+	if desired > p.MaxUnits {
+		desired = p.MaxUnits
+	}
+	return desired
+}
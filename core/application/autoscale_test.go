@@ -0,0 +1,116 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+type AutoscaleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&AutoscaleSuite{})
+
+func (s *AutoscaleSuite) TestValidate(c *gc.C) {
+	valid := application.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetCPU: 70}
+	c.Assert(valid.Validate(), jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		policy application.AutoscalePolicy
+		err    string
+	}{{
+		policy: application.AutoscalePolicy{MinUnits: -1, MaxUnits: 5, TargetCPU: 70},
+		err:    "minimum units -1 not valid",
+	}, {
+		policy: application.AutoscalePolicy{MinUnits: 3, MaxUnits: 2, TargetCPU: 70},
+		err:    "maximum units 2 with minimum units 3 not valid",
+	}, {
+		policy: application.AutoscalePolicy{MaxUnits: 0, TargetCPU: 70},
+		err:    "maximum units 0 with minimum units 0 not valid",
+	}, {
+		policy: application.AutoscalePolicy{MaxUnits: 5, TargetMemory: -10},
+		err:    "target memory -10% not valid",
+	}, {
+		policy: application.AutoscalePolicy{MaxUnits: 5, Metric: "queue-length"},
+		err:    `metric "queue-length" with target 0 not valid`,
+	}, {
+		policy: application.AutoscalePolicy{MaxUnits: 5},
+		err:    "autoscale policy without a target not valid",
+	}} {
+		c.Logf("test %d: %s", i, test.err)
+		c.Check(test.policy.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func (s *AutoscaleSuite) TestDesiredScale(c *gc.C) {
+	policy := application.AutoscalePolicy{
+		MinUnits:     2,
+		MaxUnits:     10,
+		TargetCPU:    50,
+		TargetMemory: 80,
+		Metric:       "queue-length",
+		TargetMetric: 100,
+	}
+	for i, test := range []struct {
+		about   string
+		current int
+		usage   application.AutoscaleUsage
+		desired int
+	}{{
+		about:   "no usage known",
+		current: 3,
+		desired: 3,
+	}, {
+		about:   "within tolerance",
+		current: 4,
+		usage:   application.AutoscaleUsage{CPU: floatPtr(54)},
+		desired: 4,
+	}, {
+		about:   "scale up on CPU",
+		current: 4,
+		usage:   application.AutoscaleUsage{CPU: floatPtr(75)},
+		desired: 6,
+	}, {
+		about:   "scale down on memory",
+		current: 6,
+		usage:   application.AutoscaleUsage{Memory: floatPtr(40)},
+		desired: 3,
+	}, {
+		about:   "largest scale wins",
+		current: 4,
+		usage: application.AutoscaleUsage{
+			CPU:    floatPtr(25),
+			Memory: floatPtr(80),
+			Metric: floatPtr(150),
+		},
+		desired: 6,
+	}, {
+		about:   "kept above the minimum",
+		current: 3,
+		usage:   application.AutoscaleUsage{CPU: floatPtr(5)},
+		desired: 2,
+	}, {
+		about:   "kept below the maximum",
+		current: 8,
+		usage:   application.AutoscaleUsage{CPU: floatPtr(100)},
+		desired: 10,
+	}, {
+		about:   "no units scale to the minimum",
+		current: 0,
+		usage:   application.AutoscaleUsage{CPU: floatPtr(100)},
+		desired: 2,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		c.Check(policy.DesiredScale(test.current, test.usage), gc.Equals, test.desired)
+	}
+}
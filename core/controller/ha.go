@@ -3,5 +3,66 @@
 
 package controller
 
+import "sort"
+
 // MaxPeers defines the maximum number of peers that mongo supports.
 const MaxPeers = 7
+
+// SpreadZones returns the availability zones in which to start the
+// given number of new controllers, so that the controllers are spread
+// as evenly as possible across the zones. voters holds the number of
+// existing voting controllers in each zone. Each new controller goes
+// into the least populated zone, with ties broken by zone name.
+func SpreadZones(zones []string, voters map[string]int, count int) []string {
+	if len(zones) == 0 {
+		return nil
+	}
+	population := make(map[string]int, len(zones))
+	for _, zone := range zones {
+		population[zone] = voters[zone]
+	}
+	sorted := append([]string(nil), zones...)
+	sort.Strings(sorted)
+
+	result := make([]string, count)
+	for i := range result {
+		best := sorted[0]
+		for _, zone := range sorted[1:] {
+			if population[zone] < population[best] {
+				best = zone
+			}
+		}
+		population[best]++
+		result[i] = best
+	}
+	return result
+}
+
+// ZoneFailureLosesQuorum returns the availability zone, if any, whose
+// failure alone would leave fewer than a majority of the voting
+// controllers. voters holds the number of voting controllers in each
+// zone; controllers in an unknown zone are keyed by the empty string,
+// and count towards the total only. A single controller can't survive
+// any failure, so is never reported.
+func ZoneFailureLosesQuorum(voters map[string]int) (string, bool) {
+	total := 0
+	for _, n := range voters {
+		total += n
+	}
+	if total < 2 {
+		return "", false
+	}
+	var zones []string
+	for zone := range voters {
+		if zone != "" {
+			zones = append(zones, zone)
+		}
+	}
+	sort.Strings(zones)
+	for _, zone := range zones {
+		if total-voters[zone] <= total/2 {
+			return zone, true
+		}
+	}
+	return "", false
+}
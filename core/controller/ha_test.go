@@ -0,0 +1,60 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/controller"
+)
+
+type haSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&haSuite{})
+
+func (s *haSuite) TestSpreadZones(c *gc.C) {
+	zones := []string{"c", "a", "b"}
+	c.Check(controller.SpreadZones(zones, nil, 3), jc.DeepEquals, []string{"a", "b", "c"})
+	c.Check(controller.SpreadZones(zones, map[string]int{"a": 1}, 2), jc.DeepEquals, []string{"b", "c"})
+	c.Check(controller.SpreadZones(zones, map[string]int{"a": 1, "b": 1, "c": 1}, 2), jc.DeepEquals, []string{"a", "b"})
+	c.Check(controller.SpreadZones(zones, map[string]int{"b": 2, "x": 1}, 4), jc.DeepEquals, []string{"a", "c", "a", "c"})
+	c.Check(controller.SpreadZones(nil, nil, 2), gc.HasLen, 0)
+}
+
+func (s *haSuite) TestZoneFailureLosesQuorum(c *gc.C) {
+	for i, test := range []struct {
+		voters map[string]int
+		zone   string
+		lost   bool
+	}{{
+		voters: map[string]int{"a": 1},
+	}, {
+		voters: map[string]int{"a": 1, "b": 1, "c": 1},
+	}, {
+		voters: map[string]int{"a": 2, "b": 2, "c": 1},
+	}, {
+		voters: map[string]int{"a": 1, "b": 2},
+		zone:   "b",
+		lost:   true,
+	}, {
+		voters: map[string]int{"a": 3, "b": 1, "c": 1},
+		zone:   "a",
+		lost:   true,
+	}, {
+		voters: map[string]int{"": 2, "a": 1},
+	}, {
+		voters: map[string]int{"": 1, "a": 2},
+		zone:   "a",
+		lost:   true,
+	}} {
+		c.Logf("test %d: %v", i, test.voters)
+		zone, lost := controller.ZoneFailureLosesQuorum(test.voters)
+		c.Check(zone, gc.Equals, test.zone)
+		c.Check(lost, gc.Equals, test.lost)
+	}
+}
@@ -32,6 +32,39 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// Progress records how much of the model's data has been
+	// transferred to the target controller.
+	Progress TransferProgress
+
+	// Retries is the number of times a retry of a failed model data
+	// transfer has been requested.
+	Retries int
+}
+
+// TransferProgress records how much of a model's data has been
+// transferred to the target controller during a migration.
+type TransferProgress struct {
+	// Charms and CharmsTotal hold the number of charms uploaded to the
+	// target controller and the number to be uploaded.
+	Charms      int
+	CharmsTotal int
+
+	// Tools and ToolsTotal hold the number of agent binaries uploaded
+	// to the target controller and the number to be uploaded.
+	Tools      int
+	ToolsTotal int
+
+	// Resources and ResourcesTotal hold the number of resources
+	// transferred to the target controller and the number to be
+	// transferred.
+	Resources      int
+	ResourcesTotal int
+
+	// LogMessages and LogBytes hold the number of log messages and
+	// the size of their text transferred to the target controller.
+	LogMessages int
+	LogBytes    int64
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -68,6 +101,19 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+
+	// CloudName, CloudType and CloudRegion describe the cloud
+	// hosting the model. When set, the target controller checks
+	// that it knows the same cloud and region.
+	CloudName   string
+	CloudType   string
+	CloudRegion string
+
+	// CloudCredential and CloudCredentialAuthType describe the
+	// model's cloud credential. When set, the target controller
+	// checks that the credential doesn't conflict with one it has.
+	CloudCredential         names.CloudCredentialTag
+	CloudCredentialAuthType string
 }
 
 func (i *ModelInfo) Validate() error {
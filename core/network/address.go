@@ -224,7 +224,17 @@ func (a MachineAddress) ValueWithMask() (string, error) {
 // - non-hostnames with unknown scope last.
 // Secondary addresses with otherwise equal weight will be sorted to come after
 // primary addresses, including host names *except* localhost.
+// IPv4 addresses are sorted ahead of IPv6 addresses with otherwise equal
+// weight.
 func (a MachineAddress) sortOrder() int {
+	return a.sortOrderPreferring(IPv4Address)
+}
+
+// sortOrderPreferring calculates the "weight" of the address as described
+// for sortOrder, except that addresses of the input preferred type are
+// sorted ahead of those for the other IP address family with otherwise
+// equal weight.
+func (a MachineAddress) sortOrderPreferring(preferredType AddressType) int {
 	order := 100
 
 	switch a.Scope {
@@ -250,9 +260,10 @@ func (a MachineAddress) sortOrder() int {
 		if a.Value == "localhost" {
 			order = 20
 		}
-	case IPv6Address:
-		order++
-	case IPv4Address:
+	case IPv4Address, IPv6Address:
+		if a.Type != preferredType {
+			order++
+		}
 	}
 
 	if a.IsSecondary {
@@ -710,6 +721,24 @@ func ScopeMatchCloudLocal(addr Address) ScopeMatch {
 	return invalidScope
 }
 
+// ScopeMatchPreferringIPv6 returns a scope matching function that ranks
+// addresses as the input function does, except that IPv6 addresses are
+// ranked ahead of IPv4 addresses with the same scope.
+func ScopeMatchPreferringIPv6(matchFunc ScopeMatchFunc) ScopeMatchFunc {
+	return func(addr Address) ScopeMatch {
+		match := matchFunc(addr)
+		switch match {
+		case exactScopeIPv4, firstFallbackScopeIPv4, secondFallbackScopeIPv4:
+			return match + 1
+		case exactScope, firstFallbackScope, secondFallbackScope:
+			if addr.AddressType() == IPv6Address {
+				return match - 1
+			}
+		}
+		return match
+	}
+}
+
 type addressByIndexFunc func(index int) Address
 
 // indexesForScope returns the indexes of the addresses with the best
@@ -766,15 +795,18 @@ func scopeMatchHierarchy() []ScopeMatch {
 	}
 }
 
-type addressesPreferringIPv4 []SpaceAddress
+type addressesPreferringType struct {
+	addrs         []SpaceAddress
+	preferredType AddressType
+}
 
-func (a addressesPreferringIPv4) Len() int      { return len(a) }
-func (a addressesPreferringIPv4) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
-func (a addressesPreferringIPv4) Less(i, j int) bool {
-	addr1 := a[i]
-	addr2 := a[j]
-	order1 := addr1.sortOrder()
-	order2 := addr2.sortOrder()
+func (a addressesPreferringType) Len() int      { return len(a.addrs) }
+func (a addressesPreferringType) Swap(i, j int) { a.addrs[i], a.addrs[j] = a.addrs[j], a.addrs[i] }
+func (a addressesPreferringType) Less(i, j int) bool {
+	addr1 := a.addrs[i]
+	addr2 := a.addrs[j]
+	order1 := addr1.sortOrderPreferring(a.preferredType)
+	order2 := addr2.sortOrderPreferring(a.preferredType)
 	if order1 == order2 {
 		return addr1.Value < addr2.Value
 	}
@@ -784,7 +816,14 @@ func (a addressesPreferringIPv4) Less(i, j int) bool {
 // SortAddresses sorts the given Address slice according to the sortOrder of
 // each address. See Address.sortOrder() for more info.
 func SortAddresses(addrs []SpaceAddress) {
-	sort.Sort(addressesPreferringIPv4(addrs))
+	sort.Sort(addressesPreferringType{addrs: addrs, preferredType: IPv4Address})
+}
+
+// SortAddressesPreferringIPv6 sorts the given Address slice in the same
+// manner as SortAddresses, except that IPv6 addresses are placed ahead of
+// IPv4 addresses that would otherwise have the same weight.
+func SortAddressesPreferringIPv6(addrs []SpaceAddress) {
+	sort.Sort(addressesPreferringType{addrs: addrs, preferredType: IPv6Address})
 }
 
 // MergedAddresses provides a single list of addresses without duplicates
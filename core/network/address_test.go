@@ -545,6 +545,33 @@ func (s *AddressSuite) TestSelectInternalMachineAddress(c *gc.C) {
 	}
 }
 
+func (s *AddressSuite) TestScopeMatchPreferringIPv6(c *gc.C) {
+	addrs := network.SpaceAddresses{
+		network.NewSpaceAddress("10.0.0.1", network.WithScope(network.ScopeCloudLocal)),
+		network.NewSpaceAddress("fc00::1", network.WithScope(network.ScopeCloudLocal)),
+		network.NewSpaceAddress("8.8.8.8", network.WithScope(network.ScopePublic)),
+		network.NewSpaceAddress("2001:db8::1", network.WithScope(network.ScopePublic)),
+	}
+
+	addr, ok := addrs.OneMatchingScope(network.ScopeMatchPreferringIPv6(network.ScopeMatchCloudLocal))
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "fc00::1")
+
+	addr, ok = addrs.OneMatchingScope(network.ScopeMatchPreferringIPv6(network.ScopeMatchPublic))
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "2001:db8::1")
+
+	// Scope still takes precedence over address family.
+	addr, ok = addrs[:3].OneMatchingScope(network.ScopeMatchPreferringIPv6(network.ScopeMatchPublic))
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "8.8.8.8")
+
+	// IPv4-only addresses are still selected.
+	addr, ok = addrs[:1].OneMatchingScope(network.ScopeMatchPreferringIPv6(network.ScopeMatchCloudLocal))
+	c.Assert(ok, jc.IsTrue)
+	c.Check(addr.Value, gc.Equals, "10.0.0.1")
+}
+
 type selectInternalAddressesTest struct {
 	about     string
 	addresses network.SpaceAddresses
@@ -737,6 +764,42 @@ func (*AddressSuite) TestSortAddresses(c *gc.C) {
 	})
 }
 
+func (*AddressSuite) TestSortAddressesPreferringIPv6(c *gc.C) {
+	addrs := network.NewSpaceAddresses(
+		"127.0.0.1",
+		"::1",
+		"fc00::1",
+		"169.254.1.2",
+		"localhost",
+		"2001:db8::1",
+		"fe80::2",
+		"7.8.8.8",
+		"172.16.0.2",
+		"example.com",
+	)
+
+	network.SortAddressesPreferringIPv6(addrs)
+	c.Assert(addrs.Values(), jc.DeepEquals, []string{
+		// Public IPv6 addresses on top.
+		"2001:db8::1",
+		// After that public IPv4 addresses.
+		"7.8.8.8",
+		// Then hostnames, with localhost last.
+		"example.com",
+		"localhost",
+		// Then IPv6 cloud-local addresses.
+		"fc00::1",
+		// Then IPv4 cloud-local addresses.
+		"172.16.0.2",
+		// Then machine-local addresses.
+		"::1",
+		"127.0.0.1",
+		// Finally, link-local addresses.
+		"fe80::2",
+		"169.254.1.2",
+	})
+}
+
 func (*AddressSuite) TestExactScopeMatch(c *gc.C) {
 	var addr network.Address
 
@@ -6,16 +6,16 @@ package docker
 import (
 	"time"
 
-	"github.com/juju/juju/tools"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version/v2"
 	gc "gopkg.in/check.v1"
 
-	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
 )
 
 type DockerSuiteSuite struct {
-	coretesting.BaseSuite
+	testing.IsolationSuite
 }
 
 var _ = gc.Suite(&DockerSuiteSuite{})
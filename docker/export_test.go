@@ -0,0 +1,22 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package docker
+
+import (
+	"time"
+)
+
+var JWTExpiry = jwtExpiry
+
+type patcher interface {
+	PatchValue(dest, value interface{})
+}
+
+// PatchTokenExchange replaces the exchange of cloud credentials for pull
+// tokens with f, which is passed the name of the cloud registry.
+func PatchTokenExchange(p patcher, f func(registry string, rid ImageRepoDetails) (string, time.Time, error)) {
+	p.PatchValue(&exchangeToken, func(provider *tokenProvider, rid ImageRepoDetails) (string, time.Time, error) {
+		return f(provider.name, rid)
+	})
+}
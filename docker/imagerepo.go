@@ -0,0 +1,198 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package docker
+
+import (
+	// Import shas that are used for docker image validation.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/juju/errors"
+)
+
+// ImageRepoDetails contains the location of, and the credentials for,
+// the OCI registry repository that Juju pulls its controller, operator
+// and charm base images from.
+type ImageRepoDetails struct {
+	// Repository is the path of the repository, including the registry
+	// host, e.g. "registry.example.com/juju".
+	Repository string `json:"repository,omitempty" yaml:"repository,omitempty"`
+
+	// ServerAddress is the address of the registry to authenticate
+	// with. It defaults to the host of the repository.
+	ServerAddress string `json:"serveraddress,omitempty" yaml:"serveraddress,omitempty"`
+
+	// Username and Password are basic auth credentials. For registries
+	// issuing short lived pull tokens, they are the long lived cloud
+	// credentials exchanged for the token.
+	Username string `json:"username,omitempty" yaml:"username,omitempty"`
+	Password string `json:"password,omitempty" yaml:"password,omitempty"`
+
+	// Auth is the base64 encoded "username:password" basic auth
+	// credentials, as found in a docker config file.
+	Auth string `json:"auth,omitempty" yaml:"auth,omitempty"`
+
+	// IdentityToken is used to obtain an access token for the registry.
+	IdentityToken string `json:"identitytoken,omitempty" yaml:"identitytoken,omitempty"`
+
+	// RegistryToken is a bearer token presented to the registry.
+	RegistryToken string `json:"registrytoken,omitempty" yaml:"registrytoken,omitempty"`
+}
+
+// NewImageRepoDetails parses the value of the caas-image-repo
+// controller config. The value is either the path of a public
+// repository, or a JSON object describing a private one.
+func NewImageRepoDetails(content string) (*ImageRepoDetails, error) {
+	content = strings.TrimSpace(content)
+	details := &ImageRepoDetails{}
+	if strings.HasPrefix(content, "{") {
+		if err := json.Unmarshal([]byte(content), details); err != nil {
+			return nil, errors.Annotate(err, "parsing image repo details")
+		}
+	} else {
+		details.Repository = content
+	}
+	if err := details.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return details, nil
+}
+
+// Validate returns an error if the details are not valid.
+func (rid ImageRepoDetails) Validate() error {
+	if rid.Repository == "" {
+		return errors.NotValidf("empty repository")
+	}
+	if _, err := reference.ParseNormalizedNamed(rid.Repository); err != nil {
+		return errors.NotValidf("docker image path %q", rid.Repository)
+	}
+	if (rid.Username == "") != (rid.Password == "") {
+		return errors.NotValidf("username and password must both be set or both be empty, basic auth")
+	}
+	if rid.Auth != "" {
+		if rid.Username != "" {
+			return errors.NotValidf("both auth and username set, basic auth")
+		}
+		decoded, err := base64.StdEncoding.DecodeString(rid.Auth)
+		if err != nil || !strings.Contains(string(decoded), ":") {
+			return errors.NotValidf("auth, expected base64 encoded \"username:password\"")
+		}
+	}
+	p := rid.tokenProvider()
+	if p == nil && rid.RegistryToken != "" && (rid.Username != "" || rid.Auth != "") {
+		return errors.NotValidf("both registry token and basic auth set")
+	}
+	return nil
+}
+
+// Empty returns true if no repository is set.
+func (rid ImageRepoDetails) Empty() bool {
+	return rid == ImageRepoDetails{}
+}
+
+// IsPrivate returns true if credentials are required to pull images
+// from the repository.
+func (rid ImageRepoDetails) IsPrivate() bool {
+	return rid.Username != "" || rid.Auth != "" || rid.IdentityToken != "" || rid.RegistryToken != ""
+}
+
+// RegistryURL returns the address of the registry to authenticate with.
+func (rid ImageRepoDetails) RegistryURL() string {
+	if rid.ServerAddress != "" {
+		return rid.ServerAddress
+	}
+	named, err := reference.ParseNormalizedNamed(rid.Repository)
+	if err != nil {
+		return ""
+	}
+	return reference.Domain(named)
+}
+
+// Content returns the details in the form accepted by
+// NewImageRepoDetails.
+func (rid ImageRepoDetails) Content() string {
+	if !rid.IsPrivate() && rid.ServerAddress == "" {
+		return rid.Repository
+	}
+	data, _ := json.Marshal(rid)
+	return string(data)
+}
+
+// dockerConfigJSON is the content of a ~/.docker/config.json file, and
+// of a Kubernetes image pull secret.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	Auth          string `json:"auth,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+// SecretData returns the docker config JSON used as the content of
+// an image pull secret for the repository. For registries issuing short
+// lived pull tokens, the details must have been refreshed with
+// RefreshAuth.
+func (rid ImageRepoDetails) SecretData() ([]byte, error) {
+	if !rid.IsPrivate() {
+		return nil, errors.NotValidf("secret for public repository %q", rid.Repository)
+	}
+	entry := dockerConfigEntry{
+		Username:      rid.Username,
+		Password:      rid.Password,
+		Auth:          rid.Auth,
+		IdentityToken: rid.IdentityToken,
+		RegistryToken: rid.RegistryToken,
+	}
+	if p := rid.tokenProvider(); p != nil {
+		if rid.RegistryToken == "" {
+			return nil, errors.NotValidf("%s registry %q without a pull token", p.name, rid.RegistryURL())
+		}
+		entry = dockerConfigEntry{
+			Username: p.tokenUser,
+			Password: rid.RegistryToken,
+		}
+	}
+	if entry.Auth == "" && entry.Username != "" {
+		entry.Auth = base64.StdEncoding.EncodeToString([]byte(entry.Username + ":" + entry.Password))
+	}
+	return json.Marshal(dockerConfigJSON{
+		Auths: map[string]dockerConfigEntry{rid.RegistryURL(): entry},
+	})
+}
+
+// ShouldRefreshAuth returns true if the credentials must be exchanged
+// for short lived pull tokens, which must be refreshed before they
+// expire.
+func (rid ImageRepoDetails) ShouldRefreshAuth() bool {
+	return rid.tokenProvider() != nil
+}
+
+// RefreshAuth returns a copy of the details with a new pull token from
+// the cloud's token service, and the time the token expires. Details for
+// registries that don't issue pull tokens are returned unchanged, with a
+// zero expiry time.
+func (rid ImageRepoDetails) RefreshAuth() (ImageRepoDetails, time.Time, error) {
+	p := rid.tokenProvider()
+	if p == nil {
+		return rid, time.Time{}, nil
+	}
+	if err := rid.Validate(); err != nil {
+		return rid, time.Time{}, errors.Trace(err)
+	}
+	token, expiry, err := exchangeToken(p, rid)
+	if err != nil {
+		return rid, time.Time{}, errors.Annotatef(err, "refreshing %s pull token", p.name)
+	}
+	rid.RegistryToken = token
+	return rid, expiry, nil
+}
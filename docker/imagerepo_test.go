@@ -0,0 +1,216 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package docker_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/docker"
+)
+
+type ImageRepoSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ImageRepoSuite{})
+
+func (s *ImageRepoSuite) TestNewImageRepoDetailsPath(c *gc.C) {
+	details, err := docker.NewImageRepoDetails("registry.example.com/juju")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*details, jc.DeepEquals, docker.ImageRepoDetails{Repository: "registry.example.com/juju"})
+	c.Assert(details.IsPrivate(), jc.IsFalse)
+	c.Assert(details.RegistryURL(), gc.Equals, "registry.example.com")
+	c.Assert(details.Content(), gc.Equals, "registry.example.com/juju")
+}
+
+func (s *ImageRepoSuite) TestNewImageRepoDetailsJSON(c *gc.C) {
+	details, err := docker.NewImageRepoDetails(`{
+		"repository": "registry.example.com/juju",
+		"serveraddress": "registry.example.com:5000",
+		"username": "jujuqa",
+		"password": "secret"
+	}`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*details, jc.DeepEquals, docker.ImageRepoDetails{
+		Repository:    "registry.example.com/juju",
+		ServerAddress: "registry.example.com:5000",
+		Username:      "jujuqa",
+		Password:      "secret",
+	})
+	c.Assert(details.IsPrivate(), jc.IsTrue)
+	c.Assert(details.RegistryURL(), gc.Equals, "registry.example.com:5000")
+
+	roundTrip, err := docker.NewImageRepoDetails(details.Content())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roundTrip, jc.DeepEquals, details)
+}
+
+func (s *ImageRepoSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		details docker.ImageRepoDetails
+		err     string
+	}{{
+		details: docker.ImageRepoDetails{},
+		err:     "empty repository not valid",
+	}, {
+		details: docker.ImageRepoDetails{Repository: "Bad Path"},
+		err:     `docker image path "Bad Path" not valid`,
+	}, {
+		details: docker.ImageRepoDetails{Repository: "example.com/juju", Username: "jujuqa"},
+		err:     "username and password must both be set or both be empty, basic auth not valid",
+	}, {
+		details: docker.ImageRepoDetails{Repository: "example.com/juju", Auth: "xxx"},
+		err:     `auth, expected base64 encoded "username:password" not valid`,
+	}, {
+		details: docker.ImageRepoDetails{Repository: "example.com/juju", Username: "a", Password: "b", RegistryToken: "t"},
+		err:     "both registry token and basic auth set not valid",
+	}, {
+		details: docker.ImageRepoDetails{Repository: "juju.azurecr.io/juju", RegistryToken: "t"},
+	}, {
+		details: docker.ImageRepoDetails{Repository: "juju.azurecr.io/juju"},
+	}, {
+		details: docker.ImageRepoDetails{Repository: "public.ecr.aws/juju"},
+	}, {
+		details: docker.ImageRepoDetails{Repository: "123456789012.dkr.ecr.eu-west-1.amazonaws.com/juju"},
+	}, {
+		details: docker.ImageRepoDetails{Repository: "gcr.io/juju"},
+	}, {
+		details: docker.ImageRepoDetails{Repository: "example.com/juju", RegistryToken: "t"},
+	}, {
+		details: docker.ImageRepoDetails{
+			Repository: "example.com/juju",
+			Auth:       base64.StdEncoding.EncodeToString([]byte("jujuqa:secret")),
+		},
+	}} {
+		c.Logf("test %d", i)
+		err := test.details.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *ImageRepoSuite) TestSecretData(c *gc.C) {
+	details := docker.ImageRepoDetails{
+		Repository: "registry.example.com/juju",
+		Username:   "jujuqa",
+		Password:   "secret",
+	}
+	data, err := details.SecretData()
+	c.Assert(err, jc.ErrorIsNil)
+	var config map[string]interface{}
+	err = json.Unmarshal(data, &config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, map[string]interface{}{
+		"auths": map[string]interface{}{
+			"registry.example.com": map[string]interface{}{
+				"username": "jujuqa",
+				"password": "secret",
+				"auth":     base64.StdEncoding.EncodeToString([]byte("jujuqa:secret")),
+			},
+		},
+	})
+}
+
+func (s *ImageRepoSuite) TestSecretDataPublic(c *gc.C) {
+	details := docker.ImageRepoDetails{Repository: "registry.example.com/juju"}
+	_, err := details.SecretData()
+	c.Assert(err, gc.ErrorMatches, `secret for public repository "registry.example.com/juju" not valid`)
+}
+
+func (s *ImageRepoSuite) TestRefreshAuth(c *gc.C) {
+	details := docker.ImageRepoDetails{
+		Repository: "123456789012.dkr.ecr.eu-west-1.amazonaws.com/juju",
+		Username:   "access-key",
+		Password:   "secret-key",
+	}
+	c.Assert(details.ShouldRefreshAuth(), jc.IsTrue)
+	_, err := details.SecretData()
+	c.Assert(err, gc.ErrorMatches, `ecr registry ".*" without a pull token not valid`)
+
+	expiry := time.Date(2021, 5, 3, 12, 0, 0, 0, time.UTC)
+	docker.PatchTokenExchange(s, func(registry string, rid docker.ImageRepoDetails) (string, time.Time, error) {
+		c.Check(registry, gc.Equals, "ecr")
+		c.Check(rid, jc.DeepEquals, details)
+		return "pull-token", expiry, nil
+	})
+	refreshed, refreshedExpiry, err := details.RefreshAuth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(refreshedExpiry, gc.Equals, expiry)
+	c.Assert(refreshed.RegistryToken, gc.Equals, "pull-token")
+	c.Assert(refreshed.Username, gc.Equals, "access-key")
+
+	data, err := refreshed.SecretData()
+	c.Assert(err, jc.ErrorIsNil)
+	var config struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		} `json:"auths"`
+	}
+	err = json.Unmarshal(data, &config)
+	c.Assert(err, jc.ErrorIsNil)
+	entry := config.Auths["123456789012.dkr.ecr.eu-west-1.amazonaws.com"]
+	c.Assert(entry.Username, gc.Equals, "AWS")
+	c.Assert(entry.Password, gc.Equals, "pull-token")
+}
+
+func (s *ImageRepoSuite) TestRefreshAuthError(c *gc.C) {
+	details := docker.ImageRepoDetails{
+		Repository: "juju.azurecr.io/juju",
+		Username:   "app-id",
+		Password:   "secret",
+	}
+	docker.PatchTokenExchange(s, func(registry string, rid docker.ImageRepoDetails) (string, time.Time, error) {
+		return "", time.Time{}, errors.New("boom")
+	})
+	_, _, err := details.RefreshAuth()
+	c.Assert(err, gc.ErrorMatches, "refreshing acr pull token: boom")
+}
+
+func (s *ImageRepoSuite) TestRefreshAuthPublicCloudRegistry(c *gc.C) {
+	details := docker.ImageRepoDetails{Repository: "gcr.io/juju"}
+	docker.PatchTokenExchange(s, func(registry string, rid docker.ImageRepoDetails) (string, time.Time, error) {
+		c.Fatalf("unexpected token exchange")
+		return "", time.Time{}, nil
+	})
+	c.Assert(details.IsPrivate(), jc.IsFalse)
+	c.Assert(details.ShouldRefreshAuth(), jc.IsFalse)
+	refreshed, expiry, err := details.RefreshAuth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expiry.IsZero(), jc.IsTrue)
+	c.Assert(refreshed, jc.DeepEquals, details)
+}
+
+func (s *ImageRepoSuite) TestJWTExpiry(c *gc.C) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"exp": 1620000000}`))
+	expiry, err := docker.JWTExpiry("header." + claims + ".signature")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expiry.Equal(time.Unix(1620000000, 0)), jc.IsTrue)
+
+	_, err = docker.JWTExpiry("not-a-token")
+	c.Assert(err, gc.ErrorMatches, "token not valid")
+}
+
+func (s *ImageRepoSuite) TestRefreshAuthNotNeeded(c *gc.C) {
+	details := docker.ImageRepoDetails{
+		Repository: "registry.example.com/juju",
+		Username:   "jujuqa",
+		Password:   "secret",
+	}
+	c.Assert(details.ShouldRefreshAuth(), jc.IsFalse)
+	refreshed, expiry, err := details.RefreshAuth()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(expiry.IsZero(), jc.IsTrue)
+	c.Assert(refreshed, jc.DeepEquals, details)
+}
@@ -0,0 +1,215 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package docker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/juju/errors"
+	"golang.org/x/oauth2/google"
+)
+
+// tokenExchangeTimeout is how long the exchange of credentials for a
+// pull token may take.
+const tokenExchangeTimeout = 30 * time.Second
+
+// tokenProvider describes a cloud registry which doesn't accept its
+// long lived credentials for pulling images, but exchanges them for a
+// short lived pull token.
+type tokenProvider struct {
+	// name identifies the cloud registry in messages.
+	name string
+
+	// match returns true if the registry address belongs to the cloud.
+	match func(address string) bool
+
+	// tokenUser is the user name the pull token is presented with.
+	tokenUser string
+
+	// exchange calls the cloud's token service to exchange the
+	// credentials in the details for a pull token, returning the token
+	// and the time it expires.
+	exchange func(ctx context.Context, rid ImageRepoDetails) (string, time.Time, error)
+}
+
+var tokenProviders = []tokenProvider{{
+	name: "ecr",
+	match: func(address string) bool {
+		return strings.Contains(address, ".dkr.ecr.") && strings.HasSuffix(address, ".amazonaws.com")
+	},
+	tokenUser: "AWS",
+	exchange:  exchangeECRToken,
+}, {
+	name: "acr",
+	match: func(address string) bool {
+		return strings.HasSuffix(address, ".azurecr.io")
+	},
+	tokenUser: "00000000-0000-0000-0000-000000000000",
+	exchange:  exchangeACRToken,
+}, {
+	name: "gcr",
+	match: func(address string) bool {
+		return address == "gcr.io" || strings.HasSuffix(address, ".gcr.io") || strings.HasSuffix(address, "-docker.pkg.dev")
+	},
+	tokenUser: "oauth2accesstoken",
+	exchange:  exchangeGCRToken,
+}}
+
+// exchangeToken exchanges the credentials in the details for a pull
+// token from the provider. It is a variable so that tests can avoid
+// calling the clouds' token services.
+var exchangeToken = func(p *tokenProvider, rid ImageRepoDetails) (string, time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenExchangeTimeout)
+	defer cancel()
+	return p.exchange(ctx, rid)
+}
+
+// tokenProvider returns the provider of pull tokens for the registry,
+// or nil if the registry accepts its credentials directly, or no
+// credentials are needed to pull from the repository.
+func (rid ImageRepoDetails) tokenProvider() *tokenProvider {
+	if rid.Username == "" {
+		return nil
+	}
+	address := rid.registryHost()
+	for i, p := range tokenProviders {
+		if p.match(address) {
+			return &tokenProviders[i]
+		}
+	}
+	return nil
+}
+
+// registryHost returns the host of the registry to authenticate with.
+func (rid ImageRepoDetails) registryHost() string {
+	address := strings.TrimPrefix(strings.TrimPrefix(rid.RegistryURL(), "https://"), "http://")
+	return strings.SplitN(address, "/", 2)[0]
+}
+
+// exchangeECRToken exchanges an AWS access key, held as the username
+// and password, for an ECR authorization token.
+func exchangeECRToken(ctx context.Context, rid ImageRepoDetails) (string, time.Time, error) {
+	// ECR registries are addressed as
+	// <account>.dkr.ecr.<region>.amazonaws.com.
+	parts := strings.Split(rid.registryHost(), ".")
+	if len(parts) < 6 {
+		return "", time.Time{}, errors.NotValidf("ecr registry %q", rid.RegistryURL())
+	}
+	account, region := parts[0], parts[3]
+	sess, err := session.NewSession(&aws.Config{
+		Region: aws.String(region),
+		Credentials: credentials.NewStaticCredentialsFromCreds(credentials.Value{
+			AccessKeyID:     rid.Username,
+			SecretAccessKey: rid.Password,
+		}),
+	})
+	if err != nil {
+		return "", time.Time{}, errors.Trace(err)
+	}
+	out, err := ecr.New(sess).GetAuthorizationTokenWithContext(ctx, &ecr.GetAuthorizationTokenInput{
+		RegistryIds: []*string{aws.String(account)},
+	})
+	if err != nil {
+		return "", time.Time{}, errors.Annotate(err, "getting ecr authorization token")
+	}
+	if len(out.AuthorizationData) == 0 {
+		return "", time.Time{}, errors.New("getting ecr authorization token: no token returned")
+	}
+	data := out.AuthorizationData[0]
+	// The token is the base64 encoded "AWS:password".
+	decoded, err := base64.StdEncoding.DecodeString(aws.StringValue(data.AuthorizationToken))
+	if err != nil {
+		return "", time.Time{}, errors.Annotate(err, "decoding ecr authorization token")
+	}
+	token := strings.TrimPrefix(string(decoded), "AWS:")
+	return token, aws.TimeValue(data.ExpiresAt), nil
+}
+
+// exchangeACRToken exchanges the credentials of an Azure service
+// principal for an ACR refresh token, using the registry's token
+// endpoint.
+func exchangeACRToken(ctx context.Context, rid ImageRepoDetails) (string, time.Time, error) {
+	host := rid.registryHost()
+	query := url.Values{
+		"service":       {host},
+		"client_id":     {"juju"},
+		"offline_token": {"true"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://"+host+"/oauth2/token?"+query.Encode(), nil)
+	if err != nil {
+		return "", time.Time{}, errors.Trace(err)
+	}
+	req.SetBasicAuth(rid.Username, rid.Password)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, errors.Annotate(err, "getting acr refresh token")
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, errors.Errorf("getting acr refresh token: %s", resp.Status)
+	}
+	var result struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", time.Time{}, errors.Annotate(err, "decoding acr refresh token")
+	}
+	if result.RefreshToken == "" {
+		return "", time.Time{}, errors.New("getting acr refresh token: no token returned")
+	}
+	expiry, err := jwtExpiry(result.RefreshToken)
+	if err != nil {
+		return "", time.Time{}, errors.Annotate(err, "decoding acr refresh token")
+	}
+	return result.RefreshToken, expiry, nil
+}
+
+// exchangeGCRToken exchanges a Google service account key, held as the
+// password of the "_json_key" user, for an OAuth access token.
+func exchangeGCRToken(ctx context.Context, rid ImageRepoDetails) (string, time.Time, error) {
+	if rid.Username != "_json_key" {
+		return "", time.Time{}, errors.NotValidf("gcr username %q, expected \"_json_key\"", rid.Username)
+	}
+	config, err := google.JWTConfigFromJSON([]byte(rid.Password), "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		return "", time.Time{}, errors.Annotate(err, "parsing gcr service account key")
+	}
+	token, err := config.TokenSource(ctx).Token()
+	if err != nil {
+		return "", time.Time{}, errors.Annotate(err, "getting gcr access token")
+	}
+	return token.AccessToken, token.Expiry, nil
+}
+
+// jwtExpiry returns the expiry time in the claims of the JSON web token.
+func jwtExpiry(token string) (time.Time, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, errors.NotValidf("token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	var claims struct {
+		Expiry int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	if claims.Expiry == 0 {
+		return time.Time{}, errors.NotValidf("token without expiry")
+	}
+	return time.Unix(claims.Expiry, 0), nil
+}
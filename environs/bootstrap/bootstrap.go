@@ -199,6 +199,9 @@ func (p BootstrapParams) Validate() error {
 	if p.SupportedBootstrapSeries == nil || p.SupportedBootstrapSeries.Size() == 0 {
 		return errors.NotValidf("supported bootstrap series")
 	}
+	if err := p.ControllerConfig.ValidateCloudType(p.Cloud.Type); err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(axw) validate other things.
 	return nil
 }
@@ -132,6 +132,19 @@ func (s *bootstrapSuite) TestBootstrapNeedsSettings(c *gc.C) {
 		})
 	c.Assert(err, gc.ErrorMatches, "validating bootstrap parameters: empty ca-private-key")
 
+	controllerCfg = coretesting.FakeControllerConfig()
+	controllerCfg["external-mongo-addresses"] = "db-0.example.com:27017"
+	err = bootstrap.Bootstrap(envtesting.BootstrapContext(c), env,
+		s.callContext, bootstrap.BootstrapParams{
+			ControllerConfig:         controllerCfg,
+			AdminSecret:              "admin-secret",
+			CAPrivateKey:             coretesting.CAKey,
+			SupportedBootstrapSeries: supportedJujuSeries,
+			Cloud:                    cloud.Cloud{Type: "ec2"},
+		})
+	c.Assert(err, gc.ErrorMatches, `validating bootstrap parameters: external-mongo-addresses on "ec2" cloud not valid`)
+
+	controllerCfg = coretesting.FakeControllerConfig()
 	err = bootstrap.Bootstrap(envtesting.BootstrapContext(c), env,
 		s.callContext, bootstrap.BootstrapParams{
 			ControllerConfig:         controllerCfg,
@@ -230,6 +230,11 @@ const (
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
 
+	// PreferIPv6 determines whether IPv6 addresses are preferred over IPv4
+	// addresses when selecting ingress and egress addresses for units in
+	// dual-stack networks.
+	PreferIPv6 = "prefer-ipv6"
+
 	// FanConfig defines the configuration for FAN network running in the model.
 	FanConfig = "fan-config"
 
@@ -490,6 +495,7 @@ var defaultConfigValues = map[string]interface{}{
 	TransmitVendorMetricsKey:      true,
 	UpdateStatusHookInterval:      DefaultUpdateStatusHookInterval,
 	EgressSubnets:                 "",
+	PreferIPv6:                    false,
 	FanConfig:                     "",
 	CloudInitUserDataKey:          "",
 	ContainerInheritPropertiesKey: "",
@@ -1500,6 +1506,13 @@ func (c *Config) EgressSubnets() []string {
 	return result
 }
 
+// PreferIPv6 returns whether IPv6 addresses should be preferred over IPv4
+// addresses when selecting unit ingress and egress addresses.
+func (c *Config) PreferIPv6() bool {
+	v, _ := c.defined[PreferIPv6].(bool)
+	return v
+}
+
 // FanConfig is the configuration of FAN network running in the model.
 func (c *Config) FanConfig() (network.FanConfig, error) {
 	// At this point we are sure that the line is valid.
@@ -1655,6 +1668,7 @@ var alwaysOptional = schema.Defaults{
 	MaxActionResultsSize:          schema.Omit,
 	UpdateStatusHookInterval:      schema.Omit,
 	EgressSubnets:                 schema.Omit,
+	PreferIPv6:                    schema.Omit,
 	FanConfig:                     schema.Omit,
 	CloudInitUserDataKey:          schema.Omit,
 	ContainerInheritPropertiesKey: schema.Omit,
@@ -2158,6 +2172,11 @@ where possible. (default "")`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	PreferIPv6: {
+		Description: "Whether IPv6 addresses are preferred over IPv4 addresses for unit ingress and egress in dual-stack networks",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	FanConfig: {
 		Description: "Configuration for fan networking for this model",
 		Type:        environschema.Tstring,
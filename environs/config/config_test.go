@@ -1426,6 +1426,16 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestPreferIPv6(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.PreferIPv6(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"prefer-ipv6": true,
+	})
+	c.Assert(cfg.PreferIPv6(), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		config.CloudInitUserDataKey: validCloudInitUserData,
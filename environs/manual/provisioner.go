@@ -80,3 +80,13 @@ type ProvisioningClientAPI interface {
 	ForceDestroyMachines(machines ...string) error
 	ProvisioningScript(params.ProvisioningScriptParams) (script string, err error)
 }
+
+// HostSubnet describes a subnet to which an address statically
+// configured on a network interface of a manual machine belongs.
+type HostSubnet struct {
+	// Interface is the name of the network interface.
+	Interface string
+
+	// CIDR is the subnet's CIDR.
+	CIDR string
+}
@@ -0,0 +1,163 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sshprovisioner
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/v2/ssh"
+
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/network/debinterfaces"
+	"github.com/juju/juju/network/netplan"
+)
+
+const (
+	netplanMarker    = "#juju-netplan "
+	interfacesMarker = "#juju-interfaces "
+)
+
+// networkConfigScript is the script to run on the remote machine to
+// gather its netplan and ifupdown network configuration.
+// Each file is preceded by a marker line identifying its type and name.
+const networkConfigScript = `#!/bin/bash
+for f in /etc/netplan/*.yaml; do
+  [ -f "$f" ] || continue
+  echo "` + netplanMarker + `$(basename "$f")"
+  cat "$f"
+  echo
+done
+for f in /etc/network/interfaces /etc/network/interfaces.d/*; do
+  [ -f "$f" ] || continue
+  echo "` + interfacesMarker + `$f"
+  cat "$f"
+  echo
+done`
+
+// DetectHostSubnets detects the subnets statically configured on the
+// remote machine by reading its netplan and ifupdown configuration.
+var DetectHostSubnets = detectHostSubnets
+
+func detectHostSubnets(host string) ([]manual.HostSubnet, error) {
+	logger.Infof("Detecting network configuration on %s", host)
+	cmd := ssh.Command("ubuntu@"+host, []string{"/bin/bash"}, nil)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Stdin = strings.NewReader(networkConfigScript)
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() != 0 {
+			err = fmt.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
+		}
+		return nil, err
+	}
+	return parseHostNetworkConfig(stdout.String())
+}
+
+// parseHostNetworkConfig parses the output of networkConfigScript,
+// returning the subnets configured on each interface.
+func parseHostNetworkConfig(output string) ([]manual.HostSubnet, error) {
+	netplanFiles := make(map[string]string)
+	var interfaces strings.Builder
+
+	var current *strings.Builder
+	var currentNetplan string
+	flush := func() {
+		if currentNetplan != "" {
+			netplanFiles[currentNetplan] = current.String()
+			currentNetplan = ""
+		}
+	}
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, netplanMarker):
+			flush()
+			currentNetplan = filepath.Base(strings.TrimPrefix(line, netplanMarker))
+			current = &strings.Builder{}
+			continue
+		case strings.HasPrefix(line, interfacesMarker):
+			flush()
+			current = &interfaces
+			continue
+		}
+		if current != nil {
+			current.WriteString(line)
+			current.WriteString("\n")
+		}
+	}
+	flush()
+
+	cidrs := make(map[string][]string)
+	if len(netplanFiles) > 0 {
+		npCIDRs, err := netplanCIDRs(netplanFiles)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing netplan configuration")
+		}
+		for name, c := range npCIDRs {
+			cidrs[name] = append(cidrs[name], c...)
+		}
+	}
+	if interfaces.Len() > 0 {
+		stanzas, err := debinterfaces.ParseContent(interfaces.String())
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing interfaces configuration")
+		}
+		eniCIDRs, err := debinterfaces.InterfaceCIDRs(stanzas)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing interfaces configuration")
+		}
+		for name, c := range eniCIDRs {
+			cidrs[name] = append(cidrs[name], c...)
+		}
+	}
+
+	names := make([]string, 0, len(cidrs))
+	for name := range cidrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var subnets []manual.HostSubnet
+	seen := make(map[manual.HostSubnet]bool)
+	for _, name := range names {
+		for _, cidr := range cidrs[name] {
+			subnet := manual.HostSubnet{Interface: name, CIDR: cidr}
+			if seen[subnet] {
+				continue
+			}
+			seen[subnet] = true
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets, nil
+}
+
+// netplanCIDRs writes the input netplan files to a temporary directory,
+// so that they can be read and merged as netplan would, and returns the
+// subnets configured on each interface.
+func netplanCIDRs(files map[string]string) (map[string][]string, error) {
+	dir, err := ioutil.TempDir("", "juju-netplan")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	np, err := netplan.ReadDirectory(dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return np.InterfaceCIDRs()
+}
@@ -0,0 +1,74 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sshprovisioner_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/environs/manual/sshprovisioner"
+	"github.com/juju/juju/testing"
+)
+
+type networkConfigSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&networkConfigSuite{})
+
+const networkConfigOutput = `#juju-netplan 50-cloud-init.yaml
+network:
+  version: 2
+  ethernets:
+    eno1:
+      addresses:
+      - 10.0.0.5/24
+    eno2:
+      dhcp4: true
+
+#juju-netplan 60-storage.yaml
+network:
+  version: 2
+  ethernets:
+    eno3:
+      addresses:
+      - 192.168.10.5/23
+
+#juju-interfaces /etc/network/interfaces
+auto lo
+iface lo inet loopback
+
+source /etc/network/interfaces.d/*
+
+#juju-interfaces /etc/network/interfaces.d/50-eth4.cfg
+auto eth4
+iface eth4 inet static
+    address 172.16.0.5
+    netmask 255.255.0.0
+`
+
+func (s *networkConfigSuite) TestDetectHostSubnets(c *gc.C) {
+	defer installFakeSSH(c, sshprovisioner.NetworkConfigScript, networkConfigOutput, 0)()
+	subnets, err := sshprovisioner.DetectHostSubnets("hostname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnets, jc.DeepEquals, []manual.HostSubnet{
+		{Interface: "eno1", CIDR: "10.0.0.0/24"},
+		{Interface: "eno3", CIDR: "192.168.10.0/23"},
+		{Interface: "eth4", CIDR: "172.16.0.0/16"},
+	})
+}
+
+func (s *networkConfigSuite) TestDetectHostSubnetsNoConfig(c *gc.C) {
+	defer installFakeSSH(c, sshprovisioner.NetworkConfigScript, "", 0)()
+	subnets, err := sshprovisioner.DetectHostSubnets("hostname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnets, gc.HasLen, 0)
+}
+
+func (s *networkConfigSuite) TestDetectHostSubnetsError(c *gc.C) {
+	defer installFakeSSH(c, sshprovisioner.NetworkConfigScript, []string{"", "oh noes"}, 33)()
+	_, err := sshprovisioner.DetectHostSubnets("hostname")
+	c.Assert(err, gc.ErrorMatches, `subprocess encountered error code 33 \(oh noes\)`)
+}
@@ -123,10 +123,11 @@ func supportsNetworking(environ BootstrapEnviron) (NetworkingEnviron, bool) {
 	return ne, ok
 }
 
-// SupportsSpaces checks if the environment implements NetworkingEnviron
+// SupportsSpaces checks if the environment implements Networking
 // and also if it supports spaces.
+// Note that CAAS brokers may support spaces without being environs.
 func SupportsSpaces(ctx context.ProviderCallContext, env BootstrapEnviron) bool {
-	netEnv, ok := supportsNetworking(env)
+	netEnv, ok := env.(Networking)
 	if !ok {
 		return false
 	}
@@ -54,7 +54,7 @@ type ReloadSpacesState interface {
 // ReloadSpaces loads spaces and subnets from provider specified by environ into state.
 // Currently it's an append-only operation, no spaces/subnets are deleted.
 func ReloadSpaces(ctx context.ProviderCallContext, state ReloadSpacesState, environ environs.BootstrapEnviron) error {
-	netEnviron, ok := environs.SupportsNetworking(environ)
+	netEnviron, ok := environ.(environs.Networking)
 	if !ok || netEnviron == nil {
 		return errors.NotSupportedf("spaces discovery in a non-networking environ")
 	}
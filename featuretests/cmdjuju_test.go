@@ -169,6 +169,23 @@ application-config:
     source: user
     type: string
     value: ext-host
+  kubernetes-container-resources:
+    description: a space separated list of <container>.<resource>=<value> resource
+      requests and limits for the application's containers, such as "workload.cpu-limit=500
+      workload.mem-request=256M"; cpu is in millicores and sizes take an optional
+      M/G/T/P suffix; resources can't be set in the charm's metadata.yaml
+    source: unset
+    type: string
+  kubernetes-disruption-budget-max-unavailable:
+    description: if set, a pod disruption budget allows at most this number or percentage
+      of pods to be unavailable
+    source: unset
+    type: string
+  kubernetes-disruption-budget-min-available:
+    description: if set, a pod disruption budget keeps at least this number or percentage
+      of pods available
+    source: unset
+    type: string
   kubernetes-ingress-allow-http:
     default: false
     description: whether to allow HTTP traffic to the ingress controller
@@ -193,6 +210,23 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-network-policy:
+    default: false
+    description: whether to restrict ingress traffic to the application's pods to
+      related applications
+    source: default
+    type: bool
+    value: false
+  kubernetes-network-policy-egress-cidrs:
+    description: a space separated list of CIDRs to which egress traffic is also allowed;
+      if set, other egress traffic is denied
+    source: unset
+    type: string
+  kubernetes-network-policy-ingress-cidrs:
+    description: a space separated list of CIDRs from which ingress traffic is also
+      allowed
+    source: unset
+    type: string
   kubernetes-service-annotations:
     description: a space separated set of annotations to add to the service
     source: unset
@@ -225,6 +259,21 @@ application-config:
     description: determines how the Service is exposed
     source: unset
     type: string
+  kubernetes-update-max-surge:
+    description: the number or percentage of pods which may be created above the scale
+      during an update of a stateless application
+    source: unset
+    type: string
+  kubernetes-update-max-unavailable:
+    description: the number or percentage of pods which may be unavailable during
+      an update of a stateless or daemon application
+    source: unset
+    type: string
+  kubernetes-update-partition:
+    description: for stateful applications, only pods with an ordinal at or above
+      the partition are updated
+    source: unset
+    type: int
   trust:
     default: false
     description: Does this application have access to trusted credentials
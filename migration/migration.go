@@ -162,6 +162,26 @@ type UploadBinariesConfig struct {
 	Resources          []migration.SerializedModelResource
 	ResourceDownloader ResourceDownloader
 	ResourceUploader   ResourceUploader
+
+	// Progress is optional. If set, it is notified as each binary is
+	// uploaded.
+	Progress UploadProgress
+}
+
+// UploadProgress is notified by UploadBinaries as each binary is
+// sent to the target controller.
+type UploadProgress interface {
+	// CharmUploaded is called when the charm with the given URL has
+	// been uploaded.
+	CharmUploaded(curl string)
+
+	// ToolsUploaded is called when the agent binaries for the given
+	// version have been uploaded.
+	ToolsUploaded(vers version.Binary)
+
+	// ResourceUploaded is called when the given resource, including
+	// its unit revisions, has been transferred.
+	ResourceUploaded(res migration.SerializedModelResource)
 }
 
 // Validate makes sure that all the config values are non-nil.
@@ -264,6 +284,9 @@ func uploadCharms(config UploadBinariesConfig) error {
 			// The target controller shouldn't assign a different charm URL.
 			return errors.Errorf("charm %s unexpectedly assigned %s", curl, usedCurl)
 		}
+		if config.Progress != nil {
+			config.Progress.CharmUploaded(charmURL)
+		}
 	}
 	return nil
 }
@@ -287,6 +310,9 @@ func uploadTools(config UploadBinariesConfig) error {
 		if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
 			return errors.Annotate(err, "cannot upload agent binaries")
 		}
+		if config.Progress != nil {
+			config.Progress.ToolsUploaded(v)
+		}
 	}
 	return nil
 }
@@ -310,6 +336,9 @@ func uploadResources(config UploadBinariesConfig) error {
 		// Each config.Resources element also contains a
 		// CharmStoreRevision field. This isn't especially important
 		// to migrate so is skipped for now.
+		if config.Progress != nil {
+			config.Progress.ResourceUploaded(res)
+		}
 	}
 	return nil
 }
@@ -198,6 +198,7 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 		{ApplicationRevision: app2Res},
 	}
 
+	progress := &fakeProgress{}
 	config := migration.UploadBinariesConfig{
 		Charms: []string{
 			// These 2 are out of order. Rev 2 must be uploaded first.
@@ -213,6 +214,7 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 		Resources:          resources,
 		ResourceDownloader: downloader,
 		ResourceUploader:   uploader,
+		Progress:           progress,
 	}
 	err := migration.UploadBinaries(config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -241,6 +243,13 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 		"app1/blob1": "blob1",
 	})
 	c.Assert(uploader.unitResources, jc.SameContents, []string{"app1/99-blob1"})
+
+	c.Assert(progress.charms, jc.DeepEquals, expectedCharms)
+	c.Assert(progress.tools, jc.SameContents, []version.Binary{
+		version.MustParseBinary("2.1.0-ubuntu-amd64"),
+		version.MustParseBinary("2.0.0-ubuntu-amd64"),
+	})
+	c.Assert(progress.resources, jc.DeepEquals, []string{"blob0", "blob1", "blob2"})
 }
 
 func (s *ImportSuite) TestWrongCharmURLAssigned(c *gc.C) {
@@ -291,6 +300,24 @@ func (d *fakeDownloader) OpenResource(app, name string) (io.ReadCloser, error) {
 	return ioutil.NopCloser(bytes.NewReader([]byte(name))), nil
 }
 
+type fakeProgress struct {
+	charms    []string
+	tools     []version.Binary
+	resources []string
+}
+
+func (p *fakeProgress) CharmUploaded(curl string) {
+	p.charms = append(p.charms, curl)
+}
+
+func (p *fakeProgress) ToolsUploaded(vers version.Binary) {
+	p.tools = append(p.tools, vers)
+}
+
+func (p *fakeProgress) ResourceUploaded(res coremigration.SerializedModelResource) {
+	p.resources = append(p.resources, res.ApplicationRevision.Name)
+}
+
 type fakeUploader struct {
 	tools            map[version.Binary]string
 	charms           []string
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/version/v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -34,6 +35,7 @@ type PrecheckBackend interface {
 	AllApplications() ([]PrecheckApplication, error)
 	AllRelations() ([]PrecheckRelation, error)
 	ControllerBackend() (PrecheckBackend, error)
+	Cloud(name string) (cloud.Cloud, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
 }
@@ -73,6 +75,7 @@ type PrecheckApplication interface {
 	Name() string
 	Life() state.Life
 	CharmURL() (*charm.URL, bool)
+	CharmAvailable() (bool, error)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
 }
@@ -120,7 +123,32 @@ func SourcePrecheck(
 	modelPresence ModelPresence,
 	controllerPresence ModelPresence,
 ) error {
-	ctx := precheckContext{backend, modelPresence}
+	return errors.Trace(sourcePrecheck(backend, modelPresence, controllerPresence, nil))
+}
+
+// SourcePrecheckIssues runs all of the source controller migration
+// prechecks, returning every blocking issue found rather than
+// stopping at the first. An error is only returned if the prechecks
+// could not be completed.
+func SourcePrecheckIssues(
+	backend PrecheckBackend,
+	modelPresence ModelPresence,
+	controllerPresence ModelPresence,
+) ([]string, error) {
+	issues := &precheckIssues{}
+	if err := sourcePrecheck(backend, modelPresence, controllerPresence, issues); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return issues.all, nil
+}
+
+func sourcePrecheck(
+	backend PrecheckBackend,
+	modelPresence ModelPresence,
+	controllerPresence ModelPresence,
+	issues *precheckIssues,
+) error {
+	ctx := precheckContext{backend, modelPresence, issues}
 	if err := ctx.checkModel(); err != nil {
 		return errors.Trace(err)
 	}
@@ -141,7 +169,9 @@ func SourcePrecheck(
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if err := ctx.blocked(errors.New("cleanup needed")); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	// Check the source controller.
@@ -149,16 +179,56 @@ func SourcePrecheck(
 	if err != nil {
 		return errors.Trace(err)
 	}
-	controllerCtx := precheckContext{controllerBackend, controllerPresence}
+	controllerCtx := precheckContext{controllerBackend, controllerPresence, issues.withPrefix("controller")}
 	if err := controllerCtx.checkController(); err != nil {
 		return errors.Annotate(err, "controller")
 	}
 	return nil
 }
 
+// precheckIssues collects the blocking issues found by the
+// prechecks, so that they can all be reported at once.
+type precheckIssues struct {
+	prefix string
+	all    []string
+	parent *precheckIssues
+}
+
+// withPrefix returns a precheckIssues which records issues in p, with
+// the specified prefix.
+func (p *precheckIssues) withPrefix(prefix string) *precheckIssues {
+	if p == nil {
+		return nil
+	}
+	return &precheckIssues{prefix: prefix, parent: p}
+}
+
+func (p *precheckIssues) add(issue string) {
+	if p.parent != nil {
+		p.parent.add(p.prefix + ": " + issue)
+		return
+	}
+	p.all = append(p.all, issue)
+}
+
 type precheckContext struct {
 	backend  PrecheckBackend
 	presence ModelPresence
+
+	// issues collects the blocking issues found. When nil, the
+	// prechecks fail at the first issue found.
+	issues *precheckIssues
+}
+
+// blocked handles a blocking issue found by the prechecks. If issues
+// are being collected the issue is recorded and nil returned,
+// otherwise the issue is returned.
+func (ctx *precheckContext) blocked(issue error) error {
+	if issue == nil || ctx.issues == nil {
+		return issue
+	}
+	ctx.issues.add(issue.Error())
+	return nil
 }
 
 func (ctx *precheckContext) checkModel() error {
@@ -167,10 +237,14 @@ func (ctx *precheckContext) checkModel() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.blocked(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		if err := ctx.blocked(errors.New("model is being imported as part of another migration")); err != nil {
+			return err
+		}
 	}
 	if credTag, found := model.CloudCredentialTag(); found {
 		creds, err := ctx.backend.CloudCredential(credTag)
@@ -178,7 +252,7 @@ func (ctx *precheckContext) checkModel() error {
 			return errors.Trace(err)
 		}
 		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			return ctx.blocked(errors.New("model has revoked credentials"))
 		}
 	}
 	return nil
@@ -188,9 +262,30 @@ func (ctx *precheckContext) checkModel() error {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) error {
+	return errors.Trace(targetPrecheck(backend, pool, modelInfo, presence, nil))
+}
+
+// TargetPrecheckIssues runs all of the target controller migration
+// prechecks, returning every blocking issue found rather than
+// stopping at the first. An error is only returned if the prechecks
+// could not be completed.
+func TargetPrecheckIssues(
+	backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence,
+) ([]string, error) {
+	issues := &precheckIssues{}
+	if err := targetPrecheck(backend, pool, modelInfo, presence, issues); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return issues.all, nil
+}
+
+func targetPrecheck(
+	backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence, issues *precheckIssues,
+) error {
 	if err := modelInfo.Validate(); err != nil {
 		return errors.Trace(err)
 	}
+	controllerCtx := precheckContext{backend, presence, issues}
 
 	// This check is necessary because there is a window between the
 	// REAP phase and then end of the DONE phase where a model's
@@ -202,7 +297,9 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
 		return errors.Annotate(err, "checking for active migration")
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if err := controllerCtx.blocked(errors.New("model is being migrated out of target controller")); err != nil {
+			return err
+		}
 	}
 
 	controllerVersion, err := backend.AgentVersion()
@@ -211,13 +308,17 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		if err := controllerCtx.blocked(errors.Errorf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion)); err != nil {
+			return err
+		}
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		if err := controllerCtx.blocked(errors.Errorf("source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion)); err != nil {
+			return err
+		}
 	}
 
 	// The UpgradeAllowed check is the same as validating if a model can be migrated
@@ -227,10 +328,15 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		return errors.Maskf(err, "unknown target controller version %v", controllerVersion)
 	}
 	if !allowed {
-		return errors.Errorf("model must be upgraded to at least version %s before being migrated to a controller with version %s", minVer, controllerVersion)
+		if err := controllerCtx.blocked(errors.Errorf("model must be upgraded to at least version %s before being migrated to a controller with version %s", minVer, controllerVersion)); err != nil {
+			return err
+		}
+	}
+
+	if err := controllerCtx.checkCloud(modelInfo); err != nil {
+		return errors.Trace(err)
 	}
 
-	controllerCtx := precheckContext{backend, presence}
 	if err := controllerCtx.checkController(); err != nil {
 		return errors.Trace(err)
 	}
@@ -251,16 +357,70 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			if err := controllerCtx.blocked(errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)); err != nil {
+				return err
+			}
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			if err := controllerCtx.blocked(errors.Errorf("model named %q already exists", model.Name())); err != nil {
+				return err
+			}
 		}
 	}
 
 	return nil
 }
 
+// checkCloud checks that the target controller knows the cloud hosting
+// the model, and that the model's credential doesn't conflict with a
+// credential of the same name on the target controller.
+func (ctx *precheckContext) checkCloud(modelInfo coremigration.ModelInfo) error {
+	if modelInfo.CloudName == "" {
+		// Older source controllers don't send the model's cloud.
+		return nil
+	}
+	modelCloud, err := ctx.backend.Cloud(modelInfo.CloudName)
+	if errors.IsNotFound(err) {
+		return ctx.blocked(errors.Errorf("cloud %q not found", modelInfo.CloudName))
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving cloud %q", modelInfo.CloudName)
+	}
+	if modelInfo.CloudType != "" && modelCloud.Type != modelInfo.CloudType {
+		if err := ctx.blocked(errors.Errorf("cloud %q has type %q, model cloud has type %q",
+			modelInfo.CloudName, modelCloud.Type, modelInfo.CloudType)); err != nil {
+			return err
+		}
+	}
+	if modelInfo.CloudRegion != "" {
+		if _, err := cloud.RegionByName(modelCloud.Regions, modelInfo.CloudRegion); err != nil {
+			if err := ctx.blocked(errors.Errorf("cloud %q has no region %q",
+				modelInfo.CloudName, modelInfo.CloudRegion)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if modelInfo.CloudCredential.IsZero() {
+		return nil
+	}
+	credTag := modelInfo.CloudCredential
+	cred, err := ctx.backend.CloudCredential(credTag)
+	if errors.IsNotFound(err) {
+		// The credential is added when the model is imported.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "retrieving credential %q", credTag.Id())
+	}
+	if cred.Revoked {
+		return ctx.blocked(errors.Errorf("credential %q is revoked", credTag.Id()))
+	}
+	if modelInfo.CloudCredentialAuthType != "" && cred.AuthType != modelInfo.CloudCredentialAuthType {
+		return ctx.blocked(errors.Errorf("credential %q auth type mismatch (%q != %q)",
+			credTag.Id(), cred.AuthType, modelInfo.CloudCredentialAuthType))
+	}
+	return nil
+}
+
 func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bool {
 	// Compare source controller version to target controller version, only
 	// considering major and minor version numbers. Downgrades between
@@ -284,13 +444,17 @@ func (ctx *precheckContext) checkController() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.blocked(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 
 	if upgrading, err := ctx.backend.IsUpgrading(); err != nil {
 		return errors.Annotate(err, "checking for upgrades")
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if err := ctx.blocked(errors.New("upgrade in progress")); err != nil {
+			return err
+		}
 	}
 
 	return errors.Trace(ctx.checkMachines())
@@ -306,36 +470,43 @@ func (ctx *precheckContext) checkMachines() error {
 	if err != nil {
 		return errors.Annotate(err, "retrieving machines")
 	}
-	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
 	for _, machine := range machines {
-		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+		if err := ctx.checkMachine(machine, modelVersion); err != nil {
+			return errors.Trace(err)
 		}
+	}
+	return nil
+}
 
-		if statusInfo, err := machine.InstanceStatus(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
-		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
-		}
+// checkMachine checks that the machine is ready to be migrated. Only
+// a failure to retrieve the machine's details is returned as an error
+// when issues are being collected.
+func (ctx *precheckContext) checkMachine(machine PrecheckMachine, modelVersion version.Number) error {
+	if machine.Life() != state.Alive {
+		return ctx.blocked(errors.Errorf("machine %s is %s", machine.Id(), machine.Life()))
+	}
 
-		if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
-		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
-		}
+	if statusInfo, err := machine.InstanceStatus(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
+	} else if statusInfo.Status != status.Running {
+		return ctx.blocked(newStatusError("machine %s not running", machine.Id(), statusInfo.Status))
+	}
 
-		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
-		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
-		}
+	modelPresenceContext := common.ModelPresenceContext{Presence: ctx.presence}
+	if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
+	} else if statusInfo.Status != status.Started {
+		return ctx.blocked(newStatusError("machine %s agent not functioning at this time",
+			machine.Id(), statusInfo.Status))
+	}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
-			return errors.Trace(err)
-		}
+	if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
+		return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
+	} else if rebootAction != state.ShouldDoNothing {
+		return ctx.blocked(errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction))
 	}
-	return nil
+
+	return errors.Trace(ctx.checkAgentTools(modelVersion, machine, "machine "+machine.Id()))
 }
 
 func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, error) {
@@ -355,7 +526,18 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 	appUnits := make(map[string][]PrecheckUnit, len(apps))
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if err := ctx.blocked(errors.Errorf("application %s is %s", app.Name(), app.Life())); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if available, err := app.CharmAvailable(); err != nil {
+			return nil, errors.Annotatef(err, "retrieving charm for %s", app.Name())
+		} else if !available {
+			curl, _ := app.CharmURL()
+			if err := ctx.blocked(errors.Errorf("charm %s for application %s is not available", curl, app.Name())); err != nil {
+				return nil, err
+			}
 		}
 		units, err := app.AllUnits()
 		if err != nil {
@@ -372,31 +554,43 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 
 func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number, modelType state.ModelType) error {
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		if err := ctx.blocked(errors.Errorf("application %s is below its minimum units threshold", app.Name())); err != nil {
+			return err
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
-		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
-		}
-
-		if err := ctx.checkUnitAgentStatus(unit); err != nil {
+		if err := ctx.checkUnit(unit, appCharmURL, modelVersion, modelType); err != nil {
 			return errors.Trace(err)
 		}
+	}
+	return nil
+}
 
-		if modelType == state.ModelTypeIAAS {
-			if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
-				return errors.Trace(err)
-			}
-		}
+// checkUnit checks that the unit is ready to be migrated. Only a
+// failure to retrieve the unit's details is returned as an error when
+// issues are being collected.
+func (ctx *precheckContext) checkUnit(unit PrecheckUnit, appCharmURL *charm.URL, modelVersion version.Number, modelType state.ModelType) error {
+	if unit.Life() != state.Alive {
+		return ctx.blocked(errors.Errorf("unit %s is %s", unit.Name(), unit.Life()))
+	}
 
-		unitCharmURL, _ := unit.CharmURL()
-		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+	if err := ctx.checkUnitAgentStatus(unit); err != nil {
+		return errors.Trace(err)
+	}
+
+	if modelType == state.ModelTypeIAAS {
+		if err := ctx.checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+			return errors.Trace(err)
 		}
 	}
+
+	unitCharmURL, _ := unit.CharmURL()
+	if appCharmURL.String() != unitCharmURL.String() {
+		return ctx.blocked(errors.Errorf("unit %s is upgrading", unit.Name()))
+	}
 	return nil
 }
 
@@ -411,20 +605,20 @@ func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	case status.Idle, status.Executing:
 		// These two are fine.
 	default:
-		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus)
+		return ctx.blocked(newStatusError("unit %s not idle or executing", unit.Name(), agentStatus))
 	}
 	return nil
 }
 
-func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
+func (ctx *precheckContext) checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
 	tools, err := agent.AgentTools()
 	if err != nil {
 		return errors.Annotatef(err, "retrieving agent binaries for %s", agentLabel)
 	}
 	agentVersion := tools.Version.Number
 	if agentVersion != modelVersion {
-		return errors.Errorf("%s agent binaries don't match model (%s != %s)",
-			agentLabel, agentVersion, modelVersion)
+		return ctx.blocked(errors.Errorf("%s agent binaries don't match model (%s != %s)",
+			agentLabel, agentVersion, modelVersion))
 	}
 	return nil
 }
@@ -475,7 +669,9 @@ func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) e
 					return errors.Trace(err)
 				}
 				if !inScope {
-					return errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)
+					if err := ctx.blocked(errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)); err != nil {
+						return err
+					}
 				}
 			}
 		}
@@ -145,6 +145,15 @@ func (s *precheckAppShim) AllUnits() ([]PrecheckUnit, error) {
 	return out, nil
 }
 
+// CharmAvailable implements PrecheckApplication.
+func (s *precheckAppShim) CharmAvailable() (bool, error) {
+	ch, _, err := s.Application.Charm()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return ch.IsUploaded() && !ch.IsPlaceholder(), nil
+}
+
 // precheckRelationShim implements PrecheckRelation.
 type precheckRelationShim struct {
 	*state.Relation
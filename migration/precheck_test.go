@@ -11,6 +11,7 @@ import (
 	"github.com/juju/version/v2"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -396,6 +397,80 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (*SourcePrecheckSuite) TestIssuesNone(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	issues, err := migration.SourcePrecheckIssues(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestIssuesAll(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name:             "spanner",
+			charmURL:         "cs:spanner-3",
+			charmUnavailable: true,
+			minunits:         3,
+			units: []migration.PrecheckUnit{
+				&fakeUnit{name: "spanner/0", charmURL: "cs:spanner-3"},
+				&fakeUnit{name: "spanner/1", charmURL: "cs:spanner-2"},
+			},
+		},
+	}
+	backend.cleanupNeeded = true
+	backend.controllerBackend = newBackendWithRebootingMachine()
+	issues, err := migration.SourcePrecheckIssues(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []string{
+		"machine 0 is dying",
+		"charm cs:spanner-3 for application spanner is not available",
+		"application spanner is below its minimum units threshold",
+		"unit spanner/1 is upgrading",
+		"cleanup needed",
+		"controller: machine 0 is scheduled to reboot",
+	})
+}
+
+func (*SourcePrecheckSuite) TestIssuesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cleanupErr = errors.New("boom")
+	_, err := migration.SourcePrecheckIssues(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
+func (*SourcePrecheckSuite) TestIssuesMachineError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.machines = []migration.PrecheckMachine{
+		&fakeMachine{id: "0", instanceStatusErr: errors.New("boom")},
+	}
+	_, err := migration.SourcePrecheckIssues(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, "retrieving machine 0 instance status: boom")
+}
+
+func (*SourcePrecheckSuite) TestIssuesUnitError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.model.modelType = state.ModelTypeIAAS
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name:  "foo",
+			units: []migration.PrecheckUnit{&fakeUnit{name: "foo/0", noTools: true}},
+		},
+	}
+	_, err := migration.SourcePrecheckIssues(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, "retrieving agent binaries for unit foo/0: tools not found")
+}
+
+func (*SourcePrecheckSuite) TestCharmUnavailable(c *gc.C) {
+	backend := newHappyBackend()
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{name: "foo", charmURL: "local:foo-1", charmUnavailable: true},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "charm local:foo-1 for application foo is not available")
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -645,6 +720,87 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestCloudNotFound(c *gc.C) {
+	backend := newHappyBackend()
+	backend.cloudErr = errors.NotFoundf("cloud")
+	s.modelInfo.CloudName = "aws"
+	err := s.runPrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `cloud "aws" not found`)
+}
+
+func (s *TargetPrecheckSuite) TestCloudError(c *gc.C) {
+	backend := newHappyBackend()
+	backend.cloudErr = errors.New("boom")
+	s.modelInfo.CloudName = "aws"
+	_, err := migration.TargetPrecheckIssues(backend, nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, `retrieving cloud "aws": boom`)
+}
+
+func (s *TargetPrecheckSuite) TestCloudMismatch(c *gc.C) {
+	backend := newHappyBackend()
+	backend.cloud = cloud.Cloud{
+		Name:    "aws",
+		Type:    "openstack",
+		Regions: []cloud.Region{{Name: "us-east-1"}},
+	}
+	backend.credentials.AuthType = "userpass"
+	s.modelInfo.CloudName = "aws"
+	s.modelInfo.CloudType = "ec2"
+	s.modelInfo.CloudRegion = "eu-west-1"
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("aws/owner/default")
+	s.modelInfo.CloudCredentialAuthType = "access-key"
+	issues, err := migration.TargetPrecheckIssues(backend, nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []string{
+		`cloud "aws" has type "openstack", model cloud has type "ec2"`,
+		`cloud "aws" has no region "eu-west-1"`,
+		`credential "aws/owner/default" auth type mismatch ("userpass" != "access-key")`,
+	})
+}
+
+func (s *TargetPrecheckSuite) TestCloudCompatible(c *gc.C) {
+	backend := newHappyBackend()
+	backend.cloud = cloud.Cloud{
+		Name:    "aws",
+		Type:    "ec2",
+		Regions: []cloud.Region{{Name: "us-east-1"}},
+	}
+	backend.credentialsErr = errors.NotFoundf("credential")
+	s.modelInfo.CloudName = "aws"
+	s.modelInfo.CloudType = "ec2"
+	s.modelInfo.CloudRegion = "us-east-1"
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("aws/owner/default")
+	s.modelInfo.CloudCredentialAuthType = "access-key"
+	err := s.runPrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestIssuesAll(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{
+				uuid:      modelUUID,
+				name:      modelName,
+				modelType: state.ModelTypeIAAS,
+				owner:     modelOwner,
+			},
+		},
+	}
+	backend := newBackendWithRebootingMachine()
+	backend.isUpgrading = true
+	backend.models = pool.uuids()
+	s.modelInfo.AgentVersion = version.MustParse("1.2.4")
+	issues, err := migration.TargetPrecheckIssues(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(issues, jc.DeepEquals, []string{
+		"model has higher version than target controller (1.2.4 > 1.2.3)",
+		"upgrade in progress",
+		"machine 0 is scheduled to reboot",
+		`model with same UUID already exists (model-uuid)`,
+		`model named "model-name" already exists`,
+	})
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -765,6 +921,9 @@ type fakeBackend struct {
 	relations  []migration.PrecheckRelation
 	allRelsErr error
 
+	cloud    cloud.Cloud
+	cloudErr error
+
 	credentials    state.Credential
 	credentialsErr error
 
@@ -798,6 +957,10 @@ func (b *fakeBackend) IsMigrationActive(string) (bool, error) {
 	return b.migrationActive, b.migrationActiveErr
 }
 
+func (b *fakeBackend) Cloud(name string) (cloud.Cloud, error) {
+	return b.cloud, b.cloudErr
+}
+
 func (b *fakeBackend) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
 	return b.credentials, b.credentialsErr
 }
@@ -888,12 +1051,13 @@ func (m *fakeModel) CloudCredentialTag() (names.CloudCredentialTag, bool) {
 }
 
 type fakeMachine struct {
-	id             string
-	version        version.Binary
-	life           state.Life
-	status         status.Status
-	instanceStatus status.Status
-	rebootAction   state.RebootAction
+	id                string
+	version           version.Binary
+	life              state.Life
+	status            status.Status
+	instanceStatus    status.Status
+	instanceStatusErr error
+	rebootAction      state.RebootAction
 }
 
 func (m *fakeMachine) Id() string {
@@ -914,6 +1078,9 @@ func (m *fakeMachine) Status() (status.StatusInfo, error) {
 }
 
 func (m *fakeMachine) InstanceStatus() (status.StatusInfo, error) {
+	if m.instanceStatusErr != nil {
+		return status.StatusInfo{}, m.instanceStatusErr
+	}
 	s := m.instanceStatus
 	if s == "" {
 		// Avoid the need to specify this everywhere.
@@ -942,11 +1109,12 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 }
 
 type fakeApp struct {
-	name     string
-	life     state.Life
-	charmURL string
-	units    []migration.PrecheckUnit
-	minunits int
+	name             string
+	life             state.Life
+	charmURL         string
+	charmUnavailable bool
+	units            []migration.PrecheckUnit
+	minunits         int
 }
 
 func (a *fakeApp) Name() string {
@@ -965,6 +1133,10 @@ func (a *fakeApp) CharmURL() (*charm.URL, bool) {
 	return charm.MustParseURL(url), false
 }
 
+func (a *fakeApp) CharmAvailable() (bool, error) {
+	return !a.charmUnavailable, nil
+}
+
 func (a *fakeApp) AllUnits() ([]migration.PrecheckUnit, error) {
 	return a.units, nil
 }
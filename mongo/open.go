@@ -95,6 +95,12 @@ type Info struct {
 	// DisableTLS controls whether the connection to MongoDB servers
 	// is made using TLS (the default), or not.
 	DisableTLS bool
+
+	// VerifyHostnames controls whether the certificates of the MongoDB
+	// servers are validated against the host names they are dialed on,
+	// as for externally managed servers, rather than the name used by
+	// Juju's own servers.
+	VerifyHostnames bool
 }
 
 // MongoInfo encapsulates information about cluster of
@@ -161,7 +167,12 @@ func DialInfo(info Info, opts DialOpts) (*mgo.DialInfo, error) {
 			return nil, err
 		}
 		if tlsConfig != nil {
-			cc := tls.Client(c, tlsConfig)
+			serverTLSConfig := tlsConfig
+			if info.VerifyHostnames {
+				serverTLSConfig = tlsConfig.Clone()
+				serverTLSConfig.ServerName, _, _ = net.SplitHostPort(server.String())
+			}
+			cc := tls.Client(c, serverTLSConfig)
 			if err := cc.Handshake(); err != nil {
 				logger.Warningf("TLS handshake failed: %v", err)
 				if err := c.Close(); err != nil {
@@ -0,0 +1,114 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debinterfaces
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// ParseContent parses the definitions in the input Debian style network
+// interfaces(5) content and returns the corresponding set of stanza
+// definitions. Any source or source-directory stanzas are skipped, as the
+// content may have been read from another host, where the files to which
+// they refer are not available.
+func ParseContent(content string) ([]Stanza, error) {
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		words := strings.Fields(line)
+		if len(words) > 0 && (words[0] == "source" || words[0] == "source-directory") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return parseSource("", strings.Join(lines, "\n"), newWordExpander())
+}
+
+// InterfaceCIDRs returns the CIDRs of the subnets to which statically
+// configured addresses in the input stanzas belong, indexed by device name.
+func InterfaceCIDRs(stanzas []Stanza) (map[string][]string, error) {
+	result := make(map[string][]string)
+	for _, s := range FlattenStanzas(stanzas) {
+		iface, ok := s.(IfaceStanza)
+		if !ok {
+			continue
+		}
+
+		var address, netmask string
+		for _, option := range iface.Options {
+			words := strings.Fields(option)
+			if len(words) < 2 {
+				continue
+			}
+			switch words[0] {
+			case "address":
+				address = words[1]
+			case "netmask":
+				netmask = words[1]
+			}
+		}
+		if address == "" {
+			continue
+		}
+
+		cidr, err := subnetCIDR(address, netmask)
+		if err != nil {
+			return nil, errors.Annotatef(err, "device %q", iface.DeviceName)
+		}
+
+		// Aliases such as eth0:1 are addresses on the underlying device.
+		name := strings.SplitN(iface.DeviceName, ":", 2)[0]
+		result[name] = append(result[name], cidr)
+	}
+	return result, nil
+}
+
+// subnetCIDR returns the CIDR of the subnet for the input address, which
+// may include a prefix length, and optional netmask. The netmask may be in
+// dotted-quad form, or a prefix length.
+func subnetCIDR(address, netmask string) (string, error) {
+	if strings.Contains(address, "/") {
+		_, ipNet, err := net.ParseCIDR(address)
+		if err != nil {
+			return "", errors.NotValidf("address %q", address)
+		}
+		return ipNet.String(), nil
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return "", errors.NotValidf("address %q", address)
+	}
+	if netmask == "" {
+		return "", errors.NotValidf("address %q without netmask", address)
+	}
+
+	var mask net.IPMask
+	if bits, err := strconv.Atoi(netmask); err == nil {
+		size := 8 * net.IPv6len
+		if ip.To4() != nil {
+			size = 8 * net.IPv4len
+		}
+		if bits < 0 || bits > size {
+			return "", errors.NotValidf("netmask %q", netmask)
+		}
+		mask = net.CIDRMask(bits, size)
+	} else {
+		maskIP := net.ParseIP(netmask).To4()
+		if maskIP == nil || ip.To4() == nil {
+			return "", errors.NotValidf("netmask %q", netmask)
+		}
+		mask = net.IPMask(maskIP)
+		if ones, bits := mask.Size(); ones == 0 && bits == 0 {
+			return "", errors.NotValidf("netmask %q", netmask)
+		}
+		ip = ip.To4()
+	}
+
+	ipNet := net.IPNet{IP: ip.Mask(mask), Mask: mask}
+	return ipNet.String(), nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debinterfaces_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network/debinterfaces"
+)
+
+type SubnetsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SubnetsSuite{})
+
+const subnetsContent = `
+auto lo
+iface lo inet loopback
+
+source /etc/network/interfaces.d/*.cfg
+source-directory interfaces.d
+
+auto eth0
+iface eth0 inet static
+    address 10.0.0.5
+    netmask 255.255.255.0
+    gateway 10.0.0.1
+
+auto eth0:1
+iface eth0:1 inet static
+    address 192.168.10.5/23
+
+iface eth0 inet6 static
+    address 2001:db8::5
+    netmask 64
+
+auto eth1
+iface eth1 inet dhcp
+`
+
+func (s *SubnetsSuite) TestInterfaceCIDRs(c *gc.C) {
+	stanzas, err := debinterfaces.ParseContent(subnetsContent)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cidrs, err := debinterfaces.InterfaceCIDRs(stanzas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cidrs, jc.DeepEquals, map[string][]string{
+		"eth0": {"10.0.0.0/24", "192.168.10.0/23", "2001:db8::/64"},
+	})
+}
+
+func (s *SubnetsSuite) TestInterfaceCIDRsInvalidNetmask(c *gc.C) {
+	stanzas, err := debinterfaces.ParseContent(`
+iface eth0 inet static
+    address 10.0.0.5
+    netmask 255.0.255.0
+`)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = debinterfaces.InterfaceCIDRs(stanzas)
+	c.Assert(err, gc.ErrorMatches, `device "eth0": netmask "255.0.255.0" not valid`)
+}
@@ -6,6 +6,7 @@ package netplan
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path"
 	"path/filepath"
@@ -662,3 +663,53 @@ func (np *Netplan) FindDeviceByNameOrMAC(name, mac string) (string, DeviceType,
 	}
 	return "", "", errors.NotFoundf("device - name %q MAC %q", name, mac)
 }
+
+// InterfaceCIDRs returns the CIDRs of the subnets to which statically
+// configured addresses belong, indexed by device name.
+// Ethernet devices renamed via set-name are indexed by the new name.
+func (np *Netplan) InterfaceCIDRs() (map[string][]string, error) {
+	result := make(map[string][]string)
+	add := func(name string, intf Interface) error {
+		for _, address := range intf.Addresses {
+			_, ipNet, err := net.ParseCIDR(address)
+			if err != nil {
+				return errors.NotValidf("address %q for device %q", address, name)
+			}
+			result[name] = append(result[name], ipNet.String())
+		}
+		return nil
+	}
+
+	for name, ethernet := range np.Network.Ethernets {
+		if ethernet.SetName != "" {
+			name = ethernet.SetName
+		}
+		if err := add(name, ethernet.Interface); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	for name, wifi := range np.Network.Wifis {
+		if wifi.SetName != "" {
+			name = wifi.SetName
+		}
+		if err := add(name, wifi.Interface); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	for name, bridge := range np.Network.Bridges {
+		if err := add(name, bridge.Interface); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	for name, bond := range np.Network.Bonds {
+		if err := add(name, bond.Interface); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	for name, vlan := range np.Network.VLANs {
+		if err := add(name, vlan.Interface); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return result, nil
+}
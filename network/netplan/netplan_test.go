@@ -1617,3 +1617,51 @@ func (s *NetplanSuite) TestNetplanExamples(c *gc.C) {
 		}
 	}
 }
+
+func (s *NetplanSuite) TestInterfaceCIDRs(c *gc.C) {
+	np := MustNetplanFromYaml(c, `
+network:
+  version: 2
+  ethernets:
+    id0:
+      match:
+        macaddress: "00:11:22:33:44:55"
+      set-name: eno1
+      addresses:
+      - 10.0.0.5/24
+      - 2001:db8::5/64
+    eno2:
+      dhcp4: true
+  bridges:
+    br-eno3:
+      interfaces: [eno3]
+      addresses:
+      - 192.168.1.10/23
+  vlans:
+    eno1.100:
+      id: 100
+      link: eno1
+      addresses:
+      - 172.16.0.5/16
+`)
+	cidrs, err := np.InterfaceCIDRs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cidrs, jc.DeepEquals, map[string][]string{
+		"eno1":     {"10.0.0.0/24", "2001:db8::/64"},
+		"br-eno3":  {"192.168.0.0/23"},
+		"eno1.100": {"172.16.0.0/16"},
+	})
+}
+
+func (s *NetplanSuite) TestInterfaceCIDRsInvalidAddress(c *gc.C) {
+	np := MustNetplanFromYaml(c, `
+network:
+  version: 2
+  ethernets:
+    eno1:
+      addresses:
+      - 10.0.0.5
+`)
+	_, err := np.InterfaceCIDRs()
+	c.Assert(err, gc.ErrorMatches, `address "10.0.0.5" for device "eno1" not valid`)
+}
@@ -764,11 +764,20 @@ func (e *environ) checkBroken(method string) error {
 }
 
 // PrecheckInstance is specified in the environs.InstancePrechecker interface.
-func (*environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
-	if args.Placement != "" && args.Placement != "valid" {
-		return fmt.Errorf("%s placement is invalid", args.Placement)
+func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	if args.Placement == "" || args.Placement == "valid" {
+		return nil
 	}
-	return nil
+	if strings.HasPrefix(args.Placement, "zone=") {
+		zones, err := env.AvailabilityZones(ctx)
+		if err != nil {
+			return err
+		}
+		if err := zones.Validate(strings.TrimPrefix(args.Placement, "zone=")); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s placement is invalid", args.Placement)
 }
 
 // Create is part of the Environ interface.
@@ -22,3 +22,48 @@ type ConfigChangedMessage struct {
 	// different machines, and the forwarding of those messages cross each other.
 	// Adding a version could allow subscribers to ignore lower versioned messages.
 }
+
+// HealthRequestTopic is published by the controller facade to ask every
+// controller agent to report on its health. Each controller responds on
+// the topic given in the request.
+// data: `HealthRequest`
+const HealthRequestTopic = "controller.health.request"
+
+// HealthRequest asks the controllers to publish a HealthReport on the
+// response topic.
+type HealthRequest struct {
+	ResponseTopic string `yaml:"response-topic"`
+}
+
+// HealthReport describes the health of the controller agent identified
+// by Origin.
+type HealthReport struct {
+	Origin      string           `yaml:"origin"`
+	Raft        RaftHealth       `yaml:"raft"`
+	LeaseClaims LeaseClaimHealth `yaml:"lease-claims"`
+	// Forwarders maps the other controllers to the status of the
+	// pubsub forwarding connection to them.
+	Forwarders map[string]string `yaml:"forwarders,omitempty"`
+	Errors     []string          `yaml:"errors,omitempty"`
+}
+
+// RaftHealth describes the state of a controller's raft node.
+type RaftHealth struct {
+	State        string `yaml:"state"`
+	Leader       string `yaml:"leader,omitempty"`
+	Term         uint64 `yaml:"term"`
+	AppliedIndex uint64 `yaml:"applied-index"`
+	LastIndex    uint64 `yaml:"last-index"`
+	// LastContact is when the node last heard from the leader, in
+	// RFC3339 format. It is empty for the leader, or if the node has
+	// never heard from a leader.
+	LastContact string `yaml:"last-contact,omitempty"`
+}
+
+// LeaseClaimHealth summarises the successful lease claims made through
+// a controller's lease store. Latencies are in milliseconds.
+type LeaseClaimHealth struct {
+	Count  uint64  `yaml:"count"`
+	Median float64 `yaml:"median"`
+	P99    float64 `yaml:"p99"`
+}
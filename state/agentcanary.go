@@ -0,0 +1,197 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v2"
+	"github.com/juju/mgo/v2/bson"
+	"github.com/juju/mgo/v2/txn"
+	jujutxn "github.com/juju/txn/v2"
+	"github.com/juju/version/v2"
+
+	jujuversion "github.com/juju/juju/version"
+)
+
+// agentUpgradeCanaryKey is the id of the single agent upgrade canary
+// document of a model.
+const agentUpgradeCanaryKey = "canary"
+
+// AgentUpgradeCanary describes a staged upgrade of a model's agents,
+// in which a set of the model's machines run a newer agent version
+// than the rest of the model. The agents of the units on those machines
+// follow the version of their machine.
+type AgentUpgradeCanary struct {
+	doc agentUpgradeCanaryDoc
+}
+
+// agentUpgradeCanaryDoc is the persistent representation of an
+// AgentUpgradeCanary.
+type agentUpgradeCanaryDoc struct {
+	DocID         string   `bson:"_id"`
+	ModelUUID     string   `bson:"model-uuid"`
+	TargetVersion string   `bson:"target-version"`
+	Machines      []string `bson:"machines"`
+}
+
+// TargetVersion returns the agent version the canary machines run.
+func (c *AgentUpgradeCanary) TargetVersion() version.Number {
+	return version.MustParse(c.doc.TargetVersion)
+}
+
+// Machines returns the ids of the machines running the target version.
+func (c *AgentUpgradeCanary) Machines() []string {
+	machines := make([]string, len(c.doc.Machines))
+	copy(machines, c.doc.Machines)
+	return machines
+}
+
+// IncludesMachine returns whether the machine with the given id runs
+// the target version.
+func (c *AgentUpgradeCanary) IncludesMachine(id string) bool {
+	for _, m := range c.doc.Machines {
+		if m == id {
+			return true
+		}
+	}
+	return false
+}
+
+// AgentUpgradeCanary returns the model's agent upgrade canary, or an
+// error satisfying errors.IsNotFound if no canary upgrade is in
+// progress.
+func (st *State) AgentUpgradeCanary() (*AgentUpgradeCanary, error) {
+	coll, closer := st.db().GetCollection(agentUpgradeCanariesC)
+	defer closer()
+
+	var doc agentUpgradeCanaryDoc
+	err := coll.FindId(agentUpgradeCanaryKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("agent upgrade canary")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot get agent upgrade canary")
+	}
+	return &AgentUpgradeCanary{doc: doc}, nil
+}
+
+// SetAgentUpgradeCanary upgrades the agents of the given machines, and
+// of the units on them, to the target version, leaving the rest of the
+// model at its current agent version. Any machines previously upgraded
+// but not in the given set are returned to the model's agent version.
+//
+// The target version must be newer than the model's agent version, and
+// must be the same as the target version of any canary upgrade already
+// in progress. Canary upgrades are not supported for the controller
+// model.
+func (st *State) SetAgentUpgradeCanary(targetVersion version.Number, machineIds []string) error {
+	if st.IsController() {
+		return errors.NotSupportedf("canary upgrades of the controller model")
+	}
+	if targetVersion.Compare(jujuversion.Current) > 0 {
+		return errors.Errorf("model cannot be upgraded to %s while the controller is %s: upgrade 'controller' model first",
+			targetVersion, jujuversion.Current)
+	}
+	if len(machineIds) == 0 {
+		return errors.New("no canary machines specified")
+	}
+	machineIds = append([]string(nil), machineIds...)
+	sort.Strings(machineIds)
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		settings, err := readSettings(st.db(), settingsC, modelGlobalKey)
+		if err != nil {
+			return nil, errors.Annotatef(err, "model %q", st.modelTag.Id())
+		}
+		agentVersion, _ := settings.Get("agent-version")
+		currentVersion, _ := agentVersion.(string)
+		current, err := version.Parse(currentVersion)
+		if err != nil {
+			return nil, errors.Annotate(err, "invalid model agent version")
+		}
+		if targetVersion.Compare(current) <= 0 {
+			return nil, errors.Errorf("canary version %s must be newer than the model agent version %s", targetVersion, current)
+		}
+
+		ops := []txn.Op{{
+			// The model's agent version must not change while
+			// the canary machines are chosen.
+			C:      settingsC,
+			Id:     st.docID(modelGlobalKey),
+			Assert: bson.D{{"version", settings.version}},
+		}}
+		for _, id := range machineIds {
+			m, err := st.Machine(id)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if m.IsManager() {
+				return nil, errors.Errorf("machine %s is a controller machine", id)
+			}
+			ops = append(ops, txn.Op{
+				C:      machinesC,
+				Id:     m.doc.DocID,
+				Assert: isAliveDoc,
+			})
+		}
+
+		existing, err := st.AgentUpgradeCanary()
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      agentUpgradeCanariesC,
+				Id:     agentUpgradeCanaryKey,
+				Assert: txn.DocMissing,
+				Insert: &agentUpgradeCanaryDoc{
+					TargetVersion: targetVersion.String(),
+					Machines:      machineIds,
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing.doc.TargetVersion != targetVersion.String() {
+			return nil, errors.Errorf("canary upgrade to %s already in progress", existing.doc.TargetVersion)
+		}
+		return append(ops, txn.Op{
+			C:      agentUpgradeCanariesC,
+			Id:     agentUpgradeCanaryKey,
+			Assert: bson.D{{"target-version", existing.doc.TargetVersion}},
+			Update: bson.D{{"$set", bson.D{{"machines", machineIds}}}},
+		}), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set agent upgrade canary")
+	}
+	return nil
+}
+
+// RemoveAgentUpgradeCanary ends any canary upgrade in progress. The
+// canary machines return to running the model's agent version, which
+// rolls them back unless the model has itself been upgraded to the
+// target version.
+func (st *State) RemoveAgentUpgradeCanary() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.AgentUpgradeCanary(); errors.IsNotFound(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      agentUpgradeCanariesC,
+			Id:     agentUpgradeCanaryKey,
+			Remove: true,
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot remove agent upgrade canary")
+	}
+	return nil
+}
+
+// WatchAgentUpgradeCanary returns a NotifyWatcher which notifies when
+// the model's agent upgrade canary changes.
+func (st *State) WatchAgentUpgradeCanary() NotifyWatcher {
+	return newEntityWatcher(st, agentUpgradeCanariesC, st.docID(agentUpgradeCanaryKey))
+}
@@ -0,0 +1,121 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version/v2"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type AgentUpgradeCanarySuite struct {
+	ConnSuite
+	st      *state.State
+	factory *factory.Factory
+}
+
+var _ = gc.Suite(&AgentUpgradeCanarySuite{})
+
+func (s *AgentUpgradeCanarySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.st = s.Factory.MakeModel(c, nil)
+	s.AddCleanup(func(*gc.C) { _ = s.st.Close() })
+	s.factory = factory.NewFactory(s.st, s.StatePool)
+
+	// Canary versions must be newer than the model's agent version
+	// and no newer than the controller's.
+	older := jujuversion.Current
+	older.Minor--
+	err := s.st.SetModelAgentVersion(older, true)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AgentUpgradeCanarySuite) TestNoCanary(c *gc.C) {
+	_, err := s.st.AgentUpgradeCanary()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *AgentUpgradeCanarySuite) TestSetAgentUpgradeCanary(c *gc.C) {
+	m0 := s.factory.MakeMachine(c, nil)
+	m1 := s.factory.MakeMachine(c, nil)
+	m2 := s.factory.MakeMachine(c, nil)
+
+	err := s.st.SetAgentUpgradeCanary(jujuversion.Current, []string{m1.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	canary, err := s.st.AgentUpgradeCanary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(canary.TargetVersion(), gc.Equals, jujuversion.Current)
+	c.Check(canary.Machines(), jc.DeepEquals, []string{m1.Id()})
+	c.Check(canary.IncludesMachine(m0.Id()), jc.IsFalse)
+
+	// The canary is extended by setting more machines.
+	err = s.st.SetAgentUpgradeCanary(jujuversion.Current, []string{m2.Id(), m1.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	canary, err = s.st.AgentUpgradeCanary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(canary.Machines(), jc.DeepEquals, []string{m1.Id(), m2.Id()})
+
+	err = s.st.RemoveAgentUpgradeCanary()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.st.AgentUpgradeCanary()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing a missing canary is not an error.
+	err = s.st.RemoveAgentUpgradeCanary()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AgentUpgradeCanarySuite) TestSetAgentUpgradeCanaryDifferentVersion(c *gc.C) {
+	m0 := s.factory.MakeMachine(c, nil)
+	err := s.st.SetModelAgentVersion(version.MustParse("2.7.0"), true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.st.SetAgentUpgradeCanary(version.MustParse("2.8.0"), []string{m0.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.st.SetAgentUpgradeCanary(jujuversion.Current, []string{m0.Id()})
+	c.Assert(err, gc.ErrorMatches, "cannot set agent upgrade canary: canary upgrade to 2.8.0 already in progress")
+}
+
+func (s *AgentUpgradeCanarySuite) TestSetAgentUpgradeCanaryNotNewer(c *gc.C) {
+	m0 := s.factory.MakeMachine(c, nil)
+	model, err := s.st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	current, err := model.AgentVersion()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.st.SetAgentUpgradeCanary(current, []string{m0.Id()})
+	c.Assert(err, gc.ErrorMatches, "cannot set agent upgrade canary: canary version .* must be newer than the model agent version .*")
+}
+
+func (s *AgentUpgradeCanarySuite) TestSetAgentUpgradeCanaryMissingMachine(c *gc.C) {
+	err := s.st.SetAgentUpgradeCanary(jujuversion.Current, []string{"42"})
+	c.Assert(err, gc.ErrorMatches, "cannot set agent upgrade canary: machine 42 not found")
+}
+
+func (s *AgentUpgradeCanarySuite) TestSetAgentUpgradeCanaryControllerModel(c *gc.C) {
+	err := s.State.SetAgentUpgradeCanary(jujuversion.Current, []string{"0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *AgentUpgradeCanarySuite) TestWatchAgentUpgradeCanary(c *gc.C) {
+	m0 := s.factory.MakeMachine(c, nil)
+	w := s.st.WatchAgentUpgradeCanary()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.st, w)
+	wc.AssertOneChange()
+
+	err := s.st.SetAgentUpgradeCanary(jujuversion.Current, []string{m0.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.st.RemoveAgentUpgradeCanary()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
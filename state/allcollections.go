@@ -334,6 +334,10 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		// This collection holds the machines running a newer agent
+		// version during a canary upgrade of the model's agents.
+		agentUpgradeCanariesC: {},
+
 		// -----
 
 		// These collections hold information associated with storage.
@@ -546,6 +550,7 @@ const (
 	actionNotificationsC       = "actionnotifications"
 	actionresultsC             = "actionresults"
 	actionsC                   = "actions"
+	agentUpgradeCanariesC      = "agentUpgradeCanaries"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
 	assignUnitC                = "assignUnits"
@@ -114,6 +114,8 @@ type applicationDoc struct {
 	// and any k8s cluster resources have been fully cleaned up.
 	// Until then, the application must not be removed from the Juju model.
 	HasResources bool `bson:"has-resources,omitempty"`
+	// Autoscale holds the application's autoscaling policy, if any.
+	Autoscale *autoscalePolicyDoc `bson:"autoscale,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -2512,6 +2514,11 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 	}
 	if m.Type() == ModelTypeCAAS {
 		ops = append(ops, u.removeCloudContainerOps()...)
+		healthOps, err := u.removeContainerHealthOps()
+		if op.FatalError(err) {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, healthOps...)
 	}
 	branchOps, err := unassignUnitFromBranchOp(u.doc.Name, a.doc.Name, m)
 	if err != nil {
@@ -3279,6 +3286,7 @@ func (a *Application) PasswordValid(password string) bool {
 type UnitUpdateProperties struct {
 	ProviderId           *string
 	Address              *string
+	NetworkAddresses     *[]string
 	Ports                *[]string
 	UnitName             *string
 	AgentStatus          *status.StatusInfo
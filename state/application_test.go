@@ -4301,9 +4301,10 @@ func (s *CAASApplicationSuite) assertUpdateCAASUnits(c *gc.C, aliveApp bool) {
 			},
 		}),
 		existingUnit.UpdateOperation(state.UnitUpdateProperties{
-			ProviderId: strPtr("unit-uuid"),
-			Address:    strPtr("192.168.1.2"),
-			Ports:      &[]string{"443"},
+			ProviderId:       strPtr("unit-uuid"),
+			Address:          strPtr("192.168.1.2"),
+			NetworkAddresses: &[]string{"10.10.0.2"},
+			Ports:            &[]string{"443"},
 			AgentStatus: &status.StatusInfo{
 				Status:  status.Running,
 				Message: "existing running",
@@ -4344,6 +4345,11 @@ func (s *CAASApplicationSuite) assertUpdateCAASUnits(c *gc.C, aliveApp bool) {
 	c.Check(*info.Address(), gc.DeepEquals,
 		network.NewSpaceAddress("192.168.1.2", network.WithScope(network.ScopeMachineLocal)))
 	c.Check(info.Ports(), jc.DeepEquals, []string{"443"})
+	networkAddrs, err := u.NetworkAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(networkAddrs, jc.DeepEquals, network.SpaceAddresses{
+		network.NewSpaceAddress("10.10.0.2", network.WithScope(network.ScopeMachineLocal)),
+	})
 	statusInfo, err := u.AgentStatus()
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(statusInfo.Status, gc.Equals, status.Running)
@@ -0,0 +1,98 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v2/bson"
+	"github.com/juju/mgo/v2/txn"
+	jujutxn "github.com/juju/txn/v2"
+
+	"github.com/juju/juju/core/application"
+)
+
+// autoscalePolicyDoc is the persistent representation of an
+// application.AutoscalePolicy.
+type autoscalePolicyDoc struct {
+	MinUnits     int     `bson:"min-units"`
+	MaxUnits     int     `bson:"max-units"`
+	TargetCPU    int     `bson:"target-cpu,omitempty"`
+	TargetMemory int     `bson:"target-memory,omitempty"`
+	Metric       string  `bson:"metric,omitempty"`
+	TargetMetric float64 `bson:"target-metric,omitempty"`
+}
+
+// AutoscalePolicy returns the application's autoscaling policy, or nil
+// if the application isn't autoscaled.
+// This is used on CAAS models.
+func (a *Application) AutoscalePolicy() *application.AutoscalePolicy {
+	doc := a.doc.Autoscale
+	if doc == nil {
+		return nil
+	}
+	return &application.AutoscalePolicy{
+		MinUnits:     doc.MinUnits,
+		MaxUnits:     doc.MaxUnits,
+		TargetCPU:    doc.TargetCPU,
+		TargetMemory: doc.TargetMemory,
+		Metric:       doc.Metric,
+		TargetMetric: doc.TargetMetric,
+	}
+}
+
+// SetAutoscalePolicy sets the policy used to scale the application
+// automatically. A nil policy stops the application being autoscaled.
+// This is used on CAAS models.
+func (a *Application) SetAutoscalePolicy(policy *application.AutoscalePolicy) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set autoscale policy for application %q", a)
+	m, err := a.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if m.Type() != ModelTypeCAAS {
+		return errors.NotSupportedf("autoscaling on non-caas model")
+	}
+	var doc *autoscalePolicyDoc
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		doc = &autoscalePolicyDoc{
+			MinUnits:     policy.MinUnits,
+			MaxUnits:     policy.MaxUnits,
+			TargetCPU:    policy.TargetCPU,
+			TargetMemory: policy.TargetMemory,
+			Metric:       policy.Metric,
+			TargetMetric: policy.TargetMetric,
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		if doc == nil && a.doc.Autoscale == nil {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"$set", bson.D{{"autoscale", doc}}}}
+		if doc == nil {
+			update = bson.D{{"$unset", bson.D{{"autoscale", nil}}}}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.Autoscale = doc
+	return nil
+}
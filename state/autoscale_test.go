@@ -0,0 +1,54 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+func (s *CAASApplicationSuite) TestAutoscalePolicy(c *gc.C) {
+	c.Assert(s.app.AutoscalePolicy(), gc.IsNil)
+
+	policy := &application.AutoscalePolicy{
+		MinUnits:     2,
+		MaxUnits:     10,
+		TargetCPU:    70,
+		Metric:       "queue-length",
+		TargetMetric: 100,
+	}
+	err := s.app.SetAutoscalePolicy(policy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.AutoscalePolicy(), jc.DeepEquals, policy)
+
+	app, err := s.caasSt.Application(s.app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.AutoscalePolicy(), jc.DeepEquals, policy)
+
+	err = app.SetAutoscalePolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.AutoscalePolicy(), gc.IsNil)
+
+	// Clearing an unset policy is a no-op.
+	err = s.app.SetAutoscalePolicy(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CAASApplicationSuite) TestSetAutoscalePolicyInvalid(c *gc.C) {
+	err := s.app.SetAutoscalePolicy(&application.AutoscalePolicy{MinUnits: 1, MaxUnits: 5})
+	c.Assert(err, gc.ErrorMatches, `cannot set autoscale policy for application "gitlab": autoscale policy without a target not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePolicyIAAS(c *gc.C) {
+	err := s.mysql.SetAutoscalePolicy(&application.AutoscalePolicy{MaxUnits: 5, TargetCPU: 50})
+	c.Assert(err, gc.ErrorMatches, `cannot set autoscale policy for application "mysql": autoscaling on non-caas model not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(s.mysql.AutoscalePolicy(), gc.IsNil)
+}
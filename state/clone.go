@@ -0,0 +1,414 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/charm/v9"
+	csparams "github.com/juju/charmrepo/v7/csclient/params"
+	"github.com/juju/description/v3"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/state/storage"
+)
+
+// CloneTopologyArgs holds the arguments for cloning the topology of a
+// model into another model on the same controller.
+type CloneTopologyArgs struct {
+	// Source is the state of the model being cloned. It is used to copy
+	// the charms used by the cloned applications.
+	Source *State
+
+	// Model is the description of the source model. Only its spaces,
+	// applications, relations and offers are used.
+	Model description.Model
+
+	// MaxUnits, if greater than zero, caps the number of units added
+	// to each cloned application.
+	MaxUnits int
+}
+
+// CloneTopology adds the applications, relations and offers described
+// by args.Model to this model. No machines, units data, relation data
+// or storage are copied; units are added unassigned so they are given
+// fresh machines by the unit assigner.
+func (st *State) CloneTopology(args CloneTopologyArgs) error {
+	if args.Source == nil {
+		return errors.NotValidf("missing source state")
+	}
+	if args.Model == nil {
+		return errors.NotValidf("missing source model description")
+	}
+	dbModel, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c := &topologyCloner{
+		importer: &importer{
+			st:      st,
+			dbModel: dbModel,
+			model:   args.Model,
+			logger:  logger.Child("clone"),
+		},
+		source:   args.Source,
+		maxUnits: args.MaxUnits,
+	}
+	if err := c.spaces(); err != nil {
+		return errors.Annotate(err, "spaces")
+	}
+	if err := c.charms(); err != nil {
+		return errors.Annotate(err, "charms")
+	}
+	for _, app := range args.Model.Applications() {
+		if err := c.application(app); err != nil {
+			return errors.Annotatef(err, "application %s", app.Name())
+		}
+	}
+	if err := c.relations(); err != nil {
+		return errors.Annotate(err, "relations")
+	}
+	for _, app := range args.Model.Applications() {
+		if err := c.offers(app); err != nil {
+			return errors.Annotatef(err, "offers for application %s", app.Name())
+		}
+	}
+	return nil
+}
+
+// topologyCloner reuses the conversions of the model importer to add
+// entities through the regular state methods rather than inserting the
+// exported documents verbatim.
+type topologyCloner struct {
+	*importer
+	source   *State
+	maxUnits int
+
+	// spaceNames maps the IDs of spaces in the source model to
+	// their names.
+	spaceNames map[string]string
+}
+
+func (c *topologyCloner) spaces() error {
+	c.spaceNames = map[string]string{
+		network.AlphaSpaceId: network.AlphaSpaceName,
+	}
+	for _, s := range c.model.Spaces() {
+		c.spaceNames[s.Id()] = s.Name()
+		if s.Name() == network.AlphaSpaceName {
+			continue
+		}
+		_, err := c.st.SpaceByName(s.Name())
+		if err == nil {
+			continue
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		// The subnets are not cloned, as they belong to the source
+		// model; they are discovered anew for the target model.
+		if _, err := c.st.AddSpace(s.Name(), network.Id(s.ProviderID()), nil, s.Public()); err != nil {
+			return errors.Annotate(err, s.Name())
+		}
+	}
+	return nil
+}
+
+// spaceName returns the name of the space with the given ID in the
+// source model. Unknown spaces are mapped to the alpha space.
+func (c *topologyCloner) spaceName(id string) string {
+	if name, ok := c.spaceNames[id]; ok {
+		return name
+	}
+	return network.AlphaSpaceName
+}
+
+// clonedCharm adapts a charm from another model so it can be added
+// to this model with AddCharm.
+type clonedCharm struct {
+	*Charm
+}
+
+// LXDProfile implements charm.LXDProfiler.
+func (c clonedCharm) LXDProfile() *charm.LXDProfile {
+	profile := c.Charm.LXDProfile()
+	if profile == nil {
+		return nil
+	}
+	return &charm.LXDProfile{
+		Config:      profile.Config,
+		Description: profile.Description,
+		Devices:     profile.Devices,
+	}
+}
+
+func (c *topologyCloner) charms() error {
+	urls := make(map[string]bool)
+	for _, app := range c.model.Applications() {
+		urls[app.CharmURL()] = true
+	}
+	sorted := make([]string, 0, len(urls))
+	for url := range urls {
+		sorted = append(sorted, url)
+	}
+	sort.Strings(sorted)
+
+	sequences, err := c.st.Sequences()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	srcStorage := storage.NewStorage(c.source.ModelUUID(), c.source.MongoSession())
+	dstStorage := storage.NewStorage(c.st.ModelUUID(), c.st.MongoSession())
+	for _, url := range sorted {
+		curl, err := charm.ParseURL(url)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := c.st.Charm(curl); err == nil {
+			continue
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		// Local charms are given the next available revision, which
+		// would leave the application pointing at the wrong charm, so
+		// refuse before anything is added.
+		if curl.Schema == "local" {
+			next := sequences[charmRevSeqName(curl.WithRevision(-1).String())]
+			if next > curl.Revision {
+				return errors.Errorf("charm %s would be added as %s", url, curl.WithRevision(next))
+			}
+		}
+
+		ch, err := c.source.Charm(curl)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := copyCharmArchive(srcStorage, dstStorage, ch.StoragePath()); err != nil {
+			return errors.Annotatef(err, "copying charm %s", url)
+		}
+		added, err := c.st.AddCharm(CharmInfo{
+			Charm:       clonedCharm{ch},
+			ID:          curl,
+			StoragePath: ch.StoragePath(),
+			SHA256:      ch.BundleSha256(),
+			Version:     ch.Version(),
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// The revision may still have been taken concurrently.
+		if added.URL().String() != url {
+			return errors.Errorf("charm %s added as %s", url, added.URL())
+		}
+	}
+	return nil
+}
+
+func copyCharmArchive(src, dst storage.Storage, path string) error {
+	r, size, err := src.Get(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+	return errors.Trace(dst.Put(path, r, size))
+}
+
+func (c *topologyCloner) application(a description.Application) error {
+	c.logger.Debugf("cloning application %s", a.Name())
+
+	curl, err := charm.ParseURL(a.CharmURL())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, err := c.st.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	origin, err := c.makeCharmOrigin(a, curl, a.Units())
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	numUnits := len(a.Units())
+	if c.dbModel.Type() == ModelTypeCAAS {
+		numUnits = a.DesiredScale()
+	}
+	if a.Subordinate() {
+		numUnits = 0
+	}
+	if c.maxUnits > 0 && numUnits > c.maxUnits {
+		numUnits = c.maxUnits
+	}
+
+	var bindings map[string]string
+	if epBindings := a.EndpointBindings(); len(epBindings) > 0 {
+		bindings = make(map[string]string, len(epBindings))
+		for epName, spaceID := range epBindings {
+			bindings[epName] = c.spaceName(spaceID)
+		}
+	}
+
+	charmConfig := a.CharmConfig()
+	removeNils(charmConfig)
+	addArgs := AddApplicationArgs{
+		Name:             a.Name(),
+		Series:           a.Series(),
+		Charm:            ch,
+		CharmOrigin:      origin,
+		Channel:          csparams.Channel(a.Channel()),
+		Storage:          c.storageConstraints(a.StorageConstraints()),
+		EndpointBindings: bindings,
+		CharmConfig:      charm.Settings(charmConfig),
+		Constraints:      c.constraints(a.Constraints()),
+	}
+	if c.dbModel.Type() != ModelTypeCAAS {
+		addArgs.NumUnits = numUnits
+	}
+	app, err := c.st.AddApplication(addArgs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.dbModel.Type() == ModelTypeCAAS && numUnits > 0 {
+		if err := app.SetScale(numUnits, 0, true); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if appConfig := a.ApplicationConfig(); len(appConfig) > 0 {
+		removeNils(appConfig)
+		settings, err := readSettings(c.st.db(), settingsC, app.applicationConfigKey())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for key, value := range appConfig {
+			settings.Set(key, value)
+		}
+		if _, err := settings.Write(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if a.Exposed() {
+		exposed := map[string]ExposedEndpoint{"": {}}
+		if expEps := a.ExposedEndpoints(); len(expEps) > 0 {
+			exposed = make(map[string]ExposedEndpoint, len(expEps))
+			for epName, details := range expEps {
+				var spaceIDs []string
+				for _, id := range details.ExposeToSpaceIDs() {
+					space, err := c.st.SpaceByName(c.spaceName(id))
+					if err != nil {
+						return errors.Trace(err)
+					}
+					spaceIDs = append(spaceIDs, space.Id())
+				}
+				exposed[epName] = ExposedEndpoint{
+					ExposeToSpaceIDs: spaceIDs,
+					ExposeToCIDRs:    details.ExposeToCIDRs(),
+				}
+			}
+		}
+		if err := app.MergeExposeSettings(exposed); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if minUnits := a.MinUnits(); minUnits > 0 {
+		if minUnits > numUnits {
+			minUnits = numUnits
+		}
+		if err := app.SetMinUnits(minUnits); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if annotations := a.Annotations(); len(annotations) > 0 {
+		if err := c.dbModel.SetAnnotations(app, annotations); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (c *topologyCloner) relations() error {
+	cloned := make(map[string]bool)
+	for _, app := range c.model.Applications() {
+		cloned[app.Name()] = true
+	}
+	for _, rel := range c.model.Relations() {
+		descEndpoints := rel.Endpoints()
+		// Peer relations are created along with their application.
+		if len(descEndpoints) < 2 {
+			continue
+		}
+		var endpoints []Endpoint
+		for _, ep := range descEndpoints {
+			if !cloned[ep.ApplicationName()] {
+				// Relations with remote applications are not cloned,
+				// as the remote applications themselves are not.
+				c.logger.Debugf("skipping relation %q with remote application %s", rel.Key(), ep.ApplicationName())
+				endpoints = nil
+				break
+			}
+			app, err := c.st.Application(ep.ApplicationName())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			endpoint, err := app.Endpoint(ep.Name())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+		if len(endpoints) == 0 {
+			continue
+		}
+		if _, err := c.st.AddRelation(endpoints...); err != nil {
+			return errors.Annotatef(err, "relation %q", rel.Key())
+		}
+	}
+	return nil
+}
+
+func (c *topologyCloner) offers(a description.Application) error {
+	owner := c.dbModel.Owner()
+	offers := NewApplicationOffers(c.st)
+	for _, o := range a.Offers() {
+		var (
+			readers []string
+			others  = make(map[string]permission.Access)
+		)
+		for user, access := range o.ACL() {
+			if user == owner.Id() {
+				continue
+			}
+			if permission.Access(access) == permission.ReadAccess {
+				readers = append(readers, user)
+			} else {
+				others[user] = permission.Access(access)
+			}
+		}
+		sort.Strings(readers)
+		offer, err := offers.AddOffer(crossmodel.AddApplicationOfferArgs{
+			OfferName:              o.OfferName(),
+			Owner:                  owner.Id(),
+			HasRead:                readers,
+			ApplicationName:        a.Name(),
+			ApplicationDescription: o.ApplicationDescription(),
+			Endpoints:              o.Endpoints(),
+		})
+		if err != nil {
+			return errors.Annotatef(err, "offer %q", o.OfferName())
+		}
+		offerTag := names.NewApplicationOfferTag(offer.OfferName)
+		for user, access := range others {
+			if err := c.st.CreateOfferAccess(offerTag, names.NewUserTag(user), access); err != nil {
+				return errors.Annotatef(err, "granting %s access to offer %q", user, o.OfferName())
+			}
+		}
+	}
+	return nil
+}
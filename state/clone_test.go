@@ -0,0 +1,135 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+
+	"github.com/juju/charm/v9"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
+)
+
+type CloneTopologySuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CloneTopologySuite{})
+
+func (s *CloneTopologySuite) addCharm(c *gc.C, name string) *state.Charm {
+	ch := s.AddTestingCharm(c, name)
+	// The testing charms are not backed by an archive, which is
+	// needed to copy them to the new model.
+	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	err := stor.Put(ch.StoragePath(), strings.NewReader(name), int64(len(name)))
+	c.Assert(err, jc.ErrorIsNil)
+	return ch
+}
+
+func (s *CloneTopologySuite) TestCloneTopology(c *gc.C) {
+	wordpress := s.AddTestingApplication(c, "wordpress", s.addCharm(c, "wordpress"))
+	mysql := s.AddTestingApplication(c, "mysql", s.addCharm(c, "mysql"))
+	for i := 0; i < 3; i++ {
+		_, err := wordpress.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	_, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"blog-title": "staging"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = wordpress.MergeExposeSettings(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		Owner:           s.Model.Owner().Id(),
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	exported, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newSt := s.Factory.MakeModel(c, nil)
+	defer newSt.Close()
+	err = newSt.CloneTopology(state.CloneTopologyArgs{
+		Source:   s.State,
+		Model:    exported,
+		MaxUnits: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	clonedWordpress, err := newSt.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := clonedWordpress.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(units, gc.HasLen, 2)
+	for _, unit := range units {
+		_, err := unit.AssignedMachineId()
+		c.Check(err, jc.Satisfies, errors.IsNotAssigned)
+	}
+	c.Check(clonedWordpress.IsExposed(), jc.IsTrue)
+	settings, err := clonedWordpress.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings["blog-title"], gc.Equals, "staging")
+
+	clonedMysql, err := newSt.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	units, err = clonedMysql.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(units, gc.HasLen, 1)
+
+	eps, err = newSt.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = newSt.EndpointsRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	offer, err := state.NewApplicationOffers(newSt).ApplicationOffer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(offer.ApplicationName, gc.Equals, "mysql")
+	c.Check(offer.Endpoints, gc.HasLen, 1)
+
+	// Nothing is added to the source model.
+	units, err = wordpress.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(units, gc.HasLen, 3)
+}
+
+func (s *CloneTopologySuite) TestCloneTopologyLocalCharmRevisionTaken(c *gc.C) {
+	ch := s.addCharm(c, "mysql")
+	s.AddTestingApplication(c, "mysql", ch)
+	exported, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newSt := s.Factory.MakeModel(c, nil)
+	defer newSt.Close()
+	taken, err := newSt.PrepareLocalCharmUpload(ch.URL().WithRevision(ch.Revision() + 1))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = newSt.CloneTopology(state.CloneTopologyArgs{
+		Source: s.State,
+		Model:  exported,
+	})
+	c.Assert(err, gc.ErrorMatches, `charm `+ch.URL().String()+` would be added as .*`)
+
+	// Nothing is added to the new model.
+	_, err = newSt.Charm(ch.URL())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = newSt.Charm(taken.WithRevision(taken.Revision + 1))
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = newSt.Application("mysql")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -43,6 +43,10 @@ type cloudContainerDoc struct {
 	ProviderId string   `bson:"provider-id"`
 	Address    *address `bson:"address"`
 	Ports      []string `bson:"ports"`
+
+	// NetworkAddresses holds the addresses of the container
+	// on any secondary networks it is attached to.
+	NetworkAddresses []address `bson:"network-addresses,omitempty"`
 }
 
 // Id implements CloudContainer.
@@ -124,7 +128,8 @@ func (u *Unit) saveContainerOps(doc cloudContainerDoc) ([]txn.Op, error) {
 			{"$set",
 				bson.D{{"provider-id", doc.ProviderId},
 					{"ports", doc.Ports},
-					{"address", doc.Address}},
+					{"address", doc.Address},
+					{"network-addresses", doc.NetworkAddresses}},
 			},
 		},
 	}}, nil
@@ -0,0 +1,80 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v2/bson"
+	"github.com/juju/mgo/v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// globalContainerHealthKey returns the global database key for the
+// health of a unit's workload container.
+func globalContainerHealthKey(unitName, containerName string) string {
+	return unitGlobalKey(unitName) + "#sat#container#" + containerName
+}
+
+// ContainerHealth returns the health of the named workload container of
+// the unit, as last reported by the unit agent. An error satisfying
+// errors.IsNotFound is returned if no health has been reported.
+func (u *Unit) ContainerHealth(containerName string) (status.StatusInfo, error) {
+	return getStatus(u.st.db(), globalContainerHealthKey(u.doc.Name, containerName), "container health")
+}
+
+// SetContainerHealth records the health of the named workload container
+// of the unit. The status must be active, for a healthy container, or
+// error.
+func (u *Unit) SetContainerHealth(containerName string, health status.StatusInfo) error {
+	if containerName == "" {
+		return errors.NotValidf("empty container name")
+	}
+	if health.Status != status.Active && health.Status != status.Error {
+		return errors.NotValidf("container health %q", health.Status)
+	}
+	updated := health.Since
+	if updated == nil {
+		now := u.st.clock().Now()
+		updated = &now
+	}
+	return setStatus(u.st.db(), setStatusParams{
+		badge:     "container health",
+		globalKey: globalContainerHealthKey(u.doc.Name, containerName),
+		status:    health.Status,
+		message:   health.Message,
+		rawData:   health.Data,
+		updated:   updated,
+	})
+}
+
+// removeContainerHealthOps returns the operations to remove the health
+// of all the unit's workload containers, including those of charms the
+// unit has since been upgraded from.
+func (u *Unit) removeContainerHealthOps() ([]txn.Op, error) {
+	statuses, closer := u.st.db().GetCollection(statusesC)
+	defer closer()
+
+	prefix := u.st.docID(globalContainerHealthKey(u.doc.Name, ""))
+	sel := bson.D{{"_id", bson.D{{"$regex", "^" + regexp.QuoteMeta(prefix)}}}}
+	var docs []struct {
+		ID string `bson:"_id"`
+	}
+	if err := statuses.Find(sel).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading container health of unit %q", u.doc.Name)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = removeStatusOp(u.st, u.st.localID(doc.ID))
+	}
+	return ops, nil
+}
+
+// UnitContainerHealth returns the health of the named workload container
+// of the unit.
+func (m *ModelStatus) UnitContainerHealth(unitName, containerName string) (status.StatusInfo, error) {
+	return m.getStatus(globalContainerHealthKey(unitName, containerName), "container health")
+}
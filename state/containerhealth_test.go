@@ -0,0 +1,93 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ContainerHealthSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&ContainerHealthSuite{})
+
+func (s *ContainerHealthSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *ContainerHealthSuite) TestNotReported(c *gc.C) {
+	_, err := s.unit.ContainerHealth("workload")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ContainerHealthSuite) TestSetContainerHealth(c *gc.C) {
+	now := coretesting.NonZeroTime()
+	err := s.unit.SetContainerHealth("workload", status.StatusInfo{
+		Status:  status.Error,
+		Message: "failing checks: ready",
+		Data:    map[string]interface{}{"failing-checks": []string{"ready"}},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	health, err := s.unit.ContainerHealth("workload")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health.Status, gc.Equals, status.Error)
+	c.Assert(health.Message, gc.Equals, "failing checks: ready")
+	c.Assert(health.Data["failing-checks"], jc.DeepEquals, []interface{}{"ready"})
+
+	modelStatus, err := s.Model.LoadModelStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	health, err = modelStatus.UnitContainerHealth(s.unit.Name(), "workload")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(health.Status, gc.Equals, status.Error)
+
+	_, err = s.unit.ContainerHealth("other")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ContainerHealthSuite) TestSetContainerHealthInvalid(c *gc.C) {
+	err := s.unit.SetContainerHealth("workload", status.StatusInfo{Status: status.Waiting})
+	c.Assert(err, gc.ErrorMatches, `container health "waiting" not valid`)
+	err = s.unit.SetContainerHealth("", status.StatusInfo{Status: status.Active})
+	c.Assert(err, gc.ErrorMatches, "empty container name not valid")
+}
+
+func (s *ContainerHealthSuite) TestRemoveUnitRemovesAllContainerHealth(c *gc.C) {
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer func() { _ = st.Close() }()
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{Name: "gitlab", Charm: ch})
+	unit := f.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	// The health of containers the current charm no longer defines,
+	// left by an earlier revision, is removed too.
+	for _, name := range []string{"gitlab", "removed-in-upgrade"} {
+		err := unit.SetContainerHealth(name, status.StatusInfo{Status: status.Active})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	c.Assert(unit.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(unit.Remove(), jc.ErrorIsNil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	modelStatus, err := m.LoadModelStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, name := range []string{"gitlab", "removed-in-upgrade"} {
+		_, err := modelStatus.UnitContainerHealth(unit.Name(), name)
+		c.Check(err, jc.Satisfies, errors.IsNotFound)
+	}
+}
@@ -61,6 +61,7 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.MaxAgentStateSize,
 		controller.NonSyncedWritesToRaftLog,
 		controller.MigrationMinionWaitMax,
+		controller.MigrationRetryWait,
 	)
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/mgo/v2"
@@ -155,6 +156,103 @@ func (st *State) EnableHA(
 	return change, nil
 }
 
+// RemoveControllerNode starts the removal of the controller machine with
+// the given id. The machine is made Dying and gives up its vote, after
+// which the peergrouper removes it from the replica set and the set of
+// controllers, and stops publishing its API addresses. Any controller
+// charm unit on the machine is destroyed with it.
+//
+// Only one controller may be removed at a time, and the last controller
+// can't be removed. If force is true, the machine is removed even if its
+// agent is not running, as by Machine.ForceDestroy.
+func (st *State) RemoveControllerNode(id string, force bool, maxWait time.Duration) error {
+	m, err := st.Machine(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !m.IsManager() {
+		return errors.Errorf("machine %s is not a controller", id)
+	}
+	if m.Life() != Alive && !force {
+		return errors.Errorf("controller %s is already being removed", id)
+	}
+	controllerIds, err := st.ControllerIds()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	voters := 0
+	for _, cid := range controllerIds {
+		if cid == id {
+			continue
+		}
+		node, err := st.ControllerNode(cid)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !node.WantsVote() && node.HasVote() {
+			return errors.Errorf("controller %s is still being removed", cid)
+		}
+		if node.WantsVote() {
+			voters++
+		}
+	}
+	if voters == 0 {
+		return errors.Errorf("controller %s cannot be removed as it is the last controller", id)
+	}
+
+	units, err := m.Units()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, u := range units {
+		if u.ApplicationName() != bootstrap.ControllerApplicationName {
+			continue
+		}
+		if err := u.Destroy(); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "destroying controller unit %s", u.Name())
+		}
+	}
+	if force {
+		return errors.Trace(m.ForceDestroy(maxWait))
+	}
+	return errors.Trace(m.Destroy())
+}
+
+// ReplaceControllerNode removes the controller machine with the given id,
+// as by RemoveControllerNode, and adds a new controller machine to keep
+// the number of controllers. The new machine is started as by EnableHA.
+func (st *State) ReplaceControllerNode(
+	id string, force bool, maxWait time.Duration, cons constraints.Value, series string, placement []string,
+) (ControllersChanges, error) {
+	votingCount, err := st.getVotingControllerCount()
+	if err != nil {
+		return ControllersChanges{}, errors.Trace(err)
+	}
+	node, err := st.ControllerNode(id)
+	if err != nil {
+		return ControllersChanges{}, errors.Trace(err)
+	}
+	if !node.WantsVote() {
+		// The machine is already being removed, so it isn't
+		// counted as a voter.
+		votingCount++
+	}
+	if err := st.RemoveControllerNode(id, force, maxWait); err != nil {
+		return ControllersChanges{}, errors.Trace(err)
+	}
+	// An even number of voting controllers is rounded up by EnableHA.
+	numControllers := votingCount
+	if numControllers%2 == 0 {
+		numControllers = 0
+	}
+	changes, err := st.EnableHA(numControllers, cons, series, placement)
+	if err != nil {
+		return ControllersChanges{}, errors.Annotatef(err, "replacing controller %s", id)
+	}
+	changes.Removed = append(changes.Removed, id)
+	return changes, nil
+}
+
 // Change in controllers after the ensure availability txn has committed.
 type ControllersChanges struct {
 	Added      []string
@@ -420,7 +518,7 @@ func (st *State) SafeControllerIds() ([]string, error) {
 	session := st.session.Copy()
 	defer session.Close()
 
-	db := session.DB(jujuDB)
+	db := session.DB(st.dbName)
 	controllers := db.C(controllersC)
 
 	var info bson.M
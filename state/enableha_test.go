@@ -671,3 +671,55 @@ func (s *EnableHASuite) TestRemoveControllerMachineRace(c *gc.C) {
 	c.Assert(m0.Refresh(), jc.ErrorIsNil)
 	c.Check(m0.Jobs(), gc.DeepEquals, []state.MachineJob{state.JobHostUnits, state.JobManageModel})
 }
+
+func (s *EnableHASuite) addVotingControllers(c *gc.C) {
+	_, err := s.State.EnableHA(3, constraints.Value{}, "bionic", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, id := range []string{"0", "1", "2"} {
+		node, err := s.State.ControllerNode(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(node.SetHasVote(true), jc.ErrorIsNil)
+	}
+}
+
+func (s *EnableHASuite) TestRemoveControllerNode(c *gc.C) {
+	s.addVotingControllers(c)
+	err := s.State.RemoveControllerNode("1", false, dontWait)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m1, err := s.State.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m1.Life(), gc.Equals, state.Dying)
+	s.assertControllerInfo(c, []string{"0", "1", "2"}, []string{"0", "2"}, nil)
+
+	// Only one controller can be removed at a time.
+	err = s.State.RemoveControllerNode("2", false, dontWait)
+	c.Assert(err, gc.ErrorMatches, "controller 1 is still being removed")
+	err = s.State.RemoveControllerNode("1", false, dontWait)
+	c.Assert(err, gc.ErrorMatches, "controller 1 is already being removed")
+}
+
+func (s *EnableHASuite) TestRemoveControllerNodeNotController(c *gc.C) {
+	s.addVotingControllers(c)
+	m, err := s.State.AddMachine("bionic", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveControllerNode(m.Id(), false, dontWait)
+	c.Assert(err, gc.ErrorMatches, "machine 3 is not a controller")
+}
+
+func (s *EnableHASuite) TestRemoveControllerNodeLast(c *gc.C) {
+	m0, err := s.State.AddMachine("bionic", state.JobHostUnits, state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveControllerNode(m0.Id(), false, dontWait)
+	c.Assert(err, gc.ErrorMatches, "controller 0 cannot be removed as it is the last controller")
+}
+
+func (s *EnableHASuite) TestReplaceControllerNode(c *gc.C) {
+	s.addVotingControllers(c)
+	changes, err := s.State.ReplaceControllerNode("1", false, dontWait, constraints.Value{}, "bionic", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(changes.Added, jc.DeepEquals, []string{"3"})
+	c.Check(changes.Removed, jc.DeepEquals, []string{"1"})
+	c.Check(changes.Maintained, jc.SameContents, []string{"0", "2"})
+	s.assertControllerInfo(c, []string{"0", "1", "2", "3"}, []string{"0", "2", "3"}, nil)
+}
@@ -1049,3 +1049,23 @@ func (st *State) SetClockForTesting(clock clock.Clock) error {
 	}
 	return nil
 }
+
+// ExportConsistentWith runs ExportConsistent, calling the supplied
+// function before each attempt at exporting the model.
+func ExportConsistentWith(st *State, attempts int, beforeExport func()) (description.Model, error) {
+	return st.exportConsistent(attempts, func(st *State, cfg ExportConfig) (description.Model, error) {
+		beforeExport()
+		return st.exportImpl(cfg)
+	})
+}
+
+// LastTxnLogId returns the id of the newest transaction log entry.
+func LastTxnLogId(st *State) (interface{}, error) {
+	return st.lastTxnLogId()
+}
+
+// ModelChangedSince reports whether the model has changed since the
+// transaction log entry with the specified id.
+func ModelChangedSince(st *State, lastId interface{}) (bool, error) {
+	return st.modelChangedSince(lastId)
+}
@@ -179,7 +179,7 @@ func LegacyLeases(pool *StatePool, localTime time.Time) (map[corelease.Key]corel
 // collection already not existing.
 func DropLeasesCollection(pool *StatePool) error {
 	st := pool.SystemState()
-	names, err := st.MongoSession().DB("juju").CollectionNames()
+	names, err := st.MongoSession().DB(st.dbName).CollectionNames()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -1590,45 +1590,65 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
-	publicAddress := m.doc.PreferredPublicAddress
-	logger.Tracef(
-		"machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v",
-		m.Id(), publicAddress, providerAddresses, machineAddresses)
-
+// publicAddressSelector returns the functions used to pick a machine's
+// preferred public address.
+func publicAddressSelector(preferIPv6 bool) (func([]address) network.SpaceAddress, func(address) bool) {
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
 		return network.ExactScopeMatch(addr.networkAddress(), network.ScopePublic)
 	}
 	// Without an exact match, prefer a fallback match.
+	matchFunc := network.ScopeMatchPublic
+	if preferIPv6 {
+		matchFunc = network.ScopeMatchPreferringIPv6(matchFunc)
+	}
 	getAddr := func(addresses []address) network.SpaceAddress {
-		addr, _ := networkAddresses(addresses).OneMatchingScope(network.ScopeMatchPublic)
+		addr, _ := networkAddresses(addresses).OneMatchingScope(matchFunc)
 		return addr
 	}
-
-	newAddr, changed := maybeGetNewAddress(publicAddress, providerAddresses, machineAddresses, getAddr, checkScope)
-	if !changed {
-		// No change, so no ops.
-		return []txn.Op{}, nil
-	}
-
-	ops := m.setPreferredAddressOps(newAddr, true)
-	return ops, &newAddr
+	return getAddr, checkScope
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
-	privateAddress := m.doc.PreferredPrivateAddress
+// privateAddressSelector returns the functions used to pick a machine's
+// preferred private address.
+func privateAddressSelector(preferIPv6 bool) (func([]address) network.SpaceAddress, func(address) bool) {
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
 		return network.ExactScopeMatch(
 			addr.networkAddress(), network.ScopeMachineLocal, network.ScopeCloudLocal, network.ScopeFanLocal)
 	}
 	// Without an exact match, prefer a fallback match.
+	matchFunc := network.ScopeMatchCloudLocal
+	if preferIPv6 {
+		matchFunc = network.ScopeMatchPreferringIPv6(matchFunc)
+	}
 	getAddr := func(addresses []address) network.SpaceAddress {
-		addr, _ := networkAddresses(addresses).OneMatchingScope(network.ScopeMatchCloudLocal)
+		addr, _ := networkAddresses(addresses).OneMatchingScope(matchFunc)
 		return addr
 	}
+	return getAddr, checkScope
+}
 
+func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address, preferIPv6 bool) ([]txn.Op, *address) {
+	publicAddress := m.doc.PreferredPublicAddress
+	logger.Tracef(
+		"machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v",
+		m.Id(), publicAddress, providerAddresses, machineAddresses)
+
+	getAddr, checkScope := publicAddressSelector(preferIPv6)
+	newAddr, changed := maybeGetNewAddress(publicAddress, providerAddresses, machineAddresses, getAddr, checkScope)
+	if !changed {
+		// No change, so no ops.
+		return []txn.Op{}, nil
+	}
+
+	ops := m.setPreferredAddressOps(newAddr, true)
+	return ops, &newAddr
+}
+
+func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address, preferIPv6 bool) ([]txn.Op, *address) {
+	privateAddress := m.doc.PreferredPrivateAddress
+	getAddr, checkScope := privateAddressSelector(preferIPv6)
 	newAddr, changed := maybeGetNewAddress(privateAddress, providerAddresses, machineAddresses, getAddr, checkScope)
 	if !changed {
 		// No change, so no ops.
@@ -1638,6 +1658,28 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 	return ops, &newAddr
 }
 
+// reselectPreferredAddressesOps returns the operations needed to pick
+// the machine's preferred addresses afresh from its current addresses.
+// Unlike setPublicAddressOps and setPrivateAddressOps, the currently
+// preferred addresses are not kept just because they are still usable;
+// this is used when the address family preference changes.
+func (m *Machine) reselectPreferredAddressesOps(preferIPv6 bool) (_ []txn.Op, newPrivate, newPublic *address) {
+	var ops []txn.Op
+	reselect := func(current address, isPublic bool, getAddr func([]address) network.SpaceAddress, checkScope func(address) bool) *address {
+		newAddr, changed := maybeGetNewAddress(address{}, m.doc.Addresses, m.doc.MachineAddresses, getAddr, checkScope)
+		if !changed || newAddr == current {
+			return nil
+		}
+		ops = append(ops, m.setPreferredAddressOps(newAddr, isPublic)...)
+		return &newAddr
+	}
+	getAddr, checkScope := privateAddressSelector(preferIPv6)
+	newPrivate = reselect(m.doc.PreferredPrivateAddress, false, getAddr, checkScope)
+	getAddr, checkScope = publicAddressSelector(preferIPv6)
+	newPublic = reselect(m.doc.PreferredPublicAddress, true, getAddr, checkScope)
+	return ops, newPrivate, newPublic
+}
+
 // SetProviderAddresses records any addresses related to the machine, sourced
 // by asking the provider.
 func (m *Machine) SetProviderAddresses(addresses ...network.SpaceAddress) error {
@@ -1726,14 +1768,21 @@ func (m *Machine) setAddresses(machineAddresses, providerAddresses *[]network.Sp
 				return nil, err
 			}
 		}
+		// The address family preference is read on every attempt, and
+		// asserted unchanged, so a concurrent change to prefer-ipv6 can
+		// not leave preferred addresses selected with the old setting.
+		preferIPv6, assertSettingsOp, err := machine.st.preferIPv6()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		var ops []txn.Op
 		ops, machineStateAddresses, providerStateAddresses, newPrivate, newPublic, err = machine.setAddressesOps(
-			machineAddresses, providerAddresses,
+			machineAddresses, providerAddresses, preferIPv6,
 		)
 		if err != nil {
 			return nil, err
 		}
-		return ops, nil
+		return append(ops, assertSettingsOp), nil
 	}
 	if err := m.st.db().Run(buildTxn); err != nil {
 		return errors.Trace(err)
@@ -1741,6 +1790,12 @@ func (m *Machine) setAddresses(machineAddresses, providerAddresses *[]network.Sp
 
 	m.doc.MachineAddresses = machineStateAddresses
 	m.doc.Addresses = providerStateAddresses
+	return errors.Trace(m.updatePreferredAddresses(newPrivate, newPublic))
+}
+
+// updatePreferredAddresses records newly selected preferred addresses
+// in the local machine document, after they have been written to state.
+func (m *Machine) updatePreferredAddresses(newPrivate, newPublic *address) error {
 	if newPrivate != nil {
 		oldPrivate := m.doc.PreferredPrivateAddress.networkAddress()
 		m.doc.PreferredPrivateAddress = *newPrivate
@@ -1778,7 +1833,7 @@ func (st *State) maybeUpdateControllerCharm(publicAddr string) error {
 }
 
 func (m *Machine) setAddressesOps(
-	machineAddresses, providerAddresses *[]network.SpaceAddress,
+	machineAddresses, providerAddresses *[]network.SpaceAddress, preferIPv6 bool,
 ) (_ []txn.Op, machineStateAddresses, providerStateAddresses []address, newPrivate, newPublic *address, _ error) {
 
 	if m.doc.Life == Dead {
@@ -1811,8 +1866,8 @@ func (m *Machine) setAddressesOps(
 		Update: bson.D{{"$set", set}},
 	}}
 
-	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses)
-	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses)
+	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses, preferIPv6)
+	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses, preferIPv6)
 	ops = append(ops, setPrivateAddressOps...)
 	ops = append(ops, setPublicAddressOps...)
 	return ops, machineStateAddresses, providerStateAddresses, newPrivate, newPublic, nil
@@ -2077,7 +2132,7 @@ func (m *Machine) VolumeAttachments() ([]VolumeAttachment, error) {
 
 // AddAction is part of the ActionReceiver interface.
 func (m *Machine) AddAction(operationID, name string, payload map[string]interface{}, parallel *bool, executionGroup *string) (Action, error) {
-	spec, ok := actions.PredefinedActionsSpec[name]
+	spec, ok := actions.MachineActionSpec(name)
 	if !ok {
 		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
 	}
@@ -2291,11 +2346,16 @@ func (op *UpdateMachineOperation) Build(attempt int) ([]txn.Op, error) {
 	}
 
 	if op.MachineAddresses != nil || op.ProviderAddresses != nil {
-		ops, _, _, _, _, err := op.m.setAddressesOps(op.MachineAddresses, op.ProviderAddresses)
+		preferIPv6, assertSettingsOp, err := op.m.st.preferIPv6()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops, _, _, _, _, err := op.m.setAddressesOps(op.MachineAddresses, op.ProviderAddresses, preferIPv6)
 		if err != nil {
 			return nil, errors.Annotate(err, "cannot set addresses")
 		}
 		allOps = append(allOps, ops...)
+		allOps = append(allOps, assertSettingsOp)
 	}
 
 	if op.PasswordHash != nil {
@@ -2041,6 +2041,60 @@ func (s *MachineSuite) TestPrivateAddress(c *gc.C) {
 	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
 }
 
+func (s *MachineSuite) TestPreferIPv6ChangeReselectsPreferredAddresses(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(
+		network.NewSpaceAddress("10.0.0.1", network.WithScope(network.ScopeCloudLocal)),
+		network.NewSpaceAddress("fd00::1", network.WithScope(network.ScopeCloudLocal)),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err := machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"prefer-ipv6": true}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "fd00::1")
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"prefer-ipv6": false}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
+}
+
+func (s *MachineSuite) TestSetProviderAddressesConcurrentPreferIPv6Change(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.Model.UpdateModelConfig(map[string]interface{}{"prefer-ipv6": true}, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = machine.SetProviderAddresses(
+		network.NewSpaceAddress("10.0.0.1", network.WithScope(network.ScopeCloudLocal)),
+		network.NewSpaceAddress("fd00::1", network.WithScope(network.ScopeCloudLocal)),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	addr, err := machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "fd00::1")
+}
+
 func (s *MachineSuite) TestPublicAddressBetterMatch(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/featureflag"
 	"github.com/juju/loggo"
+	"github.com/juju/mgo/v2"
 	"github.com/juju/mgo/v2/bson"
 	"github.com/juju/names/v4"
 	"github.com/juju/os/v2/series"
@@ -88,6 +89,111 @@ func (st *State) Export() (description.Model, error) {
 	return st.exportImpl(ExportConfig{})
 }
 
+// ExportConsistent exports the current model as Export does, but checks
+// that the model wasn't changed while it was being read, so that the
+// export reflects a single point in time. The export is retried if the
+// model changed, failing after the specified number of attempts.
+func (st *State) ExportConsistent(attempts int) (description.Model, error) {
+	return st.exportConsistent(attempts, (*State).exportImpl)
+}
+
+// exportConsistent is ExportConsistent with the export function
+// supplied, so that tests can change the model mid-export.
+func (st *State) exportConsistent(
+	attempts int, export func(*State, ExportConfig) (description.Model, error),
+) (description.Model, error) {
+	for attempt := 0; attempt < attempts; attempt++ {
+		lastId, err := st.lastTxnLogId()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		model, err := export(st, ExportConfig{})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changed, err := st.modelChangedSince(lastId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !changed {
+			return model, nil
+		}
+		logger.Debugf("model %s changed during export (attempt %d)", st.ModelUUID(), attempt+1)
+	}
+	return nil, errors.Errorf("model changed during export %d times, try again when it is less busy", attempts)
+}
+
+// lastTxnLogId returns the id of the newest entry in the transaction log.
+func (st *State) lastTxnLogId() (interface{}, error) {
+	coll, closer := st.db().GetRawCollection(txnLogC)
+	defer closer()
+
+	var entry struct {
+		Id interface{} `bson:"_id"`
+	}
+	err := coll.Find(nil).Sort("-$natural").One(&entry)
+	if err != nil && err != mgo.ErrNotFound {
+		return nil, errors.Trace(err)
+	}
+	return entry.Id, nil
+}
+
+// consistencyIgnoredCollections holds the collections whose changes
+// do not make an export inconsistent. Statuses and connection times
+// change constantly on a live model, and would otherwise prevent any
+// busy model from being exported.
+var consistencyIgnoredCollections = set.NewStrings(
+	statusesC,
+	statusesHistoryC,
+	modelUserLastConnectionC,
+)
+
+// modelChangedSince reports whether a transaction touching a document of
+// the current model has been logged after the transaction log entry with
+// the specified id. If that entry is no longer in the log, which is
+// capped, the model is assumed to have changed.
+func (st *State) modelChangedSince(lastId interface{}) (bool, error) {
+	coll, closer := st.db().GetRawCollection(txnLogC)
+	defer closer()
+
+	modelUUID := st.ModelUUID()
+	iter := coll.Find(nil).Sort("-$natural").Iter()
+	var entry bson.D
+	for iter.Next(&entry) {
+		if len(entry) == 0 || entry[0].Name != "_id" {
+			continue
+		}
+		if entry[0].Value == lastId {
+			return false, errors.Trace(iter.Close())
+		}
+		// See txn's Runner.ChangeLog for the structure of log entries.
+		for _, c := range entry[1:] {
+			if consistencyIgnoredCollections.Contains(c.Name) {
+				continue
+			}
+			dr, _ := c.Value.(bson.D)
+			for _, item := range dr {
+				if item.Name != "d" {
+					continue
+				}
+				ids, _ := item.Value.([]interface{})
+				for _, id := range ids {
+					docID, ok := id.(string)
+					if ok && (docID == modelUUID || strings.HasPrefix(docID, modelUUID+":")) {
+						_ = iter.Close()
+						return true, nil
+					}
+				}
+			}
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return false, errors.Trace(err)
+	}
+	// An empty log has no last id, so nothing has changed.
+	return lastId != nil, nil
+}
+
 func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 	dbModel, err := st.Model()
 	if err != nil {
@@ -1055,6 +1161,10 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 				}
 			}
 			e.statusHistoryArgs(globalCCKey)
+
+			// Container health is reported again by the unit agent once
+			// it has migrated, so it isn't exported.
+			e.skipContainerHealth(unit.Name())
 		}
 		exUnit.SetAnnotations(e.getAnnotations(globalKey))
 
@@ -2075,6 +2185,22 @@ func (e *exporter) readAllStatusHistory() error {
 	return nil
 }
 
+// skipContainerHealth drops the health of the unit's workload
+// containers, and its history, from the docs to export.
+func (e *exporter) skipContainerHealth(unitName string) {
+	prefix := globalContainerHealthKey(unitName, "")
+	for key := range e.status {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.status, key)
+		}
+	}
+	for key := range e.statusHistory {
+		if strings.HasPrefix(key, prefix) {
+			delete(e.statusHistory, key)
+		}
+	}
+}
+
 func (e *exporter) statusArgs(globalKey string) (description.StatusArgs, error) {
 	result := description.StatusArgs{}
 	statusDoc, found := e.status[globalKey]
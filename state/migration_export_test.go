@@ -15,6 +15,7 @@ import (
 	"github.com/juju/description/v3"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/mgo/v2/bson"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/v2"
@@ -221,6 +222,68 @@ func (s *MigrationExportSuite) TestModelInfo(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestExportConsistent(c *gc.C) {
+	err := s.Model.SetAnnotations(s.Model, testAnnotations)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.ExportConsistent(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Tag(), gc.Equals, s.Model.ModelTag())
+	c.Assert(model.Annotations(), jc.DeepEquals, testAnnotations)
+}
+
+func (s *MigrationExportSuite) TestExportConsistentRetriesWhenModelChanges(c *gc.C) {
+	attempts := 0
+	model, err := state.ExportConsistentWith(s.State, 3, func() {
+		attempts++
+		if attempts == 1 {
+			err := s.Model.SetAnnotations(s.Model, testAnnotations)
+			c.Assert(err, jc.ErrorIsNil)
+		}
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attempts, gc.Equals, 2)
+	c.Assert(model.Annotations(), jc.DeepEquals, testAnnotations)
+}
+
+func (s *MigrationExportSuite) TestExportConsistentFailsWhenModelKeepsChanging(c *gc.C) {
+	attempts := 0
+	_, err := state.ExportConsistentWith(s.State, 3, func() {
+		attempts++
+		err := s.Model.SetAnnotations(s.Model, map[string]string{"attempt": fmt.Sprint(attempts)})
+		c.Assert(err, jc.ErrorIsNil)
+	})
+	c.Assert(err, gc.ErrorMatches, "model changed during export 3 times, try again when it is less busy")
+	c.Assert(attempts, gc.Equals, 3)
+}
+
+func (s *MigrationExportSuite) TestModelChangedSinceIgnoresStatus(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	lastId, err := state.LastTxnLogId(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	err = machine.SetStatus(status.StatusInfo{Status: status.Started, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	changed, err := state.ModelChangedSince(s.State, lastId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changed, jc.IsFalse)
+
+	err = s.Model.SetAnnotations(machine, testAnnotations)
+	c.Assert(err, jc.ErrorIsNil)
+	changed, err = state.ModelChangedSince(s.State, lastId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changed, jc.IsTrue)
+}
+
+func (s *MigrationExportSuite) TestModelChangedSinceLogEntryAgedOut(c *gc.C) {
+	// An id that is not in the capped log stands in for an entry that
+	// has been discarded; the model can't be shown to be unchanged.
+	changed, err := state.ModelChangedSince(s.State, bson.NewObjectId())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changed, jc.IsTrue)
+}
+
 func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	// Make sure we have some last connection times for the admin user,
 	// and create a few other users.
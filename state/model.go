@@ -384,6 +384,7 @@ func (ctlr *Controller) NewModel(args ModelArgs) (_ *Model, _ *State, err error)
 		names.NewModelTag(uuid),
 		controllerInfo.ModelTag,
 		session,
+		st.dbName,
 		st.newPolicy,
 		st.clock(),
 		st.runTransactionObserver,
@@ -7,7 +7,9 @@ import (
 	"reflect"
 
 	"github.com/juju/errors"
+	"github.com/juju/mgo/v2/txn"
 	"github.com/juju/schema"
+	jujutxn "github.com/juju/txn/v2"
 	"github.com/juju/version/v2"
 
 	"github.com/juju/juju/controller"
@@ -49,6 +51,65 @@ func getModelConfig(db Database, uuid string) (*config.Config, error) {
 	return config.New(config.NoDefaults, modelSettings.Map())
 }
 
+// preferIPv6 returns the model's prefer-ipv6 setting. It reads the
+// stored value directly, avoiding the cost of building and validating
+// the whole model config for this one key. The returned op asserts that
+// the model settings are unchanged, so that addresses selected using
+// the setting are never written once it has been changed.
+func (st *State) preferIPv6() (bool, txn.Op, error) {
+	modelSettings, err := readSettings(st.db(), settingsC, modelGlobalKey)
+	if err != nil {
+		return false, txn.Op{}, errors.Annotatef(err, "model %q", st.ModelUUID())
+	}
+	v, _ := modelSettings.Get(config.PreferIPv6)
+	prefer, _ := v.(bool)
+	return prefer, modelSettings.assertUnchangedOp(), nil
+}
+
+// reselectPreferredAddresses picks the preferred addresses of all the
+// model's machines afresh, so that a change to prefer-ipv6 takes effect
+// without waiting for the machines' addresses to change.
+func (st *State) reselectPreferredAddresses() error {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		var newPrivate, newPublic *address
+		machine := m
+		buildTxn := func(attempt int) ([]txn.Op, error) {
+			if attempt != 0 {
+				if machine, err = machine.st.Machine(machine.doc.Id); err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+			if machine.doc.Life == Dead {
+				return nil, jujutxn.ErrNoOperations
+			}
+			preferIPv6, assertSettingsOp, err := st.preferIPv6()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			var ops []txn.Op
+			ops, newPrivate, newPublic = machine.reselectPreferredAddressesOps(preferIPv6)
+			if len(ops) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return append(ops, assertSettingsOp), nil
+		}
+		if err := st.db().Run(buildTxn); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return errors.Annotatef(err, "reselecting preferred addresses of machine %v", m)
+		}
+		if err := machine.updatePreferredAddresses(newPrivate, newPublic); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // checkModelConfig returns an error if the config is definitely invalid.
 func checkModelConfig(cfg *config.Config) error {
 	allAttrs := cfg.AllAttrs()
@@ -397,7 +458,13 @@ func (m *Model) UpdateModelConfig(updateAttrs map[string]interface{}, removeAttr
 
 	modelSettings.Update(validAttrs)
 	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	if err := modelSettings.write(ops); err != nil {
+		return errors.Trace(err)
+	}
+	if validCfg.PreferIPv6() != oldConfig.PreferIPv6() {
+		return errors.Trace(st.reselectPreferredAddresses())
+	}
+	return nil
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
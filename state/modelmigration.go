@@ -76,6 +76,27 @@ type ModelMigration interface {
 	// current progress of the migration.
 	SetStatusMessage(text string) error
 
+	// PhaseTimes returns the times at which the migration entered
+	// each of the phases it has reached.
+	PhaseTimes() map[migration.Phase]time.Time
+
+	// Progress returns how much of the model's data has been
+	// transferred to the target controller.
+	Progress() migration.TransferProgress
+
+	// SetProgress records how much of the model's data has been
+	// transferred to the target controller.
+	SetProgress(progress migration.TransferProgress) error
+
+	// Retries returns the number of times a retry of a failed model
+	// data transfer has been requested.
+	Retries() int
+
+	// RetryTransfer requests that a failed model data transfer be
+	// retried. An error is returned if the migration is not in the
+	// IMPORT phase.
+	RetryTransfer() error
+
 	// SubmitMinionReport records a report from a migration minion
 	// worker about the success or failure to complete its actions for
 	// a given migration phase.
@@ -205,6 +226,32 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// PhaseTimes holds the time that the migration entered each of
+	// the phases it has reached (stored as per UnixNano), keyed on
+	// the phase name.
+	PhaseTimes map[string]int64 `bson:"phase-times,omitempty"`
+
+	// Progress holds how much of the model's data has been
+	// transferred to the target controller.
+	Progress modelMigProgressDoc `bson:"progress,omitempty"`
+
+	// Retries holds the number of times a retry of a failed model
+	// data transfer has been requested.
+	Retries int `bson:"retries,omitempty"`
+}
+
+// modelMigProgressDoc records the progress of the transfer of a
+// model's data to the target controller.
+type modelMigProgressDoc struct {
+	Charms         int   `bson:"charms"`
+	CharmsTotal    int   `bson:"charms-total"`
+	Tools          int   `bson:"tools"`
+	ToolsTotal     int   `bson:"tools-total"`
+	Resources      int   `bson:"resources"`
+	ResourcesTotal int   `bson:"resources-total"`
+	LogMessages    int   `bson:"log-messages"`
+	LogBytes       int64 `bson:"log-bytes"`
 }
 
 type modelMigMinionSyncDoc struct {
@@ -310,9 +357,15 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	nextDoc := mig.statusDoc
 	nextDoc.Phase = nextPhase.String()
 	nextDoc.PhaseChangedTime = now
+	nextDoc.PhaseTimes = make(map[string]int64)
+	for name, t := range mig.statusDoc.PhaseTimes {
+		nextDoc.PhaseTimes[name] = t
+	}
+	nextDoc.PhaseTimes[nextDoc.Phase] = now
 	update := bson.M{
-		"phase":              nextDoc.Phase,
-		"phase-changed-time": now,
+		"phase":                        nextDoc.Phase,
+		"phase-changed-time":           now,
+		"phase-times." + nextDoc.Phase: now,
 	}
 	if nextPhase == migration.SUCCESS {
 		nextDoc.SuccessTime = now
@@ -427,6 +480,83 @@ func (mig *modelMigration) SetStatusMessage(text string) error {
 	return nil
 }
 
+// PhaseTimes implements ModelMigration.
+func (mig *modelMigration) PhaseTimes() map[migration.Phase]time.Time {
+	times := make(map[migration.Phase]time.Time)
+	for name, t := range mig.statusDoc.PhaseTimes {
+		if phase, ok := migration.ParsePhase(name); ok {
+			times[phase] = unixNanoToTime0(t)
+		}
+	}
+	return times
+}
+
+// Progress implements ModelMigration.
+func (mig *modelMigration) Progress() migration.TransferProgress {
+	doc := mig.statusDoc.Progress
+	return migration.TransferProgress{
+		Charms:         doc.Charms,
+		CharmsTotal:    doc.CharmsTotal,
+		Tools:          doc.Tools,
+		ToolsTotal:     doc.ToolsTotal,
+		Resources:      doc.Resources,
+		ResourcesTotal: doc.ResourcesTotal,
+		LogMessages:    doc.LogMessages,
+		LogBytes:       doc.LogBytes,
+	}
+}
+
+// SetProgress implements ModelMigration.
+func (mig *modelMigration) SetProgress(progress migration.TransferProgress) error {
+	doc := modelMigProgressDoc{
+		Charms:         progress.Charms,
+		CharmsTotal:    progress.CharmsTotal,
+		Tools:          progress.Tools,
+		ToolsTotal:     progress.ToolsTotal,
+		Resources:      progress.Resources,
+		ResourcesTotal: progress.ResourcesTotal,
+		LogMessages:    progress.LogMessages,
+		LogBytes:       progress.LogBytes,
+	}
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Update: bson.M{"$set": bson.M{"progress": doc}},
+		Assert: txn.DocExists,
+	}}
+	if err := mig.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "failed to set migration progress")
+	}
+	mig.statusDoc.Progress = doc
+	return nil
+}
+
+// Retries implements ModelMigration.
+func (mig *modelMigration) Retries() int {
+	return mig.statusDoc.Retries
+}
+
+// RetryTransfer implements ModelMigration.
+func (mig *modelMigration) RetryTransfer() error {
+	phase := migration.IMPORT.String()
+	if mig.statusDoc.Phase != phase {
+		return errors.Errorf("migration is in the %s phase, not %s", mig.statusDoc.Phase, phase)
+	}
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Update: bson.M{"$inc": bson.M{"retries": 1}},
+		Assert: bson.M{"phase": phase},
+	}}
+	if err := mig.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.New("phase already changed")
+	} else if err != nil {
+		return errors.Annotate(err, "failed to request migration retry")
+	}
+	mig.statusDoc.Retries++
+	return nil
+}
+
 // SubmitMinionReport implements ModelMigration.
 func (mig *modelMigration) SubmitMinionReport(tag names.Tag, phase migration.Phase, success bool) error {
 	globalKey, err := agentTagToGlobalKey(tag)
@@ -726,6 +856,7 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			Phase:            migration.QUIESCE.String(),
 			PhaseChangedTime: now,
 			StatusMessage:    msg,
+			PhaseTimes:       map[string]int64{migration.QUIESCE.String(): now},
 		}
 
 		ops := append(ops, []txn.Op{{
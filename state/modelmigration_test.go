@@ -524,6 +524,80 @@ func (s *MigrationSuite) TestStatusMessage(c *gc.C) {
 	c.Check(mig2.StatusMessage(), gc.Equals, "foo bar")
 }
 
+func (s *MigrationSuite) TestPhaseTimes(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	started := s.Clock.Now()
+
+	s.Clock.Advance(time.Minute)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	imported := s.Clock.Now()
+
+	expected := map[migration.Phase]time.Time{
+		migration.QUIESCE: started,
+		migration.IMPORT:  imported,
+	}
+	c.Check(mig.PhaseTimes(), jc.DeepEquals, expected)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.PhaseTimes(), jc.DeepEquals, expected)
+}
+
+func (s *MigrationSuite) TestProgress(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Progress(), gc.Equals, migration.TransferProgress{})
+
+	progress := migration.TransferProgress{
+		Charms:         1,
+		CharmsTotal:    2,
+		Tools:          1,
+		ToolsTotal:     1,
+		ResourcesTotal: 3,
+		LogMessages:    10,
+		LogBytes:       1024,
+	}
+	c.Assert(mig.SetProgress(progress), jc.ErrorIsNil)
+	c.Check(mig.Progress(), gc.Equals, progress)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.Progress(), gc.Equals, progress)
+}
+
+func (s *MigrationSuite) TestRetryTransfer(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mig.RetryTransfer()
+	c.Assert(err, gc.ErrorMatches, "migration is in the QUIESCE phase, not IMPORT")
+
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+	c.Assert(mig.RetryTransfer(), jc.ErrorIsNil)
+	c.Assert(mig.RetryTransfer(), jc.ErrorIsNil)
+	c.Check(mig.Retries(), gc.Equals, 2)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.Retries(), gc.Equals, 2)
+}
+
+func (s *MigrationSuite) TestRetryTransferPhaseChanged(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.IMPORT), jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State2, func() {
+		mig, err := s.State2.LatestMigration()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	}).Check()
+
+	err = mig.RetryTransfer()
+	c.Assert(err, gc.ErrorMatches, "phase already changed")
+}
+
 func (s *MigrationSuite) TestWatchForMigration(c *gc.C) {
 	// Start watching for migration.
 	w, wc := s.createMigrationWatcher(c, s.State2)
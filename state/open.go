@@ -48,6 +48,19 @@ type OpenParams struct {
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
+
+	// DatabaseName, if non-empty, is the name of the database holding
+	// the state data. It defaults to the juju database, and is only
+	// set to open a snapshot of that database.
+	DatabaseName string
+}
+
+// databaseName returns the name of the database holding the state data.
+func (p OpenParams) databaseName() string {
+	if p.DatabaseName == "" {
+		return jujuDB
+	}
+	return p.DatabaseName
 }
 
 // Validate validates the OpenParams.
@@ -87,13 +100,14 @@ func open(
 	controllerTag names.ControllerTag,
 	controllerModelTag names.ModelTag,
 	session *mgo.Session,
+	dbName string,
 	initDatabase InitDatabaseFunc,
 	controllerConfig *controller.Config,
 	newPolicy NewPolicyFunc,
 	clock clock.Clock,
 	runTransactionObserver RunTransactionObserverFunc,
 ) (*State, error) {
-	st, err := newState(controllerTag, controllerModelTag, controllerModelTag, session, newPolicy, clock, runTransactionObserver)
+	st, err := newState(controllerTag, controllerModelTag, controllerModelTag, session, dbName, newPolicy, clock, runTransactionObserver)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -118,6 +132,7 @@ func newState(
 	controllerTag names.ControllerTag,
 	modelTag, controllerModelTag names.ModelTag,
 	session *mgo.Session,
+	dbName string,
 	newPolicy NewPolicyFunc,
 	clock clock.Clock,
 	runTransactionObserver RunTransactionObserverFunc,
@@ -129,7 +144,7 @@ func newState(
 		}
 	}()
 
-	mongodb := session.DB(jujuDB)
+	mongodb := session.DB(dbName)
 	sstxn := txn.SupportsServerSideTransactions(mongodb)
 	if sstxn {
 		logger.Infof("using server-side transactions")
@@ -152,6 +167,7 @@ func newState(
 		modelTag:               modelTag,
 		controllerModelTag:     controllerModelTag,
 		session:                session,
+		dbName:                 dbName,
 		database:               db,
 		newPolicy:              newPolicy,
 		runTransactionObserver: runTransactionObserver,
@@ -142,6 +142,7 @@ func OpenStatePool(args OpenParams) (_ *StatePool, err error) {
 		args.ControllerTag,
 		args.ControllerModelTag,
 		session,
+		args.databaseName(),
 		args.InitDatabaseFunc,
 		nil,
 		args.NewPolicy,
@@ -190,7 +191,7 @@ func OpenStatePool(args OpenParams) (_ *StatePool, err error) {
 		return watcher.NewTxnWatcher(
 			watcher.TxnWatcherConfig{
 				Session:        pool.txnWatcherSession,
-				JujuDBName:     jujuDB,
+				JujuDBName:     args.databaseName(),
 				CollectionName: txnLogC,
 				Hub:            pool.hub,
 				Clock:          args.Clock,
@@ -271,7 +272,7 @@ func (p *StatePool) openState(modelUUID string) (*State, error) {
 	newSt, err := newState(
 		p.systemState.controllerTag,
 		modelTag, p.systemState.controllerModelTag,
-		session, p.systemState.newPolicy, p.systemState.stateClock,
+		session, p.systemState.dbName, p.systemState.newPolicy, p.systemState.stateClock,
 		p.systemState.runTransactionObserver,
 	)
 	if err != nil {
@@ -0,0 +1,168 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v2"
+	"github.com/juju/mgo/v2/bson"
+)
+
+// snapshotBatchSize is the number of documents inserted at once when
+// copying a collection into a snapshot.
+const snapshotBatchSize = 1000
+
+// UpgradeCheckDatabasePrefix prefixes the names of the snapshots which
+// upgrade steps are checked against. Each check uses its own database,
+// so that concurrent checks don't collide.
+const UpgradeCheckDatabasePrefix = "juju-upgrade-check-"
+
+// SnapshotDatabase replaces the named database with a copy of the juju
+// database, so that changes such as upgrade steps can be tried without
+// touching the state data. The snapshot is opened by setting the
+// DatabaseName of the OpenParams.
+//
+// The collections of the snapshot are created as specified by the
+// schema, including the capped transaction log, and have the indexes
+// of the collections they copy. The documents are copied collection by
+// collection while the controller is running, so the snapshot is not
+// taken at a single point in time.
+//
+// Only the juju database is copied; data held in other databases, such
+// as logs and blobs, is shared with the controller. State refuses to
+// change those databases when opened on a snapshot.
+//
+// The snapshot is refused if the database's filesystem has too little
+// free space to hold it, and a partial copy is dropped if the snapshot
+// can not be completed.
+func SnapshotDatabase(session *mgo.Session, name string) (err error) {
+	if err := DropSnapshotDatabase(session, name); err != nil {
+		return errors.Trace(err)
+	}
+	src := session.DB(jujuDB)
+	dst := session.DB(name)
+	if err := checkSnapshotSpace(src); err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if dropErr := DropSnapshotDatabase(session, name); dropErr != nil {
+			logger.Warningf("cannot drop partial snapshot: %v", dropErr)
+		}
+	}()
+	if err := allCollections().Create(dst, nil); err != nil {
+		return errors.Annotate(err, "creating collections")
+	}
+	collections, err := src.CollectionNames()
+	if err != nil {
+		return errors.Annotate(err, "listing collections")
+	}
+	for _, coll := range collections {
+		if strings.HasPrefix(coll, "system.") {
+			continue
+		}
+		if err := copyIndexes(src.C(coll), dst.C(coll)); err != nil {
+			return errors.Annotatef(err, "copying indexes of collection %q", coll)
+		}
+		if err := copyCollection(src.C(coll), dst.C(coll)); err != nil {
+			return errors.Annotatef(err, "copying collection %q", coll)
+		}
+	}
+	return nil
+}
+
+// checkSnapshotSpace returns an error if the filesystem holding the
+// database doesn't have enough free space for a copy of it.
+func checkSnapshotSpace(db *mgo.Database) error {
+	var stats struct {
+		StorageSize int64 `bson:"storageSize"`
+		IndexSize   int64 `bson:"indexSize"`
+		FsUsedSize  int64 `bson:"fsUsedSize"`
+		FsTotalSize int64 `bson:"fsTotalSize"`
+	}
+	if err := db.Run(bson.D{{"dbStats", 1}}, &stats); err != nil {
+		return errors.Annotate(err, "reading database size")
+	}
+	if stats.FsTotalSize == 0 {
+		// Older versions of mongo don't report filesystem usage.
+		return nil
+	}
+	needed := stats.StorageSize + stats.IndexSize
+	free := stats.FsTotalSize - stats.FsUsedSize
+	if needed > free {
+		return errors.Errorf(
+			"not enough free space for a copy of the database: need %dMiB, have %dMiB",
+			needed>>20, free>>20,
+		)
+	}
+	return nil
+}
+
+func copyIndexes(src, dst *mgo.Collection) error {
+	indexes, err := src.Indexes()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, index := range indexes {
+		if index.Name == "_id_" {
+			continue
+		}
+		if err := dst.EnsureIndex(index); err != nil {
+			return errors.Annotatef(err, "index %q", index.Name)
+		}
+	}
+	return nil
+}
+
+func copyCollection(src, dst *mgo.Collection) error {
+	iter := src.Find(nil).Iter()
+	batch := make([]interface{}, 0, snapshotBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		bulk := dst.Bulk()
+		bulk.Unordered()
+		bulk.Insert(batch...)
+		_, err := bulk.Run()
+		batch = batch[:0]
+		return err
+	}
+	var doc bson.Raw
+	for iter.Next(&doc) {
+		batch = append(batch, doc)
+		doc = bson.Raw{}
+		if len(batch) == snapshotBatchSize {
+			if err := flush(); err != nil {
+				_ = iter.Close()
+				return errors.Trace(err)
+			}
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(flush())
+}
+
+// isSnapshot returns whether the state data is held in a snapshot
+// created by SnapshotDatabase rather than in the juju database.
+func (st *State) isSnapshot() bool {
+	return st.dbName != jujuDB
+}
+
+// DropSnapshotDatabase removes a database created by SnapshotDatabase.
+func DropSnapshotDatabase(session *mgo.Session, name string) error {
+	if name == jujuDB {
+		return errors.Errorf("cannot drop the %s database", jujuDB)
+	}
+	if err := session.DB(name).DropDatabase(); err != nil {
+		return errors.Annotatef(err, "dropping snapshot database %q", name)
+	}
+	return nil
+}
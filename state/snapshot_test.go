@@ -0,0 +1,106 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/mgo/v2/bson"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type SnapshotSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&SnapshotSuite{})
+
+func (s *SnapshotSuite) TestSnapshotDatabase(c *gc.C) {
+	m0 := s.Factory.MakeMachine(c, nil)
+
+	err := state.SnapshotDatabase(s.Session, "juju-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		err := state.DropSnapshotDatabase(s.Session, "juju-snapshot")
+		c.Check(err, jc.ErrorIsNil)
+	}()
+
+	pool, err := state.OpenStatePool(state.OpenParams{
+		Clock:              clock.WallClock,
+		ControllerTag:      s.State.ControllerTag(),
+		ControllerModelTag: s.Model.ModelTag(),
+		MongoSession:       s.Session,
+		DatabaseName:       "juju-snapshot",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer pool.Close()
+
+	// Changes to the snapshot don't affect the state data.
+	snapshot := pool.SystemState()
+	m, err := snapshot.Machine(m0.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = snapshot.Machine(m0.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	err = m0.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SnapshotSuite) TestSnapshotDatabaseSchema(c *gc.C) {
+	err := state.SnapshotDatabase(s.Session, "juju-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		err := state.DropSnapshotDatabase(s.Session, "juju-snapshot")
+		c.Check(err, jc.ErrorIsNil)
+	}()
+
+	expected, err := s.Session.DB("juju").C("statuseshistory").Indexes()
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err := s.Session.DB("juju-snapshot").C("statuseshistory").Indexes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(obtained, jc.DeepEquals, expected)
+
+	var stats bson.M
+	err = s.Session.DB("juju-snapshot").Run(bson.D{{"collStats", "txns.log"}}, &stats)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stats["capped"], jc.IsTrue)
+}
+
+func (s *SnapshotSuite) TestSnapshotDoesNotChangeOtherDatabases(c *gc.C) {
+	err := state.SnapshotDatabase(s.Session, "juju-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		err := state.DropSnapshotDatabase(s.Session, "juju-snapshot")
+		c.Check(err, jc.ErrorIsNil)
+	}()
+
+	pool, err := state.OpenStatePool(state.OpenParams{
+		Clock:              clock.WallClock,
+		ControllerTag:      s.State.ControllerTag(),
+		ControllerModelTag: s.Model.ModelTag(),
+		MongoSession:       s.Session,
+		DatabaseName:       "juju-snapshot",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer pool.Close()
+
+	err = state.DropPresenceDatabase(pool)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = state.SplitLogCollections(pool)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *SnapshotSuite) TestDropJujuDatabase(c *gc.C) {
+	err := state.DropSnapshotDatabase(s.Session, "juju")
+	c.Assert(err, gc.ErrorMatches, "cannot drop the juju database")
+}
@@ -75,6 +75,7 @@ type State struct {
 	controllerModelTag     names.ModelTag
 	controllerTag          names.ControllerTag
 	session                *mgo.Session
+	dbName                 string
 	database               Database
 	policy                 Policy
 	newPolicy              NewPolicyFunc
@@ -97,6 +98,7 @@ func (st *State) newStateNoWorkers(modelUUID string) (*State, error) {
 		names.NewModelTag(modelUUID),
 		st.controllerModelTag,
 		session,
+		st.dbName,
 		st.newPolicy,
 		st.stateClock,
 		st.runTransactionObserver,
@@ -286,7 +288,10 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 	}
 
 	// Logs are in a separate database so don't get caught by that loop.
-	_ = removeModelLogs(st.MongoSession(), modelUUID)
+	// That database is shared with the controller when using a snapshot.
+	if !st.isSnapshot() {
+		_ = removeModelLogs(st.MongoSession(), modelUUID)
+	}
 
 	// Remove all user permissions for the model.
 	permPattern := bson.M{
@@ -380,6 +380,14 @@ func (op *UpdateUnitOperation) Build(attempt int) ([]txn.Op, error) {
 		addr := fromNetworkAddress(networkAddr, network.OriginProvider)
 		containerInfo.Address = &addr
 	}
+	if op.props.NetworkAddresses != nil {
+		var addrs []address
+		for _, value := range *op.props.NetworkAddresses {
+			networkAddr := network.NewSpaceAddress(value, network.WithScope(network.ScopeMachineLocal))
+			addrs = append(addrs, fromNetworkAddress(networkAddr, network.OriginProvider))
+		}
+		containerInfo.NetworkAddresses = addrs
+	}
 	if op.props.Ports != nil {
 		containerInfo.Ports = *op.props.Ports
 	}
@@ -1248,6 +1256,22 @@ func (u *Unit) containerAddress() (network.SpaceAddress, error) {
 	return addr.networkAddress(), nil
 }
 
+// NetworkAddresses returns the addresses of the unit's container
+// on any secondary networks it is attached to.
+func (u *Unit) NetworkAddresses() (network.SpaceAddresses, error) {
+	containerInfo, err := u.cloudContainer()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(containerInfo.NetworkAddresses) == 0 {
+		return nil, nil
+	}
+	return networkAddresses(containerInfo.NetworkAddresses), nil
+}
+
 func (u *Unit) scopedAddress(scope string) (network.SpaceAddress, error) {
 	addresses, err := u.AllAddresses()
 	if err != nil {
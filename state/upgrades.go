@@ -1044,6 +1044,9 @@ func addStorageInstanceConstraints(st *State) error {
 // to the log collection per model.
 func SplitLogCollections(pool *StatePool) error {
 	st := pool.SystemState()
+	if st.isSnapshot() {
+		return errors.NotSupportedf("splitting log collections of a database snapshot")
+	}
 	session := st.MongoSession()
 	db := session.DB(logsDB)
 	oldLogs := db.C("logs")
@@ -1422,7 +1425,7 @@ func addRelationStatus(st *State) error {
 // to old-audit.log if it has any rows - if it's empty it deletes it.
 func MoveOldAuditLog(pool *StatePool) error {
 	st := pool.SystemState()
-	names, err := st.MongoSession().DB("juju").CollectionNames()
+	names, err := st.MongoSession().DB(st.dbName).CollectionNames()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -2084,7 +2087,8 @@ func EnsureApplicationDeviceConstraints(pool *StatePool) error {
 // RemoveInstanceCharmProfileDataCollection removes the
 // instanceCharmProfileData collection on upgrade.
 func RemoveInstanceCharmProfileDataCollection(pool *StatePool) error {
-	db := pool.SystemState().MongoSession().DB(jujuDB)
+	st := pool.SystemState()
+	db := st.MongoSession().DB(st.dbName)
 	instanceCharmProfileData := db.C("instanceCharmProfileData")
 	if err := instanceCharmProfileData.DropCollection(); err != nil {
 		// If the namespace is already missing, that's fine.
@@ -2971,6 +2975,9 @@ func AddOriginToIPAddresses(pool *StatePool) error {
 // DropPresenceDatabase removes the legacy presence database.
 func DropPresenceDatabase(pool *StatePool) error {
 	st := pool.SystemState()
+	if st.isSnapshot() {
+		return errors.NotSupportedf("dropping the presence database of a database snapshot")
+	}
 	return st.session.DB("presence").DropDatabase()
 }
 
@@ -0,0 +1,125 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+import (
+	"github.com/juju/version/v2"
+
+	jujuversion "github.com/juju/juju/version"
+)
+
+// PendingStep describes an upgrade step which is run when upgrading
+// to a particular version.
+type PendingStep struct {
+	// TargetVersion is the version whose upgrade runs the step.
+	TargetVersion version.Number
+
+	// Description is the step's description.
+	Description string
+
+	// Targets are the types of machine the step is run on.
+	Targets []Target
+
+	// State is true for state-based steps, which are run against the
+	// database by a controller.
+	State bool
+}
+
+// PendingSteps returns the upgrade steps which are run when upgrading
+// from one version to another, ordered by target version. State-based
+// steps precede the API-based steps of the same target version.
+func PendingSteps(from, to version.Number) []PendingStep {
+	var steps []PendingStep
+	stateOps := newOpsIterator(from, to, stateUpgradeOperations())
+	apiOps := newOpsIterator(from, to, upgradeOperations())
+	stateNext, apiNext := stateOps.Next(), apiOps.Next()
+	for stateNext || apiNext {
+		useState := stateNext
+		if stateNext && apiNext {
+			useState = stateOps.Get().TargetVersion().Compare(apiOps.Get().TargetVersion()) <= 0
+		}
+		if useState {
+			steps = appendPendingSteps(steps, stateOps.Get(), true)
+			stateNext = stateOps.Next()
+		} else {
+			steps = appendPendingSteps(steps, apiOps.Get(), false)
+			apiNext = apiOps.Next()
+		}
+	}
+	return steps
+}
+
+func appendPendingSteps(steps []PendingStep, op Operation, state bool) []PendingStep {
+	for _, step := range op.Steps() {
+		steps = append(steps, PendingStep{
+			TargetVersion: op.TargetVersion(),
+			Description:   step.Description(),
+			Targets:       step.Targets(),
+			State:         state,
+		})
+	}
+	return steps
+}
+
+// CheckedStep holds the result of checking a pending upgrade step.
+type CheckedStep struct {
+	PendingStep
+
+	// Checked is true if the step was run by the check. Only the
+	// state-based steps for the check's targets are run, and not
+	// those which change data outside the controller's database.
+	Checked bool
+
+	// Err holds the error returned by the step, if any.
+	Err error
+}
+
+// CheckUpgrade runs the state-based upgrade steps needed to upgrade
+// from the given version to this version of Juju on the target type of
+// machine, and reports the result of each pending step. Unlike
+// PerformUpgrade, a failed step does not prevent the steps after it
+// from being run, so the context should hold a disposable copy of the
+// database.
+func CheckUpgrade(from version.Number, targets []Target, context Context) []CheckedStep {
+	context = context.StateContext()
+	var results []CheckedStep
+	for _, step := range PendingSteps(from, jujuversion.Current) {
+		results = append(results, CheckedStep{PendingStep: step})
+	}
+	ops := newOpsIterator(from, jujuversion.Current, stateUpgradeOperations())
+	for ops.Next() {
+		for _, step := range ops.Get().Steps() {
+			if !targetsMatch(targets, step.Targets()) {
+				continue
+			}
+			if isExternal(step) {
+				logger.Infof("not checking upgrade step: %v", step.Description())
+				continue
+			}
+			logger.Infof("checking upgrade step: %v", step.Description())
+			err := step.Run(context)
+			if err != nil {
+				logger.Warningf("upgrade step %q failed: %v", step.Description(), err)
+			}
+			for i, result := range results {
+				if result.State && !result.Checked &&
+					result.TargetVersion == ops.Get().TargetVersion() &&
+					result.Description == step.Description() {
+					results[i].Checked = true
+					results[i].Err = err
+					break
+				}
+			}
+		}
+	}
+	return results
+}
+
+// isExternal returns whether the step changes data outside the
+// controller's juju database, and so can't be checked against a copy
+// of that database.
+func isExternal(step Step) bool {
+	s, ok := step.(*upgradeStep)
+	return ok && s.external
+}
@@ -27,3 +27,9 @@ func UpgradeModelConfig(
 ) error {
 	return upgradeModelConfig(reader, updater, registry)
 }
+
+// NewExternalStep returns a step which changes data outside the
+// controller's juju database.
+func NewExternalStep(description string, targets []Target, run func(Context) error) Step {
+	return &upgradeStep{description: description, targets: targets, run: run, external: true}
+}
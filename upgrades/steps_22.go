@@ -52,6 +52,7 @@ func stateStepsFor22() []Step {
 			run: func(context Context) error {
 				return context.State().SplitLogCollections()
 			},
+			external: true,
 		},
 	}
 }
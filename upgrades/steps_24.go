@@ -57,6 +57,7 @@ func stateStepsFor24() []Step {
 			description: "bootstrap raft cluster",
 			targets:     []Target{Controller},
 			run:         BootstrapRaft,
+			external:    true,
 		},
 	}
 }
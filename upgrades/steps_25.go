@@ -17,6 +17,7 @@ func stateStepsFor25() []Step {
 			description: "migrate legacy leases into raft",
 			targets:     []Target{Controller},
 			run:         MigrateLegacyLeases,
+			external:    true,
 		},
 		&upgradeStep{
 			description: "migrate add-model permissions",
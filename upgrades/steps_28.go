@@ -35,6 +35,7 @@ func stateStepsFor28() []Step {
 			run: func(context Context) error {
 				return context.State().DropPresenceDatabase()
 			},
+			external: true,
 		},
 		&upgradeStep{
 			description: "increment tasks sequence by 1",
@@ -165,6 +165,11 @@ type upgradeStep struct {
 	description string
 	targets     []Target
 	run         func(Context) error
+
+	// external is set for steps which change data outside the
+	// controller's juju database, such as the logs database or the
+	// agent's data directory. They are not run by CheckUpgrade.
+	external bool
 }
 
 var _ Step = (*upgradeStep)(nil)
@@ -665,3 +665,93 @@ func extractUpgradeVersions(c *gc.C, ops []upgrades.Operation) []string {
 	}
 	return versions
 }
+
+func (s *upgradeSuite) TestPendingSteps(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, stateUpgradeOperations)
+	s.PatchValue(upgrades.UpgradeOperations, upgradeOperations)
+
+	var descriptions []string
+	for _, step := range upgrades.PendingSteps(version.MustParse("1.17.1"), version.MustParse("1.21.0")) {
+		descriptions = append(descriptions, fmt.Sprintf("%s %s %v", step.TargetVersion, step.Description, step.State))
+	}
+	c.Assert(descriptions, jc.DeepEquals, []string{
+		"1.18.0 step 1 - 1.18.0 false",
+		"1.18.0 step 2 - 1.18.0 false",
+		"1.20.0 step 1 - 1.20.0 false",
+		"1.20.0 step 2 - 1.20.0 false",
+		"1.20.0 step 3 - 1.20.0 false",
+		"1.21.0 state step 1 - 1.21.0 true",
+		"1.21.0 state step 2 - 1.21.0 true",
+		"1.21.0 step 1 - 1.21.0 false",
+	})
+}
+
+func (s *upgradeSuite) TestCheckUpgrade(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, stateUpgradeOperations)
+	s.PatchValue(upgrades.UpgradeOperations, upgradeOperations)
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.21.0"))
+	ctx := &mockContext{state: &mockStateBackend{}}
+
+	results := upgrades.CheckUpgrade(version.MustParse("1.18.0"), []upgrades.Target{upgrades.Controller}, ctx)
+	var checked []string
+	for _, result := range results {
+		checked = append(checked, fmt.Sprintf("%s: %v %v", result.Description, result.Checked, result.Err))
+	}
+	// Only the state steps for the given targets are run.
+	c.Check(checked, jc.DeepEquals, []string{
+		"step 1 - 1.20.0: false <nil>",
+		"step 2 - 1.20.0: false <nil>",
+		"step 3 - 1.20.0: false <nil>",
+		"state step 1 - 1.21.0: false <nil>",
+		"state step 2 - 1.21.0: true <nil>",
+		"step 1 - 1.21.0: false <nil>",
+	})
+	c.Check(ctx.messages, jc.DeepEquals, []string{"state step 2 - 1.21.0"})
+}
+
+func (s *upgradeSuite) TestCheckUpgradeContinuesAfterFailure(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, stateUpgradeOperations)
+	s.PatchValue(upgrades.UpgradeOperations, func() []upgrades.Operation { return nil })
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.11.0"))
+	ctx := &mockContext{state: &mockStateBackend{}}
+
+	results := upgrades.CheckUpgrade(version.MustParse("1.10.0"), []upgrades.Target{upgrades.Controller}, ctx)
+	var checked []string
+	for _, result := range results {
+		checked = append(checked, fmt.Sprintf("%s: %v", result.Description, result.Err))
+	}
+	c.Check(checked, jc.DeepEquals, []string{
+		"state step 1 - 1.11.0: <nil>",
+		"state step 2 error: upgrade error occurred",
+		"state step 3 - 1.11.0: <nil>",
+	})
+}
+
+func (s *upgradeSuite) TestCheckUpgradeSkipsExternalSteps(c *gc.C) {
+	s.PatchValue(upgrades.StateUpgradeOperations, func() []upgrades.Operation {
+		return []upgrades.Operation{&mockUpgradeOperation{
+			targetVersion: version.MustParse("1.11.0"),
+			steps: []upgrades.Step{
+				upgrades.NewExternalStep("external step", targets(upgrades.Controller), func(upgrades.Context) error {
+					c.Fatalf("external step run")
+					return nil
+				}),
+				newUpgradeStep("state step", upgrades.Controller),
+			},
+		}}
+	})
+	s.PatchValue(upgrades.UpgradeOperations, func() []upgrades.Operation { return nil })
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.11.0"))
+	ctx := &mockContext{state: &mockStateBackend{}}
+
+	results := upgrades.CheckUpgrade(version.MustParse("1.10.0"), []upgrades.Target{upgrades.Controller}, ctx)
+	var checked []string
+	for _, result := range results {
+		checked = append(checked, fmt.Sprintf("%s: %v", result.Description, result.Checked))
+	}
+	c.Check(checked, jc.DeepEquals, []string{
+		"external step: false",
+		"state step: true",
+	})
+	c.Check(ctx.messages, jc.DeepEquals, []string{"state step"})
+}
@@ -36,6 +36,11 @@ type K8sBroker interface {
 	// IsLegacyLabels reports if the k8s broker requires legacy labels to be
 	// used for the broker model/namespace
 	IsLegacyLabels() bool
+
+	// IsAdoptedNamespace reports if the broker's model was attached to an
+	// existing namespace, where Juju doesn't manage cluster wide resources
+	// such as webhook configurations.
+	IsAdoptedNamespace() bool
 }
 
 // Logger represents the methods used by the worker to log details
@@ -102,6 +107,10 @@ func (c ManifoldConfig) Start(context dependency.Context) (worker.Worker, error)
 	if err := context.Get(c.BrokerName, &broker); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if broker.IsAdoptedNamespace() {
+		c.Logger.Infof("not running admission webhook for model in adopted namespace %q", broker.GetCurrentNamespace())
+		return nil, dependency.ErrUninstall
+	}
 
 	var rbacMapper caasrbacmapper.Mapper
 	if err := context.Get(c.RBACMapperName, &rbacMapper); err != nil {
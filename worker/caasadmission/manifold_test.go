@@ -0,0 +1,109 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasadmission_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/dependency"
+	dt "github.com/juju/worker/v2/dependency/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/pki"
+	"github.com/juju/juju/worker/caasadmission"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+
+	broker fakeBroker
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+type fakeAgent struct {
+	agent.Agent
+}
+
+type fakeAuthority struct {
+	pki.Authority
+}
+
+type fakeBroker struct {
+	caasadmission.K8sBroker
+	adopted bool
+}
+
+func (b *fakeBroker) IsAdoptedNamespace() bool {
+	return b.adopted
+}
+
+func (b *fakeBroker) GetCurrentNamespace() string {
+	return "test"
+}
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.broker = fakeBroker{}
+}
+
+func (s *ManifoldSuite) validConfig() caasadmission.ManifoldConfig {
+	return caasadmission.ManifoldConfig{
+		AgentName:        "agent",
+		AuthorityName:    "authority",
+		BrokerName:       "broker",
+		Logger:           loggo.GetLogger("test"),
+		MuxName:          "mux",
+		RBACMapperName:   "rbac-mapper",
+		ServerInfoName:   "server-info",
+		ServiceName:      "modeloperator",
+		ServiceNamespace: "test",
+	}
+}
+
+// newContext returns a context with just the agent, authority and broker;
+// the rbac mapper is missing, so the manifold can't get past the broker
+// without reporting it.
+func (s *ManifoldSuite) newContext() dependency.Context {
+	return dt.StubContext(nil, map[string]interface{}{
+		"agent":       &fakeAgent{},
+		"authority":   &fakeAuthority{},
+		"broker":      &s.broker,
+		"rbac-mapper": dependency.ErrMissing,
+	})
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := caasadmission.Manifold(s.validConfig())
+	c.Assert(manifold.Inputs, jc.SameContents, []string{
+		"agent", "authority", "broker", "rbac-mapper", "mux", "server-info",
+	})
+}
+
+func (s *ManifoldSuite) TestMissingServiceName(c *gc.C) {
+	config := s.validConfig()
+	config.ServiceName = ""
+	manifold := caasadmission.Manifold(config)
+	w, err := manifold.Start(s.newContext())
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "empty ServiceName not valid")
+}
+
+func (s *ManifoldSuite) TestAdoptedNamespaceUninstalls(c *gc.C) {
+	s.broker.adopted = true
+	manifold := caasadmission.Manifold(s.validConfig())
+	w, err := manifold.Start(s.newContext())
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.Equals, dependency.ErrUninstall)
+}
+
+func (s *ManifoldSuite) TestNotAdoptedNamespaceContinues(c *gc.C) {
+	manifold := caasadmission.Manifold(s.validConfig())
+	w, err := manifold.Start(s.newContext())
+	c.Assert(w, gc.IsNil)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
@@ -226,6 +226,14 @@ func (a *appWorker) loop() error {
 			if err := a.ensureTrust(app); err != nil {
 				return err
 			}
+			// The trust watcher fires on any change to the application
+			// config, which also holds the update strategy and
+			// disruption budget, so ensure those are up to date.
+			if appLife == life.Alive {
+				if err := a.alive(app); err != nil {
+					return errors.Trace(err)
+				}
+			}
 		case <-a.catacomb.Dying():
 			return a.catacomb.ErrDying()
 		case <-appStateChanges:
@@ -290,9 +298,16 @@ func (a *appWorker) updateState(app caas.Application, force bool, lastReportedSt
 	}
 
 	reportedStatus := make(map[string]status.StatusInfo)
+	// The status reported by the cloud is always sent, even when it
+	// is empty, so the application status set by the charm is restored
+	// once the cloud no longer reports a problem.
 	args := params.UpdateApplicationUnits{
 		ApplicationTag: names.NewApplicationTag(a.name).String(),
-		Status:         params.EntityStatus{},
+		Status: params.EntityStatus{
+			Status: st.Status.Status,
+			Info:   st.Status.Message,
+			Data:   st.Status.Data,
+		},
 	}
 	for _, u := range units {
 		// For pods managed by the substrate, any marked as dying
@@ -315,13 +330,14 @@ func (a *appWorker) updateState(app caas.Application, force bool, lastReportedSt
 			}
 		}
 		unitParams := params.ApplicationUnitParams{
-			ProviderId: u.Id,
-			Address:    u.Address,
-			Ports:      u.Ports,
-			Stateful:   u.Stateful,
-			Status:     unitStatus.Status.String(),
-			Info:       unitStatus.Message,
-			Data:       unitStatus.Data,
+			ProviderId:       u.Id,
+			Address:          u.Address,
+			NetworkAddresses: u.NetworkAddresses,
+			Ports:            u.Ports,
+			Stateful:         u.Stateful,
+			Status:           unitStatus.Status.String(),
+			Info:             unitStatus.Message,
+			Data:             unitStatus.Data,
 		}
 		// Fill in any filesystem info for volumes attached to the unit.
 		// A unit will not become active until all required volumes are
@@ -471,7 +487,8 @@ func (a *appWorker) alive(app caas.Application) error {
 	containers := make(map[string]caas.ContainerConfig)
 	for k, v := range ch.Meta().Containers {
 		container := caas.ContainerConfig{
-			Name: k,
+			Name:      k,
+			Resources: provisionInfo.ContainerResources[k],
 		}
 		if v.Resource == "" {
 			return errors.NotValidf("empty container resource reference")
@@ -492,18 +509,23 @@ func (a *appWorker) alive(app caas.Application) error {
 
 	// TODO(embedded): container.Mounts[*].Path <= consolidate? => provisionInfo.Filesystems[*].Attachment.Path
 	config := caas.ApplicationConfig{
-		IntroductionSecret:   a.password,
-		AgentVersion:         provisionInfo.Version,
-		AgentImagePath:       provisionInfo.ImagePath,
-		ControllerAddresses:  strings.Join(provisionInfo.APIAddresses, ","),
-		ControllerCertBundle: provisionInfo.CACert,
-		ResourceTags:         provisionInfo.Tags,
-		Constraints:          provisionInfo.Constraints,
-		Filesystems:          provisionInfo.Filesystems,
-		Devices:              provisionInfo.Devices,
-		CharmBaseImage:       charmBaseImage,
-		Containers:           containers,
-		CharmModifiedVersion: provisionInfo.CharmModifiedVersion,
+		IntroductionSecret:      a.password,
+		AgentVersion:            provisionInfo.Version,
+		AgentImagePath:          provisionInfo.ImagePath,
+		IsPrivateImageRepo:      provisionInfo.IsPrivateImageRepo,
+		ControllerAddresses:     strings.Join(provisionInfo.APIAddresses, ","),
+		ControllerCertBundle:    provisionInfo.CACert,
+		ResourceTags:            provisionInfo.Tags,
+		Constraints:             provisionInfo.Constraints,
+		Filesystems:             provisionInfo.Filesystems,
+		Devices:                 provisionInfo.Devices,
+		CharmBaseImage:          charmBaseImage,
+		Containers:              containers,
+		CharmContainerResources: provisionInfo.ContainerResources[caas.CharmContainerName],
+		CharmModifiedVersion:    provisionInfo.CharmModifiedVersion,
+		NetworkAttachments:      provisionInfo.NetworkAttachments,
+		UpdateStrategy:          provisionInfo.UpdateStrategy,
+		DisruptionBudget:        provisionInfo.DisruptionBudget,
 	}
 	reason := "unchanged"
 	// TODO(embedded): implement Equals method for caas.ApplicationConfig
@@ -187,6 +187,10 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 			return caas.ApplicationState{
 				DesiredReplicas: 1,
 				Replicas:        []string{"test-0"},
+				Status: status.StatusInfo{
+					Status:  status.Blocked,
+					Message: "failed to create pods: exceeded quota",
+				},
 			}, nil
 		}),
 		facade.EXPECT().GarbageCollect("test", []names.Tag{names.NewUnitTag("test/0")}, 1, []string{"test-0"}, false).DoAndReturn(func(appName string, observedUnits []names.Tag, desiredReplicas int, activePodNames []string, force bool) error {
@@ -222,7 +226,10 @@ func (s *ApplicationWorkerSuite) TestWorker(c *gc.C) {
 		}}, nil),
 		facade.EXPECT().UpdateUnits(params.UpdateApplicationUnits{
 			ApplicationTag: "application-test",
-			Status:         params.EntityStatus{},
+			Status: params.EntityStatus{
+				Status: status.Blocked,
+				Info:   "failed to create pods: exceeded quota",
+			},
 			Units: []params.ApplicationUnitParams{{
 				ProviderId: "test-0",
 				Address:    "10.10.10.1",
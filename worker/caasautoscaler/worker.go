@@ -0,0 +1,244 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	api "github.com/juju/juju/api/caasautoscaler"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
+
+const (
+	// DefaultInterval is how often the applications' usage is
+	// checked against their autoscaling policies.
+	DefaultInterval = 30 * time.Second
+
+	// DefaultScaleDownStabilisation is how long an application must
+	// have been recommended fewer units before it is scaled down,
+	// so that brief dips in usage don't remove units.
+	DefaultScaleDownStabilisation = 5 * time.Minute
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Facade exposes the CAASAutoscaler facade functionality to the worker.
+type Facade interface {
+	AutoscaledApplications() ([]api.AutoscaledApplication, error)
+	SetScale(appName string, scale int) error
+}
+
+// Broker exposes the CAAS broker functionality to the worker.
+type Broker interface {
+	Application(string, caas.DeploymentType) caas.Application
+}
+
+// Config defines the operation of the worker.
+type Config struct {
+	Facade Facade
+	Broker Broker
+	Clock  clock.Clock
+	Logger Logger
+
+	// Interval is how often the applications are checked.
+	Interval time.Duration
+
+	// ScaleDownStabilisation is how far back the worker looks at the
+	// scale it recommended for an application before scaling it
+	// down. The largest recommendation in that time is used.
+	ScaleDownStabilisation time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Broker == nil {
+		return errors.NotValidf("nil Broker")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.ScaleDownStabilisation < 0 {
+		return errors.NotValidf("negative ScaleDownStabilisation")
+	}
+	return nil
+}
+
+// recommendation is the scale recommended for an application at a
+// point in time.
+type recommendation struct {
+	time  time.Time
+	scale int
+}
+
+// autoscaler periodically compares the usage of the autoscaled
+// applications in a model with their policies, and scales them through
+// Juju so that units are added and removed with the usual lifecycle.
+type autoscaler struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// recommendations holds the recent recommendations for each
+	// application, oldest first.
+	recommendations map[string][]recommendation
+}
+
+// NewWorker returns a worker that autoscales the applications in a
+// model according to their policies.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &autoscaler{
+		config:          config,
+		recommendations: make(map[string][]recommendation),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *autoscaler) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *autoscaler) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *autoscaler) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+			if err := w.autoscale(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// autoscale scales each of the autoscaled applications whose usage has
+// moved away from its policy's targets.
+func (w *autoscaler) autoscale() error {
+	apps, err := w.config.Facade.AutoscaledApplications()
+	if err != nil {
+		return errors.Annotate(err, "getting autoscaled applications")
+	}
+	autoscaled := make(map[string]bool)
+	for _, app := range apps {
+		autoscaled[app.Name] = true
+		scale := w.desiredScale(app)
+		if scale == app.Scale {
+			continue
+		}
+		w.config.Logger.Infof("scaling application %q from %d to %d units", app.Name, app.Scale, scale)
+		if err := w.config.Facade.SetScale(app.Name, scale); err != nil {
+			// The application may have been removed, or its
+			// policy cleared, since it was listed.
+			w.config.Logger.Warningf("scaling application %q: %v", app.Name, err)
+		}
+	}
+	for name := range w.recommendations {
+		if !autoscaled[name] {
+			delete(w.recommendations, name)
+		}
+	}
+	return nil
+}
+
+// desiredScale returns the number of units the application should
+// have, given the usage of its units.
+func (w *autoscaler) desiredScale(app api.AutoscaledApplication) int {
+	var usage application.AutoscaleUsage
+	if app.Err != nil {
+		w.config.Logger.Warningf("getting metric %q for application %q: %v", app.Policy.Metric, app.Name, app.Err)
+	} else {
+		usage.Metric = app.MetricAverage
+	}
+	if app.Policy.TargetCPU > 0 || app.Policy.TargetMemory > 0 {
+		utilisation, err := w.config.Broker.Application(app.Name, caas.DeploymentStateful).Utilisation()
+		if err != nil {
+			w.config.Logger.Warningf("getting resource usage for application %q: %v", app.Name, err)
+		} else if utilisation.Units == app.Scale {
+			// Usage is only used once every unit is reporting, so
+			// that units which are starting or stopping don't skew
+			// the average.
+			usage.CPU = utilisation.CPU
+			usage.Memory = utilisation.Memory
+		} else {
+			w.config.Logger.Debugf(
+				"application %q has %d of %d units reporting resource usage",
+				app.Name, utilisation.Units, app.Scale,
+			)
+		}
+	}
+	scale := app.Policy.DesiredScale(app.Scale, usage)
+	return w.stabilise(app, scale)
+}
+
+// stabilise records the recommended scale for the application, and
+// returns the scale to apply. Scaling down uses the largest scale
+// recommended within the stabilisation window.
+func (w *autoscaler) stabilise(app api.AutoscaledApplication, scale int) int {
+	now := w.config.Clock.Now()
+	cutoff := now.Add(-w.config.ScaleDownStabilisation)
+	recommendations, ok := w.recommendations[app.Name]
+	if !ok {
+		// The recommendations made before the worker started, or
+		// before the application was autoscaled, are unknown, so
+		// the current scale is treated as recommended now. This
+		// stops a restart from scaling the application down before
+		// a full window has passed.
+		recommendations = []recommendation{{time: now, scale: app.Scale}}
+	}
+	var recent []recommendation
+	for _, r := range recommendations {
+		if r.time.After(cutoff) {
+			recent = append(recent, r)
+		}
+	}
+	w.recommendations[app.Name] = append(recent, recommendation{time: now, scale: scale})
+
+	if scale >= app.Scale {
+		return scale
+	}
+	for _, r := range recent {
+		if r.scale > scale {
+			scale = r.scale
+		}
+	}
+	if scale > app.Scale {
+		scale = app.Scale
+	}
+	if scale > app.Policy.MaxUnits {
+		scale = app.Policy.MaxUnits
+	}
+	return scale
+}
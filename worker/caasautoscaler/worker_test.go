@@ -0,0 +1,297 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasautoscaler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	api "github.com/juju/juju/api/caasautoscaler"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/caasautoscaler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	clock  *testclock.Clock
+	facade *mockFacade
+	broker *mockBroker
+	config caasautoscaler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.facade = &mockFacade{scaled: make(chan scaleCall, 10)}
+	s.broker = &mockBroker{utilisation: make(map[string]caas.ApplicationUtilisation)}
+	s.config = caasautoscaler.Config{
+		Facade:                 s.facade,
+		Broker:                 s.broker,
+		Clock:                  s.clock,
+		Logger:                 loggo.GetLogger("test"),
+		Interval:               time.Minute,
+		ScaleDownStabilisation: 5 * time.Minute,
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		mutate func(*caasautoscaler.Config)
+		err    string
+	}{
+		{func(cfg *caasautoscaler.Config) { cfg.Facade = nil }, "nil Facade not valid"},
+		{func(cfg *caasautoscaler.Config) { cfg.Broker = nil }, "nil Broker not valid"},
+		{func(cfg *caasautoscaler.Config) { cfg.Clock = nil }, "nil Clock not valid"},
+		{func(cfg *caasautoscaler.Config) { cfg.Logger = nil }, "nil Logger not valid"},
+		{func(cfg *caasautoscaler.Config) { cfg.Interval = 0 }, "non-positive Interval not valid"},
+		{func(cfg *caasautoscaler.Config) { cfg.ScaleDownStabilisation = -1 }, "negative ScaleDownStabilisation not valid"},
+	} {
+		c.Logf("test %d: %s", i, test.err)
+		config := s.config
+		test.mutate(&config)
+		c.Check(config.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) {
+	w, err := caasautoscaler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+// tick advances the clock to the next check and waits for the check
+// to list the applications.
+func (s *WorkerSuite) tick(c *gc.C) {
+	err := s.clock.WaitAdvance(s.config.Interval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-s.facade.listed():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for applications to be listed")
+	}
+}
+
+func (s *WorkerSuite) assertScaled(c *gc.C, expect scaleCall) {
+	select {
+	case call := <-s.facade.scaled:
+		c.Assert(call, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %q to be scaled", expect.name)
+	}
+}
+
+func (s *WorkerSuite) assertNotScaled(c *gc.C) {
+	select {
+	case call := <-s.facade.scaled:
+		c.Fatalf("unexpected scale %+v", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestScaleUpOnCPU(c *gc.C) {
+	s.facade.setApps(api.AutoscaledApplication{
+		Name:   "gitlab",
+		Policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 10, TargetCPU: 50},
+		Scale:  2,
+	})
+	s.broker.utilisation["gitlab"] = caas.ApplicationUtilisation{Units: 2, CPU: floatPtr(100)}
+	s.startWorker(c)
+
+	s.tick(c)
+	s.assertScaled(c, scaleCall{"gitlab", 4})
+}
+
+func (s *WorkerSuite) TestScaleUpOnMetric(c *gc.C) {
+	s.facade.setApps(api.AutoscaledApplication{
+		Name:          "rabbitmq",
+		Policy:        application.AutoscalePolicy{MinUnits: 1, MaxUnits: 10, Metric: "queue-length", TargetMetric: 100},
+		Scale:         2,
+		MetricAverage: floatPtr(250),
+	})
+	s.startWorker(c)
+
+	s.tick(c)
+	s.assertScaled(c, scaleCall{"rabbitmq", 5})
+	c.Assert(s.broker.called, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestUnitsNotSettled(c *gc.C) {
+	s.facade.setApps(api.AutoscaledApplication{
+		Name:   "gitlab",
+		Policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 10, TargetCPU: 50},
+		Scale:  4,
+	})
+	s.broker.utilisation["gitlab"] = caas.ApplicationUtilisation{Units: 2, CPU: floatPtr(100)}
+	s.startWorker(c)
+
+	s.tick(c)
+	s.assertNotScaled(c)
+}
+
+func (s *WorkerSuite) TestUtilisationError(c *gc.C) {
+	s.facade.setApps(api.AutoscaledApplication{
+		Name:   "gitlab",
+		Policy: application.AutoscalePolicy{MinUnits: 3, MaxUnits: 10, TargetCPU: 50},
+		Scale:  1,
+	})
+	s.broker.err = errors.New("metrics server not found")
+	s.startWorker(c)
+
+	// Without usage, the application is still kept within bounds.
+	s.tick(c)
+	s.assertScaled(c, scaleCall{"gitlab", 3})
+}
+
+func (s *WorkerSuite) TestScaleDownStabilised(c *gc.C) {
+	s.facade.setApps(api.AutoscaledApplication{
+		Name:   "gitlab",
+		Policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 10, TargetCPU: 50},
+		Scale:  4,
+	})
+	s.broker.utilisation["gitlab"] = caas.ApplicationUtilisation{Units: 4, CPU: floatPtr(50)}
+	s.startWorker(c)
+
+	s.tick(c)
+	s.assertNotScaled(c)
+
+	// Usage drops, but the earlier recommendation of 4 units is still
+	// within the stabilisation window.
+	s.broker.setUtilisation("gitlab", caas.ApplicationUtilisation{Units: 4, CPU: floatPtr(25)})
+	for i := 0; i < 4; i++ {
+		s.tick(c)
+		s.assertNotScaled(c)
+	}
+
+	// Once it falls out of the window, the application is scaled down.
+	s.tick(c)
+	s.assertScaled(c, scaleCall{"gitlab", 2})
+}
+
+func (s *WorkerSuite) TestScaleDownStabilisedAfterStart(c *gc.C) {
+	s.facade.setApps(api.AutoscaledApplication{
+		Name:   "gitlab",
+		Policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 10, TargetCPU: 50},
+		Scale:  4,
+	})
+	s.broker.utilisation["gitlab"] = caas.ApplicationUtilisation{Units: 4, CPU: floatPtr(25)}
+	s.startWorker(c)
+
+	// The current scale counts as recommended when the worker
+	// starts, so usage that was low all along doesn't scale the
+	// application down until a full window has passed.
+	for i := 0; i < 5; i++ {
+		s.tick(c)
+		s.assertNotScaled(c)
+	}
+	s.tick(c)
+	s.assertScaled(c, scaleCall{"gitlab", 2})
+}
+
+func (s *WorkerSuite) TestListError(c *gc.C) {
+	s.facade.setErr(errors.New("boom"))
+	w, err := caasautoscaler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.tick(c)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting autoscaled applications: boom")
+}
+
+type scaleCall struct {
+	name  string
+	scale int
+}
+
+type mockFacade struct {
+	mu       sync.Mutex
+	apps     []api.AutoscaledApplication
+	err      error
+	listedCh chan struct{}
+	scaled   chan scaleCall
+}
+
+func (f *mockFacade) setApps(apps ...api.AutoscaledApplication) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.apps = apps
+}
+
+func (f *mockFacade) setErr(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+func (f *mockFacade) listed() <-chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.listedCh == nil {
+		f.listedCh = make(chan struct{}, 10)
+	}
+	return f.listedCh
+}
+
+func (f *mockFacade) AutoscaledApplications() ([]api.AutoscaledApplication, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.listedCh == nil {
+		f.listedCh = make(chan struct{}, 10)
+	}
+	f.listedCh <- struct{}{}
+	return f.apps, f.err
+}
+
+func (f *mockFacade) SetScale(appName string, scale int) error {
+	f.scaled <- scaleCall{appName, scale}
+	return nil
+}
+
+type mockBroker struct {
+	mu          sync.Mutex
+	utilisation map[string]caas.ApplicationUtilisation
+	err         error
+	called      []string
+}
+
+func (b *mockBroker) setUtilisation(name string, u caas.ApplicationUtilisation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.utilisation[name] = u
+}
+
+func (b *mockBroker) Application(name string, deploymentType caas.DeploymentType) caas.Application {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.called = append(b.called, name)
+	return &mockApplication{broker: b, name: name}
+}
+
+type mockApplication struct {
+	caas.Application
+	broker *mockBroker
+	name   string
+}
+
+func (a *mockApplication) Utilisation() (caas.ApplicationUtilisation, error) {
+	a.broker.mu.Lock()
+	defer a.broker.mu.Unlock()
+	return a.broker.utilisation[a.name], a.broker.err
+}
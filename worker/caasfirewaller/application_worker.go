@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"reflect"
 	"strings"
 
 	"github.com/juju/errors"
@@ -11,6 +12,8 @@ import (
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/catacomb"
 
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs/tags"
 )
 
@@ -21,12 +24,19 @@ type applicationWorker struct {
 	application       string
 	applicationGetter ApplicationGetter
 	serviceExposer    ServiceExposer
+	policyManager     NetworkPolicyManager
 
 	lifeGetter LifeGetter
 
 	initial           bool
 	previouslyExposed bool
 
+	// policy and policyConfig hold the network policy last applied
+	// for the application, and the application config it was
+	// applied with.
+	policy       *caas.NetworkPolicyParams
+	policyConfig application.ConfigAttributes
+
 	logger Logger
 }
 
@@ -36,6 +46,7 @@ func newApplicationWorker(
 	application string,
 	applicationGetter ApplicationGetter,
 	applicationExposer ServiceExposer,
+	policyManager NetworkPolicyManager,
 	lifeGetter LifeGetter,
 	logger Logger,
 ) (worker.Worker, error) {
@@ -45,6 +56,7 @@ func newApplicationWorker(
 		application:       application,
 		applicationGetter: applicationGetter,
 		serviceExposer:    applicationExposer,
+		policyManager:     policyManager,
 		lifeGetter:        lifeGetter,
 		initial:           true,
 		logger:            logger,
@@ -122,6 +134,13 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := w.processExposedChange(exposed); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.processNetworkPolicy(exposed))
+}
+
+func (w *applicationWorker) processExposedChange(exposed bool) error {
 	if !w.initial && exposed == w.previouslyExposed {
 		return nil
 	}
@@ -147,3 +166,30 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	}
 	return nil
 }
+
+// processNetworkPolicy updates the network policy of the application
+// if its relations, exposure or config have changed since the policy
+// was last applied.
+func (w *applicationWorker) processNetworkPolicy(exposed bool) error {
+	related, err := w.applicationGetter.RelatedApplications(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy := caas.NetworkPolicyParams{
+		RelatedApplications: related,
+		Exposed:             exposed,
+	}
+	if w.policy != nil && reflect.DeepEqual(*w.policy, policy) && reflect.DeepEqual(w.policyConfig, appConfig) {
+		return nil
+	}
+	if err := w.policyManager.EnsureNetworkPolicy(w.application, policy, appConfig); err != nil {
+		return errors.Trace(err)
+	}
+	w.policy = &policy
+	w.policyConfig = appConfig
+	return nil
+}
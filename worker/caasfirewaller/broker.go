@@ -3,9 +3,18 @@
 
 package caasfirewaller
 
-import "github.com/juju/juju/core/application"
+import (
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
 
 type ServiceExposer interface {
 	ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error
 	UnexposeService(appName string) error
 }
+
+// NetworkPolicyManager provides an interface for keeping the network
+// policy of an application in sync with its relations.
+type NetworkPolicyManager interface {
+	EnsureNetworkPolicy(appName string, params caas.NetworkPolicyParams, config application.ConfigAttributes) error
+}
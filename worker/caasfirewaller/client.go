@@ -26,6 +26,7 @@ type ApplicationGetter interface {
 	WatchApplication(string) (watcher.NotifyWatcher, error)
 	IsExposed(string) (bool, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
+	RelatedApplications(string) ([]string, error)
 }
 
 // LifeGetter provides an interface for getting the
@@ -19,12 +19,13 @@ var _ logger = struct{}{}
 
 // Config holds configuration for the CAAS unit firewaller worker.
 type Config struct {
-	ControllerUUID    string
-	ModelUUID         string
-	ApplicationGetter ApplicationGetter
-	LifeGetter        LifeGetter
-	ServiceExposer    ServiceExposer
-	Logger            Logger
+	ControllerUUID       string
+	ModelUUID            string
+	ApplicationGetter    ApplicationGetter
+	LifeGetter           LifeGetter
+	ServiceExposer       ServiceExposer
+	NetworkPolicyManager NetworkPolicyManager
+	Logger               Logger
 }
 
 // Validate validates the worker configuration.
@@ -44,6 +45,9 @@ func (config Config) Validate() error {
 	if config.LifeGetter == nil {
 		return errors.NotValidf("missing LifeGetter")
 	}
+	if config.NetworkPolicyManager == nil {
+		return errors.NotValidf("missing NetworkPolicyManager")
+	}
 	if config.Logger == nil {
 		return errors.NotValidf("missing Logger")
 	}
@@ -123,6 +127,7 @@ func (p *firewaller) loop() error {
 					appID,
 					p.config.ApplicationGetter,
 					p.config.ServiceExposer,
+					p.config.NetworkPolicyManager,
 					p.config.LifeGetter,
 					logger,
 				)
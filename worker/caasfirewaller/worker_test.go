@@ -13,6 +13,7 @@ import (
 	"github.com/juju/worker/v2/workertest"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher/watchertest"
@@ -26,12 +27,14 @@ type WorkerSuite struct {
 	config            caasfirewaller.Config
 	applicationGetter mockApplicationGetter
 	serviceExposer    mockServiceExposer
+	policyManager     mockNetworkPolicyManager
 	lifeGetter        mockLifeGetter
 
 	applicationChanges chan []string
 	appExposedChange   chan struct{}
 	serviceExposed     chan struct{}
 	serviceUnexposed   chan struct{}
+	policyEnsured      chan struct{}
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -43,6 +46,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.appExposedChange = make(chan struct{})
 	s.serviceExposed = make(chan struct{})
 	s.serviceUnexposed = make(chan struct{})
+	s.policyEnsured = make(chan struct{}, 10)
 
 	s.applicationGetter = mockApplicationGetter{
 		allWatcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
@@ -57,14 +61,18 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		exposed:   s.serviceExposed,
 		unexposed: s.serviceUnexposed,
 	}
+	s.policyManager = mockNetworkPolicyManager{
+		ensured: s.policyEnsured,
+	}
 
 	s.config = caasfirewaller.Config{
-		ControllerUUID:    coretesting.ControllerTag.Id(),
-		ModelUUID:         coretesting.ModelTag.Id(),
-		ApplicationGetter: &s.applicationGetter,
-		ServiceExposer:    &s.serviceExposer,
-		LifeGetter:        &s.lifeGetter,
-		Logger:            loggo.GetLogger("test"),
+		ControllerUUID:       coretesting.ControllerTag.Id(),
+		ModelUUID:            coretesting.ModelTag.Id(),
+		ApplicationGetter:    &s.applicationGetter,
+		ServiceExposer:       &s.serviceExposer,
+		NetworkPolicyManager: &s.policyManager,
+		LifeGetter:           &s.lifeGetter,
+		Logger:               loggo.GetLogger("test"),
 	}
 }
 
@@ -97,6 +105,10 @@ func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 		config.LifeGetter = nil
 	}, `missing LifeGetter not valid`)
 
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.NetworkPolicyManager = nil
+	}, `missing NetworkPolicyManager not valid`)
+
 	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
 		config.Logger = nil
 	}, `missing Logger not valid`)
@@ -194,6 +206,56 @@ func (s *WorkerSuite) TestUnexposedChange(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) TestNetworkPolicyChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.related = []string{"mariadb"}
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+	select {
+	case <-s.policyEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy")
+	}
+
+	// An unrelated change to the application does not
+	// result in the network policy being updated.
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.policyEnsured:
+		c.Fatal("network policy updated unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	s.applicationGetter.related = []string{"mariadb", "redis"}
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.policyEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy")
+	}
+
+	s.policyManager.CheckCallNames(c, "EnsureNetworkPolicy", "EnsureNetworkPolicy")
+	s.policyManager.CheckCall(c, 0, "EnsureNetworkPolicy", "gitlab",
+		caas.NetworkPolicyParams{RelatedApplications: []string{"mariadb"}},
+		application.ConfigAttributes{"juju-external-hostname": "exthost"})
+	s.policyManager.CheckCall(c, 1, "EnsureNetworkPolicy", "gitlab",
+		caas.NetworkPolicyParams{RelatedApplications: []string{"mariadb", "redis"}},
+		application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
 func (s *WorkerSuite) TestWatchApplicationDead(c *gc.C) {
 	w, err := caasfirewaller.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
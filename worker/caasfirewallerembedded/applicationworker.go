@@ -12,6 +12,7 @@ import (
 	"github.com/juju/worker/v2/catacomb"
 
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
 	corecharm "github.com/juju/juju/core/charm"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/watcher"
@@ -39,6 +40,12 @@ type applicationWorker struct {
 
 	currentPorts portRanges
 
+	// policy and policyConfig hold the network policy last applied
+	// for the application, and the application config it was
+	// applied with.
+	policy       *caas.NetworkPolicyParams
+	policyConfig application.ConfigAttributes
+
 	logger Logger
 }
 
@@ -224,6 +231,13 @@ func (w *applicationWorker) onApplicationChanged() (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := w.processExposedChange(exposed); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.processNetworkPolicy(exposed))
+}
+
+func (w *applicationWorker) processExposedChange(exposed bool) error {
 	if !w.initial && exposed == w.previouslyExposed {
 		return nil
 	}
@@ -236,6 +250,33 @@ func (w *applicationWorker) onApplicationChanged() (err error) {
 	return errors.Trace(unExposeService(w.serviceUpdater))
 }
 
+// processNetworkPolicy updates the network policy of the application
+// if its relations, exposure or config have changed since the policy
+// was last applied.
+func (w *applicationWorker) processNetworkPolicy(exposed bool) error {
+	related, err := w.firewallerAPI.RelatedApplications(w.appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appConfig, err := w.firewallerAPI.ApplicationConfig(w.appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy := caas.NetworkPolicyParams{
+		RelatedApplications: related,
+		Exposed:             exposed,
+	}
+	if w.policy != nil && reflect.DeepEqual(*w.policy, policy) && reflect.DeepEqual(w.policyConfig, appConfig) {
+		return nil
+	}
+	if err := w.broker.EnsureNetworkPolicy(w.appName, policy, appConfig); err != nil {
+		return errors.Trace(err)
+	}
+	w.policy = &policy
+	w.policyConfig = appConfig
+	return nil
+}
+
 func exposeService(app ServiceUpdater) error {
 	// TODO(embedded): implement expose once it's modelled.
 	// app.UpdateService()
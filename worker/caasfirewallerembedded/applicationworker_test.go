@@ -17,6 +17,7 @@ import (
 	charmscommon "github.com/juju/juju/api/common/charms"
 	"github.com/juju/juju/caas"
 	caasmocks "github.com/juju/juju/caas/mocks"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/testing"
@@ -118,8 +119,15 @@ func (s *appWorkerSuite) TestWorker(c *gc.C) {
 		s.portsChanges <- []string{"port changes"}
 
 		s.applicationChanges <- struct{}{}
+		// A change which doesn't alter the network policy.
+		s.applicationChanges <- struct{}{}
 	}()
 
+	appConfig := application.ConfigAttributes{"foo": "bar"}
+	policy := caas.NetworkPolicyParams{
+		RelatedApplications: []string{"mariadb"},
+	}
+
 	gomock.InOrder(
 		s.firewallerAPI.EXPECT().WatchApplication(s.appName).Return(s.appsWatcher, nil),
 		s.firewallerAPI.EXPECT().WatchOpenedPorts().Return(s.portsWatcher, nil),
@@ -127,9 +135,16 @@ func (s *appWorkerSuite) TestWorker(c *gc.C) {
 
 		s.broker.EXPECT().Application(s.appName, caas.DeploymentStateful).Return(s.brokerApp),
 
-		s.firewallerAPI.EXPECT().IsExposed(s.appName).DoAndReturn(func(_ string) (bool, error) {
+		s.firewallerAPI.EXPECT().IsExposed(s.appName).Return(false, nil),
+		s.firewallerAPI.EXPECT().RelatedApplications(s.appName).Return([]string{"mariadb"}, nil),
+		s.firewallerAPI.EXPECT().ApplicationConfig(s.appName).Return(appConfig, nil),
+		s.broker.EXPECT().EnsureNetworkPolicy(s.appName, policy, appConfig).Return(nil),
+
+		s.firewallerAPI.EXPECT().IsExposed(s.appName).Return(false, nil),
+		s.firewallerAPI.EXPECT().RelatedApplications(s.appName).Return([]string{"mariadb"}, nil),
+		s.firewallerAPI.EXPECT().ApplicationConfig(s.appName).DoAndReturn(func(_ string) (application.ConfigAttributes, error) {
 			close(done)
-			return false, nil
+			return appConfig, nil
 		}),
 	)
 
@@ -5,6 +5,7 @@ package caasfirewallerembedded
 
 import (
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
 )
 
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/broker_mock.go github.com/juju/juju/worker/caasfirewallerembedded CAASBroker,PortMutator,ServiceUpdater
@@ -12,6 +13,7 @@ import (
 // CAASBroker exposes CAAS broker functionality to a worker.
 type CAASBroker interface {
 	Application(string, caas.DeploymentType) caas.Application
+	EnsureNetworkPolicy(appName string, params caas.NetworkPolicyParams, config application.ConfigAttributes) error
 }
 
 // PortMutator exposes CAAS application functionality to a worker.
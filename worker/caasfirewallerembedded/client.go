@@ -33,6 +33,7 @@ type CAASFirewallerAPI interface {
 
 	IsExposed(string) (bool, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
+	RelatedApplications(string) ([]string, error)
 
 	ApplicationCharmInfo(appName string) (*charmscommon.CharmInfo, error)
 }
@@ -7,6 +7,7 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	caas "github.com/juju/juju/caas"
+	application "github.com/juju/juju/core/application"
 	reflect "reflect"
 )
 
@@ -47,6 +48,20 @@ func (mr *MockCAASBrokerMockRecorder) Application(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Application", reflect.TypeOf((*MockCAASBroker)(nil).Application), arg0, arg1)
 }
 
+// EnsureNetworkPolicy mocks base method
+func (m *MockCAASBroker) EnsureNetworkPolicy(arg0 string, arg1 caas.NetworkPolicyParams, arg2 application.ConfigAttributes) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureNetworkPolicy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureNetworkPolicy indicates an expected call of EnsureNetworkPolicy
+func (mr *MockCAASBrokerMockRecorder) EnsureNetworkPolicy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureNetworkPolicy", reflect.TypeOf((*MockCAASBroker)(nil).EnsureNetworkPolicy), arg0, arg1, arg2)
+}
+
 // MockPortMutator is a mock of PortMutator interface
 type MockPortMutator struct {
 	ctrl     *gomock.Controller
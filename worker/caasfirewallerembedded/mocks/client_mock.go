@@ -96,6 +96,21 @@ func (mr *MockClientMockRecorder) Life(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Life", reflect.TypeOf((*MockClient)(nil).Life), arg0)
 }
 
+// RelatedApplications mocks base method
+func (m *MockClient) RelatedApplications(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelatedApplications", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelatedApplications indicates an expected call of RelatedApplications
+func (mr *MockClientMockRecorder) RelatedApplications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelatedApplications", reflect.TypeOf((*MockClient)(nil).RelatedApplications), arg0)
+}
+
 // WatchApplication mocks base method
 func (m *MockClient) WatchApplication(arg0 string) (watcher.NotifyWatcher, error) {
 	m.ctrl.T.Helper()
@@ -209,6 +224,21 @@ func (mr *MockCAASFirewallerAPIMockRecorder) IsExposed(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsExposed", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).IsExposed), arg0)
 }
 
+// RelatedApplications mocks base method
+func (m *MockCAASFirewallerAPI) RelatedApplications(arg0 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelatedApplications", arg0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelatedApplications indicates an expected call of RelatedApplications
+func (mr *MockCAASFirewallerAPIMockRecorder) RelatedApplications(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelatedApplications", reflect.TypeOf((*MockCAASFirewallerAPI)(nil).RelatedApplications), arg0)
+}
+
 // WatchApplication mocks base method
 func (m *MockCAASFirewallerAPI) WatchApplication(arg0 string) (watcher.NotifyWatcher, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,8 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasmodeloperator
+
+// RefreshImageRepoAuth is the function the worker refreshes the image
+// repo's pull token with.
+var RefreshImageRepoAuth = &refreshImageRepoAuth
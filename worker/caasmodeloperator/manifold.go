@@ -4,6 +4,7 @@
 package caasmodeloperator
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
@@ -27,6 +28,8 @@ type ManifoldConfig struct {
 	APICallerName string
 	// BrokerName is the name of the api caller dependency to fetch
 	BrokerName string
+	// Clock is used to schedule refreshes of the image repo secret
+	Clock clock.Clock
 	// Logger to use in this worker
 	Logger Logger
 	// ModelUUID is the id of the model this worker is operating on
@@ -72,7 +75,7 @@ func (m ManifoldConfig) Start(context dependency.Context) (worker.Worker, error)
 	api := caasmodeloperator.NewClient(apiCaller)
 
 	return NewModelOperatorManager(
-		m.Logger, api, broker, m.ModelUUID, agent.CurrentConfig())
+		m.Logger, m.Clock, api, broker, m.ModelUUID, agent.CurrentConfig())
 }
 
 // Validate checks all the config fields are valid for the Manifold to start
@@ -86,6 +89,9 @@ func (m ManifoldConfig) Validate() error {
 	if m.BrokerName == "" {
 		return errors.NotValidf("empty BrokerName")
 	}
+	if m.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	if m.Logger == nil {
 		return errors.NotValidf("nil Logger")
 	}
@@ -4,6 +4,9 @@
 package caasmodeloperator
 
 import (
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/utils/v2"
@@ -13,6 +16,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/caasmodeloperator"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/docker"
 )
 
 type ModelOperatorAPI interface {
@@ -26,6 +30,7 @@ type ModelOperatorBroker interface {
 	EnsureModelOperator(string, string, *caas.ModelOperatorConfig) error
 	ModelOperator() (*caas.ModelOperatorConfig, error)
 	ModelOperatorExists() (bool, error)
+	EnsureImageRepoSecret(docker.ImageRepoDetails) error
 }
 
 // ModelOperatorManager defines the worker used for managing model operators in
@@ -35,6 +40,7 @@ type ModelOperatorManager struct {
 	api         ModelOperatorAPI
 	broker      ModelOperatorBroker
 	catacomb    catacomb.Catacomb
+	clock       clock.Clock
 	logger      Logger
 	modelUUID   string
 }
@@ -95,14 +101,20 @@ func (m *ModelOperatorManager) loop() error {
 		agentConfBuf = mo.AgentConf
 	}
 
+	refreshAfter, err := m.ensureImageRepoSecret(info.ImageRepo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	m.logger.Debugf("ensuring model operator deployment in kubernetes for model %s", m.modelUUID)
 	err = m.broker.EnsureModelOperator(
 		m.modelUUID,
 		m.agentConfig.DataDir(),
 		&caas.ModelOperatorConfig{
-			AgentConf:         agentConfBuf,
-			OperatorImagePath: info.ImagePath,
-			Port:              DefaultModelOperatorPort,
+			AgentConf:          agentConfBuf,
+			OperatorImagePath:  info.ImagePath,
+			IsPrivateImageRepo: info.ImageRepo != nil,
+			Port:               DefaultModelOperatorPort,
 		},
 	)
 
@@ -110,15 +122,58 @@ func (m *ModelOperatorManager) loop() error {
 		return errors.Annotate(err, "deploying model operator")
 	}
 
-	select {
-	case <-m.catacomb.Dying():
-		return m.catacomb.ErrDying()
+	for {
+		var refresh <-chan time.Time
+		if refreshAfter > 0 {
+			refresh = m.clock.After(refreshAfter)
+		}
+		select {
+		case <-m.catacomb.Dying():
+			return m.catacomb.ErrDying()
+		case <-refresh:
+			info, err := m.api.ModelOperatorProvisioningInfo()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if refreshAfter, err = m.ensureImageRepoSecret(info.ImageRepo); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// refreshImageRepoAuth refreshes the pull token for the image repo. It
+// is a variable so that tests can avoid calling the clouds' token
+// services.
+var refreshImageRepoAuth = docker.ImageRepoDetails.RefreshAuth
+
+// ensureImageRepoSecret ensures the secret for pulling images from the
+// controller's private image repo is up to date. If the secret holds a
+// short lived pull token, it returns how long until the token should be
+// refreshed, which is half of its lifetime.
+func (m *ModelOperatorManager) ensureImageRepoSecret(imageRepo *docker.ImageRepoDetails) (time.Duration, error) {
+	if imageRepo == nil {
+		return 0, nil
+	}
+	now := m.clock.Now()
+	refreshed, expiry, err := refreshImageRepoAuth(*imageRepo)
+	if err != nil {
+		return 0, errors.Annotate(err, "refreshing image repo credentials")
+	}
+	m.logger.Debugf("ensuring image repo secret for model %s", m.modelUUID)
+	if err := m.broker.EnsureImageRepoSecret(refreshed); err != nil {
+		return 0, errors.Annotate(err, "ensuring image repo secret")
+	}
+	if expiry.IsZero() {
+		return 0, nil
 	}
+	return expiry.Sub(now) / 2, nil
 }
 
 // NewModelOperatorManager constructs a new model operator manager worker
 func NewModelOperatorManager(
 	logger Logger,
+	clock clock.Clock,
 	api ModelOperatorAPI,
 	broker ModelOperatorBroker,
 	modelUUID string,
@@ -128,6 +183,7 @@ func NewModelOperatorManager(
 		agentConfig: agentConfig,
 		api:         api,
 		broker:      broker,
+		clock:       clock,
 		logger:      logger,
 		modelUUID:   modelUUID,
 	}
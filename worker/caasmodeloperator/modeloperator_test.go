@@ -4,13 +4,21 @@
 package caasmodeloperator_test
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/loggo"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version/v2"
 	gc "gopkg.in/check.v1"
 
 	modeloperatorapi "github.com/juju/juju/api/caasmodeloperator"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/docker"
+	"github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/caasmodeloperator"
 )
 
@@ -20,9 +28,10 @@ type dummyAPI struct {
 }
 
 type dummyBroker struct {
-	ensureModelOperator func(string, string, *caas.ModelOperatorConfig) error
-	modelOperator       func() (*caas.ModelOperatorConfig, error)
-	modelOperatorExists func() (bool, error)
+	ensureModelOperator   func(string, string, *caas.ModelOperatorConfig) error
+	modelOperator         func() (*caas.ModelOperatorConfig, error)
+	modelOperatorExists   func() (bool, error)
+	ensureImageRepoSecret func(docker.ImageRepoDetails) error
 }
 
 type ModelOperatorManagerSuite struct{}
@@ -50,6 +59,13 @@ func (b *dummyBroker) ModelOperatorExists() (bool, error) {
 	return b.modelOperatorExists()
 }
 
+func (b *dummyBroker) EnsureImageRepoSecret(imageRepo docker.ImageRepoDetails) error {
+	if b.ensureImageRepoSecret == nil {
+		return nil
+	}
+	return b.ensureImageRepoSecret(imageRepo)
+}
+
 func (a *dummyAPI) ModelOperatorProvisioningInfo() (modeloperatorapi.ModelOperatorProvisioningInfo, error) {
 	if a.provInfo == nil {
 		return modeloperatorapi.ModelOperatorProvisioningInfo{}, nil
@@ -93,7 +109,7 @@ func (m *ModelOperatorManagerSuite) TestModelOperatorManagerConstruction(c *gc.C
 		},
 	}
 
-	worker, err := caasmodeloperator.NewModelOperatorManager(loggo.Logger{},
+	worker, err := caasmodeloperator.NewModelOperatorManager(loggo.Logger{}, clock.WallClock,
 		api, broker, modelUUID, &mockAgentConfig{})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -104,3 +120,70 @@ func (m *ModelOperatorManagerSuite) TestModelOperatorManagerConstruction(c *gc.C
 	c.Assert(apiCalled, gc.Equals, true)
 	c.Assert(brokerCalled, gc.Equals, true)
 }
+
+func (m *ModelOperatorManagerSuite) TestImageRepoSecretRefreshed(c *gc.C) {
+	imageRepo := &docker.ImageRepoDetails{
+		Repository: "123456789012.dkr.ecr.eu-west-1.amazonaws.com/juju",
+		Username:   "access-key",
+		Password:   "secret-key",
+	}
+	api := &dummyAPI{
+		provInfo: func() (modeloperatorapi.ModelOperatorProvisioningInfo, error) {
+			return modeloperatorapi.ModelOperatorProvisioningInfo{
+				APIAddresses: []string{"fe80:abcd::1"},
+				ImagePath:    "juju/jujud:1",
+				Version:      version.MustParse("2.9.0"),
+				ImageRepo:    imageRepo,
+			}, nil
+		},
+	}
+
+	secrets := make(chan docker.ImageRepoDetails, 2)
+	broker := &dummyBroker{
+		ensureModelOperator: func(_, _ string, conf *caas.ModelOperatorConfig) error {
+			c.Check(conf.IsPrivateImageRepo, jc.IsTrue)
+			return nil
+		},
+		ensureImageRepoSecret: func(details docker.ImageRepoDetails) error {
+			secrets <- details
+			return nil
+		},
+	}
+
+	clk := testclock.NewClock(time.Date(2021, 5, 3, 0, 0, 0, 0, time.UTC))
+	refreshes := 0
+	restore := jujutesting.PatchValue(caasmodeloperator.RefreshImageRepoAuth,
+		func(rid docker.ImageRepoDetails) (docker.ImageRepoDetails, time.Time, error) {
+			refreshes++
+			rid.RegistryToken = fmt.Sprintf("token-%d", refreshes)
+			return rid, clk.Now().Add(12 * time.Hour), nil
+		},
+	)
+	defer restore()
+
+	worker, err := caasmodeloperator.NewModelOperatorManager(loggo.Logger{}, clk,
+		api, broker, "deadbeef-0bad-400d-8000-4b1d0d06f00d", &mockAgentConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		worker.Kill()
+		c.Assert(worker.Wait(), jc.ErrorIsNil)
+	}()
+
+	var first docker.ImageRepoDetails
+	select {
+	case first = <-secrets:
+		c.Assert(first.RegistryToken, gc.Equals, "token-1")
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for image repo secret")
+	}
+
+	// Tokens lasting 12 hours are refreshed after 6.
+	err = clk.WaitAdvance(6*time.Hour, testing.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case refreshed := <-secrets:
+		c.Assert(refreshed.RegistryToken, gc.Equals, "token-2")
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for image repo secret refresh")
+	}
+}
@@ -268,6 +268,7 @@ func (p *provisioner) updateOperatorConfig(appName, password string, prevCfg caa
 
 	cfg := &caas.OperatorConfig{
 		OperatorImagePath:   info.ImagePath,
+		IsPrivateImageRepo:  info.IsPrivateImageRepo,
 		Version:             info.Version,
 		ResourceTags:        info.Tags,
 		CharmStorage:        charmStorageParams(info.CharmStorage),
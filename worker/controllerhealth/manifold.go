@@ -0,0 +1,87 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth
+
+import (
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ManifoldConfig holds the resources needed to start a controller
+// health worker in a dependency engine.
+type ManifoldConfig struct {
+	RaftName       string
+	CentralHubName string
+
+	PubSubReporter     Reporter
+	PrometheusGatherer prometheus.Gatherer
+	Logger             Logger
+	NewWorker          func(Config) (worker.Worker, error)
+}
+
+// Validate checks that the config has all the required values.
+func (config ManifoldConfig) Validate() error {
+	if config.RaftName == "" {
+		return errors.NotValidf("empty RaftName")
+	}
+	if config.CentralHubName == "" {
+		return errors.NotValidf("empty CentralHubName")
+	}
+	if config.PubSubReporter == nil {
+		return errors.NotValidf("nil PubSubReporter")
+	}
+	if config.PrometheusGatherer == nil {
+		return errors.NotValidf("nil PrometheusGatherer")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var r *raft.Raft
+	if err := context.Get(config.RaftName, &r); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var hub *pubsub.StructuredHub
+	if err := context.Get(config.CentralHubName, &hub); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w, err := config.NewWorker(Config{
+		Hub:                hub,
+		Raft:               r,
+		PubSubReporter:     config.PubSubReporter,
+		PrometheusGatherer: config.PrometheusGatherer,
+		Logger:             config.Logger,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Manifold builds a dependency.Manifold for running a controller health
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.RaftName,
+			config.CentralHubName,
+		},
+		Start: config.start,
+	}
+}
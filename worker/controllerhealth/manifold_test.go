@@ -0,0 +1,139 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth_test
+
+import (
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	dt "github.com/juju/worker/v2/dependency/testing"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/controllerhealth"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+
+	context  dependency.Context
+	manifold dependency.Manifold
+	config   controllerhealth.ManifoldConfig
+
+	raft     *raft.Raft
+	hub      *pubsub.StructuredHub
+	reporter *fakeReporter
+	gatherer *prometheus.Registry
+	worker   worker.Worker
+	stub     testing.Stub
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (s *manifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.stub.ResetCalls()
+
+	s.raft = &raft.Raft{}
+	s.hub = &pubsub.StructuredHub{}
+	s.reporter = &fakeReporter{}
+	s.gatherer = prometheus.NewRegistry()
+	s.worker = &struct{ worker.Worker }{}
+
+	s.context = dt.StubContext(nil, map[string]interface{}{
+		"raft": s.raft,
+		"hub":  s.hub,
+	})
+	s.config = controllerhealth.ManifoldConfig{
+		RaftName:           "raft",
+		CentralHubName:     "hub",
+		PubSubReporter:     s.reporter,
+		PrometheusGatherer: s.gatherer,
+		Logger:             loggo.GetLogger("controllerhealth_test"),
+		NewWorker:          s.newWorker,
+	}
+	s.manifold = controllerhealth.Manifold(s.config)
+}
+
+func (s *manifoldSuite) newWorker(config controllerhealth.Config) (worker.Worker, error) {
+	s.stub.MethodCall(s, "NewWorker", config)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.worker, nil
+}
+
+func (s *manifoldSuite) TestValidate(c *gc.C) {
+	c.Assert(s.config.Validate(), jc.ErrorIsNil)
+	type test struct {
+		f      func(cfg *controllerhealth.ManifoldConfig)
+		expect string
+	}
+	tests := []test{{
+		func(cfg *controllerhealth.ManifoldConfig) { cfg.RaftName = "" },
+		"empty RaftName not valid",
+	}, {
+		func(cfg *controllerhealth.ManifoldConfig) { cfg.CentralHubName = "" },
+		"empty CentralHubName not valid",
+	}, {
+		func(cfg *controllerhealth.ManifoldConfig) { cfg.PubSubReporter = nil },
+		"nil PubSubReporter not valid",
+	}, {
+		func(cfg *controllerhealth.ManifoldConfig) { cfg.PrometheusGatherer = nil },
+		"nil PrometheusGatherer not valid",
+	}, {
+		func(cfg *controllerhealth.ManifoldConfig) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *controllerhealth.ManifoldConfig) { cfg.NewWorker = nil },
+		"nil NewWorker not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test #%d (%s)", i, test.expect)
+		config := s.config
+		test.f(&config)
+		c.Check(config.Validate(), gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *manifoldSuite) TestInputs(c *gc.C) {
+	c.Assert(s.manifold.Inputs, jc.SameContents, []string{"raft", "hub"})
+}
+
+func (s *manifoldSuite) TestMissingInputs(c *gc.C) {
+	for _, input := range s.manifold.Inputs {
+		context := dt.StubContext(nil, map[string]interface{}{
+			"raft": s.raft,
+			"hub":  s.hub,
+			input:  dependency.ErrMissing,
+		})
+		_, err := s.manifold.Start(context)
+		c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	}
+}
+
+func (s *manifoldSuite) TestStart(c *gc.C) {
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w, gc.Equals, s.worker)
+
+	s.stub.CheckCallNames(c, "NewWorker")
+	config := s.stub.Calls()[0].Args[0].(controllerhealth.Config)
+	c.Assert(config.Hub, gc.Equals, s.hub)
+	c.Assert(config.Raft, gc.Equals, s.raft)
+	c.Assert(config.PubSubReporter, gc.Equals, s.reporter)
+	c.Assert(config.PrometheusGatherer, gc.Equals, s.gatherer)
+}
+
+func (s *manifoldSuite) TestStartError(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(w, gc.IsNil)
+}
@@ -0,0 +1,246 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/pubsub/controller"
+)
+
+// This worker answers controller health requests published on the
+// central hub with a report on the local controller agent.
+
+const (
+	// leaseRequestMetric is the name of the summary recorded by the
+	// raft lease store for each lease operation.
+	leaseRequestMetric = "juju_raftlease_request"
+)
+
+// Raft defines the methods of the raft node used to report on its
+// health. It is satisfied by *raft.Raft.
+type Raft interface {
+	State() raft.RaftState
+	Leader() raft.ServerAddress
+	Stats() map[string]string
+	AppliedIndex() uint64
+	LastIndex() uint64
+	LastContact() time.Time
+	GetConfiguration() raft.ConfigurationFuture
+}
+
+// Reporter is the report of the pubsub forwarding worker.
+type Reporter interface {
+	Report() map[string]interface{}
+}
+
+// Logger specifies the interface we use from loggo.Logger.
+type Logger interface {
+	Errorf(string, ...interface{})
+	Tracef(string, ...interface{})
+}
+
+// Config defines the resources the worker needs to run.
+type Config struct {
+	Hub                *pubsub.StructuredHub
+	Raft               Raft
+	PubSubReporter     Reporter
+	PrometheusGatherer prometheus.Gatherer
+	Logger             Logger
+}
+
+// Validate checks that this config can be used.
+func (config Config) Validate() error {
+	if config.Hub == nil {
+		return errors.NotValidf("nil Hub")
+	}
+	if config.Raft == nil {
+		return errors.NotValidf("nil Raft")
+	}
+	if config.PubSubReporter == nil {
+		return errors.NotValidf("nil PubSubReporter")
+	}
+	if config.PrometheusGatherer == nil {
+		return errors.NotValidf("nil PrometheusGatherer")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker creates and starts a worker that responds to controller
+// health requests.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &healthWorker{config: config}
+	unsubscribe, err := config.Hub.Subscribe(controller.HealthRequestTopic, w.handleRequest)
+	if err != nil {
+		return nil, errors.Annotatef(err, "subscribing to %q", controller.HealthRequestTopic)
+	}
+	w.unsubscribe = unsubscribe
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		unsubscribe()
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type healthWorker struct {
+	catacomb    catacomb.Catacomb
+	config      Config
+	unsubscribe func()
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *healthWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *healthWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *healthWorker) loop() error {
+	defer w.unsubscribe()
+	<-w.catacomb.Dying()
+	return w.catacomb.ErrDying()
+}
+
+func (w *healthWorker) handleRequest(_ string, req controller.HealthRequest, err error) {
+	if err != nil {
+		// This should never happen, so treat it as fatal.
+		w.catacomb.Kill(errors.Annotate(err, "health request callback failed"))
+		return
+	}
+	w.config.Logger.Tracef("health requested, responding on %q", req.ResponseTopic)
+	if _, err := w.config.Hub.Publish(req.ResponseTopic, w.report()); err != nil {
+		w.config.Logger.Errorf("publishing health report: %v", err)
+	}
+}
+
+func (w *healthWorker) report() controller.HealthReport {
+	var report controller.HealthReport
+	raftHealth, err := w.raftHealth()
+	if err != nil {
+		report.Errors = append(report.Errors, "raft: "+err.Error())
+	}
+	report.Raft = raftHealth
+	claims, err := w.leaseClaimHealth()
+	if err != nil {
+		report.Errors = append(report.Errors, "lease claims: "+err.Error())
+	}
+	report.LeaseClaims = claims
+	report.Forwarders = w.forwarders()
+	return report
+}
+
+func (w *healthWorker) raftHealth() (controller.RaftHealth, error) {
+	r := w.config.Raft
+	health := controller.RaftHealth{
+		State:        r.State().String(),
+		AppliedIndex: r.AppliedIndex(),
+		LastIndex:    r.LastIndex(),
+	}
+	if term, err := strconv.ParseUint(r.Stats()["term"], 10, 64); err == nil {
+		health.Term = term
+	}
+	if health.State != raft.Leader.String() {
+		if t := r.LastContact(); !t.IsZero() {
+			health.LastContact = t.UTC().Format(time.RFC3339)
+		}
+	}
+
+	// Report the leader by its server id, which is the controller's
+	// machine id, rather than its address.
+	leader := r.Leader()
+	if leader == "" {
+		return health, nil
+	}
+	health.Leader = string(leader)
+	future := r.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return health, errors.Annotate(err, "getting configuration")
+	}
+	for _, server := range future.Configuration().Servers {
+		if server.Address == leader {
+			health.Leader = string(server.ID)
+			break
+		}
+	}
+	return health, nil
+}
+
+func (w *healthWorker) leaseClaimHealth() (controller.LeaseClaimHealth, error) {
+	var health controller.LeaseClaimHealth
+	families, err := w.config.PrometheusGatherer.Gather()
+	if err != nil {
+		return health, errors.Trace(err)
+	}
+	for _, family := range families {
+		if family.GetName() != leaseRequestMetric {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["operation"] != "claim" || labels["result"] != "success" {
+				continue
+			}
+			summary := metric.GetSummary()
+			health.Count = summary.GetSampleCount()
+			for _, q := range summary.GetQuantile() {
+				// Quantiles of a summary with no recent
+				// observations are NaN.
+				if math.IsNaN(q.GetValue()) {
+					continue
+				}
+				switch q.GetQuantile() {
+				case 0.5:
+					health.Median = q.GetValue()
+				case 0.99:
+					health.P99 = q.GetValue()
+				}
+			}
+		}
+	}
+	return health, nil
+}
+
+// forwarders returns the status of the pubsub forwarding connections
+// to the other controllers.
+func (w *healthWorker) forwarders() map[string]string {
+	targets, _ := w.config.PubSubReporter.Report()["targets"].(map[string]interface{})
+	if len(targets) == 0 {
+		return nil
+	}
+	result := make(map[string]string)
+	for target, report := range targets {
+		status := "unknown"
+		if values, ok := report.(map[string]interface{}); ok {
+			if s, ok := values["status"].(string); ok {
+				status = s
+			}
+		}
+		result[target] = status
+	}
+	return result
+}
@@ -0,0 +1,229 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controllerhealth_test
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/workertest"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/pubsub/centralhub"
+	"github.com/juju/juju/pubsub/controller"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/controllerhealth"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+	raft     *fakeRaft
+	reporter *fakeReporter
+	registry *prometheus.Registry
+	hub      *pubsub.StructuredHub
+	config   controllerhealth.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.raft = &fakeRaft{
+		state:   raft.Follower,
+		leader:  "10.0.0.1:17070",
+		term:    "4",
+		applied: 40,
+		last:    42,
+		contact: time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC),
+		servers: []raft.Server{
+			{ID: "0", Address: "10.0.0.1:17070"},
+			{ID: "1", Address: "10.0.0.2:17070"},
+		},
+	}
+	s.reporter = &fakeReporter{report: map[string]interface{}{
+		"source": "machine-1",
+		"targets": map[string]interface{}{
+			"machine-0": map[string]interface{}{"status": "connected"},
+			"machine-2": map[string]interface{}{"status": "disconnected"},
+		},
+	}}
+	s.registry = prometheus.NewRegistry()
+	s.hub = centralhub.New(names.NewMachineTag("1"))
+	s.config = controllerhealth.Config{
+		Hub:                s.hub,
+		Raft:               s.raft,
+		PubSubReporter:     s.reporter,
+		PrometheusGatherer: s.registry,
+		Logger:             loggo.GetLogger("controllerhealth_test"),
+	}
+}
+
+func (s *workerSuite) TestValidateErrors(c *gc.C) {
+	type test struct {
+		f      func(*controllerhealth.Config)
+		expect string
+	}
+	tests := []test{{
+		func(cfg *controllerhealth.Config) { cfg.Hub = nil },
+		"nil Hub not valid",
+	}, {
+		func(cfg *controllerhealth.Config) { cfg.Raft = nil },
+		"nil Raft not valid",
+	}, {
+		func(cfg *controllerhealth.Config) { cfg.PubSubReporter = nil },
+		"nil PubSubReporter not valid",
+	}, {
+		func(cfg *controllerhealth.Config) { cfg.PrometheusGatherer = nil },
+		"nil PrometheusGatherer not valid",
+	}, {
+		func(cfg *controllerhealth.Config) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test #%d (%s)", i, test.expect)
+		config := s.config
+		test.f(&config)
+		w, err := controllerhealth.NewWorker(config)
+		c.Check(err, gc.ErrorMatches, test.expect)
+		c.Check(w, gc.IsNil)
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := controllerhealth.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *workerSuite) requestReport(c *gc.C) controller.HealthReport {
+	reports := make(chan controller.HealthReport, 1)
+	unsubscribe, err := s.hub.Subscribe("health.response",
+		func(_ string, report controller.HealthReport, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			reports <- report
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	_, err = s.hub.Publish(controller.HealthRequestTopic, controller.HealthRequest{
+		ResponseTopic: "health.response",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case report := <-reports:
+		return report
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for health report")
+	}
+	return controller.HealthReport{}
+}
+
+func (s *workerSuite) TestCleanKill(c *gc.C) {
+	w := s.startWorker(c)
+	workertest.CleanKill(c, w)
+}
+
+func (s *workerSuite) TestReport(c *gc.C) {
+	claims := prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Name:       "juju_raftlease_request",
+		Objectives: map[float64]float64{0.5: 0.05, 0.99: 0.001},
+	}, []string{"operation", "result"})
+	c.Assert(s.registry.Register(claims), jc.ErrorIsNil)
+	claims.WithLabelValues("claim", "success").Observe(3)
+	claims.WithLabelValues("claim", "failure").Observe(1000)
+	claims.WithLabelValues("extend", "success").Observe(1000)
+
+	s.startWorker(c)
+	report := s.requestReport(c)
+	c.Assert(report, jc.DeepEquals, controller.HealthReport{
+		Origin: "machine-1",
+		Raft: controller.RaftHealth{
+			State:        "Follower",
+			Leader:       "0",
+			Term:         4,
+			AppliedIndex: 40,
+			LastIndex:    42,
+			LastContact:  "2021-05-01T12:00:00Z",
+		},
+		LeaseClaims: controller.LeaseClaimHealth{
+			Count:  1,
+			Median: 3,
+			P99:    3,
+		},
+		Forwarders: map[string]string{
+			"machine-0": "connected",
+			"machine-2": "disconnected",
+		},
+	})
+}
+
+func (s *workerSuite) TestReportLeaderWithoutClaims(c *gc.C) {
+	s.raft.state = raft.Leader
+	s.raft.leader = "10.0.0.2:17070"
+	s.reporter.report = map[string]interface{}{"source": "machine-1"}
+
+	s.startWorker(c)
+	report := s.requestReport(c)
+	c.Assert(report, jc.DeepEquals, controller.HealthReport{
+		Origin: "machine-1",
+		Raft: controller.RaftHealth{
+			State:        "Leader",
+			Leader:       "1",
+			Term:         4,
+			AppliedIndex: 40,
+			LastIndex:    42,
+		},
+	})
+}
+
+type fakeRaft struct {
+	state   raft.RaftState
+	leader  raft.ServerAddress
+	term    string
+	applied uint64
+	last    uint64
+	contact time.Time
+	servers []raft.Server
+}
+
+func (r *fakeRaft) State() raft.RaftState      { return r.state }
+func (r *fakeRaft) Leader() raft.ServerAddress { return r.leader }
+func (r *fakeRaft) Stats() map[string]string   { return map[string]string{"term": r.term} }
+func (r *fakeRaft) AppliedIndex() uint64       { return r.applied }
+func (r *fakeRaft) LastIndex() uint64          { return r.last }
+func (r *fakeRaft) LastContact() time.Time     { return r.contact }
+
+func (r *fakeRaft) GetConfiguration() raft.ConfigurationFuture {
+	return &fakeConfigurationFuture{servers: r.servers}
+}
+
+type fakeConfigurationFuture struct {
+	raft.IndexFuture
+	servers []raft.Server
+}
+
+func (f *fakeConfigurationFuture) Error() error {
+	return nil
+}
+
+func (f *fakeConfigurationFuture) Configuration() raft.Configuration {
+	return raft.Configuration{Servers: f.servers}
+}
+
+type fakeReporter struct {
+	report map[string]interface{}
+}
+
+func (r *fakeReporter) Report() map[string]interface{} {
+	return r.report
+}
@@ -958,11 +958,11 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 	// use that, else open to the world.
 	if cidrs.Size() > maxAllowedCIDRS {
 		// First, try and merge the cidrs.
-		merged, err := cidrman.MergeCIDRs(cidrs.Values())
+		merged, err := mergeCIDRs(cidrs)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		cidrs = set.NewStrings(merged...)
+		cidrs = merged
 	}
 
 	// If there's still too many after merging, look for any firewall whitelist.
@@ -990,6 +990,34 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 	return cidrs, nil
 }
 
+// mergeCIDRs returns the input CIDRs with adjacent and overlapping IPv4
+// ranges merged together. The merge library does not support IPv6, so IPv6
+// CIDRs are retained as they are.
+func mergeCIDRs(cidrs set.Strings) (set.Strings, error) {
+	var v4CIDRs []string
+	merged := set.NewStrings()
+	for _, cidr := range cidrs.Values() {
+		addrType, err := network.CIDRAddressType(cidr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if addrType == network.IPv6Address {
+			merged.Add(cidr)
+			continue
+		}
+		v4CIDRs = append(v4CIDRs, cidr)
+	}
+
+	mergedV4, err := cidrman.MergeCIDRs(v4CIDRs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, cidr := range mergedV4 {
+		merged.Add(cidr)
+	}
+	return merged, nil
+}
+
 // flushGlobalPorts opens and closes global ports in the environment.
 // It keeps a reference count for ports so that only 0-to-1 and 1-to-0 events
 // modify the environment.
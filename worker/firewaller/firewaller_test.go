@@ -1337,6 +1337,25 @@ func (s *InstanceModeSuite) TestRemoteRelationIngressMergesCIDRS(c *gc.C) {
 	s.assertIngressCidrs(c, ingress, expected)
 }
 
+func (s *InstanceModeSuite) TestRemoteRelationIngressMergesIPV4CIDRSRetainingIPV6(c *gc.C) {
+	var ingress []string
+	for i := 0; i < 16; i++ {
+		ingress = append(ingress, fmt.Sprintf("192.0.2.%d/28", i*16))
+	}
+	for i := 1; i < 6; i++ {
+		ingress = append(ingress, fmt.Sprintf("2001:db8:%d::/64", i))
+	}
+	expected := []string{
+		"192.0.2.0/24",
+		"2001:db8:1::/64",
+		"2001:db8:2::/64",
+		"2001:db8:3::/64",
+		"2001:db8:4::/64",
+		"2001:db8:5::/64",
+	}
+	s.assertIngressCidrs(c, ingress, expected)
+}
+
 func (s *InstanceModeSuite) TestExposedApplicationWithExposedEndpoints(c *gc.C) {
 	// Create a spaces and add a subnet to it
 	sp1, err := s.State.AddSpace("space1", network.Id("sp-1"), nil, false)
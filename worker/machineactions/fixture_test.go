@@ -12,6 +12,7 @@ import (
 
 	"github.com/juju/juju/api/machineactions"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -22,6 +23,13 @@ func mockHandleAction(stub *testing.Stub) func(string, map[string]interface{}) (
 	}
 }
 
+func mockCheckNetwork(stub *testing.Stub) func([]params.NetworkCheckTarget, map[string]interface{}) (map[string]interface{}, error) {
+	return func(targets []params.NetworkCheckTarget, params map[string]interface{}) (map[string]interface{}, error) {
+		stub.AddCall("CheckNetwork", targets)
+		return nil, stub.NextErr()
+	}
+}
+
 // stubWatcher implements watcher.StringsWatcher and supplied canned
 // data over the Changes() channel.
 type stubWatcher struct {
@@ -51,6 +59,7 @@ var (
 	firstAction        = machineactions.NewAction("1", "foo", nil, true, "")
 	secondAction       = machineactions.NewAction("2", "baz", nil, false, "")
 	thirdAction        = machineactions.NewAction("3", "boo", nil, true, "")
+	networkCheckAction = machineactions.NewAction("1", actions.JujuNetworkCheckActionName, nil, true, "")
 	firstActionID      = "1"
 	secondActionID     = "2"
 	thirdActionID      = "3"
@@ -23,7 +23,7 @@ var RunAsUser = "ubuntu"
 // HandleAction receives a name and a map of parameters for a given machine action.
 // It will handle that action in a specific way and return a results map suitable for ActionFinish.
 func HandleAction(name string, params map[string]interface{}) (results map[string]interface{}, err error) {
-	spec, ok := actions.PredefinedActionsSpec[name]
+	spec, ok := actions.MachineActionSpec(name)
 	if !ok {
 		return nil, errors.Errorf("unexpected action %s", name)
 	}
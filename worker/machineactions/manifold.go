@@ -38,6 +38,7 @@ func (config ManifoldConfig) start(a agent.Agent, apiCaller base.APICaller) (wor
 		MachineTag:   machineTag,
 		MachineLock:  config.MachineLock,
 		HandleAction: HandleAction,
+		CheckNetwork: CheckNetwork,
 	})
 }
 
@@ -80,6 +80,21 @@ func (mr *MockFacadeMockRecorder) ActionFinish(arg0, arg1, arg2, arg3 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActionFinish", reflect.TypeOf((*MockFacade)(nil).ActionFinish), arg0, arg1, arg2, arg3)
 }
 
+// NetworkCheckTargets mocks base method
+func (m *MockFacade) NetworkCheckTargets(arg0 names.MachineTag) ([]params.NetworkCheckTarget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkCheckTargets", arg0)
+	ret0, _ := ret[0].([]params.NetworkCheckTarget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NetworkCheckTargets indicates an expected call of NetworkCheckTargets
+func (mr *MockFacadeMockRecorder) NetworkCheckTargets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkCheckTargets", reflect.TypeOf((*MockFacade)(nil).NetworkCheckTargets), arg0)
+}
+
 // RunningActions mocks base method
 func (m *MockFacade) RunningActions(arg0 names.MachineTag) ([]params.ActionResult, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,127 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machineactions
+
+import (
+	stderrors "errors"
+	"net"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+const (
+	// defaultNetworkCheckPort is the TCP port probed on each target address
+	// if none is supplied in the action parameters.
+	defaultNetworkCheckPort = 22
+
+	// defaultNetworkCheckTimeout is the time allowed for each probe if no
+	// timeout is supplied in the action parameters.
+	defaultNetworkCheckTimeout = 5 * time.Second
+)
+
+// DialFunc describes a function used to make a connection
+// to an address, such as net.DialTimeout.
+type DialFunc func(network, address string, timeout time.Duration) (net.Conn, error)
+
+// CheckNetwork probes each of the input network check targets via TCP,
+// and returns the outcome of each probe in a form suitable for
+// ActionFinish.
+func CheckNetwork(targets []params.NetworkCheckTarget, actionParams map[string]interface{}) (map[string]interface{}, error) {
+	return checkNetwork(targets, actionParams, net.DialTimeout, clock.WallClock)
+}
+
+func checkNetwork(
+	targets []params.NetworkCheckTarget, actionParams map[string]interface{}, dial DialFunc, clock clock.Clock,
+) (map[string]interface{}, error) {
+	port, err := networkCheckPort(actionParams)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// The timeout is passed in in nanoseconds, but due to
+	// serialization it comes out as float64.
+	timeout := defaultNetworkCheckTimeout
+	if t, ok := actionParams["timeout"].(float64); ok && t > 0 {
+		timeout = time.Duration(t)
+	}
+
+	probes := make([]interface{}, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target params.NetworkCheckTarget) {
+			defer wg.Done()
+			probes[i] = probeTarget(target, port, timeout, dial, clock)
+		}(i, target)
+	}
+	wg.Wait()
+
+	return map[string]interface{}{
+		"probes": probes,
+	}, nil
+}
+
+// probeTarget attempts a TCP connection to the input target's address.
+// A target is considered reachable if the connection succeeds or is
+// actively refused, as either indicates that the host responded.
+func probeTarget(
+	target params.NetworkCheckTarget, port int, timeout time.Duration, dial DialFunc, clock clock.Clock,
+) map[string]interface{} {
+	result := map[string]interface{}{
+		"unit":         target.Unit,
+		"endpoint":     target.Endpoint,
+		"related-unit": target.RelatedUnit,
+		"space":        target.Space,
+		"address":      target.Address,
+		"port":         port,
+	}
+
+	start := clock.Now()
+	conn, err := dial("tcp", net.JoinHostPort(target.Address, strconv.Itoa(port)), timeout)
+	latency := clock.Now().Sub(start)
+	if conn != nil {
+		_ = conn.Close()
+	}
+
+	if err != nil && !stderrors.Is(err, syscall.ECONNREFUSED) {
+		logger.Debugf("network check of %s from %s failed: %v", target.Address, target.Unit, err)
+		result["reachable"] = false
+		result["error"] = err.Error()
+		return result
+	}
+
+	result["reachable"] = true
+	result["latency"] = latency.String()
+	return result
+}
+
+// networkCheckPort returns the port to probe from the input action
+// parameters. Depending on how the parameters were serialized,
+// the value may be represented by an integer or a float.
+func networkCheckPort(actionParams map[string]interface{}) (int, error) {
+	var port int
+	switch p := actionParams["port"].(type) {
+	case nil:
+		return defaultNetworkCheckPort, nil
+	case int:
+		port = p
+	case int64:
+		port = int(p)
+	case float64:
+		port = int(p)
+	default:
+		return 0, errors.NotValidf("port %v", p)
+	}
+	if port < 1 || port > 65535 {
+		return 0, errors.NotValidf("port %d", port)
+	}
+	return port, nil
+}
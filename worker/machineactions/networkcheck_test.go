@@ -0,0 +1,132 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machineactions_test
+
+import (
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/machineactions"
+)
+
+type NetworkCheckSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&NetworkCheckSuite{})
+
+var networkCheckTargets = []params.NetworkCheckTarget{{
+	Unit:        "mysql/0",
+	Endpoint:    "db",
+	RelatedUnit: "wordpress/0",
+	Space:       "alpha",
+	Address:     "10.0.0.2",
+}, {
+	Unit:        "mysql/0",
+	Endpoint:    "db",
+	RelatedUnit: "wordpress/1",
+	Space:       "alpha",
+	Address:     "10.0.0.3",
+}, {
+	Unit:        "mysql/0",
+	Endpoint:    "db",
+	RelatedUnit: "wordpress/2",
+	Space:       "alpha",
+	Address:     "10.0.0.4",
+}}
+
+func (s *NetworkCheckSuite) TestCheckNetwork(c *gc.C) {
+	dial := func(network, address string, timeout time.Duration) (net.Conn, error) {
+		c.Check(network, gc.Equals, "tcp")
+		c.Check(timeout, gc.Equals, 2*time.Second)
+		switch address {
+		case "10.0.0.2:8080":
+			client, server := net.Pipe()
+			_ = server.Close()
+			return client, nil
+		case "10.0.0.3:8080":
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+		}
+		return nil, errors.New("i/o timeout")
+	}
+
+	results, err := machineactions.CheckNetworkWithDialer(
+		networkCheckTargets,
+		map[string]interface{}{"port": float64(8080), "timeout": float64(2 * time.Second)},
+		dial,
+		testclock.NewClock(time.Time{}),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, map[string]interface{}{
+		"probes": []interface{}{
+			map[string]interface{}{
+				"unit":         "mysql/0",
+				"endpoint":     "db",
+				"related-unit": "wordpress/0",
+				"space":        "alpha",
+				"address":      "10.0.0.2",
+				"port":         8080,
+				"reachable":    true,
+				"latency":      "0s",
+			},
+			map[string]interface{}{
+				"unit":         "mysql/0",
+				"endpoint":     "db",
+				"related-unit": "wordpress/1",
+				"space":        "alpha",
+				"address":      "10.0.0.3",
+				"port":         8080,
+				"reachable":    true,
+				"latency":      "0s",
+			},
+			map[string]interface{}{
+				"unit":         "mysql/0",
+				"endpoint":     "db",
+				"related-unit": "wordpress/2",
+				"space":        "alpha",
+				"address":      "10.0.0.4",
+				"port":         8080,
+				"reachable":    false,
+				"error":        "i/o timeout",
+			},
+		},
+	})
+}
+
+func (s *NetworkCheckSuite) TestCheckNetworkDefaults(c *gc.C) {
+	dial := func(network, address string, timeout time.Duration) (net.Conn, error) {
+		c.Check(address, gc.Equals, "10.0.0.2:22")
+		c.Check(timeout, gc.Equals, 5*time.Second)
+		return nil, syscall.ECONNREFUSED
+	}
+
+	results, err := machineactions.CheckNetworkWithDialer(
+		networkCheckTargets[:1], nil, dial, testclock.NewClock(time.Time{}))
+	c.Assert(err, jc.ErrorIsNil)
+	probes, ok := results["probes"].([]interface{})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(probes, gc.HasLen, 1)
+	c.Check(probes[0].(map[string]interface{})["reachable"], jc.IsTrue)
+}
+
+func (s *NetworkCheckSuite) TestCheckNetworkInvalidPort(c *gc.C) {
+	dial := func(string, string, time.Duration) (net.Conn, error) {
+		c.Fatalf("unexpected dial")
+		return nil, nil
+	}
+
+	for _, port := range []interface{}{float64(0), 70000, "ssh"} {
+		_, err := machineactions.CheckNetworkWithDialer(
+			networkCheckTargets, map[string]interface{}{"port": port}, dial, testclock.NewClock(time.Time{}))
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
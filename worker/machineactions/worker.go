@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/api/machineactions"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/watcher"
 )
@@ -29,6 +30,8 @@ type Facade interface {
 	Action(names.ActionTag) (*machineactions.Action, error)
 	ActionBegin(names.ActionTag) error
 	ActionFinish(tag names.ActionTag, status string, results map[string]interface{}, message string) error
+
+	NetworkCheckTargets(agent names.MachineTag) ([]params.NetworkCheckTarget, error)
 }
 
 // WorkerConfig defines the worker's dependencies.
@@ -37,6 +40,7 @@ type WorkerConfig struct {
 	MachineTag   names.MachineTag
 	MachineLock  machinelock.Lock
 	HandleAction func(name string, params map[string]interface{}) (results map[string]interface{}, err error)
+	CheckNetwork func(targets []params.NetworkCheckTarget, params map[string]interface{}) (results map[string]interface{}, err error)
 }
 
 // Validate returns an error if the configuration is not complete.
@@ -50,6 +54,9 @@ func (c WorkerConfig) Validate() error {
 	if c.HandleAction == nil {
 		return errors.NotValidf("nil HandleAction")
 	}
+	if c.CheckNetwork == nil {
+		return errors.NotValidf("nil CheckNetwork")
+	}
 	return nil
 }
 
@@ -164,12 +171,26 @@ func (h *handler) Handle(abort <-chan struct{}, actionsSlice []string) error {
 				actionErr = errors.Annotatef(err, "could not begin action %s", action.Name())
 				return
 			}
+			if actions.IsJujuNetworkCheckAction(action.Name()) {
+				results, actionErr = h.checkNetwork(action.Params())
+				return
+			}
 			results, actionErr = h.config.HandleAction(action.Name(), action.Params())
 		}(*action)
 	}
 	return nil
 }
 
+// checkNetwork retrieves the addresses of units related to those on this
+// machine and probes each of them.
+func (h *handler) checkNetwork(actionParams map[string]interface{}) (map[string]interface{}, error) {
+	targets, err := h.config.Facade.NetworkCheckTargets(h.config.MachineTag)
+	if err != nil {
+		return nil, errors.Annotate(err, "retrieving network check targets")
+	}
+	return h.config.CheckNetwork(targets, actionParams)
+}
+
 // TearDown is part of the watcher.NotifyHandler interface.
 func (h *handler) TearDown() error {
 	// Wait for any running actions to finish.
@@ -5,6 +5,8 @@
 package machineactions_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/machinelock"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/machineactions"
 	"github.com/juju/juju/worker/machineactions/mocks"
 )
@@ -59,11 +62,25 @@ func (s *WorkerSuite) TestInvalidHandleAction(c *gc.C) {
 	c.Assert(worker, gc.IsNil)
 }
 
+func (s *WorkerSuite) TestInvalidCheckNetwork(c *gc.C) {
+	worker, err := machineactions.NewMachineActionsWorker(machineactions.WorkerConfig{
+		Facade:       s.facade,
+		MachineTag:   fakeTag,
+		MachineLock:  s.lock,
+		HandleAction: mockHandleAction(&testing.Stub{}),
+		CheckNetwork: nil,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil CheckNetwork not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(worker, gc.IsNil)
+}
+
 func defaultConfig(stub *testing.Stub, facade machineactions.Facade, lock machinelock.Lock) machineactions.WorkerConfig {
 	return machineactions.WorkerConfig{
 		Facade:       facade,
 		MachineTag:   fakeTag,
 		HandleAction: mockHandleAction(stub),
+		CheckNetwork: mockCheckNetwork(stub),
 		MachineLock:  lock,
 	}
 }
@@ -198,6 +215,87 @@ func (s *WorkerSuite) TestActionHandleErr(c *gc.C) {
 	}})
 }
 
+func (s *WorkerSuite) TestRunNetworkCheckAction(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	changes := make(chan []string, 1)
+	changes <- []string{firstActionID}
+
+	targets := []params.NetworkCheckTarget{{
+		Unit:        "mysql/0",
+		Endpoint:    "db",
+		RelatedUnit: "wordpress/0",
+		Space:       "alpha",
+		Address:     "10.0.0.2",
+	}}
+
+	s.facade.EXPECT().RunningActions(fakeTag).Return([]params.ActionResult{}, nil)
+	s.facade.EXPECT().WatchActionNotifications(fakeTag).Return(&stubWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: changes,
+	}, nil)
+	s.facade.EXPECT().Action(names.NewActionTag(firstActionID)).Return(networkCheckAction, nil)
+	s.facade.EXPECT().ActionBegin(names.NewActionTag(firstActionID)).Return(nil)
+	s.facade.EXPECT().NetworkCheckTargets(fakeTag).Return(targets, nil)
+	finished := make(chan struct{})
+	s.facade.EXPECT().ActionFinish(names.NewActionTag(firstActionID), params.ActionCompleted, nil, "").DoAndReturn(
+		func(names.ActionTag, string, map[string]interface{}, string) error {
+			close(finished)
+			return nil
+		})
+
+	stub := &testing.Stub{}
+	worker, err := machineactions.NewMachineActionsWorker(defaultConfig(stub, s.facade, s.lock))
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case <-finished:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for network check action to finish")
+	}
+	workertest.CleanKill(c, worker)
+	stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "CheckNetwork",
+		Args:     []interface{}{targets},
+	}})
+}
+
+func (s *WorkerSuite) TestRunNetworkCheckActionTargetsError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	changes := make(chan []string, 1)
+	changes <- []string{firstActionID}
+
+	s.facade.EXPECT().RunningActions(fakeTag).Return([]params.ActionResult{}, nil)
+	s.facade.EXPECT().WatchActionNotifications(fakeTag).Return(&stubWatcher{
+		Worker:  workertest.NewErrorWorker(nil),
+		changes: changes,
+	}, nil)
+	s.facade.EXPECT().Action(names.NewActionTag(firstActionID)).Return(networkCheckAction, nil)
+	s.facade.EXPECT().ActionBegin(names.NewActionTag(firstActionID)).Return(nil)
+	s.facade.EXPECT().NetworkCheckTargets(fakeTag).Return(nil, errors.New("boom"))
+	finished := make(chan struct{})
+	s.facade.EXPECT().ActionFinish(
+		names.NewActionTag(firstActionID), params.ActionFailed, nil, "retrieving network check targets: boom",
+	).DoAndReturn(
+		func(names.ActionTag, string, map[string]interface{}, string) error {
+			close(finished)
+			return nil
+		})
+
+	stub := &testing.Stub{}
+	worker, err := machineactions.NewMachineActionsWorker(defaultConfig(stub, s.facade, s.lock))
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case <-finished:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for network check action to finish")
+	}
+	workertest.CleanKill(c, worker)
+	stub.CheckNoCalls(c)
+}
+
 func (s *WorkerSuite) TestWorkerNoErr(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/charm/v9"
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
@@ -53,6 +54,10 @@ var (
 // to the newly-migrated model.
 const progressUpdateInterval = 30 * time.Second
 
+// retryPollInterval is the time between checks for a retry of a
+// failed model data transfer having been requested.
+const retryPollInterval = 10 * time.Second
+
 // Facade exposes controller functionality to a Worker.
 type Facade interface {
 	// Watch returns a watcher which reports when a migration is
@@ -71,6 +76,10 @@ type Facade interface {
 	// progress of a migration.
 	SetStatusMessage(string) error
 
+	// SetProgress records how much of the model's data has been
+	// transferred to the target controller.
+	SetProgress(coremigration.TransferProgress) error
+
 	// Prechecks performs pre-migration checks on the model and
 	// (source) controller.
 	Prechecks() error
@@ -105,6 +114,11 @@ type Facade interface {
 	// to report on a migration phase.
 	MinionReportTimeout() (time.Duration, error)
 
+	// TransferRetryTimeout returns how long to wait for a retry to be
+	// requested after the model data transfer fails, before aborting
+	// the migration. If zero, the migration is aborted immediately.
+	TransferRetryTimeout() (time.Duration, error)
+
 	// StreamModelLog takes a starting time and returns a channel that
 	// will yield the logs on or after that time - these are the logs
 	// that need to be transferred to the target after the migration
@@ -188,6 +202,7 @@ type Worker struct {
 	logger              loggo.Logger
 	lastFailure         string
 	minionReportTimeout time.Duration
+	progress            coremigration.TransferProgress
 }
 
 // Kill implements worker.Worker.
@@ -218,6 +233,7 @@ func (w *Worker) run() error {
 	}
 
 	phase := status.Phase
+	w.progress = status.Progress
 
 	for {
 		var err error
@@ -225,7 +241,7 @@ func (w *Worker) run() error {
 		case coremigration.QUIESCE:
 			phase, err = w.doQUIESCE(status)
 		case coremigration.IMPORT:
-			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doIMPORT(status)
 		case coremigration.PROCESSRELATIONS:
 			phase, err = w.doPROCESSRELATIONS(status)
 		case coremigration.VALIDATION:
@@ -305,6 +321,14 @@ func (w *Worker) setStatus(message string) error {
 	return errors.Annotate(err, "failed to set status message")
 }
 
+func (w *Worker) reportProgress() {
+	if err := w.config.Facade.SetProgress(w.progress); err != nil {
+		// As with the status message, recording progress isn't
+		// critical.
+		w.logger.Errorf("failed to set migration progress: %v", err)
+	}
+}
+
 func (w *Worker) doQUIESCE(status coremigration.MigrationStatus) (coremigration.Phase, error) {
 	// Run prechecks before waiting for minions to report back. This
 	// short-circuits the long timeout in the case of an agent being
@@ -359,13 +383,163 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 	return errors.Annotate(err, "target prechecks failed")
 }
 
-func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferModel(targetInfo, modelUUID)
-	if err != nil {
-		w.setErrorStatus("model data transfer failed, %v", err)
-		return coremigration.ABORT, nil
+func (w *Worker) doIMPORT(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	transfer := newModelTransfer(w)
+	retries := status.Retries
+	for {
+		transferErr := w.transferModel(status.TargetInfo, status.ModelUUID, transfer)
+		if transferErr == nil {
+			return coremigration.PROCESSRELATIONS, nil
+		}
+		timeout, err := w.config.Facade.TransferRetryTimeout()
+		if err != nil {
+			return coremigration.UNKNOWN, errors.Annotate(err, "retrieving transfer retry timeout")
+		}
+		if timeout == 0 {
+			w.setErrorStatus("model data transfer failed, %v", transferErr)
+			return coremigration.ABORT, nil
+		}
+
+		// Only retries requested after the user has been told of
+		// the failure count, not any made while the transfer ran.
+		current, err := w.config.Facade.MigrationStatus()
+		if err != nil {
+			return coremigration.UNKNOWN, errors.Annotate(err, "retrieving migration status")
+		}
+		retries = current.Retries
+
+		// Give the user a chance to fix the problem and retry the
+		// transfer, rather than throwing away the work done so far.
+		w.lastFailure = fmt.Sprintf("model data transfer failed, %v", transferErr)
+		w.setStatusAndLog(w.logger.Errorf, "%s; waiting %s for the transfer to be retried", w.lastFailure, timeout)
+		retried, err := w.waitForRetry(timeout, &retries)
+		if err != nil {
+			return coremigration.UNKNOWN, errors.Trace(err)
+		}
+		if !retried {
+			return coremigration.ABORT, nil
+		}
+		w.setInfoStatus("retrying model data transfer")
+	}
+}
+
+// waitForRetry waits for a retry of a failed model data transfer to
+// be requested, returning true if one was. The number of retries
+// requested so far is updated.
+func (w *Worker) waitForRetry(wait time.Duration, retries *int) (bool, error) {
+	clk := w.config.Clock
+	timeout := clk.After(wait)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return false, w.catacomb.ErrDying()
+		case <-timeout:
+			w.logger.Infof("timed out waiting for the model data transfer to be retried")
+			return false, nil
+		case <-clk.After(retryPollInterval):
+		}
+
+		status, err := w.config.Facade.MigrationStatus()
+		if err != nil {
+			return false, errors.Annotate(err, "retrieving migration status")
+		}
+		if status.Phase != coremigration.IMPORT {
+			// The migration has been aborted by the user.
+			return false, nil
+		}
+		if status.Retries > *retries {
+			*retries = status.Retries
+			return true, nil
+		}
 	}
-	return coremigration.PROCESSRELATIONS, nil
+}
+
+// modelTransfer tracks the transfer of a model's data to the target
+// controller, so that a retried transfer only repeats the steps that
+// didn't complete. It reports progress as each binary is uploaded.
+type modelTransfer struct {
+	worker     *Worker
+	serialized *coremigration.SerializedModel
+	imported   bool
+	// importAttempted records that an import was attempted but
+	// failed, possibly leaving a partial model on the target.
+	importAttempted bool
+	charms     set.Strings
+	tools      map[version.Binary]bool
+	resources  set.Strings
+}
+
+func newModelTransfer(w *Worker) *modelTransfer {
+	return &modelTransfer{
+		worker:    w,
+		charms:    set.NewStrings(),
+		tools:     make(map[version.Binary]bool),
+		resources: set.NewStrings(),
+	}
+}
+
+func resourceKey(res coremigration.SerializedModelResource) string {
+	return res.ApplicationRevision.ApplicationID + "/" + res.ApplicationRevision.Name
+}
+
+// CharmUploaded implements migration.UploadProgress.
+func (t *modelTransfer) CharmUploaded(curl string) {
+	t.charms.Add(curl)
+	t.worker.progress.Charms = len(t.charms)
+	t.worker.reportProgress()
+}
+
+// ToolsUploaded implements migration.UploadProgress.
+func (t *modelTransfer) ToolsUploaded(vers version.Binary) {
+	t.tools[vers] = true
+	t.worker.progress.Tools = len(t.tools)
+	t.worker.reportProgress()
+}
+
+// ResourceUploaded implements migration.UploadProgress.
+func (t *modelTransfer) ResourceUploaded(res coremigration.SerializedModelResource) {
+	t.resources.Add(resourceKey(res))
+	t.worker.progress.Resources = len(t.resources)
+	t.worker.reportProgress()
+}
+
+func (t *modelTransfer) remainingCharms() []string {
+	if t.charms.IsEmpty() {
+		return t.serialized.Charms
+	}
+	var remaining []string
+	for _, curl := range t.serialized.Charms {
+		if !t.charms.Contains(curl) {
+			remaining = append(remaining, curl)
+		}
+	}
+	return remaining
+}
+
+func (t *modelTransfer) remainingTools() map[version.Binary]string {
+	if len(t.tools) == 0 {
+		return t.serialized.Tools
+	}
+	remaining := make(map[version.Binary]string)
+	for vers, uri := range t.serialized.Tools {
+		if !t.tools[vers] {
+			remaining[vers] = uri
+		}
+	}
+	return remaining
+}
+
+func (t *modelTransfer) remainingResources() []coremigration.SerializedModelResource {
+	if t.resources.IsEmpty() {
+		return t.serialized.Resources
+	}
+	var remaining []coremigration.SerializedModelResource
+	for _, res := range t.serialized.Resources {
+		if !t.resources.Contains(resourceKey(res)) {
+			remaining = append(remaining, res)
+		}
+	}
+	return remaining
 }
 
 type uploadWrapper struct {
@@ -398,23 +572,44 @@ func (w *uploadWrapper) SetUnitResource(unitName string, res resource.Resource)
 	return w.client.SetUnitResource(w.modelUUID, unitName, res)
 }
 
-func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string) error {
-	w.setInfoStatus("exporting model")
-	serialized, err := w.config.Facade.Export()
-	if err != nil {
-		return errors.Annotate(err, "model export failed")
+func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string, transfer *modelTransfer) error {
+	if transfer.serialized == nil {
+		w.setInfoStatus("exporting model")
+		serialized, err := w.config.Facade.Export()
+		if err != nil {
+			return errors.Annotate(err, "model export failed")
+		}
+		transfer.serialized = &serialized
+		w.progress.Charms, w.progress.CharmsTotal = 0, len(serialized.Charms)
+		w.progress.Tools, w.progress.ToolsTotal = 0, len(serialized.Tools)
+		w.progress.Resources, w.progress.ResourcesTotal = 0, len(serialized.Resources)
+		w.reportProgress()
 	}
 
-	w.setInfoStatus("importing model into target controller")
+	if !transfer.imported {
+		w.setInfoStatus("importing model into target controller")
+	}
 	conn, err := w.openAPIConn(targetInfo)
 	if err != nil {
 		return errors.Annotate(err, "failed to connect to target controller")
 	}
 	defer conn.Close()
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(serialized.Bytes)
-	if err != nil {
-		return errors.Annotate(err, "failed to import model into target controller")
+	if !transfer.imported {
+		if transfer.importAttempted {
+			// Clean up whatever the failed import left behind,
+			// so the model can be imported afresh.
+			w.setInfoStatus("removing partially imported model from target controller")
+			if err := targetClient.Abort(modelUUID); err != nil && !params.IsCodeNotFound(err) {
+				return errors.Annotate(err, "failed to remove partially imported model from target controller")
+			}
+		}
+		transfer.importAttempted = true
+		err = targetClient.Import(transfer.serialized.Bytes)
+		if err != nil {
+			return errors.Annotate(err, "failed to import model into target controller")
+		}
+		transfer.imported = true
 	}
 
 	if wrench.IsActive("migrationmaster", "die-in-export") {
@@ -425,17 +620,19 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	w.setInfoStatus("uploading model binaries into target controller")
 	wrapper := &uploadWrapper{targetClient, modelUUID}
 	err = w.config.UploadBinaries(migration.UploadBinariesConfig{
-		Charms:          serialized.Charms,
+		Charms:          transfer.remainingCharms(),
 		CharmDownloader: w.config.CharmDownloader,
 		CharmUploader:   wrapper,
 
-		Tools:           serialized.Tools,
+		Tools:           transfer.remainingTools(),
 		ToolsDownloader: w.config.ToolsDownloader,
 		ToolsUploader:   wrapper,
 
-		Resources:          serialized.Resources,
+		Resources:          transfer.remainingResources(),
 		ResourceDownloader: w.config.Facade,
 		ResourceUploader:   wrapper,
+
+		Progress: transfer,
 	})
 	return errors.Annotate(err, "failed to migrate binaries")
 }
@@ -563,6 +760,7 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 			verb = "transferred"
 		}
 		w.setInfoStatus("successful, %s logs to target controller (%d sent)", verb, sent)
+		w.reportProgress()
 	}
 	reportProgress(false, sent)
 
@@ -618,6 +816,8 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 				return errors.Trace(err)
 			}
 			sent++
+			w.progress.LogMessages++
+			w.progress.LogBytes += int64(len(msg.Message))
 
 			if throwWrench && sent == 500 {
 				// Simulate a connection drop to test restartability.
@@ -508,6 +508,7 @@ func (s *Suite) TestExportFailure(c *gc.C) {
 		[]jujutesting.StubCall{
 			{"facade.MinionReportTimeout", nil},
 			{"facade.Export", nil},
+			{"facade.TransferRetryTimeout", nil},
 		},
 		abortCalls,
 	))
@@ -524,6 +525,7 @@ func (s *Suite) TestAPIOpenFailure(c *gc.C) {
 			{"facade.MinionReportTimeout", nil},
 			{"facade.Export", nil},
 			apiOpenControllerCall,
+			{"facade.TransferRetryTimeout", nil},
 			{"facade.SetPhase", []interface{}{coremigration.ABORT}},
 			apiOpenControllerCall,
 			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
@@ -544,11 +546,203 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 			apiOpenControllerCall,
 			importCall,
 			apiCloseCall,
+			{"facade.TransferRetryTimeout", nil},
 		},
 		abortCalls,
 	))
 }
 
+func (s *Suite) TestImportReportsProgress(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.processRelationsErr = errors.New("stop")
+	s.config.UploadBinaries = func(config migration.UploadBinariesConfig) error {
+		for _, curl := range config.Charms {
+			config.Progress.CharmUploaded(curl)
+		}
+		for vers := range config.Tools {
+			config.Progress.ToolsUploaded(vers)
+		}
+		return nil
+	}
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	c.Assert(s.facade.progress, jc.DeepEquals, []coremigration.TransferProgress{
+		{CharmsTotal: 2, ToolsTotal: 1},
+		{Charms: 1, CharmsTotal: 2, ToolsTotal: 1},
+		{Charms: 2, CharmsTotal: 2, ToolsTotal: 1},
+		{Charms: 2, CharmsTotal: 2, Tools: 1, ToolsTotal: 1},
+	})
+}
+
+func (s *Suite) TestImportRetry(c *gc.C) {
+	s.facade.transferRetryTimeout = time.Minute
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.status = append(s.facade.status, s.makeStatus(coremigration.IMPORT))
+	retryStatus := s.makeStatus(coremigration.IMPORT)
+	retryStatus.Retries = 1
+	s.facade.status = append(s.facade.status, retryStatus)
+	// Stop the migration once the transfer has completed.
+	s.facade.processRelationsErr = errors.New("stop")
+
+	attempts := 0
+	s.config.UploadBinaries = func(config migration.UploadBinariesConfig) error {
+		s.stub.AddCall("UploadBinaries", config.Charms)
+		attempts++
+		config.Progress.CharmUploaded(config.Charms[0])
+		if attempts == 1 {
+			return errors.New("boom")
+		}
+		return nil
+	}
+	go func() {
+		// Wait for the worker to start polling for a retry.
+		err := s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 2)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MinionReportTimeout", nil},
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			{"UploadBinaries", []interface{}{[]string{"charm0", "charm1"}}},
+			apiCloseCall,
+			{"facade.TransferRetryTimeout", nil},
+			{"facade.MigrationStatus", nil},
+			{"facade.MigrationStatus", nil},
+			// The model isn't imported again, and only the charm
+			// which wasn't uploaded is sent.
+			apiOpenControllerCall,
+			{"UploadBinaries", []interface{}{[]string{"charm1"}}},
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.PROCESSRELATIONS}},
+			{"facade.ProcessRelations", []interface{}{""}},
+		},
+		abortCalls,
+	))
+	c.Assert(s.facade.statuses[3:5], jc.DeepEquals, []string{
+		"model data transfer failed, failed to migrate binaries: boom; waiting 1m0s for the transfer to be retried",
+		"retrying model data transfer",
+	})
+	c.Assert(s.facade.progress[len(s.facade.progress)-1], gc.Equals, coremigration.TransferProgress{
+		Charms:      2,
+		CharmsTotal: 2,
+		ToolsTotal:  1,
+	})
+}
+
+func (s *Suite) TestImportRetryAfterFailedImport(c *gc.C) {
+	s.facade.transferRetryTimeout = time.Minute
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.status = append(s.facade.status, s.makeStatus(coremigration.IMPORT))
+	retryStatus := s.makeStatus(coremigration.IMPORT)
+	retryStatus.Retries = 1
+	s.facade.status = append(s.facade.status, retryStatus)
+	s.connection.importErrs = []error{errors.New("boom")}
+	s.config.UploadBinaries = func(migration.UploadBinariesConfig) error { return nil }
+	// Stop the migration once the transfer has completed.
+	s.facade.processRelationsErr = errors.New("stop")
+	go func() {
+		// Wait for the worker to start polling for a retry.
+		err := s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 2)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MinionReportTimeout", nil},
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			apiCloseCall,
+			{"facade.TransferRetryTimeout", nil},
+			{"facade.MigrationStatus", nil},
+			{"facade.MigrationStatus", nil},
+			// The partially imported model is removed from the
+			// target before the model is imported again.
+			apiOpenControllerCall,
+			abortCall,
+			importCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.PROCESSRELATIONS}},
+			{"facade.ProcessRelations", []interface{}{""}},
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestImportRetryRequestedDuringTransfer(c *gc.C) {
+	s.facade.transferRetryTimeout = 15 * time.Second
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	// A retry was requested while the transfer was running, before
+	// it failed; it isn't taken as a retry of the failure.
+	retryStatus := s.makeStatus(coremigration.IMPORT)
+	retryStatus.Retries = 1
+	s.facade.status = append(s.facade.status, retryStatus, retryStatus)
+	s.connection.importErr = errors.New("boom")
+	go func() {
+		// Poll once for a retry, then time out.
+		err := s.clock.WaitAdvance(10*time.Second, coretesting.LongWait, 2)
+		c.Check(err, jc.ErrorIsNil)
+		err = s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 2)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MinionReportTimeout", nil},
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			apiCloseCall,
+			{"facade.TransferRetryTimeout", nil},
+			{"facade.MigrationStatus", nil},
+			{"facade.MigrationStatus", nil},
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestImportRetryTimeout(c *gc.C) {
+	// The timeout is less than the retry poll interval, so the
+	// migration is aborted without checking for a retry.
+	s.facade.transferRetryTimeout = 5 * time.Second
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.status = append(s.facade.status, s.makeStatus(coremigration.IMPORT))
+	s.connection.importErr = errors.New("boom")
+	go func() {
+		// Wait for the worker to start waiting for a retry.
+		err := s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 2)
+		c.Check(err, jc.ErrorIsNil)
+	}()
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MinionReportTimeout", nil},
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			apiCloseCall,
+			{"facade.TransferRetryTimeout", nil},
+			{"facade.MigrationStatus", nil},
+		},
+		abortCalls,
+	))
+	c.Assert(s.facade.statuses[2:], jc.DeepEquals, []string{
+		"model data transfer failed, failed to import model into target controller: boom; waiting 5s for the transfer to be retried",
+		"aborted, removing model from target controller: model data transfer failed, failed to import model into target controller: boom",
+	})
+}
+
 func (s *Suite) TestVALIDATIONMinionWaitWatchError(c *gc.C) {
 	s.checkMinionWaitWatchError(c, coremigration.VALIDATION)
 }
@@ -967,6 +1161,11 @@ func (s *Suite) TestLogTransferSendsRecords(c *gc.C) {
 		},
 	})
 	c.Assert(s.connection.logStream.closeCount, gc.Equals, 1)
+	c.Assert(s.facade.progress, gc.Not(gc.HasLen), 0)
+	c.Assert(s.facade.progress[len(s.facade.progress)-1], gc.Equals, coremigration.TransferProgress{
+		LogMessages: 3,
+		LogBytes:    40,
+	})
 }
 
 func (s *Suite) TestLogTransferReportsProgress(c *gc.C) {
@@ -1139,10 +1338,12 @@ type stubMasterFacade struct {
 	minionReports         []coremigration.MinionReports
 	minionReportsErr      error
 	minionReportTimeout   time.Duration
+	transferRetryTimeout  time.Duration
 
 	exportedResources []coremigration.SerializedModelResource
 
 	statuses []string
+	progress []coremigration.TransferProgress
 }
 
 func (f *stubMasterFacade) triggerWatcher() {
@@ -1219,6 +1420,11 @@ func (f *stubMasterFacade) MinionReportTimeout() (time.Duration, error) {
 	return f.minionReportTimeout, nil
 }
 
+func (f *stubMasterFacade) TransferRetryTimeout() (time.Duration, error) {
+	f.stub.AddCall("facade.TransferRetryTimeout")
+	return f.transferRetryTimeout, nil
+}
+
 func (f *stubMasterFacade) Prechecks() error {
 	f.stub.AddCall("facade.Prechecks")
 	return f.prechecksErr
@@ -1270,6 +1476,11 @@ func (f *stubMasterFacade) SetStatusMessage(message string) error {
 	return nil
 }
 
+func (f *stubMasterFacade) SetProgress(progress coremigration.TransferProgress) error {
+	f.progress = append(f.progress, progress)
+	return nil
+}
+
 func (f *stubMasterFacade) Reap() error {
 	f.stub.AddCall("facade.Reap")
 	return nil
@@ -1313,6 +1524,8 @@ type stubConnection struct {
 	stub                *jujutesting.Stub
 	prechecksErr        error
 	importErr           error
+	importErrs          []error
+	abortErr            error
 	processRelationsErr error
 	controllerTag       names.ControllerTag
 
@@ -1338,7 +1551,14 @@ func (c *stubConnection) APICall(objType string, _ int, _, request string, args,
 		case "Prechecks":
 			return c.prechecksErr
 		case "Import":
+			if len(c.importErrs) > 0 {
+				err := c.importErrs[0]
+				c.importErrs = c.importErrs[1:]
+				return err
+			}
 			return c.importErr
+		case "Abort":
+			return c.abortErr
 		case "ProcessRelations":
 			return c.processRelationsErr
 		case "Activate", "AdoptResources":
@@ -525,15 +525,27 @@ func inStrings(t string, ss []string) bool {
 }
 
 // apiServerHostPorts returns the host-ports for each apiserver controller.
+//
+// Controllers which no longer want a vote are being removed, so their
+// addresses aren't included unless there are no others, so that agents
+// and the raft cluster stop using them before they go away.
 func (w *pgWorker) apiServerHostPorts() map[string]network.SpaceHostPorts {
 	servers := make(map[string]network.SpaceHostPorts)
+	leaving := make(map[string]network.SpaceHostPorts)
 	for _, m := range w.controllerTrackers {
 		hostPorts := network.SpaceAddressesWithPort(m.Addresses(), w.config.APIPort)
 		if len(hostPorts) == 0 {
 			continue
 		}
+		if !m.WantsVote() {
+			leaving[m.Id()] = hostPorts
+			continue
+		}
 		servers[m.Id()] = hostPorts
 	}
+	if len(servers) == 0 {
+		return leaving
+	}
 	return servers
 }
 
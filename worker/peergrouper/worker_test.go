@@ -521,6 +521,43 @@ func (s *workerSuite) TestControllersArePublished(c *gc.C) {
 	})
 }
 
+func (s *workerSuite) TestLeavingControllersAreNotPublished(c *gc.C) {
+	publishCh := make(chan []network.SpaceHostPorts, 10)
+	publish := func(apiServers []network.SpaceHostPorts) error {
+		publishCh <- apiServers
+		return nil
+	}
+
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+	w := s.newWorker(c, st, st.session, SetAPIHostPortsFunc(publish), true)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case servers := <-publishCh:
+		AssertAPIHostPorts(c, servers, ExpectedAPIHostPorts(3, testIPv4))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for publish")
+	}
+
+	// A controller which is being removed no longer wants the vote,
+	// and its addresses are withdrawn.
+	st.controller("12").setWantsVote(false)
+	timeout := time.After(coretesting.LongWait)
+	for {
+		select {
+		case servers := <-publishCh:
+			if len(servers) == 3 {
+				continue
+			}
+			AssertAPIHostPorts(c, servers, ExpectedAPIHostPorts(2, testIPv4))
+			return
+		case <-timeout:
+			c.Fatalf("timed out waiting for publish")
+		}
+	}
+}
+
 func (s *workerSuite) TestControllersArePublishedOverHub(c *gc.C) {
 	st := NewFakeState()
 	InitState(c, st, 3, testIPv4)
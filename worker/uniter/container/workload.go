@@ -31,13 +31,37 @@ type WorkloadEventType int
 const (
 	// ReadyEvent is triggered when the container/pebble starts up.
 	ReadyEvent WorkloadEventType = iota
+
+	// CheckFailedEvent is triggered when a Pebble check in the container
+	// starts failing.
+	CheckFailedEvent
+
+	// CheckRecoveredEvent is triggered when a failing Pebble check in the
+	// container succeeds again.
+	CheckRecoveredEvent
 )
 
+// hookKind returns the kind of hook run for the event type.
+func (t WorkloadEventType) hookKind() (hooks.Kind, error) {
+	switch t {
+	case ReadyEvent:
+		return hooks.PebbleReady, nil
+	case CheckFailedEvent:
+		return hook.PebbleCheckFailed, nil
+	case CheckRecoveredEvent:
+		return hook.PebbleCheckRecovered, nil
+	}
+	return "", errors.NotValidf("workload event type %v", t)
+}
+
 // WorkloadEvent contains information about the event type and data associated with
 // the event.
 type WorkloadEvent struct {
 	Type         WorkloadEventType
 	WorkloadName string
+
+	// CheckName is the name of the Pebble check for check events.
+	CheckName string
 }
 
 // WorkloadEventCallback is the type used to callback when an event has been processed.
@@ -139,7 +163,7 @@ func (r *workloadHookResolver) NextOp(
 	noOp := func() (operation.Operation, error) {
 		if localState.Kind == operation.RunHook &&
 			localState.Hook != nil &&
-			localState.Hook.Kind == hooks.PebbleReady {
+			hook.IsWorkload(localState.Hook.Kind) {
 			// If we are resuming from an unexpected state, skip hook.
 			return opFactory.NewSkipHook(*localState.Hook)
 		}
@@ -149,7 +173,7 @@ func (r *workloadHookResolver) NextOp(
 	case operation.RunHook:
 		if localState.Step != operation.Pending ||
 			localState.Hook == nil ||
-			localState.Hook.Kind != hooks.PebbleReady {
+			!hook.IsWorkload(localState.Hook.Kind) {
 			break
 		}
 		fallthrough
@@ -161,8 +185,9 @@ func (r *workloadHookResolver) NextOp(
 			} else if err != nil {
 				return nil, errors.Trace(err)
 			}
-			if evt.Type != ReadyEvent {
-				return nil, errors.NotValidf("workload event type %v", evt.Type)
+			kind, err := evt.Type.hookKind()
+			if err != nil {
+				return nil, errors.Trace(err)
 			}
 			done := func(err error) {
 				cb(err)
@@ -172,8 +197,9 @@ func (r *workloadHookResolver) NextOp(
 				}
 			}
 			op, err := opFactory.NewRunHook(hook.Info{
-				Kind:         hooks.PebbleReady,
+				Kind:         kind,
 				WorkloadName: evt.WorkloadName,
+				CheckName:    evt.CheckName,
 			})
 			if err != nil {
 				done(err)
@@ -4,6 +4,7 @@
 package container_test
 
 import (
+	"github.com/juju/charm/v9/hooks"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -87,3 +88,44 @@ func (s *workloadSuite) TestWorkloadReadyHook(c *gc.C) {
 		WorkloadName: "test",
 	})
 }
+
+func (s *workloadSuite) TestWorkloadCheckHooks(c *gc.C) {
+	for _, test := range []struct {
+		eventType container.WorkloadEventType
+		kind      hooks.Kind
+	}{
+		{container.CheckFailedEvent, "pebble-check-failed"},
+		{container.CheckRecoveredEvent, "pebble-check-recovered"},
+	} {
+		c.Logf("event type %v", test.eventType)
+		events := container.NewWorkloadEvents()
+		containerResolver := container.NewWorkloadHookResolver(
+			loggo.GetLogger("test"),
+			events,
+			events.RemoveWorkloadEvent)
+		localState := resolver.LocalState{
+			State: operation.State{
+				Kind: operation.Continue,
+				Step: operation.Pending,
+			},
+		}
+		remoteState := remotestate.Snapshot{
+			WorkloadEvents: []string{
+				events.AddWorkloadEvent(container.WorkloadEvent{
+					Type:         test.eventType,
+					WorkloadName: "test",
+					CheckName:    "ready",
+				}, func(error) {}),
+			},
+		}
+		op, err := containerResolver.NextOp(localState, remoteState, &mockOperations{})
+		c.Assert(err, jc.ErrorIsNil)
+		hookOp, ok := operation.Unwrap(op).(*mockRunHookOp)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(hookOp.hookInfo, gc.DeepEquals, hook.Info{
+			Kind:         test.kind,
+			WorkloadName: "test",
+			CheckName:    "ready",
+		})
+	}
+}
@@ -16,8 +16,23 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// PebbleCheckFailed and PebbleCheckRecovered are run when a Pebble
+	// health check in a workload container starts or stops failing.
+	PebbleCheckFailed    hooks.Kind = "pebble-check-failed"
+	PebbleCheckRecovered hooks.Kind = "pebble-check-recovered"
 )
 
+// IsWorkload returns whether the hook kind is run in response to an
+// event in a workload container.
+func IsWorkload(kind hooks.Kind) bool {
+	switch kind {
+	case PebbleCheckFailed, PebbleCheckRecovered:
+		return true
+	}
+	return kind.IsWorkload()
+}
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -48,6 +63,10 @@ type Info struct {
 
 	// WorkloadName is the name of the sidecar container or workload relevant to the hook.
 	WorkloadName string `yaml:"workload-name,omitempty"`
+
+	// CheckName is the name of the Pebble check relevant to the hook. It
+	// is only set when Kind indicates a Pebble check hook.
+	CheckName string `yaml:"check-name,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -75,6 +94,14 @@ func (hi Info) Validate() error {
 			return fmt.Errorf("%q hook requires a workload name", hi.Kind)
 		}
 		return nil
+	case PebbleCheckFailed, PebbleCheckRecovered:
+		if hi.WorkloadName == "" {
+			return fmt.Errorf("%q hook requires a workload name", hi.Kind)
+		}
+		if hi.CheckName == "" {
+			return fmt.Errorf("%q hook requires a check name", hi.Kind)
+		}
+		return nil
 	case hooks.Install, hooks.Remove, hooks.Start, hooks.ConfigChanged, hooks.UpgradeCharm, hooks.Stop, hooks.RelationCreated, hooks.RelationBroken,
 		hooks.CollectMetrics, hooks.MeterStatusChanged, hooks.UpdateStatus, hooks.PreSeriesUpgrade, hooks.PostSeriesUpgrade:
 		return nil
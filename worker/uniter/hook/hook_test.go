@@ -46,6 +46,12 @@ var validateTests = []struct {
 	}, {
 		hook.Info{Kind: hooks.PebbleReady},
 		`"pebble-ready" hook requires a workload name`,
+	}, {
+		hook.Info{Kind: hook.PebbleCheckFailed, CheckName: "ready"},
+		`"pebble-check-failed" hook requires a workload name`,
+	}, {
+		hook.Info{Kind: hook.PebbleCheckRecovered, WorkloadName: "gitlab"},
+		`"pebble-check-recovered" hook requires a check name`,
 	},
 	{hook.Info{Kind: hooks.Install}, ""},
 	{hook.Info{Kind: hooks.Start}, ""},
@@ -65,6 +71,8 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.PebbleReady, WorkloadName: "gitlab"}, ""},
+	{hook.Info{Kind: hook.PebbleCheckFailed, WorkloadName: "gitlab", CheckName: "ready"}, ""},
+	{hook.Info{Kind: hook.PebbleCheckRecovered, WorkloadName: "gitlab", CheckName: "ready"}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -78,3 +86,10 @@ func (s *InfoSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *InfoSuite) TestIsWorkload(c *gc.C) {
+	c.Assert(hook.IsWorkload(hooks.PebbleReady), jc.IsTrue)
+	c.Assert(hook.IsWorkload(hook.PebbleCheckFailed), jc.IsTrue)
+	c.Assert(hook.IsWorkload(hook.PebbleCheckRecovered), jc.IsTrue)
+	c.Assert(hook.IsWorkload(hooks.Install), jc.IsFalse)
+}
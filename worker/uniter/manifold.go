@@ -35,6 +35,7 @@ type Logger interface {
 	Tracef(string, ...interface{})
 
 	Child(string) loggo.Logger
+	Root() loggo.Logger
 }
 
 // ManifoldConfig defines the names of the manifolds on which a
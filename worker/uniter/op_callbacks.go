@@ -30,7 +30,7 @@ type operationCallbacks struct {
 func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 	name := string(hi.Kind)
 	switch {
-	case hi.Kind.IsWorkload():
+	case hook.IsWorkload(hi.Kind):
 		name = fmt.Sprintf("%s-%s", hi.WorkloadName, hi.Kind)
 	case hi.Kind.IsRelation():
 		var err error
@@ -62,7 +62,7 @@ func (opc *operationCallbacks) PrepareHook(hi hook.Info) (string, error) {
 // CommitHook is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) CommitHook(hi hook.Info) error {
 	switch {
-	case hi.Kind.IsWorkload():
+	case hook.IsWorkload(hi.Kind):
 	case hi.Kind.IsRelation():
 		return opc.u.relationStateTracker.CommitHook(hi)
 	case hi.Kind.IsStorage():
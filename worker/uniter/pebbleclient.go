@@ -0,0 +1,153 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/canonical/pebble/client"
+	"github.com/juju/errors"
+)
+
+// CheckStatus is the status of a Pebble health check.
+type CheckStatus string
+
+const (
+	// CheckStatusUp means the check is passing.
+	CheckStatusUp CheckStatus = "up"
+
+	// CheckStatusDown means the check has failed at least as many times
+	// in a row as its threshold.
+	CheckStatusDown CheckStatus = "down"
+)
+
+// CheckInfo holds the result of a Pebble health check.
+type CheckInfo struct {
+	Name      string      `json:"name"`
+	Level     string      `json:"level,omitempty"`
+	Status    CheckStatus `json:"status"`
+	Failures  int         `json:"failures,omitempty"`
+	Threshold int         `json:"threshold"`
+}
+
+// LogEntry is a line of output written by a Pebble service.
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Service string    `json:"service"`
+	Message string    `json:"message"`
+}
+
+// pebbleRequestTimeout is how long a request to Pebble may take before
+// it is abandoned, so that a hung Pebble doesn't stop the container
+// being polled. Following the logs is only bounded until Pebble starts
+// responding, as the stream may be quiet for any length of time.
+var pebbleRequestTimeout = 30 * time.Second
+
+// pebbleClient extends the Pebble client with the checks and logs APIs,
+// which the client version we use doesn't provide.
+type pebbleClient struct {
+	*client.Client
+
+	// http is used to follow the logs, and requests for everything
+	// else, which must complete within pebbleRequestTimeout.
+	http     *http.Client
+	requests *http.Client
+}
+
+// newDefaultPebbleClient returns a client for the Pebble socket in the
+// config.
+func newDefaultPebbleClient(config *client.Config) PebbleClient {
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", config.Socket)
+		},
+		ResponseHeaderTimeout: pebbleRequestTimeout,
+	}
+	requests := &http.Client{Transport: transport, Timeout: pebbleRequestTimeout}
+	// Requests made through the Pebble client, such as for the system
+	// info and services, are bounded too.
+	pc := client.New(config)
+	pc.Hijack(requests.Do)
+	return &pebbleClient{
+		Client:   pc,
+		http:     &http.Client{Transport: transport},
+		requests: requests,
+	}
+}
+
+// Checks returns the results of the container's health checks. Versions
+// of Pebble without health checks report none.
+func (c *pebbleClient) Checks() ([]*CheckInfo, error) {
+	resp, err := c.requests.Get("http://localhost/v1/checks")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	var result struct {
+		Type   string          `json:"type"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, errors.Annotate(err, "decoding checks")
+	}
+	if result.Type != "sync" {
+		var pebbleErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(result.Result, &pebbleErr)
+		return nil, errors.Errorf("cannot get checks: %s", pebbleErr.Message)
+	}
+	var checks []*CheckInfo
+	if err := json.Unmarshal(result.Result, &checks); err != nil {
+		return nil, errors.Annotate(err, "decoding checks")
+	}
+	return checks, nil
+}
+
+// Logs returns a stream of the container's service logs, as JSON
+// encoded LogEntry values. The stream starts with up to n of the most
+// recent entries and, if follow is true, continues with entries as they
+// are written. The caller must close the stream. An error satisfying
+// errors.IsNotSupported is returned by versions of Pebble without logs.
+func (c *pebbleClient) Logs(n int, follow bool) (io.ReadCloser, error) {
+	query := url.Values{"n": {strconv.Itoa(n)}}
+	if follow {
+		query.Set("follow", "true")
+	}
+	resp, err := c.http.Get("http://localhost/v1/logs?" + query.Encode())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.NotSupportedf("pebble logs")
+	}
+	var result struct {
+		Result struct {
+			Message string `json:"message"`
+		} `json:"result"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&result)
+	return nil, errors.Errorf("cannot get logs: %s", result.Result.Message)
+}
+
+// CloseIdleConnections is part of the PebbleClient interface.
+func (c *pebbleClient) CloseIdleConnections() {
+	c.Client.CloseIdleConnections()
+	c.http.CloseIdleConnections()
+}
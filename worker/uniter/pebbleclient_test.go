@@ -0,0 +1,178 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"time"
+
+	"github.com/canonical/pebble/client"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+type pebbleClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&pebbleClientSuite{})
+
+func (s *pebbleClientSuite) serve(c *gc.C, handler http.HandlerFunc) PebbleClient {
+	socket := filepath.Join(c.MkDir(), "pebble.socket")
+	listener, err := net.Listen("unix", socket)
+	c.Assert(err, jc.ErrorIsNil)
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener = listener
+	server.Start()
+	s.AddCleanup(func(*gc.C) { server.Close() })
+	return newDefaultPebbleClient(&client.Config{Socket: socket})
+}
+
+func (s *pebbleClientSuite) TestChecks(c *gc.C) {
+	pc := s.serve(c, func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, gc.Equals, "/v1/checks")
+		fmt.Fprint(w, `{"type": "sync", "status-code": 200, "result": [
+			{"name": "ready", "level": "ready", "status": "down", "failures": 3, "threshold": 3},
+			{"name": "online", "status": "up", "threshold": 3}
+		]}`)
+	})
+	defer pc.CloseIdleConnections()
+
+	checks, err := pc.Checks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, jc.DeepEquals, []*CheckInfo{
+		{Name: "ready", Level: "ready", Status: CheckStatusDown, Failures: 3, Threshold: 3},
+		{Name: "online", Status: CheckStatusUp, Threshold: 3},
+	})
+}
+
+func (s *pebbleClientSuite) TestChecksNotSupported(c *gc.C) {
+	pc := s.serve(c, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"type": "error", "status-code": 404, "result": {"message": "not found"}}`)
+	})
+	defer pc.CloseIdleConnections()
+
+	checks, err := pc.Checks()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 0)
+}
+
+func (s *pebbleClientSuite) TestChecksError(c *gc.C) {
+	pc := s.serve(c, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"type": "error", "status-code": 500, "result": {"message": "boom"}}`)
+	})
+	defer pc.CloseIdleConnections()
+
+	_, err := pc.Checks()
+	c.Assert(err, gc.ErrorMatches, "cannot get checks: boom")
+}
+
+func (s *pebbleClientSuite) TestChecksTimeout(c *gc.C) {
+	s.PatchValue(&pebbleRequestTimeout, 10*time.Millisecond)
+	pc := s.serve(c, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	defer pc.CloseIdleConnections()
+
+	_, err := pc.Checks()
+	c.Assert(err, gc.ErrorMatches, ".*(Client.Timeout exceeded|timeout awaiting response headers).*")
+}
+
+func (s *pebbleClientSuite) TestLogs(c *gc.C) {
+	pc := s.serve(c, func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.URL.Path, gc.Equals, "/v1/logs")
+		c.Check(r.URL.Query().Get("n"), gc.Equals, "10")
+		c.Check(r.URL.Query().Get("follow"), gc.Equals, "true")
+		fmt.Fprintln(w, `{"time": "2021-05-03T03:55:49.360994155Z", "service": "web", "message": "started\n"}`)
+	})
+	defer pc.CloseIdleConnections()
+
+	logs, err := pc.Logs(10, true)
+	c.Assert(err, jc.ErrorIsNil)
+	defer logs.Close()
+	var entry LogEntry
+	err = json.NewDecoder(logs).Decode(&entry)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entry, jc.DeepEquals, LogEntry{
+		Time:    time.Date(2021, 5, 3, 3, 55, 49, 360994155, time.UTC),
+		Service: "web",
+		Message: "started\n",
+	})
+}
+
+func (s *pebbleClientSuite) TestLogsNotSupported(c *gc.C) {
+	pc := s.serve(c, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"type": "error", "status-code": 404, "result": {"message": "not found"}}`)
+	})
+	defer pc.CloseIdleConnections()
+
+	_, err := pc.Logs(10, true)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *pebbleClientSuite) TestLogsError(c *gc.C) {
+	pc := s.serve(c, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"type": "error", "status-code": 400, "result": {"message": "invalid n"}}`)
+	})
+	defer pc.CloseIdleConnections()
+
+	_, err := pc.Logs(10, true)
+	c.Assert(err, gc.ErrorMatches, "cannot get logs: invalid n")
+}
+
+func (s *pebbleClientSuite) TestLogsTimeout(c *gc.C) {
+	s.PatchValue(&pebbleRequestTimeout, 10*time.Millisecond)
+	pc := s.serve(c, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	defer pc.CloseIdleConnections()
+
+	_, err := pc.Logs(10, true)
+	c.Assert(err, gc.ErrorMatches, ".*timeout awaiting response headers.*")
+}
+
+func (s *pebbleClientSuite) TestLogsFollowNotTimedOut(c *gc.C) {
+	s.PatchValue(&pebbleRequestTimeout, 10*time.Millisecond)
+	pc := s.serve(c, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(50 * time.Millisecond)
+		fmt.Fprintln(w, `{"time": "2021-05-03T03:55:49.360994155Z", "service": "web", "message": "started\n"}`)
+	})
+	defer pc.CloseIdleConnections()
+
+	logs, err := pc.Logs(10, true)
+	c.Assert(err, jc.ErrorIsNil)
+	defer logs.Close()
+	var entry LogEntry
+	err = json.NewDecoder(logs).Decode(&entry)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entry.Service, gc.Equals, "web")
+}
+
+func (s *pebbleClientSuite) TestSysInfoAndServicesTimeout(c *gc.C) {
+	s.PatchValue(&pebbleRequestTimeout, 10*time.Millisecond)
+	restore := client.FakeDoRetry(time.Millisecond, time.Millisecond)
+	s.AddCleanup(func(*gc.C) { restore() })
+	pc := s.serve(c, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	defer pc.CloseIdleConnections()
+
+	_, err := pc.SysInfo()
+	c.Assert(err, gc.ErrorMatches, ".*Client.Timeout exceeded.*")
+	_, err = pc.Services(&client.ServicesOptions{})
+	c.Assert(err, gc.ErrorMatches, ".*Client.Timeout exceeded.*")
+}
@@ -0,0 +1,187 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/canonical/pebble/client"
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/worker/v2"
+	"gopkg.in/tomb.v2"
+)
+
+const (
+	// pebbleLogBacklog is the number of buffered log entries requested
+	// from Pebble when tailing of a container starts.
+	pebbleLogBacklog = 100
+
+	// pebbleLogRetryDelay is the delay before tailing is retried after
+	// the connection to Pebble is lost.
+	pebbleLogRetryDelay = 5 * time.Second
+)
+
+type pebbleLogTailer struct {
+	logger          Logger
+	workloadLogger  loggo.Logger
+	clock           clock.Clock
+	tomb            tomb.Tomb
+	newPebbleClient NewPebbleClientFunc
+
+	mut       sync.Mutex
+	positions map[string]*logPosition
+}
+
+// logPosition records how far through a container's logs the entries
+// have been written.
+type logPosition struct {
+	time time.Time
+
+	// logged holds the entries with the position's time that have
+	// been written, as several entries may share a timestamp.
+	logged map[logEntryKey]bool
+}
+
+// logEntryKey identifies a log entry.
+type logEntryKey struct {
+	time    time.Time
+	service string
+	message string
+}
+
+// add records that the entry has been written, returning false if it
+// already had been.
+func (pos *logPosition) add(entry LogEntry) bool {
+	key := logEntryKey{time: entry.Time, service: entry.Service, message: entry.Message}
+	switch {
+	case entry.Time.Before(pos.time):
+		return false
+	case entry.Time.After(pos.time):
+		pos.time = entry.Time
+		pos.logged = make(map[logEntryKey]bool)
+	case pos.logged[key]:
+		return false
+	}
+	pos.logged[key] = true
+	return true
+}
+
+// NewPebbleLogTailer starts a worker that follows the service logs of
+// the Pebble in each of the supplied containers, writing each line to a
+// child of workloadLogger named for the container and the service, so
+// that it is sent to the controller with the rest of the agent's logs.
+// Only entries written after the worker starts are logged, as earlier
+// entries will have been logged by the agent before it restarted.
+func NewPebbleLogTailer(logger Logger,
+	workloadLogger loggo.Logger,
+	clock clock.Clock,
+	containerNames []string,
+	newPebbleClient NewPebbleClientFunc) worker.Worker {
+	if newPebbleClient == nil {
+		newPebbleClient = newDefaultPebbleClient
+	}
+	p := &pebbleLogTailer{
+		logger:          logger,
+		workloadLogger:  workloadLogger,
+		clock:           clock,
+		newPebbleClient: newPebbleClient,
+		positions:       make(map[string]*logPosition),
+	}
+	now := clock.Now()
+	for _, v := range containerNames {
+		containerName := v
+		p.positions[containerName] = &logPosition{
+			time:   now,
+			logged: make(map[logEntryKey]bool),
+		}
+		p.tomb.Go(func() error {
+			return p.run(containerName)
+		})
+	}
+	return p
+}
+
+// Kill is part of the worker.Worker interface.
+func (p *pebbleLogTailer) Kill() {
+	p.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (p *pebbleLogTailer) Wait() error {
+	return p.tomb.Wait()
+}
+
+func (p *pebbleLogTailer) run(containerName string) error {
+	for {
+		err := p.tail(containerName)
+		if errors.IsNotSupported(err) {
+			p.logger.Debugf("not tailing logs for container %q: %v", containerName, err)
+		} else if err != nil && err != tomb.ErrDying {
+			p.logger.Errorf("pebble log tailing failed for container %q: %v", containerName, err)
+		}
+		select {
+		case <-p.tomb.Dying():
+			return tomb.ErrDying
+		case <-p.clock.After(pebbleLogRetryDelay):
+		}
+	}
+}
+
+// tail follows the container's service logs until the stream ends or
+// the worker is killed. Entries that have already been logged, because
+// they are in the backlog returned when tailing restarts, are skipped.
+func (p *pebbleLogTailer) tail(containerName string) error {
+	config := &client.Config{
+		Socket: path.Join("/charm/containers", containerName, "pebble.socket"),
+	}
+	pc := p.newPebbleClient(config)
+	defer pc.CloseIdleConnections()
+	logs, err := pc.Logs(pebbleLogBacklog, true)
+	if err != nil {
+		return errors.Annotatef(err, "failed to get pebble logs")
+	}
+
+	// Closing the stream unblocks the decoder when the worker is killed.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-p.tomb.Dying():
+		case <-done:
+		}
+		_ = logs.Close()
+	}()
+
+	decoder := json.NewDecoder(logs)
+	for {
+		var entry LogEntry
+		err := decoder.Decode(&entry)
+		select {
+		case <-p.tomb.Dying():
+			return tomb.ErrDying
+		default:
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Annotatef(err, "failed to read pebble logs")
+		}
+
+		p.mut.Lock()
+		added := p.positions[containerName].add(entry)
+		p.mut.Unlock()
+		if !added {
+			continue
+		}
+		logger := p.workloadLogger.Child(containerName).Child(entry.Service)
+		logger.Infof("%s", strings.TrimRight(entry.Message, "\n"))
+	}
+}
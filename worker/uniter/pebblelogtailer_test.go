@@ -0,0 +1,128 @@
+// Copyright 2021 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	pebbleclient "github.com/canonical/pebble/client"
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter"
+)
+
+type pebbleLogTailerSuite struct{}
+
+var _ = gc.Suite(&pebbleLogTailerSuite{})
+
+func (s *pebbleLogTailerSuite) TestTail(c *gc.C) {
+	start := time.Date(2021, 5, 3, 3, 55, 49, 0, time.UTC)
+	client := &fakePebbleClient{
+		logs: []uniter.LogEntry{
+			{Time: start, Service: "web", Message: "listening\n"},
+			{Time: start.Add(time.Second), Service: "cron", Message: "tick\n"},
+		},
+	}
+	newClient := func(cfg *pebbleclient.Config) uniter.PebbleClient {
+		c.Check(cfg.Socket, gc.Equals, "/charm/containers/a/pebble.socket")
+		return client
+	}
+	logContext := loggo.NewContext(loggo.DEBUG)
+	writer := &loggo.TestWriter{}
+	err := logContext.AddWriter("test", writer)
+	c.Assert(err, jc.ErrorIsNil)
+	workloadLogger := logContext.GetLogger("unit.foo/0")
+
+	clock := testclock.NewClock(time.Time{})
+	worker := uniter.NewPebbleLogTailer(loggo.GetLogger("test"), workloadLogger, clock, []string{"a"}, newClient)
+	defer workertest.CleanKill(c, worker)
+
+	waitLogs := func(expected []loggo.Entry) {
+		timeout := time.After(testing.LongWait)
+		for {
+			var got []loggo.Entry
+			for _, entry := range writer.Log() {
+				got = append(got, loggo.Entry{
+					Level:   entry.Level,
+					Module:  entry.Module,
+					Message: entry.Message,
+				})
+			}
+			if len(got) >= len(expected) {
+				c.Assert(got, jc.DeepEquals, expected)
+				return
+			}
+			select {
+			case <-time.After(testing.ShortWait):
+				clock.Advance(5 * time.Second)
+			case <-timeout:
+				c.Fatalf("timed out waiting for logs, got %v", got)
+			}
+		}
+	}
+	waitLogs([]loggo.Entry{
+		{Level: loggo.INFO, Module: "unit.foo/0.a.web", Message: "listening"},
+		{Level: loggo.INFO, Module: "unit.foo/0.a.cron", Message: "tick"},
+	})
+
+	// Entries already logged are skipped when tailing restarts.
+	client.addLogs(uniter.LogEntry{Time: start.Add(2 * time.Second), Service: "web", Message: "stopping\n"})
+	waitLogs([]loggo.Entry{
+		{Level: loggo.INFO, Module: "unit.foo/0.a.web", Message: "listening"},
+		{Level: loggo.INFO, Module: "unit.foo/0.a.cron", Message: "tick"},
+		{Level: loggo.INFO, Module: "unit.foo/0.a.web", Message: "stopping"},
+	})
+}
+
+func (s *pebbleLogTailerSuite) TestTailSkipsEarlierEntries(c *gc.C) {
+	start := time.Date(2021, 5, 3, 3, 55, 49, 0, time.UTC)
+	client := &fakePebbleClient{
+		logs: []uniter.LogEntry{
+			{Time: start.Add(-time.Second), Service: "web", Message: "logged before the restart\n"},
+			{Time: start, Service: "web", Message: "one\n"},
+			{Time: start, Service: "web", Message: "two\n"},
+			{Time: start, Service: "cron", Message: "one\n"},
+		},
+	}
+	newClient := func(cfg *pebbleclient.Config) uniter.PebbleClient {
+		return client
+	}
+	logContext := loggo.NewContext(loggo.DEBUG)
+	writer := &loggo.TestWriter{}
+	err := logContext.AddWriter("test", writer)
+	c.Assert(err, jc.ErrorIsNil)
+	workloadLogger := logContext.GetLogger("unit.foo/0")
+
+	clock := testclock.NewClock(start)
+	worker := uniter.NewPebbleLogTailer(loggo.GetLogger("test"), workloadLogger, clock, []string{"a"}, newClient)
+	defer workertest.CleanKill(c, worker)
+
+	// Entries sharing a timestamp are each logged once, however many
+	// times tailing restarts.
+	c.Assert(clock.WaitAdvance(5*time.Second, testing.LongWait, 1), jc.ErrorIsNil)
+	client.addLogs(uniter.LogEntry{Time: start, Service: "web", Message: "three\n"})
+	c.Assert(clock.WaitAdvance(5*time.Second, testing.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(clock.WaitAdvance(5*time.Second, testing.LongWait, 1), jc.ErrorIsNil)
+
+	expected := []loggo.Entry{
+		{Level: loggo.INFO, Module: "unit.foo/0.a.web", Message: "one"},
+		{Level: loggo.INFO, Module: "unit.foo/0.a.web", Message: "two"},
+		{Level: loggo.INFO, Module: "unit.foo/0.a.cron", Message: "one"},
+		{Level: loggo.INFO, Module: "unit.foo/0.a.web", Message: "three"},
+	}
+	var got []loggo.Entry
+	for _, entry := range writer.Log() {
+		got = append(got, loggo.Entry{
+			Level:   entry.Level,
+			Module:  entry.Module,
+			Message: entry.Message,
+		})
+	}
+	c.Assert(got, jc.DeepEquals, expected)
+}
@@ -4,16 +4,21 @@
 package uniter
 
 import (
+	"io"
 	"path"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/canonical/pebble/client"
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2"
 	"gopkg.in/tomb.v2"
 
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/worker/uniter/container"
 )
 
@@ -21,9 +26,19 @@ import (
 // need for the PebblePoller.
 type PebbleClient interface {
 	SysInfo() (*client.SysInfo, error)
+	Services(*client.ServicesOptions) ([]*client.ServiceInfo, error)
+	Checks() ([]*CheckInfo, error)
+	Logs(n int, follow bool) (io.ReadCloser, error)
 	CloseIdleConnections()
 }
 
+// ContainerHealthSetter records the health of the unit's workload containers,
+// and recalls the checks last recorded as failing.
+type ContainerHealthSetter interface {
+	SetContainerHealth(containerName string, health status.Status, info string, failingChecks []string) error
+	FailingContainerChecks(containerName string) ([]string, error)
+}
+
 // NewPebbleClientFunc is the function type used to create a PebbleClient.
 type NewPebbleClientFunc func(*client.Config) PebbleClient
 
@@ -32,6 +47,7 @@ type pebblePoller struct {
 	clock           clock.Clock
 	tomb            tomb.Tomb
 	newPebbleClient NewPebbleClientFunc
+	healthSetter    ContainerHealthSetter
 
 	containerNames    []string
 	workloadEventChan chan string
@@ -39,6 +55,8 @@ type pebblePoller struct {
 
 	mut           sync.Mutex
 	pebbleBootIDs map[string]string
+	failingChecks map[string]set.Strings
+	health        map[string]string
 }
 
 const (
@@ -46,17 +64,18 @@ const (
 )
 
 // NewPebblePoller starts a worker that polls the pebble interfaces
-// of the supplied container list.
+// of the supplied container list, queueing workload events when a
+// container starts and when its health checks fail or recover, and
+// reporting the health of each container to the health setter.
 func NewPebblePoller(logger Logger,
 	clock clock.Clock,
 	containerNames []string,
 	workloadEventChan chan string,
 	workloadEvents container.WorkloadEvents,
+	healthSetter ContainerHealthSetter,
 	newPebbleClient NewPebbleClientFunc) worker.Worker {
 	if newPebbleClient == nil {
-		newPebbleClient = func(config *client.Config) PebbleClient {
-			return client.New(config)
-		}
+		newPebbleClient = newDefaultPebbleClient
 	}
 	p := &pebblePoller{
 		logger:            logger,
@@ -64,7 +83,10 @@ func NewPebblePoller(logger Logger,
 		workloadEventChan: workloadEventChan,
 		workloadEvents:    workloadEvents,
 		newPebbleClient:   newPebbleClient,
+		healthSetter:      healthSetter,
 		pebbleBootIDs:     make(map[string]string),
+		failingChecks:     make(map[string]set.Strings),
+		health:            make(map[string]string),
 	}
 	for _, v := range containerNames {
 		containerName := v
@@ -116,15 +138,154 @@ func (p *pebblePoller) poll(containerName string) error {
 	p.mut.Lock()
 	lastBootID, _ := p.pebbleBootIDs[containerName]
 	p.mut.Unlock()
-	if lastBootID == info.BootID {
+	if lastBootID != info.BootID {
+		err := p.sendEvent(container.WorkloadEvent{
+			Type:         container.ReadyEvent,
+			WorkloadName: containerName,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		p.mut.Lock()
+		p.pebbleBootIDs[containerName] = info.BootID
+		p.mut.Unlock()
+	}
+
+	return p.pollHealth(containerName, pc)
+}
+
+// pollHealth queues hooks for the container's checks that have started
+// failing or have recovered since the last poll, and reports the health
+// of the container if it has changed.
+func (p *pebblePoller) pollHealth(containerName string, pc PebbleClient) error {
+	checks, err := pc.Checks()
+	if err != nil {
+		return errors.Annotatef(err, "failed to get pebble checks")
+	}
+	services, err := pc.Services(&client.ServicesOptions{})
+	if err != nil {
+		return errors.Annotatef(err, "failed to get pebble services")
+	}
+
+	failing := set.NewStrings()
+	for _, check := range checks {
+		if check.Status == CheckStatusDown {
+			failing.Add(check.Name)
+		}
+	}
+	var stopped []string
+	for _, service := range services {
+		if service.Startup == client.StartupEnabled && service.Current != client.StatusActive {
+			stopped = append(stopped, service.Name)
+		}
+	}
+	sort.Strings(stopped)
+
+	previous, err := p.previousFailingChecks(containerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range failing.Difference(previous).SortedValues() {
+		if err := p.sendCheckEvent(containerName, name, container.CheckFailedEvent); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, name := range previous.Difference(failing).SortedValues() {
+		if err := p.sendCheckEvent(containerName, name, container.CheckRecoveredEvent); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if p.healthSetter == nil {
 		return nil
 	}
+	health := status.Active
+	var problems []string
+	if !failing.IsEmpty() {
+		problems = append(problems, "failing checks: "+strings.Join(failing.SortedValues(), ", "))
+	}
+	if len(stopped) > 0 {
+		problems = append(problems, "services not running: "+strings.Join(stopped, ", "))
+	}
+	if len(problems) > 0 {
+		health = status.Error
+	}
+	message := strings.Join(problems, "; ")
+	key := health.String() + ":" + message
 
-	errChan := make(chan error, 1)
-	eid := p.workloadEvents.AddWorkloadEvent(container.WorkloadEvent{
-		Type:         container.ReadyEvent,
+	p.mut.Lock()
+	lastHealth := p.health[containerName]
+	p.mut.Unlock()
+	if lastHealth == key {
+		return nil
+	}
+	err = p.healthSetter.SetContainerHealth(containerName, health, message, failing.SortedValues())
+	if errors.IsNotSupported(err) {
+		p.logger.Debugf("not reporting health of container %q: %v", containerName, err)
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to set health")
+	}
+
+	p.mut.Lock()
+	p.health[containerName] = key
+	p.mut.Unlock()
+	return nil
+}
+
+// previousFailingChecks returns the checks of the container which hooks
+// have been run for as failing. Before the container's first poll they
+// are read from the controller, so that a restarted agent neither runs
+// check-failed hooks again nor misses check-recovered hooks.
+func (p *pebblePoller) previousFailingChecks(containerName string) (set.Strings, error) {
+	p.mut.Lock()
+	previous, ok := p.failingChecks[containerName]
+	p.mut.Unlock()
+	if ok || p.healthSetter == nil {
+		return previous, nil
+	}
+	checks, err := p.healthSetter.FailingContainerChecks(containerName)
+	if errors.IsNotSupported(err) {
+		p.logger.Debugf("not reading failing checks of container %q: %v", containerName, err)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "failed to get failing checks")
+	}
+	previous = set.NewStrings(checks...)
+
+	p.mut.Lock()
+	p.failingChecks[containerName] = previous
+	p.mut.Unlock()
+	return previous, nil
+}
+
+// sendCheckEvent queues a hook for the change in the named check, and
+// records its new state once the hook has run.
+func (p *pebblePoller) sendCheckEvent(containerName, checkName string, eventType container.WorkloadEventType) error {
+	err := p.sendEvent(container.WorkloadEvent{
+		Type:         eventType,
 		WorkloadName: containerName,
-	}, func(err error) {
+		CheckName:    checkName,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	failing := set.NewStrings(p.failingChecks[containerName].Values()...)
+	if eventType == container.CheckFailedEvent {
+		failing.Add(checkName)
+	} else {
+		failing.Remove(checkName)
+	}
+	p.failingChecks[containerName] = failing
+	return nil
+}
+
+// sendEvent queues the workload event and waits for its hook to run.
+func (p *pebblePoller) sendEvent(event container.WorkloadEvent) error {
+	errChan := make(chan error, 1)
+	eid := p.workloadEvents.AddWorkloadEvent(event, func(err error) {
 		errChan <- errors.Trace(err)
 	})
 	defer p.workloadEvents.RemoveWorkloadEvent(eid)
@@ -143,10 +304,5 @@ func (p *pebblePoller) poll(containerName string) error {
 	case <-p.tomb.Dying():
 		return tomb.ErrDying
 	}
-
-	p.mut.Lock()
-	p.pebbleBootIDs[containerName] = info.BootID
-	p.mut.Unlock()
-
 	return nil
 }
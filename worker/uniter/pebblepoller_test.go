@@ -4,6 +4,10 @@
 package uniter_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
 	"regexp"
 	"sync"
 	"time"
@@ -17,6 +21,7 @@ import (
 	"github.com/juju/worker/v2/workertest"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/uniter"
 	"github.com/juju/juju/worker/uniter/container"
@@ -66,7 +71,7 @@ func (s *pebblePollerSuite) TestStart(c *gc.C) {
 	}
 	workloadEventChan := make(chan string)
 	workloadEvents := container.NewWorkloadEvents()
-	worker := uniter.NewPebblePoller(loggo.GetLogger("test"), clock, containerNames, workloadEventChan, workloadEvents, newClient)
+	worker := uniter.NewPebblePoller(loggo.GetLogger("test"), clock, containerNames, workloadEventChan, workloadEvents, nil, newClient)
 
 	doRestart := func(containerName string) {
 		client := clients[containerName]
@@ -112,11 +117,191 @@ func (s *pebblePollerSuite) TestStart(c *gc.C) {
 	}
 }
 
+func (s *pebblePollerSuite) TestHealth(c *gc.C) {
+	client := &fakePebbleClient{
+		sysInfo: pebbleclient.SysInfo{BootID: "1"},
+		services: []*pebbleclient.ServiceInfo{{
+			Name:    "web",
+			Startup: pebbleclient.StartupEnabled,
+			Current: pebbleclient.StatusActive,
+		}, {
+			Name:    "cron",
+			Startup: pebbleclient.StartupDisabled,
+			Current: pebbleclient.StatusInactive,
+		}},
+		checks: []*uniter.CheckInfo{
+			{Name: "ready", Status: uniter.CheckStatusUp, Threshold: 3},
+			{Name: "online", Status: uniter.CheckStatusUp, Threshold: 3},
+		},
+	}
+	newClient := func(cfg *pebbleclient.Config) uniter.PebbleClient {
+		return client
+	}
+	clock := testclock.NewClock(time.Time{})
+	workloadEventChan := make(chan string)
+	workloadEvents := container.NewWorkloadEvents()
+	healthSetter := &fakeHealthSetter{health: make(chan containerHealth, 10)}
+	worker := uniter.NewPebblePoller(loggo.GetLogger("test"), clock, []string{"a"}, workloadEventChan, workloadEvents, healthSetter, newClient)
+	defer workertest.CleanKill(c, worker)
+
+	waitEvent := func(expected container.WorkloadEvent) {
+		timeout := time.After(testing.LongWait)
+		for {
+			select {
+			case id := <-workloadEventChan:
+				evt, cb, err := workloadEvents.GetWorkloadEvent(id)
+				c.Assert(err, jc.ErrorIsNil)
+				c.Assert(evt, gc.DeepEquals, expected)
+				workloadEvents.RemoveWorkloadEvent(id)
+				cb(nil)
+				return
+			case <-time.After(testing.ShortWait):
+				clock.Advance(5 * time.Second)
+			case <-timeout:
+				c.Fatalf("timed out waiting for event %v", expected)
+			}
+		}
+	}
+	waitHealth := func(expected containerHealth) {
+		select {
+		case health := <-healthSetter.health:
+			c.Assert(health, jc.DeepEquals, expected)
+		case <-time.After(testing.LongWait):
+			c.Fatalf("timed out waiting for health")
+		}
+	}
+
+	waitEvent(container.WorkloadEvent{Type: container.ReadyEvent, WorkloadName: "a"})
+	waitHealth(containerHealth{name: "a", status: status.Active})
+
+	client.setHealth([]*uniter.CheckInfo{
+		{Name: "ready", Status: uniter.CheckStatusDown, Failures: 3, Threshold: 3},
+		{Name: "online", Status: uniter.CheckStatusDown, Failures: 3, Threshold: 3},
+	}, pebbleclient.StatusError)
+	waitEvent(container.WorkloadEvent{Type: container.CheckFailedEvent, WorkloadName: "a", CheckName: "online"})
+	waitEvent(container.WorkloadEvent{Type: container.CheckFailedEvent, WorkloadName: "a", CheckName: "ready"})
+	waitHealth(containerHealth{
+		name:          "a",
+		status:        status.Error,
+		info:          "failing checks: online, ready; services not running: web",
+		failingChecks: []string{"online", "ready"},
+	})
+
+	client.setHealth([]*uniter.CheckInfo{
+		{Name: "ready", Status: uniter.CheckStatusUp, Threshold: 3},
+		{Name: "online", Status: uniter.CheckStatusDown, Failures: 5, Threshold: 3},
+	}, pebbleclient.StatusActive)
+	waitEvent(container.WorkloadEvent{Type: container.CheckRecoveredEvent, WorkloadName: "a", CheckName: "ready"})
+	waitHealth(containerHealth{
+		name:          "a",
+		status:        status.Error,
+		info:          "failing checks: online",
+		failingChecks: []string{"online"},
+	})
+}
+
+func (s *pebblePollerSuite) TestHealthAfterRestart(c *gc.C) {
+	// The "ready" check was failing, and its hook run, before the agent
+	// restarted. It has since recovered; "online" is still failing.
+	client := &fakePebbleClient{
+		sysInfo: pebbleclient.SysInfo{BootID: "1"},
+		services: []*pebbleclient.ServiceInfo{{
+			Name:    "web",
+			Startup: pebbleclient.StartupEnabled,
+			Current: pebbleclient.StatusActive,
+		}},
+		checks: []*uniter.CheckInfo{
+			{Name: "ready", Status: uniter.CheckStatusUp, Threshold: 3},
+			{Name: "online", Status: uniter.CheckStatusDown, Failures: 3, Threshold: 3},
+		},
+	}
+	newClient := func(cfg *pebbleclient.Config) uniter.PebbleClient {
+		return client
+	}
+	clock := testclock.NewClock(time.Time{})
+	workloadEventChan := make(chan string)
+	workloadEvents := container.NewWorkloadEvents()
+	healthSetter := &fakeHealthSetter{
+		health:  make(chan containerHealth, 10),
+		failing: []string{"online", "ready"},
+	}
+	worker := uniter.NewPebblePoller(loggo.GetLogger("test"), clock, []string{"a"}, workloadEventChan, workloadEvents, healthSetter, newClient)
+	defer workertest.CleanKill(c, worker)
+
+	var events []container.WorkloadEvent
+	timeout := time.After(testing.LongWait)
+	for len(events) < 2 {
+		select {
+		case id := <-workloadEventChan:
+			evt, cb, err := workloadEvents.GetWorkloadEvent(id)
+			c.Assert(err, jc.ErrorIsNil)
+			events = append(events, evt)
+			workloadEvents.RemoveWorkloadEvent(id)
+			cb(nil)
+		case <-time.After(testing.ShortWait):
+			clock.Advance(5 * time.Second)
+		case <-timeout:
+			c.Fatalf("timed out waiting for events")
+		}
+	}
+	c.Assert(events, jc.DeepEquals, []container.WorkloadEvent{
+		{Type: container.ReadyEvent, WorkloadName: "a"},
+		{Type: container.CheckRecoveredEvent, WorkloadName: "a", CheckName: "ready"},
+	})
+
+	select {
+	case health := <-healthSetter.health:
+		c.Assert(health, jc.DeepEquals, containerHealth{
+			name:          "a",
+			status:        status.Error,
+			info:          "failing checks: online",
+			failingChecks: []string{"online"},
+		})
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for health")
+	}
+	select {
+	case id := <-workloadEventChan:
+		evt, _, _ := workloadEvents.GetWorkloadEvent(id)
+		c.Fatalf("unexpected event %v", evt)
+	case <-time.After(testing.ShortWait):
+	}
+}
+
+type containerHealth struct {
+	name          string
+	status        status.Status
+	info          string
+	failingChecks []string
+}
+
+type fakeHealthSetter struct {
+	health  chan containerHealth
+	failing []string
+}
+
+func (s *fakeHealthSetter) FailingContainerChecks(containerName string) ([]string, error) {
+	return s.failing, nil
+}
+
+func (s *fakeHealthSetter) SetContainerHealth(containerName string, health status.Status, info string, failingChecks []string) error {
+	s.health <- containerHealth{
+		name:          containerName,
+		status:        health,
+		info:          info,
+		failingChecks: failingChecks,
+	}
+	return nil
+}
+
 type fakePebbleClient struct {
-	sysInfo pebbleclient.SysInfo
-	err     error
-	mut     sync.Mutex
-	closed  bool
+	sysInfo  pebbleclient.SysInfo
+	services []*pebbleclient.ServiceInfo
+	checks   []*uniter.CheckInfo
+	logs     []uniter.LogEntry
+	err      error
+	mut      sync.Mutex
+	closed   bool
 }
 
 func (c *fakePebbleClient) SysInfo() (*pebbleclient.SysInfo, error) {
@@ -129,6 +314,54 @@ func (c *fakePebbleClient) SysInfo() (*pebbleclient.SysInfo, error) {
 	return &sysInfoCopy, nil
 }
 
+func (c *fakePebbleClient) Services(*pebbleclient.ServicesOptions) ([]*pebbleclient.ServiceInfo, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.services, nil
+}
+
+func (c *fakePebbleClient) Checks() ([]*uniter.CheckInfo, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.checks, nil
+}
+
+func (c *fakePebbleClient) Logs(n int, follow bool) (io.ReadCloser, error) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range c.logs {
+		if err := encoder.Encode(entry); err != nil {
+			return nil, err
+		}
+	}
+	return ioutil.NopCloser(&buf), nil
+}
+
+// addLogs appends entries to the client's service logs.
+func (c *fakePebbleClient) addLogs(entries ...uniter.LogEntry) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.logs = append(c.logs, entries...)
+}
+
+// setHealth sets the results of the client's checks and the status of
+// its first service.
+func (c *fakePebbleClient) setHealth(checks []*uniter.CheckInfo, serviceStatus pebbleclient.ServiceStatus) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.checks = checks
+	c.services[0] = &pebbleclient.ServiceInfo{
+		Name:    c.services[0].Name,
+		Startup: c.services[0].Startup,
+		Current: serviceStatus,
+	}
+}
+
 func (c *fakePebbleClient) TriggerStart() {
 	c.mut.Lock()
 	defer c.mut.Unlock()
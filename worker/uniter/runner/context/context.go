@@ -302,6 +302,10 @@ type HookContext struct {
 	// workloadName is the name of the container which the hook is in relation to.
 	workloadName string
 
+	// checkName is the name of the Pebble check which the hook is in
+	// relation to.
+	checkName string
+
 	mu sync.Mutex
 }
 
@@ -1017,6 +1021,9 @@ func (ctx *HookContext) HookVars(paths Paths, remote bool, getEnv GetEnvFunc) ([
 	if ctx.workloadName != "" {
 		vars = append(vars, "JUJU_WORKLOAD_NAME="+ctx.workloadName)
 	}
+	if ctx.checkName != "" {
+		vars = append(vars, "JUJU_PEBBLE_CHECK_NAME="+ctx.checkName)
+	}
 	return append(vars, OSDependentEnvVars(paths, getEnv)...), nil
 }
 
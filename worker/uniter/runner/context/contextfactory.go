@@ -242,8 +242,9 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
-	if hookInfo.Kind.IsWorkload() {
+	if hook.IsWorkload(hookInfo.Kind) {
 		ctx.workloadName = hookInfo.WorkloadName
+		ctx.checkName = hookInfo.CheckName
 		hookName = fmt.Sprintf("%s-%s", hookInfo.WorkloadName, hookName)
 	}
 	ctx.id = f.newId(hookName)
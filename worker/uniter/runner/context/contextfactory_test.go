@@ -5,6 +5,7 @@ package context_test
 
 import (
 	"os"
+	"strings"
 	"time"
 
 	"github.com/juju/charm/v9/hooks"
@@ -220,6 +221,22 @@ func (s *ContextFactorySuite) TestWorkloadHookContext(c *gc.C) {
 	s.AssertNotStorageContext(c, ctx)
 }
 
+func (s *ContextFactorySuite) TestPebbleCheckHookContext(c *gc.C) {
+	hi := hook.Info{
+		Kind:         hook.PebbleCheckFailed,
+		WorkloadName: "test",
+		CheckName:    "ready",
+	}
+	ctx, err := s.factory.HookContext(hi)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertCoreContext(c, ctx)
+	s.AssertWorkloadContext(c, ctx, "test")
+
+	vars, err := ctx.HookVars(MockEnvPaths{}, false, func(string) string { return "" })
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(strings.Join(vars, "\n"), jc.Contains, "JUJU_WORKLOAD_NAME=test\nJUJU_PEBBLE_CHECK_NAME=ready")
+}
+
 func (s *ContextFactorySuite) TestNewHookContextWithStorage(c *gc.C) {
 	// We need to set up a unit that has storage metadata defined.
 	ch := s.AddTestingCharm(c, "storage-block")
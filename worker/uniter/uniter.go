@@ -888,10 +888,19 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	u.workloadEvents = container.NewWorkloadEvents()
 	u.workloadEventChannel = make(chan string)
 	if len(u.containerNames) > 0 {
-		pebblePoller := NewPebblePoller(u.logger, u.clock, u.containerNames, u.workloadEventChannel, u.workloadEvents, u.newPebbleClient)
+		pebblePoller := NewPebblePoller(u.logger, u.clock, u.containerNames, u.workloadEventChannel, u.workloadEvents, u.unit, u.newPebbleClient)
 		if err := u.catacomb.Add(pebblePoller); err != nil {
 			return errors.Trace(err)
 		}
+
+		// Workload logs are written under the same module as hook output,
+		// in the uniter's logging context, so that they are shown and
+		// forwarded alongside it.
+		workloadLogger := u.logger.Root().Child("unit." + u.unit.Name())
+		pebbleLogTailer := NewPebbleLogTailer(u.logger, workloadLogger, u.clock, u.containerNames, u.newPebbleClient)
+		if err := u.catacomb.Add(pebbleLogTailer); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	return nil
 }